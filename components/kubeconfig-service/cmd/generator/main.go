@@ -7,7 +7,9 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
+	"github.com/kyma-project/control-plane/components/kubeconfig-service/pkg/access"
 	"github.com/kyma-project/control-plane/components/kubeconfig-service/pkg/authn"
 	"github.com/kyma-project/control-plane/components/kubeconfig-service/pkg/reload"
 	"github.com/kyma-project/control-plane/components/kubeconfig-service/pkg/runtime"
@@ -34,27 +36,43 @@ func main() {
 		log.Fatalf("Cannot create OIDC Authenticator, %v", err)
 	}
 
-	ec := endpoints.NewEndpointClient(env.Config.GraphqlURL)
+	tokenPolicy := runtime.TokenPolicy{
+		Default: env.Config.Token.DefaultTTL,
+		Max:     env.Config.Token.MaxTTL,
+	}
+	if err := tokenPolicy.Validate(); err != nil {
+		log.Fatalf("Invalid token policy, %v", err)
+	}
+
+	kcpK8s, err := runtime.GetK8sClient()
+	if err != nil {
+		log.Fatalf("Cannot create KCP k8s client, %v", err)
+	}
+	accessManager := access.NewManager(access.NewConfigMapStorage(kcpK8s, runtime.KcpNamespace), access.Config{
+		ApprovalRequired: env.Config.Access.ApprovalRequired,
+		ApproverGroup:    env.Config.Access.ApproverGroup,
+		RequestTTL:       env.Config.Access.RequestTTL,
+	})
+
+	grants := runtime.NewGrantStorage(kcpK8s, runtime.KcpNamespace)
+	ec := endpoints.NewEndpointClient(env.Config.GraphqlURL, tokenPolicy, accessManager, grants)
+	ae := endpoints.NewAccessEndpoint(accessManager)
 	router := mux.NewRouter()
-	router.Use(authn.AuthMiddleware(oidcAuthenticator))
+	router.Use(authn.AuthMiddleware(oidcAuthenticator, env.Config.Access.ApproverGroup))
 	router.Methods("GET").Path("/kubeconfig/{tenantID}/{runtimeID}").HandlerFunc(ec.GetKubeConfig)
+	router.Methods("POST").Path("/access-requests").HandlerFunc(ae.CreateAccessRequest)
+	router.Methods("GET").Path("/access-requests").HandlerFunc(ae.ListAccessRequests)
+	router.Methods("PUT").Path("/access-requests/{requestID}/approve").HandlerFunc(ae.ApproveAccessRequest)
+	router.Methods("GET").Path("/audit-log").HandlerFunc(ae.ListAuditLog)
 
 	healthRouter := mux.NewRouter()
 	healthRouter.Methods("GET").Path("/health/ready").HandlerFunc(ec.GetHealthStatus)
 
-	term := make(chan os.Signal)
+	term := make(chan os.Signal, 1)
 	signal.Notify(term, os.Interrupt, syscall.SIGTERM)
 
-	go func() {
-		err := runtime.SetupConfigMap()
-		if err != nil {
-			log.Errorf("Error initializing ConfigMaps: %v", err)
-			term <- os.Interrupt
-		}
-		log.Infof("ConfigMaps initialization completed.")
-	}()
-
-	log.Infof("ConfigMaps initialization started.")
+	go expireAccessRequests(fileWatcherCtx, accessManager)
+	go runtime.NewSweeper(grants, ec.CallGQL).Run(fileWatcherCtx, env.Config.Token.SweepInterval)
 
 	go func() {
 		err := http.ListenAndServe(fmt.Sprintf(":%d", env.Config.Port.Service), router)
@@ -80,6 +98,21 @@ func main() {
 	}
 }
 
+func expireAccessRequests(ctx context.Context, accessManager *access.Manager) {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := accessManager.ExpireStale(); err != nil {
+				log.Errorf("Error expiring access requests: %v", err)
+			}
+		}
+	}
+}
+
 func setupLogLevel() {
 	logLevel, err := log.ParseLevel(env.Config.LogLevel)
 	if err == nil {
@@ -0,0 +1,275 @@
+package access
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+const runtimeAdminRole = "runtimeAdmin"
+
+var (
+	// ErrInvalidRequest is returned when the access request misses the justification or the ticket ID
+	ErrInvalidRequest = errors.New("invalid access request")
+	// ErrForbidden is returned when the caller is not allowed to approve the access request
+	ErrForbidden = errors.New("forbidden")
+	// ErrNotPending is returned when the access request can no longer be approved
+	ErrNotPending = errors.New("access request is not pending")
+	// ErrNotApproved is returned when no approved access request exists for the issued kubeconfig
+	ErrNotApproved = errors.New("no approved access request")
+)
+
+// Config configures the break-glass workflow
+type Config struct {
+	// ApprovalRequired enforces an approved access request before runtimeAdmin kubeconfigs are issued
+	ApprovalRequired bool
+	// ApproverGroup is the OIDC group whose members can approve access requests
+	ApproverGroup string
+	// RequestTTL is how long a pending or approved access request stays valid
+	RequestTTL time.Duration
+}
+
+// Manager implements the request/approve workflow for runtimeAdmin kubeconfigs and writes the audit log
+type Manager struct {
+	storage Storage
+	cfg     Config
+	now     func() time.Time
+}
+
+// NewManager returns a new Manager
+func NewManager(storage Storage, cfg Config) *Manager {
+	return &Manager{
+		storage: storage,
+		cfg:     cfg,
+		now:     time.Now,
+	}
+}
+
+// ApprovalRequired returns true when kubeconfigs for the given role need an approved access request
+func (m *Manager) ApprovalRequired(role string) bool {
+	return m.cfg.ApprovalRequired && role == runtimeAdminRole
+}
+
+// IsApprover returns true if the groups contain the approver group
+func (m *Manager) IsApprover(groups []string) bool {
+	return contains(groups, m.cfg.ApproverGroup)
+}
+
+// CanReview returns true for the users who can read the access requests and the audit log, runtimeAdmin and the approvers
+func (m *Manager) CanReview(role string, groups []string) bool {
+	return role == runtimeAdminRole || m.IsApprover(groups)
+}
+
+// Request files a new access request for the given runtime
+func (m *Manager) Request(requester string, dto CreateAccessRequestDTO) (AccessRequest, error) {
+	if strings.TrimSpace(dto.Justification) == "" || strings.TrimSpace(dto.TicketID) == "" {
+		return AccessRequest{}, fmt.Errorf("%w: justification and ticket ID are required", ErrInvalidRequest)
+	}
+	if dto.TenantID == "" || dto.RuntimeID == "" {
+		return AccessRequest{}, fmt.Errorf("%w: tenant ID and runtime ID are required", ErrInvalidRequest)
+	}
+
+	now := m.now()
+	r := AccessRequest{
+		ID:            uuid.New().String(),
+		TenantID:      dto.TenantID,
+		RuntimeID:     dto.RuntimeID,
+		Requester:     requester,
+		Justification: dto.Justification,
+		TicketID:      dto.TicketID,
+		Status:        StatusPending,
+		CreatedAt:     now,
+		ExpiresAt:     now.Add(m.cfg.RequestTTL),
+	}
+	if err := m.storage.InsertRequest(r); err != nil {
+		return AccessRequest{}, fmt.Errorf("while storing access request: %w", err)
+	}
+
+	details := fmt.Sprintf("ticket: %s, justification: %s", r.TicketID, r.Justification)
+	if err := m.audit(EventRequested, requester, r, details); err != nil {
+		return AccessRequest{}, err
+	}
+	return r, nil
+}
+
+// Approve approves a pending access request, the approver must belong to the approver group and cannot be the requester
+func (m *Manager) Approve(id, approver string, approverGroups []string) (AccessRequest, error) {
+	if !m.IsApprover(approverGroups) {
+		return AccessRequest{}, fmt.Errorf("%w: %s is not a member of the %s group", ErrForbidden, approver, m.cfg.ApproverGroup)
+	}
+
+	r, err := m.storage.GetRequest(id)
+	if err != nil {
+		return AccessRequest{}, err
+	}
+	if r.Requester == approver {
+		return AccessRequest{}, fmt.Errorf("%w: access request cannot be approved by the requester", ErrForbidden)
+	}
+	r, err = m.expireIfStale(r)
+	if errors.Is(err, ErrConflict) {
+		return AccessRequest{}, fmt.Errorf("%w: access request %s was changed concurrently", ErrNotPending, r.ID)
+	}
+	if err != nil {
+		return AccessRequest{}, err
+	}
+	if r.Status != StatusPending {
+		return AccessRequest{}, fmt.Errorf("%w: access request %s is %s", ErrNotPending, r.ID, r.Status)
+	}
+
+	now := m.now()
+	r.Status = StatusApproved
+	r.Approver = approver
+	r.ApprovedAt = &now
+	r.ExpiresAt = now.Add(m.cfg.RequestTTL)
+	err = m.storage.UpdateRequest(r, StatusPending)
+	if errors.Is(err, ErrConflict) {
+		return AccessRequest{}, fmt.Errorf("%w: access request %s was changed concurrently", ErrNotPending, r.ID)
+	}
+	if err != nil {
+		return AccessRequest{}, fmt.Errorf("while updating access request: %w", err)
+	}
+
+	if err := m.audit(EventApproved, approver, r, ""); err != nil {
+		return AccessRequest{}, err
+	}
+	return r, nil
+}
+
+// Approved returns the approved access request of the requester for the given runtime
+func (m *Manager) Approved(requester, tenantID, runtimeID string) (AccessRequest, error) {
+	requests, err := m.storage.ListRequests(RequestFilter{
+		TenantID:  tenantID,
+		RuntimeID: runtimeID,
+		Requester: requester,
+		Status:    StatusApproved,
+	})
+	if err != nil {
+		return AccessRequest{}, fmt.Errorf("while listing access requests: %w", err)
+	}
+
+	for _, r := range requests {
+		r, err = m.expireIfStale(r)
+		if errors.Is(err, ErrConflict) {
+			continue
+		}
+		if err != nil {
+			return AccessRequest{}, err
+		}
+		if r.Status == StatusApproved {
+			return r, nil
+		}
+	}
+	return AccessRequest{}, fmt.Errorf("%w for %s on runtime %s", ErrNotApproved, requester, runtimeID)
+}
+
+// Claim marks the approved access request as issued before the kubeconfig is generated, each approval allows a single issuance.
+// Of the concurrent claims of the same request only one succeeds, the others return ErrNotApproved
+func (m *Manager) Claim(r AccessRequest) (AccessRequest, error) {
+	r.Status = StatusIssued
+	err := m.storage.UpdateRequest(r, StatusApproved)
+	if errors.Is(err, ErrConflict) {
+		return AccessRequest{}, fmt.Errorf("%w: access request %s was already used", ErrNotApproved, r.ID)
+	}
+	if err != nil {
+		return AccessRequest{}, fmt.Errorf("while updating access request: %w", err)
+	}
+	return m.storage.GetRequest(r.ID)
+}
+
+// Release returns the claimed access request to the approved state when the kubeconfig could not be generated
+func (m *Manager) Release(r AccessRequest) error {
+	r.Status = StatusApproved
+	if err := m.storage.UpdateRequest(r, StatusIssued); err != nil {
+		return fmt.Errorf("while releasing access request: %w", err)
+	}
+	return nil
+}
+
+// RecordIssuance writes an issued kubeconfig to the audit log
+func (m *Manager) RecordIssuance(actor, tenantID, runtimeID, role, requestID, details string) error {
+	return m.storage.AppendAudit(AuditEntry{
+		ID:        uuid.New().String(),
+		Time:      m.now(),
+		Event:     EventIssued,
+		Actor:     actor,
+		RequestID: requestID,
+		TenantID:  tenantID,
+		RuntimeID: runtimeID,
+		Role:      role,
+		Details:   details,
+	})
+}
+
+// ListRequests returns the access requests matching the filter
+func (m *Manager) ListRequests(filter RequestFilter) ([]AccessRequest, error) {
+	return m.storage.ListRequests(filter)
+}
+
+// ListAudit returns the audit entries matching the filter, ordered by time
+func (m *Manager) ListAudit(filter AuditFilter) ([]AuditEntry, error) {
+	return m.storage.ListAudit(filter)
+}
+
+// ExpireStale marks all pending and approved access requests past their expiry as expired
+func (m *Manager) ExpireStale() error {
+	for _, status := range []RequestStatus{StatusPending, StatusApproved} {
+		requests, err := m.storage.ListRequests(RequestFilter{Status: status})
+		if err != nil {
+			return fmt.Errorf("while listing access requests: %w", err)
+		}
+		for _, r := range requests {
+			// a request changed concurrently is checked again in the next run
+			if _, err := m.expireIfStale(r); err != nil && !errors.Is(err, ErrConflict) {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (m *Manager) expireIfStale(r AccessRequest) (AccessRequest, error) {
+	if r.Status != StatusPending && r.Status != StatusApproved {
+		return r, nil
+	}
+	if m.now().Before(r.ExpiresAt) {
+		return r, nil
+	}
+
+	from := r.Status
+	r.Status = StatusExpired
+	if err := m.storage.UpdateRequest(r, from); err != nil {
+		return r, fmt.Errorf("while expiring access request: %w", err)
+	}
+	log.Infof("Access request %s of %s for runtime %s expired", r.ID, r.Requester, r.RuntimeID)
+	return r, m.audit(EventExpired, "system", r, "")
+}
+
+func (m *Manager) audit(event Event, actor string, r AccessRequest, details string) error {
+	err := m.storage.AppendAudit(AuditEntry{
+		ID:        uuid.New().String(),
+		Time:      m.now(),
+		Event:     event,
+		Actor:     actor,
+		RequestID: r.ID,
+		TenantID:  r.TenantID,
+		RuntimeID: r.RuntimeID,
+		Details:   details,
+	})
+	if err != nil {
+		return fmt.Errorf("while writing audit log: %w", err)
+	}
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
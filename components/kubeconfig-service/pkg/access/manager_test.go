@@ -0,0 +1,291 @@
+package access
+
+import (
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+const (
+	approverGroup = "runtimeAdminApprover"
+	tenantID      = "tenant-1"
+	runtimeID     = "runtime-1"
+)
+
+func newTestManager(storage Storage, now *time.Time) *Manager {
+	m := NewManager(storage, Config{
+		ApprovalRequired: true,
+		ApproverGroup:    approverGroup,
+		RequestTTL:       time.Hour,
+	})
+	m.now = func() time.Time { return *now }
+	return m
+}
+
+func fixDTO() CreateAccessRequestDTO {
+	return CreateAccessRequestDTO{
+		TenantID:      tenantID,
+		RuntimeID:     runtimeID,
+		Justification: "incident",
+		TicketID:      "INC-1",
+	}
+}
+
+func TestManager(t *testing.T) {
+	for name, storage := range map[string]func() Storage{
+		"memory":    NewMemoryStorage,
+		"configmap": func() Storage { return NewConfigMapStorage(newVersionedClientset(), "kcp-system") },
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Run("should issue admin kubeconfig only after approval", func(t *testing.T) {
+				// given
+				now := time.Now()
+				m := newTestManager(storage(), &now)
+
+				// when
+				r, err := m.Request("alice", fixDTO())
+				require.NoError(t, err)
+
+				// then
+				_, err = m.Approved("alice", tenantID, runtimeID)
+				assert.ErrorIs(t, err, ErrNotApproved)
+
+				// when
+				now = now.Add(time.Minute)
+				_, err = m.Approve(r.ID, "bob", []string{"runtimeAdmin", approverGroup})
+				require.NoError(t, err)
+
+				// then
+				approved, err := m.Approved("alice", tenantID, runtimeID)
+				require.NoError(t, err)
+				assert.Equal(t, r.ID, approved.ID)
+				assert.Equal(t, "bob", approved.Approver)
+
+				// when
+				now = now.Add(time.Minute)
+				claimed, err := m.Claim(approved)
+				require.NoError(t, err)
+				require.NoError(t, m.RecordIssuance("alice", tenantID, runtimeID, "runtimeAdmin", claimed.ID, "ttl: 1h0m0s"))
+
+				// then
+				_, err = m.Approved("alice", tenantID, runtimeID)
+				assert.ErrorIs(t, err, ErrNotApproved)
+
+				entries, err := m.ListAudit(AuditFilter{RuntimeID: runtimeID})
+				require.NoError(t, err)
+				require.Len(t, entries, 3)
+				assert.Equal(t, EventRequested, entries[0].Event)
+				assert.Equal(t, EventApproved, entries[1].Event)
+				assert.Equal(t, "bob", entries[1].Actor)
+				assert.Equal(t, EventIssued, entries[2].Event)
+				assert.Equal(t, r.ID, entries[2].RequestID)
+			})
+
+			t.Run("should issue single kubeconfig for concurrent downloads", func(t *testing.T) {
+				// given
+				now := time.Now()
+				m := newTestManager(storage(), &now)
+				r, err := m.Request("alice", fixDTO())
+				require.NoError(t, err)
+				_, err = m.Approve(r.ID, "bob", []string{approverGroup})
+				require.NoError(t, err)
+
+				// when
+				const downloads = 10
+				var wg sync.WaitGroup
+				var claimed int32
+				start := make(chan struct{})
+				for i := 0; i < downloads; i++ {
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						<-start
+						approved, err := m.Approved("alice", tenantID, runtimeID)
+						if err != nil {
+							return
+						}
+						if _, err := m.Claim(approved); err == nil {
+							atomic.AddInt32(&claimed, 1)
+						} else {
+							assert.ErrorIs(t, err, ErrNotApproved)
+						}
+					}()
+				}
+				close(start)
+				wg.Wait()
+
+				// then
+				assert.Equal(t, int32(1), claimed)
+				_, err = m.Approved("alice", tenantID, runtimeID)
+				assert.ErrorIs(t, err, ErrNotApproved)
+			})
+
+			t.Run("should approve request once for concurrent approvals", func(t *testing.T) {
+				// given
+				now := time.Now()
+				m := newTestManager(storage(), &now)
+				r, err := m.Request("alice", fixDTO())
+				require.NoError(t, err)
+
+				// when
+				var wg sync.WaitGroup
+				var approved int32
+				for _, approver := range []string{"bob", "carol", "dave"} {
+					wg.Add(1)
+					go func(approver string) {
+						defer wg.Done()
+						if _, err := m.Approve(r.ID, approver, []string{approverGroup}); err == nil {
+							atomic.AddInt32(&approved, 1)
+						}
+					}(approver)
+				}
+				wg.Wait()
+
+				// then
+				assert.Equal(t, int32(1), approved)
+			})
+
+			t.Run("should release claimed request", func(t *testing.T) {
+				// given
+				now := time.Now()
+				m := newTestManager(storage(), &now)
+				r, err := m.Request("alice", fixDTO())
+				require.NoError(t, err)
+				approved, err := m.Approve(r.ID, "bob", []string{approverGroup})
+				require.NoError(t, err)
+				approved, err = m.Approved("alice", tenantID, runtimeID)
+				require.NoError(t, err)
+				claimed, err := m.Claim(approved)
+				require.NoError(t, err)
+
+				// when
+				require.NoError(t, m.Release(claimed))
+
+				// then
+				_, err = m.Approved("alice", tenantID, runtimeID)
+				assert.NoError(t, err)
+			})
+
+			t.Run("should reject approval by requester or outside of the approver group", func(t *testing.T) {
+				// given
+				now := time.Now()
+				m := newTestManager(storage(), &now)
+				r, err := m.Request("alice", fixDTO())
+				require.NoError(t, err)
+
+				// when
+				_, err = m.Approve(r.ID, "alice", []string{approverGroup})
+
+				// then
+				assert.ErrorIs(t, err, ErrForbidden)
+
+				// when
+				_, err = m.Approve(r.ID, "bob", []string{"runtimeAdmin"})
+
+				// then
+				assert.ErrorIs(t, err, ErrForbidden)
+			})
+
+			t.Run("should require justification and ticket ID", func(t *testing.T) {
+				// given
+				now := time.Now()
+				m := newTestManager(storage(), &now)
+				dto := fixDTO()
+				dto.TicketID = ""
+
+				// when
+				_, err := m.Request("alice", dto)
+
+				// then
+				assert.ErrorIs(t, err, ErrInvalidRequest)
+			})
+
+			t.Run("should expire stale requests and write audit entry", func(t *testing.T) {
+				// given
+				now := time.Now()
+				m := newTestManager(storage(), &now)
+				r, err := m.Request("alice", fixDTO())
+				require.NoError(t, err)
+
+				// when
+				now = now.Add(2 * time.Hour)
+				require.NoError(t, m.ExpireStale())
+
+				// then
+				_, err = m.Approve(r.ID, "bob", []string{approverGroup})
+				assert.ErrorIs(t, err, ErrNotPending)
+
+				requests, err := m.ListRequests(RequestFilter{Status: StatusExpired})
+				require.NoError(t, err)
+				assert.Len(t, requests, 1)
+
+				entries, err := m.ListAudit(AuditFilter{Event: EventExpired})
+				require.NoError(t, err)
+				assert.Len(t, entries, 1)
+			})
+		})
+	}
+}
+
+func TestManager_ApprovalRequired(t *testing.T) {
+	m := NewManager(NewMemoryStorage(), Config{ApprovalRequired: true})
+
+	assert.True(t, m.ApprovalRequired("runtimeAdmin"))
+	assert.False(t, m.ApprovalRequired("runtimeOperator"))
+	assert.False(t, NewManager(NewMemoryStorage(), Config{}).ApprovalRequired("runtimeAdmin"))
+}
+
+func TestManager_CanReview(t *testing.T) {
+	m := NewManager(NewMemoryStorage(), Config{ApproverGroup: approverGroup})
+
+	assert.True(t, m.CanReview("runtimeAdmin", nil))
+	assert.True(t, m.CanReview("runtimeApprover", []string{approverGroup}))
+	assert.True(t, m.CanReview("runtimeOperator", []string{"runtimeOperator", approverGroup}))
+	assert.False(t, m.CanReview("runtimeOperator", []string{"runtimeOperator"}))
+}
+
+// newVersionedClientset returns a fake clientset which rejects the updates of changed ConfigMaps like the API server does
+func newVersionedClientset() *fake.Clientset {
+	clientset := fake.NewSimpleClientset()
+	var (
+		mu      sync.Mutex
+		version int
+	)
+	clientset.PrependReactor("*", "configmaps", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch action.GetVerb() {
+		case "create":
+			cm := action.(k8stesting.CreateAction).GetObject().(*v1.ConfigMap).DeepCopy()
+			version++
+			cm.ResourceVersion = strconv.Itoa(version)
+			return true, cm, clientset.Tracker().Create(action.GetResource(), cm, action.GetNamespace())
+		case "update":
+			cm := action.(k8stesting.UpdateAction).GetObject().(*v1.ConfigMap).DeepCopy()
+			stored, err := clientset.Tracker().Get(action.GetResource(), action.GetNamespace(), cm.Name)
+			if err != nil {
+				return true, nil, err
+			}
+			if stored.(*v1.ConfigMap).ResourceVersion != cm.ResourceVersion {
+				return true, nil, k8serrors.NewConflict(action.GetResource().GroupResource(), cm.Name, nil)
+			}
+			version++
+			cm.ResourceVersion = strconv.Itoa(version)
+			return true, cm, clientset.Tracker().Update(action.GetResource(), cm, action.GetNamespace())
+		default:
+			// reads are served by the tracker under the same lock
+			return k8stesting.ObjectReaction(clientset.Tracker())(action)
+		}
+	})
+	return clientset
+}
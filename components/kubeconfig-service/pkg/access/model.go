@@ -0,0 +1,100 @@
+package access
+
+import (
+	"time"
+)
+
+// RequestStatus is the state of a break-glass access request
+type RequestStatus string
+
+const (
+	StatusPending  RequestStatus = "pending"
+	StatusApproved RequestStatus = "approved"
+	StatusIssued   RequestStatus = "issued"
+	StatusExpired  RequestStatus = "expired"
+)
+
+// Event is the type of entry written to the audit log
+type Event string
+
+const (
+	EventRequested Event = "requested"
+	EventApproved  Event = "approved"
+	EventIssued    Event = "issued"
+	EventExpired   Event = "expired"
+)
+
+// AccessRequest is a request for runtimeAdmin credentials which has to be approved by a second person
+type AccessRequest struct {
+	ID            string        `json:"id"`
+	TenantID      string        `json:"tenantID"`
+	RuntimeID     string        `json:"runtimeID"`
+	Requester     string        `json:"requester"`
+	Justification string        `json:"justification"`
+	TicketID      string        `json:"ticketID"`
+	Status        RequestStatus `json:"status"`
+	Approver      string        `json:"approver,omitempty"`
+	CreatedAt     time.Time     `json:"createdAt"`
+	ApprovedAt    *time.Time    `json:"approvedAt,omitempty"`
+	ExpiresAt     time.Time     `json:"expiresAt"`
+
+	// version is the version of the stored request which was read, an update of a changed request fails with ErrConflict
+	version string
+}
+
+// CreateAccessRequestDTO is the payload used to file an access request
+type CreateAccessRequestDTO struct {
+	TenantID      string `json:"tenantID"`
+	RuntimeID     string `json:"runtimeID"`
+	Justification string `json:"justification"`
+	TicketID      string `json:"ticketID"`
+}
+
+// AuditEntry is a single, immutable record of the audit log
+type AuditEntry struct {
+	ID        string    `json:"id"`
+	Time      time.Time `json:"time"`
+	Event     Event     `json:"event"`
+	Actor     string    `json:"actor"`
+	RequestID string    `json:"requestID,omitempty"`
+	TenantID  string    `json:"tenantID"`
+	RuntimeID string    `json:"runtimeID"`
+	Role      string    `json:"role,omitempty"`
+	Details   string    `json:"details,omitempty"`
+}
+
+// RequestFilter narrows down the listed access requests, empty fields match everything
+type RequestFilter struct {
+	TenantID  string
+	RuntimeID string
+	Requester string
+	Status    RequestStatus
+}
+
+// AuditFilter narrows down the listed audit entries, empty fields match everything
+type AuditFilter struct {
+	TenantID  string
+	RuntimeID string
+	Actor     string
+	Event     Event
+	Since     time.Time
+}
+
+func (f RequestFilter) matches(r AccessRequest) bool {
+	return match(f.TenantID, r.TenantID) &&
+		match(f.RuntimeID, r.RuntimeID) &&
+		match(f.Requester, r.Requester) &&
+		match(string(f.Status), string(r.Status))
+}
+
+func (f AuditFilter) matches(e AuditEntry) bool {
+	return match(f.TenantID, e.TenantID) &&
+		match(f.RuntimeID, e.RuntimeID) &&
+		match(f.Actor, e.Actor) &&
+		match(string(f.Event), string(e.Event)) &&
+		!e.Time.Before(f.Since)
+}
+
+func match(filter, value string) bool {
+	return filter == "" || filter == value
+}
@@ -0,0 +1,268 @@
+package access
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	serviceLabel      = "service"
+	requestLabelValue = "kubeconfig-access-request"
+	auditLabelValue   = "kubeconfig-audit"
+	dataKey           = "data"
+)
+
+var (
+	// ErrNotFound is returned when the access request does not exist
+	ErrNotFound = fmt.Errorf("access request not found")
+	// ErrConflict is returned when the access request was changed since it was read
+	ErrConflict = fmt.Errorf("access request was changed concurrently")
+)
+
+// Storage persists access requests and the append-only audit log
+type Storage interface {
+	InsertRequest(r AccessRequest) error
+	// UpdateRequest stores the request read with GetRequest or ListRequests if it was not changed since,
+	// and its stored status is still the given one, otherwise ErrConflict is returned
+	UpdateRequest(r AccessRequest, from RequestStatus) error
+	GetRequest(id string) (AccessRequest, error)
+	ListRequests(filter RequestFilter) ([]AccessRequest, error)
+	// AppendAudit adds an entry to the audit log, entries are never updated nor removed
+	AppendAudit(e AuditEntry) error
+	ListAudit(filter AuditFilter) ([]AuditEntry, error)
+}
+
+type memoryStorage struct {
+	mu       sync.Mutex
+	requests map[string]AccessRequest
+	audit    []AuditEntry
+	versions int
+}
+
+// NewMemoryStorage returns a Storage which keeps everything in memory
+func NewMemoryStorage() Storage {
+	return &memoryStorage{requests: map[string]AccessRequest{}}
+}
+
+func (s *memoryStorage) InsertRequest(r AccessRequest) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.requests[r.ID]; exists {
+		return fmt.Errorf("access request %s already exists", r.ID)
+	}
+	s.requests[r.ID] = s.nextVersion(r)
+	return nil
+}
+
+func (s *memoryStorage) UpdateRequest(r AccessRequest, from RequestStatus) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stored, exists := s.requests[r.ID]
+	if !exists {
+		return ErrNotFound
+	}
+	if stored.version != r.version || stored.Status != from {
+		return ErrConflict
+	}
+	s.requests[r.ID] = s.nextVersion(r)
+	return nil
+}
+
+func (s *memoryStorage) nextVersion(r AccessRequest) AccessRequest {
+	s.versions++
+	r.version = strconv.Itoa(s.versions)
+	return r
+}
+
+func (s *memoryStorage) GetRequest(id string) (AccessRequest, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r, exists := s.requests[id]
+	if !exists {
+		return AccessRequest{}, ErrNotFound
+	}
+	return r, nil
+}
+
+func (s *memoryStorage) ListRequests(filter RequestFilter) ([]AccessRequest, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	result := []AccessRequest{}
+	for _, r := range s.requests {
+		if filter.matches(r) {
+			result = append(result, r)
+		}
+	}
+	sortRequests(result)
+	return result, nil
+}
+
+func (s *memoryStorage) AppendAudit(e AuditEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.audit = append(s.audit, e)
+	return nil
+}
+
+func (s *memoryStorage) ListAudit(filter AuditFilter) ([]AuditEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	result := []AuditEntry{}
+	for _, e := range s.audit {
+		if filter.matches(e) {
+			result = append(result, e)
+		}
+	}
+	return result, nil
+}
+
+// configMapStorage keeps each access request and each audit entry in its own ConfigMap in the KCP cluster.
+// Audit ConfigMaps are only ever created, which keeps the log append-only.
+type configMapStorage struct {
+	k8s       kubernetes.Interface
+	namespace string
+}
+
+// NewConfigMapStorage returns a Storage backed by ConfigMaps in the given KCP namespace
+func NewConfigMapStorage(k8s kubernetes.Interface, namespace string) Storage {
+	return &configMapStorage{k8s: k8s, namespace: namespace}
+}
+
+func (s *configMapStorage) InsertRequest(r AccessRequest) error {
+	cm, err := s.newConfigMap(requestName(r.ID), requestLabelValue, r)
+	if err != nil {
+		return err
+	}
+	_, err = s.k8s.CoreV1().ConfigMaps(s.namespace).Create(context.Background(), cm, metav1.CreateOptions{})
+	return err
+}
+
+func (s *configMapStorage) UpdateRequest(r AccessRequest, from RequestStatus) error {
+	stored, err := s.GetRequest(r.ID)
+	if err != nil {
+		return err
+	}
+	if stored.version != r.version || stored.Status != from {
+		return ErrConflict
+	}
+	cm, err := s.newConfigMap(requestName(r.ID), requestLabelValue, r)
+	if err != nil {
+		return err
+	}
+	// the API server rejects the update if the ConfigMap was changed after r was read
+	cm.ResourceVersion = r.version
+	_, err = s.k8s.CoreV1().ConfigMaps(s.namespace).Update(context.Background(), cm, metav1.UpdateOptions{})
+	if k8serrors.IsConflict(err) {
+		return ErrConflict
+	}
+	return err
+}
+
+func (s *configMapStorage) GetRequest(id string) (AccessRequest, error) {
+	cm, err := s.k8s.CoreV1().ConfigMaps(s.namespace).Get(context.Background(), requestName(id), metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return AccessRequest{}, ErrNotFound
+	}
+	if err != nil {
+		return AccessRequest{}, err
+	}
+	return decodeRequest(*cm)
+}
+
+func (s *configMapStorage) ListRequests(filter RequestFilter) ([]AccessRequest, error) {
+	items, err := s.list(requestLabelValue)
+	if err != nil {
+		return nil, err
+	}
+	result := []AccessRequest{}
+	for _, cm := range items {
+		r, err := decodeRequest(cm)
+		if err != nil {
+			return nil, err
+		}
+		if filter.matches(r) {
+			result = append(result, r)
+		}
+	}
+	sortRequests(result)
+	return result, nil
+}
+
+func (s *configMapStorage) AppendAudit(e AuditEntry) error {
+	cm, err := s.newConfigMap(fmt.Sprintf("kubeconfig-audit-%s", e.ID), auditLabelValue, e)
+	if err != nil {
+		return err
+	}
+	_, err = s.k8s.CoreV1().ConfigMaps(s.namespace).Create(context.Background(), cm, metav1.CreateOptions{})
+	return err
+}
+
+func (s *configMapStorage) ListAudit(filter AuditFilter) ([]AuditEntry, error) {
+	items, err := s.list(auditLabelValue)
+	if err != nil {
+		return nil, err
+	}
+	result := []AuditEntry{}
+	for _, cm := range items {
+		var e AuditEntry
+		if err := json.Unmarshal([]byte(cm.Data[dataKey]), &e); err != nil {
+			return nil, fmt.Errorf("while decoding audit entry %s: %w", cm.Name, err)
+		}
+		if filter.matches(e) {
+			result = append(result, e)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool { return result[i].Time.Before(result[j].Time) })
+	return result, nil
+}
+
+func (s *configMapStorage) list(kind string) ([]v1.ConfigMap, error) {
+	cmList, err := s.k8s.CoreV1().ConfigMaps(s.namespace).List(context.Background(), metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", serviceLabel, kind),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cmList.Items, nil
+}
+
+func (s *configMapStorage) newConfigMap(name, kind string, obj interface{}) (*v1.ConfigMap, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: s.namespace,
+			Labels:    map[string]string{serviceLabel: kind},
+		},
+		Data: map[string]string{dataKey: string(data)},
+	}, nil
+}
+
+func decodeRequest(cm v1.ConfigMap) (AccessRequest, error) {
+	var r AccessRequest
+	if err := json.Unmarshal([]byte(cm.Data[dataKey]), &r); err != nil {
+		return AccessRequest{}, fmt.Errorf("while decoding access request %s: %w", cm.Name, err)
+	}
+	r.version = cm.ResourceVersion
+	return r, nil
+}
+
+func requestName(id string) string {
+	return fmt.Sprintf("kubeconfig-access-%s", id)
+}
+
+func sortRequests(requests []AccessRequest) {
+	sort.SliceStable(requests, func(i, j int) bool { return requests[i].CreatedAt.Before(requests[j].CreatedAt) })
+}
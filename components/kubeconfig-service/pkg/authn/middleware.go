@@ -6,7 +6,6 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
-	"math/rand"
 	"net/http"
 	"reflect"
 	"regexp"
@@ -20,28 +19,35 @@ import (
 const PARSING_OIDC_TOKEN = "while parsing oidc token: "
 const NO_GROUPS_IN_TOKEN = "no [groups] in oidc token"
 const NO_EXP_IN_TOKEN = "no [exp] in oidc token"
+const NO_LOGIN_NAME_IN_TOKEN = "no [login_name] in oidc token"
 const DECODE_TOKEN_FAILD = "decode token failed"
 const UNMARSHAL_TOKEN_FAILED = "unmarshal token failed"
 const MALFORMED_TOKEN = "malformed Token"
 const saRegex = "[^a-z0-9.-]+"
-const saCharset = "abcdefghijklmnopqrstuvwxyz0123456789"
 const login_name = "login_name"
 const exp = "exp"
 const groups = "groups"
 
 var L2L3OperatiorRoles = []string{"runtimeAdmin", "runtimeOperator"}
 
+// RUNTIME_APPROVER is the role of the approver group members who are neither runtimeAdmin nor runtimeOperator,
+// it allows only approving access requests and reading the access requests and the audit log
+const RUNTIME_APPROVER = "runtimeApprover"
+
+var saNameRegexp = regexp.MustCompile(saRegex)
+
 type UserInfo struct {
-	ID   string
-	Role string
-	Exp  time.Time
+	ID     string
+	Role   string
+	Groups []string
+	Exp    time.Time
 }
 
-func AuthMiddleware(a authenticator.Request) func(http.Handler) http.Handler {
+func AuthMiddleware(a authenticator.Request, approverGroup string) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 
-			userInfo, errMsg, code := ValidateToken(r)
+			userInfo, errMsg, code := ValidateToken(r, approverGroup)
 			if errMsg != "" {
 				http.Error(w, PARSING_OIDC_TOKEN+errMsg, code)
 				return
@@ -62,7 +68,7 @@ func AuthMiddleware(a authenticator.Request) func(http.Handler) http.Handler {
 	}
 }
 
-func ValidateToken(r *http.Request) (UserInfo, string, int) {
+func ValidateToken(r *http.Request, approverGroup string) (UserInfo, string, int) {
 	var userInfo = UserInfo{ID: "", Role: "", Exp: time.Time{}}
 	authHeader := strings.Split(r.Header.Get("Authorization"), "Bearer ")
 	errMsg, code := "", http.StatusOK
@@ -76,41 +82,32 @@ func ValidateToken(r *http.Request) (UserInfo, string, int) {
 			return userInfo, errMsg, code
 		}
 
-		role, errMsg, code := extractRole(dat)
+		role, errMsg, code := extractRole(dat, approverGroup)
 		if errMsg != "" {
 			return userInfo, errMsg, code
 		}
 
+		// the user ID names the ServiceAccount and identifies the requester and approver of access requests, so it must not be made up
+		userID := ExtractUserID(dat)
+		if userID == "" {
+			return userInfo, NO_LOGIN_NAME_IN_TOKEN, http.StatusForbidden
+		}
+
 		userInfo.Role = role
-		userInfo.ID = ExtractUserID(dat)
+		userInfo.Groups = extractGroups(dat)
+		userInfo.ID = userID
 		userInfo.Exp = extractExpiredData(dat)
 	}
 	return userInfo, errMsg, code
 }
 
+// ExtractUserID returns the login name of the token as a valid ServiceAccount name, empty if the token has no login name
 func ExtractUserID(dat map[string]interface{}) string {
-	var userID string
-	if dat[login_name] != nil {
-		rawLoginName := fmt.Sprintf("%s", dat[login_name])
-		reg, err := regexp.Compile(saRegex)
-		if err == nil {
-			userID = reg.ReplaceAllString(strings.ToLower(rawLoginName), "")
-			return userID
-		}
+	if dat[login_name] == nil {
+		return ""
 	}
-	userID = StringWithCharset(7, saCharset)
-	return userID
-}
-
-func StringWithCharset(length int, charset string) string {
-	var seededRand *rand.Rand = rand.New(
-		rand.NewSource(time.Now().UnixNano()))
-
-	b := make([]byte, length)
-	for i := range b {
-		b[i] = charset[seededRand.Intn(len(charset))]
-	}
-	return string(b)
+	rawLoginName := fmt.Sprintf("%s", dat[login_name])
+	return saNameRegexp.ReplaceAllString(strings.ToLower(rawLoginName), "")
 }
 
 func dataAsString(t interface{}) string {
@@ -151,23 +148,50 @@ func extractExpiredData(dat map[string]interface{}) time.Time {
 	return tm
 }
 
-func extractRole(dat map[string]interface{}) (string, string, int) {
+func extractGroups(dat map[string]interface{}) []string {
+	var result []string
+	for _, group := range strings.Split(dataAsString(dat[groups]), ",") {
+		if group = strings.TrimSpace(group); group != "" {
+			result = append(result, group)
+		}
+	}
+	return result
+}
+
+func extractRole(dat map[string]interface{}, approverGroup string) (string, string, int) {
 	var role = ""
 	if dat[groups] == nil {
 		return role, NO_GROUPS_IN_TOKEN, http.StatusForbidden
 	}
 
-	data := dataAsString(dat[groups])
-	if strings.Contains(data, L2L3OperatiorRoles[0]) {
+	// the group names are matched exactly, e.g. the members of runtimeAdminApprover do not get the runtimeAdmin role
+	tokenGroups := extractGroups(dat)
+	if containsGroup(tokenGroups, L2L3OperatiorRoles[0]) {
 		role = L2L3OperatiorRoles[0]
-	} else if strings.Contains(data, L2L3OperatiorRoles[1]) {
+	} else if containsGroup(tokenGroups, L2L3OperatiorRoles[1]) {
 		role = L2L3OperatiorRoles[1]
+	} else if approverGroup != "" && containsGroup(tokenGroups, approverGroup) {
+		role = RUNTIME_APPROVER
 	} else {
 		return role, fmt.Sprintf("Not found %s in oidc token", L2L3OperatiorRoles), http.StatusForbidden
 	}
 	return role, "", http.StatusOK
 }
 
+// IsOperator returns true for the roles which can obtain kubeconfigs and file access requests
+func (u UserInfo) IsOperator() bool {
+	return containsGroup(L2L3OperatiorRoles, u.Role)
+}
+
+func containsGroup(groups []string, group string) bool {
+	for _, g := range groups {
+		if g == group {
+			return true
+		}
+	}
+	return false
+}
+
 func ParseToken(jwtToken string) (map[string]interface{}, string, int) {
 	errMsg, code := "", http.StatusOK
 	var dat map[string]interface{}
@@ -1,13 +1,18 @@
 package client
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"html"
 	"io"
 	"io/ioutil"
 	"net/http"
+	"net/url"
+	"time"
 
+	"github.com/kyma-project/control-plane/components/kubeconfig-service/pkg/access"
 	"github.com/pkg/errors"
 	"golang.org/x/oauth2"
 )
@@ -15,6 +20,10 @@ import (
 // Client is the interface to interact with the kubeconfig-service as an HTTP client using OIDC ID token in JWT format.
 type Client interface {
 	GetKubeConfig(tenantID, runtimeID string) (string, error)
+	CreateAccessRequest(dto access.CreateAccessRequestDTO) (access.AccessRequest, error)
+	ApproveAccessRequest(requestID string) (access.AccessRequest, error)
+	ListAccessRequests(filter access.RequestFilter) ([]access.AccessRequest, error)
+	ListAuditLog(filter access.AuditFilter) ([]access.AuditEntry, error)
 }
 
 type client struct {
@@ -56,7 +65,8 @@ func (c *client) GetKubeConfig(tenantID, runtimeID string) (string, error) {
 	}()
 
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("calling %s returned %s status", url, resp.Status)
+		msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 4096))
+		return "", fmt.Errorf("calling %s returned %s status: %s", url, resp.Status, string(msg))
 	}
 	body, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
@@ -65,6 +75,95 @@ func (c *client) GetKubeConfig(tenantID, runtimeID string) (string, error) {
 	return html.UnescapeString(string(body)), nil
 }
 
+// CreateAccessRequest files a break-glass access request
+func (c *client) CreateAccessRequest(dto access.CreateAccessRequestDTO) (access.AccessRequest, error) {
+	var r access.AccessRequest
+	body, err := json.Marshal(dto)
+	if err != nil {
+		return r, errors.Wrap(err, "while marshaling access request")
+	}
+	err = c.doJSON(http.MethodPost, fmt.Sprintf("%s/access-requests", c.url), bytes.NewReader(body), http.StatusCreated, &r)
+	return r, err
+}
+
+// ApproveAccessRequest approves the access request filed by another operator
+func (c *client) ApproveAccessRequest(requestID string) (access.AccessRequest, error) {
+	var r access.AccessRequest
+	err := c.doJSON(http.MethodPut, fmt.Sprintf("%s/access-requests/%s/approve", c.url, requestID), nil, http.StatusOK, &r)
+	return r, err
+}
+
+// ListAccessRequests lists the access requests matching the filter
+func (c *client) ListAccessRequests(filter access.RequestFilter) ([]access.AccessRequest, error) {
+	query := url.Values{}
+	setQuery(query, "tenantID", filter.TenantID)
+	setQuery(query, "runtimeID", filter.RuntimeID)
+	setQuery(query, "requester", filter.Requester)
+	setQuery(query, "status", string(filter.Status))
+
+	var requests []access.AccessRequest
+	err := c.doJSON(http.MethodGet, fmt.Sprintf("%s/access-requests?%s", c.url, query.Encode()), nil, http.StatusOK, &requests)
+	return requests, err
+}
+
+// ListAuditLog lists the audit log entries matching the filter
+func (c *client) ListAuditLog(filter access.AuditFilter) ([]access.AuditEntry, error) {
+	query := url.Values{}
+	setQuery(query, "tenantID", filter.TenantID)
+	setQuery(query, "runtimeID", filter.RuntimeID)
+	setQuery(query, "actor", filter.Actor)
+	setQuery(query, "event", string(filter.Event))
+	if !filter.Since.IsZero() {
+		query.Set("since", filter.Since.Format(time.RFC3339))
+	}
+
+	var entries []access.AuditEntry
+	err := c.doJSON(http.MethodGet, fmt.Sprintf("%s/audit-log?%s", c.url, query.Encode()), nil, http.StatusOK, &entries)
+	return entries, err
+}
+
+func (c *client) doJSON(method, url string, body io.Reader, expectedStatus int, result interface{}) (err error) {
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return errors.Wrap(err, "while creating request")
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "while calling %s", url)
+	}
+
+	// Drain response body and close, return error to context if there isn't any.
+	defer func() {
+		derr := drainResponseBody(resp.Body)
+		if err == nil {
+			err = derr
+		}
+		cerr := resp.Body.Close()
+		if err == nil {
+			err = cerr
+		}
+	}()
+
+	if resp.StatusCode != expectedStatus {
+		msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 4096))
+		return fmt.Errorf("calling %s returned %s status: %s", url, resp.Status, string(msg))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return errors.Wrap(err, "while decoding response body")
+	}
+	return nil
+}
+
+func setQuery(query url.Values, key, value string) {
+	if value != "" {
+		query.Set(key, value)
+	}
+}
+
 func drainResponseBody(body io.Reader) error {
 	if body == nil {
 		return nil
@@ -2,12 +2,14 @@ package client
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
 
+	"github.com/kyma-project/control-plane/components/kubeconfig-service/pkg/access"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/oauth2"
@@ -61,3 +63,60 @@ func TestClient_GetKubeConfig(t *testing.T) {
 	assert.True(t, called)
 	assert.Equal(t, testKubeConfig, kc)
 }
+
+func TestClient_AccessRequests(t *testing.T) {
+	// given
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, r.Header.Get("Authorization"), fmt.Sprintf("Bearer %s", fixToken))
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/access-requests":
+			var dto access.CreateAccessRequestDTO
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&dto))
+			assert.Equal(t, "INC-1", dto.TicketID)
+			w.WriteHeader(http.StatusCreated)
+			require.NoError(t, json.NewEncoder(w).Encode(access.AccessRequest{ID: "req-1", TicketID: dto.TicketID, Status: access.StatusPending}))
+		case r.Method == http.MethodPut && r.URL.Path == "/access-requests/req-1/approve":
+			require.NoError(t, json.NewEncoder(w).Encode(access.AccessRequest{ID: "req-1", Status: access.StatusApproved}))
+		case r.Method == http.MethodPut && r.URL.Path == "/access-requests/req-2/approve":
+			w.WriteHeader(http.StatusForbidden)
+			_, err := w.Write([]byte("forbidden: access request cannot be approved by the requester"))
+			require.NoError(t, err)
+		case r.Method == http.MethodGet && r.URL.Path == "/audit-log":
+			assert.Equal(t, testRuntime, r.URL.Query().Get("runtimeID"))
+			require.NoError(t, json.NewEncoder(w).Encode([]access.AuditEntry{{ID: "entry-1", Event: access.EventIssued}}))
+		default:
+			t.Errorf("unexpected call %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(context.TODO(), ts.URL, fixToken)
+
+	// when
+	created, err := client.CreateAccessRequest(access.CreateAccessRequestDTO{TenantID: testTenant, RuntimeID: testRuntime, Justification: "incident", TicketID: "INC-1"})
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "req-1", created.ID)
+
+	// when
+	approved, err := client.ApproveAccessRequest("req-1")
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, access.StatusApproved, approved.Status)
+
+	// when
+	_, err = client.ApproveAccessRequest("req-2")
+
+	// then
+	assert.ErrorContains(t, err, "cannot be approved by the requester")
+
+	// when
+	entries, err := client.ListAuditLog(access.AuditFilter{RuntimeID: testRuntime})
+
+	// then
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
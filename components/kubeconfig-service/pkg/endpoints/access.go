@@ -0,0 +1,167 @@
+package endpoints
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/kyma-project/control-plane/components/kubeconfig-service/pkg/access"
+	authn "github.com/kyma-project/control-plane/components/kubeconfig-service/pkg/authn"
+	log "github.com/sirupsen/logrus"
+)
+
+const mimeTypeJSON = "application/json"
+
+// AccessEndpoint exposes the break-glass access requests and the audit log
+type AccessEndpoint struct {
+	manager *access.Manager
+}
+
+// NewAccessEndpoint return new instance of AccessEndpoint
+func NewAccessEndpoint(manager *access.Manager) *AccessEndpoint {
+	return &AccessEndpoint{manager: manager}
+}
+
+// CreateAccessRequest REST Path for filing an access request
+func (ae AccessEndpoint) CreateAccessRequest(w http.ResponseWriter, req *http.Request) {
+	userInfo, ok := req.Context().Value("userInfo").(authn.UserInfo)
+	if !ok {
+		writeError(w, http.StatusUnauthorized, errors.New("User info is null"))
+		return
+	}
+
+	if !userInfo.IsOperator() {
+		writeError(w, http.StatusForbidden, fmt.Errorf("only %v can file access requests", authn.L2L3OperatiorRoles))
+		return
+	}
+
+	var dto access.CreateAccessRequestDTO
+	if err := json.NewDecoder(req.Body).Decode(&dto); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	r, err := ae.manager.Request(userInfo.ID, dto)
+	if err != nil {
+		writeError(w, statusFor(err), err)
+		return
+	}
+	log.Infof("Access request %s filed by %s for %s/%s", r.ID, userInfo.ID, r.TenantID, r.RuntimeID)
+	writeJSON(w, http.StatusCreated, r)
+}
+
+// ApproveAccessRequest REST Path for approving an access request
+func (ae AccessEndpoint) ApproveAccessRequest(w http.ResponseWriter, req *http.Request) {
+	userInfo, ok := req.Context().Value("userInfo").(authn.UserInfo)
+	if !ok {
+		writeError(w, http.StatusUnauthorized, errors.New("User info is null"))
+		return
+	}
+
+	r, err := ae.manager.Approve(mux.Vars(req)["requestID"], userInfo.ID, userInfo.Groups)
+	if err != nil {
+		writeError(w, statusFor(err), err)
+		return
+	}
+	log.Infof("Access request %s approved by %s", r.ID, userInfo.ID)
+	writeJSON(w, http.StatusOK, r)
+}
+
+// ListAccessRequests REST Path for listing access requests
+func (ae AccessEndpoint) ListAccessRequests(w http.ResponseWriter, req *http.Request) {
+	if !ae.authorizeReview(w, req) {
+		return
+	}
+
+	query := req.URL.Query()
+	requests, err := ae.manager.ListRequests(access.RequestFilter{
+		TenantID:  query.Get("tenantID"),
+		RuntimeID: query.Get("runtimeID"),
+		Requester: query.Get("requester"),
+		Status:    access.RequestStatus(query.Get("status")),
+	})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, requests)
+}
+
+// ListAuditLog REST Path for querying the audit log
+func (ae AccessEndpoint) ListAuditLog(w http.ResponseWriter, req *http.Request) {
+	if !ae.authorizeReview(w, req) {
+		return
+	}
+
+	query := req.URL.Query()
+	filter := access.AuditFilter{
+		TenantID:  query.Get("tenantID"),
+		RuntimeID: query.Get("runtimeID"),
+		Actor:     query.Get("actor"),
+		Event:     access.Event(query.Get("event")),
+	}
+	if since := query.Get("since"); since != "" {
+		t, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		filter.Since = t
+	}
+
+	entries, err := ae.manager.ListAudit(filter)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, entries)
+}
+
+// authorizeReview writes the error response and returns false if the user can read neither the access requests nor the audit log
+func (ae AccessEndpoint) authorizeReview(w http.ResponseWriter, req *http.Request) bool {
+	userInfo, ok := req.Context().Value("userInfo").(authn.UserInfo)
+	if !ok {
+		writeError(w, http.StatusUnauthorized, errors.New("User info is null"))
+		return false
+	}
+	if !ae.manager.CanReview(userInfo.Role, userInfo.Groups) {
+		writeError(w, http.StatusForbidden, fmt.Errorf("%s is neither runtimeAdmin nor an approver", userInfo.ID))
+		return false
+	}
+	return true
+}
+
+func statusFor(err error) int {
+	switch {
+	case errors.Is(err, access.ErrInvalidRequest):
+		return http.StatusBadRequest
+	case errors.Is(err, access.ErrForbidden), errors.Is(err, access.ErrNotApproved):
+		return http.StatusForbidden
+	case errors.Is(err, access.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, access.ErrNotPending):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func writeJSON(w http.ResponseWriter, code int, obj interface{}) {
+	w.Header().Add("Content-Type", mimeTypeJSON)
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(obj); err != nil {
+		log.Errorf("Error while sending response: %s", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, code int, err error) {
+	w.Header().Add("Content-Type", mimeTypeText)
+	w.Header().Set("Content-Security-Policy", "default-src 'none';")
+	w.WriteHeader(code)
+	if _, err2 := w.Write([]byte(err.Error())); err2 != nil {
+		log.Errorf("Error while sending response: %s", err2)
+	}
+}
@@ -2,11 +2,12 @@ package endpoints
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
-	"sync"
 	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/kyma-project/control-plane/components/kubeconfig-service/pkg/access"
 	authn "github.com/kyma-project/control-plane/components/kubeconfig-service/pkg/authn"
 	"github.com/kyma-project/control-plane/components/kubeconfig-service/pkg/caller"
 	run "github.com/kyma-project/control-plane/components/kubeconfig-service/pkg/runtime"
@@ -19,18 +20,26 @@ const (
 	mimeTypeText = "text/plain"
 )
 
-// mutex to avoid critical section in config map deployment
-var mu sync.Mutex
+const (
+	ttlParam       = "ttl"
+	namespaceParam = "namespace"
+)
 
 // EndpointClient Wrpper for Endpoints
 type EndpointClient struct {
-	gqlURL string
+	gqlURL      string
+	tokenPolicy run.TokenPolicy
+	access      *access.Manager
+	grants      *run.GrantStorage
 }
 
 // NewEndpointClient return new instance of EndpointClient
-func NewEndpointClient(gqlURL string) *EndpointClient {
+func NewEndpointClient(gqlURL string, tokenPolicy run.TokenPolicy, accessManager *access.Manager, grants *run.GrantStorage) *EndpointClient {
 	return &EndpointClient{
-		gqlURL: gqlURL,
+		gqlURL:      gqlURL,
+		tokenPolicy: tokenPolicy,
+		access:      accessManager,
+		grants:      grants,
 	}
 }
 
@@ -40,25 +49,48 @@ func (ec EndpointClient) GetKubeConfig(w http.ResponseWriter, req *http.Request)
 	tenant := vars["tenantID"]
 	runtime := vars["runtimeID"]
 
-	var err error
+	opts, err := ec.accessOptions(req)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
 	var kubeConfig []byte
 	userInfo, ok := req.Context().Value("userInfo").(authn.UserInfo)
 	if ok {
-		log.Infof("Generating kubeconfig for %s/%s %s", tenant, runtime, userInfo)
-		kubeConfig, err = ec.generateKubeConfig(tenant, runtime, userInfo)
+		if !userInfo.IsOperator() {
+			writeError(w, http.StatusForbidden, fmt.Errorf("only %v can obtain kubeconfigs", authn.L2L3OperatiorRoles))
+			return
+		}
+
+		var claimed access.AccessRequest
+		if ec.access.ApprovalRequired(userInfo.Role) {
+			claimed, err = ec.claimApproval(userInfo.ID, tenant, runtime)
+			if err != nil {
+				log.Warnf("Refusing %s kubeconfig for %s/%s %s: %s", userInfo.Role, tenant, runtime, userInfo.ID, err)
+				writeError(w, statusFor(err), err)
+				return
+			}
+		}
+
+		log.Infof("Generating kubeconfig for %s/%s %s (ttl: %s, namespace: %q)", tenant, runtime, userInfo, opts.TokenTTL, opts.Namespace)
+		kubeConfig, err = ec.generateKubeConfig(tenant, runtime, userInfo, opts)
+		if err != nil && claimed.ID != "" {
+			if rerr := ec.access.Release(claimed); rerr != nil {
+				log.Errorf("Error while releasing access request %s: %s", claimed.ID, rerr)
+			}
+		}
+		if err == nil {
+			err = ec.recordIssuance(claimed, tenant, runtime, userInfo, opts)
+		}
 	} else {
 		err = errors.New("User info is null")
 	}
 
 	if err != nil {
-		w.Header().Add("Content-Type", mimeTypeText)
-		w.WriteHeader(http.StatusInternalServerError)
-		w.Header().Set("Content-Security-Policy", "default-src 'none';")
-		_, err2 := w.Write([]byte(err.Error()))
 		log.Errorf("Error while processing the kubeconfig file: %s", err)
-		if err2 != nil {
-			log.Errorf("Error while sending response: %s", err2)
-		}
+		writeError(w, http.StatusInternalServerError, err)
+		return
 	}
 	w.Header().Add("Content-Type", mimeTypeYaml)
 	_, err = w.Write(kubeConfig)
@@ -72,7 +104,40 @@ func (ec EndpointClient) GetHealthStatus(w http.ResponseWriter, req *http.Reques
 	w.WriteHeader(http.StatusOK)
 }
 
-func (ec EndpointClient) callGQL(tenantID, runtimeID string) (string, error) {
+// accessOptions reads the requested token lifetime and namespace scope from the query parameters
+func (ec EndpointClient) accessOptions(req *http.Request) (run.AccessOptions, error) {
+	query := req.URL.Query()
+	ttl, err := ec.tokenPolicy.ResolveTTL(query.Get(ttlParam))
+	if err != nil {
+		return run.AccessOptions{}, err
+	}
+	namespace := query.Get(namespaceParam)
+	if err := run.ValidateNamespace(namespace); err != nil {
+		return run.AccessOptions{}, err
+	}
+	return run.AccessOptions{TokenTTL: ttl, Namespace: namespace}, nil
+}
+
+// claimApproval consumes the approved access request of the user before the kubeconfig is generated
+func (ec EndpointClient) claimApproval(userID, tenant, runtime string) (access.AccessRequest, error) {
+	approved, err := ec.access.Approved(userID, tenant, runtime)
+	if err != nil {
+		return access.AccessRequest{}, err
+	}
+	return ec.access.Claim(approved)
+}
+
+// recordIssuance writes the issued kubeconfig to the audit log together with the claimed access request, if any
+func (ec EndpointClient) recordIssuance(claimed access.AccessRequest, tenant, runtime string, userInfo authn.UserInfo, opts run.AccessOptions) error {
+	details := fmt.Sprintf("ttl: %s", opts.TokenTTL)
+	if opts.Namespace != "" {
+		details = fmt.Sprintf("%s, namespace: %s", details, opts.Namespace)
+	}
+	return ec.access.RecordIssuance(userInfo.ID, tenant, runtime, userInfo.Role, claimed.ID, details)
+}
+
+// CallGQL returns the admin kubeconfig of the runtime
+func (ec EndpointClient) CallGQL(tenantID, runtimeID string) (string, error) {
 	c := caller.NewCaller(ec.gqlURL, tenantID)
 	status, err := c.RuntimeStatus(runtimeID)
 	if err != nil {
@@ -81,8 +146,8 @@ func (ec EndpointClient) callGQL(tenantID, runtimeID string) (string, error) {
 	return *status.RuntimeConfiguration.Kubeconfig, nil
 }
 
-func (ec EndpointClient) generateKubeConfig(tenant, runtime string, userInfo authn.UserInfo) ([]byte, error) {
-	rawConfig, err := ec.callGQL(tenant, runtime)
+func (ec EndpointClient) generateKubeConfig(tenant, runtime string, userInfo authn.UserInfo, opts run.AccessOptions) ([]byte, error) {
+	rawConfig, err := ec.CallGQL(tenant, runtime)
 	if err != nil || rawConfig == "" {
 		return nil, err
 	}
@@ -92,31 +157,30 @@ func (ec EndpointClient) generateKubeConfig(tenant, runtime string, userInfo aut
 		return nil, err
 	}
 
-	runtimeClient, err := run.NewRuntimeClient([]byte(rawConfig), userInfo.ID, userInfo.Role, tenant)
+	runtimeClient, err := run.NewRuntimeClient([]byte(rawConfig), userInfo.ID, userInfo.Role, tenant, opts)
 	if err != nil {
 		return nil, err
 	}
 
-	tc.SaToken, err = runtimeClient.Run()
-	if err != nil {
-		return nil, err
-	}
-
-	saKubeConfig, err := tc.TransformKubeconfig(transformer.KubeconfigSaTemplate)
+	// the created objects outlive the token, the sweeper removes them once the grant expires.
+	// The grant is recorded first, so the sweeper skips a grant extended before its objects are created again,
+	// and a grant which the sweeper is revoking is recorded only after its objects are removed
+	err = ec.grants.Record(run.Grant{
+		UserID:    userInfo.ID,
+		TenantID:  tenant,
+		RuntimeID: runtime,
+		Role:      userInfo.Role,
+		Namespace: opts.Namespace,
+		ExpiresAt: time.Now().Add(opts.TokenTTL),
+	})
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("while recording grant: %w", err)
 	}
 
-	mu.Lock()
-	startTime := time.Now()
-	err = runtimeClient.DeployConfigMap(runtime, userInfo.Role, startTime)
-	mu.Unlock()
+	tc.SaToken, err = runtimeClient.Run()
 	if err != nil {
-		log.Errorf("Cannot generate config map, %s", err.Error())
 		return nil, err
 	}
 
-	go runtimeClient.SetupTimer(startTime, runtime)
-
-	return saKubeConfig, nil
+	return tc.TransformKubeconfig(transformer.KubeconfigSaTemplate)
 }
@@ -1,6 +1,8 @@
 package env
 
 import (
+	"time"
+
 	"github.com/vrischmann/envconfig"
 )
 
@@ -32,6 +34,17 @@ type EnvConfig struct {
 		}
 		SupportedSigningAlgs []string `envconfig:"default=RS256"`
 	}
+	Token struct {
+		DefaultTTL time.Duration `envconfig:"default=8h"`
+		MaxTTL     time.Duration `envconfig:"default=24h"`
+		// SweepInterval is how often the objects of the expired grants are removed from the runtimes
+		SweepInterval time.Duration `envconfig:"default=10m"`
+	}
+	Access struct {
+		ApprovalRequired bool          `envconfig:"default=true"`
+		ApproverGroup    string        `envconfig:"default=runtimeAdminApprover"`
+		RequestTTL       time.Duration `envconfig:"default=4h"`
+	}
 	LogLevel string `envconfig:"default=info"`
 }
 
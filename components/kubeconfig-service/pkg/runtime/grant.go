@@ -0,0 +1,323 @@
+package runtime
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	grantLabelKey   = "service"
+	grantLabelValue = "kubeconfig-grant"
+	grantDataKey    = "grant"
+)
+
+// Grant is the ServiceAccount with its roles and bindings created in a runtime for a user,
+// the objects are removed by the Sweeper once the last token issued for them expires
+type Grant struct {
+	UserID    string    `json:"userID"`
+	TenantID  string    `json:"tenantID"`
+	RuntimeID string    `json:"runtimeID"`
+	Role      string    `json:"role"`
+	Namespace string    `json:"namespace,omitempty"`
+	ExpiresAt time.Time `json:"expiresAt"`
+	// Revoking is set by the Sweeper before it removes the objects of the grant, such a grant cannot be extended
+	Revoking bool `json:"revoking,omitempty"`
+
+	// resourceVersion of the ConfigMap the grant was read from
+	resourceVersion string
+}
+
+// ErrGrantRevoking is returned when the grant cannot be extended because the Sweeper is removing its objects
+var ErrGrantRevoking = errors.New("the expired grant is being revoked")
+
+// GrantStorage keeps each grant in its own ConfigMap in the KCP cluster
+type GrantStorage struct {
+	k8s       kubernetes.Interface
+	namespace string
+
+	revokingPollInterval time.Duration
+	revokingTimeout      time.Duration
+}
+
+func NewGrantStorage(k8s kubernetes.Interface, namespace string) *GrantStorage {
+	return &GrantStorage{
+		k8s:                  k8s,
+		namespace:            namespace,
+		revokingPollInterval: time.Second,
+		revokingTimeout:      30 * time.Second,
+	}
+}
+
+// Record stores the grant, an already stored grant keeps the later expiration time.
+// A grant being revoked is recorded again once the Sweeper deleted it, ErrGrantRevoking is returned if it takes too long
+func (s *GrantStorage) Record(g Grant) error {
+	var lastErr error
+	err := wait.PollImmediate(s.revokingPollInterval, s.revokingTimeout, func() (bool, error) {
+		lastErr = s.record(g)
+		switch {
+		case lastErr == nil:
+			return true, nil
+		case errors.Is(lastErr, ErrGrantRevoking), k8serrors.IsConflict(lastErr), k8serrors.IsAlreadyExists(lastErr):
+			return false, nil
+		default:
+			return false, lastErr
+		}
+	})
+	if err == wait.ErrWaitTimeout {
+		return lastErr
+	}
+	return err
+}
+
+func (s *GrantStorage) record(g Grant) error {
+	name := grantName(g)
+	existing, err := s.k8s.CoreV1().ConfigMaps(s.namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		cm, err := s.newConfigMap(name, g)
+		if err != nil {
+			return err
+		}
+		_, err = s.k8s.CoreV1().ConfigMaps(s.namespace).Create(context.Background(), cm, metav1.CreateOptions{})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	var stored Grant
+	if err := json.Unmarshal([]byte(existing.Data[grantDataKey]), &stored); err == nil {
+		if stored.Revoking {
+			return ErrGrantRevoking
+		}
+		if stored.ExpiresAt.After(g.ExpiresAt) {
+			g.ExpiresAt = stored.ExpiresAt
+		}
+	}
+	data, err := json.Marshal(g)
+	if err != nil {
+		return err
+	}
+	existing.Data = map[string]string{grantDataKey: string(data)}
+	_, err = s.k8s.CoreV1().ConfigMaps(s.namespace).Update(context.Background(), existing, metav1.UpdateOptions{})
+	return err
+}
+
+// List returns all stored grants
+func (s *GrantStorage) List() ([]Grant, error) {
+	cmList, err := s.k8s.CoreV1().ConfigMaps(s.namespace).List(context.Background(), metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", grantLabelKey, grantLabelValue),
+	})
+	if err != nil {
+		return nil, err
+	}
+	grants := make([]Grant, 0, len(cmList.Items))
+	for _, cm := range cmList.Items {
+		g, err := decodeGrant(cm)
+		if err != nil {
+			return nil, err
+		}
+		grants = append(grants, g)
+	}
+	return grants, nil
+}
+
+// MarkRevoking sets the Revoking flag of the grant if it was not changed since it was read, otherwise a conflict error is returned.
+// The update is conditional on the resource version, so it is never applied over a concurrent extension of the grant
+func (s *GrantStorage) MarkRevoking(g Grant) (Grant, error) {
+	name := grantName(g)
+	cm, err := s.k8s.CoreV1().ConfigMaps(s.namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		return Grant{}, err
+	}
+	if cm.ResourceVersion != g.resourceVersion {
+		return Grant{}, k8serrors.NewConflict(v1.Resource("configmaps"), name, errors.New("the grant was changed since it was read"))
+	}
+
+	g.Revoking = true
+	data, err := json.Marshal(g)
+	if err != nil {
+		return Grant{}, err
+	}
+	cm.Data = map[string]string{grantDataKey: string(data)}
+	updated, err := s.k8s.CoreV1().ConfigMaps(s.namespace).Update(context.Background(), cm, metav1.UpdateOptions{})
+	if err != nil {
+		return Grant{}, err
+	}
+	return decodeGrant(*updated)
+}
+
+// Delete removes the stored grant if it was not changed since it was read, otherwise a conflict error is returned
+func (s *GrantStorage) Delete(g Grant) error {
+	options := metav1.DeleteOptions{}
+	if g.resourceVersion != "" {
+		options.Preconditions = &metav1.Preconditions{ResourceVersion: &g.resourceVersion}
+	}
+	err := s.k8s.CoreV1().ConfigMaps(s.namespace).Delete(context.Background(), grantName(g), options)
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+func decodeGrant(cm v1.ConfigMap) (Grant, error) {
+	var g Grant
+	if err := json.Unmarshal([]byte(cm.Data[grantDataKey]), &g); err != nil {
+		return Grant{}, fmt.Errorf("while decoding grant %s: %w", cm.Name, err)
+	}
+	g.resourceVersion = cm.ResourceVersion
+	return g, nil
+}
+
+func (s *GrantStorage) newConfigMap(name string, g Grant) (*v1.ConfigMap, error) {
+	data, err := json.Marshal(g)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: s.namespace,
+			Labels:    map[string]string{grantLabelKey: grantLabelValue},
+		},
+		Data: map[string]string{grantDataKey: string(data)},
+	}, nil
+}
+
+// grantName is unique per user, runtime and namespace scope, the user ID is hashed as it is not a valid object name
+func grantName(g Grant) string {
+	sum := sha256.Sum256([]byte(strings.Join([]string{g.UserID, g.TenantID, g.RuntimeID, g.Namespace}, "/")))
+	return fmt.Sprintf("kubeconfig-grant-%x", sum[:10])
+}
+
+// KubeconfigFetcher returns the admin kubeconfig of the runtime
+type KubeconfigFetcher func(tenantID, runtimeID string) (string, error)
+
+// Sweeper removes the objects of the expired grants from the runtimes
+type Sweeper struct {
+	grants     *GrantStorage
+	kubeconfig KubeconfigFetcher
+	newClient  func(kubeconfig string, g Grant) (*RuntimeClient, error)
+}
+
+func NewSweeper(grants *GrantStorage, kubeconfig KubeconfigFetcher) *Sweeper {
+	return &Sweeper{
+		grants:     grants,
+		kubeconfig: kubeconfig,
+		newClient: func(kubeconfig string, g Grant) (*RuntimeClient, error) {
+			return NewRuntimeClient([]byte(kubeconfig), g.UserID, g.Role, g.TenantID, AccessOptions{Namespace: g.Namespace})
+		},
+	}
+}
+
+// Run sweeps the expired grants in the given interval until the context is done
+func (s *Sweeper) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.Sweep(time.Now()); err != nil {
+				log.Errorf("Error sweeping expired kubeconfig grants: %v", err)
+			}
+		}
+	}
+}
+
+// Sweep revokes the grants expired at the given time, a failed grant stays marked as revoking and is retried in the next sweep.
+// A grant extended by a newly issued kubeconfig after it was listed is skipped, a kubeconfig requested after the grant
+// was marked waits until the grant is deleted and creates its objects again.
+func (s *Sweeper) Sweep(now time.Time) error {
+	grants, err := s.grants.List()
+	if err != nil {
+		return errors.Wrap(err, "while listing grants")
+	}
+	var lastErr error
+	for _, g := range grants {
+		if now.Before(g.ExpiresAt) {
+			continue
+		}
+		// the mark keeps a kubeconfig issued for the grant from reusing the objects while they are removed
+		marked, err := s.grants.MarkRevoking(g)
+		switch {
+		case k8serrors.IsConflict(err), k8serrors.IsNotFound(err):
+			log.Infof("Grant of %s for runtime %s was changed since it was listed, skipping", g.UserID, g.RuntimeID)
+			continue
+		case err != nil:
+			log.Errorf("Failed to mark grant of %s for runtime %s as revoking: %s", g.UserID, g.RuntimeID, err)
+			lastErr = err
+			continue
+		}
+		if err := s.revoke(marked, liveGrants(grants, g, now)); err != nil {
+			log.Errorf("Failed to revoke grant of %s for runtime %s: %s", g.UserID, g.RuntimeID, err)
+			lastErr = err
+			continue
+		}
+		if err := s.grants.Delete(marked); err != nil {
+			lastErr = err
+		}
+	}
+	return lastErr
+}
+
+// revoke deletes the binding and the ServiceAccount of the grant, the ClusterRoles are kept while the live grants of the same user use them
+func (s *Sweeper) revoke(g Grant, live []Grant) error {
+	kubeconfig, err := s.kubeconfig(g.TenantID, g.RuntimeID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			log.Infof("Runtime %s of grant for %s not found, nothing to revoke", g.RuntimeID, g.UserID)
+			return nil
+		}
+		return errors.Wrap(err, "while fetching runtime kubeconfig")
+	}
+	rtc, err := s.newClient(kubeconfig, g)
+	if err != nil {
+		return errors.Wrap(err, "while creating runtime client")
+	}
+
+	if rtc.User.NamespaceScoped {
+		err = rtc.deleteRoleBinding()
+	} else {
+		err = rtc.deleteCRBinding()
+	}
+	if err != nil {
+		return err
+	}
+	// the service account is dedicated to the grant scope, the grant of the same scope is the expired one
+	if _, err := rtc.deleteServiceAccount(); err != nil {
+		return err
+	}
+	if len(live) == 0 {
+		for _, name := range []string{rtc.User.ClusterRoleName, rtc.User.ClusterRoleRulesName} {
+			if _, err := rtc.deleteClusterRole(name); err != nil {
+				return err
+			}
+		}
+	}
+	log.Infof("Revoked expired grant of %s for runtime %s", g.UserID, g.RuntimeID)
+	return nil
+}
+
+// liveGrants returns the not expired grants of the same user in the same runtime
+func liveGrants(grants []Grant, g Grant, now time.Time) []Grant {
+	var live []Grant
+	for _, other := range grants {
+		if other.UserID == g.UserID && other.TenantID == g.TenantID && other.RuntimeID == g.RuntimeID && now.Before(other.ExpiresAt) {
+			live = append(live, other)
+		}
+	}
+	return live
+}
@@ -0,0 +1,282 @@
+package runtime
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestGrantStorage_Record(t *testing.T) {
+	// given
+	grants := NewGrantStorage(fake.NewSimpleClientset(), KcpNamespace)
+	now := time.Now()
+	grant := Grant{UserID: "user@example.com", TenantID: "tenant", RuntimeID: "runtime", Role: RUNTIME_OPERATOR, ExpiresAt: now.Add(2 * time.Hour)}
+
+	// when
+	require.NoError(t, grants.Record(grant))
+	grant.ExpiresAt = now.Add(time.Hour)
+	require.NoError(t, grants.Record(grant))
+
+	// then
+	stored, err := grants.List()
+	require.NoError(t, err)
+	require.Len(t, stored, 1)
+	assert.True(t, stored[0].ExpiresAt.Equal(now.Add(2*time.Hour)), "the later expiration is kept")
+}
+
+func TestSweeper_Sweep(t *testing.T) {
+	// given
+	kcp := fake.NewSimpleClientset()
+	runtimeK8s := fake.NewSimpleClientset()
+	grants := NewGrantStorage(kcp, KcpNamespace)
+	now := time.Now()
+
+	expired := Grant{UserID: "user", TenantID: "tenant", RuntimeID: "runtime", Role: RUNTIME_ADMIN, ExpiresAt: now.Add(-time.Minute)}
+	live := Grant{UserID: "user", TenantID: "tenant", RuntimeID: "runtime", Role: RUNTIME_ADMIN, Namespace: "default", ExpiresAt: now.Add(time.Hour)}
+	other := Grant{UserID: "other", TenantID: "tenant", RuntimeID: "runtime", Role: RUNTIME_OPERATOR, ExpiresAt: now.Add(-time.Minute)}
+	gone := Grant{UserID: "user", TenantID: "tenant", RuntimeID: "deprovisioned", Role: RUNTIME_ADMIN, ExpiresAt: now.Add(-time.Minute)}
+	for _, g := range []Grant{expired, live, other} {
+		require.NoError(t, grants.Record(g))
+		issue(t, runtimeK8s, g)
+	}
+	require.NoError(t, grants.Record(gone))
+
+	sweeper := NewSweeper(grants, func(_, runtimeID string) (string, error) {
+		if runtimeID == "deprovisioned" {
+			return "", errors.New("Failed to get Runtime status: error getting Shoot: not found")
+		}
+		return "kubeconfig", nil
+	})
+	sweeper.newClient = func(_ string, g Grant) (*RuntimeClient, error) {
+		return newTestGrantClient(runtimeK8s, g), nil
+	}
+
+	// when
+	err := sweeper.Sweep(now)
+
+	// then
+	require.NoError(t, err)
+	stored, err := grants.List()
+	require.NoError(t, err)
+	require.Len(t, stored, 1)
+	assert.Equal(t, live.Namespace, stored[0].Namespace)
+	assert.True(t, live.ExpiresAt.Equal(stored[0].ExpiresAt))
+
+	// the cluster-wide access of user is revoked, its namespace-scoped access is kept
+	_, err = runtimeK8s.RbacV1().ClusterRoleBindings().Get(context.TODO(), "user", v1.GetOptions{})
+	assert.True(t, k8serrors.IsNotFound(err))
+	_, err = runtimeK8s.CoreV1().ServiceAccounts(Namespace).Get(context.TODO(), "user", v1.GetOptions{})
+	assert.True(t, k8serrors.IsNotFound(err))
+	_, err = runtimeK8s.CoreV1().ServiceAccounts("default").Get(context.TODO(), "user-ns-default", v1.GetOptions{})
+	assert.NoError(t, err)
+	_, err = runtimeK8s.RbacV1().RoleBindings("default").Get(context.TODO(), "user-ns-default", v1.GetOptions{})
+	assert.NoError(t, err)
+	_, err = runtimeK8s.RbacV1().ClusterRoles().Get(context.TODO(), "user", v1.GetOptions{})
+	assert.NoError(t, err)
+
+	// everything of other is removed
+	_, err = runtimeK8s.RbacV1().ClusterRoles().Get(context.TODO(), "other", v1.GetOptions{})
+	assert.True(t, k8serrors.IsNotFound(err))
+	_, err = runtimeK8s.RbacV1().ClusterRoles().Get(context.TODO(), "other-rules", v1.GetOptions{})
+	assert.True(t, k8serrors.IsNotFound(err))
+	_, err = runtimeK8s.RbacV1().ClusterRoleBindings().Get(context.TODO(), "other", v1.GetOptions{})
+	assert.True(t, k8serrors.IsNotFound(err))
+	_, err = runtimeK8s.CoreV1().ServiceAccounts(Namespace).Get(context.TODO(), "other", v1.GetOptions{})
+	assert.True(t, k8serrors.IsNotFound(err))
+}
+
+// issue creates the objects which RuntimeClient.Run creates for the grant
+func issue(t *testing.T, k8s kubernetes.Interface, g Grant) {
+	user := newTestGrantClient(k8s, g).User
+	_, err := k8s.CoreV1().ServiceAccounts(user.Namespace).Create(context.TODO(), &corev1.ServiceAccount{ObjectMeta: v1.ObjectMeta{Name: user.ServiceAccountName, Namespace: user.Namespace}}, v1.CreateOptions{})
+	if err != nil && !k8serrors.IsAlreadyExists(err) {
+		require.NoError(t, err)
+	}
+	for _, name := range []string{user.ClusterRoleName, user.ClusterRoleRulesName} {
+		_, err := k8s.RbacV1().ClusterRoles().Create(context.TODO(), &rbacv1.ClusterRole{ObjectMeta: v1.ObjectMeta{Name: name}}, v1.CreateOptions{})
+		if err != nil && !k8serrors.IsAlreadyExists(err) {
+			require.NoError(t, err)
+		}
+	}
+	if user.NamespaceScoped {
+		_, err = k8s.RbacV1().RoleBindings(user.Namespace).Create(context.TODO(), &rbacv1.RoleBinding{ObjectMeta: v1.ObjectMeta{Name: user.RoleBindingName, Namespace: user.Namespace}}, v1.CreateOptions{})
+	} else {
+		_, err = k8s.RbacV1().ClusterRoleBindings().Create(context.TODO(), &rbacv1.ClusterRoleBinding{ObjectMeta: v1.ObjectMeta{Name: user.ClusterRoleBindingName}}, v1.CreateOptions{})
+	}
+	require.NoError(t, err)
+}
+
+func newTestGrantClient(k8s kubernetes.Interface, g Grant) *RuntimeClient {
+	return &RuntimeClient{K8s: k8s, User: newSAInfo(g.UserID, g.TenantID, AccessOptions{Namespace: g.Namespace}), L2L3OperatiorRole: g.Role, TokenTTL: time.Hour}
+}
+
+func TestSweeper_SweepSkipsExtendedGrant(t *testing.T) {
+	// given
+	kcp := fake.NewSimpleClientset()
+	versionConfigMaps(kcp)
+	runtimeK8s := fake.NewSimpleClientset()
+	grants := NewGrantStorage(kcp, KcpNamespace)
+	now := time.Now()
+	grant := Grant{UserID: "user", TenantID: "tenant", RuntimeID: "runtime", Role: RUNTIME_ADMIN, ExpiresAt: now.Add(-time.Minute)}
+	require.NoError(t, grants.Record(grant))
+	issue(t, runtimeK8s, grant)
+
+	// a kubeconfig is issued again after the sweeper listed the grants
+	extended := false
+	kcp.PrependReactor("get", "configmaps", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if !extended {
+			extended = true
+			// the clientset is locked while the reactors run, the stored object is changed in the tracker
+			obj, err := kcp.Tracker().Get(corev1.SchemeGroupVersion.WithResource("configmaps"), KcpNamespace, grantName(grant))
+			require.NoError(t, err)
+			cm := obj.(*corev1.ConfigMap)
+			grant.ExpiresAt = now.Add(time.Hour)
+			data, err := json.Marshal(grant)
+			require.NoError(t, err)
+			cm.Data[grantDataKey] = string(data)
+			cm.ResourceVersion += "-extended"
+			require.NoError(t, kcp.Tracker().Update(corev1.SchemeGroupVersion.WithResource("configmaps"), cm, KcpNamespace))
+		}
+		return false, nil, nil
+	})
+	sweeper := NewSweeper(grants, func(_, _ string) (string, error) { return "kubeconfig", nil })
+	sweeper.newClient = func(_ string, g Grant) (*RuntimeClient, error) {
+		return newTestGrantClient(runtimeK8s, g), nil
+	}
+
+	// when
+	err := sweeper.Sweep(now)
+
+	// then
+	require.NoError(t, err)
+	stored, err := grants.List()
+	require.NoError(t, err)
+	require.Len(t, stored, 1)
+	assert.True(t, stored[0].ExpiresAt.Equal(now.Add(time.Hour)))
+	_, err = runtimeK8s.RbacV1().ClusterRoleBindings().Get(context.TODO(), "user", v1.GetOptions{})
+	assert.NoError(t, err)
+	_, err = runtimeK8s.CoreV1().ServiceAccounts(Namespace).Get(context.TODO(), "user", v1.GetOptions{})
+	assert.NoError(t, err)
+}
+
+// versionConfigMaps sets a new resource version on every write of a ConfigMap, as the API server does
+func versionConfigMaps(k8s *fake.Clientset) {
+	version := 0
+	setVersion := func(action k8stesting.Action) (bool, runtime.Object, error) {
+		version++
+		cm := action.(interface{ GetObject() runtime.Object }).GetObject().(*corev1.ConfigMap)
+		cm.ResourceVersion = strconv.Itoa(version)
+		return false, nil, nil
+	}
+	k8s.PrependReactor("create", "configmaps", setVersion)
+	k8s.PrependReactor("update", "configmaps", setVersion)
+}
+
+func TestSweeper_SweepNamespacedGrantInClusterWideNamespace(t *testing.T) {
+	// given
+	kcp := fake.NewSimpleClientset()
+	runtimeK8s := fake.NewSimpleClientset()
+	grants := NewGrantStorage(kcp, KcpNamespace)
+	now := time.Now()
+
+	clusterWide := Grant{UserID: "user", TenantID: "tenant", RuntimeID: "runtime", Role: RUNTIME_ADMIN, ExpiresAt: now.Add(time.Hour)}
+	namespaced := Grant{UserID: "user", TenantID: "tenant", RuntimeID: "runtime", Role: RUNTIME_ADMIN, Namespace: Namespace, ExpiresAt: now.Add(-time.Minute)}
+	runtimeK8s.PrependReactor("create", "serviceaccounts", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "token" {
+			return false, nil, nil
+		}
+		return true, &authenticationv1.TokenRequest{Status: authenticationv1.TokenRequestStatus{Token: "token"}}, nil
+	})
+	for _, g := range []Grant{clusterWide, namespaced} {
+		require.NoError(t, grants.Record(g))
+		_, err := newTestGrantClient(runtimeK8s, g).Run()
+		require.NoError(t, err)
+	}
+
+	// the namespace-scoped service account is not bound to the cluster-wide role
+	crb, err := runtimeK8s.RbacV1().ClusterRoleBindings().Get(context.TODO(), "user", v1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []rbacv1.Subject{{Kind: ServiceAccount, Name: "user", Namespace: Namespace}}, crb.Subjects)
+	rb, err := runtimeK8s.RbacV1().RoleBindings(Namespace).Get(context.TODO(), "user-ns-kyma-system", v1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []rbacv1.Subject{{Kind: ServiceAccount, Name: "user-ns-kyma-system", Namespace: Namespace}}, rb.Subjects)
+
+	sweeper := NewSweeper(grants, func(_, _ string) (string, error) { return "kubeconfig", nil })
+	sweeper.newClient = func(_ string, g Grant) (*RuntimeClient, error) {
+		return newTestGrantClient(runtimeK8s, g), nil
+	}
+
+	// when
+	err = sweeper.Sweep(now)
+
+	// then
+	require.NoError(t, err)
+	_, err = runtimeK8s.CoreV1().ServiceAccounts(Namespace).Get(context.TODO(), "user-ns-kyma-system", v1.GetOptions{})
+	assert.True(t, k8serrors.IsNotFound(err))
+	_, err = runtimeK8s.RbacV1().RoleBindings(Namespace).Get(context.TODO(), "user-ns-kyma-system", v1.GetOptions{})
+	assert.True(t, k8serrors.IsNotFound(err))
+	_, err = runtimeK8s.CoreV1().ServiceAccounts(Namespace).Get(context.TODO(), "user", v1.GetOptions{})
+	assert.NoError(t, err)
+	_, err = runtimeK8s.RbacV1().ClusterRoleBindings().Get(context.TODO(), "user", v1.GetOptions{})
+	assert.NoError(t, err)
+}
+
+func TestSweeper_SweepBlocksExtensionWhileRevoking(t *testing.T) {
+	// given
+	kcp := fake.NewSimpleClientset()
+	versionConfigMaps(kcp)
+	runtimeK8s := fake.NewSimpleClientset()
+	grants := NewGrantStorage(kcp, KcpNamespace)
+	grants.revokingPollInterval = time.Millisecond
+	grants.revokingTimeout = 10 * time.Millisecond
+	now := time.Now()
+	grant := Grant{UserID: "user", TenantID: "tenant", RuntimeID: "runtime", Role: RUNTIME_ADMIN, ExpiresAt: now.Add(-time.Minute)}
+	require.NoError(t, grants.Record(grant))
+	issue(t, runtimeK8s, grant)
+
+	// a kubeconfig is requested after the grant was marked and before its objects are removed
+	extended := grant
+	extended.ExpiresAt = now.Add(time.Hour)
+	var recordErr error
+	sweeper := NewSweeper(grants, func(_, _ string) (string, error) {
+		recordErr = grants.Record(extended)
+		return "kubeconfig", nil
+	})
+	sweeper.newClient = func(_ string, g Grant) (*RuntimeClient, error) {
+		return newTestGrantClient(runtimeK8s, g), nil
+	}
+
+	// when
+	err := sweeper.Sweep(now)
+
+	// then
+	require.NoError(t, err)
+	assert.ErrorIs(t, recordErr, ErrGrantRevoking)
+	stored, err := grants.List()
+	require.NoError(t, err)
+	assert.Empty(t, stored)
+	_, err = runtimeK8s.CoreV1().ServiceAccounts(Namespace).Get(context.TODO(), "user", v1.GetOptions{})
+	assert.True(t, k8serrors.IsNotFound(err))
+
+	// the kubeconfig requested again is recorded and creates the objects again
+	require.NoError(t, grants.Record(extended))
+	stored, err = grants.List()
+	require.NoError(t, err)
+	require.Len(t, stored, 1)
+	assert.False(t, stored[0].Revoking)
+}
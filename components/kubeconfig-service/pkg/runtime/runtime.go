@@ -28,7 +28,9 @@ type SAInfo struct {
 	ClusterRoleAggrLabel   string
 	ClusterRoleRulesName   string
 	ClusterRoleBindingName string
+	RoleBindingName        string
 	Namespace              string
+	NamespaceScoped        bool
 	SecretName             string
 	TenantID               string
 }
@@ -36,6 +38,7 @@ type SAInfo struct {
 const SA = "SA"
 const ClusterRole = "ClusterRole"
 const ClusterRoleBinding = "ClusterRoleBinding"
+const RoleBinding = "RoleBinding"
 const Namespace = "kyma-system"
 const RUNTIME_ADMIN = "runtimeAdmin"
 const RUNTIME_OPERATOR = "runtimeOperator"
@@ -75,13 +78,13 @@ type RollbackE struct {
 }
 type RuntimeClient struct {
 	K8s               kubernetes.Interface
-	KcpK8s            kubernetes.Interface
 	User              SAInfo
 	L2L3OperatiorRole string
 	RollbackE         RollbackE
+	TokenTTL          time.Duration
 }
 
-func NewRuntimeClient(kubeConfig []byte, userID string, L2L3OperatiorRole string, tenant string) (*RuntimeClient, error) {
+func NewRuntimeClient(kubeConfig []byte, userID string, L2L3OperatiorRole string, tenant string, opts AccessOptions) (*RuntimeClient, error) {
 	config, err := clientcmd.RESTConfigFromKubeConfig([]byte(kubeConfig))
 	if err != nil {
 		return nil, err
@@ -91,25 +94,36 @@ func NewRuntimeClient(kubeConfig []byte, userID string, L2L3OperatiorRole string
 		return nil, err
 	}
 
-	coreClientset, err := GetK8sClient()
-	if err != nil {
-		return nil, err
-	}
+	user := newSAInfo(userID, tenant, opts)
+	RollbackE := RollbackE{}
+	return &RuntimeClient{clientset, user, L2L3OperatiorRole, RollbackE, opts.TokenTTL}, nil
+}
 
+// newSAInfo names the objects of the user, namespace-scoped credentials use a dedicated service account and role binding
+// in the target namespace, so they never share the service account bound to the cluster-wide role of the same user,
+// even if the target namespace is the one of the cluster-wide service account
+func newSAInfo(userID, tenant string, opts AccessOptions) SAInfo {
 	user := SAInfo{
 		ServiceAccountName:     userID,
 		ClusterRoleName:        userID,
 		ClusterRoleAggrLabel:   fmt.Sprintf("rbac.authorization.k8s.io/aggregate-to-%s", userID),
 		ClusterRoleRulesName:   fmt.Sprintf("%s-rules", userID),
 		ClusterRoleBindingName: userID,
+		RoleBindingName:        userID,
 		Namespace:              Namespace,
 		TenantID:               tenant,
 	}
-	RollbackE := RollbackE{}
-	return &RuntimeClient{clientset, coreClientset, user, L2L3OperatiorRole, RollbackE}, nil
+	if opts.Namespace != "" {
+		user.ServiceAccountName = fmt.Sprintf("%s-ns-%s", userID, opts.Namespace)
+		user.RoleBindingName = user.ServiceAccountName
+		user.Namespace = opts.Namespace
+		user.NamespaceScoped = true
+	}
+	return user
 }
 
-// kubeconfig access runtime, create sa and clusterrole and clusterrolebinding according to userID and l2L3OperatiorRole
+// kubeconfig access runtime, create sa and clusterrole and clusterrolebinding (or rolebinding for namespace-scoped access)
+// according to userID and l2L3OperatiorRole, then mint a bound token with the requested TTL
 func (rtc *RuntimeClient) Run() (string, error) {
 	var resultE error
 	defer func() {
@@ -141,6 +155,15 @@ func (rtc *RuntimeClient) Run() (string, error) {
 		return "", errors.Wrapf(err, "while getServiceAccountToken from %s", rtc.User.ServiceAccountName)
 	}
 
+	if rtc.User.NamespaceScoped {
+		err = rtc.createRoleBinding()
+		if err != nil {
+			rtc.RollbackE.Data = append(rtc.RollbackE.Data, SA, ClusterRole)
+			return "", errors.Wrapf(err, "while createRoleBinding %s in %s", rtc.User.RoleBindingName, rtc.User.Namespace)
+		}
+		return saToken, resultE
+	}
+
 	err = rtc.createClusterRoleBinding()
 	if err != nil {
 		rtc.RollbackE.Data = append(rtc.RollbackE.Data, SA, ClusterRole)
@@ -210,6 +233,22 @@ func (rtc *RuntimeClient) createClusterRoleBinding() error {
 	return err
 }
 
+func (rtc *RuntimeClient) createRoleBinding() error {
+	objectMeta, roleRef, subjects := initCRBindingE(rtc.User)
+	objectMeta.Name = rtc.User.RoleBindingName
+	objectMeta.Namespace = rtc.User.Namespace
+	existed, err := rtc.verifyRoleBinding(roleRef, subjects)
+	if err != nil {
+		return errors.Wrapf(err, "in verifyRoleBinding")
+	}
+	if existed {
+		return nil
+	}
+	rolebinding := initRoleBinding(objectMeta, roleRef, subjects)
+	_, err = rtc.K8s.RbacV1().RoleBindings(rtc.User.Namespace).Create(context.TODO(), rolebinding, metav1.CreateOptions{})
+	return err
+}
+
 func (rtc *RuntimeClient) deleteServiceAccount() (bool, error) {
 	err := rtc.K8s.CoreV1().ServiceAccounts(rtc.User.Namespace).Delete(context.TODO(), rtc.User.ServiceAccountName, metav1.DeleteOptions{})
 	if err == nil || apierr.IsNotFound(err) {
@@ -226,6 +265,14 @@ func (rtc *RuntimeClient) deleteCRBinding() error {
 	return err
 }
 
+func (rtc *RuntimeClient) deleteRoleBinding() error {
+	err := rtc.K8s.RbacV1().RoleBindings(rtc.User.Namespace).Delete(context.TODO(), rtc.User.RoleBindingName, metav1.DeleteOptions{})
+	if err == nil || apierr.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
 func (rtc *RuntimeClient) deleteClusterRole(name string) (bool, error) {
 	err := rtc.K8s.RbacV1().ClusterRoles().Delete(context.TODO(), name, metav1.DeleteOptions{})
 	if err == nil || apierr.IsNotFound(err) {
@@ -316,16 +363,41 @@ func (rtc *RuntimeClient) verifyCRBinding(roleRef rbacv1.RoleRef, subjects []rba
 	return false, err
 }
 
+func (rtc *RuntimeClient) verifyRoleBinding(roleRef rbacv1.RoleRef, subjects []rbacv1.Subject) (bool, error) {
+	rb, err := rtc.K8s.RbacV1().RoleBindings(rtc.User.Namespace).Get(context.TODO(), rtc.User.RoleBindingName, metav1.GetOptions{})
+	if rb != nil && err == nil {
+		if reflect.DeepEqual(rb.Subjects, subjects) && reflect.DeepEqual(rb.RoleRef, roleRef) {
+			return true, nil
+		} else {
+			err = rtc.deleteRoleBinding()
+			if err == nil {
+				return false, nil
+			} else {
+				return false, errors.Wrapf(err, "in deleteRoleBinding")
+			}
+		}
+	}
+
+	if apierr.IsNotFound(err) {
+		return false, nil
+	}
+	return false, err
+}
+
+// getServiceAccountToken mints a bound token through the TokenRequest API, the token expires on its own after TokenTTL
 func (rtc *RuntimeClient) getServiceAccountToken() (string, error) {
-	var expirationSeconds int64 = int64(ExpireTime.Seconds())
+	var expirationSeconds int64 = int64(rtc.TokenTTL.Seconds())
 	tokenRequest := authenticationv1.TokenRequest{
 		Spec: authenticationv1.TokenRequestSpec{
 			ExpirationSeconds: &expirationSeconds,
 		},
 	}
 	req, err := rtc.K8s.CoreV1().ServiceAccounts(rtc.User.Namespace).CreateToken(context.TODO(), rtc.User.ServiceAccountName, &tokenRequest, metav1.CreateOptions{})
+	if err != nil {
+		return "", err
+	}
 
-	return req.Status.Token, err
+	return req.Status.Token, nil
 }
 
 func initServiceAccount(user SAInfo) *corev1.ServiceAccount {
@@ -396,6 +468,14 @@ func initCRBinding(objectMeta metav1.ObjectMeta, roleRef rbacv1.RoleRef, subject
 	}
 }
 
+func initRoleBinding(objectMeta metav1.ObjectMeta, roleRef rbacv1.RoleRef, subjects []rbacv1.Subject) *rbacv1.RoleBinding {
+	return &rbacv1.RoleBinding{
+		ObjectMeta: objectMeta,
+		RoleRef:    roleRef,
+		Subjects:   subjects,
+	}
+}
+
 // Clean service account and cluster role
 func (rtc *RuntimeClient) Cleaner() error {
 	if len(rtc.RollbackE.Data) == 0 {
@@ -415,6 +495,8 @@ func (rtc *RuntimeClient) Cleaner() error {
 			go rtc.RetryDeleteClusterRoles(&wg, errorCh)
 		case ClusterRoleBinding:
 			go rtc.RetryDeleteClusterRoleBinding(&wg, errorCh)
+		case RoleBinding:
+			go rtc.RetryDeleteRoleBinding(&wg, errorCh)
 		default:
 			wg.Done()
 		}
@@ -522,3 +604,28 @@ func (rtc *RuntimeClient) RetryDeleteClusterRoleBinding(wg *sync.WaitGroup, erro
 	}
 	log.Infof(fmt.Sprintf("Cluster Role Binding \"%s\" is removed", rtc.User.ClusterRoleName))
 }
+
+func (rtc *RuntimeClient) RetryDeleteRoleBinding(wg *sync.WaitGroup, errorCh chan error) {
+	defer wg.Done()
+
+	err := retry.Do(func() error {
+		err := rtc.K8s.RbacV1().RoleBindings(rtc.User.Namespace).Delete(context.TODO(), rtc.User.RoleBindingName, metav1.DeleteOptions{})
+
+		if err != nil && !apierr.IsNotFound(err) {
+			errorCh <- err
+		} else if apierr.IsNotFound(err) {
+			return nil
+		}
+
+		return errors.Wrapf(err, "Role Binding \"%s\" still exists in \"%s\" Namespace", rtc.User.RoleBindingName, rtc.User.Namespace)
+	},
+		retry.Attempts(20),
+		retry.Delay(15*time.Second),
+		retry.LastErrorOnly(true),
+	)
+	if err != nil {
+		errorCh <- err
+		return
+	}
+	log.Infof(fmt.Sprintf("Role Binding \"%s\" is removed from \"%s\" Namespace", rtc.User.RoleBindingName, rtc.User.Namespace))
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/fake"
@@ -22,7 +23,6 @@ var sa1 = corev1.ServiceAccount{
 
 func NewRuntimeClientTest(kubeConfig []byte, userID string, L2L3OperatiorRole string, tenant string) (*RuntimeClient, error) {
 	clientset := fake.NewSimpleClientset()
-	coreClientset := fake.NewSimpleClientset()
 
 	user := SAInfo{
 		ServiceAccountName:     userID,
@@ -30,11 +30,12 @@ func NewRuntimeClientTest(kubeConfig []byte, userID string, L2L3OperatiorRole st
 		ClusterRoleRulesName:   fmt.Sprintf("%s-rules", userID),
 		ClusterRoleAggrLabel:   fmt.Sprintf("rbac.authorization.k8s.io/aggregate-to-%s", userID),
 		ClusterRoleBindingName: userID,
+		RoleBindingName:        userID,
 		Namespace:              "default",
 		TenantID:               tenant,
 	}
 	rollbackE := RollbackE{}
-	return &RuntimeClient{clientset, coreClientset, user, L2L3OperatiorRole, rollbackE}, nil
+	return &RuntimeClient{clientset, user, L2L3OperatiorRole, rollbackE, time.Hour}, nil
 }
 
 func TestCreateserviceaccount(t *testing.T) {
@@ -150,173 +151,80 @@ func TestCreateserviceaccount(t *testing.T) {
 		assert.Equal(t, expectedTenantID, rtc.User.TenantID)
 	})
 
-	t.Run("If kcp config map is updated with remove method", func(t *testing.T) {
-		var runtimeName = "runtime2"
-		rtc, err := NewRuntimeClientTest([]byte("kubeconfig"), "sa1", "runtimeOperator", "tenantID")
-		assert.NoError(t, err)
-
-		configmap := &corev1.ConfigMap{
-			ObjectMeta: v1.ObjectMeta{
-				Name:        "sa1",
-				Namespace:   "kcp-system",
-				Labels:      map[string]string{"service": "kubeconfig"},
-				Annotations: map[string]string{"role": "runtimeOperator", "tenant": "tenantID"},
-			},
-			Data: map[string]string{"runtime1": "startTime1", "runtime2": "startTime2"},
-		}
-		cm, err := rtc.KcpK8s.CoreV1().ConfigMaps("kcp-system").Create(context.Background(), configmap, v1.CreateOptions{})
-		assert.NoError(t, err)
-		assert.NotNil(t, cm)
-
-		err = rtc.UpdateConfigMap(runtimeName)
-		assert.NoError(t, err)
-
-		cm, err = rtc.KcpK8s.CoreV1().ConfigMaps("kcp-system").Get(context.Background(), "sa1", v1.GetOptions{})
-		assert.NotNil(t, cm)
+	t.Run("If namespace-scoped access is requested a rolebinding is created", func(t *testing.T) {
+		rtc, err := NewRuntimeClientTest([]byte("kubeconfig"), "sa1", "runtimeAdmin", "tenantID")
 		assert.NoError(t, err)
-		assert.Equal(t, "startTime1", cm.Data["runtime1"])
-		assert.Empty(t, cm.Data["runtime2"])
-	})
+		rtc.User.Namespace = "team-a"
+		rtc.User.NamespaceScoped = true
 
-	t.Run("If kcp config map deployed when no user exists", func(t *testing.T) {
-		var runtimeName, namespaceName = "runtime1", "kcp-system"
-		rtc, err := NewRuntimeClientTest([]byte("kubeconfig"), "sa1", "runtimeOperator", "tenantID")
-		assert.NoError(t, err)
+		err = rtc.createRoleBinding()
+		assert.Nil(t, err)
 
-		startTime := time.Now()
-		err = rtc.DeployConfigMap(runtimeName, "runtimeOperator", startTime)
+		rb, err := rtc.K8s.RbacV1().RoleBindings("team-a").Get(context.TODO(), rtc.User.RoleBindingName, v1.GetOptions{})
 		assert.NoError(t, err)
+		assert.Equal(t, "sa1", rb.Name)
+		assert.Equal(t, "ClusterRole", rb.RoleRef.Kind)
+		assert.Equal(t, rtc.User.ClusterRoleName, rb.RoleRef.Name)
+		assert.Equal(t, "team-a", rb.Subjects[0].Namespace)
 
-		cm, err := rtc.KcpK8s.CoreV1().ConfigMaps(namespaceName).Get(context.Background(), "sa1", v1.GetOptions{})
-		assert.NotNil(t, cm)
-		assert.NoError(t, err)
-		assert.Equal(t, expectedSaName, cm.Name)
-		assert.Equal(t, namespaceName, cm.Namespace)
-		assert.Equal(t, "kubeconfig", cm.Labels["service"])
-		assert.Equal(t, "runtimeOperator", cm.Annotations["role"])
-		assert.Equal(t, expectedTenantID, cm.Annotations["tenant"])
-		assert.NotEmpty(t, cm.Data["runtime1"])
+		_, err = rtc.K8s.RbacV1().ClusterRoleBindings().Get(context.TODO(), rtc.User.ClusterRoleBindingName, v1.GetOptions{})
+		assert.True(t, k8serrors.IsNotFound(err))
 	})
 
-	t.Run("If kcp config map deployed when user and runtime existed", func(t *testing.T) {
-		var runtimeName, namespaceName = "runtime1", "kcp-system"
-		rtc, err := NewRuntimeClientTest([]byte("kubeconfig"), "sa1", "runtimeOperator", "tenantID")
+	t.Run("If rolebinding with different subjects exists it is recreated", func(t *testing.T) {
+		rtc, err := NewRuntimeClientTest([]byte("kubeconfig"), "sa1", "runtimeAdmin", "tenantID")
 		assert.NoError(t, err)
+		rtc.User.Namespace = "team-a"
+		rtc.User.NamespaceScoped = true
 
-		configmap := &corev1.ConfigMap{
-			ObjectMeta: v1.ObjectMeta{
-				Name:        "sa1",
-				Namespace:   "kcp-system",
-				Labels:      map[string]string{"service": "kubeconfig"},
-				Annotations: map[string]string{"role": "runtimeOperator", "tenant": "tenantID"},
-			},
-			Data: map[string]string{"runtime1": "startTime1"},
-		}
-		cm, err := rtc.KcpK8s.CoreV1().ConfigMaps("kcp-system").Create(context.Background(), configmap, v1.CreateOptions{})
+		_, err = rtc.K8s.RbacV1().RoleBindings("team-a").Create(context.TODO(), &rbacv1.RoleBinding{
+			ObjectMeta: v1.ObjectMeta{Name: "sa1", Namespace: "team-a"},
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "other"},
+		}, v1.CreateOptions{})
 		assert.NoError(t, err)
-		assert.NotNil(t, cm)
 
-		startTime := time.Now()
-		err = rtc.DeployConfigMap(runtimeName, "runtimeOperator", startTime)
-		assert.NoError(t, err)
+		err = rtc.createRoleBinding()
+		assert.Nil(t, err)
 
-		cm, err = rtc.KcpK8s.CoreV1().ConfigMaps("kcp-system").Get(context.Background(), "sa1", v1.GetOptions{})
-		assert.NotNil(t, cm)
+		rb, err := rtc.K8s.RbacV1().RoleBindings("team-a").Get(context.TODO(), rtc.User.RoleBindingName, v1.GetOptions{})
 		assert.NoError(t, err)
-		assert.Equal(t, expectedSaName, cm.Name)
-		assert.Equal(t, namespaceName, cm.Namespace)
-		assert.Equal(t, "kubeconfig", cm.Labels["service"])
-		assert.Equal(t, "runtimeOperator", cm.Annotations["role"])
-		assert.Equal(t, expectedTenantID, cm.Annotations["tenant"])
-		assert.NotEmpty(t, cm.Data["runtime1"])
-		assert.NotEqual(t, "startTime1", cm.Data["runtime1"])
+		assert.Equal(t, rtc.User.ClusterRoleName, rb.RoleRef.Name)
+		assert.Len(t, rb.Subjects, 1)
 	})
 
-	t.Run("If kcp config map deployed when user existed but runtime not", func(t *testing.T) {
-		var runtimeName, namespaceName = "runtime2", "kcp-system"
-		rtc, err := NewRuntimeClientTest([]byte("kubeconfig"), "sa1", "runtimeOperator", "tenantID")
-		assert.NoError(t, err)
-
-		configmap := &corev1.ConfigMap{
-			ObjectMeta: v1.ObjectMeta{
-				Name:        "sa1",
-				Namespace:   "kcp-system",
-				Labels:      map[string]string{"service": "kubeconfig"},
-				Annotations: map[string]string{"role": "runtimeOperator", "tenant": "tenantID"},
-			},
-			Data: map[string]string{"runtime1": "startTime1"},
-		}
-		cm, err := rtc.KcpK8s.CoreV1().ConfigMaps("kcp-system").Create(context.Background(), configmap, v1.CreateOptions{})
-		assert.NoError(t, err)
-		assert.NotNil(t, cm)
+}
 
-		startTime := time.Now()
-		err = rtc.DeployConfigMap(runtimeName, "runtimeOperator", startTime)
-		assert.NoError(t, err)
+func TestTokenPolicy(t *testing.T) {
+	policy := TokenPolicy{Default: 8 * time.Hour, Max: 24 * time.Hour}
 
-		cm, err = rtc.KcpK8s.CoreV1().ConfigMaps("kcp-system").Get(context.Background(), "sa1", v1.GetOptions{})
-		assert.NotNil(t, cm)
+	t.Run("should use default when no duration is requested", func(t *testing.T) {
+		ttl, err := policy.ResolveTTL("")
 		assert.NoError(t, err)
-		assert.Equal(t, expectedSaName, cm.Name)
-		assert.Equal(t, namespaceName, cm.Namespace)
-		assert.Equal(t, "kubeconfig", cm.Labels["service"])
-		assert.Equal(t, "runtimeOperator", cm.Annotations["role"])
-		assert.Equal(t, expectedTenantID, cm.Annotations["tenant"])
-		assert.Equal(t, "startTime1", cm.Data["runtime1"])
-		assert.NotEmpty(t, cm.Data["runtime2"])
+		assert.Equal(t, 8*time.Hour, ttl)
 	})
 
-	t.Run("If kcp config map deployed when user existed with runtime removed", func(t *testing.T) {
-		var runtimeName, namespaceName = "runtime1", "kcp-system"
-		rtc, err := NewRuntimeClientTest([]byte("kubeconfig"), "sa1", "runtimeOperator", "tenantID")
+	t.Run("should accept duration within policy", func(t *testing.T) {
+		ttl, err := policy.ResolveTTL("30m")
 		assert.NoError(t, err)
+		assert.Equal(t, 30*time.Minute, ttl)
+	})
 
-		configmap := &corev1.ConfigMap{
-			ObjectMeta: v1.ObjectMeta{
-				Name:        "sa1",
-				Namespace:   "kcp-system",
-				Labels:      map[string]string{"service": "kubeconfig"},
-				Annotations: map[string]string{"role": "runtimeOperator", "tenant": "tenantID"},
-			},
-			Data: map[string]string{runtimeName: "startTime1"},
+	t.Run("should reject duration outside of policy", func(t *testing.T) {
+		for _, requested := range []string{"5m", "25h", "-1h", "tomorrow"} {
+			_, err := policy.ResolveTTL(requested)
+			assert.Error(t, err, requested)
 		}
-		cm, err := rtc.KcpK8s.CoreV1().ConfigMaps("kcp-system").Create(context.Background(), configmap, v1.CreateOptions{})
-		assert.NoError(t, err)
-		assert.NotNil(t, cm)
-
-		/*
-			var patches []*JsonPatchType
-			patch := &JsonPatchType{
-				Op:   "remove",
-				Path: "/data/runtime1",
-			}
-			patches = append(patches, patch)
-			payload, err := json.Marshal(patches)
-			assert.NoError(t, err)
-			cm, err = rtc.KcpK8s.CoreV1().ConfigMaps("kcp-system").Patch(context.Background(), "sa1", types.JSONPatchType, payload, metav1.PatchOptions{})
-			assert.NoError(t, err)
-			assert.Empty(t, cm.Data)
-		*/
-
-		err = rtc.UpdateConfigMap(runtimeName)
-		assert.NoError(t, err)
-		_, err = rtc.KcpK8s.CoreV1().ConfigMaps("kcp-system").Get(context.Background(), "sa1", v1.GetOptions{})
-		assert.True(t, k8serrors.IsNotFound(err))
-
-		startTime := time.Now()
-		err = rtc.DeployConfigMap(runtimeName, "runtimeOperator", startTime)
-		assert.NoError(t, err)
+	})
 
-		cm, err = rtc.KcpK8s.CoreV1().ConfigMaps("kcp-system").Get(context.Background(), "sa1", v1.GetOptions{})
-		assert.NotNil(t, cm)
-		assert.NoError(t, err)
-		assert.Equal(t, expectedSaName, cm.Name)
-		assert.Equal(t, namespaceName, cm.Namespace)
-		assert.Equal(t, "kubeconfig", cm.Labels["service"])
-		assert.Equal(t, "runtimeOperator", cm.Annotations["role"])
-		assert.Equal(t, expectedTenantID, cm.Annotations["tenant"])
-		assert.NotEmpty(t, cm.Data["runtime1"])
-		assert.NotEqual(t, "startTime1", cm.Data["runtime1"])
+	t.Run("should validate policy", func(t *testing.T) {
+		assert.NoError(t, policy.Validate())
+		assert.Error(t, TokenPolicy{Default: 48 * time.Hour, Max: 24 * time.Hour}.Validate())
+		assert.Error(t, TokenPolicy{Default: time.Minute, Max: time.Minute}.Validate())
 	})
+}
 
+func TestValidateNamespace(t *testing.T) {
+	assert.NoError(t, ValidateNamespace(""))
+	assert.NoError(t, ValidateNamespace("team-a"))
+	assert.Error(t, ValidateNamespace("Team_A"))
 }
@@ -0,0 +1,64 @@
+package runtime
+
+import (
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// MinTokenTTL is the shortest expiration accepted by the TokenRequest API
+const MinTokenTTL = 10 * time.Minute
+
+// TokenPolicy bounds the lifetime of tokens minted with the TokenRequest API
+type TokenPolicy struct {
+	Default time.Duration
+	Max     time.Duration
+}
+
+// AccessOptions describes the credentials requested for a runtime
+type AccessOptions struct {
+	// TokenTTL is the lifetime of the bound service account token
+	TokenTTL time.Duration
+	// Namespace restricts the granted role to a single namespace, cluster-wide access is granted when empty
+	Namespace string
+}
+
+// ResolveTTL returns the token lifetime for the requested duration, the default is used when nothing is requested
+func (p TokenPolicy) ResolveTTL(requested string) (time.Duration, error) {
+	if requested == "" {
+		return p.Default, nil
+	}
+
+	ttl, err := time.ParseDuration(requested)
+	if err != nil {
+		return 0, fmt.Errorf("invalid token duration %q: %s", requested, err)
+	}
+	if ttl < MinTokenTTL || ttl > p.Max {
+		return 0, fmt.Errorf("token duration %s is not within [%s,%s]", ttl, MinTokenTTL, p.Max)
+	}
+
+	return ttl, nil
+}
+
+// Validate checks that the policy itself is consistent
+func (p TokenPolicy) Validate() error {
+	if p.Max < MinTokenTTL {
+		return fmt.Errorf("maximum token duration %s is lower than %s", p.Max, MinTokenTTL)
+	}
+	if p.Default < MinTokenTTL || p.Default > p.Max {
+		return fmt.Errorf("default token duration %s is not within [%s,%s]", p.Default, MinTokenTTL, p.Max)
+	}
+	return nil
+}
+
+// ValidateNamespace checks that the given namespace is a valid target for namespace-scoped access
+func ValidateNamespace(namespace string) error {
+	if namespace == "" {
+		return nil
+	}
+	if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+		return fmt.Errorf("invalid namespace %q: %v", namespace, errs)
+	}
+	return nil
+}
@@ -23,6 +23,7 @@ import (
 	reconcilerApi "github.com/kyma-incubator/reconciler/pkg/keb"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/director"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/gardener"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/hyperscaler"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/avs"
@@ -35,6 +36,7 @@ import (
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/notification"
 	kebOrchestration "github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/orchestration"
 	orchestrate "github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/orchestration/handlers"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/pipeline"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process/input"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process/provisioning"
@@ -172,7 +174,7 @@ func NewBrokerSuiteTest(t *testing.T, version ...string) *BrokerSuiteTest {
 			URL:                         "http://localhost",
 			DefaultGardenerShootPurpose: "testing",
 			DefaultTrialProvider:        internal.AWS,
-		}, defaultKymaVer, map[string]string{"cf-eu10": "europe", "cf-us10": "us"}, cfg.FreemiumProviders, defaultOIDCValues())
+		}, defaultKymaVer, map[string]string{"cf-eu10": "europe", "cf-us10": "us"}, cfg.FreemiumProviders, defaultOIDCValues(), nil, nil)
 
 	db := storage.NewMemoryStorage()
 
@@ -198,28 +200,30 @@ func NewBrokerSuiteTest(t *testing.T, version ...string) *BrokerSuiteTest {
 	bundleBuilder := ias.NewBundleBuilder(iasFakeClient, cfg.IAS)
 	edpClient := edp.NewFakeClient()
 	accountProvider := fixAccountProvider()
+	customerAccountPool := hyperscaler.NewCustomerAccountPool(gardenerClient, fixedGardenerNamespace)
 	require.NoError(t, err)
 
 	fakeK8sSKRClient := fake.NewClientBuilder().WithScheme(sch).Build()
-	provisionManager := process.NewStagedManager(db.Operations(), eventBroker, cfg.OperationTimeout, logs.WithField("provisioning", "manager"))
-	provisioningQueue := NewProvisioningProcessingQueue(context.Background(), provisionManager, workersAmount, cfg, db, provisionerClient, inputFactory,
+	pipelines := pipeline.NewRegistry(nil)
+	provisionManager := process.NewStagedManager(db.Operations(), eventBroker, cfg.OperationTimeout, nil, logs.WithField("provisioning", "manager"))
+	provisioningQueue := NewProvisioningProcessingQueue(context.Background(), provisionManager, pipelines, workersAmount, cfg, db, provisionerClient, inputFactory,
 		avsDel, internalEvalAssistant, externalEvalCreator, internalEvalUpdater, runtimeVerConfigurator, runtimeOverrides,
-		edpClient, accountProvider, reconcilerClient, fakeK8sClientProvider(fakeK8sSKRClient), cli, logs)
+		edpClient, accountProvider, customerAccountPool, hyperscaler.NewStubCredentialsVerifier(), reconcilerClient, fakeK8sClientProvider(fakeK8sSKRClient), cli, logs)
 
 	provisioningQueue.SpeedUp(10000)
 	provisionManager.SpeedUp(10000)
 
-	updateManager := process.NewStagedManager(db.Operations(), eventBroker, time.Hour, logs)
+	updateManager := process.NewStagedManager(db.Operations(), eventBroker, time.Hour, nil, logs)
 	rvc := runtimeversion.NewRuntimeVersionConfigurator(cfg.KymaVersion, nil, db.RuntimeStates())
-	updateQueue := NewUpdateProcessingQueue(context.Background(), updateManager, 1, db, inputFactory, provisionerClient,
+	updateQueue := NewUpdateProcessingQueue(context.Background(), updateManager, pipelines, 1, db, inputFactory, provisionerClient,
 		eventBroker, rvc, db.RuntimeStates(), decoratedComponentListProvider, reconcilerClient, *cfg, fakeK8sClientProvider(fakeK8sSKRClient), cli, logs)
 	updateQueue.SpeedUp(10000)
 	updateManager.SpeedUp(10000)
 
-	deprovisionManager := process.NewStagedManager(db.Operations(), eventBroker, time.Hour, logs.WithField("deprovisioning", "manager"))
-	deprovisioningQueue := NewDeprovisioningProcessingQueue(ctx, workersAmount, deprovisionManager, cfg, db, eventBroker,
+	deprovisionManager := process.NewStagedManager(db.Operations(), eventBroker, time.Hour, nil, logs.WithField("deprovisioning", "manager"))
+	deprovisioningQueue := NewDeprovisioningProcessingQueue(ctx, workersAmount, deprovisionManager, pipelines, cfg, db, eventBroker, provisioningQueue,
 		provisionerClient, avsDel, internalEvalAssistant, externalEvalAssistant,
-		bundleBuilder, edpClient, accountProvider, reconcilerClient, fakeK8sClientProvider(fakeK8sSKRClient), fakeK8sSKRClient, logs,
+		bundleBuilder, edpClient, accountProvider, customerAccountPool, reconcilerClient, fakeK8sClientProvider(fakeK8sSKRClient), fakeK8sSKRClient, logs,
 	)
 	deprovisionManager.SpeedUp(10000)
 
@@ -245,16 +249,16 @@ func NewBrokerSuiteTest(t *testing.T, version ...string) *BrokerSuiteTest {
 	notificationFakeClient := notification.NewFakeClient()
 	notificationBundleBuilder := notification.NewBundleBuilder(notificationFakeClient, cfg.Notification)
 
-	upgradeEvaluationManager := avs.NewEvaluationManager(avsDel, avs.Config{})
+	upgradeEvaluationManager := avs.NewEvaluationManager(avsDel, avs.Config{}, nil)
 	runtimeLister := kebOrchestration.NewRuntimeLister(db.Instances(), db.Operations(), kebRuntime.NewConverter(defaultRegion), logs)
 	runtimeResolver := orchestration.NewGardenerRuntimeResolver(gardenerClient, fixedGardenerNamespace, runtimeLister, logs)
-	kymaQueue := NewKymaOrchestrationProcessingQueue(ctx, db, runtimeOverrides, provisionerClient, eventBroker, inputFactory, &upgrade_kyma.TimeSchedule{
+	kymaQueue := NewKymaOrchestrationProcessingQueue(ctx, db, pipelines, runtimeOverrides, provisionerClient, eventBroker, nil, inputFactory, &upgrade_kyma.TimeSchedule{
 		Retry:              10 * time.Millisecond,
 		StatusCheck:        100 * time.Millisecond,
 		UpgradeKymaTimeout: 4 * time.Second,
-	}, 250*time.Millisecond, runtimeVerConfigurator, runtimeResolver, upgradeEvaluationManager, cfg, avs.NewInternalEvalAssistant(cfg.Avs), reconcilerClient, notificationBundleBuilder, logs, cli, 1000)
+	}, 250*time.Millisecond, runtimeVerConfigurator, runtimeResolver, upgradeEvaluationManager, cfg, avs.NewInternalEvalAssistant(cfg.Avs, nil), reconcilerClient, notificationBundleBuilder, logs, cli, 1000)
 
-	clusterQueue := NewClusterOrchestrationProcessingQueue(ctx, db, provisionerClient, eventBroker, inputFactory, &upgrade_cluster.TimeSchedule{
+	clusterQueue := NewClusterOrchestrationProcessingQueue(ctx, db, pipelines, provisionerClient, eventBroker, nil, inputFactory, &upgrade_cluster.TimeSchedule{
 		Retry:                 10 * time.Millisecond,
 		StatusCheck:           100 * time.Millisecond,
 		UpgradeClusterTimeout: 4 * time.Second,
@@ -264,7 +268,7 @@ func NewBrokerSuiteTest(t *testing.T, version ...string) *BrokerSuiteTest {
 	clusterQueue.SpeedUp(1000)
 
 	// TODO: in case of cluster upgrade the same Azure Zones must be send to the Provisioner
-	orchestrationHandler := orchestrate.NewOrchestrationHandler(db, kymaQueue, clusterQueue, cfg.MaxPaginationPage, logs)
+	orchestrationHandler := orchestrate.NewOrchestrationHandler(db, kymaQueue, clusterQueue, cfg.MaxPaginationPage, nil, logs)
 	orchestrationHandler.AttachRoutes(ts.router)
 	ts.httpServer = httptest.NewServer(ts.router)
 	return ts
@@ -336,7 +340,7 @@ func (s *BrokerSuiteTest) CreateAPI(inputFactory broker.PlanValidator, cfg *Conf
 	planDefaults := func(planID string, platformProvider internal.CloudProvider, provider *internal.CloudProvider) (*gqlschema.ClusterConfigInput, error) {
 		return &gqlschema.ClusterConfigInput{}, nil
 	}
-	createAPI(s.router, servicesConfig, inputFactory, cfg, db, provisioningQueue, deprovisionQueue, updateQueue, lager.NewLogger("api"), logs, planDefaults)
+	createAPI(s.router, servicesConfig, inputFactory, cfg, db, provisioningQueue, deprovisionQueue, updateQueue, lager.NewLogger("api"), logs, planDefaults, nil, nil, nil, nil)
 
 	s.httpServer = httptest.NewServer(s.router)
 }
@@ -351,8 +355,8 @@ func createFakeAvsDelegator(t *testing.T, db storage.BrokerStorage, cfg *Config)
 	client, err := avs.NewClient(context.TODO(), avsConfig, logrus.New())
 	assert.NoError(t, err)
 	avsDel := avs.NewDelegator(client, avsConfig, db.Operations())
-	externalEvalAssistant := avs.NewExternalEvalAssistant(cfg.Avs)
-	internalEvalAssistant := avs.NewInternalEvalAssistant(cfg.Avs)
+	externalEvalAssistant := avs.NewExternalEvalAssistant(cfg.Avs, nil)
+	internalEvalAssistant := avs.NewInternalEvalAssistant(cfg.Avs, nil)
 	externalEvalCreator := provisioning.NewExternalEvalCreator(avsDel, cfg.Avs.Disabled, externalEvalAssistant)
 	internalEvalUpdater := provisioning.NewInternalEvalUpdater(avsDel, internalEvalAssistant, cfg.Avs)
 
@@ -517,7 +521,7 @@ func (s *BrokerSuiteTest) finishOperatioByOpIDnByProvisioner(operationType gqlsc
 			s.Log(fmt.Sprintf("failed to GetOperationsByID: %v", err))
 			return false, nil
 		}
-		status, err := s.provisionerClient.RuntimeOperationStatus("", op.ProvisionerOperationID)
+		status, err := s.provisionerClient.RuntimeOperationStatus(context.Background(), "", op.ProvisionerOperationID)
 		if err != nil {
 			s.Log(fmt.Sprintf("failed to get RuntimeOperationStatus: %v", err))
 			return false, nil
@@ -538,7 +542,7 @@ func (s *BrokerSuiteTest) MarkClustertConfigurationDeleted(iid string) {
 
 func (s *BrokerSuiteTest) RemoveFromReconcilerByInstanceID(iid string) {
 	op, _ := s.db.Operations().GetDeprovisioningOperationByInstanceID(iid)
-	s.reconcilerClient.DeleteCluster(op.RuntimeID)
+	s.reconcilerClient.DeleteCluster(context.Background(), op.RuntimeID)
 }
 
 func (s *BrokerSuiteTest) FinishProvisioningOperationByReconciler(operationID string) {
@@ -559,7 +563,7 @@ func (s *BrokerSuiteTest) FinishProvisioningOperationByReconciler(operationID st
 
 	var state *reconcilerApi.HTTPClusterResponse
 	err = s.poller.Invoke(func() (bool, error) {
-		state, err = s.reconcilerClient.GetCluster(provisioningOp.RuntimeID, provisioningOp.ClusterConfigurationVersion)
+		state, err = s.reconcilerClient.GetCluster(context.Background(), provisioningOp.RuntimeID, provisioningOp.ClusterConfigurationVersion)
 		if err != nil {
 			return false, err
 		}
@@ -579,7 +583,7 @@ func (s *BrokerSuiteTest) FinishReconciliation(opID string) {
 		if err != nil {
 			return false, nil
 		}
-		state, err = s.reconcilerClient.GetCluster(provisioningOp.RuntimeID, provisioningOp.ClusterConfigurationVersion)
+		state, err = s.reconcilerClient.GetCluster(context.Background(), provisioningOp.RuntimeID, provisioningOp.ClusterConfigurationVersion)
 		if err != nil {
 			return false, nil
 		}
@@ -599,7 +603,7 @@ func (s *BrokerSuiteTest) FinishDeprovisioningByReconciler(opID string) {
 		if err != nil {
 			return false, nil
 		}
-		_, err = s.reconcilerClient.GetCluster(op.RuntimeID, op.ClusterConfigurationVersion)
+		_, err = s.reconcilerClient.GetCluster(context.Background(), op.RuntimeID, op.ClusterConfigurationVersion)
 		if err != nil {
 			return false, err
 		}
@@ -616,7 +620,7 @@ func (s *BrokerSuiteTest) FailDeprovisioningByReconciler(opID string) {
 		if err != nil {
 			return false, nil
 		}
-		_, err = s.reconcilerClient.GetCluster(op.RuntimeID, op.ClusterConfigurationVersion)
+		_, err = s.reconcilerClient.GetCluster(context.Background(), op.RuntimeID, op.ClusterConfigurationVersion)
 		if err != nil {
 			return false, err
 		}
@@ -631,7 +635,7 @@ func (s *BrokerSuiteTest) FinishUpdatingOperationByReconciler(operationID string
 	assert.NoError(s.t, err)
 	var state *reconcilerApi.HTTPClusterResponse
 	err = s.poller.Invoke(func() (bool, error) {
-		state, err = s.reconcilerClient.GetCluster(op.RuntimeID, op.ClusterConfigurationVersion)
+		state, err = s.reconcilerClient.GetCluster(context.Background(), op.RuntimeID, op.ClusterConfigurationVersion)
 		if err != nil {
 			return false, err
 		}
@@ -689,7 +693,7 @@ func (s *BrokerSuiteTest) FinishUpgradeKymaOperationByReconciler(operationID str
 
 	var state *reconcilerApi.HTTPClusterResponse
 	err = s.poller.Invoke(func() (bool, error) {
-		state, err = s.reconcilerClient.GetCluster(upgradeOp.InstanceDetails.RuntimeID, upgradeOp.ClusterConfigurationVersion)
+		state, err = s.reconcilerClient.GetCluster(context.Background(), upgradeOp.InstanceDetails.RuntimeID, upgradeOp.ClusterConfigurationVersion)
 		if err != nil {
 			return false, err
 		}
@@ -734,7 +738,7 @@ func (s *BrokerSuiteTest) AssertReconcilerStartedReconcilingWhenProvisioning(pro
 
 	var state *reconcilerApi.HTTPClusterResponse
 	err = s.poller.Invoke(func() (bool, error) {
-		state, err = s.reconcilerClient.GetCluster(provisioningOp.RuntimeID, 1)
+		state, err = s.reconcilerClient.GetCluster(context.Background(), provisioningOp.RuntimeID, 1)
 		if state.Cluster != "" {
 			return true, nil
 		}
@@ -757,7 +761,7 @@ func (s *BrokerSuiteTest) AssertReconcilerStartedReconcilingWhenUpgrading(opID s
 	var state *reconcilerApi.HTTPClusterResponse
 	err = s.poller.Invoke(func() (bool, error) {
 		fmt.Println(upgradeKymaOp)
-		state, err := s.reconcilerClient.GetCluster(upgradeKymaOp.InstanceDetails.RuntimeID, upgradeKymaOp.InstanceDetails.ClusterConfigurationVersion)
+		state, err := s.reconcilerClient.GetCluster(context.Background(), upgradeKymaOp.InstanceDetails.RuntimeID, upgradeKymaOp.InstanceDetails.ClusterConfigurationVersion)
 		if err != nil {
 			return false, err
 		}
@@ -928,7 +932,7 @@ func (s *BrokerSuiteTest) AssertClusterState(operationID string, expectedState r
 
 	var state *reconcilerApi.HTTPClusterResponse
 	err = s.poller.Invoke(func() (bool, error) {
-		state, err = s.reconcilerClient.GetLatestCluster(provisioningOp.RuntimeID)
+		state, err = s.reconcilerClient.GetLatestCluster(context.Background(), provisioningOp.RuntimeID)
 		if err == nil {
 			return true, nil
 		}
@@ -15,12 +15,15 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"github.com/google/uuid"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/gardener"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/hyperscaler"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/avs"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/edp"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/event"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/fixture"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/ias"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/pipeline"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/provisioner"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
@@ -67,13 +70,13 @@ func NewDeprovisioningSuite(t *testing.T) *DeprovisioningSuite {
 	}
 	client, err := avs.NewClient(context.TODO(), avsConfig, logrus.New())
 	assert.NoError(t, err)
-	_, err = client.CreateEvaluation(&avs.BasicEvaluationCreateRequest{
+	_, err = client.CreateEvaluation(context.Background(), &avs.BasicEvaluationCreateRequest{
 		Name: "fake-evaluation",
 	})
 	assert.NoError(t, err)
 	avsDel := avs.NewDelegator(client, avsConfig, db.Operations())
-	externalEvalAssistant := avs.NewExternalEvalAssistant(cfg.Avs)
-	internalEvalAssistant := avs.NewInternalEvalAssistant(cfg.Avs)
+	externalEvalAssistant := avs.NewExternalEvalAssistant(cfg.Avs, nil)
+	internalEvalAssistant := avs.NewInternalEvalAssistant(cfg.Avs, nil)
 
 	iasFakeClient := ias.NewFakeClient()
 	bundleBuilder := ias.NewBundleBuilder(iasFakeClient, cfg.IAS)
@@ -83,16 +86,17 @@ func NewDeprovisioningSuite(t *testing.T) *DeprovisioningSuite {
 
 	accountProvider := fixAccountProvider()
 
-	deprovisionManager := process.NewStagedManager(db.Operations(), eventBroker, time.Minute, logs.WithField("deprovisioning", "manager"))
+	deprovisionManager := process.NewStagedManager(db.Operations(), eventBroker, time.Minute, nil, logs.WithField("deprovisioning", "manager"))
 	deprovisionManager.SpeedUp(1000)
 	scheme := runtime.NewScheme()
 	apiextensionsv1.AddToScheme(scheme)
 	corev1.AddToScheme(scheme)
 	fakeK8sSKRClient := fake.NewClientBuilder().WithScheme(scheme).Build()
 
-	deprovisioningQueue := NewDeprovisioningProcessingQueue(ctx, workersAmount, deprovisionManager, cfg, db, eventBroker,
+	deprovisioningQueue := NewDeprovisioningProcessingQueue(ctx, workersAmount, deprovisionManager, pipeline.NewRegistry(nil), cfg, db, eventBroker, nil,
 		provisionerClient, avsDel, internalEvalAssistant, externalEvalAssistant,
-		bundleBuilder, edpClient, accountProvider, reconcilerClient, fakeK8sClientProvider(fakeK8sSKRClient), fakeK8sSKRClient, logs,
+		bundleBuilder, edpClient, accountProvider, hyperscaler.NewCustomerAccountPool(gardener.NewDynamicFakeClient(), fixedGardenerNamespace),
+		reconcilerClient, fakeK8sClientProvider(fakeK8sSKRClient), fakeK8sSKRClient, logs,
 	)
 
 	deprovisioningQueue.SpeedUp(10000)
@@ -123,7 +127,7 @@ func (s *DeprovisioningSuite) CreateProvisionedRuntime(options RuntimeOptions) s
 	require.NoError(s.t, s.storage.Instances().Insert(instance))
 	require.NoError(s.t, s.storage.Operations().InsertOperation(provisioningOperation))
 
-	state, err := s.provisionerClient.ProvisionRuntime(options.ProvideGlobalAccountID(), options.ProvideSubAccountID(), gqlschema.ProvisionRuntimeInput{})
+	state, err := s.provisionerClient.ProvisionRuntime(context.Background(), options.ProvideGlobalAccountID(), options.ProvideSubAccountID(), gqlschema.ProvisionRuntimeInput{})
 	require.NoError(s.t, err)
 
 	s.finishProvisioningOperationByProvisioner(gqlschema.OperationTypeProvision, *state.RuntimeID)
@@ -246,7 +250,7 @@ func (s *DeprovisioningSuite) AssertInstanceNotRemoved(instanceId string) {
 
 func fixEDPClient() *edp.FakeClient {
 	client := edp.NewFakeClient()
-	client.CreateDataTenant(edp.DataTenantPayload{
+	client.CreateDataTenant(context.Background(), edp.DataTenantPayload{
 		Name:        subAccountID,
 		Environment: edpEnvironment,
 		Secret:      base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%s%s", subAccountID, edpEnvironment))),
@@ -260,7 +264,7 @@ func fixEDPClient() *edp.FakeClient {
 	}
 
 	for _, key := range metadataTenantKeys {
-		client.CreateMetadataTenant(subAccountID, edpEnvironment, edp.MetadataTenantPayload{
+		client.CreateMetadataTenant(context.Background(), subAccountID, edpEnvironment, edp.MetadataTenantPayload{
 			Key:   key,
 			Value: "-",
 		})
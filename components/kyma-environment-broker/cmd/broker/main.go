@@ -14,6 +14,7 @@ import (
 	"sort"
 	"time"
 
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/cloudprofile"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/euaccess"
 
 	"code.cloudfoundry.org/lager"
@@ -35,26 +36,31 @@ import (
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/events"
 	eventshandler "github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/events/handler"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/health"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/hooks"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/httputil"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/ias"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/kubeconfig"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/metrics"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/middleware"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/notification"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/operation"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/orchestration"
 	orchestrate "github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/orchestration/handlers"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/orchestration/manager"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/pipeline"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/pools"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process/deprovisioning"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process/input"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process/provisioning"
-	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process/steps"
-	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process/update"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process/upgrade_cluster"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process/upgrade_kyma"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/provider"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/provisioner"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/quota"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/reconciler"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/recreation"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/retrypolicy"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/runtime"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/runtime/components"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/runtimeoverrides"
@@ -63,6 +69,8 @@ import (
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbmodel"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/suspension"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/swagger"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/tracing"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/trial"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/sirupsen/logrus"
@@ -132,6 +140,28 @@ type Config struct {
 
 	Broker          broker.Config
 	CatalogFilePath string
+	// PlanDefinitionsFilePath points to the YAML file with plans offered in addition to the built-in ones
+	PlanDefinitionsFilePath string `envconfig:"optional"`
+
+	// CloudProfile configures the plan regions, machine types and zones derived from Gardener CloudProfiles
+	CloudProfile cloudprofile.Config
+
+	// RetryPolicies configures the retry intervals and times of the process steps per step and plan
+	RetryPolicies retrypolicy.Config
+
+	// Hooks configures the external services called before and after the stages of the operations
+	Hooks hooks.Config
+
+	// Tracing configures the export of the operation traces to the OpenTelemetry collector
+	Tracing tracing.Config
+
+	// HyperscalerPools configures the low watermarks of the hyperscaler account pools and the limit of the shared accounts
+	HyperscalerPools pools.Config
+
+	// TrialExpirationPeriod is the lifetime of the trial instances, it must match the expiration period of the trial cleanup job
+	TrialExpirationPeriod time.Duration `envconfig:"default=336h"`
+	// TrialLifecycle configures the expiration warnings, the extension and the grace period of the expirable instances
+	TrialLifecycle trial.Config
 
 	Avs avs.Config
 	IAS ias.Config
@@ -168,6 +198,8 @@ type Config struct {
 	Profiler ProfilerConfig
 
 	Events events.Config
+
+	OperationTimeline operation.TimelineConfig
 }
 
 type ProfilerConfig struct {
@@ -176,13 +208,6 @@ type ProfilerConfig struct {
 	Memory   bool
 }
 
-const (
-	createRuntimeStageName      = "create_runtime"
-	checkKymaStageName          = "check_kyma"
-	createKymaResourceStageName = "create_kyma_resource"
-	startStageName              = "start"
-)
-
 func periodicProfile(logger lager.Logger, profiler ProfilerConfig) {
 	if profiler.Memory == false {
 		return
@@ -218,6 +243,13 @@ func main() {
 	err = checkDefaultVersions(cfg.KymaVersion)
 	panicOnError(err)
 
+	// create the registry of the built-in and the defined plans
+	planDefinitions, err := broker.ReadPlanDefinitionsFromFile(cfg.PlanDefinitionsFilePath)
+	fatalOnError(err)
+	planRegistry, err := broker.NewPlanRegistry(planDefinitions)
+	fatalOnError(err)
+	fatalOnError(planRegistry.ValidateEnabledPlans(cfg.Broker.EnablePlans))
+
 	cfg.OrchestrationConfig.KymaVersion = cfg.KymaVersion
 	cfg.OrchestrationConfig.KubernetesVersion = cfg.Provisioner.KubernetesVersion
 
@@ -237,10 +269,19 @@ func main() {
 	health.NewServer(cfg.Host, cfg.StatusPort, logs).ServeAsync()
 	go periodicProfile(logger, cfg.Profiler)
 
+	shutdownTracing, err := tracing.Init(cfg.Tracing, logs.WithField("service", "tracing"))
+	fatalOnError(err)
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			logs.Errorf("while flushing traces: %s", err)
+		}
+	}()
+
 	// create provisioner client
 	provisionerClient := provisioner.NewProvisionerClient(cfg.Provisioner.URL, cfg.DumpProvisionerRequests)
 
-	reconcilerClient := reconciler.NewReconcilerClient(http.DefaultClient, logs.WithField("service", "reconciler"), &cfg.Reconciler)
+	reconcilerHTTPClient := &http.Client{Transport: tracing.NewTransport(http.DefaultTransport, "reconciler")}
+	reconcilerClient := reconciler.NewReconcilerClient(reconcilerHTTPClient, logs.WithField("service", "reconciler"), &cfg.Reconciler)
 
 	// create kubernetes client
 	k8sCfg, err := config.GetConfig()
@@ -248,6 +289,16 @@ func main() {
 	cli, err := initClient(k8sCfg)
 	fatalOnError(err)
 
+	// configure the retry policies of the process steps, the overrides from the ConfigMap are reloaded periodically
+	retryPolicies, err := retrypolicy.ReadFromFile(cfg.RetryPolicies.FilePath)
+	fatalOnError(err)
+	retryPolicyRegistry := retrypolicy.NewRegistry(retryPolicies, planRegistry.PlanNames())
+	retryPolicyLoader := retrypolicy.NewConfigMapLoader(cli, retryPolicyRegistry, cfg.RetryPolicies, logs)
+	if err := retryPolicyLoader.Load(ctx); err != nil {
+		logs.Errorf("while loading retry policy overrides, the policies are used without overrides until the next reload: %s", err)
+	}
+	go retryPolicyLoader.Run(ctx, cfg.RetryPolicies.RefreshInterval)
+
 	// create storage
 	cipher := storage.NewEncrypter(cfg.Database.SecretKey)
 	var db storage.BrokerStorage
@@ -293,10 +344,26 @@ func main() {
 	dynamicGardener, err := dynamic.NewForConfig(gardenerClusterConfig)
 	fatalOnError(err)
 
+	var planCatalog broker.PlanCatalog
+	var zonesProvider provider.ZonesProvider
+	if cfg.CloudProfile.Enabled {
+		overlay, err := cloudprofile.ReadOverlayFromFile(cfg.CloudProfile.OverlayFilePath)
+		fatalOnError(err)
+		cloudProfileCatalog := cloudprofile.NewCatalog(dynamicGardener, overlay, planRegistry, logs)
+		if err := cloudProfileCatalog.Refresh(ctx); err != nil {
+			logs.Errorf("while reading CloudProfiles, the built-in regions and machine types are used until the next refresh: %s", err)
+		}
+		go cloudProfileCatalog.Run(ctx, cfg.CloudProfile.RefreshInterval)
+		zonesProvider = cloudProfileCatalog
+		planCatalog = cloudProfileCatalog
+	}
+
 	gardenerNamespace := fmt.Sprintf("garden-%v", cfg.Gardener.Project)
 	gardenerAccountPool := hyperscaler.NewAccountPool(dynamicGardener, gardenerNamespace)
-	gardenerSharedPool := hyperscaler.NewSharedGardenerAccountPool(dynamicGardener, gardenerNamespace)
+	gardenerSharedPool := hyperscaler.NewSharedGardenerAccountPoolWithLimit(dynamicGardener, gardenerNamespace, cfg.HyperscalerPools.MaxShootsPerSharedAccount)
 	accountProvider := hyperscaler.NewAccountProvider(gardenerAccountPool, gardenerSharedPool)
+	customerAccountPool := hyperscaler.NewCustomerAccountPool(dynamicGardener, gardenerNamespace)
+	credentialsVerifier := hyperscaler.NewProviderCredentialsVerifier()
 
 	regions, err := provider.ReadPlatformRegionMappingFromFile(cfg.TrialRegionMappingFilePath)
 	fatalOnError(err)
@@ -305,7 +372,7 @@ func main() {
 	oidcDefaultValues, err := runtime.ReadOIDCDefaultValuesFromYAML(cfg.SkrOidcDefaultValuesYAMLFilePath)
 	fatalOnError(err)
 	inputFactory, err := input.NewInputBuilderFactory(optComponentsSvc, disabledComponentsProvider, componentsProvider,
-		configProvider, cfg.Provisioner, cfg.KymaVersion, regions, cfg.FreemiumProviders, oidcDefaultValues)
+		configProvider, cfg.Provisioner, cfg.KymaVersion, regions, cfg.FreemiumProviders, oidcDefaultValues, zonesProvider, planRegistry)
 	fatalOnError(err)
 
 	edpClient := edp.NewClient(cfg.EDP, logs.WithField("service", "edpClient"))
@@ -313,17 +380,18 @@ func main() {
 	avsClient, err := avs.NewClient(ctx, cfg.Avs, logs)
 	fatalOnError(err)
 	avsDel := avs.NewDelegator(avsClient, cfg.Avs, db.Operations())
-	externalEvalAssistant := avs.NewExternalEvalAssistant(cfg.Avs)
-	internalEvalAssistant := avs.NewInternalEvalAssistant(cfg.Avs)
+	externalEvalAssistant := avs.NewExternalEvalAssistant(cfg.Avs, planRegistry)
+	internalEvalAssistant := avs.NewInternalEvalAssistant(cfg.Avs, planRegistry)
 	externalEvalCreator := provisioning.NewExternalEvalCreator(avsDel, cfg.Avs.Disabled, externalEvalAssistant)
 	internalEvalUpdater := provisioning.NewInternalEvalUpdater(avsDel, internalEvalAssistant, cfg.Avs)
-	upgradeEvalManager := avs.NewEvaluationManager(avsDel, cfg.Avs)
+	upgradeEvalManager := avs.NewEvaluationManager(avsDel, cfg.Avs, planRegistry)
 
 	// IAS
 	clientHTTPForIAS := httputil.NewClient(60, cfg.IAS.SkipCertVerification)
 	if cfg.IAS.TLSRenegotiationEnable {
 		clientHTTPForIAS = httputil.NewRenegotiationTLSClient(30, cfg.IAS.SkipCertVerification)
 	}
+	clientHTTPForIAS.Transport = tracing.NewTransport(clientHTTPForIAS.Transport, "ias")
 	iasClient := ias.NewClient(clientHTTPForIAS, ias.ClientConfig{
 		URL:    cfg.IAS.URL,
 		ID:     cfg.IAS.UserID,
@@ -334,8 +402,15 @@ func main() {
 	// application event broker
 	eventBroker := event.NewPubSub(logs)
 
+	// records the runs of the steps exposed as the operation timeline
+	eventBroker.Subscribe(process.OperationStepProcessed{}, operation.NewTimelineRecorder(db.OperationSteps(), logs).OnOperationStepProcessed)
+	go operation.RunTimelineCleanup(ctx, db.OperationSteps(), cfg.OperationTimeline, logs)
+
 	// metrics collectors
 	metrics.RegisterAll(eventBroker, db.Operations(), db.Instances())
+	poolsReporter := pools.NewReporter(hyperscaler.NewPoolManager(dynamicGardener, gardenerNamespace), cfg.HyperscalerPools, logs)
+	go poolsReporter.Run(ctx, cfg.HyperscalerPools.RefreshInterval)
+	prometheus.MustRegister(metrics.NewHyperscalerPoolsCollector(poolsReporter))
 	metrics.StartOpsMetricService(ctx, db.Operations(), logs)
 	//setup runtime overrides appender
 	runtimeOverrides := runtimeoverrides.NewRuntimeOverrides(ctx, cli)
@@ -346,18 +421,20 @@ func main() {
 
 	// run queues
 	const workersAmount = 5
-	provisionManager := process.NewStagedManager(db.Operations(), eventBroker, cfg.OperationTimeout, logs.WithField("provisioning", "manager"))
-	provisionQueue := NewProvisioningProcessingQueue(ctx, provisionManager, 60, &cfg, db, provisionerClient, inputFactory,
+	// the pipelines of the operations are validated when they are registered
+	pipelines := pipeline.NewRegistry(planRegistry)
+	provisionManager := process.NewStagedManager(db.Operations(), eventBroker, cfg.OperationTimeout, retryPolicyRegistry, logs.WithField("provisioning", "manager"))
+	provisionQueue := NewProvisioningProcessingQueue(ctx, provisionManager, pipelines, 60, &cfg, db, provisionerClient, inputFactory,
 		avsDel, internalEvalAssistant, externalEvalCreator, internalEvalUpdater, runtimeVerConfigurator,
-		runtimeOverrides, edpClient, accountProvider, reconcilerClient, k8sClientProvider, cli, logs)
+		runtimeOverrides, edpClient, accountProvider, customerAccountPool, credentialsVerifier, reconcilerClient, k8sClientProvider, cli, logs)
 
-	deprovisionManager := process.NewStagedManager(db.Operations(), eventBroker, cfg.OperationTimeout, logs.WithField("deprovisioning", "manager"))
-	deprovisionQueue := NewDeprovisioningProcessingQueue(ctx, workersAmount, deprovisionManager, &cfg, db, eventBroker, provisionerClient,
-		avsDel, internalEvalAssistant, externalEvalAssistant, bundleBuilder, edpClient, accountProvider, reconcilerClient,
+	deprovisionManager := process.NewStagedManager(db.Operations(), eventBroker, cfg.OperationTimeout, retryPolicyRegistry, logs.WithField("deprovisioning", "manager"))
+	deprovisionQueue := NewDeprovisioningProcessingQueue(ctx, workersAmount, deprovisionManager, pipelines, &cfg, db, eventBroker, provisionQueue, provisionerClient,
+		avsDel, internalEvalAssistant, externalEvalAssistant, bundleBuilder, edpClient, accountProvider, customerAccountPool, reconcilerClient,
 		k8sClientProvider, cli, logs)
 
-	updateManager := process.NewStagedManager(db.Operations(), eventBroker, cfg.OperationTimeout, logs.WithField("update", "manager"))
-	updateQueue := NewUpdateProcessingQueue(ctx, updateManager, 20, db, inputFactory, provisionerClient, eventBroker,
+	updateManager := process.NewStagedManager(db.Operations(), eventBroker, cfg.OperationTimeout, retryPolicyRegistry, logs.WithField("update", "manager"))
+	updateQueue := NewUpdateProcessingQueue(ctx, updateManager, pipelines, 20, db, inputFactory, provisionerClient, eventBroker,
 		runtimeVerConfigurator, db.RuntimeStates(), componentsProvider, reconcilerClient, cfg, k8sClientProvider, cli, logs)
 
 	/***/
@@ -367,25 +444,29 @@ func main() {
 	// create server
 	router := mux.NewRouter()
 
-	createAPI(router, servicesConfig, inputFactory, &cfg, db, provisionQueue, deprovisionQueue, updateQueue, logger, logs, inputFactory.GetPlanDefaults)
+	quotaChecker := quota.NewChecker(db.Quotas(), db.Instances(), inputFactory.GetPlanDefaults, planRegistry, logs)
+	createAPI(router, servicesConfig, inputFactory, &cfg, db, provisionQueue, deprovisionQueue, updateQueue, logger, logs, inputFactory.GetPlanDefaults, planCatalog, quotaChecker,
+		provisioning.NewCredentialsValidator(accountProvider, inputFactory.GetPlanProvider, credentialsVerifier), planRegistry)
 
 	// create metrics endpoint
 	router.Handle("/metrics", promhttp.Handler())
 
+	trialPolicy := trial.NewPolicy(cfg.TrialLifecycle, trial.ExpirationPeriods(cfg.TrialExpirationPeriod, planRegistry.Definitions()), planRegistry.InactivityExpiryPeriods())
+
 	// create SKR kubeconfig endpoint
 	kcBuilder := kubeconfig.NewBuilder(provisionerClient)
-	kcHandler := kubeconfig.NewHandler(db, kcBuilder, cfg.Kubeconfig.AllowOrigins, logs.WithField("service", "kubeconfigHandle"))
+	kcHandler := kubeconfig.NewHandler(db, kcBuilder, cfg.Kubeconfig.AllowOrigins, trial.NewActivityRecorder(db.TrialEvents(), trialPolicy, logs), logs.WithField("service", "kubeconfigHandle"))
 	kcHandler.AttachRoutes(router)
 
 	runtimeLister := orchestration.NewRuntimeLister(db.Instances(), db.Operations(), runtime.NewConverter(cfg.DefaultRequestRegion), logs)
 	runtimeResolver := orchestrationExt.NewGardenerRuntimeResolver(dynamicGardener, gardenerNamespace, runtimeLister, logs)
 
-	kymaQueue := NewKymaOrchestrationProcessingQueue(ctx, db, runtimeOverrides, provisionerClient, eventBroker, inputFactory, nil, time.Minute, runtimeVerConfigurator, runtimeResolver, upgradeEvalManager, &cfg, internalEvalAssistant, reconcilerClient, notificationBuilder, logs, cli, 1)
-	clusterQueue := NewClusterOrchestrationProcessingQueue(ctx, db, provisionerClient, eventBroker, inputFactory,
+	kymaQueue := NewKymaOrchestrationProcessingQueue(ctx, db, pipelines, runtimeOverrides, provisionerClient, eventBroker, retryPolicyRegistry, inputFactory, nil, time.Minute, runtimeVerConfigurator, runtimeResolver, upgradeEvalManager, &cfg, internalEvalAssistant, reconcilerClient, notificationBuilder, logs, cli, 1)
+	clusterQueue := NewClusterOrchestrationProcessingQueue(ctx, db, pipelines, provisionerClient, eventBroker, retryPolicyRegistry, inputFactory,
 		nil, time.Minute, runtimeResolver, upgradeEvalManager, notificationBuilder, logs, cli, cfg, 1)
 
 	// TODO: in case of cluster upgrade the same Azure Zones must be send to the Provisioner
-	orchestrationHandler := orchestrate.NewOrchestrationHandler(db, kymaQueue, clusterQueue, cfg.MaxPaginationPage, logs)
+	orchestrationHandler := orchestrate.NewOrchestrationHandler(db, kymaQueue, clusterQueue, cfg.MaxPaginationPage, planRegistry, logs)
 
 	if !cfg.DisableProcessOperationsInProgress {
 		err = processOperationsInProgressByType(internal.OperationTypeProvision, db.Operations(), provisionQueue, logs)
@@ -413,9 +494,32 @@ func main() {
 	orchestrationHandler.AttachRoutes(router)
 
 	// create list runtimes endpoint
-	runtimeHandler := runtime.NewHandler(db.Instances(), db.Operations(), db.RuntimeStates(), cfg.MaxPaginationPage, cfg.DefaultRequestRegion)
+	runtimeHandler := runtime.NewHandler(db.Instances(), db.Operations(), db.RuntimeStates(), db.TrialEvents(), trialPolicy, cfg.MaxPaginationPage, cfg.DefaultRequestRegion)
 	runtimeHandler.AttachRoutes(router)
 
+	// create /trials
+	trial.NewHandler(db.Instances(), db.TrialEvents(), trialPolicy, logs).AttachRoutes(router)
+
+	// create /quotas
+	quotaHandler := quota.NewHandler(db.Quotas(), quotaChecker, logs)
+	quotaHandler.AttachRoutes(router)
+
+	// create /hyperscaler-pools
+	pools.NewHandler(poolsReporter, logs).AttachRoutes(router)
+
+	// create /operations admin API
+	operation.NewHandler(db.Operations(), db.OperationSteps(), map[internal.OperationType]operation.Retrier{
+		internal.OperationTypeProvision:   process.NewRetrier(provisionQueue, provisionManager),
+		internal.OperationTypeDeprovision: process.NewRetrier(deprovisionQueue, deprovisionManager),
+		internal.OperationTypeUpdate:      process.NewRetrier(updateQueue, updateManager),
+	}, logs).AttachRoutes(router)
+
+	// create /pipelines admin API
+	pipeline.NewHandler(pipelines).AttachRoutes(router)
+
+	// create /recreations admin API
+	recreation.NewHandler(db.Instances(), db.Operations(), deprovisionQueue, logs).AttachRoutes(router)
+
 	router.StrictSlash(true).PathPrefix("/").Handler(http.StripPrefix("/", http.FileServer(http.Dir("/swagger"))))
 	svr := handlers.CustomLoggingHandler(os.Stdout, router, func(writer io.Writer, params handlers.LogFormatterParams) {
 		logs.Infof("Call handled: method=%s url=%s statusCode=%d size=%d", params.Request.Method, params.URL.Path, params.StatusCode, params.Size)
@@ -453,8 +557,8 @@ func isVersionFollowingSemanticVersioning(version string) bool {
 	return false
 }
 
-func createAPI(router *mux.Router, servicesConfig broker.ServicesConfig, planValidator broker.PlanValidator, cfg *Config, db storage.BrokerStorage, provisionQueue, deprovisionQueue, updateQueue *process.Queue, logger lager.Logger, logs logrus.FieldLogger, planDefaults broker.PlanDefaults) {
-	suspensionCtxHandler := suspension.NewContextUpdateHandler(db.Operations(), provisionQueue, deprovisionQueue, logs)
+func createAPI(router *mux.Router, servicesConfig broker.ServicesConfig, planValidator broker.PlanValidator, cfg *Config, db storage.BrokerStorage, provisionQueue, deprovisionQueue, updateQueue *process.Queue, logger lager.Logger, logs logrus.FieldLogger, planDefaults broker.PlanDefaults, planCatalog broker.PlanCatalog, quotaChecker broker.QuotaChecker, credentialsValidator broker.CredentialsValidator, planRegistry *broker.PlanRegistry) {
+	suspensionCtxHandler := suspension.NewContextUpdateHandler(db.Operations(), provisionQueue, deprovisionQueue, planRegistry, logs)
 
 	defaultPlansConfig, err := servicesConfig.DefaultPlansConfig()
 	fatalOnError(err)
@@ -472,15 +576,16 @@ func createAPI(router *mux.Router, servicesConfig broker.ServicesConfig, planVal
 	logs.Infof("Number of globalAccountIds for EU Access: %d\n", len(whitelistedGlobalAccountIds))
 
 	// create KymaEnvironmentBroker endpoints
+	provisionEndpoint := broker.NewProvision(cfg.Broker, cfg.Gardener, db.Operations(), db.Instances(),
+		provisionQueue, planValidator, defaultPlansConfig, cfg.EnableOnDemandVersion,
+		planDefaults, planCatalog, planRegistry, quotaChecker, whitelistedGlobalAccountIds, cfg.EuAccessRejectionMessage, logs, cfg.KymaDashboardConfig)
 	kymaEnvBroker := &broker.KymaEnvironmentBroker{
-		broker.NewServices(cfg.Broker, servicesConfig, logs),
-		broker.NewProvision(cfg.Broker, cfg.Gardener, db.Operations(), db.Instances(),
-			provisionQueue, planValidator, defaultPlansConfig, cfg.EnableOnDemandVersion,
-			planDefaults, whitelistedGlobalAccountIds, cfg.EuAccessRejectionMessage, logs, cfg.KymaDashboardConfig),
+		broker.NewServices(cfg.Broker, servicesConfig, planCatalog, planRegistry, logs),
+		provisionEndpoint,
 		broker.NewDeprovision(db.Instances(), db.Operations(), deprovisionQueue, logs),
 		broker.NewUpdate(cfg.Broker, db.Instances(), db.RuntimeStates(), db.Operations(),
 			suspensionCtxHandler, cfg.UpdateProcessingEnabled, cfg.UpdateSubAccountMovementEnabled, updateQueue,
-			planDefaults, logs, cfg.KymaDashboardConfig),
+			planDefaults, planRegistry, quotaChecker, logs, cfg.KymaDashboardConfig),
 		broker.NewGetInstance(cfg.Broker, db.Instances(), db.Operations(), logs),
 		broker.NewLastOperation(db.Operations(), logs),
 		broker.NewBind(logs),
@@ -497,10 +602,12 @@ func createAPI(router *mux.Router, servicesConfig broker.ServicesConfig, planVal
 	} {
 		route := router.PathPrefix(prefix).Subrouter()
 		broker.AttachRoutes(route, kymaEnvBroker, logger)
+		// dry-run of the provisioning
+		broker.NewProvisionValidationHandler(provisionEndpoint, credentialsValidator, logs).AttachRoutes(route)
 	}
 
 	respWriter := httputil.NewResponseWriter(logs, cfg.DevelopmentMode)
-	runtimesInfoHandler := appinfo.NewRuntimeInfoHandler(db.Instances(), db.Operations(), defaultPlansConfig, cfg.DefaultRequestRegion, respWriter)
+	runtimesInfoHandler := appinfo.NewRuntimeInfoHandler(db.Instances(), db.Operations(), defaultPlansConfig, planRegistry, cfg.DefaultRequestRegion, respWriter)
 	router.Handle("/info/runtimes", runtimesInfoHandler)
 	router.Handle("/events", eventshandler.NewHandler(db.Events(), db.Instances()))
 }
@@ -611,6 +718,18 @@ func initClient(cfg *rest.Config) (client.Client, error) {
 	return cli, nil
 }
 
+// registerPipeline adds the hooks configured for the operation type to the pipeline, validates it and adds it to the registry
+func registerPipeline(pipelines *pipeline.Registry, p *pipeline.Pipeline, cfg hooks.Config, db storage.BrokerStorage) error {
+	configured, err := hooks.ReadFromFile(cfg.FilePath)
+	if err != nil {
+		return err
+	}
+	if err := hooks.NewRegistrar(configured, db.Operations(), db.Instances(), hooks.NewCaller(), pipelines.PlanRegistry().PlanNames()).Register(p, p.OperationType); err != nil {
+		return err
+	}
+	return pipelines.Register(p)
+}
+
 func fatalOnError(err error) {
 	if err != nil {
 		log.Fatal(err)
@@ -623,138 +742,34 @@ func panicOnError(err error) {
 	}
 }
 
-func NewProvisioningProcessingQueue(ctx context.Context, provisionManager *process.StagedManager, workersAmount int, cfg *Config,
+func NewProvisioningProcessingQueue(ctx context.Context, provisionManager *process.StagedManager, pipelines *pipeline.Registry, workersAmount int, cfg *Config,
 	db storage.BrokerStorage, provisionerClient provisioner.Client, inputFactory input.CreatorForPlan, avsDel *avs.Delegator,
 	internalEvalAssistant *avs.InternalEvalAssistant, externalEvalCreator *provisioning.ExternalEvalCreator,
 	internalEvalUpdater *provisioning.InternalEvalUpdater, runtimeVerConfigurator *runtimeversion.RuntimeVersionConfigurator,
 	runtimeOverrides provisioning.RuntimeOverridesAppender, edpClient provisioning.EDPClient, accountProvider hyperscaler.AccountProvider,
-	reconcilerClient reconciler.Client, k8sClientProvider func(kcfg string) (client.Client, error), cli client.Client, logs logrus.FieldLogger) *process.Queue {
-
-	const postActionsStageName = "post_actions"
-	provisionManager.DefineStages([]string{startStageName, createRuntimeStageName,
-		checkKymaStageName, createKymaResourceStageName, postActionsStageName})
-	/*
-			The provisioning process contains the following stages:
-			1. "start" - changes the state from pending to in progress if no deprovisioning is ongoing.
-			2. "create_runtime" - collects all information needed to make an input for the Provisioner request as overrides and labels.
-			Those data is collected using an InputCreator which is not persisted. That's why all steps which prepares such data must be in the same stage as "create runtime step".
-		    All steps which requires InputCreator must be run in this stage.
-			3. "check_kyma" - checks if the Kyma is installed
-			4. "post_actions" - all steps which must be executed after the runtime is provisioned
-
-			Once the stage is done it will never be retried.
-	*/
-
-	provisioningSteps := []struct {
-		disabled  bool
-		stage     string
-		step      process.Step
-		condition process.StepCondition
-	}{
-		{
-			stage: startStageName,
-			step:  provisioning.NewStartStep(db.Operations(), db.Instances()),
-		},
-		{
-			stage: createRuntimeStageName,
-			step:  provisioning.NewInitialisationStep(db.Operations(), db.Instances(), inputFactory, runtimeVerConfigurator),
-		},
-		{
-			stage: createRuntimeStageName,
-			step:  steps.NewInitKymaTemplate(db.Operations()),
-		},
-		{
-			stage:     createRuntimeStageName,
-			step:      provisioning.NewResolveCredentialsStep(db.Operations(), accountProvider),
-			condition: provisioning.SkipForOwnClusterPlan,
-		},
-		{
-			stage:    createRuntimeStageName,
-			step:     provisioning.NewInternalEvaluationStep(avsDel, internalEvalAssistant),
-			disabled: cfg.Avs.Disabled,
-		},
-		{
-			stage:     createRuntimeStageName,
-			step:      provisioning.NewEDPRegistrationStep(db.Operations(), edpClient, cfg.EDP),
-			disabled:  cfg.EDP.Disabled,
-			condition: provisioning.SkipForOwnClusterPlan,
-		},
-		{
-			stage: createRuntimeStageName,
-			step:  provisioning.NewOverridesFromSecretsAndConfigStep(db.Operations(), runtimeOverrides, runtimeVerConfigurator),
-			// Preview plan does not call Reconciler so it does not need overrides
-			condition: skipForPreviewPlan,
-		},
-		{
-			condition: provisioning.WhenBTPOperatorCredentialsProvided,
-			stage:     createRuntimeStageName,
-			step:      provisioning.NewBTPOperatorOverridesStep(db.Operations()),
-		},
-		{
-			condition: provisioning.SkipForOwnClusterPlan,
-			stage:     createRuntimeStageName,
-			step:      provisioning.NewCreateRuntimeWithoutKymaStep(db.Operations(), db.RuntimeStates(), db.Instances(), provisionerClient),
-		},
-		{
-			condition: provisioning.DoForOwnClusterPlanOnly,
-			stage:     createRuntimeStageName,
-			step:      provisioning.NewCreateRuntimeForOwnClusterStep(db.Operations(), db.Instances()),
-		},
-		{
-			stage:     createRuntimeStageName,
-			step:      provisioning.NewCheckRuntimeStep(db.Operations(), provisionerClient, cfg.Provisioner.ProvisioningTimeout),
-			condition: provisioning.SkipForOwnClusterPlan,
-		},
-		{
-			stage: createRuntimeStageName,
-			step:  provisioning.NewGetKubeconfigStep(db.Operations(), provisionerClient, k8sClientProvider),
-		},
-		{
-			condition: provisioning.WhenBTPOperatorCredentialsProvided,
-			stage:     createRuntimeStageName,
-			step:      provisioning.NewInjectBTPOperatorCredentialsStep(db.Operations(), k8sClientProvider),
-		},
-		{
-			disabled: cfg.LifecycleManagerIntegrationDisabled,
-			stage:    createRuntimeStageName,
-			step:     steps.SyncKubeconfig(db.Operations(), cli),
-		},
-		{
-			disabled:  cfg.ReconcilerIntegrationDisabled,
-			stage:     createRuntimeStageName,
-			step:      provisioning.NewCreateClusterConfiguration(db.Operations(), db.RuntimeStates(), reconcilerClient),
-			condition: skipForPreviewPlan,
-		},
-		{
-			disabled:  cfg.ReconcilerIntegrationDisabled,
-			stage:     checkKymaStageName,
-			step:      provisioning.NewCheckClusterConfigurationStep(db.Operations(), reconcilerClient, cfg.Reconciler.ProvisioningTimeout),
-			condition: skipForPreviewPlan,
-		},
-		{
-			disabled: cfg.LifecycleManagerIntegrationDisabled,
-			stage:    createKymaResourceStageName,
-			step:     provisioning.NewApplyKymaStep(db.Operations(), cli),
-		},
-		// post actions
-		{
-			stage: postActionsStageName,
-			step:  provisioning.NewExternalEvalStep(externalEvalCreator),
-		},
-		{
-			stage:     postActionsStageName,
-			step:      provisioning.NewRuntimeTagsStep(internalEvalUpdater, provisionerClient),
-			condition: provisioning.SkipForOwnClusterPlan,
-		},
-	}
-	for _, step := range provisioningSteps {
-		if !step.disabled {
-			err := provisionManager.AddStep(step.stage, step.step, step.condition)
-			if err != nil {
-				fatalOnError(err)
-			}
-		}
-	}
+	customerAccountPool hyperscaler.CustomerAccountPool, credentialsVerifier hyperscaler.CredentialsVerifier, reconcilerClient reconciler.Client, k8sClientProvider func(kcfg string) (client.Client, error), cli client.Client, logs logrus.FieldLogger) *process.Queue {
+
+	provisioningPipeline := pipeline.NewProvisioningPipeline(pipelineConfig(cfg), pipeline.Dependencies{
+		DB:                     db,
+		ProvisionerClient:      provisionerClient,
+		ReconcilerClient:       reconcilerClient,
+		EDPClient:              edpClient,
+		InputFactory:           inputFactory,
+		RuntimeVerConfigurator: runtimeVerConfigurator,
+		RuntimeOverrides:       runtimeOverrides,
+		PlanRegistry:           pipelines.PlanRegistry(),
+		AvsDelegator:           avsDel,
+		InternalEvalAssistant:  internalEvalAssistant,
+		ExternalEvalCreator:    externalEvalCreator,
+		InternalEvalUpdater:    internalEvalUpdater,
+		AccountProvider:        accountProvider,
+		CustomerAccountPool:    customerAccountPool,
+		CredentialsVerifier:    credentialsVerifier,
+		KcpClient:              cli,
+		K8sClientProvider:      k8sClientProvider,
+	})
+	fatalOnError(registerPipeline(pipelines, provisioningPipeline, cfg.Hooks, db))
+	fatalOnError(provisioningPipeline.Apply(provisionManager))
 
 	queue := process.NewQueue(provisionManager, logs)
 	queue.Run(ctx.Done(), workersAmount)
@@ -762,145 +777,54 @@ func NewProvisioningProcessingQueue(ctx context.Context, provisionManager *proce
 	return queue
 }
 
-func NewUpdateProcessingQueue(ctx context.Context, manager *process.StagedManager, workersAmount int, db storage.BrokerStorage, inputFactory input.CreatorForPlan,
+func NewUpdateProcessingQueue(ctx context.Context, manager *process.StagedManager, pipelines *pipeline.Registry, workersAmount int, db storage.BrokerStorage, inputFactory input.CreatorForPlan,
 	provisionerClient provisioner.Client, publisher event.Publisher, runtimeVerConfigurator *runtimeversion.RuntimeVersionConfigurator, runtimeStatesDb storage.RuntimeStates,
 	runtimeProvider input.ComponentListProvider, reconcilerClient reconciler.Client, cfg Config, k8sClientProvider func(kcfg string) (client.Client, error), cli client.Client, logs logrus.FieldLogger) *process.Queue {
 
-	requiresReconcilerUpdate := update.RequiresReconcilerUpdate
-	if cfg.ReconcilerIntegrationDisabled {
-		requiresReconcilerUpdate = func(op internal.Operation) bool { return false }
-	}
-	manager.DefineStages([]string{"cluster", "btp-operator", "btp-operator-check", "check"})
-	updateSteps := []struct {
-		stage     string
-		step      process.Step
-		condition process.StepCondition
-	}{
-		{
-			stage: "cluster",
-			step:  update.NewInitialisationStep(db.Instances(), db.Operations(), runtimeVerConfigurator, inputFactory),
-		},
-		{
-			stage:     "cluster",
-			step:      update.NewUpgradeShootStep(db.Operations(), db.RuntimeStates(), provisionerClient),
-			condition: update.SkipForOwnClusterPlan,
-		},
-		{
-			stage: "btp-operator",
-			step:  update.NewInitKymaVersionStep(db.Operations(), runtimeVerConfigurator, runtimeStatesDb),
-		},
-		{
-			stage:     "btp-operator",
-			step:      update.NewGetKubeconfigStep(db.Operations(), provisionerClient, k8sClientProvider),
-			condition: update.ForBTPOperatorCredentialsProvided,
-		},
-		{
-			stage:     "btp-operator",
-			step:      update.NewBTPOperatorOverridesStep(db.Operations(), runtimeProvider),
-			condition: update.RequiresBTPOperatorCredentials,
-		},
-		{
-			stage:     "btp-operator",
-			step:      update.NewApplyReconcilerConfigurationStep(db.Operations(), db.RuntimeStates(), reconcilerClient),
-			condition: requiresReconcilerUpdate,
-		},
-		{
-			stage:     "btp-operator-check",
-			step:      update.NewCheckReconcilerState(db.Operations(), reconcilerClient),
-			condition: update.CheckReconcilerStatus,
-		},
-		{
-			stage:     "check",
-			step:      update.NewCheckStep(db.Operations(), provisionerClient, 40*time.Minute),
-			condition: update.SkipForOwnClusterPlan,
-		},
-	}
+	updatePipeline := pipeline.NewUpdatePipeline(pipelineConfig(&cfg), pipeline.Dependencies{
+		DB:                     db,
+		ProvisionerClient:      provisionerClient,
+		ReconcilerClient:       reconcilerClient,
+		InputFactory:           inputFactory,
+		ComponentListProvider:  runtimeProvider,
+		RuntimeVerConfigurator: runtimeVerConfigurator,
+		KcpClient:              cli,
+		K8sClientProvider:      k8sClientProvider,
+	})
+	fatalOnError(registerPipeline(pipelines, updatePipeline, cfg.Hooks, db))
+	fatalOnError(updatePipeline.Apply(manager))
 
-	for _, step := range updateSteps {
-		err := manager.AddStep(step.stage, step.step, step.condition)
-		if err != nil {
-			fatalOnError(err)
-		}
-	}
 	queue := process.NewQueue(manager, logs)
 	queue.Run(ctx.Done(), workersAmount)
 
 	return queue
 }
 
-func NewDeprovisioningProcessingQueue(ctx context.Context, workersAmount int, deprovisionManager *process.StagedManager,
-	cfg *Config, db storage.BrokerStorage, pub event.Publisher,
+func NewDeprovisioningProcessingQueue(ctx context.Context, workersAmount int, deprovisionManager *process.StagedManager, pipelines *pipeline.Registry,
+	cfg *Config, db storage.BrokerStorage, pub event.Publisher, provisioningQueue deprovisioning.Queue,
 	provisionerClient provisioner.Client, avsDel *avs.Delegator, internalEvalAssistant *avs.InternalEvalAssistant,
 	externalEvalAssistant *avs.ExternalEvalAssistant, bundleBuilder ias.BundleBuilder,
-	edpClient deprovisioning.EDPClient, accountProvider hyperscaler.AccountProvider, reconcilerClient reconciler.Client,
+	edpClient provisioning.EDPClient, accountProvider hyperscaler.AccountProvider, customerAccountPool hyperscaler.CustomerAccountPool, reconcilerClient reconciler.Client,
 	k8sClientProvider func(kcfg string) (client.Client, error), cli client.Client, logs logrus.FieldLogger) *process.Queue {
 
-	deprovisioningSteps := []struct {
-		disabled bool
-		step     process.Step
-	}{
-		{
-			step: deprovisioning.NewInitStep(db.Operations(), db.Instances(), 12*time.Hour),
-		},
-		{
-			step: deprovisioning.NewBTPOperatorCleanupStep(db.Operations(), provisionerClient, k8sClientProvider),
-		},
-		{
-			step: deprovisioning.NewAvsEvaluationsRemovalStep(avsDel, db.Operations(), externalEvalAssistant, internalEvalAssistant),
-		},
-		{
-			step:     deprovisioning.NewEDPDeregistrationStep(db.Operations(), edpClient, cfg.EDP),
-			disabled: cfg.EDP.Disabled,
-		},
-		{
-			step:     deprovisioning.NewIASDeregistrationStep(db.Operations(), bundleBuilder),
-			disabled: cfg.IAS.Disabled,
-		},
-		{
-			disabled: cfg.LifecycleManagerIntegrationDisabled,
-			step:     deprovisioning.NewDeleteKymaResourceStep(db.Operations(), cli),
-		},
-		{
-			disabled: cfg.LifecycleManagerIntegrationDisabled,
-			step:     deprovisioning.NewCheckKymaResourceDeletedStep(db.Operations(), cli),
-		},
-		{
-			disabled: cfg.ReconcilerIntegrationDisabled,
-			step:     deprovisioning.NewDeregisterClusterStep(db.Operations(), reconcilerClient),
-		},
-		{
-			disabled: cfg.ReconcilerIntegrationDisabled,
-			step:     deprovisioning.NewCheckClusterDeregistrationStep(db.Operations(), reconcilerClient, 90*time.Minute),
-		},
-		{
-			step: deprovisioning.NewRemoveRuntimeStep(db.Operations(), db.Instances(), provisionerClient, cfg.Provisioner.DeprovisioningTimeout),
-		},
-		{
-			step: deprovisioning.NewCheckRuntimeRemovalStep(db.Operations(), db.Instances(), provisionerClient),
-		},
-		{
-			step: deprovisioning.NewReleaseSubscriptionStep(db.Operations(), db.Instances(), accountProvider),
-		},
-		{
-			disabled: cfg.LifecycleManagerIntegrationDisabled,
-			step:     steps.DeleteKubeconfig(db.Operations(), cli),
-		},
-		{
-			step: deprovisioning.NewRemoveInstanceStep(db.Instances(), db.Operations()),
-		},
-	}
-	var stages []string
-	for _, step := range deprovisioningSteps {
-		if !step.disabled {
-			stages = append(stages, step.step.Name())
-		}
-	}
-	deprovisionManager.DefineStages(stages)
-	for _, step := range deprovisioningSteps {
-		if !step.disabled {
-			deprovisionManager.AddStep(step.step.Name(), step.step, nil)
-		}
-	}
+	deprovisioningPipeline := pipeline.NewDeprovisioningPipeline(pipelineConfig(cfg), pipeline.Dependencies{
+		DB:                    db,
+		ProvisionerClient:     provisionerClient,
+		ReconcilerClient:      reconcilerClient,
+		EDPClient:             edpClient,
+		AvsDelegator:          avsDel,
+		InternalEvalAssistant: internalEvalAssistant,
+		ExternalEvalAssistant: externalEvalAssistant,
+		PlanRegistry:          pipelines.PlanRegistry(),
+		AccountProvider:       accountProvider,
+		CustomerAccountPool:   customerAccountPool,
+		IASBundleBuilder:      bundleBuilder,
+		KcpClient:             cli,
+		K8sClientProvider:     k8sClientProvider,
+		ProvisioningQueue:     provisioningQueue,
+	})
+	fatalOnError(registerPipeline(pipelines, deprovisioningPipeline, cfg.Hooks, db))
+	fatalOnError(deprovisioningPipeline.Apply(deprovisionManager))
 
 	queue := process.NewQueue(deprovisionManager, logs)
 	queue.Run(ctx.Done(), workersAmount)
@@ -908,72 +832,24 @@ func NewDeprovisioningProcessingQueue(ctx context.Context, workersAmount int, de
 	return queue
 }
 
-func NewKymaOrchestrationProcessingQueue(ctx context.Context, db storage.BrokerStorage, runtimeOverrides upgrade_kyma.RuntimeOverridesAppender, provisionerClient provisioner.Client, pub event.Publisher, inputFactory input.CreatorForPlan, icfg *upgrade_kyma.TimeSchedule, pollingInterval time.Duration, runtimeVerConfigurator *runtimeversion.RuntimeVersionConfigurator, runtimeResolver orchestrationExt.RuntimeResolver, upgradeEvalManager *avs.EvaluationManager, cfg *Config, internalEvalAssistant *avs.InternalEvalAssistant, reconcilerClient reconciler.Client, notificationBuilder notification.BundleBuilder, logs logrus.FieldLogger, cli client.Client, speedFactor int) *process.Queue {
-
-	upgradeKymaManager := upgrade_kyma.NewManager(db.Operations(), pub, logs.WithField("upgradeKyma", "manager"))
-	upgradeKymaInit := upgrade_kyma.NewInitialisationStep(db.Operations(), db.Orchestrations(), db.Instances(),
-		provisionerClient, inputFactory, upgradeEvalManager, icfg, runtimeVerConfigurator, notificationBuilder)
-
-	upgradeKymaManager.InitStep(upgradeKymaInit)
-	upgradeKymaSteps := []struct {
-		disabled bool
-		weight   int
-		step     upgrade_kyma.Step
-		cnd      upgrade_kyma.StepCondition
-	}{
-		// check cluster configuration is the first step - to not execute other steps, when cluster configuration was applied
-		// this should be moved to the end when we introduce stages like in the provisioning process
-		// (also return operation, 0, nil at the end of apply_cluster_configuration)
-		{
-			weight:   1,
-			disabled: cfg.ReconcilerIntegrationDisabled,
-			step:     upgrade_kyma.NewCheckClusterConfigurationStep(db.Operations(), reconcilerClient, upgradeEvalManager, cfg.Reconciler.ProvisioningTimeout),
-			cnd:      upgrade_kyma.SkipForPreviewPlan,
-		},
-		{
-			weight: 1,
-			step:   steps.InitKymaTemplateUpgradeKyma(db.Operations()),
-		},
-		{
-			weight: 2,
-			step:   upgrade_kyma.NewGetKubeconfigStep(db.Operations(), provisionerClient),
-		},
-		{
-			weight:   2,
-			disabled: cfg.LifecycleManagerIntegrationDisabled,
-			step:     steps.SyncKubeconfigUpgradeKyma(db.Operations(), cli),
-		},
-		{
-			weight:   2,
-			disabled: cfg.LifecycleManagerIntegrationDisabled,
-			step:     upgrade_kyma.NewApplyKymaStep(db.Operations(), cli),
-		},
-		{
-			weight: 3,
-			cnd:    upgrade_kyma.WhenBTPOperatorCredentialsProvided,
-			step:   upgrade_kyma.NewBTPOperatorOverridesStep(db.Operations()),
-		},
-		{
-			weight: 4,
-			step:   upgrade_kyma.NewOverridesFromSecretsAndConfigStep(db.Operations(), runtimeOverrides, runtimeVerConfigurator),
-		},
-		{
-			weight:   8,
-			step:     upgrade_kyma.NewSendNotificationStep(db.Operations(), notificationBuilder),
-			disabled: cfg.Notification.Disabled,
-		},
-		{
-			weight:   10,
-			disabled: cfg.ReconcilerIntegrationDisabled,
-			step:     upgrade_kyma.NewApplyClusterConfigurationStep(db.Operations(), db.RuntimeStates(), reconcilerClient),
-			cnd:      upgrade_kyma.SkipForPreviewPlan,
-		},
-	}
-	for _, step := range upgradeKymaSteps {
-		if !step.disabled {
-			upgradeKymaManager.AddStep(step.weight, step.step, step.cnd)
-		}
-	}
+func NewKymaOrchestrationProcessingQueue(ctx context.Context, db storage.BrokerStorage, pipelines *pipeline.Registry, runtimeOverrides upgrade_kyma.RuntimeOverridesAppender, provisionerClient provisioner.Client, pub event.Publisher, retryPolicies *retrypolicy.Registry, inputFactory input.CreatorForPlan, icfg *upgrade_kyma.TimeSchedule, pollingInterval time.Duration, runtimeVerConfigurator *runtimeversion.RuntimeVersionConfigurator, runtimeResolver orchestrationExt.RuntimeResolver, upgradeEvalManager *avs.EvaluationManager, cfg *Config, internalEvalAssistant *avs.InternalEvalAssistant, reconcilerClient reconciler.Client, notificationBuilder notification.BundleBuilder, logs logrus.FieldLogger, cli client.Client, speedFactor int) *process.Queue {
+
+	upgradeKymaPipeline := pipeline.NewUpgradeKymaPipeline(pipelineConfig(cfg), pipeline.Dependencies{
+		DB:                     db,
+		ProvisionerClient:      provisionerClient,
+		ReconcilerClient:       reconcilerClient,
+		InputFactory:           inputFactory,
+		RuntimeVerConfigurator: runtimeVerConfigurator,
+		RuntimeOverrides:       runtimeOverrides,
+		PlanRegistry:           pipelines.PlanRegistry(),
+		UpgradeEvalManager:     upgradeEvalManager,
+		NotificationBuilder:    notificationBuilder,
+		KcpClient:              cli,
+		UpgradeKymaSchedule:    icfg,
+	})
+	fatalOnError(pipelines.Register(upgradeKymaPipeline))
+	upgradeKymaManager := upgrade_kyma.NewManager(db.Operations(), pub, retryPolicies, logs.WithField("upgradeKyma", "manager"))
+	fatalOnError(upgradeKymaPipeline.ApplyToUpgradeKyma(upgradeKymaManager))
 
 	orchestrateKymaManager := manager.NewUpgradeKymaManager(db.Orchestrations(), db.Operations(), db.Instances(),
 		upgradeKymaManager, runtimeResolver, pollingInterval, logs.WithField("upgradeKyma", "orchestration"),
@@ -985,44 +861,22 @@ func NewKymaOrchestrationProcessingQueue(ctx context.Context, db storage.BrokerS
 	return queue
 }
 
-func NewClusterOrchestrationProcessingQueue(ctx context.Context, db storage.BrokerStorage, provisionerClient provisioner.Client,
-	pub event.Publisher, inputFactory input.CreatorForPlan, icfg *upgrade_cluster.TimeSchedule, pollingInterval time.Duration,
+func NewClusterOrchestrationProcessingQueue(ctx context.Context, db storage.BrokerStorage, pipelines *pipeline.Registry, provisionerClient provisioner.Client,
+	pub event.Publisher, retryPolicies *retrypolicy.Registry, inputFactory input.CreatorForPlan, icfg *upgrade_cluster.TimeSchedule, pollingInterval time.Duration,
 	runtimeResolver orchestrationExt.RuntimeResolver, upgradeEvalManager *avs.EvaluationManager, notificationBuilder notification.BundleBuilder, logs logrus.FieldLogger,
 	cli client.Client, cfg Config, speedFactor int) *process.Queue {
 
-	upgradeClusterManager := upgrade_cluster.NewManager(db.Operations(), pub, logs.WithField("upgradeCluster", "manager"))
-	upgradeClusterInit := upgrade_cluster.NewInitialisationStep(db.Operations(), db.Orchestrations(), provisionerClient, inputFactory, upgradeEvalManager, icfg, notificationBuilder)
-	upgradeClusterManager.InitStep(upgradeClusterInit)
-
-	upgradeClusterSteps := []struct {
-		disabled  bool
-		weight    int
-		step      upgrade_cluster.Step
-		condition upgrade_cluster.StepCondition
-	}{
-		{
-			weight:    1,
-			step:      upgrade_cluster.NewLogSkippingUpgradeStep(db.Operations()),
-			condition: provisioning.DoForOwnClusterPlanOnly,
-		},
-		{
-			weight:    10,
-			step:      upgrade_cluster.NewSendNotificationStep(db.Operations(), notificationBuilder),
-			disabled:  cfg.Notification.Disabled,
-			condition: provisioning.SkipForOwnClusterPlan,
-		},
-		{
-			weight:    10,
-			step:      upgrade_cluster.NewUpgradeClusterStep(db.Operations(), db.RuntimeStates(), provisionerClient, icfg),
-			condition: provisioning.SkipForOwnClusterPlan,
-		},
-	}
-
-	for _, step := range upgradeClusterSteps {
-		if !step.disabled {
-			upgradeClusterManager.AddStep(step.weight, step.step, step.condition)
-		}
-	}
+	upgradeClusterPipeline := pipeline.NewUpgradeClusterPipeline(pipelineConfig(&cfg), pipeline.Dependencies{
+		DB:                     db,
+		ProvisionerClient:      provisionerClient,
+		InputFactory:           inputFactory,
+		UpgradeEvalManager:     upgradeEvalManager,
+		NotificationBuilder:    notificationBuilder,
+		UpgradeClusterSchedule: icfg,
+	})
+	fatalOnError(pipelines.Register(upgradeClusterPipeline))
+	upgradeClusterManager := upgrade_cluster.NewManager(db.Operations(), pub, retryPolicies, logs.WithField("upgradeCluster", "manager"))
+	fatalOnError(upgradeClusterPipeline.ApplyToUpgradeCluster(upgradeClusterManager))
 
 	orchestrateClusterManager := manager.NewUpgradeClusterManager(db.Orchestrations(), db.Operations(), db.Instances(),
 		upgradeClusterManager, runtimeResolver, pollingInterval, logs.WithField("upgradeCluster", "orchestration"),
@@ -1034,6 +888,15 @@ func NewClusterOrchestrationProcessingQueue(ctx context.Context, db storage.Brok
 	return queue
 }
 
-func skipForPreviewPlan(operation internal.Operation) bool {
-	return !broker.IsPreviewPlan(operation.ProvisioningParameters.PlanID)
+func pipelineConfig(cfg *Config) pipeline.Config {
+	return pipeline.Config{
+		Provisioner:                         cfg.Provisioner,
+		Reconciler:                          cfg.Reconciler,
+		Avs:                                 cfg.Avs,
+		EDP:                                 cfg.EDP,
+		IAS:                                 cfg.IAS,
+		Notification:                        cfg.Notification,
+		LifecycleManagerIntegrationDisabled: cfg.LifecycleManagerIntegrationDisabled,
+		ReconcilerIntegrationDisabled:       cfg.ReconcilerIntegrationDisabled,
+	}
 }
@@ -29,6 +29,7 @@ import (
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/ias"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/notification"
 	kebOrchestration "github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/orchestration"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/pipeline"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process/input"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process/input/automock"
@@ -135,7 +136,7 @@ func NewOrchestrationSuite(t *testing.T, additionalKymaVersions []string) *Orche
 			ProvisioningTimeout:         time.Minute,
 			URL:                         "http://localhost",
 			DefaultGardenerShootPurpose: "testing",
-		}, kymaVer, map[string]string{"cf-eu10": "europe"}, cfg.FreemiumProviders, oidcDefaults)
+		}, kymaVer, map[string]string{"cf-eu10": "europe"}, cfg.FreemiumProviders, oidcDefaults, nil, nil)
 	require.NoError(t, err)
 
 	reconcilerClient := reconciler.NewFakeClient()
@@ -152,20 +153,21 @@ func NewOrchestrationSuite(t *testing.T, additionalKymaVersions []string) *Orche
 
 	avsClient, _ := avs.NewClient(ctx, avs.Config{}, logs)
 	avsDel := avs.NewDelegator(avsClient, avs.Config{}, db.Operations())
-	upgradeEvaluationManager := avs.NewEvaluationManager(avsDel, avs.Config{})
+	upgradeEvaluationManager := avs.NewEvaluationManager(avsDel, avs.Config{}, nil)
 	runtimeLister := kebOrchestration.NewRuntimeLister(db.Instances(), db.Operations(), kebRuntime.NewConverter(defaultRegion), logs)
 	runtimeResolver := orchestration.NewGardenerRuntimeResolver(gardenerClient, gardenerNamespace, runtimeLister, logs)
 
 	notificationFakeClient := notification.NewFakeClient()
 	notificationBundleBuilder := notification.NewBundleBuilder(notificationFakeClient, cfg.Notification)
 
-	kymaQueue := NewKymaOrchestrationProcessingQueue(ctx, db, runtimeOverrides, provisionerClient, eventBroker, inputFactory, &upgrade_kyma.TimeSchedule{
+	pipelines := pipeline.NewRegistry(nil)
+	kymaQueue := NewKymaOrchestrationProcessingQueue(ctx, db, pipelines, runtimeOverrides, provisionerClient, eventBroker, nil, inputFactory, &upgrade_kyma.TimeSchedule{
 		Retry:              2 * time.Millisecond,
 		StatusCheck:        20 * time.Millisecond,
 		UpgradeKymaTimeout: 4 * time.Second,
-	}, 250*time.Millisecond, runtimeVerConfigurator, runtimeResolver, upgradeEvaluationManager, &cfg, avs.NewInternalEvalAssistant(cfg.Avs), reconcilerClient, notificationBundleBuilder, logs, cli, 1000)
+	}, 250*time.Millisecond, runtimeVerConfigurator, runtimeResolver, upgradeEvaluationManager, &cfg, avs.NewInternalEvalAssistant(cfg.Avs, nil), reconcilerClient, notificationBundleBuilder, logs, cli, 1000)
 
-	clusterQueue := NewClusterOrchestrationProcessingQueue(ctx, db, provisionerClient, eventBroker, inputFactory, &upgrade_cluster.TimeSchedule{
+	clusterQueue := NewClusterOrchestrationProcessingQueue(ctx, db, pipelines, provisionerClient, eventBroker, nil, inputFactory, &upgrade_cluster.TimeSchedule{
 		Retry:                 2 * time.Millisecond,
 		StatusCheck:           20 * time.Millisecond,
 		UpgradeClusterTimeout: 4 * time.Second,
@@ -400,7 +402,7 @@ func (s *OrchestrationSuite) finishOperationByProvisioner(operationType gqlschem
 
 func (s *OrchestrationSuite) FinishUpgradeOperationByReconciler(runtimeID string) {
 	err := wait.Poll(time.Millisecond*20, 2*time.Second, func() (bool, error) {
-		c, err := s.reconcilerClient.GetLatestCluster(runtimeID)
+		c, err := s.reconcilerClient.GetLatestCluster(context.Background(), runtimeID)
 		if err != nil {
 			return false, nil
 		}
@@ -602,7 +604,7 @@ func NewProvisioningSuite(t *testing.T, multiZoneCluster bool, controlPlaneFailu
 			DefaultGardenerShootPurpose:  "testing",
 			MultiZoneCluster:             multiZoneCluster,
 			ControlPlaneFailureTolerance: controlPlaneFailureTolerance,
-		}, defaultKymaVer, map[string]string{"cf-eu10": "europe"}, cfg.FreemiumProviders, oidcDefaults)
+		}, defaultKymaVer, map[string]string{"cf-eu10": "europe"}, cfg.FreemiumProviders, oidcDefaults, nil, nil)
 	require.NoError(t, err)
 
 	server := avs.NewMockAvsServer(t)
@@ -615,8 +617,8 @@ func NewProvisioningSuite(t *testing.T, multiZoneCluster bool, controlPlaneFailu
 	client, err := avs.NewClient(context.TODO(), avsConfig, logrus.New())
 	assert.NoError(t, err)
 	avsDel := avs.NewDelegator(client, avsConfig, db.Operations())
-	externalEvalAssistant := avs.NewExternalEvalAssistant(cfg.Avs)
-	internalEvalAssistant := avs.NewInternalEvalAssistant(cfg.Avs)
+	externalEvalAssistant := avs.NewExternalEvalAssistant(cfg.Avs, nil)
+	internalEvalAssistant := avs.NewInternalEvalAssistant(cfg.Avs, nil)
 	externalEvalCreator := provisioning.NewExternalEvalCreator(avsDel, cfg.Avs.Disabled, externalEvalAssistant)
 	internalEvalUpdater := provisioning.NewInternalEvalUpdater(avsDel, internalEvalAssistant, cfg.Avs)
 
@@ -634,9 +636,10 @@ func NewProvisioningSuite(t *testing.T, multiZoneCluster bool, controlPlaneFailu
 
 	eventBroker := event.NewPubSub(logs)
 
-	provisionManager := process.NewStagedManager(db.Operations(), eventBroker, cfg.OperationTimeout, logs.WithField("provisioning", "manager"))
-	provisioningQueue := NewProvisioningProcessingQueue(ctx, provisionManager, workersAmount, cfg, db, provisionerClient, inputFactory, avsDel,
+	provisionManager := process.NewStagedManager(db.Operations(), eventBroker, cfg.OperationTimeout, nil, logs.WithField("provisioning", "manager"))
+	provisioningQueue := NewProvisioningProcessingQueue(ctx, provisionManager, pipeline.NewRegistry(nil), workersAmount, cfg, db, provisionerClient, inputFactory, avsDel,
 		internalEvalAssistant, externalEvalCreator, internalEvalUpdater, runtimeVerConfigurator, runtimeOverrides, edpClient, accountProvider,
+		hyperscaler.NewCustomerAccountPool(gardener.NewDynamicFakeClient(), fixedGardenerNamespace), hyperscaler.NewStubCredentialsVerifier(),
 		reconcilerClient, fakeK8sClientProvider(cli), cli, logs)
 
 	provisioningQueue.SpeedUp(10000)
@@ -817,7 +820,7 @@ func (s *ProvisioningSuite) AssertAllStagesFinished(operationID string) {
 func (s *ProvisioningSuite) finishOperationByReconciler(op *internal.Operation) {
 	time.Sleep(50 * time.Millisecond)
 	err := wait.Poll(pollingInterval, 10*time.Second, func() (bool, error) {
-		state, err := s.reconcilerClient.GetCluster(op.RuntimeID, op.ClusterConfigurationVersion)
+		state, err := s.reconcilerClient.GetCluster(context.Background(), op.RuntimeID, op.ClusterConfigurationVersion)
 		if err != nil {
 			return false, err
 		}
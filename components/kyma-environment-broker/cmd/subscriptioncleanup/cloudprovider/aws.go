@@ -10,10 +10,15 @@ import (
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
 )
 
 type awsResourceCleaner struct {
 	credentials awsCredentialsConfig
+	dryRun      bool
+	// endpointURL replaces the AWS endpoints of all services and regions, it is set only in tests
+	endpointURL string
 }
 
 type awsCredentialsConfig struct {
@@ -21,8 +26,8 @@ type awsCredentialsConfig struct {
 	secretAccessKey string
 }
 
-func NewAwsResourcesCleaner(secretData map[string][]byte) (ResourceCleaner, error) {
-	awsResourceCleaner := awsResourceCleaner{}
+func NewAwsResourcesCleaner(secretData map[string][]byte, dryRun bool) (ResourceCleaner, error) {
+	awsResourceCleaner := awsResourceCleaner{dryRun: dryRun}
 	awsConfig, err := awsResourceCleaner.toAwsConfig(secretData)
 	if err != nil {
 		return nil, err
@@ -32,56 +37,147 @@ func NewAwsResourcesCleaner(secretData map[string][]byte) (ResourceCleaner, erro
 	return awsResourceCleaner, nil
 }
 
-func (ac awsResourceCleaner) Do() error {
+func (ac awsResourceCleaner) Do() (Report, error) {
+	deleter := newResourceDeleter(ac.dryRun)
 	all_regions, err := ac.getAllRegions()
 	if err != nil {
-		return err
+		return deleter.report, err
 	}
 
 	for _, region := range all_regions.Regions {
 		logrus.Printf("Switching to region %v", *region.RegionName)
-		ec2Client, err := ac.newAwsEC2Client(ac.credentials, *region.RegionName)
+		ec2Client := ac.newAwsEC2Client(ac.credentials, *region.RegionName)
+		elbClient := ac.newAwsELBClient(ac.credentials, *region.RegionName)
+		classicELBClient := ac.newAwsClassicELBClient(ac.credentials, *region.RegionName)
+
+		// the load balancers hold the public IPs and reference the security groups, so they go first
+		ac.deleteLoadBalancers(deleter, elbClient, classicELBClient, *region.RegionName)
+		ac.releaseAddresses(deleter, ec2Client, *region.RegionName)
+		ac.deleteSecurityGroups(deleter, ec2Client, *region.RegionName)
+		ac.deleteSnapshots(deleter, ec2Client, *region.RegionName)
+		ac.deleteVolumes(deleter, ec2Client, *region.RegionName)
+	}
+
+	return deleter.result()
+}
+
+func (ac awsResourceCleaner) deleteLoadBalancers(deleter *resourceDeleter, elbClient elasticloadbalancingv2.Client, classicELBClient elasticloadbalancing.Client, region string) {
+	paginator := elasticloadbalancingv2.NewDescribeLoadBalancersPaginator(&elbClient, &elasticloadbalancingv2.DescribeLoadBalancersInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.TODO())
 		if err != nil {
-			return err
+			deleter.fail(fmt.Errorf("while listing load balancers in %s: %w", region, err))
+			break
+		}
+		for _, loadBalancer := range page.LoadBalancers {
+			arn := loadBalancer.LoadBalancerArn
+			deleter.delete(Resource{Kind: LoadBalancer, Region: region, Name: aws.ToString(arn)}, func() error {
+				_, err := elbClient.DeleteLoadBalancer(context.TODO(), &elasticloadbalancingv2.DeleteLoadBalancerInput{LoadBalancerArn: arn})
+				return err
+			})
 		}
+	}
 
-		err = ac.deleteVolumes(ec2Client)
+	classicPaginator := elasticloadbalancing.NewDescribeLoadBalancersPaginator(&classicELBClient, &elasticloadbalancing.DescribeLoadBalancersInput{})
+	for classicPaginator.HasMorePages() {
+		page, err := classicPaginator.NextPage(context.TODO())
 		if err != nil {
-			return err
+			deleter.fail(fmt.Errorf("while listing classic load balancers in %s: %w", region, err))
+			return
+		}
+		for _, loadBalancer := range page.LoadBalancerDescriptions {
+			name := loadBalancer.LoadBalancerName
+			deleter.delete(Resource{Kind: LoadBalancer, Region: region, Name: aws.ToString(name)}, func() error {
+				_, err := classicELBClient.DeleteLoadBalancer(context.TODO(), &elasticloadbalancing.DeleteLoadBalancerInput{LoadBalancerName: name})
+				return err
+			})
 		}
 	}
-
-	return nil
 }
 
-func (ac awsResourceCleaner) deleteVolumes(ec2Client ec2.Client) error {
-	volumes, err := ec2Client.DescribeVolumes(context.TODO(), &ec2.DescribeVolumesInput{})
+func (ac awsResourceCleaner) releaseAddresses(deleter *resourceDeleter, ec2Client ec2.Client, region string) {
+	addresses, err := ec2Client.DescribeAddresses(context.TODO(), &ec2.DescribeAddressesInput{})
 	if err != nil {
-		return err
+		deleter.fail(fmt.Errorf("while listing addresses in %s: %w", region, err))
+		return
+	}
+
+	for _, address := range addresses.Addresses {
+		allocationID := address.AllocationId
+		deleter.delete(Resource{Kind: PublicIP, Region: region, Name: aws.ToString(address.PublicIp)}, func() error {
+			_, err := ec2Client.ReleaseAddress(context.TODO(), &ec2.ReleaseAddressInput{AllocationId: allocationID})
+			return err
+		})
 	}
+}
 
-	for _, volume := range volumes.Volumes {
-		if volume.State == types.VolumeStateInUse {
-			return fmt.Errorf(fmt.Sprintf("There is an EC2 instance which uses this volume with id: %v", *volume.VolumeId))
+func (ac awsResourceCleaner) deleteSecurityGroups(deleter *resourceDeleter, ec2Client ec2.Client, region string) {
+	paginator := ec2.NewDescribeSecurityGroupsPaginator(&ec2Client, &ec2.DescribeSecurityGroupsInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			deleter.fail(fmt.Errorf("while listing security groups in %s: %w", region, err))
+			return
+		}
+		for _, group := range page.SecurityGroups {
+			// every VPC has a default security group which cannot be deleted
+			if aws.ToString(group.GroupName) == "default" {
+				continue
+			}
+			groupID := group.GroupId
+			deleter.delete(Resource{Kind: SecurityGroup, Region: region, Name: aws.ToString(groupID)}, func() error {
+				_, err := ec2Client.DeleteSecurityGroup(context.TODO(), &ec2.DeleteSecurityGroupInput{GroupId: groupID})
+				return err
+			})
 		}
 	}
+}
 
-	for _, volume := range volumes.Volumes {
-		logrus.Printf("Deleting volume with id %v", *volume.VolumeId)
-		ec2Client.DeleteVolume(context.TODO(), &ec2.DeleteVolumeInput{
-			VolumeId: volume.VolumeId,
-		})
+func (ac awsResourceCleaner) deleteSnapshots(deleter *resourceDeleter, ec2Client ec2.Client, region string) {
+	paginator := ec2.NewDescribeSnapshotsPaginator(&ec2Client, &ec2.DescribeSnapshotsInput{OwnerIds: []string{"self"}})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			deleter.fail(fmt.Errorf("while listing snapshots in %s: %w", region, err))
+			return
+		}
+		for _, snapshot := range page.Snapshots {
+			snapshotID := snapshot.SnapshotId
+			deleter.delete(Resource{Kind: Snapshot, Region: region, Name: aws.ToString(snapshotID)}, func() error {
+				_, err := ec2Client.DeleteSnapshot(context.TODO(), &ec2.DeleteSnapshotInput{SnapshotId: snapshotID})
+				return err
+			})
+		}
 	}
+}
 
-	return nil
+func (ac awsResourceCleaner) deleteVolumes(deleter *resourceDeleter, ec2Client ec2.Client, region string) {
+	paginator := ec2.NewDescribeVolumesPaginator(&ec2Client, &ec2.DescribeVolumesInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			deleter.fail(fmt.Errorf("while listing volumes in %s: %w", region, err))
+			return
+		}
+		for _, volume := range page.Volumes {
+			resource := Resource{Kind: Volume, Region: region, Name: aws.ToString(volume.VolumeId)}
+			switch volume.State {
+			case types.VolumeStateInUse:
+				deleter.keep(resource, "is used by an EC2 instance")
+			case types.VolumeStateAvailable, types.VolumeStateError:
+				volumeID := volume.VolumeId
+				deleter.delete(resource, func() error {
+					_, err := ec2Client.DeleteVolume(context.TODO(), &ec2.DeleteVolumeInput{VolumeId: volumeID})
+					return err
+				})
+			}
+		}
+	}
 }
 
 func (ac awsResourceCleaner) getAllRegions() (ec2.DescribeRegionsOutput, error) {
 	allRegions := false
-	ec2Client, err := ac.newAwsEC2Client(ac.credentials, "eu-central-1")
-	if err != nil {
-		return ec2.DescribeRegionsOutput{}, err
-	}
+	ec2Client := ac.newAwsEC2Client(ac.credentials, "eu-central-1")
 
 	regionOutput, err := ec2Client.DescribeRegions(context.TODO(), &ec2.DescribeRegionsInput{AllRegions: &allRegions})
 	if err != nil {
@@ -108,9 +204,39 @@ func (ac awsResourceCleaner) toAwsConfig(secretData map[string][]byte) (awsCrede
 	}, nil
 }
 
-func (ac awsResourceCleaner) newAwsEC2Client(awsCredentialConfig awsCredentialsConfig, region string) (ec2.Client, error) {
-	return *ec2.New(ec2.Options{
+func (ac awsResourceCleaner) credentialsProvider(awsCredentialConfig awsCredentialsConfig) aws.CredentialsProvider {
+	return aws.NewCredentialsCache(credentials.NewStaticCredentialsProvider(awsCredentialConfig.accessKeyID, awsCredentialConfig.secretAccessKey, ""))
+}
+
+func (ac awsResourceCleaner) newAwsEC2Client(awsCredentialConfig awsCredentialsConfig, region string) ec2.Client {
+	options := ec2.Options{
+		Region:      region,
+		Credentials: ac.credentialsProvider(awsCredentialConfig),
+	}
+	if ac.endpointURL != "" {
+		options.EndpointResolver = ec2.EndpointResolverFromURL(ac.endpointURL)
+	}
+	return *ec2.New(options)
+}
+
+func (ac awsResourceCleaner) newAwsELBClient(awsCredentialConfig awsCredentialsConfig, region string) elasticloadbalancingv2.Client {
+	options := elasticloadbalancingv2.Options{
 		Region:      region,
-		Credentials: aws.NewCredentialsCache(credentials.NewStaticCredentialsProvider(awsCredentialConfig.accessKeyID, awsCredentialConfig.secretAccessKey, "")),
-	}), nil
+		Credentials: ac.credentialsProvider(awsCredentialConfig),
+	}
+	if ac.endpointURL != "" {
+		options.EndpointResolver = elasticloadbalancingv2.EndpointResolverFromURL(ac.endpointURL)
+	}
+	return *elasticloadbalancingv2.New(options)
+}
+
+func (ac awsResourceCleaner) newAwsClassicELBClient(awsCredentialConfig awsCredentialsConfig, region string) elasticloadbalancing.Client {
+	options := elasticloadbalancing.Options{
+		Region:      region,
+		Credentials: ac.credentialsProvider(awsCredentialConfig),
+	}
+	if ac.endpointURL != "" {
+		options.EndpointResolver = elasticloadbalancing.EndpointResolverFromURL(ac.endpointURL)
+	}
+	return *elasticloadbalancing.New(options)
 }
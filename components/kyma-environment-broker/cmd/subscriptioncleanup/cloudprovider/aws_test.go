@@ -0,0 +1,154 @@
+package cloudprovider
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAwsResourceCleaner_Do(t *testing.T) {
+	t.Run("should delete all resources", func(t *testing.T) {
+		// given
+		fake := newAwsFake(t)
+		cleaner := newTestAwsResourceCleaner(t, fake, false)
+
+		// when
+		report, err := cleaner.Do()
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"DeleteLoadBalancer arn:aws:elasticloadbalancing:lb-1",
+			"DeleteLoadBalancer classic-1",
+			"ReleaseAddress eipalloc-1",
+			"DeleteSecurityGroup sg-1",
+			"DeleteSnapshot snap-1",
+			"DeleteVolume vol-1",
+		}, fake.deleted)
+		assert.Len(t, report.Resources, 6)
+		assert.Empty(t, report.Failed)
+	})
+
+	t.Run("should only report the resources in the dry-run mode", func(t *testing.T) {
+		// given
+		fake := newAwsFake(t)
+		cleaner := newTestAwsResourceCleaner(t, fake, true)
+
+		// when
+		report, err := cleaner.Do()
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, fake.deleted)
+		assert.True(t, report.DryRun)
+		assert.Contains(t, report.Resources, Resource{Kind: Volume, Region: "eu-central-1", Name: "vol-1"})
+		assert.Contains(t, report.Resources, Resource{Kind: PublicIP, Region: "eu-central-1", Name: "1.2.3.4"})
+	})
+
+	t.Run("should delete the other resources and fail when a deletion fails", func(t *testing.T) {
+		// given
+		fake := newAwsFake(t)
+		fake.failingActions = map[string]bool{"DeleteSecurityGroup": true}
+		cleaner := newTestAwsResourceCleaner(t, fake, false)
+
+		// when
+		report, err := cleaner.Do()
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "while deleting security group sg-1 in eu-central-1")
+		assert.Equal(t, []Resource{{Kind: SecurityGroup, Region: "eu-central-1", Name: "sg-1"}}, report.Failed)
+		assert.Contains(t, fake.deleted, "DeleteVolume vol-1")
+	})
+
+	t.Run("should fail when a volume is in use", func(t *testing.T) {
+		// given
+		fake := newAwsFake(t)
+		fake.volumeState = "in-use"
+		cleaner := newTestAwsResourceCleaner(t, fake, false)
+
+		// when
+		_, err := cleaner.Do()
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "volume vol-1 in eu-central-1 is used by an EC2 instance")
+		assert.NotContains(t, fake.deleted, "DeleteVolume vol-1")
+	})
+}
+
+func newTestAwsResourceCleaner(t *testing.T, fake *awsFake, dryRun bool) ResourceCleaner {
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	cleaner, err := NewAwsResourcesCleaner(map[string][]byte{"accessKeyID": []byte("key-id"), "secretAccessKey": []byte("secret")}, dryRun)
+	require.NoError(t, err)
+	awsCleaner := cleaner.(awsResourceCleaner)
+	awsCleaner.endpointURL = server.URL
+	return awsCleaner
+}
+
+// awsFake serves the EC2 and Elastic Load Balancing Query APIs with a single region and one resource of each kind
+type awsFake struct {
+	t              *testing.T
+	mu             sync.Mutex
+	deleted        []string
+	failingActions map[string]bool
+	volumeState    string
+}
+
+func newAwsFake(t *testing.T) *awsFake {
+	return &awsFake{t: t, volumeState: "available"}
+}
+
+func (f *awsFake) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	require.NoError(f.t, r.ParseForm())
+	action := r.Form.Get("Action")
+	if f.failingActions[action] {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, `<Response><Errors><Error><Code>DependencyViolation</Code><Message>resource has a dependent object</Message></Error></Errors></Response>`)
+		return
+	}
+
+	responses := map[string]string{
+		"DescribeRegions":        `<regionInfo><item><regionName>eu-central-1</regionName></item></regionInfo>`,
+		"DescribeAddresses":      `<addressesSet><item><publicIp>1.2.3.4</publicIp><allocationId>eipalloc-1</allocationId></item></addressesSet>`,
+		"DescribeSecurityGroups": `<securityGroupInfo><item><groupId>sg-0</groupId><groupName>default</groupName></item><item><groupId>sg-1</groupId><groupName>shoot--kyma</groupName></item></securityGroupInfo>`,
+		"DescribeSnapshots":      `<snapshotSet><item><snapshotId>snap-1</snapshotId></item></snapshotSet>`,
+		"DescribeVolumes":        fmt.Sprintf(`<volumeSet><item><volumeId>vol-1</volumeId><status>%s</status></item></volumeSet>`, f.volumeState),
+	}
+
+	var deleted string
+	switch action {
+	case "DescribeLoadBalancers":
+		if r.Form.Get("Version") == "2012-06-01" {
+			responses[action] = `<DescribeLoadBalancersResult><LoadBalancerDescriptions><member><LoadBalancerName>classic-1</LoadBalancerName></member></LoadBalancerDescriptions></DescribeLoadBalancersResult>`
+		} else {
+			responses[action] = `<DescribeLoadBalancersResult><LoadBalancers><member><LoadBalancerArn>arn:aws:elasticloadbalancing:lb-1</LoadBalancerArn></member></LoadBalancers></DescribeLoadBalancersResult>`
+		}
+	case "DeleteLoadBalancer":
+		responses[action] = `<DeleteLoadBalancerResult/>`
+		deleted = r.Form.Get("LoadBalancerArn") + r.Form.Get("LoadBalancerName")
+	case "ReleaseAddress":
+		deleted = r.Form.Get("AllocationId")
+	case "DeleteSecurityGroup":
+		deleted = r.Form.Get("GroupId")
+	case "DeleteSnapshot":
+		deleted = r.Form.Get("SnapshotId")
+	case "DeleteVolume":
+		deleted = r.Form.Get("VolumeId")
+	}
+	if deleted != "" {
+		f.mu.Lock()
+		f.deleted = append(f.deleted, fmt.Sprintf("%s %s", action, deleted))
+		f.mu.Unlock()
+	}
+
+	w.Header().Set("Content-Type", "text/xml")
+	fmt.Fprintf(w, `<%sResponse>%s</%sResponse>`, action, responses[action], action)
+}
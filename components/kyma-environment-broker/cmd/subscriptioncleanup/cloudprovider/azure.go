@@ -8,11 +8,11 @@ import (
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/adal"
 	"github.com/Azure/go-autorest/autorest/azure"
-	log "github.com/sirupsen/logrus"
 )
 
 type azureResourceCleaner struct {
 	azureClient resources.GroupsClient
+	dryRun      bool
 }
 
 type config struct {
@@ -23,7 +23,7 @@ type config struct {
 	userAgent      string
 }
 
-func NewAzureResourcesCleaner(secretData map[string][]byte) (ResourceCleaner, error) {
+func NewAzureResourcesCleaner(secretData map[string][]byte, dryRun bool) (ResourceCleaner, error) {
 	config, err := toConfig(secretData)
 	if err != nil {
 		return nil, err
@@ -36,33 +36,43 @@ func NewAzureResourcesCleaner(secretData map[string][]byte) (ResourceCleaner, er
 
 	return &azureResourceCleaner{
 		azureClient: azureClient,
+		dryRun:      dryRun,
 	}, nil
 }
 
-func (ac azureResourceCleaner) Do() error {
+// Do deletes all resource groups of the subscription, the volumes, snapshots, load balancers, public IPs
+// and network security groups of Azure always belong to a resource group and are deleted with it
+func (ac azureResourceCleaner) Do() (Report, error) {
 	ctx := context.Background()
-	resourceGroups, err := ac.azureClient.List(ctx, "", nil)
+	deleter := newResourceDeleter(ac.dryRun)
+	resourceGroups, err := ac.azureClient.ListComplete(ctx, "", nil)
 	if err != nil {
-		return err
+		return deleter.report, fmt.Errorf("while listing resource groups: %w", err)
 	}
 
-	for _, resourceGroup := range resourceGroups.Values() {
-		if resourceGroup.Name != nil {
-			log.Infof("Deleting resource group '%s'", *resourceGroup.Name)
-			future, err := ac.azureClient.Delete(ctx, *resourceGroup.Name)
-			if err != nil {
-				log.Errorf("failed to init resource group '%s' deletion", *resourceGroup.Name)
-				continue
-			}
-
-			err = future.WaitForCompletionRef(ctx, ac.azureClient.Client)
+	for ; resourceGroups.NotDone(); err = resourceGroups.NextWithContext(ctx) {
+		if err != nil {
+			deleter.fail(fmt.Errorf("while listing resource groups: %w", err))
+			break
+		}
+		resourceGroup := resourceGroups.Value()
+		if resourceGroup.Name == nil {
+			continue
+		}
+		name, location := *resourceGroup.Name, ""
+		if resourceGroup.Location != nil {
+			location = *resourceGroup.Location
+		}
+		deleter.delete(Resource{Kind: ResourceGroup, Region: location, Name: name}, func() error {
+			future, err := ac.azureClient.Delete(ctx, name)
 			if err != nil {
-				log.Errorf("failed to remove resource group '%s', %s: ", *resourceGroup.Name, err.Error())
+				return fmt.Errorf("while initializing deletion: %w", err)
 			}
-		}
+			return future.WaitForCompletionRef(ctx, ac.azureClient.Client)
+		})
 	}
 
-	return nil
+	return deleter.result()
 }
 
 func toConfig(secretData map[string][]byte) (config, error) {
@@ -0,0 +1,101 @@
+package cloudprovider
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"sync"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-05-01/resources"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAzureResourceCleaner_Do(t *testing.T) {
+	t.Run("should delete all resource groups", func(t *testing.T) {
+		// given
+		fake := &azureFake{}
+		cleaner := newTestAzureResourceCleaner(t, fake, false)
+
+		// when
+		report, err := cleaner.Do()
+
+		// then
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"shoot--kyma--c1", "shoot--kyma--c2"}, fake.deleted)
+		assert.Equal(t, []Resource{
+			{Kind: ResourceGroup, Region: "westeurope", Name: "shoot--kyma--c1"},
+			{Kind: ResourceGroup, Region: "westeurope", Name: "shoot--kyma--c2"},
+		}, report.Resources)
+	})
+
+	t.Run("should only report the resource groups in the dry-run mode", func(t *testing.T) {
+		// given
+		fake := &azureFake{}
+		cleaner := newTestAzureResourceCleaner(t, fake, true)
+
+		// when
+		report, err := cleaner.Do()
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, fake.deleted)
+		assert.Len(t, report.Resources, 2)
+	})
+
+	t.Run("should fail when a resource group is not deleted", func(t *testing.T) {
+		// given
+		fake := &azureFake{failing: "shoot--kyma--c1"}
+		cleaner := newTestAzureResourceCleaner(t, fake, false)
+
+		// when
+		report, err := cleaner.Do()
+
+		// then
+		require.Error(t, err)
+		assert.Equal(t, []Resource{{Kind: ResourceGroup, Region: "westeurope", Name: "shoot--kyma--c1"}}, report.Failed)
+		assert.Equal(t, []string{"shoot--kyma--c2"}, fake.deleted)
+	})
+}
+
+func newTestAzureResourceCleaner(t *testing.T, fake *azureFake, dryRun bool) ResourceCleaner {
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	client := resources.NewGroupsClientWithBaseURI(server.URL, "subscription-1")
+	client.Authorizer = autorest.NullAuthorizer{}
+	client.RetryAttempts = 1
+	return &azureResourceCleaner{azureClient: client, dryRun: dryRun}
+}
+
+// azureFake serves the resource groups API of Azure Resource Manager with two resource groups, deleted synchronously
+type azureFake struct {
+	mu      sync.Mutex
+	deleted []string
+	failing string
+}
+
+func (f *azureFake) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	switch r.Method {
+	case http.MethodGet:
+		fmt.Fprint(w, `{"value": [
+			{"id": "/subscriptions/subscription-1/resourceGroups/shoot--kyma--c1", "name": "shoot--kyma--c1", "location": "westeurope"},
+			{"id": "/subscriptions/subscription-1/resourceGroups/shoot--kyma--c2", "name": "shoot--kyma--c2", "location": "westeurope"}
+		]}`)
+	case http.MethodDelete:
+		name := path.Base(r.URL.Path)
+		if name == f.failing {
+			w.WriteHeader(http.StatusConflict)
+			fmt.Fprint(w, `{"error": {"code": "ScopeLocked", "message": "the resource group is locked"}}`)
+			return
+		}
+		f.mu.Lock()
+		f.deleted = append(f.deleted, name)
+		f.mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}
+}
@@ -6,8 +6,17 @@ import (
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/cmd/subscriptioncleanup/model"
 )
 
+// ResourceCleaner deletes the volumes, snapshots, load balancers, public IPs and security groups left in a hyperscaler account,
+// so the account can be returned to the pool
 type ResourceCleaner interface {
-	Do() error
+	Do() (Report, error)
+}
+
+type Config struct {
+	// DryRun makes the cleaners only report the resources without deleting them
+	DryRun bool
+	// OpenstackAuthURL is the Keystone URL used when the secret does not contain the authURL key
+	OpenstackAuthURL string
 }
 
 //go:generate mockery --name=ProviderFactory
@@ -15,25 +24,31 @@ type ProviderFactory interface {
 	New(hyperscalerType model.HyperscalerType, secretData map[string][]byte) (ResourceCleaner, error)
 }
 
-type providerFactory struct{}
+type providerFactory struct {
+	config Config
+}
 
-func NewProviderFactory() ProviderFactory {
-	return &providerFactory{}
+func NewProviderFactory(config Config) ProviderFactory {
+	return &providerFactory{config: config}
 }
 
 func (pf *providerFactory) New(hyperscalerType model.HyperscalerType, secretData map[string][]byte) (ResourceCleaner, error) {
 	switch hyperscalerType {
 	case model.GCP:
 		{
-			return NewGCPeResourcesCleaner(secretData), nil
+			return NewGCPResourcesCleaner(secretData, pf.config.DryRun)
 		}
 	case model.Azure:
 		{
-			return NewAzureResourcesCleaner(secretData)
+			return NewAzureResourcesCleaner(secretData, pf.config.DryRun)
 		}
 	case model.AWS:
 		{
-			return NewAwsResourcesCleaner(secretData)
+			return NewAwsResourcesCleaner(secretData, pf.config.DryRun)
+		}
+	case model.Openstack:
+		{
+			return NewOpenstackResourcesCleaner(secretData, pf.config.OpenstackAuthURL, pf.config.DryRun)
 		}
 	default:
 		return nil, fmt.Errorf("unknown hyperscaler type")
@@ -1,12 +1,265 @@
 package cloudprovider
 
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
+)
+
+// gcpOperationWaitAttempts limits the calls of the operation wait method, which returns after 2 minutes at most
+const gcpOperationWaitAttempts = 5
+
 type gcpResourceCleaner struct {
+	service *compute.Service
+	project string
+	dryRun  bool
+}
+
+type gcpServiceAccount struct {
+	ProjectID string `json:"project_id"`
+}
+
+func NewGCPResourcesCleaner(secretData map[string][]byte, dryRun bool) (ResourceCleaner, error) {
+	serviceAccountJSON, exists := secretData["serviceaccount.json"]
+	if !exists {
+		return nil, fmt.Errorf("serviceaccount.json not provided in the secret")
+	}
+	serviceAccount := gcpServiceAccount{}
+	if err := json.Unmarshal(serviceAccountJSON, &serviceAccount); err != nil {
+		return nil, fmt.Errorf("while parsing service account: %w", err)
+	}
+	if serviceAccount.ProjectID == "" {
+		return nil, fmt.Errorf("project_id not provided in the service account")
+	}
+
+	service, err := compute.NewService(context.Background(), option.WithCredentialsJSON(serviceAccountJSON), option.WithScopes(compute.ComputeScope))
+	if err != nil {
+		return nil, fmt.Errorf("while creating compute service: %w", err)
+	}
+	return newGCPResourceCleaner(service, serviceAccount.ProjectID, dryRun), nil
+}
+
+func newGCPResourceCleaner(service *compute.Service, project string, dryRun bool) *gcpResourceCleaner {
+	return &gcpResourceCleaner{
+		service: service,
+		project: project,
+		dryRun:  dryRun,
+	}
+}
+
+func (rc gcpResourceCleaner) Do() (Report, error) {
+	deleter := newResourceDeleter(rc.dryRun)
+
+	// a load balancer consists of the forwarding rule holding the public IP and the target pool it points to
+	rc.deleteForwardingRules(deleter)
+	rc.deleteTargetPools(deleter)
+	rc.deleteAddresses(deleter)
+	rc.deleteFirewalls(deleter)
+	rc.deleteSnapshots(deleter)
+	rc.deleteDisks(deleter)
+
+	return deleter.result()
 }
 
-func NewGCPeResourcesCleaner(secretData map[string][]byte) ResourceCleaner {
-	return &gcpResourceCleaner{}
+func (rc gcpResourceCleaner) deleteForwardingRules(deleter *resourceDeleter) {
+	rulesPerRegion := map[string][]string{}
+	err := rc.service.ForwardingRules.AggregatedList(rc.project).Pages(context.TODO(), func(page *compute.ForwardingRuleAggregatedList) error {
+		for scope, scopedList := range page.Items {
+			for _, rule := range scopedList.ForwardingRules {
+				rulesPerRegion[scope] = append(rulesPerRegion[scope], rule.Name)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		deleter.fail(fmt.Errorf("while listing forwarding rules: %w", err))
+		return
+	}
+	for _, scope := range sortedScopes(rulesPerRegion) {
+		region := path.Base(scope)
+		for _, name := range rulesPerRegion[scope] {
+			name := name
+			deleter.delete(Resource{Kind: LoadBalancer, Region: region, Name: name}, func() error {
+				return rc.wait(rc.service.ForwardingRules.Delete(rc.project, region, name).Do())
+			})
+		}
+	}
 }
 
-func (rc gcpResourceCleaner) Do() error {
+func (rc gcpResourceCleaner) deleteTargetPools(deleter *resourceDeleter) {
+	poolsPerRegion := map[string][]string{}
+	err := rc.service.TargetPools.AggregatedList(rc.project).Pages(context.TODO(), func(page *compute.TargetPoolAggregatedList) error {
+		for scope, scopedList := range page.Items {
+			for _, pool := range scopedList.TargetPools {
+				poolsPerRegion[scope] = append(poolsPerRegion[scope], pool.Name)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		deleter.fail(fmt.Errorf("while listing target pools: %w", err))
+		return
+	}
+	for _, scope := range sortedScopes(poolsPerRegion) {
+		region := path.Base(scope)
+		for _, name := range poolsPerRegion[scope] {
+			name := name
+			deleter.delete(Resource{Kind: LoadBalancer, Region: region, Name: name}, func() error {
+				return rc.wait(rc.service.TargetPools.Delete(rc.project, region, name).Do())
+			})
+		}
+	}
+}
+
+func (rc gcpResourceCleaner) deleteAddresses(deleter *resourceDeleter) {
+	addressesPerRegion := map[string][]string{}
+	err := rc.service.Addresses.AggregatedList(rc.project).Pages(context.TODO(), func(page *compute.AddressAggregatedList) error {
+		for scope, scopedList := range page.Items {
+			for _, address := range scopedList.Addresses {
+				addressesPerRegion[scope] = append(addressesPerRegion[scope], address.Name)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		deleter.fail(fmt.Errorf("while listing addresses: %w", err))
+		return
+	}
+	for _, scope := range sortedScopes(addressesPerRegion) {
+		region := path.Base(scope)
+		for _, name := range addressesPerRegion[scope] {
+			name := name
+			deleter.delete(Resource{Kind: PublicIP, Region: region, Name: name}, func() error {
+				return rc.wait(rc.service.Addresses.Delete(rc.project, region, name).Do())
+			})
+		}
+	}
+}
+
+func (rc gcpResourceCleaner) deleteFirewalls(deleter *resourceDeleter) {
+	var firewalls []string
+	err := rc.service.Firewalls.List(rc.project).Pages(context.TODO(), func(page *compute.FirewallList) error {
+		for _, firewall := range page.Items {
+			// the rules of the default network are part of every project
+			if path.Base(firewall.Network) == "default" {
+				continue
+			}
+			firewalls = append(firewalls, firewall.Name)
+		}
+		return nil
+	})
+	if err != nil {
+		deleter.fail(fmt.Errorf("while listing firewalls: %w", err))
+		return
+	}
+	for _, name := range firewalls {
+		name := name
+		deleter.delete(Resource{Kind: SecurityGroup, Name: name}, func() error {
+			return rc.wait(rc.service.Firewalls.Delete(rc.project, name).Do())
+		})
+	}
+}
+
+func (rc gcpResourceCleaner) deleteSnapshots(deleter *resourceDeleter) {
+	var snapshots []string
+	err := rc.service.Snapshots.List(rc.project).Pages(context.TODO(), func(page *compute.SnapshotList) error {
+		for _, snapshot := range page.Items {
+			snapshots = append(snapshots, snapshot.Name)
+		}
+		return nil
+	})
+	if err != nil {
+		deleter.fail(fmt.Errorf("while listing snapshots: %w", err))
+		return
+	}
+	for _, name := range snapshots {
+		name := name
+		deleter.delete(Resource{Kind: Snapshot, Name: name}, func() error {
+			return rc.wait(rc.service.Snapshots.Delete(rc.project, name).Do())
+		})
+	}
+}
+
+func (rc gcpResourceCleaner) deleteDisks(deleter *resourceDeleter) {
+	disksPerZone := map[string][]*compute.Disk{}
+	err := rc.service.Disks.AggregatedList(rc.project).Pages(context.TODO(), func(page *compute.DiskAggregatedList) error {
+		for scope, scopedList := range page.Items {
+			disksPerZone[scope] = append(disksPerZone[scope], scopedList.Disks...)
+		}
+		return nil
+	})
+	if err != nil {
+		deleter.fail(fmt.Errorf("while listing disks: %w", err))
+		return
+	}
+	for _, scope := range sortedScopes(disksPerZone) {
+		zone := path.Base(scope)
+		for _, disk := range disksPerZone[scope] {
+			resource := Resource{Kind: Volume, Region: zone, Name: disk.Name}
+			if len(disk.Users) > 0 {
+				deleter.keep(resource, "is attached to an instance")
+				continue
+			}
+			name := disk.Name
+			deleter.delete(resource, func() error {
+				return rc.wait(rc.service.Disks.Delete(rc.project, zone, name).Do())
+			})
+		}
+	}
+}
+
+// wait waits for the deletion operation, so the resources the deleted one depends on can be deleted afterwards,
+// a resource which is already gone is not reported
+func (rc gcpResourceCleaner) wait(operation *compute.Operation, err error) error {
+	var apiErr *googleapi.Error
+	switch {
+	case errors.As(err, &apiErr) && apiErr.Code == http.StatusNotFound:
+		return nil
+	case err != nil:
+		return err
+	}
+
+	for attempt := 0; operation.Status != "DONE"; attempt++ {
+		if attempt == gcpOperationWaitAttempts {
+			return fmt.Errorf("operation %s did not finish", operation.Name)
+		}
+		switch {
+		case operation.Zone != "":
+			operation, err = rc.service.ZoneOperations.Wait(rc.project, path.Base(operation.Zone), operation.Name).Do()
+		case operation.Region != "":
+			operation, err = rc.service.RegionOperations.Wait(rc.project, path.Base(operation.Region), operation.Name).Do()
+		default:
+			operation, err = rc.service.GlobalOperations.Wait(rc.project, operation.Name).Do()
+		}
+		if err != nil {
+			return fmt.Errorf("while waiting for operation: %w", err)
+		}
+	}
+
+	if operation.Error != nil && len(operation.Error.Errors) > 0 {
+		messages := make([]string, 0, len(operation.Error.Errors))
+		for _, operationErr := range operation.Error.Errors {
+			messages = append(messages, fmt.Sprintf("%s: %s", operationErr.Code, operationErr.Message))
+		}
+		return fmt.Errorf("operation failed: %s", strings.Join(messages, ", "))
+	}
 	return nil
 }
+
+func sortedScopes[T any](resourcesPerScope map[string][]T) []string {
+	scopes := make([]string, 0, len(resourcesPerScope))
+	for scope := range resourcesPerScope {
+		scopes = append(scopes, scope)
+	}
+	sort.Strings(scopes)
+	return scopes
+}
@@ -0,0 +1,170 @@
+package cloudprovider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/option"
+)
+
+func TestGCPResourceCleaner_Do(t *testing.T) {
+	t.Run("should delete all resources", func(t *testing.T) {
+		// given
+		fake := &gcpFake{t: t}
+		cleaner := newTestGCPResourceCleaner(t, fake, false)
+
+		// when
+		report, err := cleaner.Do()
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"regions/europe-west1/forwardingRules/lb-1",
+			"regions/europe-west1/targetPools/lb-1",
+			"regions/europe-west1/addresses/ip-1",
+			"global/firewalls/k8s-fw-lb-1",
+			"global/snapshots/snapshot-1",
+			"zones/europe-west1-b/disks/disk-1",
+		}, fake.deleted)
+		assert.Contains(t, report.Resources, Resource{Kind: Volume, Region: "europe-west1-b", Name: "disk-1"})
+		assert.Empty(t, report.Failed)
+	})
+
+	t.Run("should only report the resources in the dry-run mode", func(t *testing.T) {
+		// given
+		fake := &gcpFake{t: t}
+		cleaner := newTestGCPResourceCleaner(t, fake, true)
+
+		// when
+		report, err := cleaner.Do()
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, fake.deleted)
+		assert.Len(t, report.Resources, 6)
+	})
+
+	t.Run("should fail when the deletion operation fails", func(t *testing.T) {
+		// given
+		fake := &gcpFake{t: t, failing: "global/snapshots/snapshot-1"}
+		cleaner := newTestGCPResourceCleaner(t, fake, false)
+
+		// when
+		report, err := cleaner.Do()
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "while deleting snapshot snapshot-1: operation failed: RESOURCE_IN_USE_BY_ANOTHER_RESOURCE")
+		assert.Equal(t, []Resource{{Kind: Snapshot, Name: "snapshot-1"}}, report.Failed)
+		assert.Contains(t, fake.deleted, "zones/europe-west1-b/disks/disk-1")
+	})
+}
+
+func TestNewGCPResourcesCleaner(t *testing.T) {
+	// when
+	_, err := NewGCPResourcesCleaner(map[string][]byte{"serviceaccount.json": []byte(`{"type": "service_account"}`)}, false)
+
+	// then
+	assert.EqualError(t, err, "project_id not provided in the service account")
+}
+
+func newTestGCPResourceCleaner(t *testing.T, fake *gcpFake, dryRun bool) ResourceCleaner {
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	service, err := compute.NewService(context.Background(), option.WithEndpoint(server.URL+"/"), option.WithHTTPClient(server.Client()))
+	require.NoError(t, err)
+	return newGCPResourceCleaner(service, "project-1", dryRun)
+}
+
+// gcpFake serves the compute API with one resource of each kind, the deletion operations finish after one wait call
+type gcpFake struct {
+	t          *testing.T
+	mu         sync.Mutex
+	deleted    []string
+	failing    string
+	operations map[string]string
+}
+
+func (f *gcpFake) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	resourcePath := strings.TrimPrefix(r.URL.Path, "/projects/project-1/")
+
+	switch {
+	case r.Method == http.MethodGet:
+		lists := map[string]string{
+			"aggregated/forwardingRules": `{"items": {"regions/europe-west1": {"forwardingRules": [{"name": "lb-1"}]}, "regions/us-east1": {"warning": {"code": "NO_RESULTS_ON_PAGE"}}}}`,
+			"aggregated/targetPools":     `{"items": {"regions/europe-west1": {"targetPools": [{"name": "lb-1"}]}}}`,
+			"aggregated/addresses":       `{"items": {"regions/europe-west1": {"addresses": [{"name": "ip-1"}]}}}`,
+			"aggregated/disks":           `{"items": {"zones/europe-west1-b": {"disks": [{"name": "disk-1"}]}}}`,
+			"global/snapshots":           `{"items": [{"name": "snapshot-1"}]}`,
+			"global/firewalls": `{"items": [
+				{"name": "default-allow-ssh", "network": "https://compute.googleapis.com/compute/v1/projects/project-1/global/networks/default"},
+				{"name": "k8s-fw-lb-1", "network": "https://compute.googleapis.com/compute/v1/projects/project-1/global/networks/shoot--kyma--c1"}
+			]}`,
+		}
+		list, found := lists[resourcePath]
+		require.True(f.t, found, "unexpected list %s", resourcePath)
+		fmt.Fprint(w, list)
+	case r.Method == http.MethodDelete:
+		f.mu.Lock()
+		if resourcePath != f.failing {
+			f.deleted = append(f.deleted, resourcePath)
+		}
+		if f.operations == nil {
+			f.operations = map[string]string{}
+		}
+		name := fmt.Sprintf("operation-%d", len(f.operations))
+		f.operations[name] = resourcePath
+		f.mu.Unlock()
+		f.writeOperation(w, name, resourcePath, "RUNNING")
+	case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/wait"):
+		name := path.Base(strings.TrimSuffix(r.URL.Path, "/wait"))
+		f.mu.Lock()
+		resourcePath, found := f.operations[name]
+		f.mu.Unlock()
+		require.True(f.t, found, "unexpected operation %s", name)
+		require.True(f.t, strings.HasPrefix(strings.TrimPrefix(r.URL.Path, "/projects/project-1/"), scopeOf(resourcePath)), "operation %s waited in wrong scope", name)
+		f.writeOperation(w, name, resourcePath, "DONE")
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (f *gcpFake) writeOperation(w http.ResponseWriter, name, resourcePath, status string) {
+	operation := map[string]interface{}{
+		"name":   name,
+		"status": status,
+	}
+	switch scope := scopeOf(resourcePath); {
+	case strings.HasPrefix(scope, "zones/"):
+		operation["zone"] = "https://compute.googleapis.com/compute/v1/projects/project-1/" + scope
+	case strings.HasPrefix(scope, "regions/"):
+		operation["region"] = "https://compute.googleapis.com/compute/v1/projects/project-1/" + scope
+	}
+	if status == "DONE" && resourcePath == f.failing {
+		operation["error"] = map[string]interface{}{
+			"errors": []map[string]string{{"code": "RESOURCE_IN_USE_BY_ANOTHER_RESOURCE", "message": "the snapshot is in use"}},
+		}
+	}
+	require.NoError(f.t, json.NewEncoder(w).Encode(operation))
+}
+
+// scopeOf returns the zone, the region or global scope of the resource path
+func scopeOf(resourcePath string) string {
+	parts := strings.SplitN(resourcePath, "/", 3)
+	if parts[0] == "global" {
+		return "global"
+	}
+	return parts[0] + "/" + parts[1]
+}
@@ -0,0 +1,70 @@
+package cloudprovider
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+)
+
+// statusError is returned by doJSON for responses with a status other than 2xx
+type statusError struct {
+	method     string
+	url        string
+	statusCode int
+	body       string
+}
+
+func (e statusError) Error() string {
+	return fmt.Sprintf("%s %s failed with status %d: %s", e.method, e.url, e.statusCode, e.body)
+}
+
+func isNotFound(err error) bool {
+	var statusErr statusError
+	return errors.As(err, &statusErr) && statusErr.statusCode == http.StatusNotFound
+}
+
+// doJSON sends the request with the JSON encoded input and decodes the JSON response into the output, both are optional.
+// It returns the headers of the response.
+func doJSON(httpClient *http.Client, method, url string, header http.Header, input, output interface{}) (http.Header, error) {
+	var body io.Reader
+	if input != nil {
+		encoded, err := json.Marshal(input)
+		if err != nil {
+			return nil, fmt.Errorf("while encoding request body: %w", err)
+		}
+		body = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return nil, fmt.Errorf("while creating request: %w", err)
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	if input != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("while calling %s %s: %w", method, url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		responseBody, _ := ioutil.ReadAll(resp.Body)
+		return nil, statusError{method: method, url: url, statusCode: resp.StatusCode, body: string(responseBody)}
+	}
+	if output != nil {
+		if err := json.NewDecoder(resp.Body).Decode(output); err != nil {
+			return nil, fmt.Errorf("while decoding response of %s %s: %w", method, url, err)
+		}
+	}
+	return resp.Header, nil
+}
@@ -0,0 +1,219 @@
+package cloudprovider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	openstackLoadBalancerService = "load-balancer"
+	openstackNetworkService      = "network"
+	openstackVolumeService       = "volumev3"
+)
+
+type openstackResourceCleaner struct {
+	httpClient  *http.Client
+	credentials openstackCredentials
+	dryRun      bool
+}
+
+type openstackCredentials struct {
+	authURL    string
+	domainName string
+	tenantName string
+	username   string
+	password   string
+}
+
+type openstackEndpoint struct {
+	region string
+	url    string
+}
+
+type openstackResource struct {
+	ID                string `json:"id"`
+	Name              string `json:"name"`
+	Status            string `json:"status"`
+	FloatingIPAddress string `json:"floating_ip_address"`
+}
+
+// NewOpenstackResourcesCleaner creates the cleaner of the OpenStack project, the Keystone v3 URL is taken from the authURL key
+// of the secret and defaults to the given one, as the Gardener secrets of OpenStack do not need to contain it
+func NewOpenstackResourcesCleaner(secretData map[string][]byte, defaultAuthURL string, dryRun bool) (ResourceCleaner, error) {
+	credentials := openstackCredentials{authURL: defaultAuthURL}
+	if authURL, exists := secretData["authURL"]; exists {
+		credentials.authURL = string(authURL)
+	}
+	if credentials.authURL == "" {
+		return nil, fmt.Errorf("authURL not provided in the secret nor in the configuration")
+	}
+	for key, value := range map[string]*string{
+		"domainName": &credentials.domainName,
+		"tenantName": &credentials.tenantName,
+		"username":   &credentials.username,
+		"password":   &credentials.password,
+	} {
+		data, exists := secretData[key]
+		if !exists {
+			return nil, fmt.Errorf("%s not provided in the secret", key)
+		}
+		*value = string(data)
+	}
+
+	return newOpenstackResourceCleaner(&http.Client{Timeout: 30 * time.Second}, credentials, dryRun), nil
+}
+
+func newOpenstackResourceCleaner(httpClient *http.Client, credentials openstackCredentials, dryRun bool) *openstackResourceCleaner {
+	return &openstackResourceCleaner{
+		httpClient:  httpClient,
+		credentials: credentials,
+		dryRun:      dryRun,
+	}
+}
+
+func (rc openstackResourceCleaner) Do() (Report, error) {
+	deleter := newResourceDeleter(rc.dryRun)
+	token, endpoints, err := rc.authenticate()
+	if err != nil {
+		return deleter.report, err
+	}
+	header := http.Header{"X-Auth-Token": {token}}
+
+	// the load balancers hold the floating IPs and reference the security groups, so they go first
+	for _, endpoint := range endpoints[openstackLoadBalancerService] {
+		rc.deleteAll(deleter, header, LoadBalancer, endpoint.region, endpoint.url+"/v2/lbaas/loadbalancers", "loadbalancers", "?cascade=true")
+	}
+	for _, endpoint := range endpoints[openstackNetworkService] {
+		rc.deleteAll(deleter, header, PublicIP, endpoint.region, endpoint.url+"/v2.0/floatingips", "floatingips", "")
+		rc.deleteAll(deleter, header, SecurityGroup, endpoint.region, endpoint.url+"/v2.0/security-groups", "security_groups", "")
+	}
+	for _, endpoint := range endpoints[openstackVolumeService] {
+		rc.deleteAll(deleter, header, Snapshot, endpoint.region, endpoint.url+"/snapshots", "snapshots", "")
+		rc.deleteVolumes(deleter, header, endpoint)
+	}
+
+	return deleter.result()
+}
+
+func (rc openstackResourceCleaner) deleteAll(deleter *resourceDeleter, header http.Header, kind ResourceKind, region, collectionURL, field, deleteQuery string) {
+	resources, err := rc.list(header, collectionURL, field)
+	if err != nil {
+		deleter.fail(fmt.Errorf("while listing %s in %s: %w", field, region, err))
+		return
+	}
+	for _, resource := range resources {
+		// every project has a default security group which cannot be deleted
+		if kind == SecurityGroup && resource.Name == "default" {
+			continue
+		}
+		name := resource.ID
+		if resource.FloatingIPAddress != "" {
+			name = resource.FloatingIPAddress
+		}
+		resourceURL := fmt.Sprintf("%s/%s%s", collectionURL, resource.ID, deleteQuery)
+		deleter.delete(Resource{Kind: kind, Region: region, Name: name}, func() error {
+			return rc.delete(header, resourceURL)
+		})
+	}
+}
+
+func (rc openstackResourceCleaner) deleteVolumes(deleter *resourceDeleter, header http.Header, endpoint openstackEndpoint) {
+	volumes, err := rc.list(header, endpoint.url+"/volumes/detail", "volumes")
+	if err != nil {
+		deleter.fail(fmt.Errorf("while listing volumes in %s: %w", endpoint.region, err))
+		return
+	}
+	for _, volume := range volumes {
+		resource := Resource{Kind: Volume, Region: endpoint.region, Name: volume.ID}
+		if volume.Status == "in-use" {
+			deleter.keep(resource, "is attached to an instance")
+			continue
+		}
+		resourceURL := fmt.Sprintf("%s/volumes/%s", endpoint.url, volume.ID)
+		deleter.delete(resource, func() error {
+			return rc.delete(header, resourceURL)
+		})
+	}
+}
+
+func (rc openstackResourceCleaner) list(header http.Header, collectionURL, field string) ([]openstackResource, error) {
+	response := map[string]json.RawMessage{}
+	if _, err := doJSON(rc.httpClient, http.MethodGet, collectionURL, header, nil, &response); err != nil {
+		return nil, err
+	}
+	var resources []openstackResource
+	if raw, found := response[field]; found {
+		if err := json.Unmarshal(raw, &resources); err != nil {
+			return nil, fmt.Errorf("while decoding %s: %w", field, err)
+		}
+	}
+	return resources, nil
+}
+
+func (rc openstackResourceCleaner) delete(header http.Header, resourceURL string) error {
+	_, err := doJSON(rc.httpClient, http.MethodDelete, resourceURL, header, nil, nil)
+	if isNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// authenticate gets the project scoped token from Keystone, together with the public endpoints of the services per region
+func (rc openstackResourceCleaner) authenticate() (string, map[string][]openstackEndpoint, error) {
+	domain := map[string]string{"name": rc.credentials.domainName}
+	request := map[string]interface{}{
+		"auth": map[string]interface{}{
+			"identity": map[string]interface{}{
+				"methods": []string{"password"},
+				"password": map[string]interface{}{
+					"user": map[string]interface{}{
+						"name":     rc.credentials.username,
+						"domain":   domain,
+						"password": rc.credentials.password,
+					},
+				},
+			},
+			"scope": map[string]interface{}{
+				"project": map[string]interface{}{
+					"name":   rc.credentials.tenantName,
+					"domain": domain,
+				},
+			},
+		},
+	}
+	response := struct {
+		Token struct {
+			Catalog []struct {
+				Type      string `json:"type"`
+				Endpoints []struct {
+					Interface string `json:"interface"`
+					Region    string `json:"region"`
+					URL       string `json:"url"`
+				} `json:"endpoints"`
+			} `json:"catalog"`
+		} `json:"token"`
+	}{}
+
+	authURL := fmt.Sprintf("%s/auth/tokens", strings.TrimSuffix(rc.credentials.authURL, "/"))
+	header, err := doJSON(rc.httpClient, http.MethodPost, authURL, nil, request, &response)
+	if err != nil {
+		return "", nil, fmt.Errorf("while authenticating in Keystone: %w", err)
+	}
+
+	endpoints := map[string][]openstackEndpoint{}
+	for _, service := range response.Token.Catalog {
+		for _, endpoint := range service.Endpoints {
+			if endpoint.Interface != "public" {
+				continue
+			}
+			endpoints[service.Type] = append(endpoints[service.Type], openstackEndpoint{
+				region: endpoint.Region,
+				url:    strings.TrimSuffix(endpoint.URL, "/"),
+			})
+		}
+	}
+	return header.Get("X-Subject-Token"), endpoints, nil
+}
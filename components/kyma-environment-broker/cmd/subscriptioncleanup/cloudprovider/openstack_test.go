@@ -0,0 +1,187 @@
+package cloudprovider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenstackResourceCleaner_Do(t *testing.T) {
+	t.Run("should delete all resources", func(t *testing.T) {
+		// given
+		fake := &openstackFake{t: t, volumeStatus: "available"}
+		cleaner := newTestOpenstackResourceCleaner(t, fake, false)
+
+		// when
+		report, err := cleaner.Do()
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"/lb/v2/lbaas/loadbalancers/lb-1?cascade=true",
+			"/network/v2.0/floatingips/fip-1",
+			"/network/v2.0/security-groups/sg-1",
+			"/volume/v3/project-1/snapshots/snap-1",
+			"/volume/v3/project-1/volumes/vol-1",
+		}, fake.deleted)
+		assert.Contains(t, report.Resources, Resource{Kind: PublicIP, Region: "eu-de-1", Name: "10.0.0.1"})
+		assert.Empty(t, report.Failed)
+	})
+
+	t.Run("should only report the resources in the dry-run mode", func(t *testing.T) {
+		// given
+		fake := &openstackFake{t: t, volumeStatus: "available"}
+		cleaner := newTestOpenstackResourceCleaner(t, fake, true)
+
+		// when
+		report, err := cleaner.Do()
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, fake.deleted)
+		assert.Len(t, report.Resources, 5)
+	})
+
+	t.Run("should fail when a volume is attached", func(t *testing.T) {
+		// given
+		fake := &openstackFake{t: t, volumeStatus: "in-use"}
+		cleaner := newTestOpenstackResourceCleaner(t, fake, false)
+
+		// when
+		report, err := cleaner.Do()
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "volume vol-1 in eu-de-1 is attached to an instance")
+		assert.Equal(t, []Resource{{Kind: Volume, Region: "eu-de-1", Name: "vol-1"}}, report.Failed)
+		assert.Len(t, fake.deleted, 4)
+	})
+
+	t.Run("should fail when the authentication fails", func(t *testing.T) {
+		// given
+		fake := &openstackFake{t: t, password: "other"}
+		cleaner := newTestOpenstackResourceCleaner(t, fake, false)
+
+		// when
+		_, err := cleaner.Do()
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "while authenticating in Keystone")
+	})
+}
+
+func TestNewOpenstackResourcesCleaner(t *testing.T) {
+	secretData := map[string][]byte{
+		"domainName": []byte("domain"),
+		"tenantName": []byte("project"),
+		"username":   []byte("user"),
+		"password":   []byte("secret"),
+	}
+
+	t.Run("should use the default auth URL", func(t *testing.T) {
+		// when
+		cleaner, err := NewOpenstackResourcesCleaner(secretData, "https://keystone.local/v3", false)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "https://keystone.local/v3", cleaner.(*openstackResourceCleaner).credentials.authURL)
+	})
+
+	t.Run("should fail without auth URL", func(t *testing.T) {
+		// when
+		_, err := NewOpenstackResourcesCleaner(secretData, "", false)
+
+		// then
+		assert.EqualError(t, err, "authURL not provided in the secret nor in the configuration")
+	})
+}
+
+func newTestOpenstackResourceCleaner(t *testing.T, fake *openstackFake, dryRun bool) ResourceCleaner {
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+	fake.url = server.URL
+
+	return newOpenstackResourceCleaner(server.Client(), openstackCredentials{
+		authURL:    server.URL + "/identity/v3/",
+		domainName: "domain",
+		tenantName: "project",
+		username:   "user",
+		password:   "secret",
+	}, dryRun)
+}
+
+// openstackFake serves Keystone, Octavia, Neutron and Cinder with a single region and one resource of each kind
+type openstackFake struct {
+	t            *testing.T
+	url          string
+	mu           sync.Mutex
+	deleted      []string
+	volumeStatus string
+	password     string
+}
+
+func (f *openstackFake) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if r.URL.Path == "/identity/v3/auth/tokens" {
+		f.authenticate(w, r)
+		return
+	}
+	if r.Header.Get("X-Auth-Token") != "token-1" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		lists := map[string]string{
+			"/lb/v2/lbaas/loadbalancers":          `{"loadbalancers": [{"id": "lb-1"}]}`,
+			"/network/v2.0/floatingips":           `{"floatingips": [{"id": "fip-1", "floating_ip_address": "10.0.0.1"}]}`,
+			"/network/v2.0/security-groups":       `{"security_groups": [{"id": "sg-0", "name": "default"}, {"id": "sg-1", "name": "kyma"}]}`,
+			"/volume/v3/project-1/snapshots":      `{"snapshots": [{"id": "snap-1"}]}`,
+			"/volume/v3/project-1/volumes/detail": fmt.Sprintf(`{"volumes": [{"id": "vol-1", "status": %q}]}`, f.volumeStatus),
+		}
+		list, found := lists[r.URL.Path]
+		require.True(f.t, found, "unexpected list %s", r.URL.Path)
+		fmt.Fprint(w, list)
+	case http.MethodDelete:
+		f.mu.Lock()
+		f.deleted = append(f.deleted, r.URL.RequestURI())
+		f.mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (f *openstackFake) authenticate(w http.ResponseWriter, r *http.Request) {
+	request := struct {
+		Auth struct {
+			Identity struct {
+				Password struct {
+					User struct {
+						Name     string `json:"name"`
+						Password string `json:"password"`
+					} `json:"user"`
+				} `json:"password"`
+			} `json:"identity"`
+		} `json:"auth"`
+	}{}
+	require.NoError(f.t, json.NewDecoder(r.Body).Decode(&request))
+	if request.Auth.Identity.Password.User.Password != "secret" || f.password != "" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	w.Header().Set("X-Subject-Token", "token-1")
+	w.WriteHeader(http.StatusCreated)
+	fmt.Fprintf(w, `{"token": {"catalog": [
+		{"type": "load-balancer", "endpoints": [{"interface": "public", "region": "eu-de-1", "url": "%[1]s/lb"}]},
+		{"type": "network", "endpoints": [{"interface": "public", "region": "eu-de-1", "url": "%[1]s/network/"}, {"interface": "internal", "region": "eu-de-1", "url": "http://internal"}]},
+		{"type": "volumev3", "endpoints": [{"interface": "public", "region": "eu-de-1", "url": "%[1]s/volume/v3/project-1"}]}
+	]}}`, f.url)
+}
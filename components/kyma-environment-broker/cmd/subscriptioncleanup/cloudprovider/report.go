@@ -0,0 +1,101 @@
+package cloudprovider
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+	log "github.com/sirupsen/logrus"
+)
+
+// ResourceKind names the kind of a hyperscaler resource which can be left in an account after its clusters were deleted
+type ResourceKind string
+
+const (
+	LoadBalancer  ResourceKind = "load balancer"
+	PublicIP      ResourceKind = "public IP"
+	SecurityGroup ResourceKind = "security group"
+	Snapshot      ResourceKind = "snapshot"
+	Volume        ResourceKind = "volume"
+	ResourceGroup ResourceKind = "resource group"
+)
+
+type Resource struct {
+	Kind   ResourceKind
+	Region string
+	Name   string
+}
+
+func (r Resource) String() string {
+	if r.Region == "" {
+		return fmt.Sprintf("%s %s", r.Kind, r.Name)
+	}
+	return fmt.Sprintf("%s %s in %s", r.Kind, r.Name, r.Region)
+}
+
+// Report lists the resources found by a ResourceCleaner, the ones which could not be deleted are listed in Failed as well.
+// In the dry-run mode the resources are only listed and nothing is deleted.
+type Report struct {
+	DryRun    bool
+	Resources []Resource
+	Failed    []Resource
+}
+
+func (r Report) String() string {
+	if len(r.Resources) == 0 {
+		return "no resources found"
+	}
+	names := make([]string, 0, len(r.Resources))
+	for _, resource := range r.Resources {
+		names = append(names, resource.String())
+	}
+	switch {
+	case r.DryRun:
+		return fmt.Sprintf("%d resources to delete (dry run): %s", len(r.Resources), strings.Join(names, ", "))
+	case len(r.Failed) > 0:
+		return fmt.Sprintf("%d of %d resources not deleted: %s", len(r.Failed), len(r.Resources), strings.Join(names, ", "))
+	default:
+		return fmt.Sprintf("%d resources deleted: %s", len(r.Resources), strings.Join(names, ", "))
+	}
+}
+
+// resourceDeleter deletes the resources one by one and collects the report, a failed deletion does not stop the following ones
+// but makes the whole cleanup fail, so the secret binding stays dirty and the cleanup is repeated in the next run
+type resourceDeleter struct {
+	report Report
+	err    error
+}
+
+func newResourceDeleter(dryRun bool) *resourceDeleter {
+	return &resourceDeleter{report: Report{DryRun: dryRun}}
+}
+
+func (d *resourceDeleter) delete(resource Resource, deleteFunc func() error) {
+	d.report.Resources = append(d.report.Resources, resource)
+	if d.report.DryRun {
+		log.Infof("Dry run: %s would be deleted", resource)
+		return
+	}
+
+	log.Infof("Deleting %s", resource)
+	if err := deleteFunc(); err != nil {
+		d.report.Failed = append(d.report.Failed, resource)
+		d.err = multierror.Append(d.err, fmt.Errorf("while deleting %s: %w", resource, err))
+	}
+}
+
+// keep reports a resource which blocks the cleanup and cannot be deleted, e.g. a volume still attached to a machine
+func (d *resourceDeleter) keep(resource Resource, reason string) {
+	d.report.Resources = append(d.report.Resources, resource)
+	d.report.Failed = append(d.report.Failed, resource)
+	d.err = multierror.Append(d.err, fmt.Errorf("%s %s", resource, reason))
+}
+
+// fail records an error which is not bound to a single resource, e.g. a failed listing
+func (d *resourceDeleter) fail(err error) {
+	d.err = multierror.Append(d.err, err)
+}
+
+func (d *resourceDeleter) result() (Report, error) {
+	return d.report, d.err
+}
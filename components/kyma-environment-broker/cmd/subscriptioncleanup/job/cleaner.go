@@ -62,9 +62,13 @@ func (p *cleaner) Do() error {
 			continue
 		}
 
-		err = p.releaseResources(secretBinding)
+		report, err := p.releaseResources(secretBinding)
 		if err != nil {
-			logrus.Errorf("Failed to release resources for '%s' secret binding: %s", secretBinding.GetName(), err.Error())
+			logrus.Errorf("Failed to release resources for '%s' secret binding, %s: %s", secretBinding.GetName(), report, err.Error())
+			continue
+		}
+		if report.DryRun {
+			logrus.Infof("Dry run for '%s' secret binding, %s", secretBinding.GetName(), report)
 			continue
 		}
 		err = p.returnSecretBindingToThePool(secretBinding)
@@ -72,27 +76,28 @@ func (p *cleaner) Do() error {
 			logrus.Errorf("Failed returning '%s' secret binding to the pool: %s", secretBinding.GetName(), err.Error())
 			continue
 		}
-		logrus.Infof("Resources released for '%s' secret binding", secretBinding.GetName())
+		logrus.Infof("Resources released for '%s' secret binding, %s", secretBinding.GetName(), report)
 	}
 
 	logrus.Info("Finished releasing resources")
 	return nil
 }
 
-func (p *cleaner) releaseResources(secretBinding unstructured.Unstructured) error {
+// releaseResources deletes the resources left in the hyperscaler account, the secret binding stays dirty if any of them is not deleted
+func (p *cleaner) releaseResources(secretBinding unstructured.Unstructured) (cloudprovider.Report, error) {
 	hyperscalerType, err := model.NewHyperscalerType(secretBinding.GetLabels()["hyperscalerType"])
 	if err != nil {
-		return fmt.Errorf("starting releasing resources: %w", err)
+		return cloudprovider.Report{}, fmt.Errorf("starting releasing resources: %w", err)
 	}
 
 	secret, err := p.getBoundSecret(secretBinding)
 	if err != nil {
-		return fmt.Errorf("getting referenced secret: %w", err)
+		return cloudprovider.Report{}, fmt.Errorf("getting referenced secret: %w", err)
 	}
 
 	cleaner, err := p.providerFactory.New(hyperscalerType, secret.Data)
 	if err != nil {
-		return fmt.Errorf("initializing cloud provider cleaner: %w", err)
+		return cloudprovider.Report{}, fmt.Errorf("initializing cloud provider cleaner: %w", err)
 	}
 
 	return cleaner.Do()
@@ -130,7 +135,8 @@ func (p *cleaner) returnSecretBindingToThePool(secretBinding unstructured.Unstru
 }
 
 func (p *cleaner) getSecretBindingsToRelease() ([]unstructured.Unstructured, error) {
-	labelSelector := fmt.Sprintf("dirty=true")
+	// secret bindings of the customer hyperscaler accounts are deleted with the instance and never returned to the pool
+	labelSelector := fmt.Sprintf("dirty=true, !customerAccount")
 
 	return getSecretBindings(p.context, p.secretBindingsClient, labelSelector)
 }
@@ -2,8 +2,10 @@ package job
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/cmd/subscriptioncleanup/cloudprovider"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/cmd/subscriptioncleanup/cloudprovider/mocks"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/cmd/subscriptioncleanup/model"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/gardener"
@@ -153,10 +155,79 @@ func TestCleanerJob(t *testing.T) {
 	})
 }
 
+func TestCleanerJob_KeepsSecretBindingDirty(t *testing.T) {
+	for tn, resCleaner := range map[string]*azureMockResourceCleaner{
+		"when the deletion of a resource fails": {
+			report: cloudprovider.Report{
+				Resources: []cloudprovider.Resource{{Kind: cloudprovider.ResourceGroup, Name: "rg1"}},
+				Failed:    []cloudprovider.Resource{{Kind: cloudprovider.ResourceGroup, Name: "rg1"}},
+			},
+			error: fmt.Errorf("while deleting resource group rg1: conflict"),
+		},
+		"in the dry-run mode": {
+			report: cloudprovider.Report{
+				DryRun:    true,
+				Resources: []cloudprovider.Resource{{Kind: cloudprovider.ResourceGroup, Name: "rg1"}},
+			},
+		},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			//given
+			secret := &v1.Secret{
+				ObjectMeta: machineryv1.ObjectMeta{
+					Name: "secret1", Namespace: namespace,
+				},
+				Data: map[string][]byte{
+					"clientID": []byte("tenant1"),
+				},
+			}
+			secretBinding := &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"metadata": map[string]interface{}{
+						"name":      "secretBinding1",
+						"namespace": namespace,
+						"labels": map[string]interface{}{
+							"tenantName":      "tenant1",
+							"hyperscalerType": "azure",
+							"dirty":           "true",
+						},
+					},
+					"secretRef": map[string]interface{}{
+						"name":      "secret1",
+						"namespace": namespace,
+					},
+				},
+			}
+			secretBinding.SetGroupVersionKind(secretBindingGVK)
+
+			gardenerFake := gardener.NewDynamicFakeClient(secretBinding)
+			mockSecretBindings := gardenerFake.Resource(gardener.SecretBindingResource).Namespace(namespace)
+			mockShoots := gardenerFake.Resource(gardener.ShootResource).Namespace(namespace)
+
+			providerFactory := &mocks.ProviderFactory{}
+			providerFactory.On("New", model.Azure, mock.Anything).Return(resCleaner, nil)
+
+			cleaner := NewCleaner(context.Background(), fake.NewSimpleClientset(secret), mockSecretBindings, mockShoots, providerFactory)
+
+			//when
+			err := cleaner.Do()
+
+			//then
+			require.NoError(t, err)
+			cleanedSecretBinding, err := mockSecretBindings.Get(context.Background(), secretBinding.GetName(), machineryv1.GetOptions{})
+			require.NoError(t, err)
+
+			assert.Equal(t, "true", cleanedSecretBinding.GetLabels()["dirty"])
+			assert.Equal(t, "tenant1", cleanedSecretBinding.GetLabels()["tenantName"])
+		})
+	}
+}
+
 type azureMockResourceCleaner struct {
-	error error
+	report cloudprovider.Report
+	error  error
 }
 
-func (am *azureMockResourceCleaner) Do() error {
-	return am.error
+func (am *azureMockResourceCleaner) Do() (cloudprovider.Report, error) {
+	return am.report, am.error
 }
@@ -22,6 +22,12 @@ type config struct {
 		KubeconfigPath string `envconfig:"default=/gardener/kubeconfig"`
 		Project        string `envconfig:"default="`
 	}
+	// DryRun makes the job only report the resources left in the hyperscaler accounts, the secret bindings stay dirty
+	DryRun    bool `envconfig:"default=false"`
+	Openstack struct {
+		// AuthURL is the Keystone v3 URL used for the secrets without the authURL key
+		AuthURL string `envconfig:"optional"`
+	}
 }
 
 func main() {
@@ -43,7 +49,10 @@ func main() {
 	shootInterface := gardenerClient.Resource(gardener.ShootResource).Namespace(gardenerNamespace)
 	secretBindingsInterface := gardenerClient.Resource(gardener.SecretBindingResource).Namespace(gardenerNamespace)
 
-	err = job.NewCleaner(context.Background(), kubernetesInterface, secretBindingsInterface, shootInterface, cloudprovider.NewProviderFactory()).Do()
+	err = job.NewCleaner(context.Background(), kubernetesInterface, secretBindingsInterface, shootInterface, cloudprovider.NewProviderFactory(cloudprovider.Config{
+		DryRun:           cfg.DryRun,
+		OpenstackAuthURL: cfg.Openstack.AuthURL,
+	})).Do()
 	exitOnError(err, "Job execution failed")
 
 	log.Info("Cleanup job finished successfully!")
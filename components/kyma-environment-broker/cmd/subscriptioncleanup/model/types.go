@@ -5,9 +5,10 @@ import "fmt"
 type HyperscalerType string
 
 const (
-	GCP   HyperscalerType = "gcp"
-	Azure HyperscalerType = "azure"
-	AWS   HyperscalerType = "aws"
+	GCP       HyperscalerType = "gcp"
+	Azure     HyperscalerType = "azure"
+	AWS       HyperscalerType = "aws"
+	Openstack HyperscalerType = "openstack"
 )
 
 func NewHyperscalerType(provider string) (HyperscalerType, error) {
@@ -15,7 +16,7 @@ func NewHyperscalerType(provider string) (HyperscalerType, error) {
 	hyperscalerType := HyperscalerType(provider)
 
 	switch hyperscalerType {
-	case GCP, Azure, AWS:
+	case GCP, Azure, AWS, Openstack:
 		return hyperscalerType, nil
 	}
 	return "", fmt.Errorf("unknown Hyperscaler provider type: %s", provider)
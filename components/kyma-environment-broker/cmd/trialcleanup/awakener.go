@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+)
+
+// shootAwakener wakes up the cluster by disabling the hibernation of its Gardener shoot, the Provisioner can only hibernate the clusters
+type shootAwakener struct {
+	shoots     dynamic.ResourceInterface
+	operations storage.Operations
+}
+
+func newShootAwakener(shoots dynamic.ResourceInterface, operations storage.Operations) *shootAwakener {
+	return &shootAwakener{
+		shoots:     shoots,
+		operations: operations,
+	}
+}
+
+func (a *shootAwakener) WakeUpRuntime(ctx context.Context, instance internal.Instance) error {
+	operation, err := a.operations.GetProvisioningOperationByInstanceID(instance.InstanceID)
+	if err != nil {
+		return fmt.Errorf("while getting provisioning operation: %w", err)
+	}
+	if operation.ShootName == "" {
+		return fmt.Errorf("the shoot name of instance %s is not known", instance.InstanceID)
+	}
+
+	patch := []byte(`{"spec": {"hibernation": {"enabled": false}}}`)
+	if _, err := a.shoots.Patch(ctx, operation.ShootName, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		return fmt.Errorf("while disabling the hibernation of shoot %s: %w", operation.ShootName, err)
+	}
+	return nil
+}
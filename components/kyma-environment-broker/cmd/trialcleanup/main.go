@@ -2,44 +2,68 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"os"
 	"time"
 
+	"github.com/google/uuid"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/gardener"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/events"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/provisioner"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbmodel"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/trial"
+	schema "github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
 	"github.com/kyma-project/control-plane/components/schema-migrator/cleaner"
 	log "github.com/sirupsen/logrus"
 	"github.com/vrischmann/envconfig"
-)
-
-const (
-	trialPlanID = broker.TrialPlanID
+	"k8s.io/client-go/dynamic"
 )
 
 type BrokerClient interface {
 	SendExpirationRequest(instance internal.Instance) (bool, error)
 }
 
+// Hibernator hibernates the clusters of the expired trials in the grace period
+type Hibernator interface {
+	HibernateRuntime(ctx context.Context, accountID, runtimeID string) (schema.OperationStatus, error)
+}
+
+// Awakener wakes up the hibernated clusters of the trials which are used again in the grace period
+type Awakener interface {
+	WakeUpRuntime(ctx context.Context, instance internal.Instance) error
+}
+
 type Config struct {
 	Database         storage.Config
 	Broker           broker.ClientConfig
 	DryRun           bool          `envconfig:"default=true"`
 	ExpirationPeriod time.Duration `envconfig:"default=336h"`
+	// PlanDefinitionsFilePath points to the plan definitions, the instances of the plans with trial expiry are expired too
+	PlanDefinitionsFilePath string `envconfig:"optional"`
+	// ProvisionerURL is required to hibernate the clusters when the grace period is set
+	ProvisionerURL string `envconfig:"optional"`
+	// Gardener is used to wake up the hibernated clusters when the grace period is set
+	Gardener  gardener.Config
+	Lifecycle trial.Config
 }
 
 type TrialCleanupService struct {
 	cfg             Config
-	filter          dbmodel.InstanceFilter
+	policy          *trial.Policy
 	instanceStorage storage.Instances
+	trialEvents     storage.TrialEvents
 	brokerClient    BrokerClient
+	hibernator      Hibernator
+	awakener        Awakener
+	notifier        trial.Notifier
+	now             func() time.Time
 }
 
-type instancePredicate func(internal.Instance) bool
-
 func main() {
 	log.SetFormatter(&log.JSONFormatter{})
 	log.Info("Starting trial cleanup job")
@@ -53,16 +77,34 @@ func main() {
 		log.Info("Dry run only - no changes")
 	}
 
-	log.Infof("Expiration period: %+v", cfg.ExpirationPeriod)
+	log.Infof("Expiration period: %+v, warnings: %v, grace period: %+v", cfg.ExpirationPeriod, cfg.Lifecycle.Warnings, cfg.Lifecycle.GracePeriod)
+	if cfg.Lifecycle.GracePeriod > 0 && cfg.ProvisionerURL == "" {
+		fatalOnError(errors.New("the provisioner URL is required to hibernate the clusters in the grace period"))
+	}
 
 	ctx := context.Background()
 	brokerClient := broker.NewClient(ctx, cfg.Broker)
+	provisionerClient := provisioner.NewProvisionerClient(cfg.ProvisionerURL, false)
+	notifier := trial.NewNotifier(cfg.Lifecycle, http.DefaultClient, log.StandardLogger())
 
 	// create storage connection
 	cipher := storage.NewEncrypter(cfg.Database.SecretKey)
 	db, conn, err := storage.NewFromConfig(cfg.Database, events.Config{}, cipher, log.WithField("service", "storage"))
 	fatalOnError(err)
-	svc := newTrialCleanupService(cfg, brokerClient, db.Instances())
+	planDefinitions, err := broker.ReadPlanDefinitionsFromFile(cfg.PlanDefinitionsFilePath)
+	fatalOnError(err)
+	planRegistry, err := broker.NewPlanRegistry(planDefinitions)
+	fatalOnError(err)
+	var awakener Awakener
+	if cfg.Lifecycle.GracePeriod > 0 {
+		gardenerClusterConfig, err := gardener.NewGardenerClusterConfig(cfg.Gardener.KubeconfigPath)
+		fatalOnError(err)
+		gardenerClient, err := dynamic.NewForConfig(gardenerClusterConfig)
+		fatalOnError(err)
+		gardenerNamespace := fmt.Sprintf("garden-%s", cfg.Gardener.Project)
+		awakener = newShootAwakener(gardenerClient.Resource(gardener.ShootResource).Namespace(gardenerNamespace), db.Operations())
+	}
+	svc := newTrialCleanupService(cfg, planRegistry, brokerClient, provisionerClient, awakener, notifier, db.Instances(), db.TrialEvents())
 
 	err = svc.PerformCleanup()
 
@@ -80,17 +122,34 @@ func main() {
 	fatalOnError(err)
 }
 
-func newTrialCleanupService(cfg Config, brokerClient BrokerClient, instances storage.Instances) *TrialCleanupService {
+func newTrialCleanupService(cfg Config, planRegistry *broker.PlanRegistry, brokerClient BrokerClient, hibernator Hibernator, awakener Awakener, notifier trial.Notifier,
+	instances storage.Instances, trialEvents storage.TrialEvents) *TrialCleanupService {
+	planDefinitions := planRegistry.Definitions()
+	for _, definition := range planDefinitions {
+		if definition.Features.TrialExpiry > 0 {
+			log.Infof("Expiration period of plan %s: %+v", definition.Name, definition.Features.TrialExpiry)
+		}
+	}
+	inactivityPeriods := planRegistry.InactivityExpiryPeriods()
+	for planID, period := range inactivityPeriods {
+		log.Infof("Inactivity expiration period of plan %s: %+v", planRegistry.PlanNames()[planID], period)
+	}
 	return &TrialCleanupService{
 		cfg:             cfg,
+		policy:          trial.NewPolicy(cfg.Lifecycle, trial.ExpirationPeriods(cfg.ExpirationPeriod, planDefinitions), inactivityPeriods),
 		instanceStorage: instances,
+		trialEvents:     trialEvents,
 		brokerClient:    brokerClient,
+		hibernator:      hibernator,
+		awakener:        awakener,
+		notifier:        notifier,
+		now:             time.Now,
 	}
 }
 
 func (s *TrialCleanupService) PerformCleanup() error {
 
-	nonExpiredTrialInstancesFilter := dbmodel.InstanceFilter{PlanIDs: []string{trialPlanID}, Expired: &[]bool{false}[0]}
+	nonExpiredTrialInstancesFilter := dbmodel.InstanceFilter{PlanIDs: s.policy.PlanIDs(), Expired: &[]bool{false}[0]}
 	nonExpiredTrialInstances, nonExpiredTrialInstancesCount, err := s.getInstances(nonExpiredTrialInstancesFilter)
 
 	if err != nil {
@@ -98,41 +157,145 @@ func (s *TrialCleanupService) PerformCleanup() error {
 		return err
 	}
 
-	instancesToExpire, instancesToExpireCount := s.filterInstances(
-		nonExpiredTrialInstances,
-		func(instance internal.Instance) bool { return time.Since(instance.CreatedAt) >= s.cfg.ExpirationPeriod },
-	)
+	trialEvents, err := s.getTrialEvents(nonExpiredTrialInstances)
+	if err != nil {
+		log.Error(fmt.Sprintf("while getting trial events: %s", err))
+		return err
+	}
 
+	decisions := make(map[trial.Action][]instanceDecision)
+	now := s.now()
+	for _, instance := range nonExpiredTrialInstances {
+		decision := s.policy.Evaluate(instance, trialEvents[instance.InstanceID], now)
+		decisions[decision.Action] = append(decisions[decision.Action], instanceDecision{instance: instance, decision: decision})
+	}
+	instancesToWarn, instancesToHibernate, instancesToWakeUp := decisions[trial.ActionWarn], decisions[trial.ActionHibernate], decisions[trial.ActionWakeUp]
+	instancesToExpire := make([]internal.Instance, 0, len(decisions[trial.ActionExpire]))
+	for _, d := range decisions[trial.ActionExpire] {
+		instancesToExpire = append(instancesToExpire, d.instance)
+	}
+	instancesToExpireCount := len(instancesToExpire)
 	instancesToBeLeftCount := nonExpiredTrialInstancesCount - instancesToExpireCount
 
 	if s.cfg.DryRun {
+		s.logDecisions(instancesToWarn)
+		s.logDecisions(instancesToHibernate)
+		s.logDecisions(instancesToWakeUp)
 		s.logInstances(instancesToExpire)
-		log.Infof("Trials non-expired: %+v, to expire now: %+v, to be left non-expired: %+v", nonExpiredTrialInstancesCount, instancesToExpireCount, instancesToBeLeftCount)
+		log.Infof("Trials non-expired: %+v, to warn: %+v, to hibernate: %+v, to wake up: %+v, to expire now: %+v, to be left non-expired: %+v", nonExpiredTrialInstancesCount, len(instancesToWarn), len(instancesToHibernate), len(instancesToWakeUp), instancesToExpireCount, instancesToBeLeftCount)
 	} else {
+		warningFailures := s.warnInstances(instancesToWarn)
+		hibernationFailures := s.hibernateInstances(instancesToHibernate)
+		wakeUpFailures := s.wakeUpInstances(instancesToWakeUp)
 		suspensionsAcceptedCount, onlyMarkedAsExpiredCount, failuresCount := s.cleanupInstances(instancesToExpire)
-		log.Infof("Trials non-expired: %+v, to expire: %+v, left non-expired: %+v, suspension under way: %+v just marked expired: %+v, failures: %+v", nonExpiredTrialInstancesCount, instancesToExpireCount, instancesToBeLeftCount, suspensionsAcceptedCount, onlyMarkedAsExpiredCount, failuresCount)
+		log.Infof("Trials non-expired: %+v, warned: %+v (failures: %+v), hibernated: %+v (failures: %+v), woken up: %+v (failures: %+v)", nonExpiredTrialInstancesCount, len(instancesToWarn)-warningFailures, warningFailures, len(instancesToHibernate)-hibernationFailures, hibernationFailures, len(instancesToWakeUp)-wakeUpFailures, wakeUpFailures)
+		log.Infof("Trials to expire: %+v, left non-expired: %+v, suspension under way: %+v just marked expired: %+v, failures: %+v", instancesToExpireCount, instancesToBeLeftCount, suspensionsAcceptedCount, onlyMarkedAsExpiredCount, failuresCount)
 	}
 	return nil
 }
 
-func (s *TrialCleanupService) getInstances(filter dbmodel.InstanceFilter) ([]internal.Instance, int, error) {
+type instanceDecision struct {
+	instance internal.Instance
+	decision trial.Decision
+}
 
-	instances, _, totalCount, err := s.instanceStorage.List(filter)
+// getTrialEvents returns the trial events grouped by the instance ID
+func (s *TrialCleanupService) getTrialEvents(instances []internal.Instance) (map[string][]internal.TrialEvent, error) {
+	trialEvents := make(map[string][]internal.TrialEvent)
+	if len(instances) == 0 {
+		return trialEvents, nil
+	}
+	instanceIDs := make([]string, 0, len(instances))
+	for _, instance := range instances {
+		instanceIDs = append(instanceIDs, instance.InstanceID)
+	}
+
+	events, err := s.trialEvents.List(dbmodel.TrialEventFilter{InstanceIDs: instanceIDs})
 	if err != nil {
-		return []internal.Instance{}, 0, err
+		return nil, err
+	}
+	for _, event := range events {
+		trialEvents[event.InstanceID] = append(trialEvents[event.InstanceID], event)
 	}
+	return trialEvents, nil
+}
 
-	return instances, totalCount, nil
+func (s *TrialCleanupService) warnInstances(decisions []instanceDecision) int {
+	failures := 0
+	for _, d := range decisions {
+		err := s.notifier.NotifyExpiration(d.instance, d.decision.ExpiresAt, d.decision.ExpiresAt.Sub(s.now()))
+		if err == nil {
+			err = s.recordEvent(d.instance, internal.TrialEventWarning, d.decision.Warning)
+		}
+		if err != nil {
+			// ignoring errors - only logging, the warning is sent again in the next run
+			log.Error(fmt.Sprintf("while warning about the expiration of instanceID: %s, error: %s", d.instance.InstanceID, err))
+			failures++
+		}
+	}
+	return failures
 }
 
-func (s *TrialCleanupService) filterInstances(instances []internal.Instance, filter instancePredicate) ([]internal.Instance, int) {
-	var filteredInstances []internal.Instance
-	for _, instance := range instances {
-		if filter(instance) {
-			filteredInstances = append(filteredInstances, instance)
+func (s *TrialCleanupService) hibernateInstances(decisions []instanceDecision) int {
+	failures := 0
+	for _, d := range decisions {
+		log.Infof("About to hibernate the cluster of instanceId: %+v runtimeId: %+v", d.instance.InstanceID, d.instance.RuntimeID)
+		_, err := s.hibernator.HibernateRuntime(context.Background(), d.instance.GlobalAccountID, d.instance.RuntimeID)
+		if err == nil {
+			err = s.recordEvent(d.instance, internal.TrialEventHibernation, 0)
+		}
+		if err != nil {
+			// ignoring errors - only logging, the hibernation is retried in the next run
+			log.Error(fmt.Sprintf("while hibernating instanceID: %s, error: %s", d.instance.InstanceID, err))
+			failures++
+		}
+	}
+	return failures
+}
+
+func (s *TrialCleanupService) wakeUpInstances(decisions []instanceDecision) int {
+	failures := 0
+	for _, d := range decisions {
+		log.Infof("About to wake up the cluster of instanceId: %+v runtimeId: %+v used in the grace period", d.instance.InstanceID, d.instance.RuntimeID)
+		err := errors.New("the wake-up of the clusters is not configured")
+		if s.awakener != nil {
+			err = s.awakener.WakeUpRuntime(context.Background(), d.instance)
+		}
+		if err == nil {
+			err = s.recordEvent(d.instance, internal.TrialEventWakeUp, 0)
 		}
+		if err != nil {
+			// ignoring errors - only logging, the wake-up is retried in the next run
+			log.Error(fmt.Sprintf("while waking up instanceID: %s, error: %s", d.instance.InstanceID, err))
+			failures++
+		}
+	}
+	return failures
+}
+
+func (s *TrialCleanupService) recordEvent(instance internal.Instance, eventType internal.TrialEventType, period time.Duration) error {
+	err := s.trialEvents.Insert(internal.TrialEvent{
+		ID:           uuid.New().String(),
+		InstanceID:   instance.InstanceID,
+		SubAccountID: instance.SubAccountID,
+		Type:         eventType,
+		Period:       period,
+		CreatedAt:    s.now(),
+	})
+	if err != nil {
+		return fmt.Errorf("while saving trial %s event: %w", eventType, err)
+	}
+	return nil
+}
+
+func (s *TrialCleanupService) getInstances(filter dbmodel.InstanceFilter) ([]internal.Instance, int, error) {
+
+	instances, _, totalCount, err := s.instanceStorage.List(filter)
+	if err != nil {
+		return []internal.Instance{}, 0, err
 	}
-	return filteredInstances, len(filteredInstances)
+
+	return instances, totalCount, nil
 }
 
 func (s *TrialCleanupService) cleanupInstances(instances []internal.Instance) (int, int, int) {
@@ -163,6 +326,13 @@ func (s *TrialCleanupService) logInstances(instances []internal.Instance) {
 	}
 }
 
+func (s *TrialCleanupService) logDecisions(decisions []instanceDecision) {
+	for _, d := range decisions {
+		log.Infof("instanceId: %+v expiresAt: %+v action: %+v warning: %+v servicePlanName: %+v",
+			d.instance.InstanceID, d.decision.ExpiresAt, d.decision.Action, d.decision.Warning, d.instance.ServicePlanName)
+	}
+}
+
 func (s *TrialCleanupService) expireInstance(instance internal.Instance) (processed bool, err error) {
 	log.Infof("About to make instance suspended for instanceId: %+v", instance.InstanceID)
 	suspensionUnderWay, err := s.brokerClient.SendExpirationRequest(instance)
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/gardener"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/fixture"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/provisioner"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbmodel"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/trial"
+	schema "github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const day = 24 * time.Hour
+
+func TestTrialCleanupService_PerformCleanup(t *testing.T) {
+	now := time.Date(2022, 10, 20, 12, 0, 0, 0, time.UTC)
+	cfg := Config{
+		ExpirationPeriod: 14 * day,
+		Lifecycle:        trial.Config{Warnings: trial.Periods{7 * day, day}, GracePeriod: 3 * day},
+	}
+
+	t.Run("should warn, hibernate and expire the trials", func(t *testing.T) {
+		// given
+		db := storage.NewMemoryStorage()
+		insertInstance(t, db, "fresh", now.Add(-day))
+		insertInstance(t, db, "to-warn", now.Add(-10*day))
+		insertInstance(t, db, "to-hibernate", now.Add(-15*day))
+		insertInstance(t, db, "to-expire", now.Add(-17*day))
+		brokerClient := &fakeBrokerClient{}
+		provisionerClient := provisioner.NewFakeClient()
+		notifier := &fakeNotifier{}
+		svc := newTestService(cfg, brokerClient, provisionerClient, notifier, db, now)
+
+		// when
+		err := svc.PerformCleanup()
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []string{"to-warn"}, notifier.notified)
+		assert.Equal(t, []string{"to-expire"}, brokerClient.expired)
+		assert.Equal(t, schema.OperationTypeHibernate, provisionerClient.FindOperationByRuntimeIDAndType("runtime-to-hibernate", schema.OperationTypeHibernate).Operation)
+
+		events, err := db.TrialEvents().List(dbmodel.TrialEventFilter{})
+		require.NoError(t, err)
+		require.Len(t, events, 2)
+		assert.Equal(t, internal.TrialEvent{ID: events[0].ID, InstanceID: "to-warn", SubAccountID: "sa-to-warn", Type: internal.TrialEventWarning, Period: 7 * day, CreatedAt: now}, events[0])
+		assert.Equal(t, internal.TrialEventHibernation, events[1].Type)
+
+		// when
+		err = svc.PerformCleanup()
+
+		// then
+		require.NoError(t, err)
+		assert.Len(t, notifier.notified, 1)
+	})
+
+	t.Run("should wake up the cluster used in the grace period and hibernate it again", func(t *testing.T) {
+		// given
+		db := storage.NewMemoryStorage()
+		planRegistry, err := broker.NewPlanRegistry(broker.PlanDefinitions{
+			Lifecycles: map[string]broker.PlanLifecycle{broker.FreemiumPlanName: {InactivityExpiry: 30 * day}},
+		})
+		require.NoError(t, err)
+		require.NoError(t, db.Instances().Insert(internal.Instance{
+			InstanceID:      "free",
+			RuntimeID:       "runtime-free",
+			SubAccountID:    "sa-free",
+			ServicePlanID:   broker.FreemiumPlanID,
+			ServicePlanName: broker.FreemiumPlanName,
+			CreatedAt:       time.Now().Add(-30 * day),
+		}))
+		awakener := &fakeAwakener{}
+		// the activity is recorded with the current time
+		svc := newTrialCleanupService(cfg, planRegistry, &fakeBrokerClient{}, provisioner.NewFakeClient(), awakener, &fakeNotifier{}, db.Instances(), db.TrialEvents())
+
+		// when
+		err = svc.PerformCleanup()
+
+		// then
+		require.NoError(t, err)
+		assertEventTypes(t, db, internal.TrialEventHibernation)
+
+		// when - the kubeconfig is downloaded in the grace period
+		require.NoError(t, trial.NewActivityRecorder(db.TrialEvents(), trial.NewPolicy(cfg.Lifecycle, nil, planRegistry.InactivityExpiryPeriods()), logrus.New()).
+			Record(internal.Instance{InstanceID: "free", SubAccountID: "sa-free", ServicePlanID: broker.FreemiumPlanID}, trial.ActivitySourceKubeconfig))
+		err = svc.PerformCleanup()
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []string{"free"}, awakener.woken)
+		assertEventTypes(t, db, internal.TrialEventHibernation, internal.TrialEventActivity, internal.TrialEventWakeUp)
+
+		// when - the instance is not used again
+		svc.now = func() time.Time { return time.Now().Add(30*day + time.Hour) }
+		err = svc.PerformCleanup()
+
+		// then
+		require.NoError(t, err)
+		assert.Len(t, awakener.woken, 1)
+		assertEventTypes(t, db, internal.TrialEventHibernation, internal.TrialEventActivity, internal.TrialEventWakeUp, internal.TrialEventHibernation)
+	})
+
+	t.Run("should not change anything in the dry-run mode", func(t *testing.T) {
+		// given
+		db := storage.NewMemoryStorage()
+		insertInstance(t, db, "to-warn", now.Add(-10*day))
+		insertInstance(t, db, "to-expire", now.Add(-17*day))
+		brokerClient := &fakeBrokerClient{}
+		notifier := &fakeNotifier{}
+		dryRunCfg := cfg
+		dryRunCfg.DryRun = true
+		svc := newTestService(dryRunCfg, brokerClient, provisioner.NewFakeClient(), notifier, db, now)
+
+		// when
+		err := svc.PerformCleanup()
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, notifier.notified)
+		assert.Empty(t, brokerClient.expired)
+		events, err := db.TrialEvents().List(dbmodel.TrialEventFilter{})
+		require.NoError(t, err)
+		assert.Empty(t, events)
+	})
+}
+
+func newTestService(cfg Config, brokerClient BrokerClient, hibernator Hibernator, notifier trial.Notifier, db storage.BrokerStorage, now time.Time) *TrialCleanupService {
+	svc := newTrialCleanupService(cfg, nil, brokerClient, hibernator, nil, notifier, db.Instances(), db.TrialEvents())
+	svc.now = func() time.Time { return now }
+	return svc
+}
+
+func insertInstance(t *testing.T, db storage.BrokerStorage, id string, createdAt time.Time) {
+	require.NoError(t, db.Instances().Insert(internal.Instance{
+		InstanceID:      id,
+		RuntimeID:       "runtime-" + id,
+		GlobalAccountID: "ga-" + id,
+		SubAccountID:    "sa-" + id,
+		ServicePlanID:   broker.TrialPlanID,
+		ServicePlanName: broker.TrialPlanName,
+		CreatedAt:       createdAt,
+	}))
+}
+
+func assertEventTypes(t *testing.T, db storage.BrokerStorage, expected ...internal.TrialEventType) {
+	events, err := db.TrialEvents().List(dbmodel.TrialEventFilter{})
+	require.NoError(t, err)
+	types := make([]internal.TrialEventType, 0, len(events))
+	for _, event := range events {
+		types = append(types, event.Type)
+	}
+	assert.Equal(t, expected, types)
+}
+
+type fakeAwakener struct {
+	woken []string
+}
+
+func (a *fakeAwakener) WakeUpRuntime(_ context.Context, instance internal.Instance) error {
+	a.woken = append(a.woken, instance.InstanceID)
+	return nil
+}
+
+type fakeBrokerClient struct {
+	expired []string
+}
+
+func (c *fakeBrokerClient) SendExpirationRequest(instance internal.Instance) (bool, error) {
+	c.expired = append(c.expired, instance.InstanceID)
+	return true, nil
+}
+
+type fakeNotifier struct {
+	notified []string
+}
+
+func (n *fakeNotifier) NotifyExpiration(instance internal.Instance, expiresAt time.Time, timeLeft time.Duration) error {
+	n.notified = append(n.notified, instance.InstanceID)
+	return nil
+}
+
+func TestShootAwakener_WakeUpRuntime(t *testing.T) {
+	// given
+	db := storage.NewMemoryStorage()
+	operation := fixture.FixProvisioningOperation("op-1", "instance-1")
+	operation.ShootName = "c-1234"
+	require.NoError(t, db.Operations().InsertOperation(operation))
+	shoot := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "core.gardener.cloud/v1beta1",
+		"kind":       "Shoot",
+		"metadata":   map[string]interface{}{"name": "c-1234", "namespace": "garden-kyma"},
+		"spec":       map[string]interface{}{"hibernation": map[string]interface{}{"enabled": true}},
+	}}
+	shoots := gardener.NewDynamicFakeClient(shoot).Resource(gardener.ShootResource).Namespace("garden-kyma")
+	awakener := newShootAwakener(shoots, db.Operations())
+
+	// when
+	err := awakener.WakeUpRuntime(context.Background(), internal.Instance{InstanceID: "instance-1"})
+
+	// then
+	require.NoError(t, err)
+	patched, err := shoots.Get(context.Background(), "c-1234", metav1.GetOptions{})
+	require.NoError(t, err)
+	enabled, _, err := unstructured.NestedBool(patched.Object, "spec", "hibernation", "enabled")
+	require.NoError(t, err)
+	assert.False(t, enabled)
+}
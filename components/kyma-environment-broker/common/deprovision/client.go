@@ -13,7 +13,7 @@ const defaultPageSize = 100
 
 // Client is the interface to interact with the KEB /deprovision API as an HTTP client using OIDC ID token in JWT format.
 type Client interface {
-	DeprovisionRuntime(params DeprovisionParameters) error
+	DeprovisionRuntime(instanceID string) error
 }
 
 type DeprovisionClient struct {
@@ -62,7 +62,8 @@ func (c DeprovisionClient) DeprovisionRuntime(instanceID string) error {
 		err = cerr
 	}
 
-	if response.StatusCode != http.StatusOK {
+	// KEB accepts the asynchronous deprovisioning with 202, and returns 200 when the instance does not exist
+	if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusAccepted {
 		return fmt.Errorf("calling %s returned %d (%s) status", request.URL.String(), response.StatusCode, response.Status)
 	}
 	logrus.Infof("Deprovisioning request returned code: " + response.Status)
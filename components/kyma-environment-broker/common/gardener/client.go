@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"io/ioutil"
 
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	restclient "k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -72,8 +74,49 @@ func (b Shoot) GetSpecRegion() string {
 	return str
 }
 
+type CloudProfile struct {
+	unstructured.Unstructured
+}
+
+// CloudProfileSpec holds the parts of the CloudProfile spec used by KEB
+type CloudProfileSpec struct {
+	Type         string                    `json:"type"`
+	Regions      []CloudProfileRegion      `json:"regions"`
+	MachineTypes []CloudProfileMachineType `json:"machineTypes"`
+}
+
+type CloudProfileRegion struct {
+	Name  string             `json:"name"`
+	Zones []CloudProfileZone `json:"zones,omitempty"`
+}
+
+type CloudProfileZone struct {
+	Name string `json:"name"`
+}
+
+type CloudProfileMachineType struct {
+	Name   string            `json:"name"`
+	CPU    resource.Quantity `json:"cpu"`
+	Memory resource.Quantity `json:"memory"`
+	Usable *bool             `json:"usable,omitempty"`
+}
+
+func (p CloudProfile) GetSpec() (CloudProfileSpec, error) {
+	spec := CloudProfileSpec{}
+	raw, found, err := unstructured.NestedMap(p.Unstructured.Object, "spec")
+	if err != nil || !found {
+		return spec, fmt.Errorf("CloudProfile %s missing field '.spec': %v", p.GetName(), err)
+	}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(raw, &spec); err != nil {
+		return spec, fmt.Errorf("while converting CloudProfile %s spec: %w", p.GetName(), err)
+	}
+	return spec, nil
+}
+
+var CloudProfileResource = schema.GroupVersionResource{Group: "core.gardener.cloud", Version: "v1beta1", Resource: "cloudprofiles"}
 var SecretBindingResource = schema.GroupVersionResource{Group: "core.gardener.cloud", Version: "v1beta1", Resource: "secretbindings"}
 var ShootResource = schema.GroupVersionResource{Group: "core.gardener.cloud", Version: "v1beta1", Resource: "shoots"}
+var SecretResource = schema.GroupVersionResource{Version: "v1", Resource: "secrets"}
 
 func NewGardenerClusterConfig(kubeconfigPath string) (*restclient.Config, error) {
 
@@ -22,6 +22,7 @@ const (
 
 type AccountPool interface {
 	CredentialsSecretBinding(hyperscalerType Type, tenantName string, euAccess bool) (*gardener.SecretBinding, error)
+	LookupCredentialsSecretBinding(hyperscalerType Type, tenantName string, euAccess bool) (*gardener.SecretBinding, error)
 	MarkSecretBindingAsDirty(hyperscalerType Type, tenantName string, euAccess bool) error
 	IsSecretBindingUsed(hyperscalerType Type, tenantName string, euAccess bool) (bool, error)
 	IsSecretBindingDirty(hyperscalerType Type, tenantName string, euAccess bool) (bool, error)
@@ -139,7 +140,7 @@ func (p *secretBindingsAccountPool) CredentialsSecretBinding(hyperscalerType Typ
 	p.mux.Lock()
 	defer p.mux.Unlock()
 
-	labelSelector = fmt.Sprintf("shared!=true, !tenantName, !dirty, hyperscalerType=%s", hyperscalerType)
+	labelSelector = fmt.Sprintf("shared!=true, !tenantName, !dirty, !customerAccount, hyperscalerType=%s", hyperscalerType)
 	labelSelector = addEuAccessSelector(labelSelector, euAccess)
 	secretBinding, err = p.getSecretBinding(labelSelector)
 	if err != nil {
@@ -160,6 +161,23 @@ func (p *secretBindingsAccountPool) CredentialsSecretBinding(hyperscalerType Typ
 	return &gardener.SecretBinding{*updatedSecretBinding}, nil
 }
 
+// LookupCredentialsSecretBinding returns the secret binding which CredentialsSecretBinding would return, without assigning it to the tenant
+func (p *secretBindingsAccountPool) LookupCredentialsSecretBinding(hyperscalerType Type, tenantName string, euAccess bool) (*gardener.SecretBinding, error) {
+	for _, labelSelector := range []string{
+		fmt.Sprintf("tenantName=%s, hyperscalerType=%s, !dirty", tenantName, hyperscalerType),
+		fmt.Sprintf("shared!=true, !tenantName, !dirty, !customerAccount, hyperscalerType=%s", hyperscalerType),
+	} {
+		secretBinding, err := p.getSecretBinding(addEuAccessSelector(labelSelector, euAccess))
+		if err != nil {
+			return nil, fmt.Errorf("getting secret binding: %w", err)
+		}
+		if secretBinding != nil {
+			return secretBinding, nil
+		}
+	}
+	return nil, fmt.Errorf("failed to find unassigned secret binding for hyperscalerType: %s", hyperscalerType)
+}
+
 func (p *secretBindingsAccountPool) getSecretBinding(labelSelector string) (*gardener.SecretBinding, error) {
 	secretBindings, err := p.gardenerClient.Resource(gardener.SecretBindingResource).Namespace(p.gardenerNS).List(context.Background(), metav1.ListOptions{
 		LabelSelector: labelSelector,
@@ -86,6 +86,47 @@ func TestCredentialsSecretBinding(t *testing.T) {
 	}
 }
 
+func TestLookupCredentialsSecretBinding(t *testing.T) {
+	t.Run("should return secret binding of the tenant", func(t *testing.T) {
+		// given
+		pool := newTestAccountPool()
+
+		// when
+		secretBinding, err := pool.LookupCredentialsSecretBinding(GCP, "tenant1", false)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "secretBinding1", secretBinding.GetName())
+	})
+
+	t.Run("should return unassigned secret binding without assigning it", func(t *testing.T) {
+		// given
+		pool := newTestAccountPool()
+
+		// when
+		secretBinding, err := pool.LookupCredentialsSecretBinding(GCP, "tenant3", false)
+		require.NoError(t, err)
+		again, err := pool.LookupCredentialsSecretBinding(GCP, "tenant4", false)
+		require.NoError(t, err)
+
+		// then
+		assert.Equal(t, "secretBinding4", secretBinding.GetName())
+		assert.Equal(t, "secretBinding4", again.GetName())
+		assert.NotContains(t, again.GetLabels(), "tenantName")
+	})
+
+	t.Run("should return error when no secret binding is available", func(t *testing.T) {
+		// given
+		pool := newTestAccountPool()
+
+		// when
+		_, err := pool.LookupCredentialsSecretBinding(Openstack, "tenant5", false)
+
+		// then
+		assert.EqualError(t, err, "failed to find unassigned secret binding for hyperscalerType: openstack")
+	})
+}
+
 func TestSecretsAccountPool_IsSecretBindingInternal(t *testing.T) {
 	for _, euAccess := range []bool{false, true} {
 		t.Run(fmt.Sprintf("EuAccess=%v", euAccess), func(t *testing.T) {
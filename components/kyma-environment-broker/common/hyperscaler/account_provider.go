@@ -10,6 +10,7 @@ import (
 type AccountProvider interface {
 	GardenerSecretName(hyperscalerType Type, tenantName string, euAccess bool) (string, error)
 	GardenerSharedSecretName(hyperscalerType Type, euAccess bool) (string, error)
+	LookupGardenerSecretName(hyperscalerType Type, tenantName string, euAccess bool) (string, error)
 	MarkUnusedGardenerSecretBindingAsDirty(hyperscalerType Type, tenantName string, euAccess bool) error
 }
 
@@ -59,6 +60,20 @@ func (p *accountProvider) GardenerSecretName(hyperscalerType Type, tenantName st
 	return secretBinding.GetSecretRefName(), nil
 }
 
+// LookupGardenerSecretName returns the secret name which GardenerSecretName would return, without assigning a secret binding to the tenant
+func (p *accountProvider) LookupGardenerSecretName(hyperscalerType Type, tenantName string, euAccess bool) (string, error) {
+	if p.gardenerPool == nil {
+		return "", fmt.Errorf("failed to get Gardener Credentials. Gardener Account pool is not configured for tenant %s", tenantName)
+	}
+
+	secretBinding, err := p.gardenerPool.LookupCredentialsSecretBinding(hyperscalerType, tenantName, euAccess)
+	if err != nil {
+		return "", fmt.Errorf("failed to get Gardener Credentials for tenant %s: %w", tenantName, err)
+	}
+
+	return secretBinding.GetSecretRefName(), nil
+}
+
 func (p *accountProvider) GardenerSharedSecretName(hyperscalerType Type, euAccess bool) (string, error) {
 	if p.sharedGardenerPool == nil {
 		return "", fmt.Errorf("failed to get shared Secret Binding name. Gardener Shared Account pool is not configured for hyperscaler type %s", hyperscalerType)
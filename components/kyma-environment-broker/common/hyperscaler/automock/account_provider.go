@@ -54,6 +54,27 @@ func (_m *AccountProvider) GardenerSharedSecretName(hyperscalerType hyperscaler.
 	return r0, r1
 }
 
+// LookupGardenerSecretName provides a mock function with given fields: hyperscalerType, tenantName, euAccess
+func (_m *AccountProvider) LookupGardenerSecretName(hyperscalerType hyperscaler.Type, tenantName string, euAccess bool) (string, error) {
+	ret := _m.Called(hyperscalerType, tenantName, euAccess)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(hyperscaler.Type, string, bool) string); ok {
+		r0 = rf(hyperscalerType, tenantName, euAccess)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(hyperscaler.Type, string, bool) error); ok {
+		r1 = rf(hyperscalerType, tenantName, euAccess)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // MarkUnusedGardenerSecretBindingAsDirty provides a mock function with given fields: hyperscalerType, tenantName, euAccess
 func (_m *AccountProvider) MarkUnusedGardenerSecretBindingAsDirty(hyperscalerType hyperscaler.Type, tenantName string, euAccess bool) error {
 	ret := _m.Called(hyperscalerType, tenantName, euAccess)
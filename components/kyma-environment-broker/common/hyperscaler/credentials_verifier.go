@@ -0,0 +1,167 @@
+package hyperscaler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest/adal"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+const (
+	credentialsVerificationTimeout = 30 * time.Second
+	gcpVerificationScope           = "https://www.googleapis.com/auth/cloud-platform"
+)
+
+// ErrCredentialsRejected is matched by the verification errors of the credentials which are malformed or rejected by the hyperscaler,
+// the other errors, for example timeouts or outages of the hyperscaler, are temporary
+var ErrCredentialsRejected = errors.New("credentials rejected")
+
+type rejectedCredentialsError struct {
+	err error
+}
+
+func (e rejectedCredentialsError) Error() string {
+	return e.err.Error()
+}
+
+func (e rejectedCredentialsError) Unwrap() error {
+	return e.err
+}
+
+func (e rejectedCredentialsError) Is(target error) bool {
+	return target == ErrCredentialsRejected
+}
+
+func rejected(err error) error {
+	return rejectedCredentialsError{err: err}
+}
+
+// rejectedStatus checks if the hyperscaler answered with one of the given statuses, the AWS errors expose the status code
+// while the Azure and GCP token errors expose the whole response
+func rejectedStatus(err error, statuses ...int) bool {
+	status := 0
+	var awsErr interface{ HTTPStatusCode() int }
+	var azureErr adal.TokenRefreshError
+	var gcpErr *oauth2.RetrieveError
+	switch {
+	case errors.As(err, &awsErr):
+		status = awsErr.HTTPStatusCode()
+	case errors.As(err, &azureErr) && azureErr.Response() != nil:
+		status = azureErr.Response().StatusCode
+	case errors.As(err, &gcpErr) && gcpErr.Response != nil:
+		status = gcpErr.Response.StatusCode
+	}
+	for _, s := range statuses {
+		if status == s {
+			return true
+		}
+	}
+	return false
+}
+
+// providerCredentialsVerifier checks the customer credentials with the cheapest authenticated call of the hyperscaler:
+// sts:GetCallerIdentity on AWS, the service principal token request on Azure and the service account token exchange on GCP
+type providerCredentialsVerifier struct {
+	httpClient *http.Client
+
+	// the endpoints are replaced only in tests
+	awsEndpointURL          string
+	azureActiveDirectoryURL string
+	gcpTokenURL             string
+}
+
+func NewProviderCredentialsVerifier() CredentialsVerifier {
+	return &providerCredentialsVerifier{
+		httpClient:              &http.Client{Timeout: credentialsVerificationTimeout},
+		azureActiveDirectoryURL: azure.PublicCloud.ActiveDirectoryEndpoint,
+	}
+}
+
+func (v *providerCredentialsVerifier) Verify(hyperscalerType Type, credentials map[string]string) error {
+	if err := ValidateCustomerCredentials(hyperscalerType, credentials); err != nil {
+		return rejected(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), credentialsVerificationTimeout)
+	defer cancel()
+
+	switch hyperscalerType {
+	case AWS:
+		return v.verifyAWS(ctx, credentials)
+	case Azure:
+		return v.verifyAzure(ctx, credentials)
+	case GCP:
+		return v.verifyGCP(ctx, credentials)
+	}
+	return nil
+}
+
+func (v *providerCredentialsVerifier) verifyAWS(ctx context.Context, creds map[string]string) error {
+	options := sts.Options{
+		Region:      "us-east-1",
+		Credentials: credentials.NewStaticCredentialsProvider(creds["accessKeyID"], creds["secretAccessKey"], ""),
+		HTTPClient:  v.httpClient,
+	}
+	if v.awsEndpointURL != "" {
+		options.EndpointResolver = sts.EndpointResolverFromURL(v.awsEndpointURL)
+	}
+
+	_, err := sts.New(options).GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+	// STS answers with 400 also when the caller is throttled, the invalid or expired keys and signatures are rejected with 403
+	if rejectedStatus(err, http.StatusUnauthorized, http.StatusForbidden) {
+		return fmt.Errorf("while verifying AWS credentials: %w", rejected(err))
+	}
+	if err != nil {
+		return fmt.Errorf("while verifying AWS credentials: %w", err)
+	}
+	return nil
+}
+
+func (v *providerCredentialsVerifier) verifyAzure(ctx context.Context, creds map[string]string) error {
+	oauthConfig, err := adal.NewOAuthConfig(v.azureActiveDirectoryURL, creds["tenantID"])
+	if err != nil {
+		return fmt.Errorf("while creating Azure OAuth config: %w", err)
+	}
+	token, err := adal.NewServicePrincipalToken(*oauthConfig, creds["clientID"], creds["clientSecret"], azure.PublicCloud.ResourceManagerEndpoint)
+	if err != nil {
+		return fmt.Errorf("while creating Azure service principal token: %w", err)
+	}
+	token.SetSender(v.httpClient)
+
+	err = token.RefreshWithContext(ctx)
+	if rejectedStatus(err, http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden) {
+		return fmt.Errorf("while verifying Azure credentials: %w", rejected(err))
+	}
+	if err != nil {
+		return fmt.Errorf("while verifying Azure credentials: %w", err)
+	}
+	return nil
+}
+
+func (v *providerCredentialsVerifier) verifyGCP(ctx context.Context, creds map[string]string) error {
+	config, err := google.JWTConfigFromJSON([]byte(creds["serviceaccount.json"]), gcpVerificationScope)
+	if err != nil {
+		return fmt.Errorf("while parsing GCP service account: %w", rejected(err))
+	}
+	if v.gcpTokenURL != "" {
+		config.TokenURL = v.gcpTokenURL
+	}
+
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, v.httpClient)
+	_, err = config.TokenSource(ctx).Token()
+	if rejectedStatus(err, http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden) {
+		return fmt.Errorf("while verifying GCP credentials: %w", rejected(err))
+	}
+	if err != nil {
+		return fmt.Errorf("while verifying GCP credentials: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+package hyperscaler
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProviderCredentialsVerifier_Verify(t *testing.T) {
+	fake := &providerFake{t: t}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	verifier := &providerCredentialsVerifier{
+		httpClient:              server.Client(),
+		awsEndpointURL:          server.URL + "/aws",
+		azureActiveDirectoryURL: server.URL + "/azure/",
+		gcpTokenURL:             server.URL + "/gcp/token",
+	}
+
+	for tn, tc := range map[string]struct {
+		hyperscalerType Type
+		credentials     map[string]string
+		expectedErr     string
+		rejected        bool
+	}{
+		"valid AWS credentials": {
+			hyperscalerType: AWS,
+			credentials:     map[string]string{"accessKeyID": "key-id", "secretAccessKey": "secret"},
+		},
+		"rejected AWS credentials": {
+			hyperscalerType: AWS,
+			credentials:     map[string]string{"accessKeyID": "invalid", "secretAccessKey": "secret"},
+			expectedErr:     "InvalidClientTokenId",
+			rejected:        true,
+		},
+		"valid Azure credentials": {
+			hyperscalerType: Azure,
+			credentials:     map[string]string{"clientID": "client-1", "clientSecret": "secret", "subscriptionID": "subscription", "tenantID": "tenant"},
+		},
+		"rejected Azure credentials": {
+			hyperscalerType: Azure,
+			credentials:     map[string]string{"clientID": "invalid", "clientSecret": "secret", "subscriptionID": "subscription", "tenantID": "tenant"},
+			expectedErr:     "while verifying Azure credentials",
+			rejected:        true,
+		},
+		"valid GCP service account": {
+			hyperscalerType: GCP,
+			credentials:     map[string]string{"serviceaccount.json": fixGCPServiceAccount(t, "sa@project.iam.gserviceaccount.com")},
+		},
+		"rejected GCP service account": {
+			hyperscalerType: GCP,
+			credentials:     map[string]string{"serviceaccount.json": fixGCPServiceAccount(t, "invalid@project.iam.gserviceaccount.com")},
+			expectedErr:     "while verifying GCP credentials",
+			rejected:        true,
+		},
+		"malformed GCP service account": {
+			hyperscalerType: GCP,
+			credentials:     map[string]string{"serviceaccount.json": `{"type": "service_account"`},
+			expectedErr:     "while parsing GCP service account",
+			rejected:        true,
+		},
+		"unavailable AWS": {
+			hyperscalerType: AWS,
+			credentials:     map[string]string{"accessKeyID": "unavailable", "secretAccessKey": "secret"},
+			expectedErr:     "while verifying AWS credentials",
+		},
+		"unavailable Azure": {
+			hyperscalerType: Azure,
+			credentials:     map[string]string{"clientID": "unavailable", "clientSecret": "secret", "subscriptionID": "subscription", "tenantID": "tenant"},
+			expectedErr:     "while verifying Azure credentials",
+		},
+		"unavailable GCP": {
+			hyperscalerType: GCP,
+			credentials:     map[string]string{"serviceaccount.json": fixGCPServiceAccount(t, "unavailable@project.iam.gserviceaccount.com")},
+			expectedErr:     "while verifying GCP credentials",
+		},
+		"missing Azure keys": {
+			hyperscalerType: Azure,
+			credentials:     map[string]string{"clientID": "id", "clientSecret": "secret"},
+			expectedErr:     "credentials for hyperscaler azure must contain: subscriptionID, tenantID",
+			rejected:        true,
+		},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			// when
+			err := verifier.Verify(tc.hyperscalerType, tc.credentials)
+
+			// then
+			if tc.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErr)
+				assert.Equal(t, tc.rejected, errors.Is(err, ErrCredentialsRejected))
+			}
+		})
+	}
+}
+
+// providerFake serves the STS GetCallerIdentity action, the Azure AD token endpoint and the Google OAuth2 token endpoint,
+// the credentials of the "invalid" identities are rejected and the "unavailable" identities get a server error
+type providerFake struct {
+	t *testing.T
+}
+
+func (f *providerFake) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	require.NoError(f.t, r.ParseForm())
+
+	switch r.URL.Path {
+	case "/aws/":
+		require.Equal(f.t, "GetCallerIdentity", r.Form.Get("Action"))
+		w.Header().Set("Content-Type", "text/xml")
+		if strings.Contains(r.Header.Get("Authorization"), "Credential=invalid/") {
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `<ErrorResponse><Error><Type>Sender</Type><Code>InvalidClientTokenId</Code><Message>The security token included in the request is invalid.</Message></Error></ErrorResponse>`)
+			return
+		}
+		if strings.Contains(r.Header.Get("Authorization"), "Credential=unavailable/") {
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprint(w, `<ErrorResponse><Error><Type>Receiver</Type><Code>InternalFailure</Code><Message>The request processing has failed.</Message></Error></ErrorResponse>`)
+			return
+		}
+		fmt.Fprint(w, `<GetCallerIdentityResponse><GetCallerIdentityResult><Account>123456789012</Account></GetCallerIdentityResult></GetCallerIdentityResponse>`)
+	case "/azure/tenant/oauth2/token":
+		w.Header().Set("Content-Type", "application/json")
+		if r.Form.Get("client_id") == "invalid" {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"error": "invalid_client"}`)
+			return
+		}
+		if r.Form.Get("client_id") == "unavailable" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"access_token": "token", "token_type": "Bearer", "expires_in": "3600", "expires_on": "4102444800", "not_before": "1600000000", "resource": "https://management.azure.com/"}`)
+	case "/gcp/token":
+		w.Header().Set("Content-Type", "application/json")
+		parts := strings.Split(r.Form.Get("assertion"), ".")
+		require.Len(f.t, parts, 3)
+		claims, err := base64.RawURLEncoding.DecodeString(parts[1])
+		require.NoError(f.t, err)
+		if strings.Contains(string(claims), `"iss":"invalid@`) {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error": "invalid_grant"}`)
+			return
+		}
+		if strings.Contains(string(claims), `"iss":"unavailable@`) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"access_token": "token", "token_type": "Bearer", "expires_in": 3600}`)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func fixGCPServiceAccount(t *testing.T, email string) string {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	privateKey := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	serviceAccount, err := json.Marshal(map[string]string{
+		"type":         "service_account",
+		"project_id":   "project",
+		"client_email": email,
+		"private_key":  string(privateKey),
+	})
+	require.NoError(t, err)
+	return string(serviceAccount)
+}
@@ -0,0 +1,173 @@
+package hyperscaler
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/gardener"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+)
+
+const (
+	// CustomerAccountLabel marks the secret bindings created from the credentials supplied by the customer,
+	// such secret bindings belong to a single instance and are never assigned to a tenant, marked as dirty or released
+	CustomerAccountLabel = "customerAccount"
+	InstanceIDLabel      = "instanceID"
+)
+
+// requiredCredentialsKeys are the keys of the Gardener secret expected for every hyperscaler
+var requiredCredentialsKeys = map[Type][]string{
+	AWS:   {"accessKeyID", "secretAccessKey"},
+	Azure: {"clientID", "clientSecret", "subscriptionID", "tenantID"},
+	GCP:   {"serviceaccount.json"},
+}
+
+// ValidateCustomerCredentials checks if the credentials supplied by the customer contain all keys required by the hyperscaler
+func ValidateCustomerCredentials(hyperscalerType Type, credentials map[string]string) error {
+	keys, found := requiredCredentialsKeys[hyperscalerType]
+	if !found {
+		return fmt.Errorf("customer credentials are not supported for hyperscaler %s", hyperscalerType)
+	}
+	var missing []string
+	for _, key := range keys {
+		if credentials[key] == "" {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("credentials for hyperscaler %s must contain: %s", hyperscalerType, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+//go:generate mockery --name=CredentialsVerifier --output=automock --outpkg=automock --case=underscore
+type CredentialsVerifier interface {
+	Verify(hyperscalerType Type, credentials map[string]string) error
+}
+
+// stubCredentialsVerifier does not reach the hyperscaler and only checks if the credentials are well-formed,
+// it is used in tests instead of the provider credentials verifier
+type stubCredentialsVerifier struct{}
+
+func NewStubCredentialsVerifier() CredentialsVerifier {
+	return &stubCredentialsVerifier{}
+}
+
+func (v *stubCredentialsVerifier) Verify(hyperscalerType Type, credentials map[string]string) error {
+	if err := ValidateCustomerCredentials(hyperscalerType, credentials); err != nil {
+		return rejected(err)
+	}
+	if hyperscalerType != GCP {
+		return nil
+	}
+
+	serviceAccount := struct {
+		Type       string `json:"type"`
+		ProjectID  string `json:"project_id"`
+		PrivateKey string `json:"private_key"`
+	}{}
+	if err := json.Unmarshal([]byte(credentials["serviceaccount.json"]), &serviceAccount); err != nil {
+		return fmt.Errorf("while parsing GCP service account: %w", rejected(err))
+	}
+	if serviceAccount.Type != "service_account" || serviceAccount.ProjectID == "" || serviceAccount.PrivateKey == "" {
+		return rejected(fmt.Errorf("GCP service account must be of type service_account and contain project_id and private_key"))
+	}
+	return nil
+}
+
+//go:generate mockery --name=CustomerAccountPool --output=automock --outpkg=automock --case=underscore
+type CustomerAccountPool interface {
+	CreateSecretBinding(hyperscalerType Type, instanceID string, credentials map[string]string) (string, error)
+	DeleteSecretBinding(instanceID string) error
+}
+
+func NewCustomerAccountPool(gardenerClient dynamic.Interface, gardenerNamespace string) CustomerAccountPool {
+	return &customerAccountPool{
+		gardenerClient: gardenerClient,
+		gardenerNS:     gardenerNamespace,
+	}
+}
+
+type customerAccountPool struct {
+	gardenerClient dynamic.Interface
+	gardenerNS     string
+}
+
+func CustomerSecretBindingName(instanceID string) string {
+	return fmt.Sprintf("customer-%s", instanceID)
+}
+
+// CreateSecretBinding creates the secret with the customer credentials and the secret binding dedicated to the instance,
+// both are named after the instance and the existing ones are kept, so the method can be retried
+func (p *customerAccountPool) CreateSecretBinding(hyperscalerType Type, instanceID string, credentials map[string]string) (string, error) {
+	name := CustomerSecretBindingName(instanceID)
+	labels := map[string]interface{}{
+		CustomerAccountLabel: "true",
+		InstanceIDLabel:      instanceID,
+		"hyperscalerType":    string(hyperscalerType),
+	}
+
+	data := map[string]interface{}{}
+	for key, value := range credentials {
+		data[key] = base64.StdEncoding.EncodeToString([]byte(value))
+	}
+
+	secret := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Secret",
+		"metadata": map[string]interface{}{
+			"name":      name,
+			"namespace": p.gardenerNS,
+			"labels":    labels,
+		},
+		"type": "Opaque",
+		"data": data,
+	}}
+	_, err := p.gardenerClient.Resource(gardener.SecretResource).Namespace(p.gardenerNS).Create(context.Background(), secret, metav1.CreateOptions{})
+	if err != nil && !errors.IsAlreadyExists(err) {
+		return "", fmt.Errorf("while creating secret %s: %w", name, err)
+	}
+
+	secretBinding := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "core.gardener.cloud/v1beta1",
+		"kind":       "SecretBinding",
+		"metadata": map[string]interface{}{
+			"name":      name,
+			"namespace": p.gardenerNS,
+			"labels":    labels,
+		},
+		"secretRef": map[string]interface{}{
+			"name":      name,
+			"namespace": p.gardenerNS,
+		},
+		"provider": map[string]interface{}{
+			"type": string(hyperscalerType),
+		},
+	}}
+	_, err = p.gardenerClient.Resource(gardener.SecretBindingResource).Namespace(p.gardenerNS).Create(context.Background(), secretBinding, metav1.CreateOptions{})
+	if err != nil && !errors.IsAlreadyExists(err) {
+		return "", fmt.Errorf("while creating secret binding %s: %w", name, err)
+	}
+
+	return name, nil
+}
+
+// DeleteSecretBinding removes the secret binding and the secret of the instance, missing resources are ignored
+func (p *customerAccountPool) DeleteSecretBinding(instanceID string) error {
+	name := CustomerSecretBindingName(instanceID)
+	err := p.gardenerClient.Resource(gardener.SecretBindingResource).Namespace(p.gardenerNS).Delete(context.Background(), name, metav1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("while deleting secret binding %s: %w", name, err)
+	}
+	err = p.gardenerClient.Resource(gardener.SecretResource).Namespace(p.gardenerNS).Delete(context.Background(), name, metav1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("while deleting secret %s: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package hyperscaler
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/gardener"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/errors"
+	machineryv1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCustomerAccountPool(t *testing.T) {
+	t.Run("should create the secret binding of the instance", func(t *testing.T) {
+		// given
+		gardenerFake := gardener.NewDynamicFakeClient()
+		pool := NewCustomerAccountPool(gardenerFake, testNamespace)
+
+		// when
+		name, err := pool.CreateSecretBinding(AWS, "instance-1", map[string]string{"accessKeyID": "key-id", "secretAccessKey": "secret"})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "customer-instance-1", name)
+
+		secretBinding, err := gardenerFake.Resource(gardener.SecretBindingResource).Namespace(testNamespace).Get(context.Background(), name, machineryv1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"customerAccount": "true", "instanceID": "instance-1", "hyperscalerType": "aws"}, secretBinding.GetLabels())
+		assert.Equal(t, name, gardener.SecretBinding{Unstructured: *secretBinding}.GetSecretRefName())
+
+		secret, err := gardenerFake.Resource(gardener.SecretResource).Namespace(testNamespace).Get(context.Background(), name, machineryv1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, base64.StdEncoding.EncodeToString([]byte("secret")), secret.Object["data"].(map[string]interface{})["secretAccessKey"])
+	})
+
+	t.Run("should keep the existing secret binding", func(t *testing.T) {
+		// given
+		pool := NewCustomerAccountPool(gardener.NewDynamicFakeClient(), testNamespace)
+		_, err := pool.CreateSecretBinding(GCP, "instance-1", map[string]string{"serviceaccount.json": "{}"})
+		require.NoError(t, err)
+
+		// when
+		name, err := pool.CreateSecretBinding(GCP, "instance-1", map[string]string{"serviceaccount.json": "{}"})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "customer-instance-1", name)
+	})
+
+	t.Run("should delete the secret binding of the instance", func(t *testing.T) {
+		// given
+		gardenerFake := gardener.NewDynamicFakeClient()
+		pool := NewCustomerAccountPool(gardenerFake, testNamespace)
+		name, err := pool.CreateSecretBinding(Azure, "instance-1", map[string]string{"clientID": "id"})
+		require.NoError(t, err)
+
+		// when
+		err = pool.DeleteSecretBinding("instance-1")
+		require.NoError(t, err)
+		err = pool.DeleteSecretBinding("instance-1")
+
+		// then
+		require.NoError(t, err)
+		_, err = gardenerFake.Resource(gardener.SecretBindingResource).Namespace(testNamespace).Get(context.Background(), name, machineryv1.GetOptions{})
+		assert.True(t, errors.IsNotFound(err))
+		_, err = gardenerFake.Resource(gardener.SecretResource).Namespace(testNamespace).Get(context.Background(), name, machineryv1.GetOptions{})
+		assert.True(t, errors.IsNotFound(err))
+	})
+
+	t.Run("should not assign the secret binding of the instance to a tenant", func(t *testing.T) {
+		// given
+		gardenerFake := gardener.NewDynamicFakeClient()
+		_, err := NewCustomerAccountPool(gardenerFake, testNamespace).CreateSecretBinding(AWS, "instance-1", map[string]string{})
+		require.NoError(t, err)
+		accountPool := NewAccountPool(gardenerFake, testNamespace)
+
+		// when
+		_, err = accountPool.CredentialsSecretBinding(AWS, "tenant1", false)
+
+		// then
+		assert.EqualError(t, err, "failed to find unassigned secret binding for hyperscalerType: aws")
+	})
+}
+
+func TestStubCredentialsVerifier_Verify(t *testing.T) {
+	verifier := NewStubCredentialsVerifier()
+
+	for tn, tc := range map[string]struct {
+		hyperscalerType Type
+		credentials     map[string]string
+		expectedErr     string
+	}{
+		"valid AWS credentials": {
+			hyperscalerType: AWS,
+			credentials:     map[string]string{"accessKeyID": "key-id", "secretAccessKey": "secret"},
+		},
+		"missing Azure keys": {
+			hyperscalerType: Azure,
+			credentials:     map[string]string{"clientID": "id", "clientSecret": "secret"},
+			expectedErr:     "credentials for hyperscaler azure must contain: subscriptionID, tenantID",
+		},
+		"valid GCP service account": {
+			hyperscalerType: GCP,
+			credentials:     map[string]string{"serviceaccount.json": `{"type": "service_account", "project_id": "project", "private_key": "key"}`},
+		},
+		"invalid GCP service account": {
+			hyperscalerType: GCP,
+			credentials:     map[string]string{"serviceaccount.json": `{"type": "authorized_user"}`},
+			expectedErr:     "GCP service account must be of type service_account and contain project_id and private_key",
+		},
+		"unsupported hyperscaler": {
+			hyperscalerType: Openstack,
+			credentials:     map[string]string{},
+			expectedErr:     "customer credentials are not supported for hyperscaler openstack",
+		},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			// when
+			err := verifier.Verify(tc.hyperscalerType, tc.credentials)
+
+			// then
+			if tc.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package hyperscaler
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/gardener"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
+)
+
+// PoolStats counts the secret bindings of one hyperscaler type and EU access flag. The bindings of the account pool are either
+// free, used or dirty, the internal ones are counted additionally. Shared and customer bindings are not part of the account pool.
+type PoolStats struct {
+	HyperscalerType  Type
+	EuAccess         bool
+	Free             int
+	Used             int
+	Dirty            int
+	Internal         int
+	Shared           int
+	SharedShoots     int
+	CustomerAccounts int
+}
+
+type PoolManager interface {
+	Stats() ([]PoolStats, error)
+}
+
+func NewPoolManager(gardenerClient dynamic.Interface, gardenerNamespace string) PoolManager {
+	return &poolManager{
+		gardenerClient: gardenerClient,
+		gardenerNS:     gardenerNamespace,
+	}
+}
+
+type poolManager struct {
+	gardenerClient dynamic.Interface
+	gardenerNS     string
+}
+
+type poolKey struct {
+	hyperscalerType Type
+	euAccess        bool
+}
+
+func (m *poolManager) Stats() ([]PoolStats, error) {
+	secretBindings, err := m.gardenerClient.Resource(gardener.SecretBindingResource).Namespace(m.gardenerNS).List(context.Background(), metav1.ListOptions{
+		LabelSelector: "hyperscalerType",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("while listing secret bindings: %w", err)
+	}
+	shoots, err := m.gardenerClient.Resource(gardener.ShootResource).Namespace(m.gardenerNS).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("while listing shoots: %w", err)
+	}
+	shootsPerSecretBinding := make(map[string]int)
+	for _, shoot := range shoots.Items {
+		shootsPerSecretBinding[gardener.Shoot{Unstructured: shoot}.GetSpecSecretBindingName()]++
+	}
+
+	pools := make(map[poolKey]*PoolStats)
+	for _, secretBinding := range secretBindings.Items {
+		labels := secretBinding.GetLabels()
+		key := poolKey{hyperscalerType: Type(labels["hyperscalerType"]), euAccess: labels["euAccess"] == "true"}
+		stats, found := pools[key]
+		if !found {
+			stats = &PoolStats{HyperscalerType: key.hyperscalerType, EuAccess: key.euAccess}
+			pools[key] = stats
+		}
+
+		switch {
+		case labels[CustomerAccountLabel] == "true":
+			stats.CustomerAccounts++
+			continue
+		case labels["shared"] == "true":
+			stats.Shared++
+			stats.SharedShoots += shootsPerSecretBinding[secretBinding.GetName()]
+			continue
+		case labels["dirty"] == "true":
+			stats.Dirty++
+		case labels["tenantName"] != "":
+			stats.Used++
+		default:
+			stats.Free++
+		}
+		if labels["internal"] == "true" {
+			stats.Internal++
+		}
+	}
+
+	result := make([]PoolStats, 0, len(pools))
+	for _, stats := range pools {
+		result = append(result, *stats)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].HyperscalerType != result[j].HyperscalerType {
+			return result[i].HyperscalerType < result[j].HyperscalerType
+		}
+		return !result[i].EuAccess && result[j].EuAccess
+	})
+	return result, nil
+}
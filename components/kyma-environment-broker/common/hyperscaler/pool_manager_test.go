@@ -0,0 +1,46 @@
+package hyperscaler
+
+import (
+	"testing"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/gardener"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestPoolManager_Stats(t *testing.T) {
+	// given
+	withLabels := func(secretBinding *unstructured.Unstructured, labels map[string]string) *unstructured.Unstructured {
+		current := secretBinding.GetLabels()
+		for key, value := range labels {
+			current[key] = value
+		}
+		secretBinding.SetLabels(current)
+		return secretBinding
+	}
+	gardenerFake := gardener.NewDynamicFakeClient(
+		newSecretBinding("sb1", "s1", "aws", false, false),
+		withLabels(newSecretBinding("sb2", "s2", "aws", false, false), map[string]string{"tenantName": "tenant1", "internal": "true"}),
+		withLabels(newSecretBinding("sb3", "s3", "aws", false, false), map[string]string{"tenantName": "tenant2", "dirty": "true"}),
+		newSecretBinding("sb4", "s4", "aws", true, false),
+		withLabels(newSecretBinding("sb5", "s5", "aws", false, false), map[string]string{CustomerAccountLabel: "true"}),
+		newSecretBinding("sb6", "s6", "aws", false, true),
+		newSecretBinding("sb7", "s7", "azure", false, false),
+		newShoot("sh1", "sb4"),
+		newShoot("sh2", "sb4"),
+		newShoot("sh3", "sb2"),
+	)
+	manager := NewPoolManager(gardenerFake, testNamespace)
+
+	// when
+	stats, err := manager.Stats()
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, []PoolStats{
+		{HyperscalerType: AWS, Free: 1, Used: 1, Dirty: 1, Internal: 1, Shared: 1, SharedShoots: 2, CustomerAccounts: 1},
+		{HyperscalerType: AWS, EuAccess: true, Free: 1},
+		{HyperscalerType: Azure, Free: 1},
+	}, stats)
+}
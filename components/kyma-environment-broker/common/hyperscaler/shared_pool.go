@@ -15,15 +15,23 @@ type SharedPool interface {
 }
 
 func NewSharedGardenerAccountPool(gardenerClient dynamic.Interface, gardenerNamespace string) SharedPool {
+	return NewSharedGardenerAccountPoolWithLimit(gardenerClient, gardenerNamespace, 0)
+}
+
+// NewSharedGardenerAccountPoolWithLimit returns the shared pool which does not return a secret binding used by
+// maxShootsPerAccount shoots or more, 0 means no limit
+func NewSharedGardenerAccountPoolWithLimit(gardenerClient dynamic.Interface, gardenerNamespace string, maxShootsPerAccount int) SharedPool {
 	return &sharedAccountPool{
-		gardenerClient: gardenerClient,
-		namespace:      gardenerNamespace,
+		gardenerClient:      gardenerClient,
+		namespace:           gardenerNamespace,
+		maxShootsPerAccount: maxShootsPerAccount,
 	}
 }
 
 type sharedAccountPool struct {
-	gardenerClient dynamic.Interface
-	namespace      string
+	gardenerClient      dynamic.Interface
+	namespace           string
+	maxShootsPerAccount int
 }
 
 func (sp *sharedAccountPool) SharedCredentialsSecretBinding(hyperscalerType Type, euAccess bool) (*gardener.SecretBinding, error) {
@@ -86,6 +94,9 @@ func (sp *sharedAccountPool) getLeastUsed(secretBindings []unstructured.Unstruct
 			minIndex = i
 		}
 	}
+	if sp.maxShootsPerAccount > 0 && min >= sp.maxShootsPerAccount {
+		return nil, fmt.Errorf("sharedAccountPool error: all %d shared secret bindings are used by %d shoots or more", len(secretBindings), sp.maxShootsPerAccount)
+	}
 
 	return &gardener.SecretBinding{secretBindings[minIndex]}, nil
 }
@@ -132,6 +132,26 @@ func TestSharedPool_SharedCredentialsSecretBinding_Errors(t *testing.T) {
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "no shared secret binding found")
 	})
+
+	t.Run("should return error when all Secret Bindings reached the limit of shoots", func(t *testing.T) {
+		// given
+		gardenerFake := gardener.NewDynamicFakeClient(
+			newSecretBinding("sb1", "s1", "gcp", true, false),
+			newSecretBinding("sb2", "s2", "gcp", true, false),
+			newShoot("sh1", "sb1"),
+			newShoot("sh2", "sb1"),
+			newShoot("sh3", "sb2"),
+			newShoot("sh4", "sb2"),
+		)
+		pool := NewSharedGardenerAccountPoolWithLimit(gardenerFake, testNamespace, 2)
+
+		// when
+		_, err := pool.SharedCredentialsSecretBinding("gcp", false)
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "all 2 shared secret bindings are used by 2 shoots or more")
+	})
 }
 
 func newSecret(name string) *corev1.Secret {
@@ -0,0 +1,143 @@
+package operation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+
+	"golang.org/x/oauth2"
+)
+
+// Client is the interface to interact with the KEB /operations and /recreations admin API as an HTTP client using OIDC ID token in JWT format.
+type Client interface {
+	SkipStep(operationID, stepName string, action ActionDTO) (OperationDTO, error)
+	Retry(operationID string, action ActionDTO) (OperationDTO, error)
+	Fail(operationID string, action ActionDTO) (OperationDTO, error)
+	Succeed(operationID string, action ActionDTO) (OperationDTO, error)
+	Cancel(operationID string, action ActionDTO) (OperationDTO, error)
+	Timeline(operationID string) (TimelineDTO, error)
+	Recreate(instanceID string, action ActionDTO) (RecreationDTO, error)
+	GetRecreation(instanceID string) (RecreationDTO, error)
+}
+
+type client struct {
+	url        string
+	httpClient *http.Client
+}
+
+// NewClient constructs and returns new Client for KEB /operations API
+// It takes the following arguments:
+//   - ctx  : context in which the http request will be executed
+//   - url  : base url of all KEB APIs, e.g. https://kyma-env-broker.kyma.local
+//   - auth : TokenSource object which provides the ID token for the HTTP request
+func NewClient(ctx context.Context, url string, auth oauth2.TokenSource) Client {
+	return &client{
+		url:        url,
+		httpClient: oauth2.NewClient(ctx, auth),
+	}
+}
+
+func (c client) SkipStep(operationID, stepName string, action ActionDTO) (OperationDTO, error) {
+	return c.post(fmt.Sprintf("%s/steps/%s/skip", c.operationURL(operationID), url.PathEscape(stepName)), action)
+}
+
+func (c client) Retry(operationID string, action ActionDTO) (OperationDTO, error) {
+	return c.post(fmt.Sprintf("%s/retry", c.operationURL(operationID)), action)
+}
+
+func (c client) Fail(operationID string, action ActionDTO) (OperationDTO, error) {
+	return c.post(fmt.Sprintf("%s/fail", c.operationURL(operationID)), action)
+}
+
+func (c client) Succeed(operationID string, action ActionDTO) (OperationDTO, error) {
+	return c.post(fmt.Sprintf("%s/succeed", c.operationURL(operationID)), action)
+}
+
+func (c client) Cancel(operationID string, action ActionDTO) (OperationDTO, error) {
+	return c.post(fmt.Sprintf("%s/cancel", c.operationURL(operationID)), action)
+}
+
+func (c client) Timeline(operationID string) (timeline TimelineDTO, err error) {
+	err = c.do(http.MethodGet, fmt.Sprintf("%s/timeline", c.operationURL(operationID)), nil, &timeline)
+	return timeline, err
+}
+
+func (c client) Recreate(instanceID string, action ActionDTO) (recreation RecreationDTO, err error) {
+	body, err := json.Marshal(action)
+	if err != nil {
+		return recreation, fmt.Errorf("while marshalling action: %w", err)
+	}
+	err = c.do(http.MethodPost, c.recreationURL(instanceID), body, &recreation)
+	return recreation, err
+}
+
+func (c client) GetRecreation(instanceID string) (recreation RecreationDTO, err error) {
+	err = c.do(http.MethodGet, c.recreationURL(instanceID), nil, &recreation)
+	return recreation, err
+}
+
+func (c client) recreationURL(instanceID string) string {
+	return fmt.Sprintf("%s/recreations/%s", c.url, url.PathEscape(instanceID))
+}
+
+func (c client) operationURL(operationID string) string {
+	return fmt.Sprintf("%s/operations/%s", c.url, url.PathEscape(operationID))
+}
+
+func (c client) post(url string, action ActionDTO) (operation OperationDTO, err error) {
+	body, err := json.Marshal(action)
+	if err != nil {
+		return operation, fmt.Errorf("while marshalling action: %w", err)
+	}
+	err = c.do(http.MethodPost, url, body, &operation)
+	return operation, err
+}
+
+func (c client) do(method, url string, body []byte, result interface{}) (err error) {
+	req, err := http.NewRequest(method, url, bytes.NewBuffer(body))
+	if err != nil {
+		return fmt.Errorf("while creating request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("while calling %s: %w", url, err)
+	}
+
+	// Drain response body and close, return error to context if there isn't any.
+	defer func() {
+		derr := drainResponseBody(resp.Body)
+		if err == nil {
+			err = derr
+		}
+		cerr := resp.Body.Close()
+		if err == nil {
+			err = cerr
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 4096))
+		return fmt.Errorf("calling %s returned %s status: %s", url, resp.Status, bytes.TrimSpace(msg))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("while decoding response body: %w", err)
+	}
+	return nil
+}
+
+func drainResponseBody(body io.Reader) error {
+	if body == nil {
+		return nil
+	}
+	_, err := io.Copy(ioutil.Discard, io.LimitReader(body, 4096))
+	return err
+}
@@ -0,0 +1,55 @@
+package operation
+
+import "time"
+
+// ActionDTO is the body of the request which applies an admin action to a stuck operation
+type ActionDTO struct {
+	Reason string `json:"reason,omitempty"`
+}
+
+// OperationDTO describes the operation after the admin action was applied
+type OperationDTO struct {
+	OperationID    string   `json:"operationID"`
+	InstanceID     string   `json:"instanceID"`
+	Type           string   `json:"type"`
+	State          string   `json:"state"`
+	Description    string   `json:"description"`
+	FinishedStages []string `json:"finishedStages"`
+	SkippedSteps   []string `json:"skippedSteps"`
+	// CancellationRequested is set until the canceled operation finishes the compensation of its started stages
+	CancellationRequested bool     `json:"cancellationRequested,omitempty"`
+	CompensatedSteps      []string `json:"compensatedSteps,omitempty"`
+	TraceID               string   `json:"traceID,omitempty"`
+}
+
+// TimelineDTO lists the runs of the steps of the operation ordered by their start
+type TimelineDTO struct {
+	OperationID string    `json:"operationID"`
+	InstanceID  string    `json:"instanceID"`
+	Type        string    `json:"type"`
+	State       string    `json:"state"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+	Steps       []StepDTO `json:"steps"`
+}
+
+// StepDTO is a single run of a step, a step retried several times has several runs
+type StepDTO struct {
+	StepName       string    `json:"stepName"`
+	Stage          string    `json:"stage,omitempty"`
+	StartedAt      time.Time `json:"startedAt"`
+	FinishedAt     time.Time `json:"finishedAt"`
+	Result         string    `json:"result"`
+	RetryInSeconds int64     `json:"retryInSeconds,omitempty"`
+	Error          string    `json:"error,omitempty"`
+}
+
+// RecreationDTO describes the deprovisioning and the following provisioning of an instance with the same parameters,
+// the provisioning operation ID is set when the deprovisioning reaches its last step
+type RecreationDTO struct {
+	InstanceID                string `json:"instanceID"`
+	DeprovisioningOperationID string `json:"deprovisioningOperationID"`
+	DeprovisioningState       string `json:"deprovisioningState"`
+	ProvisioningOperationID   string `json:"provisioningOperationID,omitempty"`
+	ProvisioningState         string `json:"provisioningState,omitempty"`
+}
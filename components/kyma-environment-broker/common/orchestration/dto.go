@@ -20,6 +20,8 @@ type Parameters struct {
 	//customer notification status
 	NotificationState notificationStateType    `json:"notificationstate,omitempty"`
 	RetryOperation    RetryOperationParameters `json:"retryoperation,omitempty"`
+	// Manifest is the declarative manifest the orchestration was created from, stored for later inspection
+	Manifest string `json:"manifest,omitempty"`
 }
 
 type RetryOperationParameters struct {
@@ -37,6 +37,8 @@ type GardenerRuntimeResolver struct {
 	runtimes          map[string]runtime.RuntimeDTO
 	mutex             sync.RWMutex
 	logger            logrus.FieldLogger
+
+	includeFailedProvisioning bool
 }
 
 const (
@@ -57,6 +59,13 @@ func NewGardenerRuntimeResolver(gardenerClient dynamic.Interface, gardenerNamesp
 	}
 }
 
+// IncludeFailedProvisioning makes the resolver return also the runtimes which last provisioning or unsuspension failed,
+// e.g. to retry the failed operations. Such runtimes are resolved only if their shoot exists.
+func (resolver *GardenerRuntimeResolver) IncludeFailedProvisioning() *GardenerRuntimeResolver {
+	resolver.includeFailedProvisioning = true
+	return resolver
+}
+
 // Resolve given an input slice of target specs to include and exclude, returns back a list of unique Runtime objects
 func (resolver *GardenerRuntimeResolver) Resolve(targets TargetSpec) ([]Runtime, error) {
 	runtimeIncluded := map[string]bool{}
@@ -150,10 +159,10 @@ func (resolver *GardenerRuntimeResolver) resolveRuntimeTarget(rt RuntimeTarget,
 
 		lastOp := r.LastOperation()
 		// Skip runtimes for which the last operation is
-		//  - not succeeded provision or unsuspension
+		//  - not succeeded provision or unsuspension, unless the failed ones are included
 		//  - suspension
 		//  - deprovision
-		if lastOp.Type == runtime.Deprovision || lastOp.Type == runtime.Suspension || (lastOp.Type == runtime.Provision || lastOp.Type == runtime.Unsuspension) && lastOp.State != string(brokerapi.Succeeded) {
+		if lastOp.Type == runtime.Deprovision || lastOp.Type == runtime.Suspension || (lastOp.Type == runtime.Provision || lastOp.Type == runtime.Unsuspension) && !resolver.isProvisioned(lastOp) {
 			resolver.logger.Infof("Skipping Shoot %s (runtimeID: %s, instanceID %s) due to %s state: %s", shoot.GetName(), runtimeID, r.InstanceID, lastOp.Type, lastOp.State)
 			continue
 		}
@@ -230,6 +239,10 @@ func (resolver *GardenerRuntimeResolver) resolveRuntimeTarget(rt RuntimeTarget,
 	return runtimes, nil
 }
 
+func (resolver *GardenerRuntimeResolver) isProvisioned(lastOp runtime.Operation) bool {
+	return lastOp.State == string(brokerapi.Succeeded) || resolver.includeFailedProvisioning && lastOp.State == string(brokerapi.Failed)
+}
+
 func (*GardenerRuntimeResolver) runtimeFromDTO(runtime runtime.RuntimeDTO, shootName string, windowBegin, windowEnd time.Time) Runtime {
 	return Runtime{
 		InstanceID:             runtime.InstanceID,
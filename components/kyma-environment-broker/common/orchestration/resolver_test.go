@@ -201,6 +201,33 @@ func TestResolver_Resolve(t *testing.T) {
 	}
 }
 
+func TestResolver_Resolve_IncludeFailedProvisioning(t *testing.T) {
+	// given
+	client := newFakeGardenerClient()
+	lister := newRuntimeListerMock()
+	defer lister.AssertExpectations(t)
+	resolver := NewGardenerRuntimeResolver(client, shootNamespace, lister, newLogDummy()).IncludeFailedProvisioning()
+
+	// when
+	runtimes, err := resolver.Resolve(TargetSpec{
+		Include: []RuntimeTarget{
+			{
+				Target: TargetAll,
+			},
+		},
+	})
+
+	// then
+	require.NoError(t, err)
+	assertRuntimeTargets(t, []expectedRuntime{
+		{shoot: &shoot1, runtime: &runtime1},
+		{shoot: &shoot2, runtime: &runtime2},
+		{shoot: &shoot3, runtime: &runtime3},
+		{shoot: &shoot5, runtime: &runtime5},
+		{shoot: &shoot10, runtime: &runtime10},
+	}, runtimes)
+}
+
 func TestResolver_Resolve_GardenerFailure(t *testing.T) {
 	// given
 	fake := k8stesting.Fake{}
@@ -0,0 +1,95 @@
+package pipeline
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+
+	"golang.org/x/oauth2"
+)
+
+// Client is the interface to interact with the KEB /pipelines API as an HTTP client using OIDC ID token in JWT format.
+type Client interface {
+	ListPipelines(params ListParameters) (PipelineListDTO, error)
+}
+
+type client struct {
+	url        string
+	httpClient *http.Client
+}
+
+// NewClient constructs and returns new Client for KEB /pipelines API
+// It takes the following arguments:
+//   - ctx  : context in which the http request will be executed
+//   - url  : base url of all KEB APIs, e.g. https://kyma-env-broker.kyma.local
+//   - auth : TokenSource object which provides the ID token for the HTTP request
+func NewClient(ctx context.Context, url string, auth oauth2.TokenSource) Client {
+	return &client{
+		url:        url,
+		httpClient: oauth2.NewClient(ctx, auth),
+	}
+}
+
+// ListPipelines returns all pipelines, or the pipeline of the operation type, for all plans or for the plan with the given name
+func (c client) ListPipelines(params ListParameters) (pipelines PipelineListDTO, err error) {
+	path := fmt.Sprintf("%s/pipelines", c.url)
+	if params.OperationType != "" {
+		path = fmt.Sprintf("%s/%s", path, url.PathEscape(params.OperationType))
+	}
+	req, err := http.NewRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return pipelines, fmt.Errorf("while creating request: %w", err)
+	}
+	if params.Plan != "" {
+		query := req.URL.Query()
+		query.Add(PlanParam, params.Plan)
+		req.URL.RawQuery = query.Encode()
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return pipelines, fmt.Errorf("while calling %s: %w", req.URL.String(), err)
+	}
+
+	// Drain response body and close, return error to context if there isn't any.
+	defer func() {
+		derr := drainResponseBody(resp.Body)
+		if err == nil {
+			err = derr
+		}
+		cerr := resp.Body.Close()
+		if err == nil {
+			err = cerr
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 4096))
+		return pipelines, fmt.Errorf("calling %s returned %s status: %s", req.URL.String(), resp.Status, bytes.TrimSpace(msg))
+	}
+
+	if params.OperationType != "" {
+		var pipeline PipelineDTO
+		if err := json.NewDecoder(resp.Body).Decode(&pipeline); err != nil {
+			return pipelines, fmt.Errorf("while decoding response body: %w", err)
+		}
+		return PipelineListDTO{Data: []PipelineDTO{pipeline}, Count: 1}, nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&pipelines); err != nil {
+		return pipelines, fmt.Errorf("while decoding response body: %w", err)
+	}
+	return pipelines, nil
+}
+
+func drainResponseBody(body io.Reader) error {
+	if body == nil {
+		return nil
+	}
+	_, err := io.Copy(ioutil.Discard, io.LimitReader(body, 4096))
+	return err
+}
@@ -0,0 +1,43 @@
+package pipeline
+
+const (
+	PlanParam = "plan"
+)
+
+// PipelineDTO lists the stages and steps which process the operations of one type in the running KEB,
+// the steps not run for the plan are omitted when the pipeline is requested for a plan
+type PipelineDTO struct {
+	OperationType string     `json:"operationType"`
+	Plan          string     `json:"plan,omitempty"`
+	Stages        []StageDTO `json:"stages"`
+}
+
+type StageDTO struct {
+	Name  string    `json:"name"`
+	Steps []StepDTO `json:"steps"`
+	// Compensations undo the changes of the started stage when the operation is canceled
+	Compensations []StepDTO `json:"compensations,omitempty"`
+}
+
+type StepDTO struct {
+	Name string `json:"name"`
+	// Condition describes when the step runs, empty if the step always runs
+	Condition string `json:"condition,omitempty"`
+	// OnlyPlans and ExceptPlans list the names of the plans the step is limited to or not run for
+	OnlyPlans   []string `json:"onlyPlans,omitempty"`
+	ExceptPlans []string `json:"exceptPlans,omitempty"`
+	// Input is "creates" or "needs" for the steps which create or use the InputCreator of the operation
+	Input string `json:"input,omitempty"`
+	// Rerun is true for the step which was run in an earlier stage and runs again
+	Rerun bool `json:"rerun,omitempty"`
+}
+
+type PipelineListDTO struct {
+	Data  []PipelineDTO `json:"data"`
+	Count int           `json:"count"`
+}
+
+type ListParameters struct {
+	OperationType string
+	Plan          string
+}
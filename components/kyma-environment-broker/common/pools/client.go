@@ -0,0 +1,81 @@
+package pools
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"golang.org/x/oauth2"
+)
+
+// Client is the interface to interact with the KEB /hyperscaler-pools API as an HTTP client using OIDC ID token in JWT format.
+type Client interface {
+	ListPools(params ListParameters) (PoolListDTO, error)
+}
+
+type client struct {
+	url        string
+	httpClient *http.Client
+}
+
+// NewClient constructs and returns new Client for KEB /hyperscaler-pools API
+// It takes the following arguments:
+//   - ctx  : context in which the http request will be executed
+//   - url  : base url of all KEB APIs, e.g. https://kyma-env-broker.kyma.local
+//   - auth : TokenSource object which provides the ID token for the HTTP request
+func NewClient(ctx context.Context, url string, auth oauth2.TokenSource) Client {
+	return &client{
+		url:        url,
+		httpClient: oauth2.NewClient(ctx, auth),
+	}
+}
+
+func (c client) ListPools(params ListParameters) (pools PoolListDTO, err error) {
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/hyperscaler-pools", c.url), nil)
+	if err != nil {
+		return pools, fmt.Errorf("while creating request: %w", err)
+	}
+	query := req.URL.Query()
+	for _, hyperscalerType := range params.HyperscalerTypes {
+		query.Add(HyperscalerTypeParam, hyperscalerType)
+	}
+	req.URL.RawQuery = query.Encode()
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return pools, fmt.Errorf("while calling %s: %w", req.URL.String(), err)
+	}
+
+	// Drain response body and close, return error to context if there isn't any.
+	defer func() {
+		derr := drainResponseBody(resp.Body)
+		if err == nil {
+			err = derr
+		}
+		cerr := resp.Body.Close()
+		if err == nil {
+			err = cerr
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 4096))
+		return pools, fmt.Errorf("calling %s returned %s status: %s", req.URL.String(), resp.Status, bytes.TrimSpace(msg))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&pools); err != nil {
+		return pools, fmt.Errorf("while decoding response body: %w", err)
+	}
+	return pools, nil
+}
+
+func drainResponseBody(body io.Reader) error {
+	if body == nil {
+		return nil
+	}
+	_, err := io.Copy(ioutil.Discard, io.LimitReader(body, 4096))
+	return err
+}
@@ -0,0 +1,33 @@
+package pools
+
+const (
+	HyperscalerTypeParam = "hyperscaler_type"
+)
+
+// PoolDTO reports the capacity of the hyperscaler account pool of one hyperscaler type and EU access flag.
+// Free, Used and Dirty sum up to all secret bindings of the pool, Internal counts the internal ones among them.
+type PoolDTO struct {
+	HyperscalerType  string `json:"hyperscalerType"`
+	EuAccess         bool   `json:"euAccess"`
+	Free             int    `json:"free"`
+	Used             int    `json:"used"`
+	Dirty            int    `json:"dirty"`
+	Internal         int    `json:"internal"`
+	Shared           int    `json:"shared"`
+	SharedShoots     int    `json:"sharedShoots"`
+	CustomerAccounts int    `json:"customerAccounts"`
+	// MaxShootsPerSharedAccount is the limit of shoots using one shared secret binding, 0 means no limit
+	MaxShootsPerSharedAccount int `json:"maxShootsPerSharedAccount"`
+	// LowWatermark is the number of free secret bindings below which the pool needs to be refilled, 0 means not set
+	LowWatermark      int  `json:"lowWatermark"`
+	BelowLowWatermark bool `json:"belowLowWatermark"`
+}
+
+type PoolListDTO struct {
+	Data  []PoolDTO `json:"data"`
+	Count int       `json:"count"`
+}
+
+type ListParameters struct {
+	HyperscalerTypes []string
+}
@@ -0,0 +1,138 @@
+package quota
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+
+	"golang.org/x/oauth2"
+)
+
+// Client is the interface to interact with the KEB /quotas API as an HTTP client using OIDC ID token in JWT format.
+type Client interface {
+	ListQuotas(params ListParameters) (QuotaListDTO, error)
+	SetQuota(globalAccountID, planName string, limits LimitsDTO) (QuotaDTO, error)
+	DeleteQuota(globalAccountID, planName string) error
+	GetUsage(globalAccountID string) (UsageListDTO, error)
+}
+
+type client struct {
+	url        string
+	httpClient *http.Client
+}
+
+// NewClient constructs and returns new Client for KEB /quotas API
+// It takes the following arguments:
+//   - ctx  : context in which the http request will be executed
+//   - url  : base url of all KEB APIs, e.g. https://kyma-env-broker.kyma.local
+//   - auth : TokenSource object which provides the ID token for the HTTP request
+func NewClient(ctx context.Context, url string, auth oauth2.TokenSource) Client {
+	return &client{
+		url:        url,
+		httpClient: oauth2.NewClient(ctx, auth),
+	}
+}
+
+func (c client) ListQuotas(params ListParameters) (QuotaListDTO, error) {
+	quotas := QuotaListDTO{}
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/quotas", c.url), nil)
+	if err != nil {
+		return quotas, fmt.Errorf("while creating request: %w", err)
+	}
+	query := req.URL.Query()
+	setParamList(query, GlobalAccountIDParam, params.GlobalAccountIDs)
+	setParamList(query, PlanParam, params.Plans)
+	req.URL.RawQuery = query.Encode()
+
+	err = c.do(req, http.StatusOK, &quotas)
+	return quotas, err
+}
+
+func (c client) SetQuota(globalAccountID, planName string, limits LimitsDTO) (QuotaDTO, error) {
+	quota := QuotaDTO{}
+	body, err := json.Marshal(limits)
+	if err != nil {
+		return quota, fmt.Errorf("while marshalling quota limits: %w", err)
+	}
+	req, err := http.NewRequest(http.MethodPut, c.quotaURL(globalAccountID, planName), bytes.NewBuffer(body))
+	if err != nil {
+		return quota, fmt.Errorf("while creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	err = c.do(req, http.StatusOK, &quota)
+	return quota, err
+}
+
+func (c client) DeleteQuota(globalAccountID, planName string) error {
+	req, err := http.NewRequest(http.MethodDelete, c.quotaURL(globalAccountID, planName), nil)
+	if err != nil {
+		return fmt.Errorf("while creating request: %w", err)
+	}
+	return c.do(req, http.StatusNoContent, nil)
+}
+
+func (c client) GetUsage(globalAccountID string) (UsageListDTO, error) {
+	usage := UsageListDTO{}
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/quotas/%s/usage", c.url, url.PathEscape(globalAccountID)), nil)
+	if err != nil {
+		return usage, fmt.Errorf("while creating request: %w", err)
+	}
+	err = c.do(req, http.StatusOK, &usage)
+	return usage, err
+}
+
+func (c client) quotaURL(globalAccountID, planName string) string {
+	return fmt.Sprintf("%s/quotas/%s/%s", c.url, url.PathEscape(globalAccountID), url.PathEscape(planName))
+}
+
+// do executes the request and decodes the response body into the result if it is not nil
+func (c client) do(req *http.Request, expectedStatus int, result interface{}) (err error) {
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("while calling %s: %w", req.URL.String(), err)
+	}
+
+	// Drain response body and close, return error to context if there isn't any.
+	defer func() {
+		derr := drainResponseBody(resp.Body)
+		if err == nil {
+			err = derr
+		}
+		cerr := resp.Body.Close()
+		if err == nil {
+			err = cerr
+		}
+	}()
+
+	if resp.StatusCode != expectedStatus {
+		msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 4096))
+		return fmt.Errorf("calling %s returned %s status: %s", req.URL.String(), resp.Status, bytes.TrimSpace(msg))
+	}
+	if result == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("while decoding response body: %w", err)
+	}
+	return nil
+}
+
+func setParamList(query url.Values, key string, values []string) {
+	for _, value := range values {
+		query.Add(key, value)
+	}
+}
+
+func drainResponseBody(body io.Reader) error {
+	if body == nil {
+		return nil
+	}
+	_, err := io.Copy(ioutil.Discard, io.LimitReader(body, 4096))
+	return err
+}
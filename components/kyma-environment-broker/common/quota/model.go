@@ -0,0 +1,50 @@
+package quota
+
+import "time"
+
+const (
+	GlobalAccountIDParam = "global_account_id"
+	PlanParam            = "plan"
+)
+
+// QuotaDTO limits the instances of a global account in a plan. The global account ID "*" sets the default
+// for all global accounts, the plan "all_plans" limits the sum over all plans. The limits which are not set are not enforced.
+type QuotaDTO struct {
+	GlobalAccountID  string    `json:"globalAccountID"`
+	PlanName         string    `json:"planName"`
+	MaxInstances     *int      `json:"maxInstances,omitempty"`
+	MaxAutoScalerMax *int      `json:"maxAutoScalerMax,omitempty"`
+	MaxVolumeSizeGb  *int      `json:"maxVolumeSizeGb,omitempty"`
+	CreatedAt        time.Time `json:"createdAt,omitempty"`
+	UpdatedAt        time.Time `json:"updatedAt,omitempty"`
+}
+
+// LimitsDTO is the body of the request which sets a quota
+type LimitsDTO struct {
+	MaxInstances     *int `json:"maxInstances,omitempty"`
+	MaxAutoScalerMax *int `json:"maxAutoScalerMax,omitempty"`
+	MaxVolumeSizeGb  *int `json:"maxVolumeSizeGb,omitempty"`
+}
+
+type QuotaListDTO struct {
+	Data  []QuotaDTO `json:"data"`
+	Count int        `json:"count"`
+}
+
+// UsageDTO compares the resources used by the instances of a global account with the quota in effect
+type UsageDTO struct {
+	PlanName      string   `json:"planName"`
+	Quota         QuotaDTO `json:"quota"`
+	Instances     int      `json:"instances"`
+	AutoScalerMax int      `json:"autoScalerMax"`
+	VolumeSizeGb  int      `json:"volumeSizeGb"`
+}
+
+type UsageListDTO struct {
+	Data []UsageDTO `json:"data"`
+}
+
+type ListParameters struct {
+	GlobalAccountIDs []string
+	Plans            []string
+}
@@ -54,6 +54,26 @@ type RuntimeDTO struct {
 	KymaVersion                 string                         `json:"kymaVersion,omitempty"`
 	KymaConfig                  *gqlschema.KymaConfigInput     `json:"kymaConfig,omitempty"`
 	ClusterConfig               *gqlschema.GardenerConfigInput `json:"clusterConfig,omitempty"`
+	Trial                       *TrialDTO                      `json:"trial,omitempty"`
+}
+
+// TrialDTO shows the expiration of an expirable runtime together with its warnings, extensions and hibernation
+type TrialDTO struct {
+	ExpiresAt    time.Time       `json:"expiresAt"`
+	HibernatedAt *time.Time      `json:"hibernatedAt,omitempty"`
+	Events       []TrialEventDTO `json:"events"`
+}
+
+type TrialEventDTO struct {
+	Type      string    `json:"type"`
+	Period    string    `json:"period"`
+	Message   string    `json:"message,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// TrialExtensionDTO is the request body of the trial extension
+type TrialExtensionDTO struct {
+	Reason string `json:"reason,omitempty"`
 }
 
 type RuntimeStatus struct {
@@ -100,6 +120,7 @@ type Operation struct {
 	FinishedStages               []string      `json:"finishedStages"`
 	ExecutedButNotCompletedSteps []string      `json:"executedButNotCompletedSteps,omitempty"`
 	RuntimeVersion               string        `json:"runtimeVersion"`
+	TraceID                      string        `json:"traceID,omitempty"`
 }
 
 type RuntimesPage struct {
@@ -34,15 +34,17 @@ type RuntimeInfoHandler struct {
 	lastOperationFinder     LastOperationFinder
 	respWriter              ResponseWriter
 	plansConfig             broker.PlansConfig
+	planRegistry            *broker.PlanRegistry
 	defaultSubaccountRegion string
 }
 
-func NewRuntimeInfoHandler(instanceFinder InstanceFinder, lastOpFinder LastOperationFinder, plansConfig broker.PlansConfig, region string, respWriter ResponseWriter) *RuntimeInfoHandler {
+func NewRuntimeInfoHandler(instanceFinder InstanceFinder, lastOpFinder LastOperationFinder, plansConfig broker.PlansConfig, planRegistry *broker.PlanRegistry, region string, respWriter ResponseWriter) *RuntimeInfoHandler {
 	return &RuntimeInfoHandler{
 		instanceFinder:          instanceFinder,
 		lastOperationFinder:     lastOpFinder,
 		respWriter:              respWriter,
 		plansConfig:             plansConfig,
+		planRegistry:            planRegistry,
 		defaultSubaccountRegion: region,
 	}
 }
@@ -157,7 +159,7 @@ func (h *RuntimeInfoHandler) planNameOrDefault(inst internal.InstanceWithOperati
 	if inst.ServicePlanName != "" {
 		return inst.ServicePlanName
 	}
-	return broker.Plans(h.plansConfig, "", false, false)[inst.ServicePlanID].Name
+	return broker.Plans(nil, h.planRegistry, h.plansConfig, "", false, false)[inst.ServicePlanID].Name
 }
 
 func getIfNotZero(in time.Time) *time.Time {
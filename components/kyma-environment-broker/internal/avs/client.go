@@ -12,6 +12,7 @@ import (
 	"strings"
 
 	kebError "github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/error"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/tracing"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/oauth2"
 )
@@ -31,7 +32,7 @@ func NewClient(ctx context.Context, avsConfig Config, log logrus.FieldLogger) (*
 	}, nil
 }
 
-func (c *Client) CreateEvaluation(evaluationRequest *BasicEvaluationCreateRequest) (*BasicEvaluationCreateResponse, error) {
+func (c *Client) CreateEvaluation(ctx context.Context, evaluationRequest *BasicEvaluationCreateRequest) (*BasicEvaluationCreateResponse, error) {
 	var responseObject BasicEvaluationCreateResponse
 
 	objAsBytes, err := json.Marshal(evaluationRequest)
@@ -39,7 +40,7 @@ func (c *Client) CreateEvaluation(evaluationRequest *BasicEvaluationCreateReques
 		return &responseObject, fmt.Errorf("while marshaling evaluation request: %w", err)
 	}
 
-	request, err := http.NewRequest(http.MethodPost, c.avsConfig.ApiEndpoint, bytes.NewReader(objAsBytes))
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, c.avsConfig.ApiEndpoint, bytes.NewReader(objAsBytes))
 	if err != nil {
 		return &responseObject, fmt.Errorf("while creating request: %w", err)
 	}
@@ -63,11 +64,11 @@ func (c *Client) CreateEvaluation(evaluationRequest *BasicEvaluationCreateReques
 	return &responseObject, nil
 }
 
-func (c *Client) GetEvaluation(evaluationID int64) (*BasicEvaluationCreateResponse, error) {
+func (c *Client) GetEvaluation(ctx context.Context, evaluationID int64) (*BasicEvaluationCreateResponse, error) {
 	var responseObject BasicEvaluationCreateResponse
 	absoluteURL := appendId(c.avsConfig.ApiEndpoint, evaluationID)
 
-	request, err := http.NewRequest(http.MethodGet, absoluteURL, nil)
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, absoluteURL, nil)
 	if err != nil {
 		return &responseObject, fmt.Errorf("while creating request: %w", err)
 	}
@@ -91,7 +92,7 @@ func (c *Client) GetEvaluation(evaluationID int64) (*BasicEvaluationCreateRespon
 	return &responseObject, nil
 }
 
-func (c *Client) AddTag(evaluationID int64, tag *Tag) (*BasicEvaluationCreateResponse, error) {
+func (c *Client) AddTag(ctx context.Context, evaluationID int64, tag *Tag) (*BasicEvaluationCreateResponse, error) {
 	var responseObject BasicEvaluationCreateResponse
 
 	objAsBytes, err := json.Marshal(tag)
@@ -100,7 +101,7 @@ func (c *Client) AddTag(evaluationID int64, tag *Tag) (*BasicEvaluationCreateRes
 	}
 	absoluteURL := appendId(c.avsConfig.ApiEndpoint, evaluationID)
 
-	request, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/tag", absoluteURL), bytes.NewReader(objAsBytes))
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/tag", absoluteURL), bytes.NewReader(objAsBytes))
 	if err != nil {
 		return &responseObject, fmt.Errorf("while creating AddTag request: %w", err)
 	}
@@ -124,7 +125,7 @@ func (c *Client) AddTag(evaluationID int64, tag *Tag) (*BasicEvaluationCreateRes
 	return &responseObject, nil
 }
 
-func (c *Client) SetStatus(evaluationID int64, status string) (*BasicEvaluationCreateResponse, error) {
+func (c *Client) SetStatus(ctx context.Context, evaluationID int64, status string) (*BasicEvaluationCreateResponse, error) {
 	var responseObject BasicEvaluationCreateResponse
 
 	objAsBytes, err := json.Marshal(status)
@@ -133,7 +134,7 @@ func (c *Client) SetStatus(evaluationID int64, status string) (*BasicEvaluationC
 	}
 	absoluteURL := appendId(c.avsConfig.ApiEndpoint, evaluationID)
 
-	request, err := http.NewRequest(http.MethodPut, fmt.Sprintf("%s/lifecycle", absoluteURL), bytes.NewReader(objAsBytes))
+	request, err := http.NewRequestWithContext(ctx, http.MethodPut, fmt.Sprintf("%s/lifecycle", absoluteURL), bytes.NewReader(objAsBytes))
 	if err != nil {
 		return &responseObject, fmt.Errorf("while creating SetStatus request: %w", err)
 	}
@@ -157,9 +158,9 @@ func (c *Client) SetStatus(evaluationID int64, status string) (*BasicEvaluationC
 	return &responseObject, nil
 }
 
-func (c *Client) RemoveReferenceFromParentEval(parentID, evaluationID int64) (err error) {
+func (c *Client) RemoveReferenceFromParentEval(ctx context.Context, parentID, evaluationID int64) (err error) {
 	absoluteURL := fmt.Sprintf("%s/child/%d", appendId(c.avsConfig.ApiEndpoint, parentID), evaluationID)
-	response, err := c.deleteRequest(absoluteURL)
+	response, err := c.deleteRequest(ctx, absoluteURL)
 	if err == nil {
 		return nil
 	}
@@ -191,9 +192,9 @@ func (c *Client) RemoveReferenceFromParentEval(parentID, evaluationID int64) (er
 	return fmt.Errorf("unexpected response for evaluationId: %d while deleting reference from parent evaluation, error: %w", evaluationID, err)
 }
 
-func (c *Client) DeleteEvaluation(evaluationId int64) (err error) {
+func (c *Client) DeleteEvaluation(ctx context.Context, evaluationId int64) (err error) {
 	absoluteURL := appendId(c.avsConfig.ApiEndpoint, evaluationId)
-	response, err := c.deleteRequest(absoluteURL)
+	response, err := c.deleteRequest(ctx, absoluteURL)
 	defer func() {
 		if closeErr := c.closeResponseBody(response); closeErr != nil {
 			err = kebError.AsTemporaryError(closeErr, "while closing DeleteEvaluation response body")
@@ -214,8 +215,8 @@ func appendId(baseUrl string, id int64) string {
 	}
 }
 
-func (c *Client) deleteRequest(absoluteURL string) (*http.Response, error) {
-	req, err := http.NewRequest(http.MethodDelete, absoluteURL, nil)
+func (c *Client) deleteRequest(ctx context.Context, absoluteURL string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, absoluteURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("while creating delete request: %w", err)
 	}
@@ -297,5 +298,7 @@ func getHttpClient(ctx context.Context, cfg Config) (http.Client, error) {
 		return http.Client{}, kebError.AsTemporaryError(err, "while fetching initial token")
 	}
 
-	return *config.Client(ctx, initialToken), nil
+	httpClient := config.Client(ctx, initialToken)
+	httpClient.Transport = tracing.NewTransport(httpClient.Transport, "avs")
+	return *httpClient, nil
 }
@@ -26,7 +26,7 @@ func TestClient_CreateEvaluation(t *testing.T) {
 		assert.NoError(t, err)
 
 		// When
-		response, err := client.CreateEvaluation(&BasicEvaluationCreateRequest{
+		response, err := client.CreateEvaluation(context.Background(), &BasicEvaluationCreateRequest{
 			Name:     evaluationName,
 			ParentId: parentEvaluationID,
 		})
@@ -49,7 +49,7 @@ func TestClient_CreateEvaluation(t *testing.T) {
 		assert.NoError(t, err)
 
 		// When
-		response, err := client.CreateEvaluation(&BasicEvaluationCreateRequest{
+		response, err := client.CreateEvaluation(context.Background(), &BasicEvaluationCreateRequest{
 			Name:     evaluationName,
 			ParentId: parentEvaluationID,
 		})
@@ -73,7 +73,7 @@ func TestClient_CreateEvaluation(t *testing.T) {
 		assert.NoError(t, err)
 
 		// When
-		_, err = client.CreateEvaluation(&BasicEvaluationCreateRequest{
+		_, err = client.CreateEvaluation(context.Background(), &BasicEvaluationCreateRequest{
 			Name: "test_evaluation",
 		})
 
@@ -95,13 +95,13 @@ func TestClient_DeleteEvaluation(t *testing.T) {
 		}, logrus.New())
 		assert.NoError(t, err)
 
-		resp, err := client.CreateEvaluation(&BasicEvaluationCreateRequest{
+		resp, err := client.CreateEvaluation(context.Background(), &BasicEvaluationCreateRequest{
 			Name: "test_evaluation",
 		})
 		assert.NoError(t, err)
 
 		// When
-		err = client.DeleteEvaluation(resp.Id)
+		err = client.DeleteEvaluation(context.Background(), resp.Id)
 
 		// Then
 		assert.NoError(t, err)
@@ -119,14 +119,14 @@ func TestClient_DeleteEvaluation(t *testing.T) {
 		}, logrus.New())
 		assert.NoError(t, err)
 
-		_, err = client.CreateEvaluation(&BasicEvaluationCreateRequest{
+		_, err = client.CreateEvaluation(context.Background(), &BasicEvaluationCreateRequest{
 			Name:     "test_evaluation",
 			ParentId: parentEvaluationID,
 		})
 		assert.NoError(t, err)
 
 		// When
-		err = client.DeleteEvaluation(123)
+		err = client.DeleteEvaluation(context.Background(), 123)
 
 		// Then
 		assert.NoError(t, err)
@@ -146,14 +146,14 @@ func TestClient_GetEvaluation(t *testing.T) {
 		}, logrus.New())
 		assert.NoError(t, err)
 
-		resp, err := client.CreateEvaluation(&BasicEvaluationCreateRequest{
+		resp, err := client.CreateEvaluation(context.Background(), &BasicEvaluationCreateRequest{
 			Name:        "test_evaluation_create",
 			Description: "custom description",
 		})
 		assert.NoError(t, err)
 
 		// When
-		getResp, err := client.GetEvaluation(resp.Id)
+		getResp, err := client.GetEvaluation(context.Background(), resp.Id)
 
 		// Then
 		assert.NoError(t, err)
@@ -172,7 +172,7 @@ func TestClient_GetEvaluation(t *testing.T) {
 		assert.NoError(t, err)
 
 		// When
-		_, err = client.GetEvaluation(1)
+		_, err = client.GetEvaluation(context.Background(), 1)
 
 		// Then
 		assert.Contains(t, err.Error(), "404")
@@ -191,11 +191,11 @@ func TestClient_Status(t *testing.T) {
 		}, logrus.New())
 		assert.NoError(t, err)
 
-		resp, err := client.CreateEvaluation(&BasicEvaluationCreateRequest{})
+		resp, err := client.CreateEvaluation(context.Background(), &BasicEvaluationCreateRequest{})
 		assert.NoError(t, err)
 
 		// When
-		getResp, err := client.GetEvaluation(resp.Id)
+		getResp, err := client.GetEvaluation(context.Background(), resp.Id)
 
 		// Then
 		assert.NoError(t, err)
@@ -213,11 +213,11 @@ func TestClient_Status(t *testing.T) {
 		}, logrus.New())
 		assert.NoError(t, err)
 
-		resp, err := client.CreateEvaluation(&BasicEvaluationCreateRequest{})
+		resp, err := client.CreateEvaluation(context.Background(), &BasicEvaluationCreateRequest{})
 		assert.NoError(t, err)
 
 		// When
-		resp, err = client.SetStatus(resp.Id, StatusDeleted)
+		resp, err = client.SetStatus(context.Background(), resp.Id, StatusDeleted)
 
 		// Then
 		assert.NoError(t, err)
@@ -235,11 +235,11 @@ func TestClient_Status(t *testing.T) {
 		}, logrus.New())
 		assert.NoError(t, err)
 
-		resp, err := client.CreateEvaluation(&BasicEvaluationCreateRequest{})
+		resp, err := client.CreateEvaluation(context.Background(), &BasicEvaluationCreateRequest{})
 		assert.NoError(t, err)
 
 		// When
-		resp, err = client.SetStatus(resp.Id, "")
+		resp, err = client.SetStatus(context.Background(), resp.Id, "")
 
 		// Then
 		assert.Contains(t, err.Error(), "500")
@@ -258,14 +258,14 @@ func TestClient_RemoveReferenceFromParentEval(t *testing.T) {
 		}, logrus.New())
 		assert.NoError(t, err)
 
-		resp, err := client.CreateEvaluation(&BasicEvaluationCreateRequest{
+		resp, err := client.CreateEvaluation(context.Background(), &BasicEvaluationCreateRequest{
 			Name:     "test_evaluation",
 			ParentId: parentEvaluationID,
 		})
 		assert.NoError(t, err)
 
 		// When
-		err = client.RemoveReferenceFromParentEval(parentEvaluationID, resp.Id)
+		err = client.RemoveReferenceFromParentEval(context.Background(), parentEvaluationID, resp.Id)
 
 		// Then
 		assert.NoError(t, err)
@@ -283,7 +283,7 @@ func TestClient_RemoveReferenceFromParentEval(t *testing.T) {
 		assert.NoError(t, err)
 
 		// When
-		err = client.RemoveReferenceFromParentEval(parentEvaluationID, 111)
+		err = client.RemoveReferenceFromParentEval(context.Background(), parentEvaluationID, 111)
 
 		// then
 		assert.Error(t, err)
@@ -300,7 +300,7 @@ func TestClient_RemoveReferenceFromParentEval(t *testing.T) {
 		assert.NoError(t, err)
 
 		// When
-		err = client.RemoveReferenceFromParentEval(int64(9999), 111)
+		err = client.RemoveReferenceFromParentEval(context.Background(), int64(9999), 111)
 
 		// then
 		assert.NoError(t, err)
@@ -320,7 +320,7 @@ func TestClient_AddTag(t *testing.T) {
 		}, logrus.New())
 		assert.NoError(t, err)
 
-		response, err := client.CreateEvaluation(&BasicEvaluationCreateRequest{
+		response, err := client.CreateEvaluation(context.Background(), &BasicEvaluationCreateRequest{
 			Name:     "test_evaluation",
 			ParentId: parentEvaluationID,
 		})
@@ -329,7 +329,7 @@ func TestClient_AddTag(t *testing.T) {
 		fixedTag := FixTag()
 
 		// when
-		eval, err := client.AddTag(response.Id, fixedTag)
+		eval, err := client.AddTag(context.Background(), response.Id, fixedTag)
 
 		// then
 		assert.NoError(t, err)
@@ -1,6 +1,7 @@
 package avs
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -8,6 +9,7 @@ import (
 	kebError "github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/error"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/tracing"
 	"github.com/sirupsen/logrus"
 )
 
@@ -49,7 +51,7 @@ func (del *Delegator) CreateEvaluation(log logrus.FieldLogger, operation interna
 			return operation, 5 * time.Second, nil
 		}
 
-		evalResp, err := del.client.CreateEvaluation(evaluationObject)
+		evalResp, err := del.client.CreateEvaluation(tracing.StepContext(operation), evaluationObject)
 		switch {
 		case err == nil:
 		case kebError.IsTemporaryError(err):
@@ -79,8 +81,9 @@ func (del *Delegator) AddTags(log logrus.FieldLogger, operation internal.Operati
 	log.Infof("making avs calls to add tags to the Evaluation")
 	evalID := evalAssistant.GetEvaluationId(operation.Avs)
 
+	ctx := tracing.StepContext(operation)
 	for _, tag := range tags {
-		_, err := del.client.AddTag(evalID, tag)
+		_, err := del.client.AddTag(ctx, evalID, tag)
 		switch {
 		case err == nil:
 		case kebError.IsTemporaryError(err):
@@ -99,7 +102,7 @@ func (del *Delegator) AddTags(log logrus.FieldLogger, operation internal.Operati
 	return updatedOperation, d, nil
 }
 
-func (del *Delegator) ResetStatus(log logrus.FieldLogger, lifecycleData *internal.AvsLifecycleData, evalAssistant EvalAssistant) error {
+func (del *Delegator) ResetStatus(ctx context.Context, log logrus.FieldLogger, lifecycleData *internal.AvsLifecycleData, evalAssistant EvalAssistant) error {
 	status := evalAssistant.GetOriginalEvalStatus(*lifecycleData)
 	// For cases when operation is not loaded (properly) from DB, status fields will be rendered
 	// invalid. This will lead to a failing operation on reset in the following scenario:
@@ -112,17 +115,17 @@ func (del *Delegator) ResetStatus(log logrus.FieldLogger, lifecycleData *interna
 		status = StatusActive
 	}
 
-	return del.SetStatus(log, lifecycleData, evalAssistant, status)
+	return del.SetStatus(ctx, log, lifecycleData, evalAssistant, status)
 }
 
 // RefreshStatus ensures that operation AVS lifecycle data is fetched from Avs API
-func (del *Delegator) RefreshStatus(log logrus.FieldLogger, lifecycleData *internal.AvsLifecycleData, evalAssistant EvalAssistant) string {
+func (del *Delegator) RefreshStatus(ctx context.Context, log logrus.FieldLogger, lifecycleData *internal.AvsLifecycleData, evalAssistant EvalAssistant) string {
 	evalID := evalAssistant.GetEvaluationId(*lifecycleData)
 	currentStatus := evalAssistant.GetEvalStatus(*lifecycleData)
 
 	// obtain status from avs
 	log.Infof("making avs calls to get evaluation data")
-	eval, err := del.client.GetEvaluation(evalID)
+	eval, err := del.client.GetEvaluation(ctx, evalID)
 	if err != nil || eval == nil {
 		log.Errorf("cannot obtain evaluation data on RefreshStatus: %s", err)
 	} else {
@@ -134,7 +137,7 @@ func (del *Delegator) RefreshStatus(log logrus.FieldLogger, lifecycleData *inter
 	return currentStatus
 }
 
-func (del *Delegator) SetStatus(log logrus.FieldLogger, lifecycleData *internal.AvsLifecycleData, evalAssistant EvalAssistant, status string) error {
+func (del *Delegator) SetStatus(ctx context.Context, log logrus.FieldLogger, lifecycleData *internal.AvsLifecycleData, evalAssistant EvalAssistant, status string) error {
 	// skip for non-existent or deleted evaluation
 	if !evalAssistant.IsValid(*lifecycleData) {
 		return nil
@@ -148,14 +151,14 @@ func (del *Delegator) SetStatus(log logrus.FieldLogger, lifecycleData *internal.
 	}
 
 	evalID := evalAssistant.GetEvaluationId(*lifecycleData)
-	currentStatus := del.RefreshStatus(log, lifecycleData, evalAssistant)
+	currentStatus := del.RefreshStatus(ctx, log, lifecycleData, evalAssistant)
 
 	log.Infof("SetStatus %s to avs id [%d]", status, evalID)
 
 	// do api call iff current and requested status are different
 	if currentStatus != status {
 		log.Infof("making avs calls to set status %s to the evaluation", status)
-		_, err := del.client.SetStatus(evalID, status)
+		_, err := del.client.SetStatus(ctx, evalID, status)
 
 		switch {
 		case err == nil:
@@ -197,13 +200,14 @@ func (del *Delegator) DeleteAvsEvaluation(deProvisioningOperation internal.Opera
 func (del *Delegator) tryDeleting(assistant EvalAssistant, deProvisioningOperation internal.Operation, logger logrus.FieldLogger) error {
 	evaluationID := assistant.GetEvaluationId(deProvisioningOperation.Avs)
 	parentID := assistant.ProvideParentId(deProvisioningOperation.ProvisioningParameters)
-	err := del.client.RemoveReferenceFromParentEval(parentID, evaluationID)
+	ctx := tracing.StepContext(deProvisioningOperation)
+	err := del.client.RemoveReferenceFromParentEval(ctx, parentID, evaluationID)
 	if err != nil {
 		logger.Errorf("error while deleting reference for evaluation %v", err)
 		return err
 	}
 
-	err = del.client.DeleteEvaluation(evaluationID)
+	err = del.client.DeleteEvaluation(ctx, evaluationID)
 	if err != nil {
 		logger.Errorf("error while deleting evaluation %v", err)
 	}
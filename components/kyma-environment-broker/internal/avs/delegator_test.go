@@ -22,8 +22,8 @@ func newTestParams(t *testing.T) (*Client, *MockAvsServer, Config, *InternalEval
 	}
 	client, err := NewClient(context.TODO(), avsCfg, logrus.New())
 	assert.NoError(t, err)
-	iea := NewInternalEvalAssistant(avsCfg)
-	eea := NewExternalEvalAssistant(avsCfg)
+	iea := NewInternalEvalAssistant(avsCfg, nil)
+	eea := NewExternalEvalAssistant(avsCfg, nil)
 
 	log := logrus.New()
 
@@ -75,11 +75,11 @@ func setOpAvsStatus(op *internal.UpgradeKymaOperation, current string, original
 }
 
 func createMonitors(client *Client) (BasicEvaluationCreateResponse, BasicEvaluationCreateResponse) {
-	internalEval, _ := client.CreateEvaluation(&BasicEvaluationCreateRequest{
+	internalEval, _ := client.CreateEvaluation(context.Background(), &BasicEvaluationCreateRequest{
 		Name: "internal-monitor",
 	})
 
-	externalEval, _ := client.CreateEvaluation(&BasicEvaluationCreateRequest{
+	externalEval, _ := client.CreateEvaluation(context.Background(), &BasicEvaluationCreateRequest{
 		Name: "external-monitor",
 	})
 
@@ -106,7 +106,7 @@ func TestDelegator_SetStatus(t *testing.T) {
 		current, _ := setOpAvsStatus(&op, StatusActive, StatusMaintenance)
 
 		// When
-		err := delegator.SetStatus(logger, &op.Avs, internalEA, requested)
+		err := delegator.SetStatus(context.Background(), logger, &op.Avs, internalEA, requested)
 
 		// Then
 		assert.NoError(t, err)
@@ -121,7 +121,7 @@ func TestDelegator_SetStatus(t *testing.T) {
 		current, _ := setOpAvsStatus(&op, requested, StatusMaintenance)
 
 		// When
-		err := delegator.SetStatus(logger, &op.Avs, internalEA, requested)
+		err := delegator.SetStatus(context.Background(), logger, &op.Avs, internalEA, requested)
 
 		// Then
 		assert.NoError(t, err)
@@ -136,7 +136,7 @@ func TestDelegator_SetStatus(t *testing.T) {
 		current, _ := setOpAvsStatus(&op, StatusActive, "")
 
 		// When
-		err := delegator.SetStatus(logger, &op.Avs, internalEA, requested)
+		err := delegator.SetStatus(context.Background(), logger, &op.Avs, internalEA, requested)
 
 		// Then
 		assert.NoError(t, err)
@@ -151,7 +151,7 @@ func TestDelegator_SetStatus(t *testing.T) {
 		current, _ := setOpAvsStatus(&op, requested, "")
 
 		// When
-		err := delegator.SetStatus(logger, &op.Avs, internalEA, requested)
+		err := delegator.SetStatus(context.Background(), logger, &op.Avs, internalEA, requested)
 
 		// Then
 		assert.NoError(t, err)
@@ -166,7 +166,7 @@ func TestDelegator_SetStatus(t *testing.T) {
 		setOpAvsStatus(&op, "", StatusMaintenance)
 
 		// When
-		err := delegator.SetStatus(logger, &op.Avs, internalEA, requested)
+		err := delegator.SetStatus(context.Background(), logger, &op.Avs, internalEA, requested)
 
 		// Then
 		assert.NoError(t, err)
@@ -181,7 +181,7 @@ func TestDelegator_SetStatus(t *testing.T) {
 		_, _ = setOpAvsStatus(&op, "", StatusMaintenance)
 
 		// When
-		err := delegator.SetStatus(logger, &op.Avs, internalEA, requested)
+		err := delegator.SetStatus(context.Background(), logger, &op.Avs, internalEA, requested)
 
 		// Then
 		assert.NoError(t, err)
@@ -196,7 +196,7 @@ func TestDelegator_SetStatus(t *testing.T) {
 		_, _ = setOpAvsStatus(&op, "", "")
 
 		// When
-		err := delegator.SetStatus(logger, &op.Avs, internalEA, requested)
+		err := delegator.SetStatus(context.Background(), logger, &op.Avs, internalEA, requested)
 
 		// Then
 		assert.NoError(t, err)
@@ -213,7 +213,7 @@ func TestDelegator_SetStatus(t *testing.T) {
 		_, _ = setOpAvsStatus(&op, StatusActive, StatusMaintenance)
 
 		// When
-		err := delegator.SetStatus(logger, &op.Avs, internalEA, requested)
+		err := delegator.SetStatus(context.Background(), logger, &op.Avs, internalEA, requested)
 
 		// Then
 		assert.NotNil(t, err)
@@ -227,7 +227,7 @@ func TestDelegator_SetStatus(t *testing.T) {
 		version := op.Version
 
 		// When
-		err := delegator.SetStatus(logger, &op.Avs, internalEA, StatusActive)
+		err := delegator.SetStatus(context.Background(), logger, &op.Avs, internalEA, StatusActive)
 
 		// Then
 		assert.NoError(t, err)
@@ -241,7 +241,7 @@ func TestDelegator_SetStatus(t *testing.T) {
 		version := op.Version
 
 		// When
-		err := delegator.SetStatus(logger, &op.Avs, internalEA, StatusActive)
+		err := delegator.SetStatus(context.Background(), logger, &op.Avs, internalEA, StatusActive)
 
 		// Then
 		assert.NoError(t, err)
@@ -254,7 +254,7 @@ func TestDelegator_SetStatus(t *testing.T) {
 		current, original := setOpAvsStatus(&op, StatusActive, StatusMaintenance)
 
 		// When
-		err := delegator.ResetStatus(logger, &op.Avs, internalEA)
+		err := delegator.ResetStatus(context.Background(), logger, &op.Avs, internalEA)
 
 		// Then
 		assert.NoError(t, err)
@@ -268,7 +268,7 @@ func TestDelegator_SetStatus(t *testing.T) {
 		current, _ := setOpAvsStatus(&op, StatusInactive, StatusInactive)
 
 		// When
-		err := delegator.ResetStatus(logger, &op.Avs, internalEA)
+		err := delegator.ResetStatus(context.Background(), logger, &op.Avs, internalEA)
 
 		// Then
 		assert.NoError(t, err)
@@ -284,7 +284,7 @@ func TestDelegator_SetStatus(t *testing.T) {
 		_, _ = setOpAvsStatus(&op, "", "")
 
 		// When
-		err := delegator.ResetStatus(logger, &op.Avs, internalEA)
+		err := delegator.ResetStatus(context.Background(), logger, &op.Avs, internalEA)
 
 		// Then
 		assert.NoError(t, err)
@@ -301,7 +301,7 @@ func TestDelegator_SetStatus(t *testing.T) {
 		_, _ = setOpAvsStatus(&op, StatusMaintenance, "")
 
 		// When
-		err := delegator.ResetStatus(logger, &op.Avs, internalEA)
+		err := delegator.ResetStatus(context.Background(), logger, &op.Avs, internalEA)
 
 		// Then
 		assert.NoError(t, err)
@@ -321,7 +321,7 @@ func TestDelegator_SetStatus(t *testing.T) {
 		_, original := setOpAvsStatus(&op, "", StatusMaintenance)
 
 		// When
-		err := delegator.ResetStatus(logger, &op.Avs, internalEA)
+		err := delegator.ResetStatus(context.Background(), logger, &op.Avs, internalEA)
 
 		// Then
 		assert.NoError(t, err)
@@ -336,7 +336,7 @@ func TestDelegator_SetStatus(t *testing.T) {
 		_, _ = setOpAvsStatus(&op, StatusInactive, "invalid")
 
 		// When
-		err := delegator.ResetStatus(logger, &op.Avs, internalEA)
+		err := delegator.ResetStatus(context.Background(), logger, &op.Avs, internalEA)
 
 		// Then
 		assert.NoError(t, err)
@@ -352,7 +352,7 @@ func TestDelegator_SetStatus(t *testing.T) {
 		_, original := setOpAvsStatus(&op, "invalidField", StatusMaintenance)
 
 		// When
-		err := delegator.ResetStatus(logger, &op.Avs, internalEA)
+		err := delegator.ResetStatus(context.Background(), logger, &op.Avs, internalEA)
 
 		// Then
 		assert.NoError(t, err)
@@ -370,7 +370,7 @@ func TestDelegator_SetStatus(t *testing.T) {
 		current, _ := setOpAvsStatus(&op, StatusInactive, "invalid")
 
 		// When
-		err := delegator.ResetStatus(logger, &op.Avs, internalEA)
+		err := delegator.ResetStatus(context.Background(), logger, &op.Avs, internalEA)
 
 		// Then
 		assert.NoError(t, err)
@@ -389,7 +389,7 @@ func TestDelegator_SetStatus(t *testing.T) {
 		current, _ := setOpAvsStatus(&op, StatusInactive, instanceStatus)
 
 		// When
-		err := delegator.ResetStatus(logger, &op.Avs, internalEA)
+		err := delegator.ResetStatus(context.Background(), logger, &op.Avs, internalEA)
 
 		// Then
 		assert.NoError(t, err)
@@ -408,7 +408,7 @@ func TestDelegator_SetStatus(t *testing.T) {
 		_, original := setOpAvsStatus(&op, "invalid", StatusInactive)
 
 		// When
-		err := delegator.ResetStatus(logger, &op.Avs, internalEA)
+		err := delegator.ResetStatus(context.Background(), logger, &op.Avs, internalEA)
 
 		// Then
 		assert.NoError(t, err)
@@ -426,7 +426,7 @@ func TestDelegator_SetStatus(t *testing.T) {
 		_, original := setOpAvsStatus(&op, instanceStatus, StatusInactive)
 
 		// When
-		err := delegator.ResetStatus(logger, &op.Avs, internalEA)
+		err := delegator.ResetStatus(context.Background(), logger, &op.Avs, internalEA)
 
 		// Then
 		assert.NoError(t, err)
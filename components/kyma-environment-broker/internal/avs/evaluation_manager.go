@@ -1,7 +1,10 @@
 package avs
 
 import (
+	"context"
+
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
 	"github.com/sirupsen/logrus"
 )
 
@@ -12,25 +15,25 @@ type EvaluationManager struct {
 	externalAssistant *ExternalEvalAssistant
 }
 
-func NewEvaluationManager(delegator *Delegator, config Config) *EvaluationManager {
+func NewEvaluationManager(delegator *Delegator, config Config, planRegistry *broker.PlanRegistry) *EvaluationManager {
 	return &EvaluationManager{
 		delegator:         delegator,
 		avsConfig:         config,
-		internalAssistant: NewInternalEvalAssistant(config),
-		externalAssistant: NewExternalEvalAssistant(config),
+		internalAssistant: NewInternalEvalAssistant(config, planRegistry),
+		externalAssistant: NewExternalEvalAssistant(config, planRegistry),
 	}
 }
 
 // SetStatus updates evaluation monitors (internal and external) status.
-func (em *EvaluationManager) SetStatus(status string, avsData *internal.AvsLifecycleData, logger logrus.FieldLogger) error {
+func (em *EvaluationManager) SetStatus(ctx context.Context, status string, avsData *internal.AvsLifecycleData, logger logrus.FieldLogger) error {
 	// do internal monitor status update
-	err := em.delegator.SetStatus(logger, avsData, em.internalAssistant, status)
+	err := em.delegator.SetStatus(ctx, logger, avsData, em.internalAssistant, status)
 	if err != nil {
 		return err
 	}
 
 	// do external monitor status update
-	err = em.delegator.SetStatus(logger, avsData, em.externalAssistant, status)
+	err = em.delegator.SetStatus(ctx, logger, avsData, em.externalAssistant, status)
 	if err != nil {
 		return err
 	}
@@ -41,15 +44,15 @@ func (em *EvaluationManager) SetStatus(status string, avsData *internal.AvsLifec
 // RestoreStatus reverts previously set evaluation monitors status.
 // On error, parent method should fail the operation progress.
 // On delay, parent method should retry.
-func (em *EvaluationManager) RestoreStatus(avsData *internal.AvsLifecycleData, logger logrus.FieldLogger) error {
+func (em *EvaluationManager) RestoreStatus(ctx context.Context, avsData *internal.AvsLifecycleData, logger logrus.FieldLogger) error {
 	// do internal monitor status reset
-	err := em.delegator.ResetStatus(logger, avsData, em.internalAssistant)
+	err := em.delegator.ResetStatus(ctx, logger, avsData, em.internalAssistant)
 	if err != nil {
 		return err
 	}
 
 	// do external monitor status reset
-	err = em.delegator.ResetStatus(logger, avsData, em.externalAssistant)
+	err = em.delegator.ResetStatus(ctx, logger, avsData, em.externalAssistant)
 	if err != nil {
 		return err
 	}
@@ -57,8 +60,8 @@ func (em *EvaluationManager) RestoreStatus(avsData *internal.AvsLifecycleData, l
 	return nil
 }
 
-func (em *EvaluationManager) SetMaintenanceStatus(avsData *internal.AvsLifecycleData, logger logrus.FieldLogger) error {
-	return em.SetStatus(StatusMaintenance, avsData, logger)
+func (em *EvaluationManager) SetMaintenanceStatus(ctx context.Context, avsData *internal.AvsLifecycleData, logger logrus.FieldLogger) error {
+	return em.SetStatus(ctx, StatusMaintenance, avsData, logger)
 }
 
 func (em *EvaluationManager) InMaintenance(avsData internal.AvsLifecycleData) bool {
@@ -4,24 +4,27 @@ import (
 	"time"
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
 )
 
 const externalEvalCheckType = "HTTPSGET"
 
 type ExternalEvalAssistant struct {
-	avsConfig   Config
-	retryConfig *RetryConfig
+	avsConfig    Config
+	retryConfig  *RetryConfig
+	planRegistry *broker.PlanRegistry
 }
 
-func NewExternalEvalAssistant(avsConfig Config) *ExternalEvalAssistant {
+func NewExternalEvalAssistant(avsConfig Config, planRegistry *broker.PlanRegistry) *ExternalEvalAssistant {
 	return &ExternalEvalAssistant{
-		avsConfig:   avsConfig,
-		retryConfig: &RetryConfig{maxTime: 20 * time.Minute, retryInterval: 30 * time.Second},
+		avsConfig:    avsConfig,
+		retryConfig:  &RetryConfig{maxTime: 20 * time.Minute, retryInterval: 30 * time.Second},
+		planRegistry: planRegistry,
 	}
 }
 
 func (eea *ExternalEvalAssistant) CreateBasicEvaluationRequest(operations internal.Operation, url string) (*BasicEvaluationCreateRequest, error) {
-	return newBasicEvaluationCreateRequest(operations, eea, url)
+	return newBasicEvaluationCreateRequest(operations, eea, eea.planRegistry, url)
 }
 
 func (eea *ExternalEvalAssistant) IsAlreadyCreated(lifecycleData internal.AvsLifecycleData) bool {
@@ -8,19 +8,21 @@ import (
 )
 
 type InternalEvalAssistant struct {
-	avsConfig   Config
-	retryConfig *RetryConfig
+	avsConfig    Config
+	retryConfig  *RetryConfig
+	planRegistry *broker.PlanRegistry
 }
 
-func NewInternalEvalAssistant(avsConfig Config) *InternalEvalAssistant {
+func NewInternalEvalAssistant(avsConfig Config, planRegistry *broker.PlanRegistry) *InternalEvalAssistant {
 	return &InternalEvalAssistant{
-		avsConfig:   avsConfig,
-		retryConfig: &RetryConfig{maxTime: 10 * time.Minute, retryInterval: 30 * time.Second},
+		avsConfig:    avsConfig,
+		retryConfig:  &RetryConfig{maxTime: 10 * time.Minute, retryInterval: 30 * time.Second},
+		planRegistry: planRegistry,
 	}
 }
 
 func (iec *InternalEvalAssistant) CreateBasicEvaluationRequest(operations internal.Operation, url string) (*BasicEvaluationCreateRequest, error) {
-	return newBasicEvaluationCreateRequest(operations, iec, url)
+	return newBasicEvaluationCreateRequest(operations, iec, iec.planRegistry, url)
 }
 
 func (iec *InternalEvalAssistant) IsAlreadyCreated(lifecycleData internal.AvsLifecycleData) bool {
@@ -36,21 +38,21 @@ func (iec *InternalEvalAssistant) ProvideSuffix() string {
 }
 
 func (iec *InternalEvalAssistant) ProvideTesterAccessId(pp internal.ProvisioningParameters) int64 {
-	if (broker.IsTrialPlan(pp.PlanID) || broker.IsFreemiumPlan(pp.PlanID)) && iec.avsConfig.IsTrialConfigured() {
+	if (broker.IsTrialPlan(pp.PlanID) || iec.planRegistry.IsFreemiumPlan(pp.PlanID)) && iec.avsConfig.IsTrialConfigured() {
 		return iec.avsConfig.TrialInternalTesterAccessId
 	}
 	return iec.avsConfig.InternalTesterAccessId
 }
 
 func (iec *InternalEvalAssistant) ProvideGroupId(pp internal.ProvisioningParameters) int64 {
-	if (broker.IsTrialPlan(pp.PlanID) || broker.IsFreemiumPlan(pp.PlanID)) && iec.avsConfig.IsTrialConfigured() {
+	if (broker.IsTrialPlan(pp.PlanID) || iec.planRegistry.IsFreemiumPlan(pp.PlanID)) && iec.avsConfig.IsTrialConfigured() {
 		return iec.avsConfig.TrialGroupId
 	}
 	return iec.avsConfig.GroupId
 }
 
 func (iec *InternalEvalAssistant) ProvideParentId(pp internal.ProvisioningParameters) int64 {
-	if (broker.IsTrialPlan(pp.PlanID) || broker.IsFreemiumPlan(pp.PlanID)) && iec.avsConfig.IsTrialConfigured() {
+	if (broker.IsTrialPlan(pp.PlanID) || iec.planRegistry.IsFreemiumPlan(pp.PlanID)) && iec.avsConfig.IsTrialConfigured() {
 		return iec.avsConfig.TrialParentId
 	}
 	return iec.avsConfig.ParentId
@@ -91,9 +91,9 @@ type BasicEvaluationCreateResponse struct {
 	IdOnTester                 string `json:"id_on_tester"`
 }
 
-func newBasicEvaluationCreateRequest(operation internal.Operation, evalTypeSpecificConfig ModelConfigurator, url string) (*BasicEvaluationCreateRequest, error) {
+func newBasicEvaluationCreateRequest(operation internal.Operation, evalTypeSpecificConfig ModelConfigurator, planRegistry *broker.PlanRegistry, url string) (*BasicEvaluationCreateRequest, error) {
 
-	beName, beDescription := generateNameAndDescription(operation, evalTypeSpecificConfig.ProvideSuffix())
+	beName, beDescription := generateNameAndDescription(operation, evalTypeSpecificConfig.ProvideSuffix(), planRegistry)
 
 	return &BasicEvaluationCreateRequest{
 		DefinitionType:   DefinitionType,
@@ -116,20 +116,20 @@ func newBasicEvaluationCreateRequest(operation internal.Operation, evalTypeSpeci
 	}, nil
 }
 
-func generateNameAndDescription(operation internal.Operation, beType string) (string, string) {
+func generateNameAndDescription(operation internal.Operation, beType string, planRegistry *broker.PlanRegistry) (string, string) {
 	globalAccountID := operation.ProvisioningParameters.ErsContext.GlobalAccountID
 	subAccountID := operation.ProvisioningParameters.ErsContext.SubAccountID
 	instanceID := operation.InstanceID
 	name := operation.ProvisioningParameters.Parameters.Name
 	shootName := operation.InstanceDetails.ShootName
-	beName := fmt.Sprintf("K8S-%s-Kyma-%s-%s-%s", providerCodeByPlan(operation.ProvisioningParameters.PlanID), beType, instanceID, name)
+	beName := fmt.Sprintf("K8S-%s-Kyma-%s-%s-%s", providerCodeByPlan(operation.ProvisioningParameters.PlanID, planRegistry), beType, instanceID, name)
 	beDescription := fmt.Sprintf("{\"instanceName\": \"%s\", \"globalAccountID\": \"%s\", \"subAccountID\": \"%s\", \"instanceID\": \"%s\", \"shootName\": \"%s\"}",
 		name, globalAccountID, subAccountID, instanceID, shootName)
 
 	return truncateString(beName, 80), truncateString(beDescription, 255)
 }
 
-func providerCodeByPlan(planID string) string {
+func providerCodeByPlan(planID string, planRegistry *broker.PlanRegistry) string {
 	switch planID {
 	case broker.AWSPlanID:
 		return "AWS"
@@ -142,6 +142,16 @@ func providerCodeByPlan(planID string) string {
 	case broker.OpenStackPlanID:
 		return "CC"
 	default:
+		if definition, found := planRegistry.DefinitionByID(planID); found {
+			switch definition.Provider {
+			case internal.AWS:
+				return "AWS"
+			case internal.GCP:
+				return "GCP"
+			case internal.Openstack:
+				return "CC"
+			}
+		}
 		return "AZR"
 	}
 }
@@ -31,8 +31,8 @@ func TestAvsEvaluationConfigs(t *testing.T) {
 	mockAvsServer := newMockAvsServer(t)
 	defer mockAvsServer.Close()
 	avsConfig := avsConfig(mockOauthServer, mockAvsServer)
-	internalEvalAssistant := NewInternalEvalAssistant(avsConfig)
-	externalEvalAssistant := NewExternalEvalAssistant(avsConfig)
+	internalEvalAssistant := NewInternalEvalAssistant(avsConfig, nil)
+	externalEvalAssistant := NewExternalEvalAssistant(avsConfig, nil)
 
 	// verify assistant configs
 	assert.Equal(internalEvalId, internalEvalAssistant.ProvideTesterAccessId(internal.ProvisioningParameters{}))
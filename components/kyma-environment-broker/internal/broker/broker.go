@@ -18,6 +18,14 @@ const (
 
 type PlanDefaults func(planID string, platformProvider internal.CloudProvider, parametersProvider *internal.CloudProvider) (*gqlschema.ClusterConfigInput, error)
 
+// QuotaChecker verifies that provisioning or updating an instance does not exceed the quotas of the global account,
+// the check and the instance change are done under the lock of the global account
+type QuotaChecker interface {
+	CheckProvisioning(globalAccountID, planID string, autoScalerMax, volumeSizeGb int) error
+	CheckUpdate(instance internal.Instance, parameters internal.ProvisioningParametersDTO) error
+	Lock(globalAccountID string) (unlock func(), err error)
+}
+
 type KymaEnvironmentBroker struct {
 	*ServicesEndpoint
 	*ProvisionEndpoint
@@ -96,16 +104,10 @@ type PlanMetadata struct {
 // EnablePlans defines the plans that should be available for provisioning
 type EnablePlans []string
 
-// Unmarshal provides custom parsing of enabled plans.
+// Unmarshal provides custom parsing of enabled plans, the names are validated by PlanRegistry.ValidateEnabledPlans
+// as the defined plans are not known yet when the configuration is read.
 // Implements envconfig.Unmarshal interface.
 func (m *EnablePlans) Unmarshal(in string) error {
-	plans := strings.Split(in, ",")
-	for _, name := range plans {
-		if _, exists := PlanIDsMapping[name]; !exists {
-			return fmt.Errorf("unrecognized %v plan name", name)
-		}
-	}
-
-	*m = plans
+	*m = strings.Split(in, ",")
 	return nil
 }
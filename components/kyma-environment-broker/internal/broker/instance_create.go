@@ -15,12 +15,14 @@ import (
 	"github.com/google/uuid"
 	"github.com/kyma-incubator/compass/components/director/pkg/jsonschema"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/gardener"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/hyperscaler"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/dashboard"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/middleware"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/ptr"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbmodel"
 	"github.com/pivotal-cf/brokerapi/v8/domain"
 	"github.com/pivotal-cf/brokerapi/v8/domain/apiresponses"
 	"github.com/sirupsen/logrus"
@@ -49,6 +51,9 @@ type ProvisionEndpoint struct {
 	plansConfig       PlansConfig
 	kymaVerOnDemand   bool
 	planDefaults      PlanDefaults
+	planCatalog       PlanCatalog
+	planRegistry      *PlanRegistry
+	quotaChecker      QuotaChecker
 
 	shootDomain       string
 	shootProject      string
@@ -71,6 +76,9 @@ func NewProvision(cfg Config,
 	plansConfig PlansConfig,
 	kvod bool,
 	planDefaults PlanDefaults,
+	planCatalog PlanCatalog,
+	planRegistry *PlanRegistry,
+	quotaChecker QuotaChecker,
 	euAccessWhitelist euaccess.WhitelistSet,
 	euRejectMessage string,
 	log logrus.FieldLogger,
@@ -78,7 +86,7 @@ func NewProvision(cfg Config,
 ) *ProvisionEndpoint {
 	enabledPlanIDs := map[string]struct{}{}
 	for _, planName := range cfg.EnablePlans {
-		id := PlanIDsMapping[planName]
+		id := planRegistry.PlanIDs()[planName]
 		enabledPlanIDs[id] = struct{}{}
 	}
 
@@ -96,6 +104,9 @@ func NewProvision(cfg Config,
 		shootProject:             gardenerConfig.Project,
 		shootDnsProviders:        gardenerConfig.DNSProviders,
 		planDefaults:             planDefaults,
+		planCatalog:              planCatalog,
+		planRegistry:             planRegistry,
+		quotaChecker:             quotaChecker,
 		euAccessWhitelist:        euAccessWhitelist,
 		euAccessRejectionMessage: euRejectMessage,
 		dashboardConfig:          dashboardConfig,
@@ -151,6 +162,24 @@ func (b *ProvisionEndpoint) Provision(ctx context.Context, instanceID string, de
 		return b.handleExistingOperation(existingOperation, provisioningParameters)
 	}
 
+	if b.quotaChecker != nil {
+		// the instance is inserted under the lock, so concurrent requests of the global account see it in the quota usage
+		unlock, err := b.quotaChecker.Lock(ersContext.GlobalAccountID)
+		if err != nil {
+			logger.Errorf("unable to lock the quota of the global account: %s", err)
+			return domain.ProvisionedServiceSpec{}, fmt.Errorf("unable to check the quota of the global account")
+		}
+		defer unlock()
+	}
+	if err := b.checkQuota(provisioningParameters); err != nil {
+		logger.Infof("provisioning rejected: %s", err)
+		return domain.ProvisionedServiceSpec{}, err
+	}
+
+	if err := b.checkSingleInstance(details.PlanID, ersContext.GlobalAccountID, logger); err != nil {
+		return domain.ProvisionedServiceSpec{}, apiresponses.NewFailureResponse(err, http.StatusBadRequest, err.Error())
+	}
+
 	shootName := gardener.CreateShootName()
 	shootDomainSuffix := strings.Trim(b.shootDomain, ".")
 
@@ -187,7 +216,7 @@ func (b *ProvisionEndpoint) Provision(ctx context.Context, instanceID string, de
 		ServiceID:       provisioningParameters.ServiceID,
 		ServiceName:     KymaServiceName,
 		ServicePlanID:   provisioningParameters.PlanID,
-		ServicePlanName: PlanNamesMapping[provisioningParameters.PlanID],
+		ServicePlanName: b.planRegistry.PlanNames()[provisioningParameters.PlanID],
 		DashboardURL:    dashboardURL,
 		Parameters:      operation.ProvisioningParameters,
 	}
@@ -210,6 +239,33 @@ func (b *ProvisionEndpoint) Provision(ctx context.Context, instanceID string, de
 	}, nil
 }
 
+// checkQuota verifies the requested resources against the quotas, plan defaults are used for the parameters which are not set
+func (b *ProvisionEndpoint) checkQuota(pp internal.ProvisioningParameters) error {
+	if b.quotaChecker == nil {
+		return nil
+	}
+	autoScalerMax, volumeSizeGb := 0, 0
+	if pp.Parameters.AutoScalerMax == nil || pp.Parameters.VolumeSizeGb == nil {
+		defaults, err := b.planDefaults(pp.PlanID, pp.PlatformProvider, pp.Parameters.Provider)
+		if err != nil {
+			return fmt.Errorf("while obtaining plan defaults: %w", err)
+		}
+		if defaults.GardenerConfig != nil {
+			autoScalerMax = defaults.GardenerConfig.AutoScalerMax
+			if defaults.GardenerConfig.VolumeSizeGb != nil {
+				volumeSizeGb = *defaults.GardenerConfig.VolumeSizeGb
+			}
+		}
+	}
+	if pp.Parameters.AutoScalerMax != nil {
+		autoScalerMax = *pp.Parameters.AutoScalerMax
+	}
+	if pp.Parameters.VolumeSizeGb != nil {
+		volumeSizeGb = *pp.Parameters.VolumeSizeGb
+	}
+	return b.quotaChecker.CheckProvisioning(pp.ErsContext.GlobalAccountID, pp.PlanID, autoScalerMax, volumeSizeGb)
+}
+
 func logParametersWithMaskedKubeconfig(parameters internal.ProvisioningParametersDTO, logger *logrus.Entry) {
 	parameters.Kubeconfig = "*****"
 	logger.Infof("Runtime parameters: %+v", parameters)
@@ -243,9 +299,47 @@ func (b *ProvisionEndpoint) validateAndExtract(details domain.ProvisionDetails,
 	if err != nil {
 		return ersContext, parameters, fmt.Errorf("while extracting input parameters: %w", err)
 	}
-	defaults, err := b.planDefaults(details.PlanID, provider, parameters.Provider)
+	if err := b.checkParameters(details.PlanID, provider, parameters); err != nil {
+		return ersContext, parameters, err
+	}
+	if err := b.checkSchema(details, provider, ctx); err != nil {
+		return ersContext, parameters, err
+	}
+	if err := b.checkEuAccess(ctx, details.PlanID, ersContext.GlobalAccountID, logger); err != nil {
+		return ersContext, parameters, err
+	}
+
+	if !b.kymaVerOnDemand {
+		logger.Infof("Kyma on demand functionality is disabled. Default Kyma version will be used instead %s", parameters.KymaVersion)
+		parameters.KymaVersion = ""
+		parameters.OverridesVersion = ""
+	}
+	parameters.LicenceType = b.determineLicenceType(details.PlanID)
+
+	found := b.builderFactory.IsPlanSupport(details.PlanID)
+	if !found {
+		return ersContext, parameters, fmt.Errorf("the plan ID not known, planID: %s", details.PlanID)
+	}
+
+	if IsOwnClusterPlan(details.PlanID) {
+		parameters.Kubeconfig, err = decodeKubeconfig(parameters.Kubeconfig)
+		if err != nil {
+			return ersContext, parameters, err
+		}
+	}
+
+	if err := b.checkTrial(details.PlanID, parameters, ersContext.GlobalAccountID, logger); err != nil {
+		return ersContext, parameters, err
+	}
+
+	return ersContext, parameters, nil
+}
+
+// checkParameters validates the autoscaler parameters against the plan defaults, the OIDC, the networking and the hyperscaler account parameters
+func (b *ProvisionEndpoint) checkParameters(planID string, provider internal.CloudProvider, parameters internal.ProvisioningParametersDTO) error {
+	defaults, err := b.planDefaults(planID, provider, parameters.Provider)
 	if err != nil {
-		return ersContext, parameters, fmt.Errorf("while obtaining plan defaults: %w", err)
+		return fmt.Errorf("while obtaining plan defaults: %w", err)
 	}
 	var autoscalerMin, autoscalerMax int
 	if defaults.GardenerConfig != nil {
@@ -253,81 +347,131 @@ func (b *ProvisionEndpoint) validateAndExtract(details domain.ProvisionDetails,
 		autoscalerMin, autoscalerMax = p.AutoScalerMin, p.AutoScalerMax
 	}
 	if err := parameters.AutoScalerParameters.Validate(autoscalerMin, autoscalerMax); err != nil {
-		return ersContext, parameters, apiresponses.NewFailureResponse(err, http.StatusUnprocessableEntity, err.Error())
+		return apiresponses.NewFailureResponse(err, http.StatusUnprocessableEntity, err.Error())
 	}
 	if parameters.OIDC.IsProvided() {
 		if err := parameters.OIDC.Validate(); err != nil {
-			return ersContext, parameters, apiresponses.NewFailureResponse(err, http.StatusUnprocessableEntity, err.Error())
+			return apiresponses.NewFailureResponse(err, http.StatusUnprocessableEntity, err.Error())
 		}
 	}
+	if parameters.Networking != nil {
+		if IsTrialPlan(planID) || b.planRegistry.IsFreemiumPlan(planID) || IsOwnClusterPlan(planID) {
+			err := fmt.Errorf("networking parameters are not supported in this plan")
+			return apiresponses.NewFailureResponse(err, http.StatusUnprocessableEntity, err.Error())
+		}
+		if err := parameters.Networking.Validate(); err != nil {
+			err = fmt.Errorf("while validating networking parameters: %w", err)
+			return apiresponses.NewFailureResponse(err, http.StatusUnprocessableEntity, err.Error())
+		}
+		// the pinned Provisioner gqlschema has no pods and services ranges yet, the Gardener defaults are used
+		if parameters.Networking.Pods != nil || parameters.Networking.Services != nil {
+			err := fmt.Errorf("the pods and services ranges are not supported yet, only the nodes range can be set")
+			return apiresponses.NewFailureResponse(err, http.StatusUnprocessableEntity, err.Error())
+		}
+	}
+	if parameters.HyperscalerAccount != nil {
+		if IsTrialPlan(planID) || b.planRegistry.IsFreemiumPlan(planID) || IsOwnClusterPlan(planID) || defaults.GardenerConfig == nil {
+			err := fmt.Errorf("hyperscaler account parameters are not supported in this plan")
+			return apiresponses.NewFailureResponse(err, http.StatusUnprocessableEntity, err.Error())
+		}
+		if err := hyperscaler.ValidateCustomerCredentials(hyperscaler.Type(defaults.GardenerConfig.Provider), parameters.HyperscalerAccount.Credentials); err != nil {
+			err = fmt.Errorf("while validating hyperscaler account parameters: %w", err)
+			return apiresponses.NewFailureResponse(err, http.StatusUnprocessableEntity, err.Error())
+		}
+	}
+	return nil
+}
 
-	planValidator, err := b.validator(&details, provider,
-		ctx)
+func (b *ProvisionEndpoint) checkSchema(details domain.ProvisionDetails, provider internal.CloudProvider, ctx context.Context) error {
+	planValidator, err := b.validator(&details, provider, ctx)
 	if err != nil {
-		return ersContext, parameters, fmt.Errorf("while creating plan validator: %w", err)
+		return fmt.Errorf("while creating plan validator: %w", err)
 	}
 	result, err := planValidator.ValidateString(string(details.RawParameters))
 	if err != nil {
-		return ersContext, parameters, fmt.Errorf("while executing JSON schema validator: %w", err)
+		return fmt.Errorf("while executing JSON schema validator: %w", err)
 	}
 	if !result.Valid {
-		return ersContext, parameters, fmt.Errorf("while validating input parameters: %w", result.Error)
+		return fmt.Errorf("while validating input parameters: %w", result.Error)
 	}
+	return nil
+}
 
-	// EU Access: reject requests for not whitelisted globalAccountIds
-	if isEuRestrictedAccess(ctx) {
-		logger.Infof("EU Access restricted instance creation")
-		if euaccess.IsNotWhitelisted(ersContext.GlobalAccountID, b.euAccessWhitelist) {
-			logger.Infof(b.euAccessRejectionMessage)
-			err = fmt.Errorf(b.euAccessRejectionMessage)
-			return ersContext, parameters, apiresponses.NewFailureResponse(err, http.StatusBadRequest, "provisioning")
-		}
+// checkEuAccess rejects requests for not whitelisted globalAccountIds in EU access restricted regions
+func (b *ProvisionEndpoint) checkEuAccess(ctx context.Context, planID, globalAccountID string, logger logrus.FieldLogger) error {
+	if !isEuRestrictedAccess(ctx) {
+		return nil
 	}
-
-	if !b.kymaVerOnDemand {
-		logger.Infof("Kyma on demand functionality is disabled. Default Kyma version will be used instead %s", parameters.KymaVersion)
-		parameters.KymaVersion = ""
-		parameters.OverridesVersion = ""
+	if definition, found := b.planRegistry.DefinitionByID(planID); found && !definition.Features.EUAccess {
+		err := fmt.Errorf("plan %s is not available in EU access restricted regions", definition.Name)
+		return apiresponses.NewFailureResponse(err, http.StatusBadRequest, "provisioning")
 	}
-	parameters.LicenceType = b.determineLicenceType(details.PlanID)
-
-	found := b.builderFactory.IsPlanSupport(details.PlanID)
-	if !found {
-		return ersContext, parameters, fmt.Errorf("the plan ID not known, planID: %s", details.PlanID)
+	logger.Infof("EU Access restricted instance creation")
+	if euaccess.IsNotWhitelisted(globalAccountID, b.euAccessWhitelist) {
+		logger.Infof(b.euAccessRejectionMessage)
+		err := fmt.Errorf(b.euAccessRejectionMessage)
+		return apiresponses.NewFailureResponse(err, http.StatusBadRequest, "provisioning")
 	}
+	return nil
+}
 
-	if IsOwnClusterPlan(details.PlanID) {
-		decodedKubeconfig, err := base64.StdEncoding.DecodeString(parameters.Kubeconfig)
-		if err != nil {
-			return ersContext, parameters, fmt.Errorf("while decoding kubeconfig: %w", err)
-		}
-		parameters.Kubeconfig = string(decodedKubeconfig)
-		err = validateKubeconfig(parameters.Kubeconfig)
-		if err != nil {
-			return ersContext, parameters, fmt.Errorf("while validating kubeconfig: %w", err)
-		}
+// checkTrial validates the trial region and allows only one trial per global account if configured
+func (b *ProvisionEndpoint) checkTrial(planID string, parameters internal.ProvisioningParametersDTO, globalAccountID string, logger logrus.FieldLogger) error {
+	if !IsTrialPlan(planID) {
+		return nil
 	}
-
-	if IsTrialPlan(details.PlanID) && parameters.Region != nil && *parameters.Region != "" {
+	if parameters.Region != nil && *parameters.Region != "" {
 		_, valid := validRegionsForTrial[TrialCloudRegion(*parameters.Region)]
 		if !valid {
-			return ersContext, parameters, fmt.Errorf("invalid region specified in request for trial")
+			return fmt.Errorf("invalid region specified in request for trial")
 		}
 	}
 
-	if IsTrialPlan(details.PlanID) && b.config.OnlySingleTrialPerGA {
-		count, err := b.instanceStorage.GetNumberOfInstancesForGlobalAccountID(ersContext.GlobalAccountID)
+	if b.config.OnlySingleTrialPerGA {
+		count, err := b.instanceStorage.GetNumberOfInstancesForGlobalAccountID(globalAccountID)
 		if err != nil {
-			return ersContext, parameters, fmt.Errorf("while checking if a trial Kyma instance exists for given global account: %w", err)
+			return fmt.Errorf("while checking if a trial Kyma instance exists for given global account: %w", err)
 		}
 
 		if count > 0 {
 			logger.Info("Provisioning Trial SKR rejected, such instance was already created for this Global Account")
-			return ersContext, parameters, fmt.Errorf("trial Kyma was created for the global account, but there is only one allowed")
+			return fmt.Errorf("trial Kyma was created for the global account, but there is only one allowed")
 		}
 	}
+	return nil
+}
 
-	return ersContext, parameters, nil
+// checkSingleInstance allows only one instance of the plan per global account if the plan lifecycle requires it
+func (b *ProvisionEndpoint) checkSingleInstance(planID, globalAccountID string, logger logrus.FieldLogger) error {
+	if !b.planRegistry.LifecycleByID(planID).SingleInstancePerGlobalAccount {
+		return nil
+	}
+	_, _, count, err := b.instanceStorage.List(dbmodel.InstanceFilter{
+		GlobalAccountIDs: []string{globalAccountID},
+		PlanIDs:          []string{planID},
+		Page:             1,
+		PageSize:         1,
+	})
+	if err != nil {
+		return fmt.Errorf("while checking if a %s Kyma instance exists for given global account: %w", b.planRegistry.PlanNames()[planID], err)
+	}
+	if count > 0 {
+		logger.Infof("Provisioning %s SKR rejected, such instance was already created for this Global Account", b.planRegistry.PlanNames()[planID])
+		return fmt.Errorf("%s Kyma was created for the global account, but there is only one allowed", b.planRegistry.PlanNames()[planID])
+	}
+	return nil
+}
+
+func decodeKubeconfig(encoded string) (string, error) {
+	decodedKubeconfig, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", fmt.Errorf("while decoding kubeconfig: %w", err)
+	}
+	err = validateKubeconfig(string(decodedKubeconfig))
+	if err != nil {
+		return "", fmt.Errorf("while validating kubeconfig: %w", err)
+	}
+	return string(decodedKubeconfig), nil
 }
 
 func isEuRestrictedAccess(ctx context.Context) bool {
@@ -408,13 +552,16 @@ func (b *ProvisionEndpoint) determineLicenceType(planId string) *string {
 	if planId == AzureLitePlanID || IsTrialPlan(planId) {
 		return ptr.String(internal.LicenceTypeLite)
 	}
+	if definition, found := b.planRegistry.DefinitionByID(planId); found && definition.Features.FreeTier {
+		return ptr.String(internal.LicenceTypeLite)
+	}
 
 	return nil
 }
 
 func (b *ProvisionEndpoint) validator(details *domain.ProvisionDetails, provider internal.CloudProvider, ctx context.Context) (JSONSchemaValidator, error) {
 	platformRegion, _ := middleware.RegionFromContext(ctx)
-	plans := Plans(b.plansConfig, provider, b.config.IncludeAdditionalParamsInSchema, euaccess.IsEURestrictedAccess(platformRegion))
+	plans := Plans(b.planCatalog, b.planRegistry, b.plansConfig, provider, b.config.IncludeAdditionalParamsInSchema, euaccess.IsEURestrictedAccess(platformRegion))
 	plan := plans[details.PlanID]
 	schema := string(Marshal(plan.Schemas.Instance.Create.Parameters))
 
@@ -20,6 +20,7 @@ import (
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/middleware"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/ptr"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
 	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
 	"github.com/pivotal-cf/brokerapi/v8/domain"
 	"github.com/sirupsen/logrus"
@@ -81,6 +82,9 @@ func TestProvision_Provision(t *testing.T) {
 			broker.PlansConfig{},
 			false,
 			planDefaults,
+			nil,
+			nil,
+			nil,
 			euaccess.WhitelistSet{},
 			"request rejected, your globalAccountId is not whitelisted",
 			logrus.StandardLogger(),
@@ -154,6 +158,9 @@ func TestProvision_Provision(t *testing.T) {
 			broker.PlansConfig{},
 			false,
 			planDefaults,
+			nil,
+			nil,
+			nil,
 			euaccess.WhitelistSet{},
 			"request rejected, your globalAccountId is not whitelisted",
 			logrus.StandardLogger(),
@@ -231,6 +238,9 @@ func TestProvision_Provision(t *testing.T) {
 			broker.PlansConfig{},
 			false,
 			planDefaults,
+			nil,
+			nil,
+			nil,
 			euaccess.WhitelistSet{},
 			"request rejected, your globalAccountId is not whitelisted",
 			logrus.StandardLogger(),
@@ -280,6 +290,9 @@ func TestProvision_Provision(t *testing.T) {
 			broker.PlansConfig{},
 			false,
 			planDefaults,
+			nil,
+			nil,
+			nil,
 			euaccess.WhitelistSet{},
 			"request rejected, your globalAccountId is not whitelisted",
 			logrus.StandardLogger(),
@@ -353,6 +366,9 @@ func TestProvision_Provision(t *testing.T) {
 			broker.PlansConfig{},
 			false,
 			planDefaults,
+			nil,
+			nil,
+			nil,
 			euaccess.WhitelistSet{},
 			"request rejected, your globalAccountId is not whitelisted",
 			logrus.StandardLogger(),
@@ -428,6 +444,9 @@ func TestProvision_Provision(t *testing.T) {
 			broker.PlansConfig{},
 			false,
 			planDefaults,
+			nil,
+			nil,
+			nil,
 			euaccess.WhitelistSet{},
 			"request rejected, your globalAccountId is not whitelisted",
 			logrus.StandardLogger(),
@@ -477,6 +496,9 @@ func TestProvision_Provision(t *testing.T) {
 			broker.PlansConfig{},
 			false,
 			planDefaults,
+			nil,
+			nil,
+			nil,
 			euaccess.WhitelistSet{},
 			"request rejected, your globalAccountId is not whitelisted",
 			logrus.StandardLogger(),
@@ -527,6 +549,9 @@ func TestProvision_Provision(t *testing.T) {
 			broker.PlansConfig{},
 			false,
 			planDefaults,
+			nil,
+			nil,
+			nil,
 			euaccess.WhitelistSet{},
 			"request rejected, your globalAccountId is not whitelisted",
 			logrus.StandardLogger(),
@@ -590,6 +615,9 @@ func TestProvision_Provision(t *testing.T) {
 			broker.PlansConfig{},
 			false,
 			planDefaults,
+			nil,
+			nil,
+			nil,
 			euaccess.WhitelistSet{},
 			"request rejected, your globalAccountId is not whitelisted",
 			logrus.StandardLogger(),
@@ -653,6 +681,9 @@ func TestProvision_Provision(t *testing.T) {
 			broker.PlansConfig{},
 			false,
 			planDefaults,
+			nil,
+			nil,
+			nil,
 			euaccess.WhitelistSet{},
 			"request rejected, your globalAccountId is not whitelisted",
 			logrus.StandardLogger(),
@@ -697,6 +728,9 @@ func TestProvision_Provision(t *testing.T) {
 			broker.PlansConfig{},
 			false,
 			planDefaults,
+			nil,
+			nil,
+			nil,
 			euaccess.WhitelistSet{},
 			"request rejected, your globalAccountId is not whitelisted",
 			logrus.StandardLogger(),
@@ -739,6 +773,9 @@ func TestProvision_Provision(t *testing.T) {
 			broker.PlansConfig{},
 			true,
 			planDefaults,
+			nil,
+			nil,
+			nil,
 			euaccess.WhitelistSet{},
 			"request rejected, your globalAccountId is not whitelisted",
 			logrus.StandardLogger(),
@@ -782,6 +819,9 @@ func TestProvision_Provision(t *testing.T) {
 			broker.PlansConfig{},
 			true,
 			planDefaults,
+			nil,
+			nil,
+			nil,
 			euaccess.WhitelistSet{},
 			"request rejected, your globalAccountId is not whitelisted",
 			logrus.StandardLogger(),
@@ -823,6 +863,9 @@ func TestProvision_Provision(t *testing.T) {
 			broker.PlansConfig{},
 			false,
 			planDefaults,
+			nil,
+			nil,
+			nil,
 			euaccess.WhitelistSet{},
 			"request rejected, your globalAccountId is not whitelisted",
 			logrus.StandardLogger(),
@@ -871,6 +914,9 @@ func TestProvision_Provision(t *testing.T) {
 			broker.PlansConfig{},
 			false,
 			planDefaults,
+			nil,
+			nil,
+			nil,
 			euaccess.WhitelistSet{},
 			"request rejected, your globalAccountId is not whitelisted",
 			logrus.StandardLogger(),
@@ -916,6 +962,9 @@ func TestProvision_Provision(t *testing.T) {
 			broker.PlansConfig{},
 			false,
 			planDefaults,
+			nil,
+			nil,
+			nil,
 			euaccess.WhitelistSet{},
 			"request rejected, your globalAccountId is not whitelisted",
 			logrus.StandardLogger(),
@@ -967,6 +1016,9 @@ func TestProvision_Provision(t *testing.T) {
 			broker.PlansConfig{},
 			false,
 			planDefaults,
+			nil,
+			nil,
+			nil,
 			euaccess.WhitelistSet{},
 			"request rejected, your globalAccountId is not whitelisted",
 			logrus.StandardLogger(),
@@ -1024,6 +1076,9 @@ func TestProvision_Provision(t *testing.T) {
 			broker.PlansConfig{},
 			false,
 			planDefaults,
+			nil,
+			nil,
+			nil,
 			euaccess.WhitelistSet{},
 			"request rejected, your globalAccountId is not whitelisted",
 			logrus.StandardLogger(),
@@ -1081,6 +1136,9 @@ func TestProvision_Provision(t *testing.T) {
 			broker.PlansConfig{},
 			false,
 			planDefaults,
+			nil,
+			nil,
+			nil,
 			euaccess.WhitelistSet{},
 			"request rejected, your globalAccountId is not whitelisted",
 			logrus.StandardLogger(),
@@ -1138,6 +1196,9 @@ func TestProvision_Provision(t *testing.T) {
 			broker.PlansConfig{},
 			false,
 			planDefaults,
+			nil,
+			nil,
+			nil,
 			euaccess.WhitelistSet{whitelistedGlobalAccountID: struct{}{}},
 			"request rejected, your globalAccountId is not whitelisted",
 			logrus.StandardLogger(),
@@ -1188,6 +1249,9 @@ func TestProvision_Provision(t *testing.T) {
 			broker.PlansConfig{},
 			false,
 			planDefaults,
+			nil,
+			nil,
+			nil,
 			euaccess.WhitelistSet{},
 			"request rejected, your globalAccountId is not whitelisted",
 			logrus.StandardLogger(),
@@ -1292,6 +1356,9 @@ func TestRegionValidation(t *testing.T) {
 				broker.PlansConfig{},
 				false,
 				planDefaults,
+				nil,
+				nil,
+				nil,
 				euaccess.WhitelistSet{},
 				"request rejected, your globalAccountId is not whitelisted",
 				logrus.StandardLogger(),
@@ -1369,3 +1436,194 @@ func fixDNSProviders() gardener.DNSProvidersData {
 		},
 	}
 }
+
+func TestProvision_Quota(t *testing.T) {
+	// given
+	memoryStorage := storage.NewMemoryStorage()
+
+	factoryBuilder := &automock.PlanValidator{}
+	factoryBuilder.On("IsPlanSupport", planID).Return(true)
+
+	planDefaults := func(planID string, platformProvider internal.CloudProvider, provider *internal.CloudProvider) (*gqlschema.ClusterConfigInput, error) {
+		return &gqlschema.ClusterConfigInput{GardenerConfig: &gqlschema.GardenerConfigInput{AutoScalerMax: 20, VolumeSizeGb: ptr.Integer(50)}}, nil
+	}
+	checker := &quotaCheckerStub{err: apiresponses.NewFailureResponse(fmt.Errorf("quota exceeded"), http.StatusUnprocessableEntity, "quota")}
+
+	provisionEndpoint := broker.NewProvision(
+		broker.Config{EnablePlans: []string{"gcp", "azure"}, URL: brokerURL},
+		gardener.Config{Project: "test", ShootDomain: "example.com"},
+		memoryStorage.Operations(),
+		memoryStorage.Instances(),
+		&automock.Queue{},
+		factoryBuilder,
+		broker.PlansConfig{},
+		false,
+		planDefaults,
+		nil,
+		nil,
+		checker,
+		euaccess.WhitelistSet{},
+		"request rejected, your globalAccountId is not whitelisted",
+		logrus.StandardLogger(),
+		dashboardConfig,
+	)
+
+	// when
+	_, err := provisionEndpoint.Provision(fixRequestContext(t, "req-region"), instanceID, domain.ProvisionDetails{
+		ServiceID:     serviceID,
+		PlanID:        planID,
+		RawParameters: json.RawMessage(fmt.Sprintf(`{"name": "%s", "autoScalerMax": 30}`, clusterName)),
+		RawContext:    json.RawMessage(fmt.Sprintf(`{"globalaccount_id": "%s", "subaccount_id": "%s", "user_id": "%s"}`, globalAccountID, subAccountID, userID)),
+	}, true)
+
+	// then
+	require.Error(t, err)
+	apierr, ok := err.(*apiresponses.FailureResponse)
+	require.True(t, ok)
+	assert.Equal(t, http.StatusUnprocessableEntity, apierr.ValidatedStatusCode(nil))
+	assert.Equal(t, []int{30, 50}, checker.requested)
+	assert.Equal(t, globalAccountID, checker.globalAccountID)
+	assert.Equal(t, []string{globalAccountID}, checker.locked)
+
+	_, err = memoryStorage.Instances().GetByID(instanceID)
+	assert.True(t, dberr.IsNotFound(err))
+}
+
+func TestProvision_SingleInstancePerGlobalAccount(t *testing.T) {
+	// given
+	planRegistry, err := broker.NewPlanRegistry(broker.PlanDefinitions{
+		Lifecycles: map[string]broker.PlanLifecycle{broker.FreemiumPlanName: {SingleInstancePerGlobalAccount: true}},
+	})
+	require.NoError(t, err)
+	memoryStorage := storage.NewMemoryStorage()
+	err = memoryStorage.Instances().Insert(internal.Instance{
+		InstanceID:      instanceID,
+		GlobalAccountID: globalAccountID,
+		ServiceID:       serviceID,
+		ServicePlanID:   broker.FreemiumPlanID,
+	})
+	require.NoError(t, err)
+
+	factoryBuilder := &automock.PlanValidator{}
+	factoryBuilder.On("IsPlanSupport", broker.FreemiumPlanID).Return(true)
+
+	planDefaults := func(planID string, platformProvider internal.CloudProvider, provider *internal.CloudProvider) (*gqlschema.ClusterConfigInput, error) {
+		return &gqlschema.ClusterConfigInput{}, nil
+	}
+	provisionEndpoint := broker.NewProvision(
+		broker.Config{EnablePlans: []string{"gcp", "azure", broker.FreemiumPlanName}, URL: brokerURL},
+		gardener.Config{Project: "test", ShootDomain: "example.com"},
+		memoryStorage.Operations(),
+		memoryStorage.Instances(),
+		&automock.Queue{},
+		factoryBuilder,
+		broker.PlansConfig{},
+		false,
+		planDefaults,
+		nil,
+		planRegistry,
+		nil,
+		euaccess.WhitelistSet{},
+		"request rejected, your globalAccountId is not whitelisted",
+		logrus.StandardLogger(),
+		dashboardConfig,
+	)
+
+	// when
+	_, err = provisionEndpoint.Provision(fixRequestContext(t, "req-region"), "new-instance-id", domain.ProvisionDetails{
+		ServiceID:     serviceID,
+		PlanID:        broker.FreemiumPlanID,
+		RawParameters: json.RawMessage(fmt.Sprintf(`{"name": "%s"}`, clusterName)),
+		RawContext:    json.RawMessage(fmt.Sprintf(`{"globalaccount_id": "%s", "subaccount_id": "%s", "user_id": "%s"}`, globalAccountID, subAccountID, userID)),
+	}, true)
+
+	// then
+	assert.EqualError(t, err, "free Kyma was created for the global account, but there is only one allowed")
+	_, err = memoryStorage.Instances().GetByID("new-instance-id")
+	assert.True(t, dberr.IsNotFound(err))
+}
+
+type quotaCheckerStub struct {
+	err             error
+	globalAccountID string
+	requested       []int
+	locked          []string
+}
+
+func (s *quotaCheckerStub) CheckProvisioning(globalAccountID, planID string, autoScalerMax, volumeSizeGb int) error {
+	s.globalAccountID = globalAccountID
+	s.requested = []int{autoScalerMax, volumeSizeGb}
+	return s.err
+}
+
+func (s *quotaCheckerStub) CheckUpdate(instance internal.Instance, parameters internal.ProvisioningParametersDTO) error {
+	return s.err
+}
+
+func (s *quotaCheckerStub) Lock(globalAccountID string) (func(), error) {
+	s.locked = append(s.locked, globalAccountID)
+	return func() {}, nil
+}
+
+func TestProvision_Networking(t *testing.T) {
+	// given
+	provisionEndpoint, memoryStorage := fixValidationProvisionEndpoint(nil)
+
+	// when
+	_, err := provisionEndpoint.Provision(fixRequestContext(t, "req-region"), instanceID, domain.ProvisionDetails{
+		ServiceID:     serviceID,
+		PlanID:        planID,
+		RawParameters: json.RawMessage(fmt.Sprintf(`{"name": "%s", "networking": {"nodes": "10.250.0.0/16", "pods": "10.250.0.0/12"}}`, clusterName)),
+		RawContext:    json.RawMessage(fmt.Sprintf(`{"globalaccount_id": "%s", "subaccount_id": "%s", "user_id": "%s"}`, globalAccountID, subAccountID, userID)),
+	}, true)
+
+	// then
+	require.Error(t, err)
+	apierr, ok := err.(*apiresponses.FailureResponse)
+	require.True(t, ok)
+	assert.Equal(t, http.StatusBadRequest, apierr.ValidatedStatusCode(nil))
+	assert.Contains(t, err.Error(), "pods: 10.250.0.0/12 is not a network address, did you mean 10.240.0.0/12")
+
+	_, err = memoryStorage.Instances().GetByID(instanceID)
+	assert.True(t, dberr.IsNotFound(err))
+}
+
+func TestProvision_NetworkingPodsAndServicesNotSupported(t *testing.T) {
+	// given
+	provisionEndpoint, memoryStorage := fixValidationProvisionEndpoint(nil)
+
+	// when
+	_, err := provisionEndpoint.Provision(fixRequestContext(t, "req-region"), instanceID, domain.ProvisionDetails{
+		ServiceID:     serviceID,
+		PlanID:        planID,
+		RawParameters: json.RawMessage(fmt.Sprintf(`{"name": "%s", "networking": {"nodes": "10.250.0.0/16", "services": "10.104.0.0/13"}}`, clusterName)),
+		RawContext:    json.RawMessage(fmt.Sprintf(`{"globalaccount_id": "%s", "subaccount_id": "%s", "user_id": "%s"}`, globalAccountID, subAccountID, userID)),
+	}, true)
+
+	// then
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "the pods and services ranges are not supported yet")
+
+	_, err = memoryStorage.Instances().GetByID(instanceID)
+	assert.True(t, dberr.IsNotFound(err))
+}
+
+func TestProvision_HyperscalerAccount(t *testing.T) {
+	// given
+	provisionEndpoint, memoryStorage := fixValidationProvisionEndpoint(nil)
+
+	// when
+	_, err := provisionEndpoint.Provision(fixRequestContext(t, "req-region"), instanceID, domain.ProvisionDetails{
+		ServiceID:     serviceID,
+		PlanID:        planID,
+		RawParameters: json.RawMessage(fmt.Sprintf(`{"name": "%s", "hyperscalerAccount": {"credentials": {"clientID": "id", "clientSecret": "secret"}}}`, clusterName)),
+		RawContext:    json.RawMessage(fmt.Sprintf(`{"globalaccount_id": "%s", "subaccount_id": "%s", "user_id": "%s"}`, globalAccountID, subAccountID, userID)),
+	}, true)
+
+	// then
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "credentials for hyperscaler azure must contain: subscriptionID, tenantID")
+
+	_, err = memoryStorage.Instances().GetByID(instanceID)
+	assert.True(t, dberr.IsNotFound(err))
+}
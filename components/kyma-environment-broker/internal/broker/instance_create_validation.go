@@ -0,0 +1,129 @@
+package broker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/httputil"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/middleware"
+	"github.com/pivotal-cf/brokerapi/v8/domain"
+	"github.com/sirupsen/logrus"
+)
+
+// CredentialsValidator checks that the hyperscaler credentials for a new instance can be resolved, without assigning them
+type CredentialsValidator interface {
+	ValidateCredentials(pp internal.ProvisioningParameters) error
+}
+
+// Violation describes a failed check of a provisioning request
+type Violation struct {
+	Check   string `json:"check"`
+	Message string `json:"message"`
+}
+
+type ProvisionValidationResponse struct {
+	Valid      bool        `json:"valid"`
+	Violations []Violation `json:"violations,omitempty"`
+}
+
+// ProvisionValidationHandler runs the checks of the provisioning request without creating the instance
+type ProvisionValidationHandler struct {
+	provisionEndpoint *ProvisionEndpoint
+	credentials       CredentialsValidator
+	log               logrus.FieldLogger
+}
+
+func NewProvisionValidationHandler(provisionEndpoint *ProvisionEndpoint, credentials CredentialsValidator, log logrus.FieldLogger) *ProvisionValidationHandler {
+	return &ProvisionValidationHandler{
+		provisionEndpoint: provisionEndpoint,
+		credentials:       credentials,
+		log:               log.WithField("service", "ProvisionValidationHandler"),
+	}
+}
+
+func (h *ProvisionValidationHandler) AttachRoutes(router *mux.Router) {
+	router.HandleFunc("/provision/validate", h.validate).Methods(http.MethodPost)
+}
+
+// validate accepts the body of the OSB provisioning request and returns all violations in one response
+//
+//	POST /provision/validate
+func (h *ProvisionValidationHandler) validate(w http.ResponseWriter, r *http.Request) {
+	var details domain.ProvisionDetails
+	if err := json.NewDecoder(r.Body).Decode(&details); err != nil {
+		httputil.WriteErrorResponse(w, http.StatusBadRequest, fmt.Errorf("while decoding request body: %w", err))
+		return
+	}
+
+	violations := h.provisionEndpoint.ValidateProvisioning(r.Context(), details, h.credentials)
+	if len(violations) > 0 {
+		h.log.Infof("provisioning request for plan %s has %d violations", details.PlanID, len(violations))
+		httputil.WriteResponse(w, http.StatusUnprocessableEntity, ProvisionValidationResponse{Valid: false, Violations: violations})
+		return
+	}
+	httputil.WriteResponse(w, http.StatusOK, ProvisionValidationResponse{Valid: true})
+}
+
+// ValidateProvisioning runs the synchronous checks of Provision and the credentials lookup without persisting anything,
+// unlike Provision it does not stop at the first failed check
+func (b *ProvisionEndpoint) ValidateProvisioning(ctx context.Context, details domain.ProvisionDetails, credentials CredentialsValidator) []Violation {
+	logger := b.log.WithFields(logrus.Fields{"planID": details.PlanID, "dryRun": true})
+	region, _ := middleware.RegionFromContext(ctx)
+	platformProvider, _ := middleware.ProviderFromContext(ctx)
+
+	var violations []Violation
+	add := func(check string, err error) {
+		if err != nil {
+			violations = append(violations, Violation{Check: check, Message: err.Error()})
+		}
+	}
+
+	if details.ServiceID != KymaServiceID {
+		add("service", fmt.Errorf("service_id not recognized"))
+	}
+	if _, exists := b.enabledPlanIDs[details.PlanID]; !exists || !b.builderFactory.IsPlanSupport(details.PlanID) {
+		// the remaining checks depend on the plan
+		add("plan", fmt.Errorf("plan ID %q is not recognized", details.PlanID))
+		return violations
+	}
+
+	ersContext, contextErr := b.extractERSContext(details)
+	add("context", contextErr)
+	parameters, err := b.extractInputParameters(details)
+	if err != nil {
+		add("parameters", err)
+		return violations
+	}
+
+	add("parameters", b.checkParameters(details.PlanID, platformProvider, parameters))
+	add("schema", b.checkSchema(details, platformProvider, ctx))
+	add("euAccess", b.checkEuAccess(ctx, details.PlanID, ersContext.GlobalAccountID, logger))
+	if IsOwnClusterPlan(details.PlanID) {
+		_, err := decodeKubeconfig(parameters.Kubeconfig)
+		add("kubeconfig", err)
+	}
+	if contextErr != nil {
+		// the global account is required by the remaining checks
+		return violations
+	}
+
+	add("trial", b.checkTrial(details.PlanID, parameters, ersContext.GlobalAccountID, logger))
+	provisioningParameters := internal.ProvisioningParameters{
+		PlanID:           details.PlanID,
+		ServiceID:        details.ServiceID,
+		ErsContext:       ersContext,
+		Parameters:       parameters,
+		PlatformRegion:   region,
+		PlatformProvider: platformProvider,
+	}
+	add("quota", b.checkQuota(provisioningParameters))
+	if credentials != nil {
+		add("credentials", credentials.ValidateCredentials(provisioningParameters))
+	}
+
+	return violations
+}
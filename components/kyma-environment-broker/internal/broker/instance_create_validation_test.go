@@ -0,0 +1,162 @@
+package broker_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/gardener"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker/automock"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/euaccess"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/middleware"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
+	"github.com/pivotal-cf/brokerapi/v8/domain"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProvision_ValidateProvisioning(t *testing.T) {
+	t.Run("should collect all violations", func(t *testing.T) {
+		// given
+		provisionEndpoint, memoryStorage := fixValidationProvisionEndpoint(&quotaCheckerStub{err: fmt.Errorf("quota exceeded")})
+		credentials := &credentialsValidatorStub{err: fmt.Errorf("no credentials available")}
+
+		// when
+		violations := provisionEndpoint.ValidateProvisioning(fixRequestContext(t, "req-region"), domain.ProvisionDetails{
+			ServiceID:     serviceID,
+			PlanID:        planID,
+			RawParameters: json.RawMessage(`{"name": "", "oidc": {"clientID": "client-id"}}`),
+			RawContext:    json.RawMessage(fmt.Sprintf(`{"globalaccount_id": "%s", "subaccount_id": "%s", "user_id": "%s"}`, globalAccountID, subAccountID, userID)),
+		}, credentials)
+
+		// then
+		assert.Equal(t, []string{"parameters", "schema", "quota", "credentials"}, violationChecks(violations))
+		assert.Equal(t, globalAccountID, credentials.pp.ErsContext.GlobalAccountID)
+		assert.Equal(t, "req-region", credentials.pp.PlatformRegion)
+
+		_, err := memoryStorage.Instances().GetByID(instanceID)
+		assert.Error(t, err)
+	})
+
+	t.Run("should stop at unknown plan", func(t *testing.T) {
+		// given
+		provisionEndpoint, _ := fixValidationProvisionEndpoint(nil)
+
+		// when
+		violations := provisionEndpoint.ValidateProvisioning(fixRequestContext(t, "req-region"), domain.ProvisionDetails{
+			ServiceID: "unknown",
+			PlanID:    "unknown",
+		}, nil)
+
+		// then
+		assert.Equal(t, []string{"service", "plan"}, violationChecks(violations))
+	})
+}
+
+func TestProvisionValidationHandler(t *testing.T) {
+	// given
+	provisionEndpoint, _ := fixValidationProvisionEndpoint(nil)
+	router := mux.NewRouter()
+	route := router.PathPrefix("/oauth/{region}").Subrouter()
+	route.Use(middleware.AddRegionToContext("cf-eu10"))
+	route.Use(middleware.AddProviderToContext())
+	broker.NewProvisionValidationHandler(provisionEndpoint, &credentialsValidatorStub{}, logrus.New()).AttachRoutes(route)
+
+	for tn, tc := range map[string]struct {
+		body           string
+		expectedStatus int
+		expectedValid  bool
+	}{
+		"valid request": {
+			body:           fmt.Sprintf(`{"service_id": "%s", "plan_id": "%s", "parameters": {"name": "%s"}, "context": {"globalaccount_id": "%s", "subaccount_id": "%s", "user_id": "%s"}}`, serviceID, planID, clusterName, globalAccountID, subAccountID, userID),
+			expectedStatus: http.StatusOK,
+			expectedValid:  true,
+		},
+		"invalid request": {
+			body:           fmt.Sprintf(`{"service_id": "%s", "plan_id": "%s", "parameters": {"name": "%s"}, "context": {}}`, serviceID, planID, clusterName),
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			// when
+			req, err := http.NewRequest(http.MethodPost, "/oauth/cf-eu10/provision/validate", bytes.NewBufferString(tc.body))
+			require.NoError(t, err)
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			// then
+			require.Equal(t, tc.expectedStatus, rr.Code)
+			var response broker.ProvisionValidationResponse
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+			assert.Equal(t, tc.expectedValid, response.Valid)
+			assert.Equal(t, tc.expectedValid, len(response.Violations) == 0)
+		})
+	}
+
+	t.Run("malformed request", func(t *testing.T) {
+		// when
+		req, err := http.NewRequest(http.MethodPost, "/oauth/cf-eu10/provision/validate", bytes.NewBufferString("{"))
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		// then
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func fixValidationProvisionEndpoint(checker broker.QuotaChecker) (*broker.ProvisionEndpoint, storage.BrokerStorage) {
+	memoryStorage := storage.NewMemoryStorage()
+
+	factoryBuilder := &automock.PlanValidator{}
+	factoryBuilder.On("IsPlanSupport", planID).Return(true)
+
+	planDefaults := func(planID string, platformProvider internal.CloudProvider, provider *internal.CloudProvider) (*gqlschema.ClusterConfigInput, error) {
+		return &gqlschema.ClusterConfigInput{GardenerConfig: &gqlschema.GardenerConfigInput{Provider: "azure", AutoScalerMin: 3, AutoScalerMax: 20}}, nil
+	}
+
+	return broker.NewProvision(
+		broker.Config{EnablePlans: []string{"gcp", "azure"}, URL: brokerURL},
+		gardener.Config{Project: "test", ShootDomain: "example.com"},
+		memoryStorage.Operations(),
+		memoryStorage.Instances(),
+		&automock.Queue{},
+		factoryBuilder,
+		broker.PlansConfig{},
+		false,
+		planDefaults,
+		nil,
+		nil,
+		checker,
+		euaccess.WhitelistSet{},
+		"request rejected, your globalAccountId is not whitelisted",
+		logrus.StandardLogger(),
+		dashboardConfig,
+	), memoryStorage
+}
+
+func violationChecks(violations []broker.Violation) []string {
+	checks := make([]string, 0, len(violations))
+	for _, v := range violations {
+		checks = append(checks, v.Check)
+	}
+	return checks
+}
+
+type credentialsValidatorStub struct {
+	err error
+	pp  internal.ProvisioningParameters
+}
+
+func (s *credentialsValidatorStub) ValidateCredentials(pp internal.ProvisioningParameters) error {
+	s.pp = pp
+	return s.err
+}
@@ -94,5 +94,6 @@ func (b *GetInstanceEndpoint) GetInstance(_ context.Context, instanceID string,
 func (b *GetInstanceEndpoint) prepareParametersToReturn(parameters internal.ProvisioningParameters) internal.ProvisioningParameters {
 	parameters.Parameters.Kubeconfig = ""
 	parameters.ErsContext.SMOperatorCredentials = nil
+	parameters.Parameters.HyperscalerAccount = nil
 	return parameters
 }
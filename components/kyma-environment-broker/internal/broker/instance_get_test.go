@@ -61,6 +61,9 @@ func TestGetEndpoint_GetProvisioningInstance(t *testing.T) {
 		broker.PlansConfig{},
 		false,
 		planDefaults,
+		nil,
+		nil,
+		nil,
 		euaccess.WhitelistSet{},
 		"request rejected, your globalAccountId is not whitelisted",
 		logrus.StandardLogger(),
@@ -258,3 +261,40 @@ func TestGetEndpoint_GetExpiredInstanceWithoutExpirationInfo(t *testing.T) {
 	assert.NotContains(t, response.Metadata.Labels, "Trial expiration details")
 	assert.NotContains(t, response.Metadata.Labels, "Trial documentation")
 }
+
+func TestGetEndpoint_DoNotReturnHyperscalerAccountCredentials(t *testing.T) {
+	// given
+	st := storage.NewMemoryStorage()
+
+	const (
+		instanceID  = "cluster-test"
+		operationID = "operationID"
+	)
+	op := fixture.FixProvisioningOperation(operationID, instanceID)
+
+	instance := fixture.FixInstance(instanceID)
+	instance.Parameters.Parameters.HyperscalerAccount = &internal.HyperscalerAccountDTO{
+		Credentials: map[string]string{"accessKeyID": "key-id", "secretAccessKey": "secret"},
+	}
+
+	err := st.Operations().InsertOperation(op)
+	require.NoError(t, err)
+
+	err = st.Instances().Insert(instance)
+	require.NoError(t, err)
+
+	svc := broker.NewGetInstance(broker.Config{}, st.Instances(), st.Operations(), logrus.New())
+
+	// when
+	response, err := svc.GetInstance(context.Background(), instanceID, domain.FetchInstanceDetails{})
+
+	// then
+	require.NoError(t, err)
+	parameters, ok := response.Parameters.(internal.ProvisioningParameters)
+	require.True(t, ok)
+	assert.Nil(t, parameters.Parameters.HyperscalerAccount)
+	marshalled, err := json.Marshal(response.Parameters)
+	require.NoError(t, err)
+	assert.NotContains(t, string(marshalled), "hyperscalerAccount")
+	assert.NotContains(t, string(marshalled), "secret")
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
 	"github.com/pivotal-cf/brokerapi/v8/domain"
@@ -44,7 +45,7 @@ func (b *LastOperationEndpoint) LastOperation(ctx context.Context, instanceID st
 				fmt.Sprintf("while getting last operation from storage"))
 		}
 		return domain.LastOperation{
-			State:       mapStateToOSBCompliantState(lastOp.State),
+			State:       mapStateToOSBCompliantState(*lastOp),
 			Description: lastOp.Description,
 		}, nil
 	}
@@ -67,15 +68,20 @@ func (b *LastOperationEndpoint) LastOperation(ctx context.Context, instanceID st
 	}
 
 	return domain.LastOperation{
-		State:       mapStateToOSBCompliantState(operation.State),
+		State:       mapStateToOSBCompliantState(*operation),
 		Description: operation.Description,
 	}, nil
 }
 
-func mapStateToOSBCompliantState(opState domain.LastOperationState) domain.LastOperationState {
+// mapStateToOSBCompliantState reports the operations canceled through the operations API as failed, the operation did not reach its goal,
+// the operations canceled together with their orchestration did not change the instance and are reported as succeeded
+func mapStateToOSBCompliantState(operation internal.Operation) domain.LastOperationState {
+	opState := operation.State
 	switch {
 	case opState == orchestration.Pending || opState == orchestration.Retrying:
 		return domain.InProgress
+	case opState == orchestration.Canceled && operation.CancellationRequested:
+		return domain.Failed
 	case opState == orchestration.Canceled || opState == orchestration.Canceling:
 		return domain.Succeeded
 	default:
@@ -178,6 +178,29 @@ func TestLastOperation_LastOperation(t *testing.T) {
 			Description: updateOp.Description,
 		}, response)
 	})
+	t.Run("Should convert the state of operation canceled through the API to failed", func(t *testing.T) {
+		// given
+		memoryStorage := storage.NewMemoryStorage()
+		provisioningOp := fixture.FixProvisioningOperation(operationID, instID)
+		provisioningOp.State = orchestration.Canceled
+		provisioningOp.CancellationRequested = true
+		provisioningOp.Description = "Operation canceled"
+		err := memoryStorage.Operations().InsertOperation(provisioningOp)
+		assert.NoError(t, err)
+
+		lastOperationEndpoint := broker.NewLastOperation(memoryStorage.Operations(), logrus.StandardLogger())
+
+		// when
+		response, err := lastOperationEndpoint.LastOperation(context.TODO(), instID,
+			domain.PollDetails{OperationData: operationID})
+		assert.NoError(t, err)
+
+		// then
+		assert.Equal(t, domain.LastOperation{
+			State:       domain.Failed,
+			Description: "Operation canceled",
+		}, response)
+	})
 }
 
 func fixOperation() internal.Operation {
@@ -41,6 +41,8 @@ type UpdateEndpoint struct {
 	updatingQueue Queue
 
 	planDefaults PlanDefaults
+	planRegistry *PlanRegistry
+	quotaChecker QuotaChecker
 
 	dashboardConfig dashboard.Config
 }
@@ -54,6 +56,8 @@ func NewUpdate(cfg Config,
 	subAccountMovementEnabled bool,
 	queue Queue,
 	planDefaults PlanDefaults,
+	planRegistry *PlanRegistry,
+	quotaChecker QuotaChecker,
 	log logrus.FieldLogger,
 	dashboardConfig dashboard.Config,
 ) *UpdateEndpoint {
@@ -68,6 +72,8 @@ func NewUpdate(cfg Config,
 		subAccountMovementEnabled: subAccountMovementEnabled,
 		updatingQueue:             queue,
 		planDefaults:              planDefaults,
+		planRegistry:              planRegistry,
+		quotaChecker:              quotaChecker,
 		dashboardConfig:           dashboardConfig,
 	}
 }
@@ -89,7 +95,7 @@ func (b *UpdateEndpoint) Update(_ context.Context, instanceID string, details do
 		logger.Errorf("unable to get instance: %s", err.Error())
 		return domain.UpdateServiceSpec{}, fmt.Errorf("unable to get instance")
 	}
-	logger.Infof("Plan ID/Name: %s/%s", instance.ServicePlanID, PlanNamesMapping[instance.ServicePlanID])
+	logger.Infof("Plan ID/Name: %s/%s", instance.ServicePlanID, b.planRegistry.PlanNames()[instance.ServicePlanID])
 
 	var ersContext internal.ERSContext
 	err = json.Unmarshal(details.RawContext, &ersContext)
@@ -197,6 +203,12 @@ func (b *UpdateEndpoint) processUpdateParameters(instance *internal.Instance, de
 			return domain.UpdateServiceSpec{}, apiresponses.NewFailureResponse(err, http.StatusUnprocessableEntity, err.Error())
 		}
 	}
+	if definition, found := b.planRegistry.DefinitionByID(instance.ServicePlanID); found {
+		if err := definition.ValidateUpdate(params); err != nil {
+			logger.Errorf("invalid update parameters: %s", err.Error())
+			return domain.UpdateServiceSpec{}, apiresponses.NewFailureResponse(err, http.StatusUnprocessableEntity, err.Error())
+		}
+	}
 
 	operationID := uuid.New().String()
 	logger = logger.WithField("operationID", operationID)
@@ -221,6 +233,19 @@ func (b *UpdateEndpoint) processUpdateParameters(instance *internal.Instance, de
 		logger.Errorf("invalid autoscaler parameters: %s", err.Error())
 		return domain.UpdateServiceSpec{}, apiresponses.NewFailureResponse(err, http.StatusUnprocessableEntity, err.Error())
 	}
+	if b.quotaChecker != nil {
+		// the instance is updated under the lock, so concurrent requests of the global account see the new parameters
+		unlock, err := b.quotaChecker.Lock(instance.GlobalAccountID)
+		if err != nil {
+			logger.Errorf("unable to lock the quota of the global account: %s", err)
+			return domain.UpdateServiceSpec{}, fmt.Errorf("unable to check the quota of the global account")
+		}
+		defer unlock()
+		if err := b.quotaChecker.CheckUpdate(*instance, operation.ProvisioningParameters.Parameters); err != nil {
+			logger.Infof("update rejected: %s", err)
+			return domain.UpdateServiceSpec{}, err
+		}
+	}
 	err = b.operationStorage.InsertOperation(operation)
 	if err != nil {
 		return domain.UpdateServiceSpec{}, err
@@ -365,7 +390,7 @@ func (b *UpdateEndpoint) processExpirationParam(instance *internal.Instance, det
 			return instance, err
 		}
 		if params.Expired {
-			if !IsTrialPlan(instance.ServicePlanID) {
+			if !b.planRegistry.IsExpirablePlan(instance.ServicePlanID) {
 				logger.Warn("Expiration of a non-trial instance is not supported")
 				return instance, apiresponses.NewFailureResponse(fmt.Errorf("expiration of a non-trial instance is not supported"), http.StatusBadRequest, "")
 			}
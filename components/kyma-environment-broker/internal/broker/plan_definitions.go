@@ -0,0 +1,283 @@
+package broker
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/pivotal-cf/brokerapi/v8/domain"
+	"gopkg.in/yaml.v2"
+)
+
+// update parameters which can be listed in PlanDefinition.AllowedUpdates
+const (
+	UpdateMachineType    = "machineType"
+	UpdateAutoScalerMin  = "autoScalerMin"
+	UpdateAutoScalerMax  = "autoScalerMax"
+	UpdateOIDC           = "oidc"
+	UpdateAdministrators = "administrators"
+)
+
+var allowedUpdateNames = map[string]struct{}{
+	UpdateMachineType:    {},
+	UpdateAutoScalerMin:  {},
+	UpdateAutoScalerMax:  {},
+	UpdateOIDC:           {},
+	UpdateAdministrators: {},
+}
+
+// PlanDefinitions is the content of the file with declarative plans
+type PlanDefinitions struct {
+	Plans []PlanDefinition `yaml:"plans"`
+	// Lifecycles sets the lifecycle rules by plan name, for the built-in and the defined plans
+	Lifecycles map[string]PlanLifecycle `yaml:"lifecycles"`
+}
+
+// PlanDefinition describes a plan offered in addition to the built-in ones, the catalog entry, the schemas
+// and the provisioning defaults are generated from it.
+// The built-in plans are not declarative, their schemas and defaults stay in plans.go and the provider inputs,
+// only their lifecycle rules can be configured with PlanDefinitions.Lifecycles.
+type PlanDefinition struct {
+	ID       string                 `yaml:"id"`
+	Name     string                 `yaml:"name"`
+	Provider internal.CloudProvider `yaml:"provider"`
+	Regions  []string               `yaml:"regions"`
+	// EUAccessRegions are offered on the EU access restricted platform regions, requires features.euAccess
+	EUAccessRegions []string                `yaml:"euAccessRegions"`
+	MachineTypes    []MachineTypeDefinition `yaml:"machineTypes"`
+	AutoScaler      AutoScalerBounds        `yaml:"autoScaler"`
+	VolumeSizeGb    int                     `yaml:"volumeSizeGb"`
+	Defaults        PlanDefinitionDefaults  `yaml:"defaults"`
+	// AllowedUpdates lists the parameters which can be changed by an update, nothing can be changed if it is empty
+	AllowedUpdates []string     `yaml:"allowedUpdates"`
+	Features       PlanFeatures `yaml:"features"`
+}
+
+type MachineTypeDefinition struct {
+	Name    string `yaml:"name"`
+	Display string `yaml:"display"`
+}
+
+type AutoScalerBounds struct {
+	Minimum int `yaml:"minimum"`
+	Maximum int `yaml:"maximum"`
+}
+
+type PlanDefinitionDefaults struct {
+	Region        string `yaml:"region"`
+	MachineType   string `yaml:"machineType"`
+	AutoScalerMin int    `yaml:"autoScalerMin"`
+	AutoScalerMax int    `yaml:"autoScalerMax"`
+}
+
+type PlanFeatures struct {
+	// TrialExpiry enables the expiration of the instances after the given period, like for the trial plan
+	TrialExpiry time.Duration `yaml:"trialExpiry"`
+	// FreeTier handles the instances like the freemium ones, e.g. the lite licence and the trial AVS configuration
+	FreeTier bool `yaml:"freeTier"`
+	// EUAccess offers the plan on the EU access restricted platform regions
+	EUAccess bool `yaml:"euAccess"`
+}
+
+func ReadPlanDefinitionsFromFile(filename string) (PlanDefinitions, error) {
+	definitions := PlanDefinitions{}
+	if filename == "" {
+		return definitions, nil
+	}
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return definitions, fmt.Errorf("while reading %s file with plan definitions: %w", filename, err)
+	}
+	if err := yaml.UnmarshalStrict(data, &definitions); err != nil {
+		return definitions, fmt.Errorf("while unmarshalling a file with plan definitions: %w", err)
+	}
+	return definitions, nil
+}
+
+func (d PlanDefinition) Validate() error {
+	switch {
+	case d.ID == "" || d.Name == "":
+		return fmt.Errorf("id and name are required")
+	case len(d.Regions) == 0:
+		return fmt.Errorf("at least one region is required")
+	case len(d.MachineTypes) == 0:
+		return fmt.Errorf("at least one machine type is required")
+	case d.Features.EUAccess && len(d.EUAccessRegions) == 0:
+		return fmt.Errorf("euAccessRegions are required when euAccess is enabled")
+	case d.VolumeSizeGb < 0:
+		return fmt.Errorf("volumeSizeGb must not be negative")
+	case d.Features.TrialExpiry < 0:
+		return fmt.Errorf("trialExpiry must not be negative")
+	}
+	switch d.Provider {
+	case internal.AWS, internal.Azure, internal.GCP, internal.Openstack:
+	default:
+		return fmt.Errorf("unsupported provider %q", d.Provider)
+	}
+
+	bounds := d.AutoScalerBounds()
+	if bounds.Minimum < 1 || bounds.Minimum > bounds.Maximum {
+		return fmt.Errorf("invalid autoScaler bounds %d-%d", bounds.Minimum, bounds.Maximum)
+	}
+	defaults := d.DefaultsOrFirst()
+	if defaults.AutoScalerMin < bounds.Minimum || defaults.AutoScalerMax > bounds.Maximum || defaults.AutoScalerMin > defaults.AutoScalerMax {
+		return fmt.Errorf("default autoscaler values %d-%d are out of the bounds %d-%d", defaults.AutoScalerMin, defaults.AutoScalerMax, bounds.Minimum, bounds.Maximum)
+	}
+	if !contains(d.Regions, defaults.Region) {
+		return fmt.Errorf("default region %s is not one of the regions", defaults.Region)
+	}
+	if _, found := d.MachineTypesDisplay()[defaults.MachineType]; !found {
+		return fmt.Errorf("default machine type %s is not one of the machine types", defaults.MachineType)
+	}
+	for _, update := range d.AllowedUpdates {
+		if _, found := allowedUpdateNames[update]; !found {
+			return fmt.Errorf("unsupported allowed update %q", update)
+		}
+	}
+	return nil
+}
+
+// AutoScalerBounds returns the autoscaler bounds, the maximum defaults to 80 and the minimum to 2 like for the built-in plans
+func (d PlanDefinition) AutoScalerBounds() AutoScalerBounds {
+	bounds := d.AutoScaler
+	if bounds.Minimum == 0 {
+		bounds.Minimum = 2
+	}
+	if bounds.Maximum == 0 {
+		bounds.Maximum = 80
+	}
+	return bounds
+}
+
+// DefaultsOrFirst returns the defaults, unset values are taken from the first region and machine type and from the autoscaler bounds
+func (d PlanDefinition) DefaultsOrFirst() PlanDefinitionDefaults {
+	defaults := d.Defaults
+	bounds := d.AutoScalerBounds()
+	if defaults.Region == "" && len(d.Regions) > 0 {
+		defaults.Region = d.Regions[0]
+	}
+	if defaults.MachineType == "" && len(d.MachineTypes) > 0 {
+		defaults.MachineType = d.MachineTypes[0].Name
+	}
+	if defaults.AutoScalerMin == 0 {
+		defaults.AutoScalerMin = bounds.Minimum
+	}
+	if defaults.AutoScalerMax == 0 {
+		defaults.AutoScalerMax = bounds.Maximum
+	}
+	return defaults
+}
+
+func (d PlanDefinition) MachineTypeNames() []string {
+	names := make([]string, 0, len(d.MachineTypes))
+	for _, machineType := range d.MachineTypes {
+		names = append(names, machineType.Name)
+	}
+	return names
+}
+
+func (d PlanDefinition) MachineTypesDisplay() map[string]string {
+	display := make(map[string]string, len(d.MachineTypes))
+	for _, machineType := range d.MachineTypes {
+		display[machineType.Name] = machineType.Display
+		if machineType.Display == "" {
+			display[machineType.Name] = machineType.Name
+		}
+	}
+	return display
+}
+
+func (d PlanDefinition) IsUpdateAllowed(name string) bool {
+	return contains(d.AllowedUpdates, name)
+}
+
+// ValidateUpdate returns an error listing the provided parameters which cannot be changed in the plan
+func (d PlanDefinition) ValidateUpdate(params internal.UpdatingParametersDTO) error {
+	provided := map[string]bool{
+		UpdateMachineType:    params.MachineType != nil && *params.MachineType != "",
+		UpdateAutoScalerMin:  params.AutoScalerMin != nil,
+		UpdateAutoScalerMax:  params.AutoScalerMax != nil,
+		UpdateOIDC:           params.OIDC.IsProvided(),
+		UpdateAdministrators: len(params.RuntimeAdministrators) != 0,
+	}
+	var denied []string
+	for name, isProvided := range provided {
+		if isProvided && !d.IsUpdateAllowed(name) {
+			denied = append(denied, name)
+		}
+	}
+	if len(denied) > 0 {
+		sort.Strings(denied)
+		return fmt.Errorf("the %s plan does not allow updating %v", d.Name, denied)
+	}
+	return nil
+}
+
+func planDefinitionSchema(d PlanDefinition, machineTypesDisplay map[string]string, machineTypes, regions []string, additionalParams, update bool) *map[string]interface{} {
+	properties := NewProvisioningProperties(machineTypesDisplay, machineTypes, regions, update)
+	bounds := d.AutoScalerBounds()
+	defaults := d.DefaultsOrFirst()
+	properties.AutoScalerMin.Minimum = bounds.Minimum
+	properties.AutoScalerMin.Maximum = bounds.Maximum
+	properties.AutoScalerMax.Minimum = bounds.Minimum
+	properties.AutoScalerMax.Maximum = bounds.Maximum
+	if !update {
+		properties.AutoScalerMin.Default = defaults.AutoScalerMin
+		properties.AutoScalerMax.Default = defaults.AutoScalerMax
+	}
+	if additionalParams {
+		properties.IncludeAdditional()
+	}
+
+	if update {
+		if !d.IsUpdateAllowed(UpdateMachineType) {
+			properties.MachineType = nil
+		}
+		if !d.IsUpdateAllowed(UpdateAutoScalerMin) {
+			properties.AutoScalerMin = nil
+		}
+		if !d.IsUpdateAllowed(UpdateAutoScalerMax) {
+			properties.AutoScalerMax = nil
+		}
+		if !d.IsUpdateAllowed(UpdateOIDC) {
+			properties.OIDC = nil
+		}
+		if !d.IsUpdateAllowed(UpdateAdministrators) {
+			properties.Administrators = nil
+		}
+		return createSchemaWith(properties.UpdateProperties, update)
+	}
+	return createSchemaWith(properties, update)
+}
+
+// definedPlans returns the service plans of the registered definitions which are offered for the given platform region,
+// the regions and machine types are taken from the catalog if it provides them like for the built-in plans
+func (r *PlanRegistry) definedPlans(catalog PlanCatalog, plans PlansConfig, includeAdditionalParamsInSchema bool, euAccessRestricted bool) map[string]domain.ServicePlan {
+	servicePlans := map[string]domain.ServicePlan{}
+	for id, definition := range r.definitionsByID() {
+		regions := definition.Regions
+		if euAccessRestricted {
+			if !definition.Features.EUAccess {
+				continue
+			}
+			regions = definition.EUAccessRegions
+		}
+		regions = catalogRegions(catalog, definition.Name, euAccessRestricted, regions)
+		machineTypes, machineTypesDisplay := catalogMachineTypes(catalog, definition.Name, definition.MachineTypeNames(), definition.MachineTypesDisplay())
+		servicePlans[id] = defaultServicePlan(id, definition.Name, plans,
+			planDefinitionSchema(definition, machineTypesDisplay, machineTypes, regions, includeAdditionalParamsInSchema, false),
+			planDefinitionSchema(definition, machineTypesDisplay, machineTypes, regions, includeAdditionalParamsInSchema, true))
+	}
+	return servicePlans
+}
+
+func contains(items []string, item string) bool {
+	for _, i := range items {
+		if i == item {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,202 @@
+package broker
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/ptr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const fixPlanDefinitions = `plans:
+- id: "d1f1c0de-0000-4000-8000-000000000001"
+  name: "aws_small"
+  provider: "AWS"
+  regions: ["eu-central-1", "us-east-1"]
+  euAccessRegions: ["eu-central-1"]
+  machineTypes:
+  - name: "m5.large"
+    display: "m5.large (2vCPU, 8GB RAM)"
+  - name: "m5.xlarge"
+  autoScaler:
+    minimum: 1
+    maximum: 5
+  volumeSizeGb: 30
+  defaults:
+    region: "us-east-1"
+    autoScalerMax: 3
+  allowedUpdates: ["autoScalerMin", "autoScalerMax"]
+  features:
+    trialExpiry: 720h
+    freeTier: true
+    euAccess: true
+lifecycles:
+  free:
+    suspension: true
+    singleInstancePerGlobalAccount: true
+    inactivityExpiry: 720h
+`
+
+func TestReadPlanDefinitionsFromFile(t *testing.T) {
+	// given
+	filename := filepath.Join(t.TempDir(), "plans.yaml")
+	require.NoError(t, os.WriteFile(filename, []byte(fixPlanDefinitions), 0600))
+
+	// when
+	definitions, err := ReadPlanDefinitionsFromFile(filename)
+
+	// then
+	require.NoError(t, err)
+	require.Len(t, definitions.Plans, 1)
+	definition := definitions.Plans[0]
+	assert.Equal(t, internal.AWS, definition.Provider)
+	assert.Equal(t, 720*time.Hour, definition.Features.TrialExpiry)
+	assert.Equal(t, PlanDefinitionDefaults{Region: "us-east-1", MachineType: "m5.large", AutoScalerMin: 1, AutoScalerMax: 3}, definition.DefaultsOrFirst())
+	assert.Equal(t, map[string]string{"m5.large": "m5.large (2vCPU, 8GB RAM)", "m5.xlarge": "m5.xlarge"}, definition.MachineTypesDisplay())
+	assert.NoError(t, definition.Validate())
+	assert.Equal(t, map[string]PlanLifecycle{"free": {Suspension: true, SingleInstancePerGlobalAccount: true, InactivityExpiry: 720 * time.Hour}}, definitions.Lifecycles)
+}
+
+func TestPlanDefinition_Validate(t *testing.T) {
+	for name, tc := range map[string]struct {
+		modify func(d *PlanDefinition)
+		err    string
+	}{
+		"unknown provider":          {modify: func(d *PlanDefinition) { d.Provider = "alicloud" }, err: "unsupported provider"},
+		"no regions":                {modify: func(d *PlanDefinition) { d.Regions = nil }, err: "at least one region"},
+		"default region":            {modify: func(d *PlanDefinition) { d.Defaults.Region = "ap-south-1" }, err: "default region"},
+		"default machine type":      {modify: func(d *PlanDefinition) { d.Defaults.MachineType = "m5.metal" }, err: "default machine type"},
+		"autoscaler out of bounds":  {modify: func(d *PlanDefinition) { d.Defaults.AutoScalerMax = 10 }, err: "out of the bounds"},
+		"eu access without regions": {modify: func(d *PlanDefinition) { d.EUAccessRegions = nil }, err: "euAccessRegions"},
+		"unknown update":            {modify: func(d *PlanDefinition) { d.AllowedUpdates = []string{"region"} }, err: "unsupported allowed update"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// given
+			definition := fixPlanDefinition()
+			tc.modify(&definition)
+
+			// when
+			err := definition.Validate()
+
+			// then
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
+
+func TestNewPlanRegistry(t *testing.T) {
+	t.Run("should reject built-in plan name", func(t *testing.T) {
+		// given
+		definition := fixPlanDefinition()
+		definition.Name = AWSPlanName
+
+		// when
+		_, err := NewPlanRegistry(PlanDefinitions{Plans: []PlanDefinition{definition}})
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "already used")
+	})
+
+	t.Run("should offer defined plan", func(t *testing.T) {
+		// given
+		definition := fixPlanDefinition()
+		registry := newPlanRegistryForTest(t, definition)
+
+		// when
+		plans := Plans(nil, registry, PlansConfig{}, internal.AWS, false, false)
+		euPlans := Plans(nil, registry, PlansConfig{}, internal.AWS, false, true)
+
+		// then
+		assert.Equal(t, definition.ID, registry.PlanIDs()[definition.Name])
+		assert.Equal(t, AWSPlanID, registry.PlanIDs()[AWSPlanName])
+		assert.NotContains(t, PlanIDsMapping, definition.Name)
+		assert.True(t, registry.IsFreemiumPlan(definition.ID))
+		assert.True(t, registry.IsExpirablePlan(definition.ID))
+		assert.NoError(t, registry.ValidateEnabledPlans(EnablePlans{AWSPlanName, definition.Name}))
+
+		plan, found := plans[definition.ID]
+		require.True(t, found)
+		assert.Equal(t, definition.Name, plan.Name)
+		create := plan.Schemas.Instance.Create.Parameters[PropertiesKey].(map[string]interface{})
+		assert.Equal(t, []interface{}{"eu-central-1", "us-east-1"}, create["region"].(map[string]interface{})["enum"])
+		assert.Equal(t, []interface{}{"m5.large", "m5.xlarge"}, create["machineType"].(map[string]interface{})["enum"])
+		assert.Equal(t, float64(5), create["autoScalerMax"].(map[string]interface{})["maximum"])
+		assert.Equal(t, float64(3), create["autoScalerMax"].(map[string]interface{})["default"])
+
+		update := plan.Schemas.Instance.Update.Parameters[PropertiesKey].(map[string]interface{})
+		assert.Contains(t, update, "autoScalerMin")
+		assert.NotContains(t, update, "machineType")
+
+		euCreate := euPlans[definition.ID].Schemas.Instance.Create.Parameters[PropertiesKey].(map[string]interface{})
+		assert.Equal(t, []interface{}{"eu-central-1"}, euCreate["region"].(map[string]interface{})["enum"])
+	})
+
+	t.Run("should know only the built-in plans without a registry", func(t *testing.T) {
+		// given
+		var registry *PlanRegistry
+
+		// when
+		plans := Plans(nil, registry, PlansConfig{}, internal.AWS, false, false)
+
+		// then
+		assert.Len(t, plans, len(PlanNamesMapping))
+		assert.Equal(t, PlanIDsMapping, registry.PlanIDs())
+		assert.True(t, registry.IsFreemiumPlan(FreemiumPlanID))
+		assert.EqualError(t, registry.ValidateEnabledPlans(EnablePlans{"aws_small"}), "unrecognized aws_small plan name")
+	})
+
+	t.Run("should not offer defined plan without EU access in EU access regions", func(t *testing.T) {
+		// given
+		definition := fixPlanDefinition()
+		definition.Features.EUAccess = false
+		registry := newPlanRegistryForTest(t, definition)
+
+		// when
+		plans := Plans(nil, registry, PlansConfig{}, internal.AWS, false, true)
+
+		// then
+		assert.NotContains(t, plans, definition.ID)
+	})
+}
+
+func TestPlanDefinition_ValidateUpdate(t *testing.T) {
+	// given
+	definition := fixPlanDefinition()
+
+	// when
+	allowedErr := definition.ValidateUpdate(internal.UpdatingParametersDTO{AutoScalerParameters: internal.AutoScalerParameters{AutoScalerMax: ptr.Integer(4)}})
+	deniedErr := definition.ValidateUpdate(internal.UpdatingParametersDTO{MachineType: ptr.String("m5.xlarge"), RuntimeAdministrators: []string{"admin@example.com"}})
+
+	// then
+	assert.NoError(t, allowedErr)
+	require.Error(t, deniedErr)
+	assert.Contains(t, deniedErr.Error(), "[administrators machineType]")
+}
+
+func fixPlanDefinition() PlanDefinition {
+	return PlanDefinition{
+		ID:              "d1f1c0de-0000-4000-8000-000000000001",
+		Name:            "aws_small",
+		Provider:        internal.AWS,
+		Regions:         []string{"eu-central-1", "us-east-1"},
+		EUAccessRegions: []string{"eu-central-1"},
+		MachineTypes:    []MachineTypeDefinition{{Name: "m5.large", Display: "m5.large (2vCPU, 8GB RAM)"}, {Name: "m5.xlarge"}},
+		AutoScaler:      AutoScalerBounds{Minimum: 1, Maximum: 5},
+		VolumeSizeGb:    30,
+		Defaults:        PlanDefinitionDefaults{AutoScalerMax: 3},
+		AllowedUpdates:  []string{UpdateAutoScalerMin, UpdateAutoScalerMax},
+		Features:        PlanFeatures{TrialExpiry: 720 * time.Hour, FreeTier: true, EUAccess: true},
+	}
+}
+
+func newPlanRegistryForTest(t *testing.T, definitions ...PlanDefinition) *PlanRegistry {
+	registry, err := NewPlanRegistry(PlanDefinitions{Plans: definitions})
+	require.NoError(t, err)
+	return registry
+}
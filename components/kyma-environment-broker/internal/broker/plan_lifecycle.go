@@ -0,0 +1,35 @@
+package broker
+
+import (
+	"time"
+)
+
+// PlanLifecycle configures the lifecycle rules of a plan instead of hard-wiring them to the trial plan
+type PlanLifecycle struct {
+	// Suspension suspends and unsuspends the instances when the active flag of the context changes
+	Suspension bool `yaml:"suspension"`
+	// SingleInstancePerGlobalAccount allows only one instance of the plan in a global account
+	SingleInstancePerGlobalAccount bool `yaml:"singleInstancePerGlobalAccount"`
+	// InactivityExpiry expires the instances which have not been used for the given period, the usage is recorded
+	// when the kubeconfig is downloaded or the consumption is reported
+	InactivityExpiry time.Duration `yaml:"inactivityExpiry"`
+}
+
+// LifecycleByID returns the lifecycle rules of the plan, without configured rules only the expirable plans are suspended
+func (r *PlanRegistry) LifecycleByID(planID string) PlanLifecycle {
+	if lifecycle, found := r.lifecyclesByID()[planID]; found {
+		return lifecycle
+	}
+	return PlanLifecycle{Suspension: r.IsExpirablePlan(planID)}
+}
+
+// InactivityExpiryPeriods maps the IDs of the plans with inactivity expiry to their periods
+func (r *PlanRegistry) InactivityExpiryPeriods() map[string]time.Duration {
+	periods := make(map[string]time.Duration)
+	for planID, lifecycle := range r.lifecyclesByID() {
+		if lifecycle.InactivityExpiry > 0 {
+			periods[planID] = lifecycle.InactivityExpiry
+		}
+	}
+	return periods
+}
@@ -0,0 +1,76 @@
+package broker
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+)
+
+func TestPlanRegistry_Lifecycles(t *testing.T) {
+	t.Run("should apply the lifecycle rules to the free plan", func(t *testing.T) {
+		// given
+		lifecycle := PlanLifecycle{Suspension: true, SingleInstancePerGlobalAccount: true, InactivityExpiry: 720 * time.Hour}
+
+		// when
+		registry, err := NewPlanRegistry(PlanDefinitions{Lifecycles: map[string]PlanLifecycle{FreemiumPlanName: lifecycle}})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, lifecycle, registry.LifecycleByID(FreemiumPlanID))
+		assert.True(t, registry.IsExpirablePlan(FreemiumPlanID))
+		assert.Equal(t, map[string]time.Duration{FreemiumPlanID: 720 * time.Hour}, registry.InactivityExpiryPeriods())
+	})
+
+	t.Run("should suspend only the expirable plans by default", func(t *testing.T) {
+		// given
+		registry := newPlanRegistryForTest(t)
+
+		// then
+		assert.Equal(t, PlanLifecycle{Suspension: true}, registry.LifecycleByID(TrialPlanID))
+		assert.Equal(t, PlanLifecycle{}, registry.LifecycleByID(FreemiumPlanID))
+		assert.False(t, registry.IsExpirablePlan(FreemiumPlanID))
+	})
+
+	t.Run("should reject an unknown plan", func(t *testing.T) {
+		// when
+		_, err := NewPlanRegistry(PlanDefinitions{Lifecycles: map[string]PlanLifecycle{"unknown": {Suspension: true}}})
+
+		// then
+		assert.EqualError(t, err, "lifecycle of unknown plan unknown")
+	})
+
+	t.Run("should reject a negative inactivity expiry", func(t *testing.T) {
+		// when
+		_, err := NewPlanRegistry(PlanDefinitions{Lifecycles: map[string]PlanLifecycle{FreemiumPlanName: {InactivityExpiry: -time.Hour}}})
+
+		// then
+		assert.EqualError(t, err, "inactivityExpiry of plan free must not be negative")
+	})
+}
+
+func TestPlanLifecycles_ChartDefaults(t *testing.T) {
+	// given
+	values, err := os.ReadFile("../../../../resources/kcp/charts/kyma-environment-broker/values.yaml")
+	require.NoError(t, err)
+	var chart struct {
+		PlanDefinitions string `yaml:"planDefinitions"`
+	}
+	require.NoError(t, yaml.Unmarshal(values, &chart))
+	filename := filepath.Join(t.TempDir(), "planDefinitions.yaml")
+	require.NoError(t, os.WriteFile(filename, []byte(chart.PlanDefinitions), 0600))
+
+	// when
+	definitions, err := ReadPlanDefinitionsFromFile(filename)
+	require.NoError(t, err)
+	registry, err := NewPlanRegistry(definitions)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, PlanLifecycle{Suspension: true, SingleInstancePerGlobalAccount: true, InactivityExpiry: 720 * time.Hour}, registry.LifecycleByID(FreemiumPlanID))
+	assert.Equal(t, PlanLifecycle{Suspension: true}, registry.LifecycleByID(TrialPlanID))
+}
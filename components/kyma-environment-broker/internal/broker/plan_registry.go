@@ -0,0 +1,146 @@
+package broker
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+)
+
+// PlanRegistry holds the plans defined in the configuration next to the built-in ones and the lifecycle rules of the plans.
+// It is created once when the broker starts and passed to the components which handle the defined plans,
+// a nil registry knows only the built-in plans.
+type PlanRegistry struct {
+	definitions map[string]PlanDefinition
+	lifecycles  map[string]PlanLifecycle
+	names       map[string]string
+	ids         map[string]string
+}
+
+// NewPlanRegistry validates the plan definitions and the lifecycle rules and registers them next to the built-in plans
+func NewPlanRegistry(definitions PlanDefinitions) (*PlanRegistry, error) {
+	r := &PlanRegistry{
+		definitions: map[string]PlanDefinition{},
+		lifecycles:  map[string]PlanLifecycle{},
+		names:       map[string]string{},
+		ids:         map[string]string{},
+	}
+	for id, name := range PlanNamesMapping {
+		r.names[id] = name
+		r.ids[name] = id
+	}
+
+	for _, definition := range definitions.Plans {
+		if err := definition.Validate(); err != nil {
+			return nil, fmt.Errorf("while validating plan %q: %w", definition.Name, err)
+		}
+		if _, exists := r.names[definition.ID]; exists {
+			return nil, fmt.Errorf("plan ID %s is already used", definition.ID)
+		}
+		if _, exists := r.ids[definition.Name]; exists {
+			return nil, fmt.Errorf("plan name %s is already used", definition.Name)
+		}
+		r.names[definition.ID] = definition.Name
+		r.ids[definition.Name] = definition.ID
+		r.definitions[definition.ID] = definition
+	}
+
+	for planName, lifecycle := range definitions.Lifecycles {
+		planID, found := r.ids[planName]
+		if !found {
+			return nil, fmt.Errorf("lifecycle of unknown plan %s", planName)
+		}
+		if lifecycle.InactivityExpiry < 0 {
+			return nil, fmt.Errorf("inactivityExpiry of plan %s must not be negative", planName)
+		}
+		r.lifecycles[planID] = lifecycle
+	}
+	return r, nil
+}
+
+// DefinitionByID returns the definition of a plan defined in the configuration
+func (r *PlanRegistry) DefinitionByID(planID string) (PlanDefinition, bool) {
+	definition, found := r.definitionsByID()[planID]
+	return definition, found
+}
+
+// Definitions returns the plans defined in the configuration ordered by name
+func (r *PlanRegistry) Definitions() []PlanDefinition {
+	definitions := make([]PlanDefinition, 0, len(r.definitionsByID()))
+	for _, definition := range r.definitionsByID() {
+		definitions = append(definitions, definition)
+	}
+	sort.Slice(definitions, func(i, j int) bool { return definitions[i].Name < definitions[j].Name })
+	return definitions
+}
+
+// PlanNames maps the IDs of the built-in and the defined plans to their names, the map must not be modified
+func (r *PlanRegistry) PlanNames() map[string]string {
+	if r == nil {
+		return PlanNamesMapping
+	}
+	return r.names
+}
+
+// PlanIDs maps the names of the built-in and the defined plans to their IDs, the map must not be modified
+func (r *PlanRegistry) PlanIDs() map[string]string {
+	if r == nil {
+		return PlanIDsMapping
+	}
+	return r.ids
+}
+
+// ProviderByName returns the provider of a built-in plan with a fixed provider or of a defined plan
+func (r *PlanRegistry) ProviderByName(planName string) (internal.CloudProvider, bool) {
+	if provider, found := builtInPlanProviders[planName]; found {
+		return provider, true
+	}
+	definition, found := r.DefinitionByID(r.PlanIDs()[planName])
+	return definition.Provider, found
+}
+
+// ValidateEnabledPlans checks that the enabled plans are built-in or defined
+func (r *PlanRegistry) ValidateEnabledPlans(plans EnablePlans) error {
+	for _, name := range plans {
+		if _, exists := r.PlanIDs()[name]; !exists {
+			return fmt.Errorf("unrecognized %v plan name", name)
+		}
+	}
+	return nil
+}
+
+// IsFreemiumPlan returns true for the freemium plan and the defined plans with the free tier feature
+func (r *PlanRegistry) IsFreemiumPlan(planID string) bool {
+	if IsFreemiumPlan(planID) {
+		return true
+	}
+	definition, found := r.DefinitionByID(planID)
+	return found && definition.Features.FreeTier
+}
+
+// IsExpirablePlan returns true for the plans which instances can be expired, the trial plan, the defined plans with trial expiry
+// and the plans with inactivity expiry
+func (r *PlanRegistry) IsExpirablePlan(planID string) bool {
+	if IsTrialPlan(planID) {
+		return true
+	}
+	definition, found := r.DefinitionByID(planID)
+	if found && definition.Features.TrialExpiry > 0 {
+		return true
+	}
+	return r.lifecyclesByID()[planID].InactivityExpiry > 0
+}
+
+func (r *PlanRegistry) definitionsByID() map[string]PlanDefinition {
+	if r == nil {
+		return nil
+	}
+	return r.definitions
+}
+
+func (r *PlanRegistry) lifecyclesByID() map[string]PlanLifecycle {
+	if r == nil {
+		return nil
+	}
+	return r.lifecycles
+}
@@ -57,6 +57,17 @@ var PlanIDsMapping = map[string]string{
 	PreviewPlanName:    PreviewPlanID,
 }
 
+// builtInPlanProviders maps the built-in plans with a fixed provider to it, the trial, freemium and own_cluster plans
+// have no fixed provider
+var builtInPlanProviders = map[string]internal.CloudProvider{
+	AWSPlanName:       internal.AWS,
+	PreviewPlanName:   internal.AWS,
+	AzurePlanName:     internal.Azure,
+	AzureLitePlanName: internal.Azure,
+	GCPPlanName:       internal.GCP,
+	OpenStackPlanName: internal.Openstack,
+}
+
 type TrialCloudRegion string
 
 const (
@@ -75,6 +86,13 @@ type JSONSchemaValidator interface {
 	ValidateString(json string) (jsonschema.ValidationResult, error)
 }
 
+// PlanCatalog supplies the regions and machine types offered by the plans, e.g. derived from Gardener CloudProfiles.
+// The found result is false when the catalog has no data for the plan, then the built-in lists are used.
+type PlanCatalog interface {
+	Regions(planName string, euAccessRestricted bool) (regions []string, found bool)
+	MachineTypes(planName string) (machineTypes []string, machineTypesDisplay map[string]string, found bool)
+}
+
 func AzureRegions(euRestrictedAccess bool) []string {
 	if euRestrictedAccess {
 		return []string{
@@ -114,7 +132,11 @@ func OpenStackRegions() []string {
 }
 
 func OpenStackSchema(machineTypesDisplay map[string]string, machineTypes []string, additionalParams, update bool) *map[string]interface{} {
-	properties := NewProvisioningProperties(machineTypesDisplay, machineTypes, OpenStackRegions(), update)
+	return openStackSchema(machineTypesDisplay, machineTypes, OpenStackRegions(), additionalParams, update)
+}
+
+func openStackSchema(machineTypesDisplay map[string]string, machineTypes, regions []string, additionalParams, update bool) *map[string]interface{} {
+	properties := NewProvisioningProperties(machineTypesDisplay, machineTypes, regions, update)
 	properties.AutoScalerMax.Maximum = 40
 	if !update {
 		properties.AutoScalerMax.Default = 8
@@ -124,28 +146,44 @@ func OpenStackSchema(machineTypesDisplay map[string]string, machineTypes []strin
 }
 
 func GCPSchema(machineTypesDisplay map[string]string, machineTypes []string, additionalParams, update bool) *map[string]interface{} {
-	properties := NewProvisioningProperties(machineTypesDisplay, machineTypes, GCPRegions(), update)
+	return gcpSchema(machineTypesDisplay, machineTypes, GCPRegions(), additionalParams, update)
+}
+
+func gcpSchema(machineTypesDisplay map[string]string, machineTypes, regions []string, additionalParams, update bool) *map[string]interface{} {
+	properties := NewProvisioningProperties(machineTypesDisplay, machineTypes, regions, update)
 	properties.AutoScalerMax.Minimum = 3
 	properties.AutoScalerMin.Minimum = 3
 	return createSchemaWithProperties(properties, additionalParams, update)
 }
 
 func AWSSchema(machineTypesDisplay map[string]string, machineTypes []string, additionalParams, update bool, euAccessRestricted bool) *map[string]interface{} {
-	properties := NewProvisioningProperties(machineTypesDisplay, machineTypes, AWSRegions(euAccessRestricted), update)
+	return awsSchema(machineTypesDisplay, machineTypes, AWSRegions(euAccessRestricted), additionalParams, update)
+}
+
+func awsSchema(machineTypesDisplay map[string]string, machineTypes, regions []string, additionalParams, update bool) *map[string]interface{} {
+	properties := NewProvisioningProperties(machineTypesDisplay, machineTypes, regions, update)
 	properties.AutoScalerMax.Minimum = 3
 	properties.AutoScalerMin.Minimum = 3
 	return createSchemaWithProperties(properties, additionalParams, update)
 }
 
 func AzureSchema(machineTypesDisplay map[string]string, machineTypes []string, additionalParams, update bool, euAccessRestricted bool) *map[string]interface{} {
-	properties := NewProvisioningProperties(machineTypesDisplay, machineTypes, AzureRegions(euAccessRestricted), update)
+	return azureSchema(machineTypesDisplay, machineTypes, AzureRegions(euAccessRestricted), additionalParams, update)
+}
+
+func azureSchema(machineTypesDisplay map[string]string, machineTypes, regions []string, additionalParams, update bool) *map[string]interface{} {
+	properties := NewProvisioningProperties(machineTypesDisplay, machineTypes, regions, update)
 	properties.AutoScalerMax.Minimum = 3
 	properties.AutoScalerMin.Minimum = 3
 	return createSchemaWithProperties(properties, additionalParams, update)
 }
 
 func AzureLiteSchema(machineTypesDisplay map[string]string, machineTypes []string, additionalParams, update bool, euAccessRestricted bool) *map[string]interface{} {
-	properties := NewProvisioningProperties(machineTypesDisplay, machineTypes, AzureRegions(euAccessRestricted), update)
+	return azureLiteSchema(machineTypesDisplay, machineTypes, AzureRegions(euAccessRestricted), additionalParams, update)
+}
+
+func azureLiteSchema(machineTypesDisplay map[string]string, machineTypes, regions []string, additionalParams, update bool) *map[string]interface{} {
+	properties := NewProvisioningProperties(machineTypesDisplay, machineTypes, regions, update)
 	properties.AutoScalerMax.Maximum = 40
 
 	if !update {
@@ -157,19 +195,21 @@ func AzureLiteSchema(machineTypesDisplay map[string]string, machineTypes []strin
 }
 
 func FreemiumSchema(provider internal.CloudProvider, additionalParams, update bool, euAccessRestricted bool) *map[string]interface{} {
-	if update && !additionalParams {
-		return empty()
-	}
-
 	var regions []string
 	switch provider {
-	case internal.AWS:
-		regions = AWSRegions(euAccessRestricted)
 	case internal.Azure:
 		regions = AzureRegions(euAccessRestricted)
 	default:
 		regions = AWSRegions(euAccessRestricted)
 	}
+	return freemiumSchema(regions, additionalParams, update)
+}
+
+func freemiumSchema(regions []string, additionalParams, update bool) *map[string]interface{} {
+	if update && !additionalParams {
+		return empty()
+	}
+
 	properties := ProvisioningProperties{
 		Name: NameProperty(),
 		Region: &Type{
@@ -258,9 +298,10 @@ func unmarshalSchema(schema *RootSchema) *map[string]interface{} {
 	return unmarshaled
 }
 
-// Plans is designed to hold plan defaulting logic
+// Plans is designed to hold plan defaulting logic, the regions and machine types are taken from the catalog if it provides them,
+// the defined plans are added from the registry
 // keep internal/hyperscaler/azure/config.go in sync with any changes to available zones
-func Plans(plans PlansConfig, provider internal.CloudProvider, includeAdditionalParamsInSchema bool, euAccessRestricted bool) map[string]domain.ServicePlan {
+func Plans(catalog PlanCatalog, registry *PlanRegistry, plans PlansConfig, provider internal.CloudProvider, includeAdditionalParamsInSchema bool, euAccessRestricted bool) map[string]domain.ServicePlan {
 	awsMachines := []string{"m5.xlarge", "m5.2xlarge", "m5.4xlarge", "m5.8xlarge", "m5.12xlarge", "m6i.xlarge", "m6i.2xlarge", "m6i.4xlarge", "m6i.8xlarge", "m6i.12xlarge"}
 	awsMachinesDisplay := map[string]string{
 		// source: https://aws.amazon.com/ec2/instance-types/m5/
@@ -288,14 +329,18 @@ func Plans(plans PlansConfig, provider internal.CloudProvider, includeAdditional
 		"n2-standard-32": "n2-standard-32 (32vCPU, 128GB RAM)",
 		"n2-standard-48": "n2-standard-48 (48vCPU, 192B RAM)",
 	}
-	gcpSchema := GCPSchema(gcpMachinesDisplay, gcpMachines, includeAdditionalParamsInSchema, false)
+	gcpMachines, gcpMachinesDisplay = catalogMachineTypes(catalog, GCPPlanName, gcpMachines, gcpMachinesDisplay)
+	gcpRegions := catalogRegions(catalog, GCPPlanName, euAccessRestricted, GCPRegions())
+	gcpCreateSchema := gcpSchema(gcpMachinesDisplay, gcpMachines, gcpRegions, includeAdditionalParamsInSchema, false)
 
 	openStackMachines := []string{"g_c4_m16", "g_c8_m32"}
 	openStackMachinesDisplay := map[string]string{
 		"g_c4_m16": "g_c4_m16 (4vCPU, 16GB RAM)",
 		"g_c8_m32": "g_c8_m32 (8vCPU, 32GB RAM)",
 	}
-	openstackSchema := OpenStackSchema(openStackMachinesDisplay, openStackMachines, includeAdditionalParamsInSchema, false)
+	openStackMachines, openStackMachinesDisplay = catalogMachineTypes(catalog, OpenStackPlanName, openStackMachines, openStackMachinesDisplay)
+	openStackRegions := catalogRegions(catalog, OpenStackPlanName, euAccessRestricted, OpenStackRegions())
+	openstackSchema := openStackSchema(openStackMachinesDisplay, openStackMachines, openStackRegions, includeAdditionalParamsInSchema, false)
 
 	// source: https://docs.microsoft.com/en-us/azure/cloud-services/cloud-services-sizes-specs#dv3-series
 	azureMachines := []string{"Standard_D4_v3", "Standard_D8_v3", "Standard_D16_v3", "Standard_D32_v3", "Standard_D48_v3", "Standard_D64_v3"}
@@ -307,14 +352,25 @@ func Plans(plans PlansConfig, provider internal.CloudProvider, includeAdditional
 		"Standard_D48_v3": "Standard_D48_v3 (48vCPU, 192GB RAM)",
 		"Standard_D64_v3": "Standard_D64_v3 (64vCPU, 256GB RAM)",
 	}
-	azureSchema := AzureSchema(azureMachinesDisplay, azureMachines, includeAdditionalParamsInSchema, false, euAccessRestricted)
-
 	azureLiteMachines := []string{"Standard_D4_v3"}
 	azureLiteMachinesDisplay := map[string]string{
 		"Standard_D4_v3": azureMachinesDisplay["Standard_D4_v3"],
 	}
-	azureLiteSchema := AzureLiteSchema(azureLiteMachinesDisplay, azureLiteMachines, includeAdditionalParamsInSchema, false, euAccessRestricted)
-	freemiumSchema := FreemiumSchema(provider, includeAdditionalParamsInSchema, false, euAccessRestricted)
+	azureMachines, azureMachinesDisplay = catalogMachineTypes(catalog, AzurePlanName, azureMachines, azureMachinesDisplay)
+	azureRegions := catalogRegions(catalog, AzurePlanName, euAccessRestricted, AzureRegions(euAccessRestricted))
+	azureCreateSchema := azureSchema(azureMachinesDisplay, azureMachines, azureRegions, includeAdditionalParamsInSchema, false)
+
+	azureLiteMachines, azureLiteMachinesDisplay = catalogMachineTypes(catalog, AzureLitePlanName, azureLiteMachines, azureLiteMachinesDisplay)
+	azureLiteRegions := catalogRegions(catalog, AzureLitePlanName, euAccessRestricted, AzureRegions(euAccessRestricted))
+	azureLiteCreateSchema := azureLiteSchema(azureLiteMachinesDisplay, azureLiteMachines, azureLiteRegions, includeAdditionalParamsInSchema, false)
+
+	awsRegions := catalogRegions(catalog, AWSPlanName, euAccessRestricted, AWSRegions(euAccessRestricted))
+	// freemium runtimes are created in the regions of the corresponding paid plan
+	freemiumRegions := awsRegions
+	if provider == internal.Azure {
+		freemiumRegions = azureRegions
+	}
+	freemiumCreateSchema := freemiumSchema(freemiumRegions, includeAdditionalParamsInSchema, false)
 	trialSchema := TrialSchema(includeAdditionalParamsInSchema, false)
 	ownClusterSchema := OwnClusterSchema(false)
 
@@ -329,23 +385,53 @@ func Plans(plans PlansConfig, provider internal.CloudProvider, includeAdditional
 		"m5.8xlarge":  awsMachinesDisplay["m5.8xlarge"],
 		"m5.12xlarge": awsMachinesDisplay["m5.12xlarge"],
 	}
-	awsCatalogSchema := AWSSchema(awsCatalogMachinesDisplay, awsCatalogMachines, includeAdditionalParamsInSchema, false, euAccessRestricted)
+	// machine types provided by the catalog are offered both on the catalog endpoint and for updates
+	if catalog != nil {
+		if machines, display, found := catalog.MachineTypes(AWSPlanName); found {
+			awsMachines, awsMachinesDisplay = machines, display
+			awsCatalogMachines, awsCatalogMachinesDisplay = machines, display
+		}
+	}
+	awsCatalogSchema := awsSchema(awsCatalogMachinesDisplay, awsCatalogMachines, awsRegions, includeAdditionalParamsInSchema, false)
 
 	outputPlans := map[string]domain.ServicePlan{
-		AWSPlanID:        defaultServicePlan(AWSPlanID, AWSPlanName, plans, awsCatalogSchema, AWSSchema(awsMachinesDisplay, awsMachines, includeAdditionalParamsInSchema, true, euAccessRestricted)),
-		GCPPlanID:        defaultServicePlan(GCPPlanID, GCPPlanName, plans, gcpSchema, GCPSchema(gcpMachinesDisplay, gcpMachines, includeAdditionalParamsInSchema, true)),
-		OpenStackPlanID:  defaultServicePlan(OpenStackPlanID, OpenStackPlanName, plans, openstackSchema, OpenStackSchema(openStackMachinesDisplay, openStackMachines, includeAdditionalParamsInSchema, true)),
-		AzurePlanID:      defaultServicePlan(AzurePlanID, AzurePlanName, plans, azureSchema, AzureSchema(azureMachinesDisplay, azureMachines, includeAdditionalParamsInSchema, true, euAccessRestricted)),
-		AzureLitePlanID:  defaultServicePlan(AzureLitePlanID, AzureLitePlanName, plans, azureLiteSchema, AzureLiteSchema(azureLiteMachinesDisplay, azureLiteMachines, includeAdditionalParamsInSchema, true, euAccessRestricted)),
-		FreemiumPlanID:   defaultServicePlan(FreemiumPlanID, FreemiumPlanName, plans, freemiumSchema, FreemiumSchema(provider, includeAdditionalParamsInSchema, true, euAccessRestricted)),
+		AWSPlanID:        defaultServicePlan(AWSPlanID, AWSPlanName, plans, awsCatalogSchema, awsSchema(awsMachinesDisplay, awsMachines, awsRegions, includeAdditionalParamsInSchema, true)),
+		GCPPlanID:        defaultServicePlan(GCPPlanID, GCPPlanName, plans, gcpCreateSchema, gcpSchema(gcpMachinesDisplay, gcpMachines, gcpRegions, includeAdditionalParamsInSchema, true)),
+		OpenStackPlanID:  defaultServicePlan(OpenStackPlanID, OpenStackPlanName, plans, openstackSchema, openStackSchema(openStackMachinesDisplay, openStackMachines, openStackRegions, includeAdditionalParamsInSchema, true)),
+		AzurePlanID:      defaultServicePlan(AzurePlanID, AzurePlanName, plans, azureCreateSchema, azureSchema(azureMachinesDisplay, azureMachines, azureRegions, includeAdditionalParamsInSchema, true)),
+		AzureLitePlanID:  defaultServicePlan(AzureLitePlanID, AzureLitePlanName, plans, azureLiteCreateSchema, azureLiteSchema(azureLiteMachinesDisplay, azureLiteMachines, azureLiteRegions, includeAdditionalParamsInSchema, true)),
+		FreemiumPlanID:   defaultServicePlan(FreemiumPlanID, FreemiumPlanName, plans, freemiumCreateSchema, freemiumSchema(freemiumRegions, includeAdditionalParamsInSchema, true)),
 		TrialPlanID:      defaultServicePlan(TrialPlanID, TrialPlanName, plans, trialSchema, TrialSchema(includeAdditionalParamsInSchema, true)),
 		OwnClusterPlanID: defaultServicePlan(OwnClusterPlanID, OwnClusterPlanName, plans, ownClusterSchema, OwnClusterSchema(true)),
-		PreviewPlanID:    defaultServicePlan(PreviewPlanID, PreviewPlanName, plans, awsCatalogSchema, AWSSchema(awsMachinesDisplay, awsMachines, includeAdditionalParamsInSchema, true, euAccessRestricted)),
+		PreviewPlanID:    defaultServicePlan(PreviewPlanID, PreviewPlanName, plans, awsCatalogSchema, awsSchema(awsMachinesDisplay, awsMachines, awsRegions, includeAdditionalParamsInSchema, true)),
+	}
+	for id, plan := range registry.definedPlans(catalog, plans, includeAdditionalParamsInSchema, euAccessRestricted) {
+		outputPlans[id] = plan
 	}
 
 	return outputPlans
 }
 
+func catalogRegions(catalog PlanCatalog, planName string, euAccessRestricted bool, defaults []string) []string {
+	if catalog == nil {
+		return defaults
+	}
+	if regions, found := catalog.Regions(planName, euAccessRestricted); found {
+		return regions
+	}
+	return defaults
+}
+
+func catalogMachineTypes(catalog PlanCatalog, planName string, defaults []string, defaultsDisplay map[string]string) ([]string, map[string]string) {
+	if catalog == nil {
+		return defaults, defaultsDisplay
+	}
+	if machines, display, found := catalog.MachineTypes(planName); found {
+		return machines, display
+	}
+	return defaults, defaultsDisplay
+}
+
 func defaultServicePlan(id, name string, plans PlansConfig, createParams, updateParams *map[string]interface{}) domain.ServicePlan {
 	servicePlan := domain.ServicePlan{
 		ID:          id,
@@ -17,10 +17,11 @@ type RootSchema struct {
 type ProvisioningProperties struct {
 	UpdateProperties
 
-	Name        NameType `json:"name"`
-	ShootName   *Type    `json:"shootName,omitempty"`
-	ShootDomain *Type    `json:"shootDomain,omitempty"`
-	Region      *Type    `json:"region,omitempty"`
+	Name        NameType        `json:"name"`
+	ShootName   *Type           `json:"shootName,omitempty"`
+	ShootDomain *Type           `json:"shootDomain,omitempty"`
+	Region      *Type           `json:"region,omitempty"`
+	Networking  *NetworkingType `json:"networking,omitempty"`
 }
 
 type UpdateProperties struct {
@@ -52,6 +53,17 @@ type OIDCType struct {
 	Required   []string       `json:"required"`
 }
 
+type NetworkingProperties struct {
+	Nodes    Type `json:"nodes"`
+	Pods     Type `json:"pods"`
+	Services Type `json:"services"`
+}
+
+type NetworkingType struct {
+	Type
+	Properties NetworkingProperties `json:"properties"`
+}
+
 type Type struct {
 	Type        string `json:"type"`
 	Title       string `json:"title,omitempty"`
@@ -158,6 +170,8 @@ func NewProvisioningProperties(machineTypesDisplay map[string]string, machineTyp
 	if update {
 		properties.AutoScalerMax.Default = nil
 		properties.AutoScalerMin.Default = nil
+	} else {
+		properties.Networking = NewNetworkingSchema()
 	}
 
 	return properties
@@ -184,6 +198,34 @@ func NewOIDCSchema() *OIDCType {
 	}
 }
 
+// NewNetworkingSchema describes the ranges of the runtime network, they can be set only when the runtime is provisioned
+func NewNetworkingSchema() *NetworkingType {
+	cidrPattern := `^(\d{1,3}\.){3}\d{1,3}/\d{1,2}$`
+	return &NetworkingType{
+		Type: Type{Type: "object", Description: "Networking configuration. The ranges must not overlap and cannot be changed after the runtime is created"},
+		Properties: NetworkingProperties{
+			Nodes: Type{
+				Type:        "string",
+				Title:       "Node network's CIDR",
+				Description: "The range of the nodes, the subnets of the zones are created in it",
+				Pattern:     cidrPattern,
+			},
+			Pods: Type{
+				Type:        "string",
+				Title:       "Pod network's CIDR",
+				Description: "The range of the pods, 100.64.0.0/12 by default",
+				Pattern:     cidrPattern,
+			},
+			Services: Type{
+				Type:        "string",
+				Title:       "Service network's CIDR",
+				Description: "The range of the services, 100.104.0.0/13 by default",
+				Pattern:     cidrPattern,
+			},
+		},
+	}
+}
+
 func NewSchemaWithOnlyNameRequired(properties interface{}, update bool) *RootSchema {
 	return NewSchemaForOwnCluster(properties, update, []string{"name"})
 }
@@ -218,7 +260,7 @@ func unmarshalOrPanic(from, to interface{}) interface{} {
 }
 
 func DefaultControlsOrder() []string {
-	return []string{"name", "kubeconfig", "shootName", "shootDomain", "region", "machineType", "autoScalerMin", "autoScalerMax", "zonesCount", "oidc", "administrators"}
+	return []string{"name", "kubeconfig", "shootName", "shootDomain", "region", "machineType", "autoScalerMin", "autoScalerMax", "zonesCount", "oidc", "administrators", "networking"}
 }
 
 func ToInterfaceSlice(input []string) []interface{} {
@@ -22,14 +22,16 @@ type ServicesEndpoint struct {
 	log            logrus.FieldLogger
 	cfg            Config
 	servicesConfig ServicesConfig
+	planCatalog    PlanCatalog
+	planRegistry   *PlanRegistry
 
 	enabledPlanIDs map[string]struct{}
 }
 
-func NewServices(cfg Config, servicesConfig ServicesConfig, log logrus.FieldLogger) *ServicesEndpoint {
+func NewServices(cfg Config, servicesConfig ServicesConfig, planCatalog PlanCatalog, planRegistry *PlanRegistry, log logrus.FieldLogger) *ServicesEndpoint {
 	enabledPlanIDs := map[string]struct{}{}
 	for _, planName := range cfg.EnablePlans {
-		id := PlanIDsMapping[planName]
+		id := planRegistry.PlanIDs()[planName]
 		enabledPlanIDs[id] = struct{}{}
 	}
 
@@ -37,6 +39,8 @@ func NewServices(cfg Config, servicesConfig ServicesConfig, log logrus.FieldLogg
 		log:            log.WithField("service", "ServicesEndpoint"),
 		cfg:            cfg,
 		servicesConfig: servicesConfig,
+		planCatalog:    planCatalog,
+		planRegistry:   planRegistry,
 		enabledPlanIDs: enabledPlanIDs,
 	}
 }
@@ -54,7 +58,7 @@ func (b *ServicesEndpoint) Services(ctx context.Context) ([]domain.Service, erro
 
 	provider, ok := middleware.ProviderFromContext(ctx)
 	platformRegion, ok := middleware.RegionFromContext(ctx)
-	for _, plan := range Plans(class.Plans, provider, b.cfg.IncludeAdditionalParamsInSchema, euaccess.IsEURestrictedAccess(platformRegion)) {
+	for _, plan := range Plans(b.planCatalog, b.planRegistry, class.Plans, provider, b.cfg.IncludeAdditionalParamsInSchema, euaccess.IsEURestrictedAccess(platformRegion)) {
 		// filter out not enabled plans
 		if _, exists := b.enabledPlanIDs[plan.ID]; !exists {
 			continue
@@ -31,7 +31,7 @@ func TestServices_Services(t *testing.T) {
 				},
 			},
 		}
-		servicesEndpoint := broker.NewServices(cfg, servicesConfig, logrus.StandardLogger())
+		servicesEndpoint := broker.NewServices(cfg, servicesConfig, nil, nil, logrus.StandardLogger())
 
 		// when
 		services, err := servicesEndpoint.Services(context.TODO())
@@ -63,7 +63,7 @@ func TestServices_Services(t *testing.T) {
 				},
 			},
 		}
-		servicesEndpoint := broker.NewServices(cfg, servicesConfig, logrus.StandardLogger())
+		servicesEndpoint := broker.NewServices(cfg, servicesConfig, nil, nil, logrus.StandardLogger())
 
 		// when
 		services, err := servicesEndpoint.Services(context.TODO())
@@ -99,7 +99,7 @@ func TestServices_Services(t *testing.T) {
 				},
 			},
 		}
-		servicesEndpoint := broker.NewServices(cfg, servicesConfig, logrus.StandardLogger())
+		servicesEndpoint := broker.NewServices(cfg, servicesConfig, nil, nil, logrus.StandardLogger())
 
 		// when
 		services, err := servicesEndpoint.Services(context.TODO())
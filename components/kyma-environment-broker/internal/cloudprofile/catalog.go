@@ -0,0 +1,223 @@
+package cloudprofile
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/gardener"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
+)
+
+// providerTypes maps the cloud providers to the Gardener provider types of their CloudProfiles
+var providerTypes = map[internal.CloudProvider]string{
+	internal.AWS:       "aws",
+	internal.Azure:     "azure",
+	internal.GCP:       "gcp",
+	internal.Openstack: "openstack",
+}
+
+type providerData struct {
+	profileName  string
+	regions      []string
+	zones        map[string][]string
+	machineTypes map[string]gardener.CloudProfileMachineType
+}
+
+// Catalog caches the Gardener CloudProfiles and derives the regions, zones and machine types offered by the plans.
+// It implements broker.PlanCatalog and provider.ZonesProvider, the cache is refreshed periodically by Run.
+type Catalog struct {
+	client   dynamic.Interface
+	overlay  Overlay
+	registry *broker.PlanRegistry
+	log      logrus.FieldLogger
+
+	mu        sync.RWMutex
+	providers map[string]providerData
+}
+
+// NewCatalog creates the catalog, the provider of a plan is taken from the registry which knows the built-in and the defined plans
+func NewCatalog(client dynamic.Interface, overlay Overlay, registry *broker.PlanRegistry, log logrus.FieldLogger) *Catalog {
+	return &Catalog{
+		client:    client,
+		overlay:   overlay,
+		registry:  registry,
+		log:       log.WithField("service", "CloudProfileCatalog"),
+		providers: map[string]providerData{},
+	}
+}
+
+// Run refreshes the cache every interval until the context is done, the previous data is kept when a refresh fails
+func (c *Catalog) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := c.Refresh(ctx); err != nil {
+				c.log.Errorf("while refreshing CloudProfiles: %s", err)
+			}
+		}
+	}
+}
+
+// Refresh reads the CloudProfiles from Gardener and replaces the cached data
+func (c *Catalog) Refresh(ctx context.Context) error {
+	list, err := c.client.Resource(gardener.CloudProfileResource).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("while listing CloudProfiles: %w", err)
+	}
+	sort.Slice(list.Items, func(i, j int) bool { return list.Items[i].GetName() < list.Items[j].GetName() })
+
+	providers := map[string]providerData{}
+	for _, item := range list.Items {
+		profile := gardener.CloudProfile{Unstructured: item}
+		spec, err := profile.GetSpec()
+		if err != nil {
+			c.log.Warnf("skipping CloudProfile: %s", err)
+			continue
+		}
+		if name, configured := c.overlay.CloudProfiles[spec.Type]; configured && name != profile.GetName() {
+			continue
+		}
+		if _, exists := providers[spec.Type]; exists {
+			continue
+		}
+		providers[spec.Type] = newProviderData(profile.GetName(), spec)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.providers = providers
+	for providerType, data := range providers {
+		c.log.Infof("using CloudProfile %s for provider %s with %d regions", data.profileName, providerType, len(data.regions))
+	}
+	return nil
+}
+
+func newProviderData(name string, spec gardener.CloudProfileSpec) providerData {
+	data := providerData{
+		profileName:  name,
+		zones:        map[string][]string{},
+		machineTypes: map[string]gardener.CloudProfileMachineType{},
+	}
+	for _, region := range spec.Regions {
+		data.regions = append(data.regions, region.Name)
+		for _, zone := range region.Zones {
+			data.zones[region.Name] = append(data.zones[region.Name], zone.Name)
+		}
+	}
+	for _, machineType := range spec.MachineTypes {
+		if machineType.Usable != nil && !*machineType.Usable {
+			continue
+		}
+		data.machineTypes[machineType.Name] = machineType
+	}
+	return data
+}
+
+// Regions returns the regions of the plan CloudProfile narrowed down by the overlay
+func (c *Catalog) Regions(planName string, euAccessRestricted bool) ([]string, bool) {
+	data, found := c.providerForPlan(planName)
+	if !found {
+		return nil, false
+	}
+	overlay := c.overlay.Plans[planName]
+
+	allowed := data.regions
+	if euAccessRestricted {
+		// EU access regions must be configured explicitly
+		if len(overlay.EUAccessRegions) == 0 {
+			return nil, false
+		}
+		allowed = intersect(allowed, overlay.EUAccessRegions)
+	} else if len(overlay.AllowedRegions) > 0 {
+		allowed = intersect(allowed, overlay.AllowedRegions)
+	}
+
+	denied := toSet(overlay.DeniedRegions)
+	regions := []string{}
+	for _, region := range allowed {
+		if _, isDenied := denied[region]; !isDenied {
+			regions = append(regions, region)
+		}
+	}
+	if len(regions) == 0 {
+		return nil, false
+	}
+	return regions, true
+}
+
+// MachineTypes returns the machine types configured for the plan in the overlay which are usable in the plan CloudProfile
+func (c *Catalog) MachineTypes(planName string) ([]string, map[string]string, bool) {
+	data, found := c.providerForPlan(planName)
+	if !found {
+		return nil, nil, false
+	}
+
+	machineTypes := []string{}
+	display := map[string]string{}
+	for _, name := range c.overlay.Plans[planName].MachineTypes {
+		machineType, usable := data.machineTypes[name]
+		if !usable {
+			continue
+		}
+		machineTypes = append(machineTypes, name)
+		display[name] = fmt.Sprintf("%s (%dvCPU, %dGB RAM)", name, machineType.CPU.Value(), machineType.Memory.Value()/(1<<30))
+	}
+	if len(machineTypes) == 0 {
+		return nil, nil, false
+	}
+	return machineTypes, display, true
+}
+
+// Zones returns the availability zones of the region in the CloudProfile of the given provider type
+func (c *Catalog) Zones(providerType, region string) ([]string, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	zones, found := c.providers[providerType].zones[region]
+	return zones, found
+}
+
+func (c *Catalog) providerForPlan(planName string) (providerData, bool) {
+	provider, found := c.registry.ProviderByName(planName)
+	if !found {
+		return providerData{}, false
+	}
+	providerType, found := providerTypes[provider]
+	if !found {
+		return providerData{}, false
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	data, found := c.providers[providerType]
+	return data, found
+}
+
+// intersect returns the items of the list which are in the filter, in the list order
+func intersect(list, filter []string) []string {
+	set := toSet(filter)
+	result := []string{}
+	for _, item := range list {
+		if _, found := set[item]; found {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+func toSet(items []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(items))
+	for _, item := range items {
+		set[item] = struct{}{}
+	}
+	return set
+}
@@ -0,0 +1,145 @@
+package cloudprofile
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/gardener"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestCatalog(t *testing.T) {
+	// given
+	client := gardener.NewDynamicFakeClient(
+		fixCloudProfile("aws", "aws", map[string][]string{"eu-central-1": {"eu-central-1a", "eu-central-1b"}, "eu-north-1": {"eu-north-1a"}, "us-east-1": {"us-east-1a"}}),
+		fixCloudProfile("aws-legacy", "aws", map[string][]string{"eu-west-1": {"eu-west-1a"}}),
+		fixCloudProfile("az", "azure", map[string][]string{"westeurope": {"1", "2", "3"}}),
+	)
+	overlay := Overlay{
+		CloudProfiles: map[string]string{"aws": "aws"},
+		Plans: map[string]PlanOverlay{
+			broker.AWSPlanName: {
+				DeniedRegions:   []string{"us-east-1"},
+				EUAccessRegions: []string{"eu-central-1"},
+				MachineTypes:    []string{"m5.2xlarge", "m5.xlarge", "m5.unknown", "m4.xlarge"},
+			},
+			broker.AzurePlanName: {AllowedRegions: []string{"northeurope"}},
+			"aws_small":          {MachineTypes: []string{"m5.xlarge"}},
+		},
+	}
+	registry, err := broker.NewPlanRegistry(broker.PlanDefinitions{Plans: []broker.PlanDefinition{{
+		ID:           "d1f1c0de-0000-4000-8000-000000000001",
+		Name:         "aws_small",
+		Provider:     internal.AWS,
+		Regions:      []string{"eu-central-1"},
+		MachineTypes: []broker.MachineTypeDefinition{{Name: "m5.large"}},
+	}}})
+	require.NoError(t, err)
+	catalog := NewCatalog(client, overlay, registry, logrus.New())
+
+	// when
+	err = catalog.Refresh(context.Background())
+
+	// then
+	require.NoError(t, err)
+
+	regions, found := catalog.Regions(broker.AWSPlanName, false)
+	assert.True(t, found)
+	assert.Equal(t, []string{"eu-central-1", "eu-north-1"}, regions)
+
+	regions, found = catalog.Regions(broker.AWSPlanName, true)
+	assert.True(t, found)
+	assert.Equal(t, []string{"eu-central-1"}, regions)
+
+	// no EU access regions configured for the preview plan and no allowed Azure regions in the CloudProfile
+	_, found = catalog.Regions(broker.PreviewPlanName, true)
+	assert.False(t, found)
+	_, found = catalog.Regions(broker.AzurePlanName, false)
+	assert.False(t, found)
+	_, found = catalog.Regions(broker.GCPPlanName, false)
+	assert.False(t, found)
+
+	machines, display, found := catalog.MachineTypes(broker.AWSPlanName)
+	assert.True(t, found)
+	assert.Equal(t, []string{"m5.2xlarge", "m5.xlarge"}, machines)
+	assert.Equal(t, map[string]string{"m5.2xlarge": "m5.2xlarge (8vCPU, 32GB RAM)", "m5.xlarge": "m5.xlarge (4vCPU, 16GB RAM)"}, display)
+	_, _, found = catalog.MachineTypes(broker.AzurePlanName)
+	assert.False(t, found)
+
+	// the provider of the defined plan is taken from its definition
+	regions, found = catalog.Regions("aws_small", false)
+	assert.True(t, found)
+	assert.Equal(t, []string{"eu-central-1", "eu-north-1", "us-east-1"}, regions)
+	machines, _, found = catalog.MachineTypes("aws_small")
+	assert.True(t, found)
+	assert.Equal(t, []string{"m5.xlarge"}, machines)
+
+	zones, found := catalog.Zones("aws", "eu-central-1")
+	assert.True(t, found)
+	assert.Equal(t, []string{"eu-central-1a", "eu-central-1b"}, zones)
+	_, found = catalog.Zones("aws", "eu-west-1")
+	assert.False(t, found)
+}
+
+func TestCatalog_Plans(t *testing.T) {
+	// given
+	client := gardener.NewDynamicFakeClient(fixCloudProfile("gcp", "gcp", map[string][]string{"europe-west4": {"europe-west4-a"}}))
+	registry, err := broker.NewPlanRegistry(broker.PlanDefinitions{Plans: []broker.PlanDefinition{{
+		ID:           "d1f1c0de-0000-4000-8000-000000000002",
+		Name:         "gcp_small",
+		Provider:     internal.GCP,
+		Regions:      []string{"europe-west3"},
+		MachineTypes: []broker.MachineTypeDefinition{{Name: "n2-standard-2"}},
+	}}})
+	require.NoError(t, err)
+	catalog := NewCatalog(client, Overlay{}, registry, logrus.New())
+	require.NoError(t, catalog.Refresh(context.Background()))
+
+	// when
+	plans := broker.Plans(catalog, registry, broker.PlansConfig{}, "", false, false)
+
+	// then
+	gcpRegions := plans[broker.GCPPlanID].Schemas.Instance.Create.Parameters["properties"].(map[string]interface{})["region"].(map[string]interface{})["enum"]
+	assert.Equal(t, []interface{}{"europe-west4"}, gcpRegions)
+	awsRegions := plans[broker.AWSPlanID].Schemas.Instance.Create.Parameters["properties"].(map[string]interface{})["region"].(map[string]interface{})["enum"]
+	assert.Len(t, awsRegions, len(broker.AWSRegions(false)))
+	definedProperties := plans["d1f1c0de-0000-4000-8000-000000000002"].Schemas.Instance.Create.Parameters["properties"].(map[string]interface{})
+	assert.Equal(t, []interface{}{"europe-west4"}, definedProperties["region"].(map[string]interface{})["enum"])
+	assert.Equal(t, []interface{}{"n2-standard-2"}, definedProperties["machineType"].(map[string]interface{})["enum"])
+}
+
+func fixCloudProfile(name, providerType string, zones map[string][]string) *unstructured.Unstructured {
+	names := []string{}
+	for region := range zones {
+		names = append(names, region)
+	}
+	sort.Strings(names)
+	regions := []interface{}{}
+	for _, region := range names {
+		zoneList := []interface{}{}
+		for _, zone := range zones[region] {
+			zoneList = append(zoneList, map[string]interface{}{"name": zone})
+		}
+		regions = append(regions, map[string]interface{}{"name": region, "zones": zoneList})
+	}
+	u := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"type":    providerType,
+			"regions": regions,
+			"machineTypes": []interface{}{
+				map[string]interface{}{"name": "m5.xlarge", "cpu": "4", "memory": "16Gi", "usable": true},
+				map[string]interface{}{"name": "m5.2xlarge", "cpu": "8", "memory": "32Gi"},
+				map[string]interface{}{"name": "m4.xlarge", "cpu": "4", "memory": "16Gi", "usable": false},
+			},
+		},
+	}}
+	u.SetName(name)
+	u.SetGroupVersionKind(gardener.CloudProfileResource.GroupVersion().WithKind("CloudProfile"))
+	return u
+}
@@ -0,0 +1,51 @@
+package cloudprofile
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+type Config struct {
+	// Enabled switches the plan regions, machine types and the AWS and GCP zones to the values derived from Gardener CloudProfiles
+	Enabled         bool          `envconfig:"default=false"`
+	RefreshInterval time.Duration `envconfig:"default=10m"`
+	// OverlayFilePath points to the YAML file which narrows the CloudProfile data down per plan
+	OverlayFilePath string `envconfig:"optional"`
+}
+
+// Overlay selects the CloudProfiles and restricts the regions and machine types offered by the plans
+type Overlay struct {
+	// CloudProfiles maps a provider type (aws, azure, gcp, openstack) to the name of the CloudProfile to use,
+	// the first CloudProfile of the given type is used if it is not set
+	CloudProfiles map[string]string `yaml:"cloudProfiles"`
+	// Plans holds the restrictions per plan name
+	Plans map[string]PlanOverlay `yaml:"plans"`
+}
+
+type PlanOverlay struct {
+	// AllowedRegions limits the regions of the CloudProfile, all regions are allowed if it is empty
+	AllowedRegions []string `yaml:"allowedRegions"`
+	DeniedRegions  []string `yaml:"deniedRegions"`
+	// EUAccessRegions are offered for the EU access restricted platform regions, the built-in list is used if it is empty
+	EUAccessRegions []string `yaml:"euAccessRegions"`
+	// MachineTypes are offered in the given order if they are usable in the CloudProfile, the built-in list is used if it is empty
+	MachineTypes []string `yaml:"machineTypes"`
+}
+
+func ReadOverlayFromFile(filename string) (Overlay, error) {
+	overlay := Overlay{}
+	if filename == "" {
+		return overlay, nil
+	}
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return overlay, fmt.Errorf("while reading %s file with CloudProfile overlay: %w", filename, err)
+	}
+	if err := yaml.UnmarshalStrict(data, &overlay); err != nil {
+		return overlay, fmt.Errorf("while unmarshalling a file with CloudProfile overlay: %w", err)
+	}
+	return overlay, nil
+}
@@ -5,6 +5,8 @@ import (
 	"net/url"
 	"reflect"
 	"strings"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/networking"
 )
 
 const (
@@ -181,7 +183,32 @@ type ProvisioningParametersDTO struct {
 	ShootName   string `json:"shootName,omitempty"`
 	ShootDomain string `json:"shootDomain,omitempty"`
 
-	OIDC *OIDCConfigDTO `json:"oidc,omitempty"`
+	OIDC       *OIDCConfigDTO `json:"oidc,omitempty"`
+	Networking *NetworkingDTO `json:"networking,omitempty"`
+
+	// HyperscalerAccount - the credentials of the customer's own hyperscaler account used instead of the account pool
+	HyperscalerAccount *HyperscalerAccountDTO `json:"hyperscalerAccount,omitempty"`
+}
+
+// NetworkingDTO defines the ranges of the nodes, pods and services of a runtime, they cannot be changed after provisioning
+type NetworkingDTO struct {
+	Nodes    *string `json:"nodes,omitempty"`
+	Pods     *string `json:"pods,omitempty"`
+	Services *string `json:"services,omitempty"`
+}
+
+func (n *NetworkingDTO) Validate() error {
+	return networking.Validate(n.Nodes, n.Pods, n.Services)
+}
+
+// HyperscalerAccountDTO holds the credentials of the hyperscaler account supplied by the customer,
+// the keys are the keys of the Gardener secret for the given hyperscaler, e.g. accessKeyID and secretAccessKey for AWS
+type HyperscalerAccountDTO struct {
+	Credentials map[string]string `json:"credentials"`
+}
+
+func (p ProvisioningParametersDTO) IsCustomerAccount() bool {
+	return p.HyperscalerAccount != nil
 }
 
 type UpdatingParametersDTO struct {
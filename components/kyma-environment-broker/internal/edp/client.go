@@ -10,6 +10,7 @@ import (
 	"time"
 
 	kebError "github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/error"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/tracing"
 
 	"github.com/sirupsen/logrus"
 	"golang.org/x/oauth2/clientcredentials"
@@ -52,6 +53,7 @@ func NewClient(config Config, log logrus.FieldLogger) *Client {
 	}
 	httpClientOAuth := cfg.Client(context.Background())
 	httpClientOAuth.Timeout = 30 * time.Second
+	httpClientOAuth.Transport = tracing.NewTransport(httpClientOAuth.Transport, "edp")
 
 	return &Client{
 		config:     config,
@@ -68,18 +70,18 @@ func (c *Client) metadataTenantURL(name, env string) string {
 	return fmt.Sprintf(metadataTenantTmpl, c.config.AdminURL, c.config.Namespace, name, env)
 }
 
-func (c *Client) CreateDataTenant(data DataTenantPayload) error {
+func (c *Client) CreateDataTenant(ctx context.Context, data DataTenantPayload) error {
 	rawData, err := json.Marshal(data)
 	if err != nil {
 		return fmt.Errorf("while marshaling dataTenant payload: %w", err)
 	}
 
-	return c.post(c.dataTenantURL(), rawData, data.Name)
+	return c.post(ctx, c.dataTenantURL(), rawData, data.Name)
 }
 
-func (c *Client) DeleteDataTenant(name, env string) (err error) {
+func (c *Client) DeleteDataTenant(ctx context.Context, name, env string) (err error) {
 	URL := fmt.Sprintf("%s/%s/%s", c.dataTenantURL(), name, env)
-	request, err := http.NewRequest(http.MethodDelete, URL, nil)
+	request, err := http.NewRequestWithContext(ctx, http.MethodDelete, URL, nil)
 	if err != nil {
 		return fmt.Errorf("while creating delete dataTenant request: %w", err)
 	}
@@ -97,18 +99,18 @@ func (c *Client) DeleteDataTenant(name, env string) (err error) {
 	return c.processResponse(response, true, name)
 }
 
-func (c *Client) CreateMetadataTenant(name, env string, data MetadataTenantPayload) error {
+func (c *Client) CreateMetadataTenant(ctx context.Context, name, env string, data MetadataTenantPayload) error {
 	rawData, err := json.Marshal(data)
 	if err != nil {
 		return fmt.Errorf("while marshaling tenant metadata payload: %w", err)
 	}
 
-	return c.post(c.metadataTenantURL(name, env), rawData, name)
+	return c.post(ctx, c.metadataTenantURL(name, env), rawData, name)
 }
 
-func (c *Client) DeleteMetadataTenant(name, env, key string) (err error) {
+func (c *Client) DeleteMetadataTenant(ctx context.Context, name, env, key string) (err error) {
 	URL := fmt.Sprintf("%s/%s", c.metadataTenantURL(name, env), key)
-	request, err := http.NewRequest(http.MethodDelete, URL, nil)
+	request, err := http.NewRequestWithContext(ctx, http.MethodDelete, URL, nil)
 	if err != nil {
 		return fmt.Errorf("while creating delete metadata request: %w", err)
 	}
@@ -126,9 +128,9 @@ func (c *Client) DeleteMetadataTenant(name, env, key string) (err error) {
 	return c.processResponse(response, true, name)
 }
 
-func (c *Client) GetMetadataTenant(name, env string) (_ []MetadataItem, err error) {
+func (c *Client) GetMetadataTenant(ctx context.Context, name, env string) (_ []MetadataItem, err error) {
 	var metadata []MetadataItem
-	request, err := http.NewRequest(http.MethodGet, c.metadataTenantURL(name, env), nil)
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, c.metadataTenantURL(name, env), nil)
 	if err != nil {
 		return metadata, fmt.Errorf("while creating GET metadata tenant request: %w", err)
 	}
@@ -151,8 +153,8 @@ func (c *Client) GetMetadataTenant(name, env string) (_ []MetadataItem, err erro
 	return metadata, nil
 }
 
-func (c *Client) post(URL string, data []byte, id string) (err error) {
-	request, err := http.NewRequest(http.MethodPost, URL, bytes.NewBuffer(data))
+func (c *Client) post(ctx context.Context, URL string, data []byte, id string) (err error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, URL, bytes.NewBuffer(data))
 	if err != nil {
 		return fmt.Errorf("while creating POST request for %s: %w", URL, err)
 	}
@@ -1,6 +1,7 @@
 package edp
 
 import (
+	"context"
 	"fmt"
 	"sync"
 )
@@ -25,7 +26,7 @@ func NewFakeClient() *FakeClient {
 	}
 }
 
-func (f *FakeClient) CreateDataTenant(data DataTenantPayload) error {
+func (f *FakeClient) CreateDataTenant(ctx context.Context, data DataTenantPayload) error {
 	f.mu.Lock()
 	defer f.mu.Unlock()
 
@@ -48,7 +49,7 @@ func (f *FakeClient) CreateDataTenant(data DataTenantPayload) error {
 	return nil
 }
 
-func (f *FakeClient) CreateMetadataTenant(name, env string, data MetadataTenantPayload) error {
+func (f *FakeClient) CreateMetadataTenant(ctx context.Context, name, env string, data MetadataTenantPayload) error {
 	f.mu.Lock()
 	defer f.mu.Unlock()
 
@@ -73,7 +74,7 @@ func (f *FakeClient) CreateMetadataTenant(name, env string, data MetadataTenantP
 	return nil
 }
 
-func (f *FakeClient) DeleteDataTenant(name, env string) error {
+func (f *FakeClient) DeleteDataTenant(ctx context.Context, name, env string) error {
 	f.mu.Lock()
 	defer f.mu.Unlock()
 
@@ -87,7 +88,7 @@ func (f *FakeClient) DeleteDataTenant(name, env string) error {
 	return nil
 }
 
-func (f *FakeClient) DeleteMetadataTenant(name, env, key string) error {
+func (f *FakeClient) DeleteMetadataTenant(ctx context.Context, name, env, key string) error {
 	f.mu.Lock()
 	defer f.mu.Unlock()
 
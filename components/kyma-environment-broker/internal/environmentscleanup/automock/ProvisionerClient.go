@@ -2,27 +2,31 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
 
 // ProvisionerClient is an autogenerated mock type for the ProvisionerClient type
 type ProvisionerClient struct {
 	mock.Mock
 }
 
-// DeprovisionRuntime provides a mock function with given fields: accountID, runtimeID
-func (_m *ProvisionerClient) DeprovisionRuntime(accountID string, runtimeID string) (string, error) {
-	ret := _m.Called(accountID, runtimeID)
+// DeprovisionRuntime provides a mock function with given fields: ctx, accountID, runtimeID
+func (_m *ProvisionerClient) DeprovisionRuntime(ctx context.Context, accountID string, runtimeID string) (string, error) {
+	ret := _m.Called(ctx, accountID, runtimeID)
 
 	var r0 string
-	if rf, ok := ret.Get(0).(func(string, string) string); ok {
-		r0 = rf(accountID, runtimeID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
+		r0 = rf(ctx, accountID, runtimeID)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(accountID, runtimeID)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, accountID, runtimeID)
 	} else {
 		r1 = ret.Error(1)
 	}
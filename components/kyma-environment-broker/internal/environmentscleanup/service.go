@@ -38,7 +38,7 @@ type BrokerClient interface {
 
 //go:generate mockery --name=ProvisionerClient --output=automock
 type ProvisionerClient interface {
-	DeprovisionRuntime(accountID, runtimeID string) (string, error)
+	DeprovisionRuntime(ctx context.Context, accountID, runtimeID string) (string, error)
 }
 
 type Service struct {
@@ -259,7 +259,7 @@ func (s *Service) cleanUpProvisionerInstances(runtimesToDelete []runtime, kebIns
 }
 
 func (s *Service) triggerRuntimeDeprovisioning(runtime runtime) error {
-	operationID, err := s.provisionerClient.DeprovisionRuntime(runtime.AccountID, runtime.ID)
+	operationID, err := s.provisionerClient.DeprovisionRuntime(context.Background(), runtime.AccountID, runtime.ID)
 	if error2.IsNotFoundError(err) {
 		s.logger.Warnf("Runtime %s does not exists in the provisioner, skipping", runtime.ID)
 		return nil
@@ -41,7 +41,7 @@ func TestService_PerformCleanup(t *testing.T) {
 		bcMock := &mocks.BrokerClient{}
 		bcMock.On("Deprovision", mock.AnythingOfType("internal.Instance")).Return(fixOperationID, nil)
 		pMock := &mocks.ProvisionerClient{}
-		pMock.On("DeprovisionRuntime", fixAccountID, fixRuntimeID3).Return("", nil)
+		pMock.On("DeprovisionRuntime", mock.Anything, fixAccountID, fixRuntimeID3).Return("", nil)
 
 		memoryStorage := storage.NewMemoryStorage()
 		memoryStorage.Instances().Insert(internal.Instance{
@@ -99,7 +99,7 @@ func TestService_PerformCleanup(t *testing.T) {
 		bcMock := &mocks.BrokerClient{}
 		bcMock.On("Deprovision", mock.AnythingOfType("internal.Instance")).Return(fixOperationID, nil)
 		pMock := &mocks.ProvisionerClient{}
-		pMock.On("DeprovisionRuntime", fixAccountID, fixRuntimeID3).Return("", nil)
+		pMock.On("DeprovisionRuntime", mock.Anything, fixAccountID, fixRuntimeID3).Return("", nil)
 
 		memoryStorage := storage.NewMemoryStorage()
 		memoryStorage.Instances().Insert(internal.Instance{
@@ -177,8 +177,8 @@ func TestService_PerformCleanup(t *testing.T) {
 
 		pMock := &mocks.ProvisionerClient{}
 		bcMock.On("Deprovision", mock.AnythingOfType("internal.Instance")).Return("", nil)
-		pMock.On("DeprovisionRuntime", fixAccountID, fixRuntimeID2).Return("", fmt.Errorf("some error"))
-		pMock.On("DeprovisionRuntime", fixAccountID, fixRuntimeID3).Return("", fmt.Errorf("some other error"))
+		pMock.On("DeprovisionRuntime", mock.Anything, fixAccountID, fixRuntimeID2).Return("", fmt.Errorf("some error"))
+		pMock.On("DeprovisionRuntime", mock.Anything, fixAccountID, fixRuntimeID3).Return("", fmt.Errorf("some other error"))
 
 		memoryStorage := storage.NewMemoryStorage()
 		memoryStorage.Instances().Insert(internal.Instance{
@@ -0,0 +1,128 @@
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/tracing"
+)
+
+// Request is sent to the hook with the context of the operation
+type Request struct {
+	Hook            string                             `json:"hook"`
+	Phase           Phase                              `json:"phase"`
+	Stage           string                             `json:"stage"`
+	OperationID     string                             `json:"operationID"`
+	OperationType   internal.OperationType             `json:"operationType"`
+	InstanceID      string                             `json:"instanceID"`
+	RuntimeID       string                             `json:"runtimeID,omitempty"`
+	GlobalAccountID string                             `json:"globalAccountID"`
+	SubAccountID    string                             `json:"subAccountID"`
+	PlanID          string                             `json:"planID"`
+	PlanName        string                             `json:"planName"`
+	Parameters      internal.ProvisioningParametersDTO `json:"parameters"`
+}
+
+// Response returned by the hook, an empty response lets the operation continue unchanged
+type Response struct {
+	// Veto fails the operation, the reason is set in the description of the operation
+	Veto   bool   `json:"veto,omitempty"`
+	Reason string `json:"reason,omitempty"`
+	// ParametersPatch is a JSON merge patch (RFC 7386) applied to the provisioning parameters of the operation
+	ParametersPatch json.RawMessage `json:"parametersPatch,omitempty"`
+	// Overrides are appended to the runtime overrides, only the overrides returned before the overrides are applied in the create_runtime stage take effect
+	Overrides []internal.HookOverride `json:"overrides,omitempty"`
+}
+
+// Caller calls the hook at the given URL
+type Caller interface {
+	Call(ctx context.Context, hookURL string, request Request) (Response, error)
+}
+
+type caller struct {
+	http *httpCaller
+	grpc *grpcCaller
+}
+
+// NewCaller returns the caller which selects the protocol by the scheme of the hook URL
+func NewCaller() Caller {
+	return &caller{
+		http: &httpCaller{httpClient: &http.Client{Transport: tracing.NewTransport(nil, "hook")}},
+		grpc: newGRPCCaller(),
+	}
+}
+
+func (c *caller) Call(ctx context.Context, hookURL string, request Request) (Response, error) {
+	u, err := url.Parse(hookURL)
+	if err != nil {
+		return Response{}, fmt.Errorf("while parsing hook url: %w", err)
+	}
+	switch u.Scheme {
+	case "grpc", "grpcs":
+		return c.grpc.Call(ctx, hookURL, request)
+	default:
+		return c.http.Call(ctx, hookURL, request)
+	}
+}
+
+// httpCaller posts the request as JSON and expects the response as JSON with the 200 status
+type httpCaller struct {
+	httpClient *http.Client
+}
+
+func (c *httpCaller) Call(ctx context.Context, hookURL string, request Request) (response Response, err error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return response, fmt.Errorf("while marshalling hook request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hookURL, bytes.NewBuffer(body))
+	if err != nil {
+		return response, fmt.Errorf("while creating hook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return response, fmt.Errorf("while calling hook %s: %w", hookURL, err)
+	}
+	defer func() {
+		_, _ = io.Copy(ioutil.Discard, io.LimitReader(resp.Body, 4096))
+		if cerr := resp.Body.Close(); err == nil {
+			err = cerr
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 4096))
+		return response, fmt.Errorf("hook %s returned %s status: %s", hookURL, resp.Status, bytes.TrimSpace(msg))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return response, fmt.Errorf("while decoding hook response: %w", err)
+	}
+	return response, nil
+}
+
+// NewHandler returns the HTTP handler of a hook implemented in Go, e.g. a local hook server used in tests
+func NewHandler(hook func(Request) (Response, error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request Request
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, fmt.Sprintf("while decoding request: %s", err), http.StatusBadRequest)
+			return
+		}
+		response, err := hook(request)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+	})
+}
@@ -0,0 +1,168 @@
+package hooks
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/tracing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// GRPCMethod is the unary method called on the gRPC hooks, the request and the response are google.protobuf.Struct messages
+// with the fields of the Request and the Response:
+//
+//	service Hooks {
+//	  rpc Call(google.protobuf.Struct) returns (google.protobuf.Struct);
+//	}
+const GRPCMethod = "/kyma.keb.hooks.v1.Hooks/Call"
+
+// grpcServer is the server of the Hooks service
+type grpcServer interface {
+	Call(context.Context, *structpb.Struct) (*structpb.Struct, error)
+}
+
+// grpcServiceDesc describes the Hooks service, the messages are google.protobuf.Struct so no generated code is needed
+var grpcServiceDesc = grpc.ServiceDesc{
+	ServiceName: "kyma.keb.hooks.v1.Hooks",
+	HandlerType: (*grpcServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Call",
+			Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+				in := &structpb.Struct{}
+				if err := dec(in); err != nil {
+					return nil, err
+				}
+				if interceptor == nil {
+					return srv.(grpcServer).Call(ctx, in)
+				}
+				info := &grpc.UnaryServerInfo{Server: srv, FullMethod: GRPCMethod}
+				return interceptor(ctx, in, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+					return srv.(grpcServer).Call(ctx, req.(*structpb.Struct))
+				})
+			},
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "hooks.proto",
+}
+
+// grpcCaller calls the unary GRPCMethod, grpc:// URLs are called without TLS
+type grpcCaller struct{}
+
+func newGRPCCaller() *grpcCaller {
+	return &grpcCaller{}
+}
+
+func (c *grpcCaller) Call(ctx context.Context, hookURL string, request Request) (Response, error) {
+	var response Response
+	u, err := url.Parse(hookURL)
+	if err != nil {
+		return response, fmt.Errorf("while parsing hook url: %w", err)
+	}
+	transportCredentials := credentials.NewTLS(&tls.Config{})
+	if u.Scheme == "grpc" {
+		transportCredentials = insecure.NewCredentials()
+	}
+	conn, err := grpc.DialContext(ctx, u.Host, grpc.WithTransportCredentials(transportCredentials), grpc.WithUnaryInterceptor(traceUnaryCall))
+	if err != nil {
+		return response, fmt.Errorf("while connecting to hook %s: %w", hookURL, err)
+	}
+	defer conn.Close()
+
+	in, err := toStruct(request)
+	if err != nil {
+		return response, err
+	}
+	out := &structpb.Struct{}
+	if err := conn.Invoke(ctx, GRPCMethod, in, out); err != nil {
+		st := status.Convert(err)
+		return response, fmt.Errorf("hook %s returned gRPC status %s: %s: %w", hookURL, st.Code(), st.Message(), err)
+	}
+	return response, fromStruct(out, &response)
+}
+
+// traceUnaryCall records the call as a client span and propagates the trace context in the request metadata
+func traceUnaryCall(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	ctx, span := tracing.Tracer().Start(ctx, fmt.Sprintf("hook %s", method),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("peer.service", "hook"),
+			attribute.String("rpc.system", "grpc"),
+			attribute.String("rpc.method", method),
+		))
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	for key, value := range carrier {
+		ctx = metadata.AppendToOutgoingContext(ctx, key, value)
+	}
+
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	span.SetAttributes(attribute.String("rpc.grpc.status_code", status.Code(err).String()))
+	tracing.End(span, err)
+	return err
+}
+
+type grpcHook func(Request) (Response, error)
+
+func (h grpcHook) Call(_ context.Context, in *structpb.Struct) (*structpb.Struct, error) {
+	var request Request
+	if err := fromStruct(in, &request); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	response, err := h(request)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, err.Error())
+	}
+	out, err := toStruct(response)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return out, nil
+}
+
+// RegisterGRPCHook registers the hook implemented in Go as the Hooks service of the gRPC server
+func RegisterGRPCHook(server *grpc.Server, hook func(Request) (Response, error)) {
+	server.RegisterService(&grpcServiceDesc, grpcHook(hook))
+}
+
+func toStruct(obj interface{}) (*structpb.Struct, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, fmt.Errorf("while marshalling %T: %w", obj, err)
+	}
+	fields := map[string]interface{}{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("while unmarshalling %T: %w", obj, err)
+	}
+	message, err := structpb.NewStruct(fields)
+	if err != nil {
+		return nil, fmt.Errorf("while converting %T to struct: %w", obj, err)
+	}
+	return message, nil
+}
+
+func fromStruct(message *structpb.Struct, obj interface{}) error {
+	data, err := json.Marshal(message.AsMap())
+	if err != nil {
+		return fmt.Errorf("while marshalling struct: %w", err)
+	}
+	if err := json.Unmarshal(data, obj); err != nil {
+		return fmt.Errorf("while unmarshalling struct to %T: %w", obj, err)
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+package hooks
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+
+	"gopkg.in/yaml.v2"
+)
+
+type Phase string
+
+const (
+	// PhasePre hooks are called before the steps of the stage
+	PhasePre Phase = "pre"
+	// PhasePost hooks are called after the steps of the stage
+	PhasePost Phase = "post"
+)
+
+// FailurePolicy defines what happens with the operation when the hook cannot be called or returns an error
+type FailurePolicy string
+
+const (
+	FailurePolicyFail   FailurePolicy = "fail"
+	FailurePolicyIgnore FailurePolicy = "ignore"
+	FailurePolicyRetry  FailurePolicy = "retry"
+)
+
+const (
+	defaultTimeout          = 10 * time.Second
+	defaultRetryInterval    = 10 * time.Second
+	defaultMaxRetryDuration = 10 * time.Minute
+)
+
+type Config struct {
+	// FilePath points to the YAML file with the hooks, no hooks are called when it is not set
+	FilePath string `envconfig:"optional"`
+}
+
+// Hook is an external service called by KEB in a stage of the operations of the given type.
+// The URL scheme selects the protocol: http and https for JSON over HTTP, grpc and grpcs for the gRPC Hooks service.
+type Hook struct {
+	Name          string                 `yaml:"name"`
+	OperationType internal.OperationType `yaml:"operation"`
+	Stage         string                 `yaml:"stage"`
+	Phase         Phase                  `yaml:"phase"`
+	URL           string                 `yaml:"url"`
+	// Plans limits the hook to the operations of the given plan names, all plans when empty
+	Plans         []string      `yaml:"plans"`
+	Timeout       time.Duration `yaml:"timeout"`
+	FailurePolicy FailurePolicy `yaml:"failurePolicy"`
+	// RetryInterval and MaxRetryDuration are used with the retry failure policy, the operation fails when the hook fails longer than MaxRetryDuration
+	RetryInterval    time.Duration `yaml:"retryInterval"`
+	MaxRetryDuration time.Duration `yaml:"maxRetryDuration"`
+}
+
+// StepName is the name of the step which calls the hook, it can be used in the retry policies and to skip the hook manually
+func (h Hook) StepName() string {
+	return fmt.Sprintf("Hook_%s", h.Name)
+}
+
+func (h *Hook) setDefaults() {
+	if h.Phase == "" {
+		h.Phase = PhasePre
+	}
+	if h.Timeout == 0 {
+		h.Timeout = defaultTimeout
+	}
+	if h.FailurePolicy == "" {
+		h.FailurePolicy = FailurePolicyFail
+	}
+	if h.RetryInterval == 0 {
+		h.RetryInterval = defaultRetryInterval
+	}
+	if h.MaxRetryDuration == 0 {
+		h.MaxRetryDuration = defaultMaxRetryDuration
+	}
+}
+
+func (h Hook) Validate() error {
+	if h.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	switch h.OperationType {
+	case internal.OperationTypeProvision, internal.OperationTypeDeprovision, internal.OperationTypeUpdate:
+	default:
+		return fmt.Errorf("unsupported operation %q, supported operations: provision, deprovision, update", h.OperationType)
+	}
+	if h.Stage == "" {
+		return fmt.Errorf("stage is required")
+	}
+	if h.Phase != PhasePre && h.Phase != PhasePost {
+		return fmt.Errorf("unsupported phase %q, supported phases: pre, post", h.Phase)
+	}
+	switch h.FailurePolicy {
+	case FailurePolicyFail, FailurePolicyIgnore, FailurePolicyRetry:
+	default:
+		return fmt.Errorf("unsupported failure policy %q, supported policies: fail, ignore, retry", h.FailurePolicy)
+	}
+	u, err := url.Parse(h.URL)
+	if err != nil {
+		return fmt.Errorf("invalid url: %w", err)
+	}
+	switch u.Scheme {
+	case "http", "https", "grpc", "grpcs":
+	default:
+		return fmt.Errorf("unsupported url scheme %q, supported schemes: http, https, grpc, grpcs", u.Scheme)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("url host is required")
+	}
+	if h.Timeout < 0 || h.RetryInterval < 0 || h.MaxRetryDuration < 0 {
+		return fmt.Errorf("durations must not be negative")
+	}
+	return nil
+}
+
+// appliesTo returns true if the hook is configured for the plan of the operation
+func (h Hook) appliesTo(operation internal.Operation, planNames map[string]string) bool {
+	if len(h.Plans) == 0 {
+		return true
+	}
+	planName := planNames[operation.ProvisioningParameters.PlanID]
+	for _, plan := range h.Plans {
+		if plan == planName {
+			return true
+		}
+	}
+	return false
+}
+
+func Parse(data []byte) ([]Hook, error) {
+	var hooks []Hook
+	if err := yaml.UnmarshalStrict(data, &hooks); err != nil {
+		return nil, fmt.Errorf("while unmarshalling hooks: %w", err)
+	}
+	names := map[string]struct{}{}
+	for i := range hooks {
+		hooks[i].setDefaults()
+		if err := hooks[i].Validate(); err != nil {
+			return nil, fmt.Errorf("while validating hook %q: %w", hooks[i].Name, err)
+		}
+		if _, found := names[hooks[i].Name]; found {
+			return nil, fmt.Errorf("hook %q is defined more than once", hooks[i].Name)
+		}
+		names[hooks[i].Name] = struct{}{}
+	}
+	return hooks, nil
+}
+
+func ReadFromFile(filename string) ([]Hook, error) {
+	if filename == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("while reading %s file with hooks: %w", filename, err)
+	}
+	return Parse(data)
+}
@@ -0,0 +1,62 @@
+package hooks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	t.Run("should set defaults", func(t *testing.T) {
+		// when
+		hooks, err := Parse([]byte(`
+- name: quota-check
+  operation: provision
+  stage: start
+  url: http://quota-check.kcp-system:8080/hook
+- name: overrides
+  operation: provision
+  stage: create_runtime
+  phase: post
+  url: grpc://overrides.kcp-system:9090
+  plans: [aws]
+  timeout: 3s
+  failurePolicy: retry
+`))
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, hooks, 2)
+		assert.Equal(t, PhasePre, hooks[0].Phase)
+		assert.Equal(t, FailurePolicyFail, hooks[0].FailurePolicy)
+		assert.Equal(t, defaultTimeout, hooks[0].Timeout)
+		assert.Equal(t, internal.OperationTypeProvision, hooks[1].OperationType)
+		assert.Equal(t, 3*time.Second, hooks[1].Timeout)
+		assert.Equal(t, defaultMaxRetryDuration, hooks[1].MaxRetryDuration)
+		assert.Equal(t, "Hook_overrides", hooks[1].StepName())
+	})
+
+	for name, data := range map[string]string{
+		"unknown field":          "- {name: a, operation: provision, stage: start, url: 'http://a', retries: 3}",
+		"unsupported operation":  "- {name: a, operation: upgradeKyma, stage: start, url: 'http://a'}",
+		"unsupported phase":      "- {name: a, operation: provision, stage: start, phase: during, url: 'http://a'}",
+		"unsupported scheme":     "- {name: a, operation: provision, stage: start, url: 'ftp://a'}",
+		"unsupported policy":     "- {name: a, operation: provision, stage: start, url: 'http://a', failurePolicy: skip}",
+		"duplicated name":        "- {name: a, operation: provision, stage: start, url: 'http://a'}\n- {name: a, operation: update, stage: cluster, url: 'http://a'}",
+		"missing stage":          "- {name: a, operation: provision, url: 'http://a'}",
+		"missing url host":       "- {name: a, operation: provision, stage: start, url: 'http://'}",
+		"negative retry timeout": "- {name: a, operation: provision, stage: start, url: 'http://a', maxRetryDuration: -1s}",
+	} {
+		t.Run("should reject "+name, func(t *testing.T) {
+			// when
+			_, err := Parse([]byte(data))
+
+			// then
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,236 @@
+package hooks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/retrypolicy"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/tracing"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/sirupsen/logrus"
+)
+
+// StepAdder is implemented by the pipelines
+type StepAdder interface {
+	AddStep(stageName string, step process.Step, cnd process.StepCondition) error
+	InsertStep(stageName string, step process.Step, cnd process.StepCondition) error
+	// InputCreatedBefore returns true if the InputCreator is created from the parameters in a stage before the given one
+	InputCreatedBefore(stageName string) bool
+}
+
+// Registrar adds the configured hooks as steps of the staged managers
+type Registrar struct {
+	hooks      []Hook
+	operations storage.Operations
+	instances  storage.Instances
+	caller     Caller
+	planNames  map[string]string
+}
+
+func NewRegistrar(hooks []Hook, operations storage.Operations, instances storage.Instances, caller Caller, planNames map[string]string) *Registrar {
+	return &Registrar{
+		hooks:      hooks,
+		operations: operations,
+		instances:  instances,
+		caller:     caller,
+		planNames:  planNames,
+	}
+}
+
+// Register adds the hooks of the operation type to the manager, it must be called after all steps are added.
+// The pre hooks are run before the steps of the stage in the configured order, the post hooks after them.
+func (r *Registrar) Register(manager StepAdder, operationType internal.OperationType) error {
+	for i := len(r.hooks) - 1; i >= 0; i-- {
+		hook := r.hooks[i]
+		if hook.OperationType != operationType || hook.Phase != PhasePre {
+			continue
+		}
+		if err := manager.InsertStep(hook.Stage, r.newStep(hook, manager), r.condition(hook)); err != nil {
+			return fmt.Errorf("while adding hook %s: %w", hook.Name, err)
+		}
+	}
+	for _, hook := range r.hooks {
+		if hook.OperationType != operationType || hook.Phase != PhasePost {
+			continue
+		}
+		if err := manager.AddStep(hook.Stage, r.newStep(hook, manager), r.condition(hook)); err != nil {
+			return fmt.Errorf("while adding hook %s: %w", hook.Name, err)
+		}
+	}
+	return nil
+}
+
+func (r *Registrar) newStep(hook Hook, manager StepAdder) *Step {
+	step := NewStep(hook, r.operations, r.instances, r.caller, r.planNames)
+	// the Provisioner input is created from the parameters once, the later patches would have no effect
+	step.patchesParameters = step.patchesParameters && !manager.InputCreatedBefore(hook.Stage)
+	return step
+}
+
+func (r *Registrar) condition(hook Hook) process.StepCondition {
+	return func(operation internal.Operation) bool {
+		return hook.appliesTo(operation, r.planNames)
+	}
+}
+
+// Step calls the hook and applies its response to the operation
+type Step struct {
+	hook             Hook
+	caller           Caller
+	planNames        map[string]string
+	operationManager *process.OperationManager
+	instances        storage.Instances
+	// patchesParameters is false for the hooks which run after the parameters are used, their patches are rejected
+	patchesParameters bool
+}
+
+func NewStep(hook Hook, operations storage.Operations, instances storage.Instances, caller Caller, planNames map[string]string) *Step {
+	return &Step{
+		hook:              hook,
+		caller:            caller,
+		planNames:         planNames,
+		operationManager:  process.NewOperationManager(operations),
+		instances:         instances,
+		patchesParameters: hook.Phase == PhasePre,
+	}
+}
+
+func (s *Step) Name() string {
+	return s.hook.StepName()
+}
+
+func (s *Step) UseRetryPolicies(policies *retrypolicy.Registry) {
+	s.operationManager.UseRetryPolicies(policies, s.Name())
+}
+
+func (s *Step) Run(operation internal.Operation, log logrus.FieldLogger) (internal.Operation, time.Duration, error) {
+	// the staged manager runs all steps of an unfinished stage again, e.g. when a polling step is retried
+	for _, completed := range operation.CompletedHooks {
+		if completed == s.Name() {
+			log.Infof("Hook %s was already called for the operation, skipping", s.hook.Name)
+			return operation, 0, nil
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(tracing.StepContext(operation), s.hook.Timeout)
+	defer cancel()
+	response, err := s.caller.Call(ctx, s.hook.URL, s.request(operation))
+	if err != nil {
+		return s.handleFailure(operation, fmt.Sprintf("calling hook %s failed", s.hook.Name), err, log)
+	}
+
+	if response.Veto {
+		log.Infof("Hook %s vetoed the operation: %s", s.hook.Name, response.Reason)
+		return s.operationManager.OperationFailed(operation, fmt.Sprintf("operation vetoed by hook %s: %s", s.hook.Name, response.Reason), nil, log)
+	}
+	if len(response.ParametersPatch) > 0 && !s.patchesParameters {
+		err := fmt.Errorf("the parameters can be patched only by the pre hooks which run before the runtime input is created")
+		return s.handleFailure(operation, fmt.Sprintf("parameters patch returned by hook %s cannot be applied", s.hook.Name), err, log)
+	}
+	parameters, err := patchParameters(operation.ProvisioningParameters.Parameters, response.ParametersPatch)
+	if err != nil {
+		return s.handleFailure(operation, fmt.Sprintf("invalid parameters patch returned by hook %s", s.hook.Name), err, log)
+	}
+	if len(response.ParametersPatch) > 0 || len(response.Overrides) > 0 {
+		log.Infof("Applying the parameters patch and %d overrides returned by hook %s", len(response.Overrides), s.hook.Name)
+	}
+	// the instance parameters are used by the later operations of the instance, e.g. updates and unsuspension
+	if err := s.patchInstanceParameters(operation.InstanceID, response.ParametersPatch); err != nil {
+		log.Errorf("unable to patch the instance parameters: %s", err)
+		return s.operationManager.RetryOperation(operation, "error while patching the instance parameters", err, 10*time.Second, time.Minute, log)
+	}
+	return s.complete(operation, func(op *internal.Operation) {
+		op.ProvisioningParameters.Parameters = parameters
+		op.HookOverrides = append(op.HookOverrides, response.Overrides...)
+	}, log)
+}
+
+// complete applies the changes and records the hook as completed, so it is not called again for the operation
+func (s *Step) complete(operation internal.Operation, apply func(op *internal.Operation), log logrus.FieldLogger) (internal.Operation, time.Duration, error) {
+	return s.operationManager.UpdateOperation(operation, func(op *internal.Operation) {
+		apply(op)
+		op.CompletedHooks = append(op.CompletedHooks, s.Name())
+	}, log)
+}
+
+func (s *Step) patchInstanceParameters(instanceID string, patch json.RawMessage) error {
+	if len(patch) == 0 {
+		return nil
+	}
+	instance, err := s.instances.GetByID(instanceID)
+	if err != nil {
+		return fmt.Errorf("while getting instance: %w", err)
+	}
+	instance.Parameters.Parameters, err = patchParameters(instance.Parameters.Parameters, patch)
+	if err != nil {
+		return err
+	}
+	if _, err := s.instances.Update(*instance); err != nil {
+		return fmt.Errorf("while updating instance: %w", err)
+	}
+	return nil
+}
+
+func (s *Step) handleFailure(operation internal.Operation, msg string, err error, log logrus.FieldLogger) (internal.Operation, time.Duration, error) {
+	switch s.hook.FailurePolicy {
+	case FailurePolicyIgnore:
+		log.Warnf("%s, ignoring the failure: %s", msg, err)
+		operation.EventErrorf(err, "%s, the failure is ignored", msg)
+		return s.complete(operation, func(*internal.Operation) {}, log)
+	case FailurePolicyRetry:
+		log.Warnf("%s: %s", msg, err)
+		return s.operationManager.RetryOperation(operation, msg, err, s.hook.RetryInterval, s.hook.MaxRetryDuration, log)
+	default:
+		log.Errorf("%s: %s", msg, err)
+		return s.operationManager.OperationFailed(operation, msg, err, log)
+	}
+}
+
+func (s *Step) request(operation internal.Operation) Request {
+	parameters := operation.ProvisioningParameters.Parameters
+	// the credentials are not sent to the hooks
+	parameters.Kubeconfig = ""
+	parameters.HyperscalerAccount = nil
+	return Request{
+		Hook:            s.hook.Name,
+		Phase:           s.hook.Phase,
+		Stage:           s.hook.Stage,
+		OperationID:     operation.ID,
+		OperationType:   operation.Type,
+		InstanceID:      operation.InstanceID,
+		RuntimeID:       operation.RuntimeID,
+		GlobalAccountID: operation.ProvisioningParameters.ErsContext.GlobalAccountID,
+		SubAccountID:    operation.ProvisioningParameters.ErsContext.SubAccountID,
+		PlanID:          operation.ProvisioningParameters.PlanID,
+		PlanName:        s.planNames[operation.ProvisioningParameters.PlanID],
+		Parameters:      parameters,
+	}
+}
+
+func patchParameters(parameters internal.ProvisioningParametersDTO, patch json.RawMessage) (internal.ProvisioningParametersDTO, error) {
+	if len(patch) == 0 {
+		return parameters, nil
+	}
+	original, err := json.Marshal(parameters)
+	if err != nil {
+		return parameters, fmt.Errorf("while marshalling parameters: %w", err)
+	}
+	patched, err := jsonpatch.MergePatch(original, patch)
+	if err != nil {
+		return parameters, fmt.Errorf("while applying merge patch: %w", err)
+	}
+	var result internal.ProvisioningParametersDTO
+	if err := json.Unmarshal(patched, &result); err != nil {
+		return parameters, fmt.Errorf("while unmarshalling patched parameters: %w", err)
+	}
+	// the credentials are not sent to the hooks, so the patch cannot remove or replace them
+	result.Kubeconfig = parameters.Kubeconfig
+	result.HyperscalerAccount = parameters.HyperscalerAccount
+	return result, nil
+}
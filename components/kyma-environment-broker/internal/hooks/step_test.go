@@ -0,0 +1,345 @@
+package hooks
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/fixture"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/pipeline"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+
+	"github.com/pivotal-cf/brokerapi/v8/domain"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+const awsPlanID = "361c511f-f939-4621-b228-d0fb79a1fe15"
+
+var planNames = map[string]string{awsPlanID: "aws"}
+
+func TestStep(t *testing.T) {
+	var received Request
+	hook := func(request Request) (Response, error) {
+		received = request
+		switch request.Hook {
+		case "veto":
+			return Response{Veto: true, Reason: "quota exceeded"}, nil
+		case "broken":
+			return Response{}, fmt.Errorf("database unavailable")
+		case "credentials":
+			return Response{ParametersPatch: []byte(`{"kubeconfig": null, "hyperscalerAccount": null, "name": "patched"}`)}, nil
+		default:
+			return Response{
+				ParametersPatch: []byte(`{"machineType": "m5.2xlarge", "autoScalerMax": 20}`),
+				Overrides:       []internal.HookOverride{{Component: "monitoring", Key: "retention", Value: "7d"}},
+			}, nil
+		}
+	}
+	httpServer := httptest.NewServer(NewHandler(hook))
+	defer httpServer.Close()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	grpcServer := grpc.NewServer()
+	RegisterGRPCHook(grpcServer, hook)
+	go grpcServer.Serve(listener)
+	defer grpcServer.Stop()
+	servers := map[string]string{
+		"http": httpServer.URL,
+		"grpc": "grpc://" + listener.Addr().String(),
+	}
+
+	for protocol, url := range servers {
+		t.Run(protocol, func(t *testing.T) {
+			t.Run("should apply patch and overrides", func(t *testing.T) {
+				// given
+				db := storage.NewMemoryStorage()
+				operation := fixOperation(t, db, "op-patch")
+				step := NewStep(fixHook("patch", url, FailurePolicyFail), db.Operations(), db.Instances(), NewCaller(), planNames)
+
+				// when
+				operation, when, err := step.Run(operation, logrus.New())
+
+				// then
+				require.NoError(t, err)
+				assert.Zero(t, when)
+				assert.Equal(t, "op-patch", received.OperationID)
+				assert.Equal(t, "aws", received.PlanName)
+				assert.Equal(t, PhasePre, received.Phase)
+				assert.Empty(t, received.Parameters.Kubeconfig)
+				assert.Equal(t, "m5.2xlarge", *operation.ProvisioningParameters.Parameters.MachineType)
+				assert.Equal(t, 20, *operation.ProvisioningParameters.Parameters.AutoScalerMax)
+				assert.Equal(t, fixture.Region, *operation.ProvisioningParameters.Parameters.Region)
+				stored, err := db.Operations().GetOperationByID("op-patch")
+				require.NoError(t, err)
+				assert.Equal(t, []internal.HookOverride{{Component: "monitoring", Key: "retention", Value: "7d"}}, stored.HookOverrides)
+				assert.Equal(t, "m5.2xlarge", *stored.ProvisioningParameters.Parameters.MachineType)
+				instance, err := db.Instances().GetByID("instance-id")
+				require.NoError(t, err)
+				assert.Equal(t, "m5.2xlarge", *instance.Parameters.Parameters.MachineType)
+				assert.Equal(t, 20, *instance.Parameters.Parameters.AutoScalerMax)
+
+				// when - the stage is run again
+				received = Request{}
+				operation, when, err = step.Run(operation, logrus.New())
+
+				// then - the hook is not called again
+				require.NoError(t, err)
+				assert.Zero(t, when)
+				assert.Empty(t, received.OperationID)
+				assert.Len(t, operation.HookOverrides, 1)
+			})
+
+			t.Run("should keep credentials removed by patch", func(t *testing.T) {
+				// given
+				db := storage.NewMemoryStorage()
+				operation := fixOperation(t, db, "op-credentials")
+				step := NewStep(fixHook("credentials", url, FailurePolicyFail), db.Operations(), db.Instances(), NewCaller(), planNames)
+
+				// when
+				operation, _, err := step.Run(operation, logrus.New())
+
+				// then
+				require.NoError(t, err)
+				assert.Equal(t, "patched", operation.ProvisioningParameters.Parameters.Name)
+				assert.Equal(t, "secret", operation.ProvisioningParameters.Parameters.Kubeconfig)
+				assert.Equal(t, fixCredentials(), operation.ProvisioningParameters.Parameters.HyperscalerAccount)
+				instance, err := db.Instances().GetByID("instance-id")
+				require.NoError(t, err)
+				assert.Equal(t, "patched", instance.Parameters.Parameters.Name)
+				assert.Equal(t, "secret", instance.Parameters.Parameters.Kubeconfig)
+				assert.Equal(t, fixCredentials(), instance.Parameters.Parameters.HyperscalerAccount)
+			})
+
+			t.Run("should reject patch of post hook", func(t *testing.T) {
+				// given
+				db := storage.NewMemoryStorage()
+				operation := fixOperation(t, db, "op-post")
+				hook := fixHook("patch", url, FailurePolicyFail)
+				hook.Phase = PhasePost
+				step := NewStep(hook, db.Operations(), db.Instances(), NewCaller(), planNames)
+
+				// when
+				operation, _, err := step.Run(operation, logrus.New())
+
+				// then
+				assert.Error(t, err)
+				assert.Equal(t, domain.Failed, operation.State)
+				assert.Equal(t, "parameters patch returned by hook patch cannot be applied", operation.Description)
+				instance, err := db.Instances().GetByID("instance-id")
+				require.NoError(t, err)
+				assert.NotEqual(t, "m5.2xlarge", *instance.Parameters.Parameters.MachineType)
+			})
+
+			t.Run("should fail operation on veto", func(t *testing.T) {
+				// given
+				db := storage.NewMemoryStorage()
+				operation := fixOperation(t, db, "op-veto")
+				step := NewStep(fixHook("veto", url, FailurePolicyIgnore), db.Operations(), db.Instances(), NewCaller(), planNames)
+
+				// when
+				operation, _, err := step.Run(operation, logrus.New())
+
+				// then
+				assert.Error(t, err)
+				assert.Equal(t, domain.Failed, operation.State)
+				assert.Equal(t, "operation vetoed by hook veto: quota exceeded", operation.Description)
+			})
+
+			t.Run("should apply failure policy", func(t *testing.T) {
+				for policy, assertResult := range map[FailurePolicy]func(t *testing.T, operation internal.Operation, when time.Duration, err error){
+					FailurePolicyFail: func(t *testing.T, operation internal.Operation, when time.Duration, err error) {
+						assert.Error(t, err)
+						assert.Equal(t, domain.Failed, operation.State)
+					},
+					FailurePolicyIgnore: func(t *testing.T, operation internal.Operation, when time.Duration, err error) {
+						assert.NoError(t, err)
+						assert.Zero(t, when)
+						assert.Equal(t, domain.InProgress, operation.State)
+					},
+					FailurePolicyRetry: func(t *testing.T, operation internal.Operation, when time.Duration, err error) {
+						assert.NoError(t, err)
+						assert.Equal(t, defaultRetryInterval, when)
+					},
+				} {
+					// given
+					db := storage.NewMemoryStorage()
+					operation := fixOperation(t, db, "op-"+string(policy))
+					step := NewStep(fixHook("broken", url, policy), db.Operations(), db.Instances(), NewCaller(), planNames)
+
+					// when
+					operation, when, err := step.Run(operation, logrus.New())
+
+					// then
+					assertResult(t, operation, when, err)
+				}
+			})
+		})
+	}
+}
+
+func TestStep_Timeout(t *testing.T) {
+	// given
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+	db := storage.NewMemoryStorage()
+	operation := fixOperation(t, db, "op-timeout")
+	hook := fixHook("slow", server.URL, FailurePolicyFail)
+	hook.Timeout = 10 * time.Millisecond
+	step := NewStep(hook, db.Operations(), db.Instances(), NewCaller(), planNames)
+
+	// when
+	operation, _, err := step.Run(operation, logrus.New())
+
+	// then
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, domain.Failed, operation.State)
+}
+
+func TestRegistrar(t *testing.T) {
+	// given
+	hooks := []Hook{
+		fixHook("first", "http://first", FailurePolicyFail),
+		fixHook("second", "http://second", FailurePolicyFail),
+		fixHook("after", "http://after", FailurePolicyFail),
+		fixHook("update", "http://update", FailurePolicyFail),
+	}
+	hooks[2].Phase = PhasePost
+	hooks[3].OperationType = internal.OperationTypeUpdate
+	hooks[1].Plans = []string{"azure"}
+	recorder := &stepRecorder{steps: []string{"Starting"}}
+
+	// when
+	err := NewRegistrar(hooks, nil, nil, nil, planNames).Register(recorder, internal.OperationTypeProvision)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Hook_first", "Hook_second", "Starting", "Hook_after"}, recorder.steps)
+	operation := internal.Operation{ProvisioningParameters: internal.ProvisioningParameters{PlanID: awsPlanID}}
+	assert.True(t, recorder.conditions["Hook_first"](operation))
+	assert.False(t, recorder.conditions["Hook_second"](operation))
+
+	t.Run("should fail for unknown stage", func(t *testing.T) {
+		// given
+		p := &pipeline.Pipeline{OperationType: internal.OperationTypeProvision, Stages: []*pipeline.Stage{{Name: "start"}}}
+		hook := fixHook("unknown", "http://unknown", FailurePolicyFail)
+		hook.Stage = "not_defined"
+
+		// when
+		err := NewRegistrar([]Hook{hook}, nil, nil, nil, planNames).Register(p, internal.OperationTypeProvision)
+
+		// then
+		assert.EqualError(t, err, "while adding hook unknown: stage not_defined not defined in the provision pipeline")
+	})
+
+	t.Run("should not let hooks patch parameters after input is created", func(t *testing.T) {
+		// given
+		p := &pipeline.Pipeline{
+			OperationType: internal.OperationTypeProvision,
+			Stages: []*pipeline.Stage{
+				{Name: "start"},
+				{Name: "create_runtime", Steps: []pipeline.Step{{Step: namedStep("Provision_Initialization"), Input: pipeline.CreatesInput}}},
+				{Name: "check_kyma"},
+			},
+		}
+		hooks := []Hook{
+			fixHook("start", "http://start", FailurePolicyFail),
+			fixHook("create-runtime", "http://create-runtime", FailurePolicyFail),
+			fixHook("create-runtime-post", "http://create-runtime-post", FailurePolicyFail),
+			fixHook("check-kyma", "http://check-kyma", FailurePolicyFail),
+		}
+		hooks[1].Stage = "create_runtime"
+		hooks[2].Stage = "create_runtime"
+		hooks[2].Phase = PhasePost
+		hooks[3].Stage = "check_kyma"
+
+		// when
+		err := NewRegistrar(hooks, nil, nil, nil, planNames).Register(p, internal.OperationTypeProvision)
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, p.Stages[0].Steps[0].Step.(*Step).patchesParameters)
+		assert.True(t, p.Stages[1].Steps[0].Step.(*Step).patchesParameters)
+		assert.False(t, p.Stages[1].Steps[2].Step.(*Step).patchesParameters)
+		assert.False(t, p.Stages[2].Steps[0].Step.(*Step).patchesParameters)
+	})
+}
+
+type namedStep string
+
+func (s namedStep) Name() string {
+	return string(s)
+}
+
+// stepRecorder records the names of the steps of a stage in the order they are run
+type stepRecorder struct {
+	steps      []string
+	conditions map[string]process.StepCondition
+}
+
+func (r *stepRecorder) AddStep(_ string, step process.Step, cnd process.StepCondition) error {
+	r.steps = append(r.steps, step.Name())
+	r.record(step, cnd)
+	return nil
+}
+
+func (r *stepRecorder) InsertStep(_ string, step process.Step, cnd process.StepCondition) error {
+	r.steps = append([]string{step.Name()}, r.steps...)
+	r.record(step, cnd)
+	return nil
+}
+
+func (r *stepRecorder) InputCreatedBefore(string) bool {
+	return false
+}
+
+func (r *stepRecorder) record(step process.Step, cnd process.StepCondition) {
+	if r.conditions == nil {
+		r.conditions = map[string]process.StepCondition{}
+	}
+	r.conditions[step.Name()] = cnd
+}
+
+func fixHook(name, url string, policy FailurePolicy) Hook {
+	hook := Hook{
+		Name:          name,
+		OperationType: internal.OperationTypeProvision,
+		Stage:         "start",
+		URL:           url,
+		FailurePolicy: policy,
+	}
+	hook.setDefaults()
+	return hook
+}
+
+func fixOperation(t *testing.T, db storage.BrokerStorage, id string) internal.Operation {
+	operation := fixture.FixProvisioningOperation(id, "instance-id")
+	operation.ProvisioningParameters.PlanID = awsPlanID
+	operation.ProvisioningParameters.Parameters.Kubeconfig = "secret"
+	operation.ProvisioningParameters.Parameters.HyperscalerAccount = fixCredentials()
+	operation.State = domain.InProgress
+	operation.UpdatedAt = time.Now()
+	require.NoError(t, db.Operations().InsertOperation(operation))
+	instance := fixture.FixInstance("instance-id")
+	instance.Parameters = operation.ProvisioningParameters
+	require.NoError(t, db.Instances().Insert(instance))
+	stored, err := db.Operations().GetOperationByID(id)
+	require.NoError(t, err)
+	return *stored
+}
+
+func fixCredentials() *internal.HyperscalerAccountDTO {
+	return &internal.HyperscalerAccountDTO{Credentials: map[string]string{"accessKeyID": "id", "secretAccessKey": "secret"}}
+}
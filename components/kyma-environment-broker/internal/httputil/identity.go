@@ -0,0 +1,40 @@
+package httputil
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+const UnknownIdentity = "unknown"
+
+// RequestIdentity returns the identity of the caller from the bearer token of the request, the email claim is preferred over the subject.
+// The token is not verified, KEB relies on the Istio RequestAuthentication which rejects the requests with invalid tokens.
+func RequestIdentity(r *http.Request) string {
+	token := strings.TrimSpace(strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "))
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return UnknownIdentity
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return UnknownIdentity
+	}
+	claims := struct {
+		Email   string `json:"email"`
+		Subject string `json:"sub"`
+	}{}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return UnknownIdentity
+	}
+
+	switch {
+	case claims.Email != "":
+		return claims.Email
+	case claims.Subject != "":
+		return claims.Subject
+	default:
+		return UnknownIdentity
+	}
+}
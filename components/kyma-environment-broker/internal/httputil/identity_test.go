@@ -0,0 +1,58 @@
+package httputil_test
+
+import (
+	"encoding/base64"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/httputil"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestIdentity(t *testing.T) {
+	for name, tc := range map[string]struct {
+		authorization string
+		expected      string
+	}{
+		"email": {
+			authorization: "Bearer " + fixToken(`{"sub":"1234","email":"admin@example.com"}`),
+			expected:      "admin@example.com",
+		},
+		"subject": {
+			authorization: "Bearer " + fixToken(`{"sub":"1234"}`),
+			expected:      "1234",
+		},
+		"no identity claims": {
+			authorization: "Bearer " + fixToken(`{"groups":["runtimeAdmin"]}`),
+			expected:      httputil.UnknownIdentity,
+		},
+		"malformed token": {
+			authorization: "Bearer abc",
+			expected:      httputil.UnknownIdentity,
+		},
+		"no token": {
+			expected: httputil.UnknownIdentity,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// given
+			req := httptest.NewRequest("POST", "/operations/op-id/retry", nil)
+			if tc.authorization != "" {
+				req.Header.Set("Authorization", tc.authorization)
+			}
+
+			// when
+			identity := httputil.RequestIdentity(req)
+
+			// then
+			assert.Equal(t, tc.expected, identity)
+		})
+	}
+}
+
+func fixToken(claims string) string {
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"RS256","typ":"JWT"}`))
+	payload := base64.RawURLEncoding.EncodeToString([]byte(claims))
+	return header + "." + payload + ".signature"
+}
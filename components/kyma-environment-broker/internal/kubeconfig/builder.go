@@ -1,6 +1,8 @@
 package kubeconfig
 
 import (
+	"context"
+
 	"bytes"
 	"fmt"
 	"text/template"
@@ -34,7 +36,7 @@ type kubeconfigData struct {
 }
 
 func (b *Builder) BuildFromAdminKubeconfig(instance *internal.Instance, adminKubeconfig string) (string, error) {
-	status, err := b.provisionerClient.RuntimeStatus(instance.GlobalAccountID, instance.RuntimeID)
+	status, err := b.provisionerClient.RuntimeStatus(context.Background(), instance.GlobalAccountID, instance.RuntimeID)
 	if err != nil {
 		return "", fmt.Errorf("while fetching runtime status from provisioner: %w", err)
 	}
@@ -8,6 +8,7 @@ import (
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/provisioner/automock"
 	schema "github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
 
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -23,7 +24,7 @@ func TestBuilder_Build(t *testing.T) {
 	t.Run("new kubeconfig was build properly", func(t *testing.T) {
 		// given
 		provisionerClient := &automock.Client{}
-		provisionerClient.On("RuntimeStatus", globalAccountID, runtimeID).Return(schema.RuntimeStatus{
+		provisionerClient.On("RuntimeStatus", mock.Anything, globalAccountID, runtimeID).Return(schema.RuntimeStatus{
 			RuntimeConfiguration: &schema.RuntimeConfig{
 				Kubeconfig: skrKubeconfig(),
 				ClusterConfig: &schema.GardenerConfig{
@@ -58,7 +59,7 @@ func TestBuilder_Build(t *testing.T) {
 	t.Run("provisioner client returned error", func(t *testing.T) {
 		// given
 		provisionerClient := &automock.Client{}
-		provisionerClient.On("RuntimeStatus", globalAccountID, runtimeID).Return(schema.RuntimeStatus{}, fmt.Errorf("cannot return kubeconfig"))
+		provisionerClient.On("RuntimeStatus", mock.Anything, globalAccountID, runtimeID).Return(schema.RuntimeStatus{}, fmt.Errorf("cannot return kubeconfig"))
 		defer provisionerClient.AssertExpectations(t)
 
 		builder := NewBuilder(provisionerClient)
@@ -78,7 +79,7 @@ func TestBuilder_Build(t *testing.T) {
 	t.Run("provisioner client returned wrong kubeconfig", func(t *testing.T) {
 		// given
 		provisionerClient := &automock.Client{}
-		provisionerClient.On("RuntimeStatus", globalAccountID, runtimeID).Return(schema.RuntimeStatus{
+		provisionerClient.On("RuntimeStatus", mock.Anything, globalAccountID, runtimeID).Return(schema.RuntimeStatus{
 			RuntimeConfiguration: &schema.RuntimeConfig{
 				Kubeconfig: skrWrongKubeconfig(),
 			},
@@ -104,7 +105,7 @@ func TestBuilder_BuildFromAdminKubeconfig(t *testing.T) {
 	t.Run("new kubeconfig was build properly", func(t *testing.T) {
 		// given
 		provisionerClient := &automock.Client{}
-		provisionerClient.On("RuntimeStatus", globalAccountID, runtimeID).Return(schema.RuntimeStatus{
+		provisionerClient.On("RuntimeStatus", mock.Anything, globalAccountID, runtimeID).Return(schema.RuntimeStatus{
 			RuntimeConfiguration: &schema.RuntimeConfig{
 				Kubeconfig: skrKubeconfig(),
 				ClusterConfig: &schema.GardenerConfig{
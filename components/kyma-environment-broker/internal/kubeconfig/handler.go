@@ -14,6 +14,7 @@ import (
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/httputil"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/trial"
 
 	"github.com/gorilla/mux"
 	"github.com/pivotal-cf/brokerapi/v8/domain"
@@ -29,20 +30,27 @@ type KcBuilder interface {
 	BuildFromAdminKubeconfig(instance *internal.Instance, adminKubeconfig string) (string, error)
 }
 
+// ActivityRecorder records the kubeconfig downloads as the usage of the instances with inactivity expiry
+type ActivityRecorder interface {
+	Record(instance internal.Instance, source string) error
+}
+
 type Handler struct {
 	kubeconfigBuilder KcBuilder
 	allowOrigins      string
 	instanceStorage   storage.Instances
 	operationStorage  storage.Operations
+	activityRecorder  ActivityRecorder
 	log               logrus.FieldLogger
 }
 
-func NewHandler(storage storage.BrokerStorage, b KcBuilder, origins string, log logrus.FieldLogger) *Handler {
+func NewHandler(storage storage.BrokerStorage, b KcBuilder, origins string, activityRecorder ActivityRecorder, log logrus.FieldLogger) *Handler {
 	return &Handler{
 		instanceStorage:   storage.Instances(),
 		operationStorage:  storage.Operations(),
 		kubeconfigBuilder: b,
 		allowOrigins:      origins,
+		activityRecorder:  activityRecorder,
 		log:               log,
 	}
 }
@@ -121,6 +129,12 @@ func (h *Handler) GetKubeconfig(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if h.activityRecorder != nil {
+		if err := h.activityRecorder.Record(*instance, trial.ActivitySourceKubeconfig); err != nil {
+			h.log.Warnf("cannot record kubeconfig download of instance %s: %s", instanceID, err)
+		}
+	}
+
 	writeToResponse(w, newKubeconfig, h.log)
 }
 
@@ -16,6 +16,7 @@ import (
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/kubeconfig/automock"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/logger"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/trial"
 
 	"github.com/gorilla/mux"
 	"github.com/pivotal-cf/brokerapi/v8/domain"
@@ -121,7 +122,7 @@ func TestHandler_GetKubeconfig(t *testing.T) {
 
 			router := mux.NewRouter()
 
-			handler := NewHandler(db, builder, "", logger.NewLogDummy())
+			handler := NewHandler(db, builder, "", nil, logger.NewLogDummy())
 			handler.AttachRoutes(router)
 
 			server := httptest.NewServer(router)
@@ -194,7 +195,7 @@ func TestHandler_GetKubeconfigForOwnCluster(t *testing.T) {
 
 	router := mux.NewRouter()
 
-	handler := NewHandler(db, builder, "", logger.NewLogDummy())
+	handler := NewHandler(db, builder, "", nil, logger.NewLogDummy())
 	handler.AttachRoutes(router)
 
 	server := httptest.NewServer(router)
@@ -207,6 +208,56 @@ func TestHandler_GetKubeconfigForOwnCluster(t *testing.T) {
 	assert.Equal(t, http.StatusNotFound, response.StatusCode)
 }
 
+func TestHandler_GetKubeconfigRecordsActivity(t *testing.T) {
+	// given
+	instance := internal.Instance{
+		InstanceID:    instanceID,
+		RuntimeID:     instanceRuntimeID,
+		ServicePlanID: broker.FreemiumPlanID,
+	}
+	operation := internal.ProvisioningOperation{
+		Operation: internal.Operation{
+			ID:         operationID,
+			InstanceID: instance.InstanceID,
+			State:      domain.Succeeded,
+			Type:       internal.OperationTypeProvision,
+		},
+	}
+
+	db := storage.NewMemoryStorage()
+	require.NoError(t, db.Instances().Insert(instance))
+	require.NoError(t, db.Operations().InsertProvisioningOperation(operation))
+
+	builder := &automock.KcBuilder{}
+	builder.On("Build", &instance).Return("--kubeconfig file", nil)
+	recorder := &activityRecorderStub{}
+
+	router := mux.NewRouter()
+	NewHandler(db, builder, "", recorder, logger.NewLogDummy()).AttachRoutes(router)
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	// when
+	response, err := http.Get(fmt.Sprintf("%s/kubeconfig/%s", server.URL, instanceID))
+	require.NoError(t, err)
+
+	// then
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, []string{instanceID}, recorder.instanceIDs)
+	assert.Equal(t, trial.ActivitySourceKubeconfig, recorder.source)
+}
+
+type activityRecorderStub struct {
+	instanceIDs []string
+	source      string
+}
+
+func (s *activityRecorderStub) Record(instance internal.Instance, source string) error {
+	s.instanceIDs = append(s.instanceIDs, instance.InstanceID)
+	s.source = source
+	return nil
+}
+
 func TestHandler_specifyAllowOriginHeader(t *testing.T) {
 	cases := map[string]struct {
 		requestHeader      http.Header
@@ -255,7 +306,7 @@ func TestHandler_specifyAllowOriginHeader(t *testing.T) {
 			request := &http.Request{Header: d.requestHeader}
 			response := &httptest.ResponseRecorder{}
 
-			handler := NewHandler(storage.NewMemoryStorage(), nil, d.origins, nil)
+			handler := NewHandler(storage.NewMemoryStorage(), nil, d.origins, nil, nil)
 
 			// when
 			handler.specifyAllowOriginHeader(request, response)
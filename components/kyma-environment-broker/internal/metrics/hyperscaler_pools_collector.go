@@ -0,0 +1,93 @@
+package metrics
+
+import (
+	"strconv"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/pools"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+)
+
+// HyperscalerPoolsGetter provides the capacity of the hyperscaler account pools:
+//
+// - compass_keb_hyperscaler_pool_secret_bindings - number of secret bindings per hyperscaler type, EU access flag and state
+// - compass_keb_hyperscaler_pool_shared_shoots - number of shoots using the shared secret bindings
+// - compass_keb_hyperscaler_pool_low_watermark - configured number of free secret bindings below which the pool is low
+// - compass_keb_hyperscaler_pool_below_low_watermark - 1 if the pool has less free secret bindings than the low watermark
+type HyperscalerPoolsGetter interface {
+	Pools() ([]pools.PoolDTO, error)
+}
+
+type HyperscalerPoolsCollector struct {
+	poolsGetter HyperscalerPoolsGetter
+
+	secretBindingsDesc    *prometheus.Desc
+	sharedShootsDesc      *prometheus.Desc
+	lowWatermarkDesc      *prometheus.Desc
+	belowLowWatermarkDesc *prometheus.Desc
+}
+
+func NewHyperscalerPoolsCollector(poolsGetter HyperscalerPoolsGetter) *HyperscalerPoolsCollector {
+	labels := []string{"hyperscaler_type", "eu_access"}
+	return &HyperscalerPoolsCollector{
+		poolsGetter: poolsGetter,
+
+		secretBindingsDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(prometheusNamespace, prometheusSubsystem, "hyperscaler_pool_secret_bindings"),
+			"The number of secret bindings in the hyperscaler account pool by state",
+			append(labels, "state"),
+			nil),
+		sharedShootsDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(prometheusNamespace, prometheusSubsystem, "hyperscaler_pool_shared_shoots"),
+			"The number of shoots using the shared secret bindings",
+			labels,
+			nil),
+		lowWatermarkDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(prometheusNamespace, prometheusSubsystem, "hyperscaler_pool_low_watermark"),
+			"The number of free secret bindings below which the hyperscaler account pool needs to be refilled",
+			labels,
+			nil),
+		belowLowWatermarkDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(prometheusNamespace, prometheusSubsystem, "hyperscaler_pool_below_low_watermark"),
+			"1 if the hyperscaler account pool has less free secret bindings than the low watermark",
+			labels,
+			nil),
+	}
+}
+
+func (c *HyperscalerPoolsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.secretBindingsDesc
+	ch <- c.sharedShootsDesc
+	ch <- c.lowWatermarkDesc
+	ch <- c.belowLowWatermarkDesc
+}
+
+// Collect implements the prometheus.Collector interface.
+func (c *HyperscalerPoolsCollector) Collect(ch chan<- prometheus.Metric) {
+	hyperscalerPools, err := c.poolsGetter.Pools()
+	if err != nil {
+		logrus.Error(err)
+		return
+	}
+
+	for _, pool := range hyperscalerPools {
+		euAccess := strconv.FormatBool(pool.EuAccess)
+		for state, num := range map[string]int{
+			"free":     pool.Free,
+			"used":     pool.Used,
+			"dirty":    pool.Dirty,
+			"internal": pool.Internal,
+			"shared":   pool.Shared,
+			"customer": pool.CustomerAccounts,
+		} {
+			collect(ch, c.secretBindingsDesc, num, pool.HyperscalerType, euAccess, state)
+		}
+		collect(ch, c.sharedShootsDesc, pool.SharedShoots, pool.HyperscalerType, euAccess)
+		collect(ch, c.lowWatermarkDesc, pool.LowWatermark, pool.HyperscalerType, euAccess)
+		below := 0
+		if pool.BelowLowWatermark {
+			below = 1
+		}
+		collect(ch, c.belowLowWatermarkDesc, below, pool.HyperscalerType, euAccess)
+	}
+}
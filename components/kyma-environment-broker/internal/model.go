@@ -207,6 +207,18 @@ type Operation struct {
 	OrchestrationID string             `json:"-"`
 	FinishedStages  []string           `json:"-"`
 	LastError       kebError.LastError `json:"-"`
+	// SkippedSteps contains the steps skipped manually by an operator, the staged manager does not run them
+	SkippedSteps []string `json:"skippedSteps,omitempty"`
+	// CancellationRequested stops the processing of the operation before its next step,
+	// the staged manager runs the compensation steps of the started stages and finishes the operation with the canceled state
+	CancellationRequested bool `json:"cancellationRequested,omitempty"`
+	// CompensatedSteps contains the compensation steps which are already done, they are not run again when the cancellation is retried
+	CompensatedSteps []string `json:"compensatedSteps,omitempty"`
+	// RetriedAt is the time of the last manual retry of the failed operation, the operation timeout is counted from this time
+	RetriedAt time.Time `json:"retriedAt,omitempty"`
+	// TraceID and RootSpanID identify the trace of the operation, the spans of all steps are children of the root span
+	TraceID    string `json:"traceID,omitempty"`
+	RootSpanID string `json:"rootSpanID,omitempty"`
 
 	// PROVISIONING
 	RuntimeVersion RuntimeVersionData `json:"runtime_version"`
@@ -220,6 +232,10 @@ type Operation struct {
 	ReconcilerDeregistrationAt  time.Time `json:"reconcilerDeregistrationAt"`
 	ExcutedButNotCompleted      []string  `json:"excutedButNotCompleted"`
 	UserAgent                   string    `json:"userAgent,omitempty"`
+	// Recreate indicates that the instance is provisioned again with the same parameters after this temporary deprovisioning
+	Recreate bool `json:"recreate,omitempty"`
+	// RecreationOperationID is the ID of the provisioning operation created after the deprovisioning with the Recreate flag
+	RecreationOperationID string `json:"recreationOperationID,omitempty"`
 
 	// UPDATING
 	UpdatingParameters    UpdatingParametersDTO `json:"updating_parameters"`
@@ -235,12 +251,28 @@ type Operation struct {
 	// denotes whether the payload to reconciler differs from last runtime state
 	RequiresReconcilerUpdate bool `json:"-"`
 
+	// StepSpanID identifies the span of the step processing the operation in the operation trace, set by the staged manager
+	StepSpanID string `json:"-"`
+
 	// UPGRADE KYMA
 	orchestration.RuntimeOperation `json:"runtime_operation"`
 	ClusterConfigurationApplied    bool `json:"cluster_configuration_applied"`
 
 	// KymaTemplate is read from the configuration then used in the apply_kyma step
 	KymaTemplate string `json:"KymaTemplate"`
+
+	// HookOverrides are returned by the hooks and appended to the runtime overrides by the overrides step
+	HookOverrides []HookOverride `json:"hookOverrides,omitempty"`
+	// CompletedHooks contains the step names of the hooks which were called successfully, they are not called again when the stage is re-run
+	CompletedHooks []string `json:"completedHooks,omitempty"`
+}
+
+// HookOverride is a runtime override of a component, the overrides without a component are global
+type HookOverride struct {
+	Component string `json:"component,omitempty"`
+	Key       string `json:"key"`
+	Value     string `json:"value"`
+	Secret    bool   `json:"secret,omitempty"`
 }
 
 func (o *Operation) IsFinished() bool {
@@ -255,6 +287,67 @@ func (o *Operation) EventErrorf(err error, fmt string, args ...any) {
 	events.Errorf(o.InstanceID, o.ID, err, fmt, args...)
 }
 
+// Quota limits the instances of a global account in a plan, the limits which are nil are not enforced.
+// The GlobalAccountID "*" sets the default for all global accounts, the PlanName "all_plans" limits the sum over all plans.
+type Quota struct {
+	GlobalAccountID string
+	PlanName        string
+	MaxInstances    *int
+	// MaxAutoScalerMax limits the sum of the autoscaler maximum of the instances
+	MaxAutoScalerMax *int
+	// MaxVolumeSizeGb limits the sum of the volume sizes of the instances
+	MaxVolumeSizeGb *int
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+type TrialEventType string
+
+const (
+	TrialEventWarning     TrialEventType = "warning"
+	TrialEventExtension   TrialEventType = "extension"
+	TrialEventHibernation TrialEventType = "hibernation"
+	TrialEventWakeUp      TrialEventType = "wakeup"
+	TrialEventActivity    TrialEventType = "activity"
+)
+
+// TrialEvent records a step in the lifecycle of an expirable instance: a sent expiration warning, an extension of the trial,
+// the hibernation of its cluster in the grace period, the wake-up of the cluster used again in the grace period
+// or the usage of an instance with inactivity expiry.
+// Only one extension is allowed per subaccount.
+type TrialEvent struct {
+	ID           string
+	InstanceID   string
+	SubAccountID string
+	Type         TrialEventType
+	// Period is the time left until the expiration for a warning and the prolongation of the trial for an extension
+	Period    time.Duration
+	Message   string
+	CreatedAt time.Time
+}
+
+type OperationStepResult string
+
+const (
+	OperationStepSucceeded OperationStepResult = "succeeded"
+	OperationStepRetry     OperationStepResult = "retry"
+	OperationStepFailed    OperationStepResult = "failed"
+)
+
+// OperationStep records a single run of a step of an operation, the runs of the steps make the timeline of the operation
+type OperationStep struct {
+	ID          string
+	OperationID string
+	StepName    string
+	StageName   string
+	StartedAt   time.Time
+	FinishedAt  time.Time
+	Result      OperationStepResult
+	// RetryIn is the time the step asked to wait before its next run
+	RetryIn time.Duration
+	Error   string
+}
+
 // Orchestration holds all information about an orchestration.
 // Orchestration performs operations of a specific type (UpgradeKymaOperation, UpgradeClusterOperation)
 // on specific targets of SKRs.
@@ -573,6 +666,13 @@ func NewSuspensionOperationWithID(operationID string, instance *Instance) Deprov
 	}
 }
 
+// NewRecreationOperationWithID creates the temporary deprovisioning operation which is followed by the provisioning of the instance
+func NewRecreationOperationWithID(operationID string, instance *Instance) DeprovisioningOperation {
+	operation := NewSuspensionOperationWithID(operationID, instance)
+	operation.Recreate = true
+	return operation
+}
+
 func (o *Operation) FinishStage(stageName string) {
 	if stageName == "" {
 		log.Warnf("Attempt to add empty stage.")
@@ -596,6 +696,46 @@ func (o *Operation) IsStageFinished(stage string) bool {
 	return false
 }
 
+func (o *Operation) SkipStep(stepName string) {
+	if o.IsStepSkipped(stepName) {
+		return
+	}
+	o.SkippedSteps = append(o.SkippedSteps, stepName)
+}
+
+func (o *Operation) IsStepSkipped(stepName string) bool {
+	for _, value := range o.SkippedSteps {
+		if value == stepName {
+			return true
+		}
+	}
+	return false
+}
+
+// ProcessingStartedAt returns the creation time of the operation or the time of its last manual retry
+func (o *Operation) ProcessingStartedAt() time.Time {
+	if o.RetriedAt.After(o.CreatedAt) {
+		return o.RetriedAt
+	}
+	return o.CreatedAt
+}
+
+func (o *Operation) CompensateStep(stepName string) {
+	if o.IsStepCompensated(stepName) {
+		return
+	}
+	o.CompensatedSteps = append(o.CompensatedSteps, stepName)
+}
+
+func (o *Operation) IsStepCompensated(stepName string) bool {
+	for _, value := range o.CompensatedSteps {
+		if value == stepName {
+			return true
+		}
+	}
+	return false
+}
+
 type ComponentConfigurationInputList []*gqlschema.ComponentConfigurationInput
 
 func (l ComponentConfigurationInputList) DeepCopy() []*gqlschema.ComponentConfigurationInput {
@@ -0,0 +1,126 @@
+package networking
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"strings"
+)
+
+const (
+	// DefaultNodesCIDR is the widest range used for the nodes by the hyperscaler inputs
+	DefaultNodesCIDR = "10.250.0.0/16"
+	// DefaultPodsCIDR and DefaultServicesCIDR are the Gardener defaults used when no range is provided
+	DefaultPodsCIDR     = "100.64.0.0/12"
+	DefaultServicesCIDR = "100.104.0.0/13"
+
+	// MaxNodesPrefixLength leaves room for the subnets of up to 4 zones, each with a workers, public and internal subnet
+	MaxNodesPrefixLength    = 22
+	MaxPodsPrefixLength     = 16
+	MaxServicesPrefixLength = 22
+)
+
+// reservedCIDRs must not be used by a runtime
+var reservedCIDRs = []string{
+	"0.0.0.0/8",      // current network
+	"10.242.0.0/16",  // Gardener seed pods
+	"10.243.0.0/16",  // Gardener seed services
+	"10.254.0.0/16",  // Gardener seed nodes
+	"127.0.0.0/8",    // loopback
+	"169.254.0.0/16", // link-local, used by the cloud metadata services
+	"172.17.0.0/16",  // default bridge of the container runtime
+	"224.0.0.0/4",    // multicast
+}
+
+// Validate checks the node, pod and service ranges of a runtime, the defaults are used for the ranges which are not provided
+func Validate(nodes, pods, services *string) error {
+	ranges := []struct {
+		name            string
+		cidr            string
+		maxPrefixLength int
+	}{
+		{name: "nodes", cidr: valueOrDefault(nodes, DefaultNodesCIDR), maxPrefixLength: MaxNodesPrefixLength},
+		{name: "pods", cidr: valueOrDefault(pods, DefaultPodsCIDR), maxPrefixLength: MaxPodsPrefixLength},
+		{name: "services", cidr: valueOrDefault(services, DefaultServicesCIDR), maxPrefixLength: MaxServicesPrefixLength},
+	}
+
+	errs := make([]string, 0)
+	networks := make([]*net.IPNet, len(ranges))
+	for i, r := range ranges {
+		network, err := ParseNetwork(r.cidr)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %s", r.name, err))
+			continue
+		}
+		if ones, _ := network.Mask.Size(); ones > r.maxPrefixLength {
+			errs = append(errs, fmt.Sprintf("%s: %s is too small, the prefix length must be at most /%d", r.name, r.cidr, r.maxPrefixLength))
+		}
+		for _, reserved := range reservedCIDRs {
+			_, reservedNetwork, _ := net.ParseCIDR(reserved)
+			if Overlaps(network, reservedNetwork) {
+				errs = append(errs, fmt.Sprintf("%s: %s overlaps with the reserved range %s", r.name, r.cidr, reserved))
+			}
+		}
+		networks[i] = network
+	}
+
+	for i := range ranges {
+		for j := i + 1; j < len(ranges); j++ {
+			if networks[i] != nil && networks[j] != nil && Overlaps(networks[i], networks[j]) {
+				errs = append(errs, fmt.Sprintf("%s %s overlaps with %s %s", ranges[i].name, ranges[i].cidr, ranges[j].name, ranges[j].cidr))
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+// ParseNetwork parses an IPv4 CIDR which must be given by its network address, e.g. 10.250.0.0/16 and not 10.250.1.0/16
+func ParseNetwork(cidr string) (*net.IPNet, error) {
+	ip, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, fmt.Errorf("%s is not a valid CIDR", cidr)
+	}
+	if ip.To4() == nil {
+		return nil, fmt.Errorf("%s is not an IPv4 CIDR", cidr)
+	}
+	if !ip.Equal(network.IP) {
+		return nil, fmt.Errorf("%s is not a network address, did you mean %s", cidr, network.String())
+	}
+	return network, nil
+}
+
+// Overlaps returns true if the networks have at least one address in common
+func Overlaps(a, b *net.IPNet) bool {
+	return a.Contains(b.IP) || b.Contains(a.IP)
+}
+
+// Subnet returns the subnet with the given prefix length and index inside the network,
+// e.g. the subnet /19 with index 2 of 10.250.0.0/16 is 10.250.64.0/19
+func Subnet(network *net.IPNet, prefixLength, index int) (*net.IPNet, error) {
+	ones, bits := network.Mask.Size()
+	if prefixLength < ones || prefixLength > bits {
+		return nil, fmt.Errorf("prefix length /%d does not fit into %s", prefixLength, network.String())
+	}
+	if index < 0 || index >= 1<<(prefixLength-ones) {
+		return nil, fmt.Errorf("%s has no subnet /%d with index %d", network.String(), prefixLength, index)
+	}
+
+	offset := new(big.Int).Lsh(big.NewInt(int64(index)), uint(bits-prefixLength))
+	ip := new(big.Int).Add(new(big.Int).SetBytes(network.IP.To4()), offset)
+	subnetIP := make(net.IP, net.IPv4len)
+	ip.FillBytes(subnetIP)
+
+	return &net.IPNet{IP: subnetIP, Mask: net.CIDRMask(prefixLength, bits)}, nil
+}
+
+func valueOrDefault(value *string, defaultValue string) string {
+	if value == nil || *value == "" {
+		return defaultValue
+	}
+	return *value
+}
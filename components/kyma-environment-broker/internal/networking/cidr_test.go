@@ -0,0 +1,74 @@
+package networking
+
+import (
+	"net"
+	"testing"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/ptr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	for tn, tc := range map[string]struct {
+		nodes, pods, services *string
+		expectedErr           string
+	}{
+		"defaults": {},
+		"custom ranges": {
+			nodes:    ptr.String("10.180.0.0/18"),
+			pods:     ptr.String("10.96.0.0/13"),
+			services: ptr.String("10.104.0.0/16"),
+		},
+		"invalid CIDR": {
+			nodes:       ptr.String("10.180.0.0"),
+			expectedErr: "nodes: 10.180.0.0 is not a valid CIDR",
+		},
+		"not a network address": {
+			pods:        ptr.String("10.96.1.0/13"),
+			expectedErr: "pods: 10.96.1.0/13 is not a network address, did you mean 10.96.0.0/13",
+		},
+		"too small": {
+			nodes:       ptr.String("10.180.0.0/24"),
+			expectedErr: "nodes: 10.180.0.0/24 is too small, the prefix length must be at most /22",
+		},
+		"reserved range": {
+			services:    ptr.String("172.16.0.0/12"),
+			expectedErr: "services: 172.16.0.0/12 overlaps with the reserved range 172.17.0.0/16",
+		},
+		"overlapping ranges": {
+			nodes:       ptr.String("100.64.0.0/16"),
+			expectedErr: "nodes 100.64.0.0/16 overlaps with pods 100.64.0.0/12",
+		},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			// when
+			err := Validate(tc.nodes, tc.pods, tc.services)
+
+			// then
+			if tc.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedErr)
+			}
+		})
+	}
+}
+
+func TestSubnet(t *testing.T) {
+	// given
+	_, network, err := net.ParseCIDR("10.250.0.0/16")
+	require.NoError(t, err)
+
+	// when
+	workers, err := Subnet(network, 19, 2)
+	require.NoError(t, err)
+	public, err := Subnet(network, 20, 3)
+	require.NoError(t, err)
+	_, outOfRange := Subnet(network, 18, 4)
+
+	// then
+	assert.Equal(t, "10.250.64.0/19", workers.String())
+	assert.Equal(t, "10.250.48.0/20", public.String())
+	assert.EqualError(t, outOfRange, "10.250.0.0/16 has no subnet /18 with index 4")
+}
@@ -0,0 +1,323 @@
+package operation
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	pkg "github.com/kyma-project/control-plane/components/kyma-environment-broker/common/operation"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	kebError "github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/error"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/httputil"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
+	"github.com/pivotal-cf/brokerapi/v8/domain"
+	"github.com/sirupsen/logrus"
+)
+
+// Retrier processes the operation again without waiting for the step retry
+type Retrier interface {
+	RetryNow(operationID string)
+}
+
+// Handler exposes the admin API which resolves the operations stuck in a step and the timeline of the operations
+type Handler struct {
+	operations storage.Operations
+	steps      storage.OperationSteps
+	retriers   map[internal.OperationType]Retrier
+	log        logrus.FieldLogger
+}
+
+// NewHandler creates the handler, the operations of types without a retrier are processed again on the next retry scheduled by their queue,
+// e.g. the upgrade operations retried by the orchestration strategy
+func NewHandler(operations storage.Operations, steps storage.OperationSteps, retriers map[internal.OperationType]Retrier, log logrus.FieldLogger) *Handler {
+	return &Handler{
+		operations: operations,
+		steps:      steps,
+		retriers:   retriers,
+		log:        log.WithField("service", "OperationHandler"),
+	}
+}
+
+func (h *Handler) AttachRoutes(router *mux.Router) {
+	router.HandleFunc("/operations/{operation_id}/steps/{step_name}/skip", h.skipStep).Methods(http.MethodPost)
+	router.HandleFunc("/operations/{operation_id}/retry", h.retryOperation).Methods(http.MethodPost)
+	router.HandleFunc("/operations/{operation_id}/fail", h.fail).Methods(http.MethodPost)
+	router.HandleFunc("/operations/{operation_id}/succeed", h.succeed).Methods(http.MethodPost)
+	router.HandleFunc("/operations/{operation_id}/cancel", h.cancel).Methods(http.MethodPost)
+	router.HandleFunc("/operations/{operation_id}/timeline", h.timeline).Methods(http.MethodGet)
+}
+
+// skipStep stores the step in the operation, the staged manager does not run the skipped steps
+func (h *Handler) skipStep(w http.ResponseWriter, r *http.Request) {
+	stepName := mux.Vars(r)["step_name"]
+	operation, action, ok := h.prepare(w, r)
+	if !ok {
+		return
+	}
+
+	operation.SkipStep(stepName)
+	updated, ok := h.update(w, *operation)
+	if !ok {
+		return
+	}
+	h.record(r, updated, action, "step %s skipped", stepName)
+	h.retry(updated)
+	httputil.WriteResponse(w, http.StatusOK, toDTO(updated))
+}
+
+// retryOperation resumes the failed provisioning or update, the operation in progress is processed immediately
+func (h *Handler) retryOperation(w http.ResponseWriter, r *http.Request) {
+	operation, action, ok := h.load(w, r)
+	if !ok {
+		return
+	}
+	if operation.State == domain.Failed {
+		h.resume(w, r, operation, action)
+		return
+	}
+	if !h.checkNotFinished(w, operation) {
+		return
+	}
+	h.retryNow(w, r, operation, action)
+}
+
+// retryNow processes the operation immediately, ignoring the time the step asked to wait for its retry
+func (h *Handler) retryNow(w http.ResponseWriter, r *http.Request, operation *internal.Operation, action pkg.ActionDTO) {
+	if _, found := h.retriers[operation.Type]; !found {
+		httputil.WriteErrorResponse(w, http.StatusBadRequest, fmt.Errorf("operations of type %s are retried by their orchestration", operation.Type))
+		return
+	}
+
+	h.record(r, *operation, action, "retry requested")
+	h.retry(*operation)
+	httputil.WriteResponse(w, http.StatusOK, toDTO(*operation))
+}
+
+// resume processes the failed operation again from its first not finished stage, the finished stages are not repeated.
+// Only the last operation of the instance can be resumed, the events of the failed processing are kept
+func (h *Handler) resume(w http.ResponseWriter, r *http.Request, operation *internal.Operation, action pkg.ActionDTO) {
+	if _, found := h.retriers[operation.Type]; !found || (operation.Type != internal.OperationTypeProvision && operation.Type != internal.OperationTypeUpdate) {
+		httputil.WriteErrorResponse(w, http.StatusBadRequest, fmt.Errorf("only the failed provisioning and update operations can be retried, operation %s is of type %s", operation.ID, operation.Type))
+		return
+	}
+	lastOperation, err := h.operations.GetLastOperation(operation.InstanceID)
+	if err != nil {
+		h.log.Errorf("while getting last operation of instance %s: %v", operation.InstanceID, err)
+		httputil.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Errorf("while getting last operation: %w", err))
+		return
+	}
+	if lastOperation.ID != operation.ID {
+		httputil.WriteErrorResponse(w, http.StatusConflict, fmt.Errorf("operation %s is not the last operation of instance %s, the %s operation %s was created later", operation.ID, operation.InstanceID, lastOperation.Type, lastOperation.ID))
+		return
+	}
+
+	failure := operation.Description
+	if operation.LastError.Error() != "" {
+		failure = operation.LastError.Error()
+	}
+	operation.State = domain.InProgress
+	operation.Description = fmt.Sprintf("Operation retried manually by %s", httputil.RequestIdentity(r))
+	operation.LastError = kebError.LastError{}
+	operation.RetriedAt = time.Now()
+	updated, ok := h.update(w, *operation)
+	if !ok {
+		return
+	}
+	h.record(r, updated, action, "retry of the failed operation requested, the processing is resumed after the finished stages %v, last failure: %s", updated.FinishedStages, failure)
+	h.retry(updated)
+	httputil.WriteResponse(w, http.StatusOK, toDTO(updated))
+}
+
+// timeline returns the runs of the steps of the operation, also the finished one
+func (h *Handler) timeline(w http.ResponseWriter, r *http.Request) {
+	operationID := mux.Vars(r)["operation_id"]
+
+	operation, err := h.operations.GetOperationByID(operationID)
+	switch {
+	case dberr.IsNotFound(err):
+		httputil.WriteErrorResponse(w, http.StatusNotFound, fmt.Errorf("operation %s not found", operationID))
+		return
+	case err != nil:
+		h.log.Errorf("while getting operation %s: %v", operationID, err)
+		httputil.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Errorf("while getting operation: %w", err))
+		return
+	}
+	steps, err := h.steps.ListByOperationID(operationID)
+	if err != nil {
+		h.log.Errorf("while listing steps of operation %s: %v", operationID, err)
+		httputil.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Errorf("while listing steps: %w", err))
+		return
+	}
+
+	httputil.WriteResponse(w, http.StatusOK, toTimelineDTO(*operation, steps))
+}
+
+// fail finishes the operation with the failed state, the remaining steps are not run
+func (h *Handler) fail(w http.ResponseWriter, r *http.Request) {
+	h.finish(w, r, domain.Failed)
+}
+
+// succeed finishes the operation with the succeeded state, the remaining steps are not run
+func (h *Handler) succeed(w http.ResponseWriter, r *http.Request) {
+	h.finish(w, r, domain.Succeeded)
+}
+
+// cancel requests the cancellation, the staged manager stops the operation before its next step,
+// compensates the started stages and finishes the operation with the canceled state
+func (h *Handler) cancel(w http.ResponseWriter, r *http.Request) {
+	operation, action, ok := h.prepare(w, r)
+	if !ok {
+		return
+	}
+	if operation.CancellationRequested {
+		httputil.WriteResponse(w, http.StatusOK, toDTO(*operation))
+		return
+	}
+
+	operation.CancellationRequested = true
+	operation.Description = "Operation is being canceled"
+	updated, ok := h.update(w, *operation)
+	if !ok {
+		return
+	}
+	h.record(r, updated, action, "cancellation requested")
+	// wake up the worker waiting for the step retry, the cancellation starts without waiting for the next retry
+	h.retry(updated)
+	httputil.WriteResponse(w, http.StatusOK, toDTO(updated))
+}
+
+func (h *Handler) finish(w http.ResponseWriter, r *http.Request, state domain.LastOperationState) {
+	operation, action, ok := h.prepare(w, r)
+	if !ok {
+		return
+	}
+
+	operation.State = state
+	operation.Description = fmt.Sprintf("Operation set to %s manually by %s", state, httputil.RequestIdentity(r))
+	if action.Reason != "" {
+		operation.Description = fmt.Sprintf("%s: %s", operation.Description, action.Reason)
+	}
+	updated, ok := h.update(w, *operation)
+	if !ok {
+		return
+	}
+	h.record(r, updated, action, "operation set to %s", state)
+	// wake up the worker waiting for the step retry, it drops the operation as it is finished
+	h.retry(updated)
+	httputil.WriteResponse(w, http.StatusOK, toDTO(updated))
+}
+
+// prepare reads the action and the operation, only the operations which are not finished can be changed
+func (h *Handler) prepare(w http.ResponseWriter, r *http.Request) (*internal.Operation, pkg.ActionDTO, bool) {
+	operation, action, ok := h.load(w, r)
+	if !ok || !h.checkNotFinished(w, operation) {
+		return nil, action, false
+	}
+	return operation, action, true
+}
+
+// load reads the action and the operation
+func (h *Handler) load(w http.ResponseWriter, r *http.Request) (*internal.Operation, pkg.ActionDTO, bool) {
+	operationID := mux.Vars(r)["operation_id"]
+
+	var action pkg.ActionDTO
+	if err := json.NewDecoder(r.Body).Decode(&action); err != nil && err != io.EOF {
+		httputil.WriteErrorResponse(w, http.StatusBadRequest, fmt.Errorf("while decoding action: %w", err))
+		return nil, action, false
+	}
+
+	operation, err := h.operations.GetOperationByID(operationID)
+	switch {
+	case dberr.IsNotFound(err):
+		httputil.WriteErrorResponse(w, http.StatusNotFound, fmt.Errorf("operation %s not found", operationID))
+		return nil, action, false
+	case err != nil:
+		h.log.Errorf("while getting operation %s: %v", operationID, err)
+		httputil.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Errorf("while getting operation: %w", err))
+		return nil, action, false
+	}
+	return operation, action, true
+}
+
+func (h *Handler) checkNotFinished(w http.ResponseWriter, operation *internal.Operation) bool {
+	if operation.IsFinished() {
+		httputil.WriteErrorResponse(w, http.StatusConflict, fmt.Errorf("operation %s is already finished with state %s", operation.ID, operation.State))
+		return false
+	}
+	return true
+}
+
+func (h *Handler) update(w http.ResponseWriter, operation internal.Operation) (internal.Operation, bool) {
+	updated, err := h.operations.UpdateOperation(operation)
+	switch {
+	case dberr.IsConflict(err):
+		httputil.WriteErrorResponse(w, http.StatusConflict, fmt.Errorf("operation %s was changed in the meantime, try again", operation.ID))
+		return operation, false
+	case err != nil:
+		h.log.Errorf("while updating operation %s: %v", operation.ID, err)
+		httputil.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Errorf("while updating operation: %w", err))
+		return operation, false
+	}
+	return *updated, true
+}
+
+// record stores the action with the identity of the operator in the events of the operation
+func (h *Handler) record(r *http.Request, operation internal.Operation, action pkg.ActionDTO, format string, args ...any) {
+	message := fmt.Sprintf(format, args...)
+	identity := httputil.RequestIdentity(r)
+	if action.Reason != "" {
+		operation.EventInfof("%s manually by %s: %s", message, identity, action.Reason)
+	} else {
+		operation.EventInfof("%s manually by %s", message, identity)
+	}
+	h.log.Infof("%s for operation %s manually by %s", message, operation.ID, identity)
+}
+
+func (h *Handler) retry(operation internal.Operation) {
+	if retrier, found := h.retriers[operation.Type]; found {
+		retrier.RetryNow(operation.ID)
+	}
+}
+
+func toDTO(operation internal.Operation) pkg.OperationDTO {
+	return pkg.OperationDTO{
+		OperationID:           operation.ID,
+		InstanceID:            operation.InstanceID,
+		Type:                  string(operation.Type),
+		State:                 string(operation.State),
+		Description:           operation.Description,
+		FinishedStages:        operation.FinishedStages,
+		SkippedSteps:          operation.SkippedSteps,
+		CancellationRequested: operation.CancellationRequested,
+		CompensatedSteps:      operation.CompensatedSteps,
+		TraceID:               operation.TraceID,
+	}
+}
+
+func toTimelineDTO(operation internal.Operation, steps []internal.OperationStep) pkg.TimelineDTO {
+	timeline := pkg.TimelineDTO{
+		OperationID: operation.ID,
+		InstanceID:  operation.InstanceID,
+		Type:        string(operation.Type),
+		State:       string(operation.State),
+		CreatedAt:   operation.CreatedAt,
+		UpdatedAt:   operation.UpdatedAt,
+		Steps:       make([]pkg.StepDTO, 0, len(steps)),
+	}
+	for _, step := range steps {
+		timeline.Steps = append(timeline.Steps, pkg.StepDTO{
+			StepName:       step.StepName,
+			Stage:          step.StageName,
+			StartedAt:      step.StartedAt,
+			FinishedAt:     step.FinishedAt,
+			Result:         string(step.Result),
+			RetryInSeconds: int64(step.RetryIn / time.Second),
+			Error:          step.Error,
+		})
+	}
+	return timeline
+}
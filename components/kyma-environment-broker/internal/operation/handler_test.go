@@ -0,0 +1,279 @@
+package operation_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	pkg "github.com/kyma-project/control-plane/components/kyma-environment-broker/common/operation"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	kebError "github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/error"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/fixture"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/operation"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/pivotal-cf/brokerapi/v8/domain"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler(t *testing.T) {
+	// given
+	db := storage.NewMemoryStorage()
+	retrier := &retrierStub{}
+	router := mux.NewRouter()
+	operation.NewHandler(db.Operations(), db.OperationSteps(), map[internal.OperationType]operation.Retrier{
+		internal.OperationTypeDeprovision: retrier,
+	}, logrus.New()).AttachRoutes(router)
+
+	t.Run("should skip step", func(t *testing.T) {
+		// given
+		insertOperation(t, db, "op-skip", internal.OperationTypeDeprovision, domain.InProgress)
+
+		// when
+		rr := call(t, router, "/operations/op-skip/steps/Check_Cluster_Deregistration/skip", `{"reason": "reconciler is down"}`)
+
+		// then
+		require.Equal(t, http.StatusOK, rr.Code)
+		var dto pkg.OperationDTO
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &dto))
+		assert.Equal(t, []string{"Check_Cluster_Deregistration"}, dto.SkippedSteps)
+		op, err := db.Operations().GetOperationByID("op-skip")
+		require.NoError(t, err)
+		assert.True(t, op.IsStepSkipped("Check_Cluster_Deregistration"))
+		assert.Contains(t, retrier.retried, "op-skip")
+	})
+
+	t.Run("should retry now", func(t *testing.T) {
+		// given
+		insertOperation(t, db, "op-retry", internal.OperationTypeDeprovision, domain.InProgress)
+
+		// when
+		rr := call(t, router, "/operations/op-retry/retry", "")
+
+		// then
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, retrier.retried, "op-retry")
+	})
+
+	t.Run("should not retry operation without retrier", func(t *testing.T) {
+		// given
+		insertOperation(t, db, "op-upgrade", internal.OperationTypeUpgradeKyma, domain.InProgress)
+
+		// when
+		rr := call(t, router, "/operations/op-upgrade/retry", "")
+
+		// then
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should fail operation", func(t *testing.T) {
+		// given
+		insertOperation(t, db, "op-fail", internal.OperationTypeDeprovision, domain.InProgress)
+
+		// when
+		rr := call(t, router, "/operations/op-fail/fail", `{"reason": "cluster removed manually"}`)
+
+		// then
+		require.Equal(t, http.StatusOK, rr.Code)
+		op, err := db.Operations().GetOperationByID("op-fail")
+		require.NoError(t, err)
+		assert.Equal(t, domain.Failed, op.State)
+		assert.Equal(t, "Operation set to failed manually by unknown: cluster removed manually", op.Description)
+	})
+
+	t.Run("should succeed operation", func(t *testing.T) {
+		// given
+		insertOperation(t, db, "op-succeed", internal.OperationTypeDeprovision, domain.InProgress)
+
+		// when
+		rr := call(t, router, "/operations/op-succeed/succeed", "")
+
+		// then
+		require.Equal(t, http.StatusOK, rr.Code)
+		op, err := db.Operations().GetOperationByID("op-succeed")
+		require.NoError(t, err)
+		assert.Equal(t, domain.Succeeded, op.State)
+	})
+
+	t.Run("should request cancellation", func(t *testing.T) {
+		// given
+		insertOperation(t, db, "op-cancel", internal.OperationTypeDeprovision, domain.InProgress)
+
+		// when
+		rr := call(t, router, "/operations/op-cancel/cancel", `{"reason": "wrong region"}`)
+
+		// then
+		require.Equal(t, http.StatusOK, rr.Code)
+		var dto pkg.OperationDTO
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &dto))
+		assert.True(t, dto.CancellationRequested)
+		op, err := db.Operations().GetOperationByID("op-cancel")
+		require.NoError(t, err)
+		assert.True(t, op.CancellationRequested)
+		assert.Equal(t, domain.InProgress, op.State)
+		assert.Contains(t, retrier.retried, "op-cancel")
+	})
+
+	t.Run("should reject finished operation", func(t *testing.T) {
+		// given
+		insertOperation(t, db, "op-finished", internal.OperationTypeDeprovision, domain.Succeeded)
+
+		// when
+		rr := call(t, router, "/operations/op-finished/fail", "")
+
+		// then
+		assert.Equal(t, http.StatusConflict, rr.Code)
+	})
+
+	t.Run("should return not found", func(t *testing.T) {
+		// when
+		rr := call(t, router, "/operations/not-existing/retry", "")
+
+		// then
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("should return timeline", func(t *testing.T) {
+		// given
+		insertOperation(t, db, "op-timeline", internal.OperationTypeProvision, domain.Succeeded)
+		recorder := operation.NewTimelineRecorder(db.OperationSteps(), logrus.New())
+		start := time.Now()
+		for _, ev := range []process.OperationStepProcessed{
+			{StepProcessed: process.StepProcessed{StepName: "Create_Runtime", Stage: "create_runtime", StartedAt: start, Duration: time.Second, When: time.Minute}},
+			{StepProcessed: process.StepProcessed{StepName: "Create_Runtime", Stage: "create_runtime", StartedAt: start.Add(time.Minute), Duration: time.Second}},
+			{StepProcessed: process.StepProcessed{Duration: time.Hour}},
+		} {
+			ev.Operation = internal.Operation{ID: "op-timeline"}
+			require.NoError(t, recorder.OnOperationStepProcessed(context.Background(), ev))
+		}
+
+		// when
+		req, err := http.NewRequest(http.MethodGet, "/operations/op-timeline/timeline", nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		// then
+		require.Equal(t, http.StatusOK, rr.Code)
+		var dto pkg.TimelineDTO
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &dto))
+		assert.Equal(t, string(domain.Succeeded), dto.State)
+		require.Len(t, dto.Steps, 2)
+		assert.Equal(t, string(internal.OperationStepRetry), dto.Steps[0].Result)
+		assert.Equal(t, int64(60), dto.Steps[0].RetryInSeconds)
+		assert.Equal(t, string(internal.OperationStepSucceeded), dto.Steps[1].Result)
+		assert.Equal(t, "create_runtime", dto.Steps[1].Stage)
+	})
+}
+
+func TestHandler_RetryFailedOperation(t *testing.T) {
+	// given
+	db := storage.NewMemoryStorage()
+	retrier := &retrierStub{}
+	router := mux.NewRouter()
+	operation.NewHandler(db.Operations(), db.OperationSteps(), map[internal.OperationType]operation.Retrier{
+		internal.OperationTypeProvision:   retrier,
+		internal.OperationTypeDeprovision: retrier,
+	}, logrus.New()).AttachRoutes(router)
+
+	t.Run("should resume failed provisioning", func(t *testing.T) {
+		// given
+		op := fixture.FixOperation("op-provisioning", "instance-provisioning", internal.OperationTypeProvision)
+		op.State = domain.Failed
+		op.CreatedAt = time.Now().Add(-48 * time.Hour)
+		op.FinishedStages = []string{"start"}
+		op.LastError = kebError.LastError{}.SetMessage("edp is down")
+		require.NoError(t, db.Operations().InsertOperation(op))
+
+		// when
+		rr := call(t, router, "/operations/op-provisioning/retry", `{"reason": "edp is up again"}`)
+
+		// then
+		require.Equal(t, http.StatusOK, rr.Code)
+		var dto pkg.OperationDTO
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &dto))
+		assert.Equal(t, string(domain.InProgress), dto.State)
+		assert.Equal(t, []string{"start"}, dto.FinishedStages)
+		stored, err := db.Operations().GetOperationByID("op-provisioning")
+		require.NoError(t, err)
+		assert.Equal(t, domain.InProgress, stored.State)
+		assert.Empty(t, stored.LastError.Error())
+		assert.Equal(t, "Operation retried manually by unknown", stored.Description)
+		assert.WithinDuration(t, time.Now(), stored.ProcessingStartedAt(), time.Minute)
+		assert.Contains(t, retrier.retried, "op-provisioning")
+	})
+
+	t.Run("should not resume failed deprovisioning", func(t *testing.T) {
+		// given
+		op := fixture.FixOperation("op-deprovisioning", "instance-deprovisioning", internal.OperationTypeDeprovision)
+		op.State = domain.Failed
+		require.NoError(t, db.Operations().InsertOperation(op))
+
+		// when
+		rr := call(t, router, "/operations/op-deprovisioning/retry", "")
+
+		// then
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should not resume operation followed by another one", func(t *testing.T) {
+		// given
+		op := fixture.FixOperation("op-old", "instance-old", internal.OperationTypeProvision)
+		op.State = domain.Failed
+		op.CreatedAt = time.Now().Add(-time.Hour)
+		require.NoError(t, db.Operations().InsertOperation(op))
+		deprovisioning := fixture.FixOperation("op-new", "instance-old", internal.OperationTypeDeprovision)
+		deprovisioning.State = domain.InProgress
+		require.NoError(t, db.Operations().InsertOperation(deprovisioning))
+
+		// when
+		rr := call(t, router, "/operations/op-old/retry", "")
+
+		// then
+		assert.Equal(t, http.StatusConflict, rr.Code)
+		stored, err := db.Operations().GetOperationByID("op-old")
+		require.NoError(t, err)
+		assert.Equal(t, domain.Failed, stored.State)
+	})
+
+	t.Run("should reject succeeded operation", func(t *testing.T) {
+		// given
+		op := fixture.FixOperation("op-succeeded", "instance-succeeded", internal.OperationTypeProvision)
+		require.NoError(t, db.Operations().InsertOperation(op))
+
+		// when
+		rr := call(t, router, "/operations/op-succeeded/retry", "")
+
+		// then
+		assert.Equal(t, http.StatusConflict, rr.Code)
+	})
+}
+
+type retrierStub struct {
+	retried []string
+}
+
+func (r *retrierStub) RetryNow(operationID string) {
+	r.retried = append(r.retried, operationID)
+}
+
+func insertOperation(t *testing.T, db storage.BrokerStorage, id string, opType internal.OperationType, state domain.LastOperationState) {
+	op := fixture.FixOperation(id, "instance-id", opType)
+	op.State = state
+	require.NoError(t, db.Operations().InsertOperation(op))
+}
+
+func call(t *testing.T, router *mux.Router, path, body string) *httptest.ResponseRecorder {
+	req, err := http.NewRequest(http.MethodPost, path, bytes.NewBufferString(body))
+	require.NoError(t, err)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	return rr
+}
@@ -0,0 +1,92 @@
+package operation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/pivotal-cf/brokerapi/v8/domain"
+	"github.com/sirupsen/logrus"
+)
+
+// TimelineConfig sets how long the runs of the steps are kept, the runs are recorded for every retry of a step
+type TimelineConfig struct {
+	Retention     time.Duration `envconfig:"default=336h"` // two weeks: 24*14 = 336
+	PollingPeriod time.Duration `envconfig:"default=1h"`
+}
+
+// TimelineRecorder stores every run of a step processed by the staged managers, the runs make the timeline of the operation
+type TimelineRecorder struct {
+	steps storage.OperationSteps
+	log   logrus.FieldLogger
+}
+
+func NewTimelineRecorder(steps storage.OperationSteps, log logrus.FieldLogger) *TimelineRecorder {
+	return &TimelineRecorder{
+		steps: steps,
+		log:   log.WithField("service", "TimelineRecorder"),
+	}
+}
+
+func (r *TimelineRecorder) OnOperationStepProcessed(_ context.Context, ev interface{}) error {
+	stepProcessed, ok := ev.(process.OperationStepProcessed)
+	if !ok {
+		return fmt.Errorf("expected process.OperationStepProcessed in OnOperationStepProcessed but got %+v", ev)
+	}
+	// the operation timeout is published without a step
+	if stepProcessed.StepName == "" {
+		return nil
+	}
+
+	step := internal.OperationStep{
+		ID:          uuid.New().String(),
+		OperationID: stepProcessed.Operation.ID,
+		StepName:    stepProcessed.StepName,
+		StageName:   stepProcessed.Stage,
+		StartedAt:   stepProcessed.StartedAt,
+		FinishedAt:  stepProcessed.StartedAt.Add(stepProcessed.Duration),
+		Result:      internal.OperationStepSucceeded,
+	}
+	switch {
+	case stepProcessed.Error != nil:
+		step.Result = internal.OperationStepFailed
+		step.Error = stepProcessed.Error.Error()
+	case stepProcessed.Operation.State == domain.Failed:
+		step.Result = internal.OperationStepFailed
+		step.Error = stepProcessed.Operation.Description
+	case stepProcessed.When > 0:
+		step.Result = internal.OperationStepRetry
+		step.RetryIn = stepProcessed.When
+	}
+
+	if err := r.steps.Insert(step); err != nil {
+		r.log.Errorf("while storing step %s of operation %s: %v", step.StepName, step.OperationID, err)
+		return fmt.Errorf("while storing step %s of operation %s: %w", step.StepName, step.OperationID, err)
+	}
+	return nil
+}
+
+// RunTimelineCleanup deletes the runs of the steps finished before the retention period every polling period until the context is done,
+// the runs are kept if the retention is 0
+func RunTimelineCleanup(ctx context.Context, steps storage.OperationSteps, cfg TimelineConfig, log logrus.FieldLogger) {
+	if cfg.Retention == 0 {
+		return
+	}
+	log = log.WithField("service", "TimelineCleanup")
+	ticker := time.NewTicker(cfg.PollingPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := steps.DeleteFinishedBefore(time.Now().Add(-cfg.Retention)); err != nil {
+				log.Errorf("while deleting the runs of the steps: %v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package operation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+func TestRunTimelineCleanup(t *testing.T) {
+	// given
+	steps := storage.NewMemoryStorage().OperationSteps()
+	now := time.Now()
+	require.NoError(t, steps.Insert(internal.OperationStep{ID: "old", OperationID: "op1", StartedAt: now.Add(-3 * time.Hour), FinishedAt: now.Add(-2 * time.Hour)}))
+	require.NoError(t, steps.Insert(internal.OperationStep{ID: "new", OperationID: "op1", StartedAt: now, FinishedAt: now}))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// when
+	go RunTimelineCleanup(ctx, steps, TimelineConfig{Retention: time.Hour, PollingPeriod: time.Millisecond}, logrus.New())
+
+	// then
+	err := wait.PollImmediate(time.Millisecond, time.Second, func() (bool, error) {
+		got, err := steps.ListByOperationID("op1")
+		return len(got) == 1, err
+	})
+	require.NoError(t, err)
+	got, err := steps.ListByOperationID("op1")
+	require.NoError(t, err)
+	assert.Equal(t, "new", got[0].ID)
+}
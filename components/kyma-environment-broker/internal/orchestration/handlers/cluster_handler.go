@@ -57,6 +57,14 @@ func (h *clusterHandler) createOrchestration(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	// validate manifest
+	err = validateManifest(params)
+	if err != nil {
+		h.log.Errorf("while validating manifest: %v", err)
+		httputil.WriteErrorResponse(w, http.StatusBadRequest, fmt.Errorf("while validating manifest: %w", err))
+		return
+	}
+
 	// validate deprecated parameteter `maintenanceWindow`
 	err = ValidateDeprecatedParameters(params)
 	if err != nil {
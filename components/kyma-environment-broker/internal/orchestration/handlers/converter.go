@@ -9,7 +9,9 @@ import (
 	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
 )
 
-type Converter struct{}
+type Converter struct {
+	planRegistry *broker.PlanRegistry
+}
 
 func (*Converter) OrchestrationToDTO(o *internal.Orchestration, stats map[string]int) (*orchestration.StatusResponse, error) {
 	return &orchestration.StatusResponse{
@@ -50,7 +52,7 @@ func (c *Converter) UpgradeKymaOperationToDTO(op internal.UpgradeKymaOperation)
 		SubAccountID:           op.RuntimeOperation.SubAccountID,
 		OrchestrationID:        op.OrchestrationID,
 		ServicePlanID:          op.ProvisioningParameters.PlanID,
-		ServicePlanName:        broker.PlanNamesMapping[op.ProvisioningParameters.PlanID],
+		ServicePlanName:        c.planRegistry.PlanNames()[op.ProvisioningParameters.PlanID],
 		DryRun:                 op.DryRun,
 		ShootName:              op.RuntimeOperation.ShootName,
 		MaintenanceWindowBegin: op.MaintenanceWindowBegin,
@@ -97,7 +99,7 @@ func (c *Converter) UpgradeClusterOperationToDTO(op internal.UpgradeClusterOpera
 		SubAccountID:           op.RuntimeOperation.SubAccountID,
 		OrchestrationID:        op.OrchestrationID,
 		ServicePlanID:          op.ProvisioningParameters.PlanID,
-		ServicePlanName:        broker.PlanNamesMapping[op.ProvisioningParameters.PlanID],
+		ServicePlanName:        c.planRegistry.PlanNames()[op.ProvisioningParameters.PlanID],
 		DryRun:                 op.DryRun,
 		ShootName:              op.RuntimeOperation.ShootName,
 		MaintenanceWindowBegin: op.MaintenanceWindowBegin,
@@ -6,6 +6,7 @@ import (
 
 	"github.com/gorilla/mux"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
 	"github.com/pkg/errors"
@@ -20,12 +21,12 @@ type handler struct {
 	handlers []Handler
 }
 
-func NewOrchestrationHandler(db storage.BrokerStorage, kymaQueue *process.Queue, clusterQueue *process.Queue, defaultMaxPage int, log logrus.FieldLogger) Handler {
+func NewOrchestrationHandler(db storage.BrokerStorage, kymaQueue *process.Queue, clusterQueue *process.Queue, defaultMaxPage int, planRegistry *broker.PlanRegistry, log logrus.FieldLogger) Handler {
 	return &handler{
 		handlers: []Handler{
 			NewKymaHandler(db.Orchestrations(), kymaQueue, log),
 			NewClusterHandler(db.Orchestrations(), clusterQueue, log),
-			NewOrchestrationStatusHandler(db.Operations(), db.Orchestrations(), db.RuntimeStates(), kymaQueue, clusterQueue, defaultMaxPage, log),
+			NewOrchestrationStatusHandler(db.Operations(), db.Orchestrations(), db.RuntimeStates(), kymaQueue, clusterQueue, defaultMaxPage, planRegistry, log),
 		},
 	}
 }
@@ -36,6 +37,9 @@ func (h *handler) AttachRoutes(router *mux.Router) {
 	}
 }
 
+// maxManifestSize is the maximum size of the manifest stored with an orchestration
+const maxManifestSize = 64 * 1024
+
 func validateTarget(spec orchestration.TargetSpec) error {
 	if spec.Include == nil || len(spec.Include) == 0 {
 		return errors.New("targets.include array must be not empty")
@@ -43,6 +47,13 @@ func validateTarget(spec orchestration.TargetSpec) error {
 	return nil
 }
 
+func validateManifest(params orchestration.Parameters) error {
+	if len(params.Manifest) > maxManifestSize {
+		return fmt.Errorf("manifest size %d exceeds the limit of %d bytes", len(params.Manifest), maxManifestSize)
+	}
+	return nil
+}
+
 // ValidateDeprecatedParameters cheks if `maintenanceWindow` parameter is used as schedule.
 func ValidateDeprecatedParameters(params orchestration.Parameters) error {
 	if params.Strategy.Schedule == string(orchestration.MaintenanceWindow) {
@@ -66,6 +66,14 @@ func (h *kymaHandler) createOrchestration(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	// validate manifest
+	err = validateManifest(params)
+	if err != nil {
+		h.log.Errorf("while validating manifest: %v", err)
+		httputil.WriteErrorResponse(w, http.StatusBadRequest, fmt.Errorf("while validating manifest: %w", err))
+		return
+	}
+
 	// validate Kyma version
 	err = h.ValidateKymaVersion(params.Kyma.Version)
 	if err != nil {
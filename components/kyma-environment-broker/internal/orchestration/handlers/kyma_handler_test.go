@@ -8,6 +8,7 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -68,6 +69,48 @@ func TestKymaHandler_AttachRoutes(t *testing.T) {
 		require.NoError(t, err)
 		assert.NotEmpty(t, out.OrchestrationID)
 	})
+
+	t.Run("upgrade with manifest", func(t *testing.T) {
+		// given
+		kHandler := fixKymaHandler(t)
+		router := mux.NewRouter()
+		kHandler.AttachRoutes(router)
+
+		params := orchestration.Parameters{
+			Targets: orchestration.TargetSpec{
+				Include: []orchestration.RuntimeTarget{{Target: orchestration.TargetAll}},
+			},
+			Kyma: &orchestration.KymaParameters{},
+			Strategy: orchestration.StrategySpec{
+				Schedule: "now",
+			},
+			Manifest: "kind: UpgradeKyma",
+		}
+		p, err := json.Marshal(&params)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+
+		// when
+		router.ServeHTTP(rr, httptest.NewRequest("POST", "/upgrade/kyma", bytes.NewBuffer(p)))
+
+		// then
+		require.Equal(t, http.StatusAccepted, rr.Code)
+		var out orchestration.UpgradeResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &out))
+		o, err := kHandler.orchestrations.GetByID(out.OrchestrationID)
+		require.NoError(t, err)
+		assert.Equal(t, "kind: UpgradeKyma", o.Parameters.Manifest)
+
+		// when
+		params.Manifest = strings.Repeat("#", maxManifestSize+1)
+		p, err = json.Marshal(&params)
+		require.NoError(t, err)
+		rr = httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest("POST", "/upgrade/kyma", bytes.NewBuffer(p)))
+
+		// then
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
 }
 
 // Testing Kyma Version is disabled due to GitHub API RATE limits
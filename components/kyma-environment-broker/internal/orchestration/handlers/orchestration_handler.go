@@ -8,6 +8,7 @@ import (
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/pagination"
 
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/httputil"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
 
@@ -44,6 +45,7 @@ func NewOrchestrationStatusHandler(operations storage.Operations,
 	kymaQueue *process.Queue,
 	clusterQueue *process.Queue,
 	defaultMaxPage int,
+	planRegistry *broker.PlanRegistry,
 	log logrus.FieldLogger) *orchestrationHandler {
 	return &orchestrationHandler{
 		operations:     operations,
@@ -51,7 +53,7 @@ func NewOrchestrationStatusHandler(operations storage.Operations,
 		runtimeStates:  runtimeStates,
 		log:            log,
 		defaultMaxPage: defaultMaxPage,
-		converter:      Converter{},
+		converter:      Converter{planRegistry: planRegistry},
 		canceler:       NewCanceler(orchestrations, log),
 		kymaRetryer:    NewKymaRetryer(orchestrations, operations, kymaQueue, log),
 		clusterRetryer: NewClusterRetryer(orchestrations, operations, clusterQueue, log),
@@ -0,0 +1,69 @@
+package pipeline
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	pkg "github.com/kyma-project/control-plane/components/kyma-environment-broker/common/pipeline"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/httputil"
+)
+
+// Handler exposes the admin API which shows the effective pipelines of the running KEB
+type Handler struct {
+	registry *Registry
+}
+
+func NewHandler(registry *Registry) *Handler {
+	return &Handler{registry: registry}
+}
+
+func (h *Handler) AttachRoutes(router *mux.Router) {
+	router.HandleFunc("/pipelines", h.listPipelines).Methods(http.MethodGet)
+	router.HandleFunc("/pipelines/{operation_type}", h.getPipeline).Methods(http.MethodGet)
+}
+
+func (h *Handler) listPipelines(w http.ResponseWriter, r *http.Request) {
+	planID, err := h.planFromQuery(r)
+	if err != nil {
+		httputil.WriteErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	response := pkg.PipelineListDTO{Data: make([]pkg.PipelineDTO, 0, len(h.registry.Pipelines()))}
+	for _, pipeline := range h.registry.Pipelines() {
+		response.Data = append(response.Data, pipeline.View(planID, h.registry.planRegistry.PlanNames()))
+	}
+	response.Count = len(response.Data)
+	httputil.WriteResponse(w, http.StatusOK, response)
+}
+
+func (h *Handler) getPipeline(w http.ResponseWriter, r *http.Request) {
+	planID, err := h.planFromQuery(r)
+	if err != nil {
+		httputil.WriteErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	operationType := mux.Vars(r)["operation_type"]
+	pipeline, found := h.registry.Get(internal.OperationType(operationType))
+	if !found {
+		httputil.WriteErrorResponse(w, http.StatusNotFound, fmt.Errorf("pipeline of the %s operations not found", operationType))
+		return
+	}
+	httputil.WriteResponse(w, http.StatusOK, pipeline.View(planID, h.registry.planRegistry.PlanNames()))
+}
+
+// planFromQuery returns the ID of the plan whose name is given in the query, empty if the plan is not given
+func (h *Handler) planFromQuery(r *http.Request) (string, error) {
+	name := r.URL.Query().Get(pkg.PlanParam)
+	if name == "" {
+		return "", nil
+	}
+	planID, found := h.registry.planRegistry.PlanIDs()[name]
+	if !found {
+		return "", fmt.Errorf("unknown plan %s", name)
+	}
+	return planID, nil
+}
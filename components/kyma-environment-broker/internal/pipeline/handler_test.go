@@ -0,0 +1,100 @@
+package pipeline_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	pkg "github.com/kyma-project/control-plane/components/kyma-environment-broker/common/pipeline"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/pipeline"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler(t *testing.T) {
+	// given
+	registry := pipeline.NewRegistry(nil)
+	require.NoError(t, registry.Register(&pipeline.Pipeline{
+		OperationType: internal.OperationTypeProvision,
+		Stages: []*pipeline.Stage{
+			{Name: "create", Steps: []pipeline.Step{
+				{Step: fixStep("Init"), Input: pipeline.CreatesInput},
+				{Step: fixStep("Create_Runtime"), Input: pipeline.NeedsInput, Plans: pipeline.Plans{Except: []string{broker.OwnClusterPlanID}}},
+				{Step: fixStep("EDP_Registration"), Disabled: true},
+			}},
+		},
+	}))
+	require.NoError(t, registry.Register(&pipeline.Pipeline{
+		OperationType: internal.OperationTypeUpdate,
+		Stages:        []*pipeline.Stage{{Name: "cluster", Steps: []pipeline.Step{{Step: fixStep("Update_Init")}}}},
+	}))
+	router := mux.NewRouter()
+	pipeline.NewHandler(registry).AttachRoutes(router)
+
+	t.Run("should list all pipelines", func(t *testing.T) {
+		// when
+		var list pkg.PipelineListDTO
+		rr := get(t, router, "/pipelines", &list)
+
+		// then
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, 2, list.Count)
+		assert.Equal(t, "provision", list.Data[0].OperationType)
+		assert.Equal(t, "update", list.Data[1].OperationType)
+	})
+
+	t.Run("should return the pipeline with the plans of the steps", func(t *testing.T) {
+		// when
+		var view pkg.PipelineDTO
+		rr := get(t, router, "/pipelines/provision", &view)
+
+		// then
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, []pkg.StageDTO{{Name: "create", Steps: []pkg.StepDTO{
+			{Name: "Init", Input: "creates"},
+			{Name: "Create_Runtime", Input: "needs", ExceptPlans: []string{"own_cluster"}},
+		}}}, view.Stages)
+	})
+
+	t.Run("should return the pipeline of the plan", func(t *testing.T) {
+		// when
+		var view pkg.PipelineDTO
+		rr := get(t, router, "/pipelines/provision?plan=own_cluster", &view)
+
+		// then
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "own_cluster", view.Plan)
+		assert.Equal(t, []pkg.StageDTO{{Name: "create", Steps: []pkg.StepDTO{{Name: "Init", Input: "creates"}}}}, view.Stages)
+	})
+
+	t.Run("should reject unknown plan", func(t *testing.T) {
+		// when
+		rr := get(t, router, "/pipelines?plan=unknown", nil)
+
+		// then
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should return not found for operation type without pipeline", func(t *testing.T) {
+		// when
+		rr := get(t, router, "/pipelines/deprovision", nil)
+
+		// then
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
+
+func get(t *testing.T, router *mux.Router, url string, response interface{}) *httptest.ResponseRecorder {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	if response != nil && rr.Code == http.StatusOK {
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), response))
+	}
+	return rr
+}
@@ -0,0 +1,382 @@
+package pipeline
+
+import (
+	"fmt"
+	"sort"
+
+	pkg "github.com/kyma-project/control-plane/components/kyma-environment-broker/common/pipeline"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process/upgrade_cluster"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process/upgrade_kyma"
+)
+
+// Pipeline declares the stages and steps which process the operations of one type
+type Pipeline struct {
+	OperationType internal.OperationType
+	Stages        []*Stage
+}
+
+// Stage is run until all its steps are done, a finished stage is never run again
+type Stage struct {
+	Name  string
+	Steps []Step
+	// Compensations undo the changes of the started stage when the operation is canceled
+	Compensations []Step
+}
+
+// NamedStep is a process.Step, an upgrade_kyma.Step or an upgrade_cluster.Step, depending on the operation type of the pipeline
+type NamedStep interface {
+	Name() string
+}
+
+// InputUsage tells how the step uses the InputCreator of the operation, which is not persisted
+type InputUsage int
+
+const (
+	// CreatesInput marks the step which sets the InputCreator
+	CreatesInput InputUsage = iota + 1
+	// NeedsInput marks the step which uses the InputCreator, it must run in the same stage after the step creating it
+	NeedsInput
+)
+
+// Step declares a step of a stage together with the configuration and conditions under which it runs
+type Step struct {
+	Step NamedStep
+	// Disabled steps are not added to the manager, e.g. the steps of the integrations disabled in the configuration
+	Disabled bool
+	// Plans limits the step to some plans, the step runs for all plans by default
+	Plans Plans
+	// Condition decides whether the step runs for the operation, When describes it in the pipeline view
+	Condition process.StepCondition
+	When      string
+	Input     InputUsage
+	// Rerun marks the step declared in an earlier stage which runs again in this stage
+	Rerun bool
+}
+
+// Plans lists the IDs of the plans the step runs for, or the IDs of the plans it does not run for
+type Plans struct {
+	Only   []string
+	Except []string
+}
+
+func (p Plans) all() bool {
+	return len(p.Only) == 0 && len(p.Except) == 0
+}
+
+// Includes returns true if the step runs for the plan
+func (p Plans) Includes(planID string) bool {
+	for _, id := range p.Except {
+		if id == planID {
+			return false
+		}
+	}
+	if len(p.Only) == 0 {
+		return true
+	}
+	for _, id := range p.Only {
+		if id == planID {
+			return true
+		}
+	}
+	return false
+}
+
+func only(planIDs ...string) Plans {
+	return Plans{Only: planIDs}
+}
+
+func except(planIDs ...string) Plans {
+	return Plans{Except: planIDs}
+}
+
+// condition combines the plans and the condition of the step
+func (s Step) condition() process.StepCondition {
+	if s.Plans.all() {
+		return s.Condition
+	}
+	return func(operation internal.Operation) bool {
+		if !s.Plans.Includes(operation.ProvisioningParameters.PlanID) {
+			return false
+		}
+		return s.Condition == nil || s.Condition(operation)
+	}
+}
+
+// AddStep adds the step at the end of the stage, it is used to register the post hooks
+func (p *Pipeline) AddStep(stageName string, step process.Step, cnd process.StepCondition) error {
+	stage, err := p.stage(stageName)
+	if err != nil {
+		return err
+	}
+	stage.Steps = append(stage.Steps, hookStep(step, cnd))
+	return nil
+}
+
+// InsertStep adds the step at the beginning of the stage, it is used to register the pre hooks
+func (p *Pipeline) InsertStep(stageName string, step process.Step, cnd process.StepCondition) error {
+	stage, err := p.stage(stageName)
+	if err != nil {
+		return err
+	}
+	stage.Steps = append([]Step{hookStep(step, cnd)}, stage.Steps...)
+	return nil
+}
+
+// InputCreatedBefore returns true if a stage before the given one creates the InputCreator, it is used to reject
+// the parameters patches of the hooks which run after the Provisioner input is created
+func (p *Pipeline) InputCreatedBefore(stageName string) bool {
+	for _, stage := range p.Stages {
+		if stage.Name == stageName {
+			return false
+		}
+		for _, step := range stage.enabledSteps() {
+			if step.Input == CreatesInput {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func hookStep(step process.Step, cnd process.StepCondition) Step {
+	return Step{Step: step, Condition: cnd, When: "the configured hook applies to the operation"}
+}
+
+func (p *Pipeline) stage(name string) (*Stage, error) {
+	for _, stage := range p.Stages {
+		if stage.Name == name {
+			return stage, nil
+		}
+	}
+	return nil, fmt.Errorf("stage %s not defined in the %s pipeline", name, p.OperationType)
+}
+
+// Validate checks that the step names are unique and that the InputCreator, which is not persisted,
+// is created in every stage which needs it. A finished stage is never run again, so the stages which need the InputCreator come first.
+func (p *Pipeline) Validate() error {
+	stageNames := make(map[string]bool)
+	stepNames := make(map[string]bool)
+	// the stages which need the InputCreator must not follow the first stage without it which comes after them
+	inputStageSeen, firstWithoutInput := false, ""
+	for _, stage := range p.Stages {
+		if stage.Name == "" {
+			return fmt.Errorf("%s pipeline: stage without name", p.OperationType)
+		}
+		if stageNames[stage.Name] {
+			return fmt.Errorf("%s pipeline: duplicated stage %s", p.OperationType, stage.Name)
+		}
+		stageNames[stage.Name] = true
+
+		inputCreated, needsInput := false, false
+		for _, step := range stage.enabledSteps() {
+			name := step.Step.Name()
+			switch {
+			case step.Rerun && !stepNames[name]:
+				return fmt.Errorf("%s pipeline: step %s in stage %s is run again but it is not declared in an earlier stage", p.OperationType, name, stage.Name)
+			case !step.Rerun && stepNames[name]:
+				return fmt.Errorf("%s pipeline: duplicated step %s in stage %s", p.OperationType, name, stage.Name)
+			}
+			stepNames[name] = true
+
+			if step.Condition != nil && step.When == "" {
+				return fmt.Errorf("%s pipeline: the condition of step %s is not described", p.OperationType, name)
+			}
+			switch step.Input {
+			case CreatesInput:
+				inputCreated = true
+			case NeedsInput:
+				if !inputCreated {
+					return fmt.Errorf("%s pipeline: step %s in stage %s needs the InputCreator which is not created before it in the stage", p.OperationType, name, stage.Name)
+				}
+				needsInput = true
+			}
+		}
+		for _, step := range stage.enabledCompensations() {
+			if stepNames[step.Step.Name()] {
+				return fmt.Errorf("%s pipeline: duplicated compensation step %s in stage %s", p.OperationType, step.Step.Name(), stage.Name)
+			}
+			stepNames[step.Step.Name()] = true
+		}
+
+		switch {
+		case needsInput && firstWithoutInput != "":
+			return fmt.Errorf("%s pipeline: stage %s needs the InputCreator, it must come before stage %s", p.OperationType, stage.Name, firstWithoutInput)
+		case needsInput:
+			inputStageSeen = true
+		case inputStageSeen && firstWithoutInput == "":
+			firstWithoutInput = stage.Name
+		}
+	}
+	return nil
+}
+
+func (s *Stage) enabledSteps() []Step {
+	return enabled(s.Steps)
+}
+
+func (s *Stage) enabledCompensations() []Step {
+	return enabled(s.Compensations)
+}
+
+func enabled(steps []Step) []Step {
+	var result []Step
+	for _, step := range steps {
+		if !step.Disabled {
+			result = append(result, step)
+		}
+	}
+	return result
+}
+
+// definedStages returns the stages added to the manager, the stages with disabled steps only are omitted
+func (p *Pipeline) definedStages() []*Stage {
+	var stages []*Stage
+	for _, stage := range p.Stages {
+		if len(stage.Steps) > 0 && len(stage.enabledSteps()) == 0 && len(stage.enabledCompensations()) == 0 {
+			continue
+		}
+		stages = append(stages, stage)
+	}
+	return stages
+}
+
+func (p *Pipeline) stageNames() []string {
+	var names []string
+	for _, stage := range p.definedStages() {
+		names = append(names, stage.Name)
+	}
+	return names
+}
+
+// View returns the enabled stages and steps of the pipeline, only the steps run for the plan if planID is not empty,
+// planNames maps the plan IDs to the names shown in the view
+func (p *Pipeline) View(planID string, planNames map[string]string) pkg.PipelineDTO {
+	view := pkg.PipelineDTO{
+		OperationType: string(p.OperationType),
+		Plan:          planNames[planID],
+		Stages:        make([]pkg.StageDTO, 0, len(p.Stages)),
+	}
+	for _, stage := range p.definedStages() {
+		view.Stages = append(view.Stages, pkg.StageDTO{
+			Name:          stage.Name,
+			Steps:         stepsView(stage.enabledSteps(), planID, planNames),
+			Compensations: stepsView(stage.enabledCompensations(), planID, planNames),
+		})
+	}
+	return view
+}
+
+func stepsView(steps []Step, planID string, planNames map[string]string) []pkg.StepDTO {
+	views := make([]pkg.StepDTO, 0, len(steps))
+	for _, step := range steps {
+		if planID != "" && !step.Plans.Includes(planID) {
+			continue
+		}
+		view := pkg.StepDTO{
+			Name:  step.Step.Name(),
+			Rerun: step.Rerun,
+		}
+		if step.Condition != nil {
+			view.Condition = step.When
+		}
+		if planID == "" {
+			view.OnlyPlans = namesOfPlans(step.Plans.Only, planNames)
+			view.ExceptPlans = namesOfPlans(step.Plans.Except, planNames)
+		}
+		switch step.Input {
+		case CreatesInput:
+			view.Input = "creates"
+		case NeedsInput:
+			view.Input = "needs"
+		}
+		views = append(views, view)
+	}
+	return views
+}
+
+func namesOfPlans(planIDs []string, planNames map[string]string) []string {
+	var names []string
+	for _, id := range planIDs {
+		if name, found := planNames[id]; found {
+			names = append(names, name)
+		} else {
+			names = append(names, id)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Apply defines the stages in the manager and adds the enabled steps and compensations
+func (p *Pipeline) Apply(manager *process.StagedManager) error {
+	manager.DefineStages(p.stageNames())
+	for _, stage := range p.definedStages() {
+		for _, step := range stage.enabledSteps() {
+			processStep, ok := step.Step.(process.Step)
+			if !ok {
+				return fmt.Errorf("%s pipeline: step %s is not a process step", p.OperationType, step.Step.Name())
+			}
+			if err := manager.AddStep(stage.Name, processStep, step.condition()); err != nil {
+				return err
+			}
+		}
+		for _, step := range stage.enabledCompensations() {
+			processStep, ok := step.Step.(process.Step)
+			if !ok {
+				return fmt.Errorf("%s pipeline: compensation %s is not a process step", p.OperationType, step.Step.Name())
+			}
+			if err := manager.AddCompensation(stage.Name, processStep, step.condition()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ApplyToUpgradeKyma defines the stages in the upgrade Kyma manager and adds the enabled steps
+func (p *Pipeline) ApplyToUpgradeKyma(manager *upgrade_kyma.Manager) error {
+	manager.DefineStages(p.stageNames())
+	for _, stage := range p.definedStages() {
+		if len(stage.enabledCompensations()) > 0 {
+			return fmt.Errorf("%s pipeline: compensations are not supported", p.OperationType)
+		}
+		for _, step := range stage.enabledSteps() {
+			upgradeStep, ok := step.Step.(upgrade_kyma.Step)
+			if !ok {
+				return fmt.Errorf("%s pipeline: step %s is not an upgrade Kyma step", p.OperationType, step.Step.Name())
+			}
+			var cnd upgrade_kyma.StepCondition
+			if condition := step.condition(); condition != nil {
+				cnd = func(operation internal.UpgradeKymaOperation) bool {
+					return condition(operation.Operation)
+				}
+			}
+			if err := manager.AddStep(stage.Name, upgradeStep, cnd); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ApplyToUpgradeCluster defines the stages in the upgrade cluster manager and adds the enabled steps
+func (p *Pipeline) ApplyToUpgradeCluster(manager *upgrade_cluster.Manager) error {
+	manager.DefineStages(p.stageNames())
+	for _, stage := range p.definedStages() {
+		if len(stage.enabledCompensations()) > 0 {
+			return fmt.Errorf("%s pipeline: compensations are not supported", p.OperationType)
+		}
+		for _, step := range stage.enabledSteps() {
+			upgradeStep, ok := step.Step.(upgrade_cluster.Step)
+			if !ok {
+				return fmt.Errorf("%s pipeline: step %s is not an upgrade cluster step", p.OperationType, step.Step.Name())
+			}
+			if err := manager.AddStep(stage.Name, upgradeStep, upgrade_cluster.StepCondition(step.condition())); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
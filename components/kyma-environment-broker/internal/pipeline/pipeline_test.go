@@ -0,0 +1,316 @@
+package pipeline_test
+
+import (
+	"testing"
+	"time"
+
+	pkg "github.com/kyma-project/control-plane/components/kyma-environment-broker/common/pipeline"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/event"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/fixture"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/pipeline"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
+	inputAutomock "github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process/input/automock"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process/update"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/runtimeversion"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/pivotal-cf/brokerapi/v8/domain"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestPipeline_Validate(t *testing.T) {
+	for name, tc := range map[string]struct {
+		stages      []*pipeline.Stage
+		expectedErr string
+	}{
+		"valid pipeline": {
+			stages: []*pipeline.Stage{
+				{Name: "start", Steps: []pipeline.Step{{Step: fixStep("Start")}}},
+				{Name: "create", Steps: []pipeline.Step{
+					{Step: fixStep("Init"), Input: pipeline.CreatesInput},
+					{Step: fixStep("Create"), Input: pipeline.NeedsInput},
+				}},
+				{Name: "check", Steps: []pipeline.Step{
+					{Step: fixStep("Init"), Rerun: true},
+					{Step: fixStep("Check")},
+				}},
+			},
+		},
+		"duplicated step": {
+			stages: []*pipeline.Stage{
+				{Name: "first", Steps: []pipeline.Step{{Step: fixStep("Step")}}},
+				{Name: "second", Steps: []pipeline.Step{{Step: fixStep("Step")}}},
+			},
+			expectedErr: "duplicated step Step in stage second",
+		},
+		"duplicated disabled step": {
+			stages: []*pipeline.Stage{
+				{Name: "first", Steps: []pipeline.Step{{Step: fixStep("Step")}}},
+				{Name: "second", Steps: []pipeline.Step{{Step: fixStep("Step"), Disabled: true}}},
+			},
+		},
+		"compensation named like a step": {
+			stages: []*pipeline.Stage{
+				{Name: "first", Steps: []pipeline.Step{{Step: fixStep("Step")}}, Compensations: []pipeline.Step{{Step: fixStep("Step")}}},
+			},
+			expectedErr: "duplicated compensation step Step in stage first",
+		},
+		"rerun of not declared step": {
+			stages: []*pipeline.Stage{
+				{Name: "first", Steps: []pipeline.Step{{Step: fixStep("Step"), Rerun: true}}},
+			},
+			expectedErr: "step Step in stage first is run again but it is not declared in an earlier stage",
+		},
+		"duplicated stage": {
+			stages: []*pipeline.Stage{
+				{Name: "first", Steps: []pipeline.Step{{Step: fixStep("A")}}},
+				{Name: "first", Steps: []pipeline.Step{{Step: fixStep("B")}}},
+			},
+			expectedErr: "duplicated stage first",
+		},
+		"condition not described": {
+			stages: []*pipeline.Stage{
+				{Name: "first", Steps: []pipeline.Step{{Step: fixStep("Step"), Condition: func(internal.Operation) bool { return true }}}},
+			},
+			expectedErr: "the condition of step Step is not described",
+		},
+		"input needed before it is created": {
+			stages: []*pipeline.Stage{
+				{Name: "first", Steps: []pipeline.Step{
+					{Step: fixStep("Create"), Input: pipeline.NeedsInput},
+					{Step: fixStep("Init"), Input: pipeline.CreatesInput},
+				}},
+			},
+			expectedErr: "step Create in stage first needs the InputCreator which is not created before it in the stage",
+		},
+		"input needed in other stage": {
+			stages: []*pipeline.Stage{
+				{Name: "first", Steps: []pipeline.Step{{Step: fixStep("Init"), Input: pipeline.CreatesInput}}},
+				{Name: "second", Steps: []pipeline.Step{{Step: fixStep("Create"), Input: pipeline.NeedsInput}}},
+			},
+			expectedErr: "step Create in stage second needs the InputCreator which is not created before it in the stage",
+		},
+		"input stage after stage without input": {
+			stages: []*pipeline.Stage{
+				{Name: "first", Steps: []pipeline.Step{
+					{Step: fixStep("Init"), Input: pipeline.CreatesInput},
+					{Step: fixStep("Create"), Input: pipeline.NeedsInput},
+				}},
+				{Name: "check", Steps: []pipeline.Step{{Step: fixStep("Check")}}},
+				{Name: "late", Steps: []pipeline.Step{
+					{Step: fixStep("Init"), Input: pipeline.CreatesInput, Rerun: true},
+					{Step: fixStep("Configure"), Input: pipeline.NeedsInput},
+				}},
+			},
+			expectedErr: "stage late needs the InputCreator, it must come before stage check",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// given
+			p := &pipeline.Pipeline{OperationType: internal.OperationTypeProvision, Stages: tc.stages}
+
+			// when
+			err := p.Validate()
+
+			// then
+			if tc.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, "provision pipeline: "+tc.expectedErr)
+			}
+		})
+	}
+}
+
+func TestPipeline_Apply(t *testing.T) {
+	// given
+	memoryStorage := storage.NewMemoryStorage()
+	var executed []string
+	p := &pipeline.Pipeline{
+		OperationType: internal.OperationTypeProvision,
+		Stages: []*pipeline.Stage{
+			{Name: "first", Steps: []pipeline.Step{
+				{Step: fixRecordingStep("Always", &executed)},
+				{Step: fixRecordingStep("Disabled", &executed), Disabled: true},
+				{Step: fixRecordingStep("Azure_Only", &executed), Plans: pipeline.Plans{Only: []string{broker.AzurePlanID}}},
+				{Step: fixRecordingStep("Not_Azure", &executed), Plans: pipeline.Plans{Except: []string{broker.AzurePlanID}}},
+				{Step: fixRecordingStep("Never", &executed), Condition: func(internal.Operation) bool { return false }, When: "never"},
+			}},
+			{Name: "disabled", Steps: []pipeline.Step{{Step: fixRecordingStep("Disabled_Stage", &executed), Disabled: true}}},
+		},
+	}
+	require.NoError(t, p.AddStep("first", fixRecordingStep("Hook", &executed), nil))
+	require.NoError(t, pipeline.NewRegistry(nil).Register(p))
+
+	operation := fixture.FixProvisioningOperation("op-id", "inst-id")
+	operation.State = domain.InProgress
+	operation.ProvisioningParameters.PlanID = broker.AzurePlanID
+	require.NoError(t, memoryStorage.Operations().InsertOperation(operation))
+	manager := process.NewStagedManager(memoryStorage.Operations(), event.NewPubSub(logrus.New()), time.Hour, nil, logrus.New())
+
+	// when
+	require.NoError(t, p.Apply(manager))
+	_, err := manager.Execute(operation.ID)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, []string{"first"}, manager.GetAllStages())
+	assert.Equal(t, []string{"Always", "Azure_Only", "Hook"}, executed)
+}
+
+func TestRegistry_Register(t *testing.T) {
+	// given
+	registry := pipeline.NewRegistry(nil)
+	p := &pipeline.Pipeline{OperationType: internal.OperationTypeUpdate, Stages: []*pipeline.Stage{{Name: "first", Steps: []pipeline.Step{{Step: fixStep("Step")}}}}}
+
+	// when
+	require.NoError(t, registry.Register(p))
+	err := registry.Register(p)
+
+	// then
+	assert.EqualError(t, err, "pipeline of the update operations already registered")
+}
+
+func TestDeclaredPipelines(t *testing.T) {
+	// given
+	deps := pipeline.Dependencies{DB: storage.NewMemoryStorage()}
+	var registry *pipeline.Registry
+
+	for _, cfg := range []pipeline.Config{
+		{},
+		{LifecycleManagerIntegrationDisabled: true, ReconcilerIntegrationDisabled: true},
+	} {
+		registry = pipeline.NewRegistry(nil)
+
+		// when
+		for _, p := range []*pipeline.Pipeline{
+			pipeline.NewProvisioningPipeline(cfg, deps),
+			pipeline.NewDeprovisioningPipeline(cfg, deps),
+			pipeline.NewUpdatePipeline(cfg, deps),
+			pipeline.NewUpgradeKymaPipeline(cfg, deps),
+			pipeline.NewUpgradeClusterPipeline(cfg, deps),
+		} {
+			// then
+			require.NoError(t, registry.Register(p))
+		}
+	}
+
+	t.Run("own cluster provisioning does not create the cluster", func(t *testing.T) {
+		// given
+		provisioning, found := registry.Get(internal.OperationTypeProvision)
+		require.True(t, found)
+
+		// when
+		view := provisioning.View(broker.OwnClusterPlanID, broker.PlanNamesMapping)
+
+		// then
+		assert.Equal(t, "own_cluster", view.Plan)
+		steps := stepNames(view.Stages[1].Steps)
+		assert.Contains(t, steps, "Create_Runtime_For_Own_Cluster")
+		assert.NotContains(t, steps, "Create_Runtime_Without_Kyma")
+	})
+
+	t.Run("disabled integrations are omitted", func(t *testing.T) {
+		// given
+		deprovisioning, found := registry.Get(internal.OperationTypeDeprovision)
+		require.True(t, found)
+
+		// when
+		view := deprovisioning.View("", broker.PlanNamesMapping)
+
+		// then
+		for _, stage := range view.Stages {
+			assert.NotEqual(t, "Delete_Kyma_Resource", stage.Name)
+			assert.NotEqual(t, "Deregister_Cluster", stage.Name)
+		}
+	})
+}
+
+func TestUpdatePipeline_ResumedBTPOperatorStage(t *testing.T) {
+	// given
+	db := storage.NewMemoryStorage()
+	inputFactory := &inputAutomock.CreatorForPlan{}
+	inputFactory.On("CreateUpgradeShootInput", mock.Anything, mock.Anything).Return(fixture.FixInputCreator(internal.Azure), nil)
+	componentsProvider := &inputAutomock.ComponentListProvider{}
+	componentsProvider.On("AllComponents", mock.Anything, mock.Anything).
+		Return([]internal.KymaComponent{{Name: update.BTPOperatorComponentName, Namespace: "kyma-system", Source: &internal.ComponentSource{URL: "https://btp-operator"}}}, nil)
+	deps := pipeline.Dependencies{
+		DB:                     db,
+		InputFactory:           inputFactory,
+		ComponentListProvider:  componentsProvider,
+		RuntimeVerConfigurator: runtimeversion.NewRuntimeVersionConfigurator("2.0", nil, db.RuntimeStates()),
+		K8sClientProvider: func(string) (client.Client, error) {
+			return fake.NewClientBuilder().Build(), nil
+		},
+	}
+	p := pipeline.NewUpdatePipeline(pipeline.Config{ReconcilerIntegrationDisabled: true}, deps)
+	manager := process.NewStagedManager(db.Operations(), event.NewPubSub(logrus.New()), time.Hour, nil, logrus.New())
+	require.NoError(t, p.Apply(manager))
+
+	// the operation resumed after the restart of KEB, the InputCreator of the finished "cluster" stage is lost
+	operation := fixture.FixUpdatingOperation("op-id", "inst-id").Operation
+	operation.State = domain.InProgress
+	operation.RuntimeID = "runtime-id"
+	operation.ProvisioningParameters.PlanID = broker.OwnClusterPlanID
+	operation.ProvisioningParameters.Parameters.Kubeconfig = "kubeconfig"
+	operation.ProvisioningParameters.ErsContext.SMOperatorCredentials = &internal.ServiceManagerOperatorCredentials{ClientID: "cid", ClientSecret: "cs"}
+	operation.InstanceDetails.ServiceManagerClusterID = "cluster-id"
+	operation.FinishedStages = []string{pipeline.UpdateClusterStage}
+	operation.InputCreator = nil
+	require.NoError(t, db.Operations().InsertOperation(operation))
+	runtimeState := fixture.FixRuntimeState("rs-id", "runtime-id", "provisioning-op-id")
+	clusterSetup := fixture.FixClusterSetup("runtime-id")
+	runtimeState.ClusterSetup = &clusterSetup
+	require.NoError(t, db.RuntimeStates().Insert(runtimeState))
+
+	// when
+	var err error
+	require.NotPanics(t, func() {
+		_, err = manager.Execute(operation.ID)
+	})
+
+	// then
+	require.NoError(t, err)
+	op, err := db.Operations().GetOperationByID(operation.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.Succeeded, op.State)
+	assert.True(t, op.RequiresReconcilerUpdate)
+}
+
+func stepNames(steps []pkg.StepDTO) []string {
+	var names []string
+	for _, step := range steps {
+		names = append(names, step.Name)
+	}
+	return names
+}
+
+type fakeStep struct {
+	name     string
+	executed *[]string
+}
+
+func fixStep(name string) *fakeStep {
+	return &fakeStep{name: name}
+}
+
+func fixRecordingStep(name string, executed *[]string) *fakeStep {
+	return &fakeStep{name: name, executed: executed}
+}
+
+func (s *fakeStep) Name() string {
+	return s.name
+}
+
+func (s *fakeStep) Run(operation internal.Operation, _ logrus.FieldLogger) (internal.Operation, time.Duration, error) {
+	if s.executed != nil {
+		*s.executed = append(*s.executed, s.name)
+	}
+	return operation, 0, nil
+}
@@ -0,0 +1,474 @@
+package pipeline
+
+import (
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/hyperscaler"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/avs"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/edp"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/ias"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/notification"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process/deprovisioning"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process/input"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process/provisioning"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process/steps"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process/update"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process/upgrade_cluster"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process/upgrade_kyma"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/provisioner"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/reconciler"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/runtimeversion"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	StartStage              = "start"
+	CreateRuntimeStage      = "create_runtime"
+	CheckKymaStage          = "check_kyma"
+	CreateKymaResourceStage = "create_kyma_resource"
+	PostActionsStage        = "post_actions"
+
+	UpdateClusterStage          = "cluster"
+	UpdateBTPOperatorStage      = "btp-operator"
+	UpdateBTPOperatorCheckStage = "btp-operator-check"
+	UpdateCheckStage            = "check"
+
+	UpgradeKymaStage    = "upgrade_kyma"
+	UpgradeClusterStage = "upgrade_cluster"
+	CheckClusterStage   = "check_cluster"
+)
+
+// Config enables the steps of the integrations and configures the time limits of the steps
+type Config struct {
+	Provisioner                         input.Config
+	Reconciler                          reconciler.Config
+	Avs                                 avs.Config
+	EDP                                 edp.Config
+	IAS                                 ias.Config
+	Notification                        notification.Config
+	LifecycleManagerIntegrationDisabled bool
+	ReconcilerIntegrationDisabled       bool
+}
+
+// Dependencies are the storages and clients used by the steps, each pipeline uses only some of them
+type Dependencies struct {
+	DB                     storage.BrokerStorage
+	ProvisionerClient      provisioner.Client
+	ReconcilerClient       reconciler.Client
+	EDPClient              provisioning.EDPClient
+	InputFactory           input.CreatorForPlan
+	ComponentListProvider  input.ComponentListProvider
+	RuntimeVerConfigurator *runtimeversion.RuntimeVersionConfigurator
+	RuntimeOverrides       provisioning.RuntimeOverridesAppender
+	PlanRegistry           *broker.PlanRegistry
+
+	AvsDelegator          *avs.Delegator
+	InternalEvalAssistant *avs.InternalEvalAssistant
+	ExternalEvalAssistant *avs.ExternalEvalAssistant
+	ExternalEvalCreator   *provisioning.ExternalEvalCreator
+	InternalEvalUpdater   *provisioning.InternalEvalUpdater
+	UpgradeEvalManager    *avs.EvaluationManager
+
+	AccountProvider     hyperscaler.AccountProvider
+	CustomerAccountPool hyperscaler.CustomerAccountPool
+	CredentialsVerifier hyperscaler.CredentialsVerifier
+	IASBundleBuilder    ias.BundleBuilder
+	NotificationBuilder notification.BundleBuilder
+
+	// KcpClient is the client of the Kyma Control Plane cluster, K8sClientProvider creates the clients of the Kyma runtimes
+	KcpClient         client.Client
+	K8sClientProvider func(kubeconfig string) (client.Client, error)
+
+	// ProvisioningQueue runs the provisioning which recreates the deprovisioned instance
+	ProvisioningQueue deprovisioning.Queue
+
+	UpgradeKymaSchedule    *upgrade_kyma.TimeSchedule
+	UpgradeClusterSchedule *upgrade_cluster.TimeSchedule
+}
+
+var (
+	notOwnCluster = except(broker.OwnClusterPlanID)
+	notPreview    = except(broker.PreviewPlanID)
+)
+
+// NewProvisioningPipeline declares the provisioning process which contains the following stages:
+//  1. "start" - changes the state from pending to in progress if no deprovisioning is ongoing.
+//  2. "create_runtime" - collects all information needed to make an input for the Provisioner request as overrides and labels.
+//     Those data is collected using an InputCreator which is not persisted. That's why all steps which prepares such data must be in the same stage as "create runtime step".
+//  3. "check_kyma" - checks if the Kyma is installed
+//  4. "create_kyma_resource" - creates the Kyma resource for the Lifecycle Manager
+//  5. "post_actions" - all steps which must be executed after the runtime is provisioned
+func NewProvisioningPipeline(cfg Config, deps Dependencies) *Pipeline {
+	db := deps.DB
+	return &Pipeline{
+		OperationType: internal.OperationTypeProvision,
+		Stages: []*Stage{
+			{
+				Name: StartStage,
+				Steps: []Step{
+					{Step: provisioning.NewStartStep(db.Operations(), db.Instances())},
+				},
+			},
+			{
+				Name: CreateRuntimeStage,
+				Steps: []Step{
+					{
+						Step:  provisioning.NewInitialisationStep(db.Operations(), db.Instances(), deps.InputFactory, deps.RuntimeVerConfigurator),
+						Input: CreatesInput,
+					},
+					{
+						Step:  steps.NewInitKymaTemplate(db.Operations()),
+						Input: NeedsInput,
+					},
+					{
+						Step:      provisioning.NewCreateCustomerSecretBindingStep(db.Operations(), deps.CustomerAccountPool, deps.CredentialsVerifier),
+						Condition: provisioning.WhenCustomerHyperscalerAccountProvided,
+						When:      "customer hyperscaler account provided",
+						Input:     NeedsInput,
+					},
+					{
+						Step:  provisioning.NewResolveCredentialsStep(db.Operations(), deps.AccountProvider),
+						Plans: notOwnCluster,
+						Input: NeedsInput,
+					},
+					{
+						Step:     provisioning.NewInternalEvaluationStep(deps.AvsDelegator, deps.InternalEvalAssistant),
+						Disabled: cfg.Avs.Disabled,
+					},
+					{
+						Step:     provisioning.NewEDPRegistrationStep(db.Operations(), deps.EDPClient, cfg.EDP),
+						Disabled: cfg.EDP.Disabled,
+						Plans:    notOwnCluster,
+					},
+					{
+						Step: provisioning.NewOverridesFromSecretsAndConfigStep(db.Operations(), deps.RuntimeOverrides, deps.RuntimeVerConfigurator, deps.PlanRegistry),
+						// Preview plan does not call Reconciler so it does not need overrides
+						Plans: notPreview,
+						Input: NeedsInput,
+					},
+					{
+						Step:      provisioning.NewBTPOperatorOverridesStep(db.Operations()),
+						Condition: provisioning.WhenBTPOperatorCredentialsProvided,
+						When:      "BTP Operator credentials provided",
+						Input:     NeedsInput,
+					},
+					{
+						Step:  provisioning.NewCreateRuntimeWithoutKymaStep(db.Operations(), db.RuntimeStates(), db.Instances(), deps.ProvisionerClient),
+						Plans: notOwnCluster,
+						Input: NeedsInput,
+					},
+					{
+						Step:  provisioning.NewCreateRuntimeForOwnClusterStep(db.Operations(), db.Instances()),
+						Plans: only(broker.OwnClusterPlanID),
+					},
+					{
+						Step:  provisioning.NewCheckRuntimeStep(db.Operations(), deps.ProvisionerClient, cfg.Provisioner.ProvisioningTimeout),
+						Plans: notOwnCluster,
+					},
+					{
+						Step: provisioning.NewGetKubeconfigStep(db.Operations(), deps.ProvisionerClient, deps.K8sClientProvider),
+					},
+					{
+						Step:      provisioning.NewInjectBTPOperatorCredentialsStep(db.Operations(), deps.K8sClientProvider),
+						Condition: provisioning.WhenBTPOperatorCredentialsProvided,
+						When:      "BTP Operator credentials provided",
+					},
+					{
+						Step:     steps.SyncKubeconfig(db.Operations(), deps.KcpClient, deps.PlanRegistry),
+						Disabled: cfg.LifecycleManagerIntegrationDisabled,
+					},
+					{
+						Step:     provisioning.NewCreateClusterConfiguration(db.Operations(), db.RuntimeStates(), deps.ReconcilerClient),
+						Disabled: cfg.ReconcilerIntegrationDisabled,
+						Plans:    notPreview,
+						Input:    NeedsInput,
+					},
+				},
+				// the compensations undo the started stage when the provisioning is canceled through the operations API
+				Compensations: []Step{
+					{
+						Step:  provisioning.NewAbortRuntimeCreationStep(db.Operations(), deps.ProvisionerClient, cfg.Provisioner.ProvisioningTimeout),
+						Plans: notOwnCluster,
+					},
+					// the hyperscaler account used by the created runtime is released by the deprovisioning of the instance
+					{
+						Step:      deprovisioning.NewDeleteCustomerSecretBindingStep(db.Operations(), deps.CustomerAccountPool),
+						Condition: provisioning.WhenRuntimeNotCreated,
+						When:      "runtime not created",
+					},
+					{
+						Step:      deprovisioning.NewReleaseSubscriptionStep(db.Operations(), db.Instances(), deps.AccountProvider),
+						Condition: provisioning.WhenRuntimeNotCreated,
+						When:      "runtime not created",
+					},
+				},
+			},
+			{
+				Name: CheckKymaStage,
+				Steps: []Step{
+					{
+						Step:     provisioning.NewCheckClusterConfigurationStep(db.Operations(), deps.ReconcilerClient, cfg.Reconciler.ProvisioningTimeout),
+						Disabled: cfg.ReconcilerIntegrationDisabled,
+						Plans:    notPreview,
+					},
+				},
+			},
+			{
+				Name: CreateKymaResourceStage,
+				Steps: []Step{
+					{
+						Step:     provisioning.NewApplyKymaStep(db.Operations(), deps.KcpClient, deps.PlanRegistry),
+						Disabled: cfg.LifecycleManagerIntegrationDisabled,
+					},
+				},
+			},
+			{
+				Name: PostActionsStage,
+				Steps: []Step{
+					{Step: provisioning.NewExternalEvalStep(deps.ExternalEvalCreator, deps.PlanRegistry)},
+					{
+						Step:  provisioning.NewRuntimeTagsStep(deps.InternalEvalUpdater, deps.ProvisionerClient),
+						Plans: notOwnCluster,
+					},
+				},
+			},
+		},
+	}
+}
+
+// NewUpdatePipeline declares the update process, the stages after the "cluster" stage apply the BTP Operator
+// credentials and the Kyma configuration. The initialisation step creates the InputCreator again in the "btp-operator" stage
+// because the InputCreator of the finished "cluster" stage is lost when the operation is resumed.
+func NewUpdatePipeline(cfg Config, deps Dependencies) *Pipeline {
+	db := deps.DB
+	initialisation := update.NewInitialisationStep(db.Instances(), db.Operations(), deps.RuntimeVerConfigurator, deps.InputFactory)
+	return &Pipeline{
+		OperationType: internal.OperationTypeUpdate,
+		Stages: []*Stage{
+			{
+				Name: UpdateClusterStage,
+				Steps: []Step{
+					{Step: initialisation, Input: CreatesInput},
+					{
+						Step:  update.NewUpgradeShootStep(db.Operations(), db.RuntimeStates(), deps.ProvisionerClient),
+						Plans: notOwnCluster,
+						Input: NeedsInput,
+					},
+				},
+			},
+			{
+				Name: UpdateBTPOperatorStage,
+				Steps: []Step{
+					{Step: initialisation, Input: CreatesInput, Rerun: true},
+					{Step: update.NewInitKymaVersionStep(db.Operations(), deps.RuntimeVerConfigurator, db.RuntimeStates())},
+					{
+						Step:      update.NewGetKubeconfigStep(db.Operations(), deps.ProvisionerClient, deps.K8sClientProvider),
+						Condition: update.ForBTPOperatorCredentialsProvided,
+						When:      "BTP Operator credentials provided",
+					},
+					{
+						Step:      update.NewBTPOperatorOverridesStep(db.Operations(), deps.ComponentListProvider),
+						Plans:     notPreview,
+						Condition: update.ForBTPOperatorCredentialsProvided,
+						When:      "BTP Operator credentials provided",
+						Input:     NeedsInput,
+					},
+					{
+						Step:     update.NewApplyReconcilerConfigurationStep(db.Operations(), db.RuntimeStates(), deps.ReconcilerClient),
+						Disabled: cfg.ReconcilerIntegrationDisabled,
+						// preview plan does not need any interaction with the Reconciler
+						Plans: notPreview,
+						Condition: func(operation internal.Operation) bool {
+							return operation.RequiresReconcilerUpdate
+						},
+						When: "the update requires the Reconciler update",
+					},
+				},
+			},
+			{
+				Name: UpdateBTPOperatorCheckStage,
+				Steps: []Step{
+					{
+						Step:      update.NewCheckReconcilerState(db.Operations(), deps.ReconcilerClient),
+						Condition: update.CheckReconcilerStatus,
+						When:      "the Reconciler status must be checked",
+					},
+				},
+			},
+			{
+				Name: UpdateCheckStage,
+				Steps: []Step{
+					{
+						Step:  update.NewCheckStep(db.Operations(), deps.ProvisionerClient, 40*time.Minute),
+						Plans: notOwnCluster,
+					},
+				},
+			},
+		},
+	}
+}
+
+// NewDeprovisioningPipeline declares the deprovisioning process, every step is run in its own stage
+func NewDeprovisioningPipeline(cfg Config, deps Dependencies) *Pipeline {
+	db := deps.DB
+	return stagePerStep(internal.OperationTypeDeprovision, []Step{
+		{Step: deprovisioning.NewInitStep(db.Operations(), db.Instances(), 12*time.Hour)},
+		{Step: deprovisioning.NewBTPOperatorCleanupStep(db.Operations(), deps.ProvisionerClient, deps.K8sClientProvider)},
+		{Step: deprovisioning.NewAvsEvaluationsRemovalStep(deps.AvsDelegator, db.Operations(), deps.ExternalEvalAssistant, deps.InternalEvalAssistant, deps.PlanRegistry)},
+		{
+			Step:     deprovisioning.NewEDPDeregistrationStep(db.Operations(), deps.EDPClient, cfg.EDP),
+			Disabled: cfg.EDP.Disabled,
+		},
+		{
+			Step:     deprovisioning.NewIASDeregistrationStep(db.Operations(), deps.IASBundleBuilder),
+			Disabled: cfg.IAS.Disabled,
+		},
+		{
+			Step:     deprovisioning.NewDeleteKymaResourceStep(db.Operations(), deps.KcpClient),
+			Disabled: cfg.LifecycleManagerIntegrationDisabled,
+		},
+		{
+			Step:     deprovisioning.NewCheckKymaResourceDeletedStep(db.Operations(), deps.KcpClient),
+			Disabled: cfg.LifecycleManagerIntegrationDisabled,
+		},
+		{
+			Step:     deprovisioning.NewDeregisterClusterStep(db.Operations(), deps.ReconcilerClient),
+			Disabled: cfg.ReconcilerIntegrationDisabled,
+		},
+		{
+			Step:     deprovisioning.NewCheckClusterDeregistrationStep(db.Operations(), deps.ReconcilerClient, 90*time.Minute),
+			Disabled: cfg.ReconcilerIntegrationDisabled,
+		},
+		{Step: deprovisioning.NewRemoveRuntimeStep(db.Operations(), db.Instances(), deps.ProvisionerClient, cfg.Provisioner.DeprovisioningTimeout)},
+		{Step: deprovisioning.NewCheckRuntimeRemovalStep(db.Operations(), db.Instances(), deps.ProvisionerClient)},
+		{Step: deprovisioning.NewDeleteCustomerSecretBindingStep(db.Operations(), deps.CustomerAccountPool)},
+		{Step: deprovisioning.NewReleaseSubscriptionStep(db.Operations(), db.Instances(), deps.AccountProvider)},
+		{
+			Step:     steps.DeleteKubeconfig(db.Operations(), deps.KcpClient),
+			Disabled: cfg.LifecycleManagerIntegrationDisabled,
+		},
+		{Step: deprovisioning.NewRemoveInstanceStep(db.Instances(), db.Operations())},
+		{Step: deprovisioning.NewRecreateInstanceStep(db.Operations(), db.Instances(), deps.ProvisioningQueue)},
+	})
+}
+
+func stagePerStep(operationType internal.OperationType, steps []Step) *Pipeline {
+	pipeline := &Pipeline{OperationType: operationType}
+	for _, step := range steps {
+		pipeline.Stages = append(pipeline.Stages, &Stage{Name: step.Step.Name(), Steps: []Step{step}})
+	}
+	return pipeline
+}
+
+// NewUpgradeKymaPipeline declares the upgrade Kyma process which contains the following stages:
+//  1. "upgrade_kyma" - prepares the upgrade with an InputCreator which is not persisted, that's why all steps which
+//     require the InputCreator must be run in this stage, and applies the cluster configuration
+//  2. "check_kyma" - checks if the cluster configuration is ready
+//
+// The initialisation step is run in both stages, it handles the pending operations and the concurrent deprovisioning.
+func NewUpgradeKymaPipeline(cfg Config, deps Dependencies) *Pipeline {
+	db := deps.DB
+	initialisation := upgrade_kyma.NewInitialisationStep(db.Operations(), db.Orchestrations(), db.Instances(),
+		deps.ProvisionerClient, deps.InputFactory, deps.UpgradeEvalManager, deps.UpgradeKymaSchedule, deps.RuntimeVerConfigurator, deps.NotificationBuilder)
+	return &Pipeline{
+		OperationType: internal.OperationTypeUpgradeKyma,
+		Stages: []*Stage{
+			{
+				Name: UpgradeKymaStage,
+				Steps: []Step{
+					{Step: initialisation, Input: CreatesInput},
+					{
+						Step:  steps.InitKymaTemplateUpgradeKyma(db.Operations()),
+						Input: NeedsInput,
+					},
+					{
+						Step:  upgrade_kyma.NewGetKubeconfigStep(db.Operations(), deps.ProvisionerClient),
+						Input: NeedsInput,
+					},
+					{
+						Step:     steps.SyncKubeconfigUpgradeKyma(db.Operations(), deps.KcpClient, deps.PlanRegistry),
+						Disabled: cfg.LifecycleManagerIntegrationDisabled,
+					},
+					{
+						Step:     upgrade_kyma.NewApplyKymaStep(db.Operations(), deps.KcpClient, deps.PlanRegistry),
+						Disabled: cfg.LifecycleManagerIntegrationDisabled,
+					},
+					{
+						Step:      upgrade_kyma.NewBTPOperatorOverridesStep(db.Operations()),
+						Condition: provisioning.WhenBTPOperatorCredentialsProvided,
+						When:      "BTP Operator credentials provided",
+						Input:     NeedsInput,
+					},
+					{
+						Step:  upgrade_kyma.NewOverridesFromSecretsAndConfigStep(db.Operations(), deps.RuntimeOverrides, deps.RuntimeVerConfigurator, deps.PlanRegistry),
+						Input: NeedsInput,
+					},
+					{
+						Step:     upgrade_kyma.NewSendNotificationStep(db.Operations(), deps.NotificationBuilder),
+						Disabled: cfg.Notification.Disabled,
+					},
+					{
+						Step:     upgrade_kyma.NewApplyClusterConfigurationStep(db.Operations(), db.RuntimeStates(), deps.ReconcilerClient),
+						Disabled: cfg.ReconcilerIntegrationDisabled,
+						Plans:    notPreview,
+						Input:    NeedsInput,
+					},
+				},
+			},
+			{
+				Name: CheckKymaStage,
+				Steps: []Step{
+					{Step: initialisation, Rerun: true},
+					{
+						Step:     upgrade_kyma.NewCheckClusterConfigurationStep(db.Operations(), deps.ReconcilerClient, deps.UpgradeEvalManager, cfg.Reconciler.ProvisioningTimeout),
+						Disabled: cfg.ReconcilerIntegrationDisabled,
+						Plans:    notPreview,
+					},
+				},
+			},
+		},
+	}
+}
+
+// NewUpgradeClusterPipeline declares the upgrade cluster process which contains the following stages:
+//  1. "upgrade_cluster" - prepares the upgrade with an InputCreator which is not persisted and sends the upgrade request to the Provisioner
+//  2. "check_cluster" - the initialisation step checks the status of the Provisioner operation
+func NewUpgradeClusterPipeline(cfg Config, deps Dependencies) *Pipeline {
+	db := deps.DB
+	initialisation := upgrade_cluster.NewInitialisationStep(db.Operations(), db.Orchestrations(), deps.ProvisionerClient,
+		deps.InputFactory, deps.UpgradeEvalManager, deps.UpgradeClusterSchedule, deps.NotificationBuilder)
+	return &Pipeline{
+		OperationType: internal.OperationTypeUpgradeCluster,
+		Stages: []*Stage{
+			{
+				Name: UpgradeClusterStage,
+				Steps: []Step{
+					{Step: initialisation, Input: CreatesInput},
+					{
+						Step:  upgrade_cluster.NewLogSkippingUpgradeStep(db.Operations()),
+						Plans: only(broker.OwnClusterPlanID),
+					},
+					{
+						Step:     upgrade_cluster.NewSendNotificationStep(db.Operations(), deps.NotificationBuilder),
+						Disabled: cfg.Notification.Disabled,
+						Plans:    notOwnCluster,
+					},
+					{
+						Step:  upgrade_cluster.NewUpgradeClusterStep(db.Operations(), db.RuntimeStates(), deps.ProvisionerClient, deps.UpgradeClusterSchedule),
+						Plans: notOwnCluster,
+						Input: NeedsInput,
+					},
+				},
+			},
+			{
+				Name: CheckClusterStage,
+				Steps: []Step{
+					{Step: initialisation, Rerun: true},
+				},
+			},
+		},
+	}
+}
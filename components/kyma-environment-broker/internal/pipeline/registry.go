@@ -0,0 +1,49 @@
+package pipeline
+
+import (
+	"fmt"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
+)
+
+// Registry holds the validated pipelines of the running KEB, one per operation type, and the plans the pipelines run for
+type Registry struct {
+	pipelines    []*Pipeline
+	planRegistry *broker.PlanRegistry
+}
+
+func NewRegistry(planRegistry *broker.PlanRegistry) *Registry {
+	return &Registry{planRegistry: planRegistry}
+}
+
+// Register validates the pipeline and adds it to the registry, it must be called after the hooks are added to the pipeline
+func (r *Registry) Register(pipeline *Pipeline) error {
+	if _, found := r.Get(pipeline.OperationType); found {
+		return fmt.Errorf("pipeline of the %s operations already registered", pipeline.OperationType)
+	}
+	if err := pipeline.Validate(); err != nil {
+		return fmt.Errorf("invalid pipeline: %w", err)
+	}
+	r.pipelines = append(r.pipelines, pipeline)
+	return nil
+}
+
+func (r *Registry) Get(operationType internal.OperationType) (*Pipeline, bool) {
+	for _, pipeline := range r.pipelines {
+		if pipeline.OperationType == operationType {
+			return pipeline, true
+		}
+	}
+	return nil, false
+}
+
+// PlanRegistry returns the plans the pipelines run for
+func (r *Registry) PlanRegistry() *broker.PlanRegistry {
+	return r.planRegistry
+}
+
+// Pipelines returns the registered pipelines in the registration order
+func (r *Registry) Pipelines() []*Pipeline {
+	return r.pipelines
+}
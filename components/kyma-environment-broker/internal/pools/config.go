@@ -0,0 +1,58 @@
+package pools
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Config struct {
+	// LowWatermarks sets the number of free secret bindings per hyperscaler type and EU access below which the pool is reported as low,
+	// e.g. aws=5,aws:eu=2,azure=10
+	LowWatermarks Watermarks `envconfig:"optional"`
+	// MaxShootsPerSharedAccount limits the number of shoots using one shared secret binding, 0 means no limit
+	MaxShootsPerSharedAccount int `envconfig:"default=0"`
+	// RefreshInterval defines how often the pools report is recomputed from Gardener
+	RefreshInterval time.Duration `envconfig:"default=5m"`
+}
+
+// euAccessSuffix marks the watermark of the pool with EU access, the pools of a hyperscaler type are split by EU access
+const euAccessSuffix = ":eu"
+
+// PoolKey identifies the pool of a hyperscaler type with or without EU access
+type PoolKey struct {
+	HyperscalerType string
+	EuAccess        bool
+}
+
+// Watermarks maps the pool to its low watermark
+type Watermarks map[PoolKey]int
+
+// Unmarshal parses the comma separated list of pools with their watermarks, e.g. aws=5,aws:eu=2,azure=10,
+// the hyperscaler type with the :eu suffix sets the watermark of the pool with EU access
+func (w *Watermarks) Unmarshal(s string) error {
+	watermarks := Watermarks{}
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 {
+			return fmt.Errorf("watermark %q must have the form <hyperscaler type>=<free secret bindings>", entry)
+		}
+		value, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+		if err != nil || value < 0 {
+			return fmt.Errorf("watermark of %s must be a non-negative number", parts[0])
+		}
+		pool := strings.TrimSpace(parts[0])
+		key := PoolKey{HyperscalerType: strings.TrimSuffix(pool, euAccessSuffix), EuAccess: strings.HasSuffix(pool, euAccessSuffix)}
+		if key.HyperscalerType == "" {
+			return fmt.Errorf("watermark %q must have the form <hyperscaler type>=<free secret bindings>", entry)
+		}
+		watermarks[key] = value
+	}
+	*w = watermarks
+	return nil
+}
@@ -0,0 +1,50 @@
+package pools
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	pkg "github.com/kyma-project/control-plane/components/kyma-environment-broker/common/pools"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/httputil"
+	"github.com/sirupsen/logrus"
+)
+
+// Handler exposes the admin API which reports the capacity of the hyperscaler account pools
+type Handler struct {
+	reporter *Reporter
+	log      logrus.FieldLogger
+}
+
+func NewHandler(reporter *Reporter, log logrus.FieldLogger) *Handler {
+	return &Handler{
+		reporter: reporter,
+		log:      log.WithField("service", "HyperscalerPoolsHandler"),
+	}
+}
+
+func (h *Handler) AttachRoutes(router *mux.Router) {
+	router.HandleFunc("/hyperscaler-pools", h.listPools).Methods(http.MethodGet)
+}
+
+func (h *Handler) listPools(w http.ResponseWriter, r *http.Request) {
+	pools, err := h.reporter.Pools()
+	if err != nil {
+		h.log.Errorf("while listing hyperscaler pools: %v", err)
+		httputil.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Errorf("while listing hyperscaler pools: %w", err))
+		return
+	}
+
+	filter := make(map[string]bool)
+	for _, hyperscalerType := range r.URL.Query()[pkg.HyperscalerTypeParam] {
+		filter[hyperscalerType] = true
+	}
+	response := pkg.PoolListDTO{Data: make([]pkg.PoolDTO, 0, len(pools))}
+	for _, pool := range pools {
+		if len(filter) == 0 || filter[pool.HyperscalerType] {
+			response.Data = append(response.Data, pool)
+		}
+	}
+	response.Count = len(response.Data)
+	httputil.WriteResponse(w, http.StatusOK, response)
+}
@@ -0,0 +1,133 @@
+package pools_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/gardener"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/hyperscaler"
+	pkg "github.com/kyma-project/control-plane/components/kyma-environment-broker/common/pools"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/pools"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const gardenerNamespace = "garden-kyma"
+
+func TestHandler(t *testing.T) {
+	// given
+	gardenerClient := gardener.NewDynamicFakeClient(
+		fixSecretBinding("sb1", map[string]interface{}{"hyperscalerType": "aws"}),
+		fixSecretBinding("sb2", map[string]interface{}{"hyperscalerType": "aws", "tenantName": "tenant1"}),
+		fixSecretBinding("sb3", map[string]interface{}{"hyperscalerType": "azure"}),
+		fixSecretBinding("sb4", map[string]interface{}{"hyperscalerType": "aws", "euAccess": "true"}),
+		fixSecretBinding("sb5", map[string]interface{}{"hyperscalerType": "aws", "euAccess": "true"}),
+	)
+	var watermarks pools.Watermarks
+	require.NoError(t, watermarks.Unmarshal("aws=2, aws:eu=2, azure:eu=1, gcp=1"))
+	reporter := pools.NewReporter(hyperscaler.NewPoolManager(gardenerClient, gardenerNamespace), pools.Config{LowWatermarks: watermarks, MaxShootsPerSharedAccount: 10}, logrus.New())
+	router := mux.NewRouter()
+	pools.NewHandler(reporter, logrus.New()).AttachRoutes(router)
+
+	t.Run("should report all pools", func(t *testing.T) {
+		// when
+		list := listPools(t, router, "/hyperscaler-pools")
+
+		// then
+		assert.Equal(t, []pkg.PoolDTO{
+			{HyperscalerType: "aws", Free: 1, Used: 1, MaxShootsPerSharedAccount: 10, LowWatermark: 2, BelowLowWatermark: true},
+			{HyperscalerType: "aws", EuAccess: true, Free: 2, MaxShootsPerSharedAccount: 10, LowWatermark: 2},
+			{HyperscalerType: "azure", Free: 1, MaxShootsPerSharedAccount: 10},
+			{HyperscalerType: "azure", EuAccess: true, MaxShootsPerSharedAccount: 10, LowWatermark: 1, BelowLowWatermark: true},
+			{HyperscalerType: "gcp", MaxShootsPerSharedAccount: 10, LowWatermark: 1, BelowLowWatermark: true},
+		}, list.Data)
+		assert.Equal(t, 5, list.Count)
+	})
+
+	t.Run("should filter pools by hyperscaler type", func(t *testing.T) {
+		// when
+		list := listPools(t, router, "/hyperscaler-pools?hyperscaler_type=azure")
+
+		// then
+		require.Len(t, list.Data, 2)
+		assert.Equal(t, "azure", list.Data[0].HyperscalerType)
+		assert.Equal(t, "azure", list.Data[1].HyperscalerType)
+	})
+}
+
+func TestReporter_Refresh(t *testing.T) {
+	// given
+	gardenerClient := gardener.NewDynamicFakeClient(
+		fixSecretBinding("sb1", map[string]interface{}{"hyperscalerType": "aws"}),
+	)
+	reporter := pools.NewReporter(hyperscaler.NewPoolManager(gardenerClient, gardenerNamespace), pools.Config{}, logrus.New())
+
+	first, err := reporter.Pools()
+	require.NoError(t, err)
+	_, err = gardenerClient.Resource(gardener.SecretBindingResource).Namespace(gardenerNamespace).
+		Create(context.Background(), fixSecretBinding("sb2", map[string]interface{}{"hyperscalerType": "aws"}), metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	// when
+	cached, err := reporter.Pools()
+	require.NoError(t, err)
+	require.NoError(t, reporter.Refresh())
+	refreshed, err := reporter.Pools()
+	require.NoError(t, err)
+
+	// then
+	assert.Equal(t, first, cached)
+	require.Len(t, refreshed, 1)
+	assert.Equal(t, 2, refreshed[0].Free)
+}
+
+func TestWatermarks_Unmarshal(t *testing.T) {
+	// given
+	var watermarks pools.Watermarks
+
+	// when
+	err := watermarks.Unmarshal("aws")
+
+	// then
+	assert.EqualError(t, err, `watermark "aws" must have the form <hyperscaler type>=<free secret bindings>`)
+	assert.EqualError(t, watermarks.Unmarshal("aws=-1"), "watermark of aws must be a non-negative number")
+	assert.EqualError(t, watermarks.Unmarshal(":eu=1"), `watermark ":eu=1" must have the form <hyperscaler type>=<free secret bindings>`)
+	require.NoError(t, watermarks.Unmarshal("aws=5, aws:eu=2"))
+	assert.Equal(t, pools.Watermarks{{HyperscalerType: "aws"}: 5, {HyperscalerType: "aws", EuAccess: true}: 2}, watermarks)
+}
+
+func listPools(t *testing.T, router *mux.Router, url string) pkg.PoolListDTO {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var list pkg.PoolListDTO
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &list))
+	return list
+}
+
+func fixSecretBinding(name string, labels map[string]interface{}) *unstructured.Unstructured {
+	secretBinding := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "core.gardener.cloud/v1beta1",
+		"kind":       "SecretBinding",
+		"metadata": map[string]interface{}{
+			"name":      name,
+			"namespace": gardenerNamespace,
+			"labels":    labels,
+		},
+		"secretRef": map[string]interface{}{
+			"name":      name,
+			"namespace": gardenerNamespace,
+		},
+	}}
+	return secretBinding
+}
@@ -0,0 +1,123 @@
+package pools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/hyperscaler"
+	pkg "github.com/kyma-project/control-plane/components/kyma-environment-broker/common/pools"
+	"github.com/sirupsen/logrus"
+)
+
+// Reporter combines the statistics of the hyperscaler account pools with the configured limits.
+// Computing the statistics lists all secret bindings and shoots in Gardener, so the report is cached
+// and refreshed periodically instead of on every request or metrics scrape.
+type Reporter struct {
+	manager hyperscaler.PoolManager
+	cfg     Config
+	log     logrus.FieldLogger
+
+	mu    sync.RWMutex
+	pools []pkg.PoolDTO
+}
+
+func NewReporter(manager hyperscaler.PoolManager, cfg Config, log logrus.FieldLogger) *Reporter {
+	return &Reporter{
+		manager: manager,
+		cfg:     cfg,
+		log:     log.WithField("service", "HyperscalerPoolsReporter"),
+	}
+}
+
+// Run refreshes the report periodically until the context is done
+func (r *Reporter) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.Refresh(); err != nil {
+				r.log.Errorf("while refreshing hyperscaler pools report: %s", err)
+			}
+		}
+	}
+}
+
+// Pools returns the cached capacity of all pools, the report is computed on the first call if it was not refreshed yet
+func (r *Reporter) Pools() ([]pkg.PoolDTO, error) {
+	r.mu.RLock()
+	pools := r.pools
+	r.mu.RUnlock()
+	if pools != nil {
+		return pools, nil
+	}
+
+	if err := r.Refresh(); err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.pools, nil
+}
+
+// Refresh computes the capacity of all pools and replaces the cached report,
+// a pool with a configured low watermark is reported even if it has no secret bindings,
+// the pools below the low watermark are exposed by the compass_keb_hyperscaler_pool_below_low_watermark metric
+func (r *Reporter) Refresh() error {
+	stats, err := r.manager.Stats()
+	if err != nil {
+		return fmt.Errorf("while getting hyperscaler pools statistics: %w", err)
+	}
+
+	reported := make(map[PoolKey]bool)
+	pools := make([]pkg.PoolDTO, 0, len(stats))
+	for _, s := range stats {
+		reported[PoolKey{HyperscalerType: string(s.HyperscalerType), EuAccess: s.EuAccess}] = true
+		pools = append(pools, r.toDTO(s))
+	}
+	for key := range r.cfg.LowWatermarks {
+		if !reported[key] {
+			pools = append(pools, r.toDTO(hyperscaler.PoolStats{HyperscalerType: hyperscaler.Type(key.HyperscalerType), EuAccess: key.EuAccess}))
+		}
+	}
+	sort.SliceStable(pools, func(i, j int) bool {
+		if pools[i].HyperscalerType != pools[j].HyperscalerType {
+			return pools[i].HyperscalerType < pools[j].HyperscalerType
+		}
+		return !pools[i].EuAccess && pools[j].EuAccess
+	})
+
+	for _, pool := range pools {
+		if pool.BelowLowWatermark {
+			r.log.Warnf("hyperscaler pool %s (euAccess: %v) has %d free secret bindings, below the low watermark %d", pool.HyperscalerType, pool.EuAccess, pool.Free, pool.LowWatermark)
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.pools = pools
+	return nil
+}
+
+func (r *Reporter) toDTO(stats hyperscaler.PoolStats) pkg.PoolDTO {
+	lowWatermark := r.cfg.LowWatermarks[PoolKey{HyperscalerType: string(stats.HyperscalerType), EuAccess: stats.EuAccess}]
+	return pkg.PoolDTO{
+		HyperscalerType:           string(stats.HyperscalerType),
+		EuAccess:                  stats.EuAccess,
+		Free:                      stats.Free,
+		Used:                      stats.Used,
+		Dirty:                     stats.Dirty,
+		Internal:                  stats.Internal,
+		Shared:                    stats.Shared,
+		SharedShoots:              stats.SharedShoots,
+		CustomerAccounts:          stats.CustomerAccounts,
+		MaxShootsPerSharedAccount: r.cfg.MaxShootsPerSharedAccount,
+		LowWatermark:              lowWatermark,
+		BelowLowWatermark:         lowWatermark > 0 && stats.Free < lowWatermark,
+	}
+}
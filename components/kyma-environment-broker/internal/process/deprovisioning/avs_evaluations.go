@@ -6,6 +6,7 @@ import (
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/retrypolicy"
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/avs"
@@ -19,15 +20,17 @@ type AvsEvaluationRemovalStep struct {
 	externalEvalAssistant avs.EvalAssistant
 	internalEvalAssistant avs.EvalAssistant
 	deProvisioningManager *process.OperationManager
+	planRegistry          *broker.PlanRegistry
 }
 
-func NewAvsEvaluationsRemovalStep(delegator *avs.Delegator, operationsStorage storage.Operations, externalEvalAssistant, internalEvalAssistant avs.EvalAssistant) *AvsEvaluationRemovalStep {
+func NewAvsEvaluationsRemovalStep(delegator *avs.Delegator, operationsStorage storage.Operations, externalEvalAssistant, internalEvalAssistant avs.EvalAssistant, planRegistry *broker.PlanRegistry) *AvsEvaluationRemovalStep {
 	return &AvsEvaluationRemovalStep{
 		delegator:             delegator,
 		operationsStorage:     operationsStorage,
 		externalEvalAssistant: externalEvalAssistant,
 		internalEvalAssistant: internalEvalAssistant,
 		deProvisioningManager: process.NewOperationManager(operationsStorage),
+		planRegistry:          planRegistry,
 	}
 }
 
@@ -35,6 +38,10 @@ func (ars *AvsEvaluationRemovalStep) Name() string {
 	return "De-provision_AVS_Evaluations"
 }
 
+func (ars *AvsEvaluationRemovalStep) UseRetryPolicies(policies *retrypolicy.Registry) {
+	ars.deProvisioningManager.UseRetryPolicies(policies, ars.Name())
+}
+
 func (ars *AvsEvaluationRemovalStep) Run(operation internal.Operation, logger logrus.FieldLogger) (internal.Operation, time.Duration, error) {
 	logger.Infof("Avs lifecycle %+v", operation.Avs)
 
@@ -44,7 +51,7 @@ func (ars *AvsEvaluationRemovalStep) Run(operation internal.Operation, logger lo
 		return ars.deProvisioningManager.RetryOperationWithoutFail(operation, ars.Name(), "error while deleting avs internal evaluation", 10*time.Second, 1*time.Minute, logger)
 	}
 
-	if broker.IsTrialPlan(operation.ProvisioningParameters.PlanID) || broker.IsFreemiumPlan(operation.ProvisioningParameters.PlanID) {
+	if broker.IsTrialPlan(operation.ProvisioningParameters.PlanID) || ars.planRegistry.IsFreemiumPlan(operation.ProvisioningParameters.PlanID) {
 		logger.Info("skipping AVS external evaluation deletion for trial/freemium plan")
 		return operation, 0, nil
 	}
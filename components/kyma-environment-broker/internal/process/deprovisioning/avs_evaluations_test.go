@@ -49,9 +49,9 @@ func TestAvsEvaluationsRemovalStep_Run(t *testing.T) {
 	avsClient, err := avs.NewClient(context.TODO(), avsConfig, logrus.New())
 	assert.NoError(t, err)
 	avsDel := avs.NewDelegator(avsClient, avsConfig, memoryStorage.Operations())
-	internalEvalAssistant := avs.NewInternalEvalAssistant(avsConfig)
-	externalEvalAssistant := avs.NewExternalEvalAssistant(avsConfig)
-	step := NewAvsEvaluationsRemovalStep(avsDel, memoryStorage.Operations(), externalEvalAssistant, internalEvalAssistant)
+	internalEvalAssistant := avs.NewInternalEvalAssistant(avsConfig, nil)
+	externalEvalAssistant := avs.NewExternalEvalAssistant(avsConfig, nil)
+	step := NewAvsEvaluationsRemovalStep(avsDel, memoryStorage.Operations(), externalEvalAssistant, internalEvalAssistant, nil)
 
 	assert.Equal(t, 0, len(evalIdsHolder))
 	assert.Equal(t, 0, len(parentEvalIdHolder))
@@ -103,9 +103,9 @@ func TestAvsEvaluationsRemovalWhenAlreadyDeleted_Run(t *testing.T) {
 	avsClient, err := avs.NewClient(context.TODO(), avsConfig, logrus.New())
 	assert.NoError(t, err)
 	avsDel := avs.NewDelegator(avsClient, avsConfig, memoryStorage.Operations())
-	internalEvalAssistant := avs.NewInternalEvalAssistant(avsConfig)
-	externalEvalAssistant := avs.NewExternalEvalAssistant(avsConfig)
-	step := NewAvsEvaluationsRemovalStep(avsDel, memoryStorage.Operations(), externalEvalAssistant, internalEvalAssistant)
+	internalEvalAssistant := avs.NewInternalEvalAssistant(avsConfig, nil)
+	externalEvalAssistant := avs.NewExternalEvalAssistant(avsConfig, nil)
+	step := NewAvsEvaluationsRemovalStep(avsDel, memoryStorage.Operations(), externalEvalAssistant, internalEvalAssistant, nil)
 
 	// when
 	deProvisioningOperation, repeat, err := step.Run(deProvisioningOperation, logger)
@@ -142,9 +142,9 @@ func TestExternalAvsEvaluationsRemovalSkipForTrialPlan_Run(t *testing.T) {
 	avsClient, err := avs.NewClient(context.TODO(), avsConfig, logrus.New())
 	assert.NoError(t, err)
 	avsDel := avs.NewDelegator(avsClient, avsConfig, memoryStorage.Operations())
-	internalEvalAssistant := avs.NewInternalEvalAssistant(avsConfig)
-	externalEvalAssistant := avs.NewExternalEvalAssistant(avsConfig)
-	step := NewAvsEvaluationsRemovalStep(avsDel, memoryStorage.Operations(), externalEvalAssistant, internalEvalAssistant)
+	internalEvalAssistant := avs.NewInternalEvalAssistant(avsConfig, nil)
+	externalEvalAssistant := avs.NewExternalEvalAssistant(avsConfig, nil)
+	step := NewAvsEvaluationsRemovalStep(avsDel, memoryStorage.Operations(), externalEvalAssistant, internalEvalAssistant, nil)
 
 	// when
 	deProvisioningOperation, repeat, err := step.Run(deProvisioningOperation, logger)
@@ -179,9 +179,9 @@ func TestExternalAvsEvaluationsRemovalSkipForFreemiumPlan_Run(t *testing.T) {
 	avsClient, err := avs.NewClient(context.TODO(), avsConfig, logrus.New())
 	assert.NoError(t, err)
 	avsDel := avs.NewDelegator(avsClient, avsConfig, memoryStorage.Operations())
-	internalEvalAssistant := avs.NewInternalEvalAssistant(avsConfig)
-	externalEvalAssistant := avs.NewExternalEvalAssistant(avsConfig)
-	step := NewAvsEvaluationsRemovalStep(avsDel, memoryStorage.Operations(), externalEvalAssistant, internalEvalAssistant)
+	internalEvalAssistant := avs.NewInternalEvalAssistant(avsConfig, nil)
+	externalEvalAssistant := avs.NewExternalEvalAssistant(avsConfig, nil)
+	step := NewAvsEvaluationsRemovalStep(avsDel, memoryStorage.Operations(), externalEvalAssistant, internalEvalAssistant, nil)
 
 	// when
 	deProvisioningOperation, repeat, err := step.Run(deProvisioningOperation, logger)
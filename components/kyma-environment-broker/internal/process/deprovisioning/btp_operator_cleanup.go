@@ -12,6 +12,7 @@ import (
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/provisioner"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/tracing"
 	"github.com/sirupsen/logrus"
 	k8serrors2 "k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -230,7 +231,7 @@ func (s *BTPOperatorCleanupStep) attemptToRemoveFinalizers(op internal.Operation
 }
 
 func (s *BTPOperatorCleanupStep) getKubeClient(operation internal.Operation, log logrus.FieldLogger) (client.Client, error) {
-	status, err := s.provisionerClient.RuntimeStatus(operation.ProvisioningParameters.ErsContext.GlobalAccountID, operation.RuntimeID)
+	status, err := s.provisionerClient.RuntimeStatus(tracing.StepContext(operation), operation.ProvisioningParameters.ErsContext.GlobalAccountID, operation.RuntimeID)
 	if err != nil {
 		if s.isNotFoundErr(err) {
 			log.Info("Cannot get kubeconfig: instance not found in provisioner")
@@ -276,31 +276,35 @@ func newEmptyProvisionerClient() fakeProvisionerClient {
 	return fakeProvisionerClient{true}
 }
 
-func (f fakeProvisionerClient) ProvisionRuntime(accountID, subAccountID string, config gqlschema.ProvisionRuntimeInput) (gqlschema.OperationStatus, error) {
+func (f fakeProvisionerClient) ProvisionRuntime(ctx context.Context, accountID, subAccountID string, config gqlschema.ProvisionRuntimeInput) (gqlschema.OperationStatus, error) {
 	panic("not implemented")
 }
 
-func (f fakeProvisionerClient) DeprovisionRuntime(accountID, runtimeID string) (string, error) {
+func (f fakeProvisionerClient) DeprovisionRuntime(ctx context.Context, accountID, runtimeID string) (string, error) {
 	panic("not implemented")
 }
 
-func (f fakeProvisionerClient) UpgradeRuntime(accountID, runtimeID string, config gqlschema.UpgradeRuntimeInput) (gqlschema.OperationStatus, error) {
+func (f fakeProvisionerClient) UpgradeRuntime(ctx context.Context, accountID, runtimeID string, config gqlschema.UpgradeRuntimeInput) (gqlschema.OperationStatus, error) {
 	panic("not implemented")
 }
 
-func (f fakeProvisionerClient) UpgradeShoot(accountID, runtimeID string, config gqlschema.UpgradeShootInput) (gqlschema.OperationStatus, error) {
+func (f fakeProvisionerClient) UpgradeShoot(ctx context.Context, accountID, runtimeID string, config gqlschema.UpgradeShootInput) (gqlschema.OperationStatus, error) {
 	panic("not implemented")
 }
 
-func (f fakeProvisionerClient) ReconnectRuntimeAgent(accountID, runtimeID string) (string, error) {
+func (f fakeProvisionerClient) HibernateRuntime(ctx context.Context, accountID, runtimeID string) (gqlschema.OperationStatus, error) {
 	panic("not implemented")
 }
 
-func (f fakeProvisionerClient) RuntimeOperationStatus(accountID, operationID string) (gqlschema.OperationStatus, error) {
+func (f fakeProvisionerClient) ReconnectRuntimeAgent(ctx context.Context, accountID, runtimeID string) (string, error) {
 	panic("not implemented")
 }
 
-func (f fakeProvisionerClient) RuntimeStatus(accountID, runtimeID string) (gqlschema.RuntimeStatus, error) {
+func (f fakeProvisionerClient) RuntimeOperationStatus(ctx context.Context, accountID, operationID string) (gqlschema.OperationStatus, error) {
+	panic("not implemented")
+}
+
+func (f fakeProvisionerClient) RuntimeStatus(ctx context.Context, accountID, runtimeID string) (gqlschema.RuntimeStatus, error) {
 	if f.empty {
 		return gqlschema.RuntimeStatus{}, fmt.Errorf("not found")
 	}
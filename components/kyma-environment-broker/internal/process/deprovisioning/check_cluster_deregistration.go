@@ -5,7 +5,9 @@ import (
 	"time"
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/retrypolicy"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/tracing"
 
 	reconcilerApi "github.com/kyma-incubator/reconciler/pkg/keb"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
@@ -32,6 +34,10 @@ func (s *CheckClusterDeregistrationStep) Name() string {
 	return "Check_Cluster_Deregistration"
 }
 
+func (s *CheckClusterDeregistrationStep) UseRetryPolicies(policies *retrypolicy.Registry) {
+	s.operationManager.UseRetryPolicies(policies, s.Name())
+}
+
 func (s *CheckClusterDeregistrationStep) Run(operation internal.Operation, log logrus.FieldLogger) (internal.Operation, time.Duration, error) {
 	if !operation.ClusterConfigurationDeleted {
 		log.Infof("Cluster deregistration has not be executed, skipping")
@@ -41,8 +47,15 @@ func (s *CheckClusterDeregistrationStep) Run(operation internal.Operation, log l
 		log.Info("ClusterConfigurationVersion is zero, skipping")
 		return operation, 0, nil
 	}
-	if operation.TimeSinceReconcilerDeregistrationTriggered() > s.timeout {
-		log.Errorf("Cluster deregistration has reached the time limit: %s", s.timeout)
+	policy := s.operationManager.RetryPolicy(operation, s.Name(), retrypolicy.Policy{
+		MaxDuration:  s.timeout,
+		OnExhaustion: retrypolicy.ExhaustionContinue,
+	})
+	if operation.TimeSinceReconcilerDeregistrationTriggered() > policy.MaxDuration {
+		log.Errorf("Cluster deregistration has reached the time limit: %s", policy.MaxDuration)
+		if policy.OnExhaustion == retrypolicy.ExhaustionFail {
+			return s.operationManager.OperationFailed(operation, "Cluster deregistration has reached the time limit", nil, log)
+		}
 		modifiedOp, d, _ := s.operationManager.UpdateOperation(operation, func(op *internal.Operation) {
 			op.ClusterConfigurationVersion = 0
 			op.ExcutedButNotCompleted = append(operation.ExcutedButNotCompleted, s.Name())
@@ -50,7 +63,7 @@ func (s *CheckClusterDeregistrationStep) Run(operation internal.Operation, log l
 		return modifiedOp, d, nil
 	}
 
-	state, err := s.reconcilerClient.GetCluster(operation.RuntimeID, operation.ClusterConfigurationVersion)
+	state, err := s.reconcilerClient.GetCluster(tracing.StepContext(operation), operation.RuntimeID, operation.ClusterConfigurationVersion)
 	if kebError.IsNotFoundError(err) {
 		log.Info("cluster already deleted")
 		modifiedOp, d, _ := s.operationManager.UpdateOperation(operation, func(op *internal.Operation) {
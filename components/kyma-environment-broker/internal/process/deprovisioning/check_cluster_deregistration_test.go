@@ -1,6 +1,8 @@
 package deprovisioning
 
 import (
+	"context"
+
 	"testing"
 	"time"
 
@@ -41,7 +43,7 @@ func TestCheckClusterDeregistrationStep(t *testing.T) {
 			operation.ClusterConfigurationVersion = 1
 			operation.ClusterConfigurationDeleted = true
 			recClient := reconciler.NewFakeClient()
-			recClient.ApplyClusterConfig(reconcilerApi.Cluster{
+			recClient.ApplyClusterConfig(context.Background(), reconcilerApi.Cluster{
 				RuntimeID:    operation.RuntimeID,
 				RuntimeInput: reconcilerApi.RuntimeInput{},
 				KymaConfig:   reconcilerApi.KymaConfig{},
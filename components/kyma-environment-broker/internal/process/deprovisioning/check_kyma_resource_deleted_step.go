@@ -14,6 +14,7 @@ import (
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/retrypolicy"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
 )
 
@@ -33,6 +34,10 @@ func (step *CheckKymaResourceDeletedStep) Name() string {
 	return "Check_Kyma_Resource_Deleted"
 }
 
+func (step *CheckKymaResourceDeletedStep) UseRetryPolicies(policies *retrypolicy.Registry) {
+	step.operationManager.UseRetryPolicies(policies, step.Name())
+}
+
 func (step *CheckKymaResourceDeletedStep) Run(operation internal.Operation, logger logrus.FieldLogger) (internal.Operation, time.Duration, error) {
 	if operation.KymaResourceNamespace == "" {
 		logger.Warnf("namespace for Kyma resource not specified")
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/tracing"
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
@@ -55,7 +56,7 @@ func (s *CheckRuntimeRemovalStep) Run(operation internal.Operation, log logrus.F
 		return operation, 1 * time.Second, nil
 	}
 
-	status, err := s.provisionerClient.RuntimeOperationStatus(instance.GlobalAccountID, operation.ProvisionerOperationID)
+	status, err := s.provisionerClient.RuntimeOperationStatus(tracing.StepContext(operation), instance.GlobalAccountID, operation.ProvisionerOperationID)
 	if err != nil {
 		log.Errorf("call to provisioner RuntimeOperationStatus failed: %s, GlobalAccountID=%s, Provisioner OperationID=%s", err.Error(), instance.GlobalAccountID, operation.ProvisionerOperationID)
 		return operation, 1 * time.Minute, nil
@@ -1,6 +1,8 @@
 package deprovisioning
 
 import (
+	"context"
+
 	"testing"
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
@@ -34,7 +36,7 @@ func TestCheckRuntimeRemovalStep(t *testing.T) {
 				GlobalAccountID: "global-acc",
 				InstanceID:      dOp.InstanceID,
 			})
-			provisionerOp, _ := provisionerClient.DeprovisionRuntime(dOp.GlobalAccountID, dOp.RuntimeID)
+			provisionerOp, _ := provisionerClient.DeprovisionRuntime(context.Background(), dOp.GlobalAccountID, dOp.RuntimeID)
 			provisionerClient.FinishProvisionerOperation(provisionerOp, tc.givenState)
 			dOp.ProvisionerOperationID = provisionerOp
 
@@ -60,7 +62,7 @@ func TestCheckRuntimeRemovalStep_ProvisionerFailed(t *testing.T) {
 		GlobalAccountID: "global-acc",
 		InstanceID:      dOp.InstanceID,
 	})
-	provisionerOp, _ := provisionerClient.DeprovisionRuntime(dOp.GlobalAccountID, dOp.RuntimeID)
+	provisionerOp, _ := provisionerClient.DeprovisionRuntime(context.Background(), dOp.GlobalAccountID, dOp.RuntimeID)
 	provisionerClient.FinishProvisionerOperation(provisionerOp, gqlschema.OperationStateFailed)
 	dOp.ProvisionerOperationID = provisionerOp
 
@@ -0,0 +1,49 @@
+package deprovisioning
+
+import (
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/hyperscaler"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/retrypolicy"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/sirupsen/logrus"
+)
+
+// DeleteCustomerSecretBindingStep removes the secret binding created from the customer hyperscaler account,
+// it must run after the runtime is removed, the secret binding is dedicated to the instance and is never returned to the pool
+type DeleteCustomerSecretBindingStep struct {
+	operationManager *process.OperationManager
+	customerAccounts hyperscaler.CustomerAccountPool
+}
+
+var _ process.Step = &DeleteCustomerSecretBindingStep{}
+
+func NewDeleteCustomerSecretBindingStep(os storage.Operations, customerAccounts hyperscaler.CustomerAccountPool) *DeleteCustomerSecretBindingStep {
+	return &DeleteCustomerSecretBindingStep{
+		operationManager: process.NewOperationManager(os),
+		customerAccounts: customerAccounts,
+	}
+}
+
+func (s *DeleteCustomerSecretBindingStep) Name() string {
+	return "Delete_Customer_Secret_Binding"
+}
+
+func (s *DeleteCustomerSecretBindingStep) UseRetryPolicies(policies *retrypolicy.Registry) {
+	s.operationManager.UseRetryPolicies(policies, s.Name())
+}
+
+func (s *DeleteCustomerSecretBindingStep) Run(operation internal.Operation, log logrus.FieldLogger) (internal.Operation, time.Duration, error) {
+	if !operation.ProvisioningParameters.Parameters.IsCustomerAccount() {
+		return operation, 0, nil
+	}
+
+	if err := s.customerAccounts.DeleteSecretBinding(operation.InstanceID); err != nil {
+		log.Errorf("unable to delete the secret binding of the customer hyperscaler account: %s", err)
+		return s.operationManager.RetryOperationWithoutFail(operation, s.Name(), "unable to delete the secret binding of the customer hyperscaler account", 10*time.Second, 5*time.Minute, log)
+	}
+	log.Infof("Deleted the secret binding of the customer hyperscaler account")
+	return operation, 0, nil
+}
@@ -0,0 +1,41 @@
+package deprovisioning
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/gardener"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/hyperscaler"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDeleteCustomerSecretBindingStep_Run(t *testing.T) {
+	// given
+	const gardenerNamespace = "garden-kyma"
+	gardenerClient := gardener.NewDynamicFakeClient()
+	customerAccounts := hyperscaler.NewCustomerAccountPool(gardenerClient, gardenerNamespace)
+	name, err := customerAccounts.CreateSecretBinding(hyperscaler.AWS, instanceID, map[string]string{})
+	require.NoError(t, err)
+
+	memoryStorage := storage.NewMemoryStorage()
+	operation := fixDeprovisioningOperationWithPlanID(broker.AWSPlanID)
+	operation.ProvisioningParameters.Parameters.HyperscalerAccount = &internal.HyperscalerAccountDTO{}
+
+	step := NewDeleteCustomerSecretBindingStep(memoryStorage.Operations(), customerAccounts)
+
+	// when
+	_, repeat, err := step.Run(operation, logrus.New())
+
+	// then
+	require.NoError(t, err)
+	assert.Zero(t, repeat)
+	_, err = gardenerClient.Resource(gardener.SecretBindingResource).Namespace(gardenerNamespace).Get(context.Background(), name, metav1.GetOptions{})
+	assert.True(t, errors.IsNotFound(err))
+}
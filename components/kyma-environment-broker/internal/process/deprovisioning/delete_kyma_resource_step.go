@@ -14,6 +14,7 @@ import (
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/retrypolicy"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
 )
 
@@ -38,6 +39,10 @@ func (step *DeleteKymaResourceStep) Name() string {
 	return "Delete_Kyma_Resource"
 }
 
+func (step *DeleteKymaResourceStep) UseRetryPolicies(policies *retrypolicy.Registry) {
+	step.operationManager.UseRetryPolicies(policies, step.Name())
+}
+
 func (step *DeleteKymaResourceStep) Run(operation internal.Operation, logger logrus.FieldLogger) (internal.Operation, time.Duration, error) {
 	if operation.KymaResourceNamespace == "" {
 		logger.Warnf("namespace for Kyma resource not specified")
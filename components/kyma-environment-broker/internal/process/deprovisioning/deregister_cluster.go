@@ -8,6 +8,7 @@ import (
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/reconciler"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/tracing"
 	"github.com/sirupsen/logrus"
 )
 
@@ -37,7 +38,7 @@ func (s *DeregisterClusterStep) Run(operation internal.Operation, log logrus.Fie
 		log.Info("Cluster configuration was deleted, skipping")
 		return operation, 0, nil
 	}
-	err := s.reconcilerClient.DeleteCluster(operation.RuntimeID)
+	err := s.reconcilerClient.DeleteCluster(tracing.StepContext(operation), operation.RuntimeID)
 	if err != nil {
 		return s.handleError(operation, err, log, "cannot remove DataTenant")
 	}
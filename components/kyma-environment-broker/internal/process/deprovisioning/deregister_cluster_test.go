@@ -1,6 +1,8 @@
 package deprovisioning
 
 import (
+	"context"
+
 	"testing"
 
 	reconcilerApi "github.com/kyma-incubator/reconciler/pkg/keb"
@@ -20,7 +22,7 @@ func TestDeregisterClusterStep_Run(t *testing.T) {
 	op.ClusterConfigurationVersion = 1
 	memoryStorage.Operations().InsertDeprovisioningOperation(op)
 	op.RuntimeID = "runtime-id"
-	cli.ApplyClusterConfig(reconcilerApi.Cluster{
+	cli.ApplyClusterConfig(context.Background(), reconcilerApi.Cluster{
 		RuntimeID: op.RuntimeID,
 	})
 
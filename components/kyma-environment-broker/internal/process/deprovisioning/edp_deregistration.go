@@ -1,6 +1,8 @@
 package deprovisioning
 
 import (
+	"context"
+
 	"fmt"
 	"strings"
 	"time"
@@ -10,14 +12,15 @@ import (
 	kebError "github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/error"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/tracing"
 
 	"github.com/sirupsen/logrus"
 )
 
 //go:generate mockery --name=EDPClient --output=automock --outpkg=automock --case=underscore
 type EDPClient interface {
-	DeleteDataTenant(name, env string) error
-	DeleteMetadataTenant(name, env, key string) error
+	DeleteDataTenant(ctx context.Context, name, env string) error
+	DeleteMetadataTenant(ctx context.Context, name, env, key string) error
 }
 
 type EDPDeregistrationStep struct {
@@ -48,14 +51,14 @@ func (s *EDPDeregistrationStep) Run(operation internal.Operation, log logrus.Fie
 		edp.MaasConsumerSubAccountKey,
 		edp.MaasConsumerServicePlan,
 	} {
-		err := s.client.DeleteMetadataTenant(subAccountID, s.config.Environment, key)
+		err := s.client.DeleteMetadataTenant(tracing.StepContext(operation), subAccountID, s.config.Environment, key)
 		if err != nil {
 			return s.handleError(operation, err, log, fmt.Sprintf("cannot remove DataTenant metadata with key: %s", key))
 		}
 	}
 
 	log.Info("Delete DataTenant")
-	err := s.client.DeleteDataTenant(subAccountID, s.config.Environment)
+	err := s.client.DeleteDataTenant(tracing.StepContext(operation), subAccountID, s.config.Environment)
 	if err != nil {
 		return s.handleError(operation, err, log, "cannot remove DataTenant")
 	}
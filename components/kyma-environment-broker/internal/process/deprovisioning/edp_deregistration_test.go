@@ -1,6 +1,8 @@
 package deprovisioning
 
 import (
+	"context"
+
 	"encoding/base64"
 	"fmt"
 	"testing"
@@ -21,7 +23,7 @@ const (
 func TestEDPDeregistration_Run(t *testing.T) {
 	// given
 	client := edp.NewFakeClient()
-	err := client.CreateDataTenant(edp.DataTenantPayload{
+	err := client.CreateDataTenant(context.Background(), edp.DataTenantPayload{
 		Name:        edpName,
 		Environment: edpEnvironment,
 		Secret:      base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%s%s", edpName, edpEnvironment))),
@@ -36,7 +38,7 @@ func TestEDPDeregistration_Run(t *testing.T) {
 	}
 
 	for _, key := range metadataTenantKeys {
-		err = client.CreateMetadataTenant(edpName, edpEnvironment, edp.MetadataTenantPayload{
+		err = client.CreateMetadataTenant(context.Background(), edpName, edpEnvironment, edp.MetadataTenantPayload{
 			Key:   key,
 			Value: "-",
 		})
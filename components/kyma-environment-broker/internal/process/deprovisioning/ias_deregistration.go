@@ -7,6 +7,7 @@ import (
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/ias"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/retrypolicy"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
 
 	"github.com/sirupsen/logrus"
@@ -28,6 +29,10 @@ func (s *IASDeregistrationStep) Name() string {
 	return "IAS_Deregistration"
 }
 
+func (s *IASDeregistrationStep) UseRetryPolicies(policies *retrypolicy.Registry) {
+	s.operationManager.UseRetryPolicies(policies, s.Name())
+}
+
 func (s *IASDeregistrationStep) Run(operation internal.Operation, log logrus.FieldLogger) (internal.Operation, time.Duration, error) {
 	for spID := range ias.ServiceProviderInputs {
 		spb, err := s.bundleBuilder.NewBundle(operation.InstanceID, spID)
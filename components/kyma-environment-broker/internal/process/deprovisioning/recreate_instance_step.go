@@ -0,0 +1,108 @@
+package deprovisioning
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Queue is the provisioning queue which processes the provisioning operation created by the RecreateInstanceStep
+type Queue interface {
+	Add(processId string)
+}
+
+// RecreateInstanceStep creates the provisioning operation of the instance after the deprovisioning with the Recreate flag.
+// The provisioning operation waits in the start stage until the deprovisioning is finished.
+type RecreateInstanceStep struct {
+	operationManager  *process.OperationManager
+	instanceStorage   storage.Instances
+	operationStorage  storage.Operations
+	provisioningQueue Queue
+}
+
+var _ process.Step = &RecreateInstanceStep{}
+
+func NewRecreateInstanceStep(operationStorage storage.Operations, instanceStorage storage.Instances, provisioningQueue Queue) *RecreateInstanceStep {
+	return &RecreateInstanceStep{
+		operationManager:  process.NewOperationManager(operationStorage),
+		instanceStorage:   instanceStorage,
+		operationStorage:  operationStorage,
+		provisioningQueue: provisioningQueue,
+	}
+}
+
+func (s *RecreateInstanceStep) Name() string {
+	return "Recreate_Instance"
+}
+
+func (s *RecreateInstanceStep) Run(operation internal.Operation, log logrus.FieldLogger) (internal.Operation, time.Duration, error) {
+	if !operation.Recreate {
+		return operation, 0, nil
+	}
+
+	instance, err := s.instanceStorage.GetByID(operation.InstanceID)
+	switch {
+	case err == nil:
+	case dberr.IsNotFound(err):
+		return s.operationManager.OperationFailed(operation, "instance to recreate does not exist", err, log)
+	default:
+		log.Errorf("unable to get instance from the storage: %s", err)
+		return operation, 10 * time.Second, nil
+	}
+
+	// the ID is stored first, so the provisioning operation is created only once when the step is retried
+	if operation.RecreationOperationID == "" {
+		var backoff time.Duration
+		operation, backoff, _ = s.operationManager.UpdateOperation(operation, func(op *internal.Operation) {
+			op.RecreationOperationID = uuid.New().String()
+		}, log)
+		if backoff != 0 {
+			return operation, backoff, nil
+		}
+	}
+
+	_, err = s.operationStorage.GetProvisioningOperationByID(operation.RecreationOperationID)
+	switch {
+	case err == nil:
+		log.Infof("Provisioning operation %s recreating the instance already exists", operation.RecreationOperationID)
+	case dberr.IsNotFound(err):
+		provisioning, err := newRecreationProvisioningOperation(operation.RecreationOperationID, instance)
+		if err != nil {
+			return s.operationManager.OperationFailed(operation, "unable to create the provisioning operation", err, log)
+		}
+		if err := s.operationStorage.InsertProvisioningOperation(provisioning); err != nil {
+			log.Errorf("unable to save provisioning operation: %s", err)
+			return operation, 10 * time.Second, nil
+		}
+		log.Infof("Created provisioning operation %s recreating the instance", provisioning.ID)
+	default:
+		log.Errorf("unable to get provisioning operation from the storage: %s", err)
+		return operation, 10 * time.Second, nil
+	}
+
+	s.provisioningQueue.Add(operation.RecreationOperationID)
+	return operation, 0, nil
+}
+
+// newRecreationProvisioningOperation provisions the instance with the stored parameters, the same way as the unsuspension
+func newRecreationProvisioningOperation(operationID string, instance *internal.Instance) (internal.ProvisioningOperation, error) {
+	operation, err := internal.NewProvisioningOperationWithID(operationID, instance.InstanceID, instance.Parameters)
+	if err != nil {
+		return operation, err
+	}
+	operation.InstanceDetails, err = instance.GetInstanceDetails()
+	if err != nil {
+		return operation, err
+	}
+	operation.State = orchestration.Pending
+	operation.RuntimeID = ""
+	operation.DashboardURL = instance.DashboardURL
+	return operation, nil
+}
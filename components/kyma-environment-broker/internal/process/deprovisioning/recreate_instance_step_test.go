@@ -0,0 +1,71 @@
+package deprovisioning
+
+import (
+	"testing"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/fixture"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecreateInstanceStep(t *testing.T) {
+	t.Run("should create provisioning operation once", func(t *testing.T) {
+		// given
+		memoryStorage := storage.NewMemoryStorage()
+		instance := fixture.FixInstance(instanceID)
+		require.NoError(t, memoryStorage.Instances().Insert(instance))
+		operation := fixture.FixSuspensionOperationAsOperation(operationID, instanceID)
+		operation.Recreate = true
+		require.NoError(t, memoryStorage.Operations().InsertOperation(operation))
+		queue := &queueStub{}
+		step := NewRecreateInstanceStep(memoryStorage.Operations(), memoryStorage.Instances(), queue)
+
+		// when
+		operation, backoff, err := step.Run(operation, logrus.New())
+		require.NoError(t, err)
+		assert.Zero(t, backoff)
+		operation, backoff, err = step.Run(operation, logrus.New())
+
+		// then
+		require.NoError(t, err)
+		assert.Zero(t, backoff)
+		require.NotEmpty(t, operation.RecreationOperationID)
+		assert.Equal(t, []string{operation.RecreationOperationID, operation.RecreationOperationID}, queue.added)
+		provisioning, err := memoryStorage.Operations().GetProvisioningOperationByID(operation.RecreationOperationID)
+		require.NoError(t, err)
+		assert.Equal(t, orchestration.Pending, string(provisioning.State))
+		assert.Equal(t, instanceID, provisioning.InstanceID)
+		assert.Empty(t, provisioning.RuntimeID)
+		assert.Equal(t, instance.Parameters.ErsContext.SubAccountID, provisioning.ProvisioningParameters.ErsContext.SubAccountID)
+		operations, err := memoryStorage.Operations().ListProvisioningOperationsByInstanceID(instanceID)
+		require.NoError(t, err)
+		assert.Len(t, operations, 1)
+	})
+
+	t.Run("should skip deprovisioning without recreation", func(t *testing.T) {
+		// given
+		memoryStorage := storage.NewMemoryStorage()
+		operation := fixture.FixSuspensionOperationAsOperation(operationID, instanceID)
+		queue := &queueStub{}
+		step := NewRecreateInstanceStep(memoryStorage.Operations(), memoryStorage.Instances(), queue)
+
+		// when
+		_, backoff, err := step.Run(operation, logrus.New())
+
+		// then
+		require.NoError(t, err)
+		assert.Zero(t, backoff)
+		assert.Empty(t, queue.added)
+	})
+}
+
+type queueStub struct {
+	added []string
+}
+
+func (q *queueStub) Add(processId string) {
+	q.added = append(q.added, processId)
+}
@@ -36,7 +36,8 @@ func (s ReleaseSubscriptionStep) Name() string {
 func (s ReleaseSubscriptionStep) Run(operation internal.Operation, log logrus.FieldLogger) (internal.Operation, time.Duration, error) {
 
 	planID := operation.ProvisioningParameters.PlanID
-	if !broker.IsTrialPlan(planID) && !broker.IsOwnClusterPlan(planID) {
+	// the secret binding of the customer hyperscaler account is not taken from the pool, see DeleteCustomerSecretBindingStep
+	if !broker.IsTrialPlan(planID) && !broker.IsOwnClusterPlan(planID) && !operation.ProvisioningParameters.Parameters.IsCustomerAccount() {
 		instance, err := s.instanceStorage.GetByID(operation.InstanceID)
 		if err != nil {
 			log.Errorf("after successful deprovisioning failing to release hyperscaler subscription - get the instance data for instanceID: %s", operation.InstanceID, err.Error())
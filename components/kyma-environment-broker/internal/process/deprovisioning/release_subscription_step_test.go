@@ -45,6 +45,31 @@ func TestReleaseSubscriptionStep_HappyPath(t *testing.T) {
 	assert.Equal(t, domain.Succeeded, operation.State)
 }
 
+func TestReleaseSubscriptionStep_CustomerAccount(t *testing.T) {
+	// given
+	log := logrus.New()
+	memoryStorage := storage.NewMemoryStorage()
+
+	operation := fixDeprovisioningOperationWithPlanID(broker.GCPPlanID)
+	operation.ProvisioningParameters.Parameters.HyperscalerAccount = &internal.HyperscalerAccountDTO{}
+	instance := fixGCPInstance(operation.InstanceID)
+
+	err := memoryStorage.Instances().Insert(instance)
+	assert.NoError(t, err)
+
+	accountProviderMock := &hyperscalerMocks.AccountProvider{}
+
+	step := NewReleaseSubscriptionStep(memoryStorage.Operations(), memoryStorage.Instances(), accountProviderMock)
+
+	// when
+	operation, repeat, err := step.Run(operation, log)
+
+	// then
+	accountProviderMock.AssertNumberOfCalls(t, "MarkUnusedGardenerSecretBindingAsDirty", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Duration(0), repeat)
+}
+
 func TestReleaseSubscriptionStep_TrialPlan(t *testing.T) {
 	// given
 	log := logrus.New()
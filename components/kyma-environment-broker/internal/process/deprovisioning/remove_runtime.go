@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/tracing"
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
 
@@ -60,7 +61,7 @@ func (s *RemoveRuntimeStep) Run(operation internal.Operation, log logrus.FieldLo
 	}
 
 	if operation.ProvisionerOperationID == "" {
-		provisionerResponse, err := s.provisionerClient.DeprovisionRuntime(instance.GlobalAccountID, instance.RuntimeID)
+		provisionerResponse, err := s.provisionerClient.DeprovisionRuntime(tracing.StepContext(operation), instance.GlobalAccountID, instance.RuntimeID)
 		if err != nil {
 			log.Errorf("unable to deprovision runtime: %s", err)
 			return operation, 10 * time.Second, nil
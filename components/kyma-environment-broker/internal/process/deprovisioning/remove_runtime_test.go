@@ -9,6 +9,7 @@ import (
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestRemoveRuntimeStep_Run(t *testing.T) {
@@ -27,7 +28,7 @@ func TestRemoveRuntimeStep_Run(t *testing.T) {
 		assert.NoError(t, err)
 
 		provisionerClient := &provisionerAutomock.Client{}
-		provisionerClient.On("DeprovisionRuntime", fixGlobalAccountID, fixRuntimeID).Return(fixProvisionerOperationID, nil)
+		provisionerClient.On("DeprovisionRuntime", mock.Anything, fixGlobalAccountID, fixRuntimeID).Return(fixProvisionerOperationID, nil)
 
 		step := NewRemoveRuntimeStep(memoryStorage.Operations(), memoryStorage.Instances(), provisionerClient, time.Minute)
 
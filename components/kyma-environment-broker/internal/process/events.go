@@ -8,9 +8,12 @@ import (
 
 type StepProcessed struct {
 	StepName string
-	Duration time.Duration
-	When     time.Duration
-	Error    error
+	// Stage is set only by the staged manager
+	Stage     string
+	StartedAt time.Time
+	Duration  time.Duration
+	When      time.Duration
+	Error     error
 }
 
 type ProvisioningStepProcessed struct {
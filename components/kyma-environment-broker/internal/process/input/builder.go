@@ -45,6 +45,7 @@ type (
 		CreateUpgradeInput(parameters internal.ProvisioningParameters, version internal.RuntimeVersionData) (internal.ProvisionerInputCreator, error)
 		CreateUpgradeShootInput(parameters internal.ProvisioningParameters, version internal.RuntimeVersionData) (internal.ProvisionerInputCreator, error)
 		GetPlanDefaults(planID string, platformProvider internal.CloudProvider, parametersProvider *internal.CloudProvider) (*gqlschema.ClusterConfigInput, error)
+		GetPlanProvider(planID string, platformProvider internal.CloudProvider, parametersProvider *internal.CloudProvider) (internal.CloudProvider, error)
 	}
 
 	ComponentListProvider interface {
@@ -66,12 +67,15 @@ type InputBuilderFactory struct {
 	trialPlatformRegionMapping map[string]string
 	enabledFreemiumProviders   map[string]struct{}
 	oidcDefaultValues          internal.OIDCConfigDTO
+	zonesProvider              cloudProvider.ZonesProvider
+	planRegistry               *broker.PlanRegistry
 }
 
 func NewInputBuilderFactory(optComponentsSvc OptionalComponentService, disabledComponentsProvider DisabledComponentsProvider,
 	componentsListProvider ComponentListProvider, configProvider ConfigurationProvider,
 	config Config, defaultKymaVersion string, trialPlatformRegionMapping map[string]string,
-	enabledFreemiumProviders []string, oidcValues internal.OIDCConfigDTO) (CreatorForPlan, error) {
+	enabledFreemiumProviders []string, oidcValues internal.OIDCConfigDTO, zonesProvider cloudProvider.ZonesProvider,
+	planRegistry *broker.PlanRegistry) (CreatorForPlan, error) {
 
 	freemiumProviders := map[string]struct{}{}
 	for _, p := range enabledFreemiumProviders {
@@ -88,6 +92,8 @@ func NewInputBuilderFactory(optComponentsSvc OptionalComponentService, disabledC
 		trialPlatformRegionMapping: trialPlatformRegionMapping,
 		enabledFreemiumProviders:   freemiumProviders,
 		oidcDefaultValues:          oidcValues,
+		zonesProvider:              zonesProvider,
+		planRegistry:               planRegistry,
 	}, nil
 }
 
@@ -102,7 +108,8 @@ func (f *InputBuilderFactory) IsPlanSupport(planID string) bool {
 		broker.AzureLitePlanID, broker.TrialPlanID, broker.OpenStackPlanID, broker.OwnClusterPlanID, broker.PreviewPlanID:
 		return true
 	default:
-		return false
+		_, found := f.planRegistry.DefinitionByID(planID)
+		return found
 	}
 }
 
@@ -114,6 +121,15 @@ func (f *InputBuilderFactory) GetPlanDefaults(planID string, platformProvider in
 	return h.Defaults(), nil
 }
 
+// GetPlanProvider returns the cloud provider on which the runtime of the plan is created
+func (f *InputBuilderFactory) GetPlanProvider(planID string, platformProvider internal.CloudProvider, parametersProvider *internal.CloudProvider) (internal.CloudProvider, error) {
+	h, err := f.getHyperscalerProviderForPlanID(planID, platformProvider, parametersProvider)
+	if err != nil {
+		return "", err
+	}
+	return h.Provider(), nil
+}
+
 func (f *InputBuilderFactory) getHyperscalerProviderForPlanID(planID string, platformProvider internal.CloudProvider, parametersProvider *internal.CloudProvider) (HyperscalerInputProvider, error) {
 	var provider HyperscalerInputProvider
 	switch planID {
@@ -121,6 +137,7 @@ func (f *InputBuilderFactory) getHyperscalerProviderForPlanID(planID string, pla
 		provider = &cloudProvider.GcpInput{
 			MultiZone:                    f.config.MultiZoneCluster,
 			ControlPlaneFailureTolerance: f.config.ControlPlaneFailureTolerance,
+			Zones:                        f.zonesProvider,
 		}
 	case broker.FreemiumPlanID:
 		return f.forFreemiumPlan(platformProvider)
@@ -141,6 +158,7 @@ func (f *InputBuilderFactory) getHyperscalerProviderForPlanID(planID string, pla
 		provider = &cloudProvider.AWSInput{
 			MultiZone:                    f.config.MultiZoneCluster,
 			ControlPlaneFailureTolerance: f.config.ControlPlaneFailureTolerance,
+			Zones:                        f.zonesProvider,
 		}
 	case broker.OwnClusterPlanID:
 		provider = &cloudProvider.NoHyperscalerInput{}
@@ -149,19 +167,54 @@ func (f *InputBuilderFactory) getHyperscalerProviderForPlanID(planID string, pla
 		provider = &cloudProvider.AWSInput{
 			MultiZone:                    f.config.MultiZoneCluster,
 			ControlPlaneFailureTolerance: f.config.ControlPlaneFailureTolerance,
+			Zones:                        f.zonesProvider,
 		}
 	default:
-		return nil, fmt.Errorf("case with plan %s is not supported", planID)
+		definition, found := f.planRegistry.DefinitionByID(planID)
+		if !found {
+			return nil, fmt.Errorf("case with plan %s is not supported", planID)
+		}
+		return f.forPlanDefinition(definition)
 	}
 	return provider, nil
 }
 
+func (f *InputBuilderFactory) forPlanDefinition(definition broker.PlanDefinition) (HyperscalerInputProvider, error) {
+	var base HyperscalerInputProvider
+	switch definition.Provider {
+	case internal.AWS:
+		base = &cloudProvider.AWSInput{
+			MultiZone:                    f.config.MultiZoneCluster,
+			ControlPlaneFailureTolerance: f.config.ControlPlaneFailureTolerance,
+			Zones:                        f.zonesProvider,
+		}
+	case internal.GCP:
+		base = &cloudProvider.GcpInput{
+			MultiZone:                    f.config.MultiZoneCluster,
+			ControlPlaneFailureTolerance: f.config.ControlPlaneFailureTolerance,
+			Zones:                        f.zonesProvider,
+		}
+	case internal.Azure:
+		base = &cloudProvider.AzureInput{
+			MultiZone:                    f.config.MultiZoneCluster,
+			ControlPlaneFailureTolerance: f.config.ControlPlaneFailureTolerance,
+		}
+	case internal.Openstack:
+		base = &cloudProvider.OpenStackInput{
+			FloatingPoolName: f.config.OpenstackFloatingPoolName,
+		}
+	default:
+		return nil, fmt.Errorf("provider %s of plan %s is not supported", definition.Provider, definition.Name)
+	}
+	return &PlanDefinitionInput{Base: base, Definition: definition}, nil
+}
+
 func (f *InputBuilderFactory) CreateProvisionInput(provisioningParameters internal.ProvisioningParameters, version internal.RuntimeVersionData) (internal.ProvisionerInputCreator, error) {
 	if !f.IsPlanSupport(provisioningParameters.PlanID) {
 		return nil, fmt.Errorf("plan %s in not supported", provisioningParameters.PlanID)
 	}
 
-	planName := broker.PlanNamesMapping[provisioningParameters.PlanID]
+	planName := f.planRegistry.PlanNames()[provisioningParameters.PlanID]
 
 	cfg, err := f.configProvider.ProvideForGivenVersionAndPlan(version.Version, planName)
 	if err != nil {
@@ -193,6 +246,7 @@ func (f *InputBuilderFactory) CreateProvisionInput(provisioningParameters intern
 		hyperscalerInputProvider:  provider,
 		optionalComponentsService: f.optComponentsSvc,
 		provisioningParameters:    provisioningParameters,
+		planName:                  planName,
 		componentsDisabler:        runtime.NewDisabledComponentsService(disabledComponents),
 		enabledOptionalComponents: map[string]struct{}{},
 		oidcDefaultValues:         f.oidcDefaultValues,
@@ -212,10 +266,12 @@ func (f *InputBuilderFactory) forTrialPlan(provider *internal.CloudProvider) Hyp
 	case internal.GCP:
 		return &cloudProvider.GcpTrialInput{
 			PlatformRegionMapping: f.trialPlatformRegionMapping,
+			Zones:                 f.zonesProvider,
 		}
 	case internal.AWS:
 		return &cloudProvider.AWSTrialInput{
 			PlatformRegionMapping: f.trialPlatformRegionMapping,
+			Zones:                 f.zonesProvider,
 		}
 	default:
 		return &cloudProvider.AzureTrialInput{
@@ -277,7 +333,7 @@ func (f *InputBuilderFactory) CreateUpgradeInput(provisioningParameters internal
 		return nil, fmt.Errorf("plan %s in not supported", provisioningParameters.PlanID)
 	}
 
-	planName := broker.PlanNamesMapping[provisioningParameters.PlanID]
+	planName := f.planRegistry.PlanNames()[provisioningParameters.PlanID]
 
 	cfg, err := f.configProvider.ProvideForGivenVersionAndPlan(version.Version, planName)
 	if err != nil {
@@ -317,6 +373,7 @@ func (f *InputBuilderFactory) CreateUpgradeInput(provisioningParameters internal
 		oidcDefaultValues:         f.oidcDefaultValues,
 		hyperscalerInputProvider:  provider,
 		config:                    cfg,
+		planName:                  planName,
 	}, nil
 }
 
@@ -372,7 +429,7 @@ func (f *InputBuilderFactory) CreateUpgradeShootInput(provisioningParameters int
 		return nil, fmt.Errorf("plan %s in not supported", provisioningParameters.PlanID)
 	}
 
-	planName := broker.PlanNamesMapping[provisioningParameters.PlanID]
+	planName := f.planRegistry.PlanNames()[provisioningParameters.PlanID]
 
 	cfg, err := f.configProvider.ProvideForGivenVersionAndPlan(version.Version, planName)
 	if err != nil {
@@ -423,7 +480,7 @@ func (f *InputBuilderFactory) forFreemiumPlan(provider internal.CloudProvider) (
 	}
 	switch provider {
 	case internal.AWS:
-		return &cloudProvider.AWSFreemiumInput{}, nil
+		return &cloudProvider.AWSFreemiumInput{Zones: f.zonesProvider}, nil
 	case internal.Azure:
 		return &cloudProvider.AzureFreemiumInput{}, nil
 	default:
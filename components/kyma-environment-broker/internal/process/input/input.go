@@ -12,6 +12,7 @@ import (
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/gardener"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/provider"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/runtime"
 	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
 )
@@ -54,6 +55,7 @@ type RuntimeInput struct {
 	hyperscalerInputProvider  HyperscalerInputProvider
 	optionalComponentsService OptionalComponentService
 	provisioningParameters    internal.ProvisioningParameters
+	planName                  string
 	shootName                 *string
 
 	componentsDisabler        ComponentsDisabler
@@ -395,7 +397,7 @@ func (r *RuntimeInput) CreateClusterConfiguration() (reconcilerApi.Cluster, erro
 			SubAccountID:    r.provisioningParameters.ErsContext.SubAccountID,
 			ServiceID:       r.provisioningParameters.ServiceID,
 			ServicePlanID:   r.provisioningParameters.PlanID,
-			ServicePlanName: broker.PlanNamesMapping[r.provisioningParameters.PlanID],
+			ServicePlanName: r.planName,
 			ShootName:       *r.shootName,
 			InstanceID:      r.instanceID,
 		},
@@ -470,6 +472,10 @@ func (r *RuntimeInput) applyProvisioningParametersForProvisionRuntime() error {
 	}
 
 	r.hyperscalerInputProvider.ApplyParameters(r.provisionRuntimeInput.ClusterConfig, r.provisioningParameters)
+	// the zones are known only after the hyperscaler parameters are applied
+	if err := provider.ApplyNetworking(r.provisionRuntimeInput.ClusterConfig, params.Networking); err != nil {
+		return fmt.Errorf("while applying networking parameters: %w", err)
+	}
 
 	return nil
 }
@@ -45,7 +45,7 @@ func TestShouldEnableComponents(t *testing.T) {
 
 		builder, err := NewInputBuilderFactory(runtime.NewOptionalComponentsService(optionalComponentsDisablers), runtime.NewDisabledComponentsProvider(),
 			componentsProvider, configProvider, Config{}, "not-important", fixTrialRegionMapping(), fixTrialProviders(),
-			fixture.FixOIDCConfigDTO())
+			fixture.FixOIDCConfigDTO(), nil, nil)
 		assert.NoError(t, err)
 
 		pp := fixProvisioningParameters(broker.AzurePlanID, "")
@@ -89,7 +89,7 @@ func TestShouldEnableComponents(t *testing.T) {
 
 		builder, err := NewInputBuilderFactory(runtime.NewOptionalComponentsService(optionalComponentsDisablers), runtime.NewDisabledComponentsProvider(),
 			componentsProvider, configProvider, Config{}, "not-important", fixTrialRegionMapping(), fixTrialProviders(),
-			fixture.FixOIDCConfigDTO())
+			fixture.FixOIDCConfigDTO(), nil, nil)
 		assert.NoError(t, err)
 
 		pp := fixProvisioningParameters(broker.AzurePlanID, "1.14.0")
@@ -134,7 +134,7 @@ func TestShouldDisableComponents(t *testing.T) {
 
 		builder, err := NewInputBuilderFactory(runtime.NewOptionalComponentsService(optionalComponentsDisablers), runtime.NewDisabledComponentsProvider(),
 			componentsProvider, configProvider, Config{}, "not-important", fixTrialRegionMapping(), fixTrialProviders(),
-			fixture.FixOIDCConfigDTO())
+			fixture.FixOIDCConfigDTO(), nil, nil)
 		assert.NoError(t, err)
 		creator, err := builder.CreateProvisionInput(pp, internal.RuntimeVersionData{Version: "1.10.0", Origin: internal.Defaults})
 		require.NoError(t, err)
@@ -170,7 +170,7 @@ func TestShouldDisableComponents(t *testing.T) {
 
 		builder, err := NewInputBuilderFactory(runtime.NewOptionalComponentsService(optionalComponentsDisablers), runtime.NewDisabledComponentsProvider(),
 			componentsProvider, configProvider, Config{}, "not-important", fixTrialRegionMapping(), fixTrialProviders(),
-			fixture.FixOIDCConfigDTO())
+			fixture.FixOIDCConfigDTO(), nil, nil)
 		assert.NoError(t, err)
 		creator, err := builder.CreateUpgradeInput(pp, internal.RuntimeVersionData{Version: "1.14.0", Origin: internal.Defaults})
 		require.NoError(t, err)
@@ -207,7 +207,7 @@ func TestDisabledComponentsForPlanNotExist(t *testing.T) {
 
 	builder, err := NewInputBuilderFactory(runtime.NewOptionalComponentsService(optionalComponentsDisablers), runtime.NewDisabledComponentsProvider(),
 		componentsProvider, configProvider, Config{}, "not-important", fixTrialRegionMapping(), fixTrialProviders(),
-		fixture.FixOIDCConfigDTO())
+		fixture.FixOIDCConfigDTO(), nil, nil)
 	assert.NoError(t, err)
 	// when
 	_, err = builder.CreateProvisionInput(pp, emptyVersion)
@@ -238,7 +238,7 @@ func TestInputBuilderFactoryOverrides(t *testing.T) {
 
 		builder, err := NewInputBuilderFactory(dummyOptComponentsSvc, runtime.NewDisabledComponentsProvider(),
 			componentsProvider, configProvider, Config{}, "not-important", fixTrialRegionMapping(), fixTrialProviders(),
-			fixture.FixOIDCConfigDTO())
+			fixture.FixOIDCConfigDTO(), nil, nil)
 		assert.NoError(t, err)
 		creator, err := builder.CreateProvisionInput(pp, internal.RuntimeVersionData{Version: "1.10.0", Origin: internal.Defaults})
 		require.NoError(t, err)
@@ -283,7 +283,7 @@ func TestInputBuilderFactoryOverrides(t *testing.T) {
 
 		builder, err := NewInputBuilderFactory(optComponentsSvc, runtime.NewDisabledComponentsProvider(),
 			componentsProvider, configProvider, Config{}, "not-important", fixTrialRegionMapping(), fixTrialProviders(),
-			fixture.FixOIDCConfigDTO())
+			fixture.FixOIDCConfigDTO(), nil, nil)
 		assert.NoError(t, err)
 		creator, err := builder.CreateProvisionInput(pp, internal.RuntimeVersionData{Version: "1.10.0", Origin: internal.Defaults})
 		require.NoError(t, err)
@@ -322,7 +322,7 @@ func TestInputBuilderFactoryOverrides(t *testing.T) {
 		pp := fixProvisioningParameters(broker.AzurePlanID, "1.14.0")
 		builder, err := NewInputBuilderFactory(optComponentsSvc, runtime.NewDisabledComponentsProvider(),
 			componentsProvider, configProvider, Config{}, "not-important", fixTrialRegionMapping(), fixTrialProviders(),
-			fixture.FixOIDCConfigDTO())
+			fixture.FixOIDCConfigDTO(), nil, nil)
 		assert.NoError(t, err)
 		creator, err := builder.CreateUpgradeInput(pp, internal.RuntimeVersionData{Version: "1.14.0", Origin: internal.Defaults})
 		require.NoError(t, err)
@@ -370,7 +370,7 @@ func TestInputBuilderFactoryOverrides(t *testing.T) {
 
 		builder, err := NewInputBuilderFactory(dummyOptComponentsSvc, runtime.NewDisabledComponentsProvider(),
 			componentsProvider, configProvider, Config{}, "not-important", fixTrialRegionMapping(), fixTrialProviders(),
-			fixture.FixOIDCConfigDTO())
+			fixture.FixOIDCConfigDTO(), nil, nil)
 		assert.NoError(t, err)
 		creator, err := builder.CreateProvisionInput(pp, internal.RuntimeVersionData{Version: "1.10.0", Origin: internal.Defaults})
 		require.NoError(t, err)
@@ -433,7 +433,7 @@ func TestInputBuilderFactoryForAzurePlan(t *testing.T) {
 
 	factory, err := NewInputBuilderFactory(optComponentsSvc, runtime.NewDisabledComponentsProvider(),
 		componentsProvider, configProvider, config, "1.10.0",
-		fixTrialRegionMapping(), fixTrialProviders(), fixture.FixOIDCConfigDTO())
+		fixTrialRegionMapping(), fixTrialProviders(), fixture.FixOIDCConfigDTO(), nil, nil)
 	assert.NoError(t, err)
 	pp := fixProvisioningParameters(broker.AzurePlanID, "")
 
@@ -515,7 +515,7 @@ func TestShouldAdjustRuntimeName(t *testing.T) {
 
 			builder, err := NewInputBuilderFactory(optComponentsSvc, runtime.NewDisabledComponentsProvider(),
 				componentsProvider, configProvider, Config{TrialNodesNumber: 0}, "not-important", fixTrialRegionMapping(),
-				fixTrialProviders(), fixture.FixOIDCConfigDTO())
+				fixTrialProviders(), fixture.FixOIDCConfigDTO(), nil, nil)
 			assert.NoError(t, err)
 
 			pp := fixProvisioningParameters(broker.TrialPlanID, "")
@@ -554,7 +554,7 @@ func TestShouldSetNumberOfNodesForTrialPlan(t *testing.T) {
 
 	builder, err := NewInputBuilderFactory(optComponentsSvc, runtime.NewDisabledComponentsProvider(),
 		componentsProvider, configProvider, Config{TrialNodesNumber: 2}, "not-important",
-		fixTrialRegionMapping(), fixTrialProviders(), fixture.FixOIDCConfigDTO())
+		fixTrialRegionMapping(), fixTrialProviders(), fixture.FixOIDCConfigDTO(), nil, nil)
 	assert.NoError(t, err)
 
 	pp := fixProvisioningParameters(broker.TrialPlanID, "")
@@ -585,7 +585,7 @@ func TestShouldSetGlobalConfiguration(t *testing.T) {
 
 		builder, err := NewInputBuilderFactory(optComponentsSvc, runtime.NewDisabledComponentsProvider(),
 			componentsProvider, configProvider, Config{}, "",
-			fixTrialRegionMapping(), fixTrialProviders(), fixture.FixOIDCConfigDTO())
+			fixTrialRegionMapping(), fixTrialProviders(), fixture.FixOIDCConfigDTO(), nil, nil)
 		assert.NoError(t, err)
 
 		pp := fixProvisioningParameters(broker.TrialPlanID, "")
@@ -613,7 +613,7 @@ func TestShouldSetGlobalConfiguration(t *testing.T) {
 
 		builder, err := NewInputBuilderFactory(optComponentsSvc, runtime.NewDisabledComponentsProvider(),
 			componentsProvider, configProvider, Config{}, "",
-			fixTrialRegionMapping(), fixTrialProviders(), fixture.FixOIDCConfigDTO())
+			fixTrialRegionMapping(), fixTrialProviders(), fixture.FixOIDCConfigDTO(), nil, nil)
 		assert.NoError(t, err)
 
 		pp := fixProvisioningParameters(broker.TrialPlanID, "")
@@ -658,7 +658,7 @@ func TestCreateProvisionRuntimeInput_ConfigureDNS(t *testing.T) {
 
 		inputBuilder, err := NewInputBuilderFactory(optComponentsSvc, runtime.NewDisabledComponentsProvider(),
 			componentsProvider, configProvider, Config{}, "1.24.4", fixTrialRegionMapping(),
-			fixTrialProviders(), fixture.FixOIDCConfigDTO())
+			fixTrialProviders(), fixture.FixOIDCConfigDTO(), nil, nil)
 		assert.NoError(t, err)
 
 		provisioningParams := fixture.FixProvisioningParameters(id)
@@ -694,7 +694,7 @@ func TestCreateProvisionRuntimeInput_ConfigureDNS(t *testing.T) {
 
 		inputBuilder, err := NewInputBuilderFactory(optComponentsSvc, runtime.NewDisabledComponentsProvider(),
 			componentsProvider, configProvider, Config{}, "1.24.4",
-			fixTrialRegionMapping(), fixTrialProviders(), fixture.FixOIDCConfigDTO())
+			fixTrialRegionMapping(), fixTrialProviders(), fixture.FixOIDCConfigDTO(), nil, nil)
 		assert.NoError(t, err)
 
 		provisioningParams := fixture.FixProvisioningParameters(id)
@@ -740,7 +740,7 @@ func TestCreateProvisionRuntimeInput_ConfigureOIDC(t *testing.T) {
 
 		inputBuilder, err := NewInputBuilderFactory(optComponentsSvc, runtime.NewDisabledComponentsProvider(),
 			componentsProvider, configProvider, Config{}, "1.24.0",
-			fixTrialRegionMapping(), fixTrialProviders(), fixture.FixOIDCConfigDTO())
+			fixTrialRegionMapping(), fixTrialProviders(), fixture.FixOIDCConfigDTO(), nil, nil)
 		assert.NoError(t, err)
 
 		provisioningParams := fixture.FixProvisioningParameters(id)
@@ -780,7 +780,7 @@ func TestCreateProvisionRuntimeInput_ConfigureOIDC(t *testing.T) {
 
 		inputBuilder, err := NewInputBuilderFactory(optComponentsSvc, runtime.NewDisabledComponentsProvider(),
 			componentsProvider, configProvider, Config{}, "1.24.0",
-			fixTrialRegionMapping(), fixTrialProviders(), fixture.FixOIDCConfigDTO())
+			fixTrialRegionMapping(), fixTrialProviders(), fixture.FixOIDCConfigDTO(), nil, nil)
 		assert.NoError(t, err)
 
 		provisioningParams := fixture.FixProvisioningParameters(id)
@@ -821,7 +821,7 @@ func TestCreateProvisionRuntimeInput_ConfigureOIDC(t *testing.T) {
 
 		inputBuilder, err := NewInputBuilderFactory(optComponentsSvc, runtime.NewDisabledComponentsProvider(),
 			componentsProvider, configProvider, Config{}, "1.24.0",
-			fixTrialRegionMapping(), fixTrialProviders(), fixture.FixOIDCConfigDTO())
+			fixTrialRegionMapping(), fixTrialProviders(), fixture.FixOIDCConfigDTO(), nil, nil)
 		assert.NoError(t, err)
 
 		provisioningParams := fixture.FixProvisioningParameters(id)
@@ -869,7 +869,7 @@ func TestCreateProvisionRuntimeInput_ConfigureOIDC(t *testing.T) {
 
 		inputBuilder, err := NewInputBuilderFactory(optComponentsSvc, runtime.NewDisabledComponentsProvider(),
 			componentsProvider, configProvider, Config{}, "1.24.0",
-			fixTrialRegionMapping(), fixTrialProviders(), fixture.FixOIDCConfigDTO())
+			fixTrialRegionMapping(), fixTrialProviders(), fixture.FixOIDCConfigDTO(), nil, nil)
 		assert.NoError(t, err)
 
 		provisioningParams := fixture.FixProvisioningParameters(id)
@@ -920,7 +920,7 @@ func TestCreateClusterConfiguration_Overrides(t *testing.T) {
 
 		inputBuilder, err := NewInputBuilderFactory(optComponentsSvc, runtime.NewDisabledComponentsProvider(),
 			componentsProvider, configProvider, Config{}, "1.24.0",
-			fixTrialRegionMapping(), fixTrialProviders(), fixture.FixOIDCConfigDTO())
+			fixTrialRegionMapping(), fixTrialProviders(), fixture.FixOIDCConfigDTO(), nil, nil)
 		assert.NoError(t, err)
 
 		provisioningParams := fixture.FixProvisioningParameters(id)
@@ -1002,7 +1002,7 @@ func TestCreateClusterConfiguration_Overrides(t *testing.T) {
 
 		builder, err := NewInputBuilderFactory(dummyOptComponentsSvc, runtime.NewDisabledComponentsProvider(),
 			componentsProvider, configProvider, Config{}, "not-important",
-			fixTrialRegionMapping(), fixTrialProviders(), fixture.FixOIDCConfigDTO())
+			fixTrialRegionMapping(), fixTrialProviders(), fixture.FixOIDCConfigDTO(), nil, nil)
 		assert.NoError(t, err)
 		creator, err := builder.CreateProvisionInput(pp, internal.RuntimeVersionData{Version: "1.10.0", Origin: internal.Defaults})
 		require.NoError(t, err)
@@ -1053,7 +1053,7 @@ func TestCreateProvisionRuntimeInput_ConfigureAdmins(t *testing.T) {
 
 		inputBuilder, err := NewInputBuilderFactory(optComponentsSvc, runtime.NewDisabledComponentsProvider(),
 			componentsProvider, configProvider, Config{}, "1.24.0",
-			fixTrialRegionMapping(), fixTrialProviders(), fixture.FixOIDCConfigDTO())
+			fixTrialRegionMapping(), fixTrialProviders(), fixture.FixOIDCConfigDTO(), nil, nil)
 		assert.NoError(t, err)
 
 		provisioningParams := fixture.FixProvisioningParameters(id)
@@ -1088,7 +1088,7 @@ func TestCreateProvisionRuntimeInput_ConfigureAdmins(t *testing.T) {
 
 		inputBuilder, err := NewInputBuilderFactory(optComponentsSvc, runtime.NewDisabledComponentsProvider(),
 			componentsProvider, configProvider, Config{}, "1.24.0",
-			fixTrialRegionMapping(), fixTrialProviders(), fixture.FixOIDCConfigDTO())
+			fixTrialRegionMapping(), fixTrialProviders(), fixture.FixOIDCConfigDTO(), nil, nil)
 		assert.NoError(t, err)
 
 		provisioningParams := fixture.FixProvisioningParameters(id)
@@ -1147,7 +1147,7 @@ func TestCreateUpgradeRuntimeInput_ConfigureAdmins(t *testing.T) {
 
 		inputBuilder, err := NewInputBuilderFactory(optComponentsSvc, runtime.NewDisabledComponentsProvider(),
 			componentsProvider, configProvider, Config{}, "1.24.0",
-			fixTrialRegionMapping(), fixTrialProviders(), fixture.FixOIDCConfigDTO())
+			fixTrialRegionMapping(), fixTrialProviders(), fixture.FixOIDCConfigDTO(), nil, nil)
 		assert.NoError(t, err)
 
 		provisioningParams := fixture.FixProvisioningParameters(id)
@@ -1185,7 +1185,7 @@ func TestCreateUpgradeRuntimeInput_ConfigureAdmins(t *testing.T) {
 
 		inputBuilder, err := NewInputBuilderFactory(optComponentsSvc, runtime.NewDisabledComponentsProvider(),
 			componentsProvider, configProvider, Config{}, "1.24.0",
-			fixTrialRegionMapping(), fixTrialProviders(), fixture.FixOIDCConfigDTO())
+			fixTrialRegionMapping(), fixTrialProviders(), fixture.FixOIDCConfigDTO(), nil, nil)
 		assert.NoError(t, err)
 
 		provisioningParams := fixture.FixProvisioningParameters(id)
@@ -1221,7 +1221,7 @@ func TestCreateUpgradeShootInput_ConfigureAutoscalerParams(t *testing.T) {
 
 		ibf, err := NewInputBuilderFactory(optComponentsSvc, runtime.NewDisabledComponentsProvider(),
 			componentsProvider, configProvider, Config{}, "1.24.0",
-			fixTrialRegionMapping(), fixTrialProviders(), fixture.FixOIDCConfigDTO())
+			fixTrialRegionMapping(), fixTrialProviders(), fixture.FixOIDCConfigDTO(), nil, nil)
 		assert.NoError(t, err)
 
 		//ar provider HyperscalerInputProvider
@@ -1263,7 +1263,7 @@ func TestCreateUpgradeShootInput_ConfigureAutoscalerParams(t *testing.T) {
 
 		ibf, err := NewInputBuilderFactory(optComponentsSvc, runtime.NewDisabledComponentsProvider(),
 			componentsProvider, configProvider, Config{}, "1.24.0",
-			fixTrialRegionMapping(), fixTrialProviders(), fixture.FixOIDCConfigDTO())
+			fixTrialRegionMapping(), fixTrialProviders(), fixture.FixOIDCConfigDTO(), nil, nil)
 		assert.NoError(t, err)
 
 		pp := fixProvisioningParameters(broker.GCPPlanID, "")
@@ -0,0 +1,57 @@
+package input
+
+import (
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/ptr"
+	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
+)
+
+// PlanDefinitionInput overrides the defaults of the provider input with the values of a plan definition
+type PlanDefinitionInput struct {
+	Base       HyperscalerInputProvider
+	Definition broker.PlanDefinition
+}
+
+func (p *PlanDefinitionInput) Defaults() *gqlschema.ClusterConfigInput {
+	input := p.Base.Defaults()
+	defaults := p.Definition.DefaultsOrFirst()
+
+	input.GardenerConfig.MachineType = defaults.MachineType
+	input.GardenerConfig.AutoScalerMin = defaults.AutoScalerMin
+	input.GardenerConfig.AutoScalerMax = defaults.AutoScalerMax
+	if p.Definition.VolumeSizeGb > 0 {
+		input.GardenerConfig.VolumeSizeGb = ptr.Integer(p.Definition.VolumeSizeGb)
+	}
+	if input.GardenerConfig.Region != defaults.Region {
+		p.applyRegion(input, defaults.Region)
+	}
+	return input
+}
+
+func (p *PlanDefinitionInput) ApplyParameters(input *gqlschema.ClusterConfigInput, pp internal.ProvisioningParameters) {
+	regionProvided := pp.Parameters.Region != nil && *pp.Parameters.Region != ""
+	if internal.IsEuAccess(pp.PlatformRegion) && !regionProvided && len(p.Definition.EUAccessRegions) > 0 {
+		// the base input gets the region as a parameter, otherwise it falls back to its own EU access region
+		region := p.Definition.EUAccessRegions[0]
+		input.GardenerConfig.Region = region
+		pp.Parameters.Region = ptr.String(region)
+	}
+	p.Base.ApplyParameters(input, pp)
+}
+
+func (p *PlanDefinitionInput) Profile() gqlschema.KymaProfile {
+	return p.Base.Profile()
+}
+
+func (p *PlanDefinitionInput) Provider() internal.CloudProvider {
+	return p.Base.Provider()
+}
+
+// applyRegion sets the region and lets the base input generate the zones for it
+func (p *PlanDefinitionInput) applyRegion(input *gqlschema.ClusterConfigInput, region string) {
+	input.GardenerConfig.Region = region
+	p.Base.ApplyParameters(input, internal.ProvisioningParameters{
+		Parameters: internal.ProvisioningParametersDTO{Region: ptr.String(region)},
+	})
+}
@@ -0,0 +1,73 @@
+package input
+
+import (
+	"testing"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
+	cloudProvider "github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/provider"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/ptr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanDefinitionInput(t *testing.T) {
+	// given
+	input := &PlanDefinitionInput{
+		Base: &cloudProvider.AWSInput{},
+		Definition: broker.PlanDefinition{
+			Provider:        internal.AWS,
+			Regions:         []string{"us-east-1", "eu-central-1"},
+			EUAccessRegions: []string{"eu-central-1"},
+			MachineTypes:    []broker.MachineTypeDefinition{{Name: "m5.large"}},
+			AutoScaler:      broker.AutoScalerBounds{Minimum: 1, Maximum: 5},
+			VolumeSizeGb:    30,
+		},
+	}
+
+	t.Run("should use plan definition defaults", func(t *testing.T) {
+		// when
+		defaults := input.Defaults()
+
+		// then
+		config := defaults.GardenerConfig
+		assert.Equal(t, "m5.large", config.MachineType)
+		assert.Equal(t, "us-east-1", config.Region)
+		assert.Equal(t, 1, config.AutoScalerMin)
+		assert.Equal(t, 5, config.AutoScalerMax)
+		assert.Equal(t, ptr.Integer(30), config.VolumeSizeGb)
+		require.Len(t, config.ProviderSpecificConfig.AwsConfig.AwsZones, 1)
+		assert.Contains(t, config.ProviderSpecificConfig.AwsConfig.AwsZones[0].Name, "us-east-1")
+		assert.Equal(t, internal.AWS, input.Provider())
+	})
+
+	t.Run("should use the first EU access region", func(t *testing.T) {
+		// given
+		defaults := input.Defaults()
+
+		// when
+		input.ApplyParameters(defaults, internal.ProvisioningParameters{PlatformRegion: "cf-eu11"})
+
+		// then
+		assert.Equal(t, "eu-central-1", defaults.GardenerConfig.Region)
+		assert.Contains(t, defaults.GardenerConfig.ProviderSpecificConfig.AwsConfig.AwsZones[0].Name, "eu-central-1")
+	})
+
+	t.Run("should apply the parameters in the EU access region", func(t *testing.T) {
+		// given
+		defaults := input.Defaults()
+
+		// when
+		input.ApplyParameters(defaults, internal.ProvisioningParameters{
+			PlatformRegion: "cf-eu11",
+			Parameters: internal.ProvisioningParametersDTO{
+				Zones: []string{"eu-central-1b"},
+			},
+		})
+
+		// then
+		assert.Equal(t, "eu-central-1", defaults.GardenerConfig.Region)
+		require.Len(t, defaults.GardenerConfig.ProviderSpecificConfig.AwsConfig.AwsZones, 1)
+		assert.Equal(t, "eu-central-1b", defaults.GardenerConfig.ProviderSpecificConfig.AwsConfig.AwsZones[0].Name)
+	})
+}
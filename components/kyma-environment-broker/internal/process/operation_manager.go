@@ -6,6 +6,7 @@ import (
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/retrypolicy"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
 	"github.com/pivotal-cf/brokerapi/v8/domain"
@@ -14,12 +15,23 @@ import (
 
 type OperationManager struct {
 	storage storage.Operations
+
+	// retryPolicies override the retry intervals and times of the step which owns the operation manager
+	retryPolicies *retrypolicy.Registry
+	stepName      string
 }
 
 func NewOperationManager(storage storage.Operations) *OperationManager {
 	return &OperationManager{storage: storage}
 }
 
+// UseRetryPolicies makes the retries of the step follow the policies configured for it, the step passes the policies
+// it gets from the staged manager
+func (om *OperationManager) UseRetryPolicies(policies *retrypolicy.Registry, stepName string) {
+	om.retryPolicies = policies
+	om.stepName = stepName
+}
+
 // OperationSucceeded marks the operation as succeeded and returns status of the operation's update
 func (om *OperationManager) OperationSucceeded(operation internal.Operation, description string, log logrus.FieldLogger) (internal.Operation, time.Duration, error) {
 	return om.update(operation, domain.Succeeded, description, log)
@@ -52,41 +64,68 @@ func (om *OperationManager) OperationCanceled(operation internal.Operation, desc
 
 // RetryOperation checks if operation should be retried or if it's the status should be marked as failed
 func (om *OperationManager) RetryOperation(operation internal.Operation, errorMessage string, err error, retryInterval time.Duration, maxTime time.Duration, log logrus.FieldLogger) (internal.Operation, time.Duration, error) {
-	log.Infof("Retry Operation was triggered with message: %s", errorMessage)
-	log.Infof("Retrying for %s in %s steps", maxTime.String(), retryInterval.String())
-	if time.Since(operation.UpdatedAt) < maxTime {
-		return operation, retryInterval, nil
-	}
-	log.Errorf("Aborting after %s of failing retries", maxTime.String())
-	op, retry, err := om.OperationFailed(operation, errorMessage, err, log)
-	if err == nil {
-		err = fmt.Errorf("Too many retries")
-	} else {
-		err = fmt.Errorf("Failed to set status for operation after too many retries: %v", err)
-	}
-	return op, retry, err
+	policy := om.RetryPolicy(operation, om.stepName, retrypolicy.Policy{
+		Backoff:         retrypolicy.BackoffConstant,
+		InitialInterval: retryInterval,
+		MaxDuration:     maxTime,
+		OnExhaustion:    retrypolicy.ExhaustionFail,
+	})
+	return om.retry(operation, om.stepName, errorMessage, err, policy, log)
 }
 
 // RetryOperationWithoutFail checks if operation should be retried or updates the status to InProgress, but omits setting the operation to failed if maxTime is reached
 func (om *OperationManager) RetryOperationWithoutFail(operation internal.Operation, stepName string, description string, retryInterval, maxTime time.Duration, log logrus.FieldLogger) (internal.Operation, time.Duration, error) {
+	policy := om.RetryPolicy(operation, stepName, retrypolicy.Policy{
+		Backoff:         retrypolicy.BackoffConstant,
+		InitialInterval: retryInterval,
+		MaxDuration:     maxTime,
+		OnExhaustion:    retrypolicy.ExhaustionContinue,
+	})
+	return om.retry(operation, stepName, description, nil, policy, log)
+}
+
+// RetryPolicy returns the retry policy configured for the step in the plan of the operation, the defaults are used for the values which are not configured
+// or when the step does not use retry policies
+func (om *OperationManager) RetryPolicy(operation internal.Operation, stepName string, defaults retrypolicy.Policy) retrypolicy.Policy {
+	if om.retryPolicies == nil {
+		return defaults
+	}
+	return om.retryPolicies.Policy(stepName, operation.ProvisioningParameters.PlanID, defaults)
+}
+
+func (om *OperationManager) retry(operation internal.Operation, stepName, description string, err error, policy retrypolicy.Policy, log logrus.FieldLogger) (internal.Operation, time.Duration, error) {
 	log.Infof("Retry Operation was triggered with message: %s", description)
-	log.Infof("Retrying for %s in %s steps", maxTime.String(), retryInterval.String())
-	if time.Since(operation.UpdatedAt) < maxTime {
-		return operation, retryInterval, nil
+	elapsed := time.Since(operation.UpdatedAt)
+	if elapsed < policy.MaxDuration {
+		interval := policy.Interval(elapsed)
+		log.Infof("Retrying for %s in %s steps", policy.MaxDuration.String(), interval.String())
+		return operation, interval, nil
 	}
-	// update description to track failed steps
-	op, repeat, err := om.UpdateOperation(operation, func(operation *internal.Operation) {
-		operation.State = domain.InProgress
-		operation.Description = description
-		operation.ExcutedButNotCompleted = append(operation.ExcutedButNotCompleted, stepName)
-	}, log)
-	if repeat != 0 {
-		return op, repeat, err
+
+	if policy.OnExhaustion == retrypolicy.ExhaustionContinue {
+		// update description to track failed steps
+		op, repeat, err := om.UpdateOperation(operation, func(operation *internal.Operation) {
+			operation.State = domain.InProgress
+			operation.Description = description
+			operation.ExcutedButNotCompleted = append(operation.ExcutedButNotCompleted, stepName)
+		}, log)
+		if repeat != 0 {
+			return op, repeat, err
+		}
+
+		op.EventErrorf(fmt.Errorf(description), "step %s failed retries: operation continues", stepName)
+		log.Errorf("Omitting after %s of failing retries", policy.MaxDuration.String())
+		return op, 0, nil
 	}
 
-	op.EventErrorf(fmt.Errorf(description), "step %s failed retries: operation continues", stepName)
-	log.Errorf("Omitting after %s of failing retries", maxTime.String())
-	return op, 0, nil
+	log.Errorf("Aborting after %s of failing retries", policy.MaxDuration.String())
+	op, retry, err := om.OperationFailed(operation, description, err, log)
+	if err == nil {
+		err = fmt.Errorf("Too many retries")
+	} else {
+		err = fmt.Errorf("Failed to set status for operation after too many retries: %v", err)
+	}
+	return op, retry, err
 }
 
 // RetryOperationOnce retries the operation once and fails the operation when call second time
@@ -5,10 +5,13 @@ import (
 	"testing"
 	"time"
 
+	"github.com/pivotal-cf/brokerapi/v8/domain"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/retrypolicy"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
 )
 
@@ -77,3 +80,41 @@ func Test_OperationManager_RetryOperation(t *testing.T) {
 	assert.True(t, when > 0)
 	assert.Nil(t, err)
 }
+
+func Test_OperationManager_RetryOperationWithPolicy(t *testing.T) {
+	// given
+	memory := storage.NewMemoryStorage()
+	operations := memory.Operations()
+	opManager := NewOperationManager(operations)
+	retryPolicies := retrypolicy.NewRegistry(retrypolicy.Policies{
+		"Check_Runtime": {
+			retrypolicy.AllPlans: {Backoff: retrypolicy.BackoffExponential, MaxInterval: 5 * time.Minute, OnExhaustion: retrypolicy.ExhaustionContinue},
+			"trial":              {MaxDuration: 10 * time.Minute},
+		},
+	}, map[string]string{broker.TrialPlanID: broker.TrialPlanName})
+	opManager.UseRetryPolicies(retryPolicies, "Check_Runtime")
+
+	op := internal.Operation{}
+	op.ID = "op-id"
+	op.ProvisioningParameters.PlanID = broker.TrialPlanID
+	op.UpdatedAt = time.Now().Add(-3 * time.Minute)
+	err := operations.InsertOperation(op)
+	require.NoError(t, err)
+
+	// when - retried with exponential backoff
+	_, when, err := opManager.RetryOperation(op, "not ready", nil, time.Minute, time.Hour, fixLogger())
+
+	// then
+	assert.NoError(t, err)
+	assert.InDelta(t, (4 * time.Minute).Seconds(), when.Seconds(), 1)
+
+	// when - max duration of the trial plan exceeded
+	op.UpdatedAt = time.Now().Add(-11 * time.Minute)
+	op, when, err = opManager.RetryOperation(op, "not ready", nil, time.Minute, time.Hour, fixLogger())
+
+	// then - the operation continues instead of failing
+	assert.NoError(t, err)
+	assert.Zero(t, when)
+	assert.Equal(t, domain.InProgress, op.State)
+	assert.Equal(t, []string{"Check_Runtime"}, op.ExcutedButNotCompleted)
+}
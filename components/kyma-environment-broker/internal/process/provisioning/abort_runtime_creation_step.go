@@ -0,0 +1,58 @@
+package provisioning
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/provisioner"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/retrypolicy"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/tracing"
+	"github.com/sirupsen/logrus"
+)
+
+// AbortRuntimeCreationStep is the compensation of the create_runtime stage run when the provisioning is canceled,
+// the runtime created in the Provisioner is deprovisioned, the step does not wait until the runtime is removed.
+// The operation fails instead of being canceled when the Provisioner does not accept the deprovisioning within the timeout.
+type AbortRuntimeCreationStep struct {
+	operationManager  *process.OperationManager
+	provisionerClient provisioner.Client
+	timeout           time.Duration
+}
+
+var _ process.Step = &AbortRuntimeCreationStep{}
+
+func NewAbortRuntimeCreationStep(os storage.Operations, cli provisioner.Client, timeout time.Duration) *AbortRuntimeCreationStep {
+	return &AbortRuntimeCreationStep{
+		operationManager:  process.NewOperationManager(os),
+		provisionerClient: cli,
+		timeout:           timeout,
+	}
+}
+
+func (s *AbortRuntimeCreationStep) Name() string {
+	return "Abort_Runtime_Creation"
+}
+
+func (s *AbortRuntimeCreationStep) UseRetryPolicies(policies *retrypolicy.Registry) {
+	s.operationManager.UseRetryPolicies(policies, s.Name())
+}
+
+func (s *AbortRuntimeCreationStep) Run(operation internal.Operation, log logrus.FieldLogger) (internal.Operation, time.Duration, error) {
+	if operation.RuntimeID == "" {
+		log.Infof("Runtime was not created in the Provisioner, nothing to abort")
+		return operation, 0, nil
+	}
+
+	// the Provisioner rejects the deprovisioning until the provisioning of the cluster is finished, the call is retried
+	provisionerOperationID, err := s.provisionerClient.DeprovisionRuntime(tracing.StepContext(operation), operation.ProvisioningParameters.ErsContext.GlobalAccountID, operation.RuntimeID)
+	if err != nil {
+		log.Errorf("unable to deprovision runtime %s: %s", operation.RuntimeID, err)
+		return s.operationManager.RetryOperation(operation, fmt.Sprintf("unable to deprovision the runtime %s of the canceled provisioning, the runtime still exists and must be deprovisioned", operation.RuntimeID), err, 30*time.Second, s.timeout, log)
+	}
+	log.Infof("Deprovisioning of the runtime %s started, provisioner operation=%s", operation.RuntimeID, provisionerOperationID)
+	operation.EventInfof("deprovisioning of the runtime %s started by the cancellation, provisioner operation %s", operation.RuntimeID, provisionerOperationID)
+	return operation, 0, nil
+}
@@ -0,0 +1,103 @@
+package provisioning
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
+	provisionerAutomock "github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/provisioner/automock"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/pivotal-cf/brokerapi/v8/domain"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAbortRuntimeCreationStep_Run(t *testing.T) {
+	t.Run("should deprovision the created runtime", func(t *testing.T) {
+		// given
+		memoryStorage := storage.NewMemoryStorage()
+		operation := fixOperationRuntimeStatus(broker.AWSPlanID, internal.AWS)
+		operation.RuntimeID = statusRuntimeID
+		require.NoError(t, memoryStorage.Operations().InsertOperation(operation))
+
+		provisionerClient := &provisionerAutomock.Client{}
+		provisionerClient.On("DeprovisionRuntime", mock.Anything, statusGlobalAccountID, statusRuntimeID).Return("deprovisioning-id", nil).Once()
+
+		step := NewAbortRuntimeCreationStep(memoryStorage.Operations(), provisionerClient, time.Minute)
+
+		// when
+		_, repeat, err := step.Run(operation, logrus.New())
+
+		// then
+		require.NoError(t, err)
+		assert.Zero(t, repeat)
+		provisionerClient.AssertExpectations(t)
+	})
+
+	t.Run("should do nothing when the runtime was not created", func(t *testing.T) {
+		// given
+		memoryStorage := storage.NewMemoryStorage()
+		operation := fixOperationRuntimeStatus(broker.AWSPlanID, internal.AWS)
+		operation.RuntimeID = ""
+		require.NoError(t, memoryStorage.Operations().InsertOperation(operation))
+
+		provisionerClient := &provisionerAutomock.Client{}
+		step := NewAbortRuntimeCreationStep(memoryStorage.Operations(), provisionerClient, time.Minute)
+
+		// when
+		_, repeat, err := step.Run(operation, logrus.New())
+
+		// then
+		require.NoError(t, err)
+		assert.Zero(t, repeat)
+		provisionerClient.AssertNotCalled(t, "DeprovisionRuntime")
+	})
+
+	t.Run("should retry when the Provisioner rejects the deprovisioning", func(t *testing.T) {
+		// given
+		memoryStorage := storage.NewMemoryStorage()
+		operation := fixOperationRuntimeStatus(broker.AWSPlanID, internal.AWS)
+		operation.RuntimeID = statusRuntimeID
+		operation.UpdatedAt = time.Now()
+		require.NoError(t, memoryStorage.Operations().InsertOperation(operation))
+
+		provisionerClient := &provisionerAutomock.Client{}
+		provisionerClient.On("DeprovisionRuntime", mock.Anything, statusGlobalAccountID, statusRuntimeID).Return("", fmt.Errorf("provisioning in progress"))
+
+		step := NewAbortRuntimeCreationStep(memoryStorage.Operations(), provisionerClient, time.Minute)
+
+		// when
+		_, repeat, err := step.Run(operation, logrus.New())
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 30*time.Second, repeat)
+	})
+
+	t.Run("should fail the operation when the Provisioner does not accept the deprovisioning in time", func(t *testing.T) {
+		// given
+		memoryStorage := storage.NewMemoryStorage()
+		operation := fixOperationRuntimeStatus(broker.AWSPlanID, internal.AWS)
+		operation.RuntimeID = statusRuntimeID
+		operation.UpdatedAt = time.Now().Add(-2 * time.Minute)
+		require.NoError(t, memoryStorage.Operations().InsertOperation(operation))
+
+		provisionerClient := &provisionerAutomock.Client{}
+		provisionerClient.On("DeprovisionRuntime", mock.Anything, statusGlobalAccountID, statusRuntimeID).Return("", fmt.Errorf("provisioning in progress"))
+
+		step := NewAbortRuntimeCreationStep(memoryStorage.Operations(), provisionerClient, time.Minute)
+
+		// when
+		op, repeat, err := step.Run(operation, logrus.New())
+
+		// then
+		require.Error(t, err)
+		assert.Zero(t, repeat)
+		assert.Equal(t, domain.Failed, op.State)
+		assert.Contains(t, op.Description, "the runtime still exists")
+	})
+}
@@ -6,10 +6,12 @@ import (
 	"reflect"
 	"time"
 
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process/steps"
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/retrypolicy"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
 	"github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -23,18 +25,23 @@ import (
 type ApplyKymaStep struct {
 	operationManager *process.OperationManager
 	k8sClient        client.Client
+	planRegistry     *broker.PlanRegistry
 }
 
 var _ process.Step = &ApplyKymaStep{}
 
-func NewApplyKymaStep(os storage.Operations, cli client.Client) *ApplyKymaStep {
-	return &ApplyKymaStep{operationManager: process.NewOperationManager(os), k8sClient: cli}
+func NewApplyKymaStep(os storage.Operations, cli client.Client, planRegistry *broker.PlanRegistry) *ApplyKymaStep {
+	return &ApplyKymaStep{operationManager: process.NewOperationManager(os), k8sClient: cli, planRegistry: planRegistry}
 }
 
 func (a *ApplyKymaStep) Name() string {
 	return "Apply_Kyma"
 }
 
+func (a *ApplyKymaStep) UseRetryPolicies(policies *retrypolicy.Registry) {
+	a.operationManager.UseRetryPolicies(policies, a.Name())
+}
+
 func (a *ApplyKymaStep) Run(operation internal.Operation, logger logrus.FieldLogger) (internal.Operation, time.Duration, error) {
 	template, err := steps.DecodeKymaTemplate(operation.KymaTemplate)
 	if err != nil {
@@ -83,7 +90,7 @@ func (a *ApplyKymaStep) Run(operation internal.Operation, logger logrus.FieldLog
 
 func (a *ApplyKymaStep) addLabelsAndName(operation internal.Operation, obj *unstructured.Unstructured) bool {
 	oldLabels := obj.GetLabels()
-	steps.ApplyLabelsAndAnnotationsForLM(obj, operation)
+	steps.ApplyLabelsAndAnnotationsForLM(obj, operation, a.planRegistry)
 	obj.SetName(steps.KymaName(operation))
 	return !reflect.DeepEqual(obj.GetLabels(), oldLabels)
 }
@@ -46,7 +46,7 @@ func TestCreatingKymaResource(t *testing.T) {
 	operation, cli := fixOperationForApplyKymaResource(t)
 	storage := storage.NewMemoryStorage()
 	storage.Operations().InsertOperation(operation)
-	svc := NewApplyKymaStep(storage.Operations(), cli)
+	svc := NewApplyKymaStep(storage.Operations(), cli, nil)
 
 	// when
 	_, backoff, err := svc.Run(operation, logrus.New())
@@ -70,7 +70,7 @@ func TestCreatingKymaResource_UseNamespaceFromTimeOfCreationNotTemplate(t *testi
 	operation.KymaResourceNamespace = "namespace-in-time-of-creation"
 	storage := storage.NewMemoryStorage()
 	storage.Operations().InsertOperation(operation)
-	svc := NewApplyKymaStep(storage.Operations(), cli)
+	svc := NewApplyKymaStep(storage.Operations(), cli, nil)
 
 	// when
 	_, backoff, err := svc.Run(operation, logrus.New())
@@ -94,7 +94,7 @@ func TestUpdatingKymaResourceIfExists(t *testing.T) {
 	operation, cli := fixOperationForApplyKymaResource(t)
 	storage := storage.NewMemoryStorage()
 	storage.Operations().InsertOperation(operation)
-	svc := NewApplyKymaStep(storage.Operations(), cli)
+	svc := NewApplyKymaStep(storage.Operations(), cli, nil)
 	err := cli.Create(context.Background(), &unstructured.Unstructured{Object: map[string]interface{}{
 		"apiVersion": "operator.kyma-project.io/v1alpha1",
 		"kind":       "Kyma",
@@ -3,6 +3,8 @@
 package automock
 
 import (
+	context "context"
+
 	edp "github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/edp"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -12,13 +14,13 @@ type EDPClient struct {
 	mock.Mock
 }
 
-// CreateDataTenant provides a mock function with given fields: data
-func (_m *EDPClient) CreateDataTenant(data edp.DataTenantPayload) error {
-	ret := _m.Called(data)
+// CreateDataTenant provides a mock function with given fields: ctx, data
+func (_m *EDPClient) CreateDataTenant(ctx context.Context, data edp.DataTenantPayload) error {
+	ret := _m.Called(ctx, data)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(edp.DataTenantPayload) error); ok {
-		r0 = rf(data)
+	if rf, ok := ret.Get(0).(func(context.Context, edp.DataTenantPayload) error); ok {
+		r0 = rf(ctx, data)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -26,13 +28,13 @@ func (_m *EDPClient) CreateDataTenant(data edp.DataTenantPayload) error {
 	return r0
 }
 
-// CreateMetadataTenant provides a mock function with given fields: name, env, data
-func (_m *EDPClient) CreateMetadataTenant(name string, env string, data edp.MetadataTenantPayload) error {
-	ret := _m.Called(name, env, data)
+// CreateMetadataTenant provides a mock function with given fields: ctx, name, env, data
+func (_m *EDPClient) CreateMetadataTenant(ctx context.Context, name string, env string, data edp.MetadataTenantPayload) error {
+	ret := _m.Called(ctx, name, env, data)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string, edp.MetadataTenantPayload) error); ok {
-		r0 = rf(name, env, data)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, edp.MetadataTenantPayload) error); ok {
+		r0 = rf(ctx, name, env, data)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -40,13 +42,13 @@ func (_m *EDPClient) CreateMetadataTenant(name string, env string, data edp.Meta
 	return r0
 }
 
-// DeleteDataTenant provides a mock function with given fields: name, env
-func (_m *EDPClient) DeleteDataTenant(name string, env string) error {
-	ret := _m.Called(name, env)
+// DeleteDataTenant provides a mock function with given fields: ctx, name, env
+func (_m *EDPClient) DeleteDataTenant(ctx context.Context, name string, env string) error {
+	ret := _m.Called(ctx, name, env)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string) error); ok {
-		r0 = rf(name, env)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, name, env)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -54,13 +56,13 @@ func (_m *EDPClient) DeleteDataTenant(name string, env string) error {
 	return r0
 }
 
-// DeleteMetadataTenant provides a mock function with given fields: name, env, key
-func (_m *EDPClient) DeleteMetadataTenant(name string, env string, key string) error {
-	ret := _m.Called(name, env, key)
+// DeleteMetadataTenant provides a mock function with given fields: ctx, name, env, key
+func (_m *EDPClient) DeleteMetadataTenant(ctx context.Context, name string, env string, key string) error {
+	ret := _m.Called(ctx, name, env, key)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string, string) error); ok {
-		r0 = rf(name, env, key)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, name, env, key)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -10,6 +10,7 @@ import (
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/reconciler"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/tracing"
 	"github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/util/wait"
 )
@@ -42,7 +43,7 @@ func (s *CheckClusterConfigurationStep) Run(operation internal.Operation, log lo
 		return s.handleTimeout(operation, log)
 	}
 
-	state, err := s.reconcilerClient.GetCluster(operation.RuntimeID, operation.ClusterConfigurationVersion)
+	state, err := s.reconcilerClient.GetCluster(tracing.StepContext(operation), operation.RuntimeID, operation.ClusterConfigurationVersion)
 	if kebError.IsTemporaryError(err) {
 		log.Errorf("Reconciler GetCluster method failed (temporary error, retrying): %s", err.Error())
 		return operation, 1 * time.Minute, nil
@@ -81,7 +82,7 @@ func (s *CheckClusterConfigurationStep) handleTimeout(operation internal.Operati
 		In case of an error, try few times.
 	*/
 	err := wait.PollImmediate(5*time.Second, 30*time.Second, func() (bool, error) {
-		err := s.reconcilerClient.DeleteCluster(operation.RuntimeID)
+		err := s.reconcilerClient.DeleteCluster(tracing.StepContext(operation), operation.RuntimeID)
 		if err != nil {
 			log.Warnf("Unable to delete cluster: %s", err.Error())
 		}
@@ -53,7 +53,7 @@ func TestCheckClusterConfigurationStep_ClusterReady(t *testing.T) {
 	operation := fixture.FixProvisioningOperation("op-id", "inst-id")
 	operation.ClusterConfigurationVersion = 1
 	recClient := reconciler.NewFakeClient()
-	recClient.ApplyClusterConfig(reconcilerApi.Cluster{
+	recClient.ApplyClusterConfig(context.Background(), reconcilerApi.Cluster{
 		RuntimeID:    operation.RuntimeID,
 		RuntimeInput: reconcilerApi.RuntimeInput{},
 		KymaConfig:   reconcilerApi.KymaConfig{},
@@ -80,7 +80,7 @@ func TestCheckClusterConfigurationStep_InProgress(t *testing.T) {
 			operation := fixture.FixProvisioningOperation("op-id", "inst-id")
 			operation.ClusterConfigurationVersion = 1
 			recClient := reconciler.NewFakeClient()
-			recClient.ApplyClusterConfig(reconcilerApi.Cluster{
+			recClient.ApplyClusterConfig(context.Background(), reconcilerApi.Cluster{
 				RuntimeID:    operation.RuntimeID,
 				RuntimeInput: reconcilerApi.RuntimeInput{},
 				KymaConfig:   reconcilerApi.KymaConfig{},
@@ -107,7 +107,7 @@ func TestCheckClusterConfigurationStep_ClusterFailed(t *testing.T) {
 	operation := fixture.FixProvisioningOperation("op-id", "inst-id")
 	operation.ClusterConfigurationVersion = 1
 	recClient := reconciler.NewFakeClient()
-	recClient.ApplyClusterConfig(reconcilerApi.Cluster{
+	recClient.ApplyClusterConfig(context.Background(), reconcilerApi.Cluster{
 		RuntimeID:    operation.RuntimeID,
 		RuntimeInput: reconcilerApi.RuntimeInput{},
 		KymaConfig:   reconcilerApi.KymaConfig{},
@@ -229,7 +229,7 @@ func fixInputCreator(t *testing.T) internal.ProvisionerInputCreator {
 			DefaultGardenerShootPurpose:   shootPurpose,
 			AutoUpdateKubernetesVersion:   autoUpdateKubernetesVersion,
 			AutoUpdateMachineImageVersion: autoUpdateMachineImageVersion,
-		}, kymaVersion, fixTrialRegionMapping(), fixFreemiumProviders(), fixture.FixOIDCConfigDTO())
+		}, kymaVersion, fixTrialRegionMapping(), fixFreemiumProviders(), fixture.FixOIDCConfigDTO(), nil, nil)
 	assert.NoError(t, err)
 
 	pp := internal.ProvisioningParameters{
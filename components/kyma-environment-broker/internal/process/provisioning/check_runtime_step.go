@@ -10,6 +10,7 @@ import (
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/provisioner"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/tracing"
 	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
 )
 
@@ -56,7 +57,7 @@ func (s *CheckRuntimeStep) checkRuntimeStatus(operation internal.Operation, log
 		return s.operationManager.OperationFailed(operation, msg, nil, log)
 	}
 
-	status, err := s.provisionerClient.RuntimeOperationStatus(operation.ProvisioningParameters.ErsContext.GlobalAccountID, operation.ProvisionerOperationID)
+	status, err := s.provisionerClient.RuntimeOperationStatus(tracing.StepContext(operation), operation.ProvisioningParameters.ErsContext.GlobalAccountID, operation.ProvisionerOperationID)
 	if err != nil {
 		log.Errorf("call to provisioner RuntimeOperationStatus failed: %s", err.Error())
 		return operation, 1 * time.Minute, nil
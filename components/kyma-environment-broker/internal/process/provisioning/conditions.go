@@ -16,3 +16,12 @@ func SkipForOwnClusterPlan(operation internal.Operation) bool {
 func DoForOwnClusterPlanOnly(operation internal.Operation) bool {
 	return !SkipForOwnClusterPlan(operation)
 }
+
+func WhenCustomerHyperscalerAccountProvided(operation internal.Operation) bool {
+	return operation.ProvisioningParameters.Parameters.IsCustomerAccount()
+}
+
+// WhenRuntimeNotCreated is true until the runtime is created in the Provisioner, the hyperscaler account is not used by a cluster yet
+func WhenRuntimeNotCreated(operation internal.Operation) bool {
+	return operation.RuntimeID == ""
+}
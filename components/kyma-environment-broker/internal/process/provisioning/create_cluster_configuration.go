@@ -12,6 +12,7 @@ import (
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/reconciler"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/tracing"
 	"github.com/sirupsen/logrus"
 )
 
@@ -76,7 +77,7 @@ func (s *CreateClusterConfigurationStep) Run(operation internal.Operation, log l
 		clusterConfiguration.RuntimeInput.Name,
 		sha256.Sum256([]byte(clusterConfiguration.Kubeconfig)))
 
-	state, err := s.reconcilerClient.ApplyClusterConfig(clusterConfiguration)
+	state, err := s.reconcilerClient.ApplyClusterConfig(tracing.StepContext(operation), clusterConfiguration)
 	switch {
 	case kebError.IsTemporaryError(err):
 		msg := fmt.Sprintf("Request to Reconciler failed: %s", err.Error())
@@ -0,0 +1,68 @@
+package provisioning
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/hyperscaler"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/retrypolicy"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/sirupsen/logrus"
+)
+
+// CreateCustomerSecretBindingStep verifies the credentials of the customer hyperscaler account and creates the secret binding
+// dedicated to the instance. The secret binding is set as the target secret, so the ResolveCredentialsStep does not use the account pool.
+type CreateCustomerSecretBindingStep struct {
+	operationManager *process.OperationManager
+	customerAccounts hyperscaler.CustomerAccountPool
+	verifier         hyperscaler.CredentialsVerifier
+}
+
+func NewCreateCustomerSecretBindingStep(os storage.Operations, customerAccounts hyperscaler.CustomerAccountPool, verifier hyperscaler.CredentialsVerifier) *CreateCustomerSecretBindingStep {
+	return &CreateCustomerSecretBindingStep{
+		operationManager: process.NewOperationManager(os),
+		customerAccounts: customerAccounts,
+		verifier:         verifier,
+	}
+}
+
+func (s *CreateCustomerSecretBindingStep) Name() string {
+	return "Create_Customer_Secret_Binding"
+}
+
+func (s *CreateCustomerSecretBindingStep) UseRetryPolicies(policies *retrypolicy.Registry) {
+	s.operationManager.UseRetryPolicies(policies, s.Name())
+}
+
+func (s *CreateCustomerSecretBindingStep) Run(operation internal.Operation, log logrus.FieldLogger) (internal.Operation, time.Duration, error) {
+	if !operation.ProvisioningParameters.Parameters.IsCustomerAccount() || operation.ProvisioningParameters.Parameters.TargetSecret != nil {
+		return operation, 0, nil
+	}
+
+	hypType, err := hyperscaler.FromCloudProvider(operation.InputCreator.Provider())
+	if err != nil {
+		return s.operationManager.OperationFailed(operation, fmt.Sprintf("failing to determine the type of Hyperscaler to use for planID: %s", operation.ProvisioningParameters.PlanID), err, log)
+	}
+
+	credentials := operation.ProvisioningParameters.Parameters.HyperscalerAccount.Credentials
+	err = s.verifier.Verify(hypType, credentials)
+	switch {
+	case errors.Is(err, hyperscaler.ErrCredentialsRejected):
+		return s.operationManager.OperationFailed(operation, fmt.Sprintf("invalid credentials of the %s hyperscaler account", hypType), err, log)
+	case err != nil:
+		return s.operationManager.RetryOperation(operation, fmt.Sprintf("unable to verify the credentials of the %s hyperscaler account", hypType), err, 10*time.Second, 10*time.Minute, log)
+	}
+
+	secretName, err := s.customerAccounts.CreateSecretBinding(hypType, operation.InstanceID, credentials)
+	if err != nil {
+		return s.operationManager.RetryOperation(operation, "unable to create the secret binding of the hyperscaler account", err, 10*time.Second, 10*time.Minute, log)
+	}
+
+	log.Infof("Created %s secret binding of the customer hyperscaler account on Hyperscaler %s", secretName, hypType)
+	return s.operationManager.UpdateOperation(operation, func(op *internal.Operation) {
+		op.ProvisioningParameters.Parameters.TargetSecret = &secretName
+	}, log)
+}
@@ -0,0 +1,115 @@
+package provisioning
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/gardener"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/hyperscaler"
+	hyperscalerMocks "github.com/kyma-project/control-plane/components/kyma-environment-broker/common/hyperscaler/automock"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/pivotal-cf/brokerapi/v8/domain"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCreateCustomerSecretBindingStep_Run(t *testing.T) {
+	t.Run("should create the secret binding and set it as the target secret", func(t *testing.T) {
+		// given
+		memoryStorage := storage.NewMemoryStorage()
+		gardenerClient := gardener.NewDynamicFakeClient()
+		operation := fixOperationRuntimeStatus(broker.AWSPlanID, internal.AWS)
+		operation.ProvisioningParameters.Parameters.HyperscalerAccount = &internal.HyperscalerAccountDTO{
+			Credentials: map[string]string{"accessKeyID": "key-id", "secretAccessKey": "secret"},
+		}
+		err := memoryStorage.Operations().InsertOperation(operation)
+		require.NoError(t, err)
+
+		step := NewCreateCustomerSecretBindingStep(memoryStorage.Operations(), hyperscaler.NewCustomerAccountPool(gardenerClient, namespace), hyperscaler.NewStubCredentialsVerifier())
+
+		// when
+		operation, repeat, err := step.Run(operation, logrus.New())
+
+		// then
+		require.NoError(t, err)
+		assert.Zero(t, repeat)
+		require.NotNil(t, operation.ProvisioningParameters.Parameters.TargetSecret)
+		assert.Equal(t, hyperscaler.CustomerSecretBindingName(operation.InstanceID), *operation.ProvisioningParameters.Parameters.TargetSecret)
+		_, err = gardenerClient.Resource(gardener.SecretBindingResource).Namespace(namespace).Get(context.Background(), *operation.ProvisioningParameters.Parameters.TargetSecret, metav1.GetOptions{})
+		assert.NoError(t, err)
+	})
+
+	t.Run("should fail the operation for invalid credentials", func(t *testing.T) {
+		// given
+		memoryStorage := storage.NewMemoryStorage()
+		operation := fixOperationRuntimeStatus(broker.AWSPlanID, internal.AWS)
+		operation.ProvisioningParameters.Parameters.HyperscalerAccount = &internal.HyperscalerAccountDTO{
+			Credentials: map[string]string{"accessKeyID": "key-id"},
+		}
+		err := memoryStorage.Operations().InsertOperation(operation)
+		require.NoError(t, err)
+
+		step := NewCreateCustomerSecretBindingStep(memoryStorage.Operations(), hyperscaler.NewCustomerAccountPool(gardener.NewDynamicFakeClient(), namespace), hyperscaler.NewStubCredentialsVerifier())
+
+		// when
+		operation, _, err = step.Run(operation, logrus.New())
+
+		// then
+		require.Error(t, err)
+		assert.Equal(t, domain.Failed, operation.State)
+		assert.Nil(t, operation.ProvisioningParameters.Parameters.TargetSecret)
+	})
+
+	t.Run("should retry the operation when the credentials cannot be verified", func(t *testing.T) {
+		// given
+		memoryStorage := storage.NewMemoryStorage()
+		gardenerClient := gardener.NewDynamicFakeClient()
+		operation := fixOperationRuntimeStatus(broker.AWSPlanID, internal.AWS)
+		credentials := map[string]string{"accessKeyID": "key-id", "secretAccessKey": "secret"}
+		operation.ProvisioningParameters.Parameters.HyperscalerAccount = &internal.HyperscalerAccountDTO{Credentials: credentials}
+		err := memoryStorage.Operations().InsertOperation(operation)
+		require.NoError(t, err)
+
+		verifier := &hyperscalerMocks.CredentialsVerifier{}
+		verifier.On("Verify", hyperscaler.AWS, credentials).Return(errors.New("while verifying AWS credentials: i/o timeout"))
+		defer verifier.AssertExpectations(t)
+
+		step := NewCreateCustomerSecretBindingStep(memoryStorage.Operations(), hyperscaler.NewCustomerAccountPool(gardenerClient, namespace), verifier)
+
+		// when
+		operation, repeat, err := step.Run(operation, logrus.New())
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 10*time.Second, repeat)
+		assert.Equal(t, domain.InProgress, operation.State)
+		assert.Nil(t, operation.ProvisioningParameters.Parameters.TargetSecret)
+		_, err = gardenerClient.Resource(gardener.SecretBindingResource).Namespace(namespace).Get(context.Background(), hyperscaler.CustomerSecretBindingName(operation.InstanceID), metav1.GetOptions{})
+		assert.Error(t, err)
+	})
+
+	t.Run("should skip when the target secret is resolved", func(t *testing.T) {
+		// given
+		memoryStorage := storage.NewMemoryStorage()
+		operation := fixOperationRuntimeStatus(broker.AWSPlanID, internal.AWS)
+		operation.ProvisioningParameters.Parameters.HyperscalerAccount = &internal.HyperscalerAccountDTO{}
+		secretName := "customer-secret"
+		operation.ProvisioningParameters.Parameters.TargetSecret = &secretName
+
+		step := NewCreateCustomerSecretBindingStep(memoryStorage.Operations(), hyperscaler.NewCustomerAccountPool(gardener.NewDynamicFakeClient(), namespace), hyperscaler.NewStubCredentialsVerifier())
+
+		// when
+		operation, repeat, err := step.Run(operation, logrus.New())
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, time.Duration(0), repeat)
+		assert.Equal(t, "customer-secret", *operation.ProvisioningParameters.Parameters.TargetSecret)
+	})
+}
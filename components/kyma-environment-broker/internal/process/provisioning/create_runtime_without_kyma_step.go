@@ -10,6 +10,7 @@ import (
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/provisioner"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/tracing"
 	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
 	"github.com/sirupsen/logrus"
 )
@@ -64,7 +65,7 @@ func (s *CreateRuntimeWithoutKymaStep) Run(operation internal.Operation, log log
 		requestInput.ClusterConfig.GardenerConfig.Provider,
 		requestInput.ClusterConfig.GardenerConfig.Name)
 
-	provisionerResponse, err := s.provisionerClient.ProvisionRuntime(operation.ProvisioningParameters.ErsContext.GlobalAccountID, operation.ProvisioningParameters.ErsContext.SubAccountID, requestInput)
+	provisionerResponse, err := s.provisionerClient.ProvisionRuntime(tracing.StepContext(operation), operation.ProvisioningParameters.ErsContext.GlobalAccountID, operation.ProvisioningParameters.ErsContext.SubAccountID, requestInput)
 	switch {
 	case kebError.IsTemporaryError(err):
 		log.Errorf("call to provisioner failed (temporary error): %s", err)
@@ -33,7 +33,7 @@ func TestCreateRuntimeWithoutKyma_Run(t *testing.T) {
 	provisionerInput := fixProvisionerInput(disabled, false)
 
 	provisionerClient := &provisionerAutomock.Client{}
-	provisionerClient.On("ProvisionRuntime", globalAccountID, subAccountID, mock.MatchedBy(
+	provisionerClient.On("ProvisionRuntime", mock.Anything, globalAccountID, subAccountID, mock.MatchedBy(
 		func(input gqlschema.ProvisionRuntimeInput) bool {
 			return reflect.DeepEqual(input.RuntimeInput.Labels, provisionerInput.RuntimeInput.Labels) &&
 				input.KymaConfig == nil && reflect.DeepEqual(input.ClusterConfig, provisionerInput.ClusterConfig)
@@ -79,7 +79,7 @@ func TestCreateRuntimeWithoutKyma_RunWithEuAccess(t *testing.T) {
 	provisionerInput := fixProvisionerInput(disabled, true)
 
 	provisionerClient := &provisionerAutomock.Client{}
-	provisionerClient.On("ProvisionRuntime", globalAccountID, subAccountID, mock.MatchedBy(
+	provisionerClient.On("ProvisionRuntime", mock.Anything, globalAccountID, subAccountID, mock.MatchedBy(
 		func(input gqlschema.ProvisionRuntimeInput) bool {
 			return reflect.DeepEqual(input.RuntimeInput.Labels, provisionerInput.RuntimeInput.Labels) &&
 				input.KymaConfig == nil && reflect.DeepEqual(input.ClusterConfig, provisionerInput.ClusterConfig)
@@ -186,7 +186,7 @@ func TestCreateRuntimeWithoutKymaStep_RunWithBadRequestError(t *testing.T) {
 	assert.NoError(t, err)
 
 	provisionerClient := &provisionerAutomock.Client{}
-	provisionerClient.On("ProvisionRuntime", globalAccountID, subAccountID, mock.Anything).Return(gqlschema.OperationStatus{}, fmt.Errorf("some permanent error"))
+	provisionerClient.On("ProvisionRuntime", mock.Anything, globalAccountID, subAccountID, mock.Anything).Return(gqlschema.OperationStatus{}, fmt.Errorf("some permanent error"))
 
 	step := NewCreateRuntimeWithoutKymaStep(memoryStorage.Operations(), memoryStorage.RuntimeStates(), memoryStorage.Instances(), provisionerClient)
 
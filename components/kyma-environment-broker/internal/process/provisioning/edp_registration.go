@@ -1,6 +1,8 @@
 package provisioning
 
 import (
+	"context"
+
 	"encoding/base64"
 	"fmt"
 	"strings"
@@ -12,17 +14,18 @@ import (
 	kebError "github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/error"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/tracing"
 
 	"github.com/sirupsen/logrus"
 )
 
 //go:generate mockery --name=EDPClient --output=automock --outpkg=automock --case=underscore
 type EDPClient interface {
-	CreateDataTenant(data edp.DataTenantPayload) error
-	CreateMetadataTenant(name, env string, data edp.MetadataTenantPayload) error
+	CreateDataTenant(ctx context.Context, data edp.DataTenantPayload) error
+	CreateMetadataTenant(ctx context.Context, name, env string, data edp.MetadataTenantPayload) error
 
-	DeleteDataTenant(name, env string) error
-	DeleteMetadataTenant(name, env, key string) error
+	DeleteDataTenant(ctx context.Context, name, env string) error
+	DeleteMetadataTenant(ctx context.Context, name, env, key string) error
 }
 
 type EDPRegistrationStep struct {
@@ -50,7 +53,7 @@ func (s *EDPRegistrationStep) Run(operation internal.Operation, log logrus.Field
 	subAccountID := strings.ToLower(operation.ProvisioningParameters.ErsContext.SubAccountID)
 
 	log.Infof("Create DataTenant for %s subaccount (env=%s)", subAccountID, s.config.Environment)
-	err := s.client.CreateDataTenant(edp.DataTenantPayload{
+	err := s.client.CreateDataTenant(tracing.StepContext(operation), edp.DataTenantPayload{
 		Name:        subAccountID,
 		Environment: s.config.Environment,
 		Secret:      s.generateSecret(subAccountID, s.config.Environment),
@@ -75,7 +78,7 @@ func (s *EDPRegistrationStep) Run(operation internal.Operation, log logrus.Field
 			Value: value,
 		}
 		log.Infof("Sending metadata %s: %s", payload.Key, payload.Value)
-		err = s.client.CreateMetadataTenant(subAccountID, s.config.Environment, payload)
+		err = s.client.CreateMetadataTenant(tracing.StepContext(operation), subAccountID, s.config.Environment, payload)
 		if err != nil {
 			if edp.IsConflictError(err) {
 				log.Warnf("Metadata already exists, deleting")
@@ -155,14 +158,14 @@ func (s *EDPRegistrationStep) handleConflict(operation internal.Operation, log l
 		edp.MaasConsumerServicePlan,
 	} {
 		log.Infof("Deleting DataTenant metadata %s (%s): %s", operation.SubAccountID, s.config.Environment, key)
-		err := s.client.DeleteMetadataTenant(operation.SubAccountID, s.config.Environment, key)
+		err := s.client.DeleteMetadataTenant(tracing.StepContext(operation), operation.SubAccountID, s.config.Environment, key)
 		if err != nil {
 			return s.handleError(operation, err, log, fmt.Sprintf("cannot remove DataTenant metadata with key: %s", key))
 		}
 	}
 
 	log.Infof("Deleting DataTenant %s (%s)", operation.SubAccountID, s.config.Environment)
-	err := s.client.DeleteDataTenant(operation.SubAccountID, s.config.Environment)
+	err := s.client.DeleteDataTenant(tracing.StepContext(operation), operation.SubAccountID, s.config.Environment)
 	if err != nil {
 		return s.handleError(operation, err, log, "cannot remove DataTenant")
 	}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/retrypolicy"
 
 	"github.com/sirupsen/logrus"
 
@@ -27,6 +28,12 @@ func (s EnableForTrialPlanStep) Name() string {
 	return s.step.Name()
 }
 
+func (s EnableForTrialPlanStep) UseRetryPolicies(policies *retrypolicy.Registry) {
+	if user, ok := s.step.(process.RetryPolicyUser); ok {
+		user.UseRetryPolicies(policies)
+	}
+}
+
 func (s EnableForTrialPlanStep) Run(operation internal.Operation, log logrus.FieldLogger) (internal.Operation, time.Duration, error) {
 	if !broker.IsTrialPlan(operation.ProvisioningParameters.PlanID) {
 		log.Infof("Skipping step %s", s.Name())
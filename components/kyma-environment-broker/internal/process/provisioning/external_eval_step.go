@@ -14,14 +14,16 @@ import (
 
 type ExternalEvalStep struct {
 	externalEvalCreator *ExternalEvalCreator
+	planRegistry        *broker.PlanRegistry
 }
 
 // ensure the interface is implemented
 var _ process.Step = (*ExternalEvalStep)(nil)
 
-func NewExternalEvalStep(externalEvalCreator *ExternalEvalCreator) *ExternalEvalStep {
+func NewExternalEvalStep(externalEvalCreator *ExternalEvalCreator, planRegistry *broker.PlanRegistry) *ExternalEvalStep {
 	return &ExternalEvalStep{
 		externalEvalCreator: externalEvalCreator,
+		planRegistry:        planRegistry,
 	}
 }
 
@@ -30,7 +32,7 @@ func (e ExternalEvalStep) Name() string {
 }
 
 func (s *ExternalEvalStep) Run(operation internal.Operation, log logrus.FieldLogger) (internal.Operation, time.Duration, error) {
-	if broker.IsTrialPlan(operation.ProvisioningParameters.PlanID) || broker.IsFreemiumPlan(operation.ProvisioningParameters.PlanID) {
+	if broker.IsTrialPlan(operation.ProvisioningParameters.PlanID) || s.planRegistry.IsFreemiumPlan(operation.ProvisioningParameters.PlanID) {
 		log.Debug("skipping AVS external evaluation creation for trial/freemium plan")
 		return operation, 0, nil
 	}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/tracing"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/sirupsen/logrus"
@@ -60,7 +61,7 @@ func (s *GetKubeconfigStep) Run(operation internal.Operation, log logrus.FieldLo
 
 func (s *GetKubeconfigStep) getKubeconfigFromRuntimeStatus(operation internal.Operation, log logrus.FieldLogger) (string, time.Duration, error) {
 
-	status, err := s.provisionerClient.RuntimeStatus(operation.ProvisioningParameters.ErsContext.GlobalAccountID, operation.RuntimeID)
+	status, err := s.provisionerClient.RuntimeStatus(tracing.StepContext(operation), operation.ProvisioningParameters.ErsContext.GlobalAccountID, operation.RuntimeID)
 	if err != nil {
 		log.Errorf("call to provisioner RuntimeStatus failed: %s", err.Error())
 		return "", 1 * time.Minute, nil
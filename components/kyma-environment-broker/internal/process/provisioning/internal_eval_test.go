@@ -52,7 +52,7 @@ func TestInternalEvaluationStep_Run(t *testing.T) {
 	avsClient, err := avs.NewClient(context.TODO(), avsConfig, logrus.New())
 	assert.NoError(t, err)
 	avsDel := avs.NewDelegator(avsClient, avsConfig, memoryStorage.Operations())
-	internalEvalAssistant := avs.NewInternalEvalAssistant(avsConfig)
+	internalEvalAssistant := avs.NewInternalEvalAssistant(avsConfig, nil)
 	ies := NewInternalEvaluationStep(avsDel, internalEvalAssistant)
 
 	// when
@@ -91,7 +91,7 @@ func TestInternalEvaluationStep_WhenOperationIsRepeatedWithIdPresent(t *testing.
 	avsClient, err := avs.NewClient(context.TODO(), avsConfig, logrus.New())
 	assert.NoError(t, err)
 	avsDel := avs.NewDelegator(avsClient, avsConfig, memoryStorage.Operations())
-	internalEvalAssistant := avs.NewInternalEvalAssistant(avsConfig)
+	internalEvalAssistant := avs.NewInternalEvalAssistant(avsConfig, nil)
 	ies := NewInternalEvaluationStep(avsDel, internalEvalAssistant)
 
 	// when
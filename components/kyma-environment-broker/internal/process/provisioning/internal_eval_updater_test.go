@@ -63,7 +63,7 @@ func TestInternalEvalUpdater_AddTagsToEval(t *testing.T) {
 		avsClient, err := avs.NewClient(context.TODO(), avsConfig, logrus.New())
 		assert.NoError(t, err)
 		avsDel := avs.NewDelegator(avsClient, avsConfig, memoryStorage.Operations())
-		internalEvalAssistant := avs.NewInternalEvalAssistant(avsConfig)
+		internalEvalAssistant := avs.NewInternalEvalAssistant(avsConfig, nil)
 		evalUpdater := NewInternalEvalUpdater(avsDel, internalEvalAssistant, avsConfig)
 
 		// when
@@ -129,7 +129,7 @@ func TestInternalEvalUpdater_AddTagsToEval(t *testing.T) {
 		avsClient, err := avs.NewClient(context.TODO(), avsConfig, logrus.New())
 		assert.NoError(t, err)
 		avsDel := avs.NewDelegator(avsClient, avsConfig, memoryStorage.Operations())
-		internalEvalAssistant := avs.NewInternalEvalAssistant(avsConfig)
+		internalEvalAssistant := avs.NewInternalEvalAssistant(avsConfig, nil)
 		evalUpdater := NewInternalEvalUpdater(avsDel, internalEvalAssistant, avsConfig)
 
 		// when
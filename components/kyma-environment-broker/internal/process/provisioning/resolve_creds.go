@@ -80,3 +80,56 @@ func (s *ResolveCredentialsStep) Run(operation internal.Operation, log logrus.Fi
 
 	return *updatedOperation, 0, nil
 }
+
+// PlanProvider returns the cloud provider on which the runtime of the plan is created
+type PlanProvider func(planID string, platformProvider internal.CloudProvider, parametersProvider *internal.CloudProvider) (internal.CloudProvider, error)
+
+// CredentialsValidator performs the lookup of the ResolveCredentialsStep without assigning a secret binding to the global account,
+// the credentials of the customer hyperscaler account are verified instead
+type CredentialsValidator struct {
+	accountProvider hyperscaler.AccountProvider
+	planProvider    PlanProvider
+	verifier        hyperscaler.CredentialsVerifier
+}
+
+func NewCredentialsValidator(accountProvider hyperscaler.AccountProvider, planProvider PlanProvider, verifier hyperscaler.CredentialsVerifier) *CredentialsValidator {
+	return &CredentialsValidator{
+		accountProvider: accountProvider,
+		planProvider:    planProvider,
+		verifier:        verifier,
+	}
+}
+
+// ValidateCredentials returns an error if the credentials for the runtime cannot be resolved
+func (v *CredentialsValidator) ValidateCredentials(pp internal.ProvisioningParameters) error {
+	if pp.Parameters.TargetSecret != nil || broker.IsOwnClusterPlan(pp.PlanID) {
+		return nil
+	}
+
+	provider, err := v.planProvider(pp.PlanID, pp.PlatformProvider, pp.Parameters.Provider)
+	if err != nil {
+		return fmt.Errorf("while determining the provider of plan %s: %w", pp.PlanID, err)
+	}
+	hypType, err := hyperscaler.FromCloudProvider(provider)
+	if err != nil {
+		return err
+	}
+
+	if pp.Parameters.IsCustomerAccount() {
+		if err := v.verifier.Verify(hypType, pp.Parameters.HyperscalerAccount.Credentials); err != nil {
+			return fmt.Errorf("invalid credentials of the %s hyperscaler account: %w", hypType, err)
+		}
+		return nil
+	}
+
+	euAccess := internal.IsEuAccess(pp.PlatformRegion)
+	if broker.IsTrialPlan(pp.PlanID) {
+		_, err = v.accountProvider.GardenerSharedSecretName(hypType, euAccess)
+	} else {
+		_, err = v.accountProvider.LookupGardenerSecretName(hypType, pp.ErsContext.GlobalAccountID, euAccess)
+	}
+	if err != nil {
+		return fmt.Errorf("no credentials available for global account %s on hyperscaler %s: %w", pp.ErsContext.GlobalAccountID, hypType, err)
+	}
+	return nil
+}
@@ -331,3 +331,54 @@ func fixEuAccessSecretBinding(name, hyperscalerType string) *unstructured.Unstru
 	o.SetLabels(labels)
 	return o
 }
+
+func TestCredentialsValidator_ValidateCredentials(t *testing.T) {
+	planProvider := func(planID string, platformProvider internal.CloudProvider, parametersProvider *internal.CloudProvider) (internal.CloudProvider, error) {
+		return internal.AWS, nil
+	}
+
+	t.Run("should look up credentials without assigning them", func(t *testing.T) {
+		// given
+		accountProviderMock := &hyperscalerMocks.AccountProvider{}
+		accountProviderMock.On("LookupGardenerSecretName", hyperscaler.AWS, globalAccountID, true).Return("gardener-secret-aws", nil)
+		validator := NewCredentialsValidator(accountProviderMock, planProvider, hyperscaler.NewStubCredentialsVerifier())
+		pp := fixProvisioningParametersWithPlanID(broker.AWSPlanID, "eu-central-1", "cf-eu11")
+
+		// when
+		err := validator.ValidateCredentials(pp)
+
+		// then
+		assert.NoError(t, err)
+		accountProviderMock.AssertExpectations(t)
+	})
+
+	t.Run("should use shared credentials for trial", func(t *testing.T) {
+		// given
+		accountProviderMock := &hyperscalerMocks.AccountProvider{}
+		accountProviderMock.On("GardenerSharedSecretName", hyperscaler.AWS, false).Return("", fmt.Errorf("no shared secret binding"))
+		validator := NewCredentialsValidator(accountProviderMock, planProvider, hyperscaler.NewStubCredentialsVerifier())
+		pp := fixProvisioningParametersWithPlanID(broker.TrialPlanID, "", "cf-us10")
+
+		// when
+		err := validator.ValidateCredentials(pp)
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no credentials available")
+	})
+	t.Run("should verify the customer hyperscaler account instead of the lookup", func(t *testing.T) {
+		// given
+		accountProviderMock := &hyperscalerMocks.AccountProvider{}
+		validator := NewCredentialsValidator(accountProviderMock, planProvider, hyperscaler.NewStubCredentialsVerifier())
+		pp := fixProvisioningParametersWithPlanID(broker.AWSPlanID, "eu-central-1", "cf-eu11")
+		pp.Parameters.HyperscalerAccount = &internal.HyperscalerAccountDTO{Credentials: map[string]string{"accessKeyID": "key-id"}}
+
+		// when
+		err := validator.ValidateCredentials(pp)
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "must contain: secretAccessKey")
+		accountProviderMock.AssertNotCalled(t, "LookupGardenerSecretName", hyperscaler.AWS, globalAccountID, true)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/tracing"
 
 	"github.com/sirupsen/logrus"
 
@@ -32,7 +33,7 @@ func (e *RuntimeTagsStep) Name() string {
 }
 
 func (s *RuntimeTagsStep) Run(operation internal.Operation, log logrus.FieldLogger) (internal.Operation, time.Duration, error) {
-	status, err := s.provisionerClient.RuntimeStatus(operation.ProvisioningParameters.ErsContext.GlobalAccountID, operation.RuntimeID)
+	status, err := s.provisionerClient.RuntimeStatus(tracing.StepContext(operation), operation.ProvisioningParameters.ErsContext.GlobalAccountID, operation.RuntimeID)
 	if err != nil {
 		return operation, 1 * time.Minute, err
 	}
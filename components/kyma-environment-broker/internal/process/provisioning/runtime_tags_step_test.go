@@ -15,6 +15,7 @@ import (
 	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -55,9 +56,9 @@ func setupAvs(t *testing.T, operations storage.Operations) (*InternalEvalUpdater
 	avsClient, err := avs.NewClient(context.TODO(), avsConfig, logrus.New())
 	require.NoError(t, err)
 	avsDel := avs.NewDelegator(avsClient, avsConfig, operations)
-	internalEvalAssistant := avs.NewInternalEvalAssistant(avsConfig)
+	internalEvalAssistant := avs.NewInternalEvalAssistant(avsConfig, nil)
 	internalEvalUpdater := NewInternalEvalUpdater(avsDel, internalEvalAssistant, avsConfig)
-	externalEvalAssistant := avs.NewExternalEvalAssistant(avsConfig)
+	externalEvalAssistant := avs.NewExternalEvalAssistant(avsConfig, nil)
 	externalEvalCreator := NewExternalEvalCreator(avsDel, false, externalEvalAssistant)
 
 	return internalEvalUpdater, externalEvalCreator, mockOauthServer, mockAvsSvc
@@ -65,7 +66,7 @@ func setupAvs(t *testing.T, operations storage.Operations) (*InternalEvalUpdater
 
 func setupProvisionerClient(runtimeID string) provisioner.Client {
 	provisionerClient := &provisionerAutomock.Client{}
-	provisionerClient.On("RuntimeStatus", statusGlobalAccountID, runtimeID).Return(gqlschema.RuntimeStatus{
+	provisionerClient.On("RuntimeStatus", mock.Anything, statusGlobalAccountID, runtimeID).Return(gqlschema.RuntimeStatus{
 		LastOperationStatus:     nil,
 		RuntimeConnectionStatus: nil,
 		RuntimeConfiguration: &gqlschema.RuntimeConfig{ClusterConfig: &gqlschema.GardenerConfig{
@@ -10,6 +10,7 @@ import (
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
 
 	"github.com/sirupsen/logrus"
 )
@@ -27,14 +28,16 @@ type OverridesFromSecretsAndConfigStep struct {
 	operationManager       *process.OperationManager
 	runtimeOverrides       RuntimeOverridesAppender
 	runtimeVerConfigurator RuntimeVersionConfiguratorForProvisioning
+	planRegistry           *broker.PlanRegistry
 }
 
 func NewOverridesFromSecretsAndConfigStep(os storage.Operations, runtimeOverrides RuntimeOverridesAppender,
-	rvc RuntimeVersionConfiguratorForProvisioning) *OverridesFromSecretsAndConfigStep {
+	rvc RuntimeVersionConfiguratorForProvisioning, planRegistry *broker.PlanRegistry) *OverridesFromSecretsAndConfigStep {
 	return &OverridesFromSecretsAndConfigStep{
 		operationManager:       process.NewOperationManager(os),
 		runtimeOverrides:       runtimeOverrides,
 		runtimeVerConfigurator: rvc,
+		planRegistry:           planRegistry,
 	}
 }
 
@@ -43,7 +46,7 @@ func (s *OverridesFromSecretsAndConfigStep) Name() string {
 }
 
 func (s *OverridesFromSecretsAndConfigStep) Run(operation internal.Operation, log logrus.FieldLogger) (internal.Operation, time.Duration, error) {
-	planName, exists := broker.PlanNamesMapping[operation.ProvisioningParameters.PlanID]
+	planName, exists := s.planRegistry.PlanNames()[operation.ProvisioningParameters.PlanID]
 	if !exists {
 		log.Errorf("cannot map planID '%s' to planName", operation.ProvisioningParameters.PlanID)
 		return s.operationManager.OperationFailed(operation, "invalid operation provisioning parameters", nil, log)
@@ -69,10 +72,24 @@ func (s *OverridesFromSecretsAndConfigStep) Run(operation internal.Operation, lo
 		log.Error(fmt.Sprintf("%s: %s", errMsg, err.Error()))
 		return s.operationManager.OperationFailed(operation, errMsg, err, log)
 	}
+	appendHookOverrides(operation.InputCreator, operation.HookOverrides)
 
 	return operation, 0, nil
 }
 
+// appendHookOverrides appends the overrides returned by the hooks after the overrides from secrets and config, so they take precedence
+func appendHookOverrides(creator internal.ProvisionerInputCreator, overrides []internal.HookOverride) {
+	for _, override := range overrides {
+		secret := override.Secret
+		entry := []*gqlschema.ConfigEntryInput{{Key: override.Key, Value: override.Value, Secret: &secret}}
+		if override.Component == "" {
+			creator.AppendGlobalOverrides(entry)
+		} else {
+			creator.AppendOverrides(override.Component, entry)
+		}
+	}
+}
+
 func (s *OverridesFromSecretsAndConfigStep) getRuntimeVersion(op internal.Operation) (*internal.RuntimeVersionData, error) {
 	// for some previously stored operations the RuntimeVersion property may not be initialized
 	if op.RuntimeVersion.Version != "" {
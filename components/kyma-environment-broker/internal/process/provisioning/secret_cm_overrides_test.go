@@ -7,6 +7,7 @@ import (
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process/provisioning/automock"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
 
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
@@ -40,7 +41,7 @@ func TestOverridesFromSecretsAndConfigStep_Run_WithVersionComputed(t *testing.T)
 		defer rcvMock.AssertExpectations(t)
 		rcvMock.On("ForProvisioning", mock.Anything, mock.Anything).Return(&internal.RuntimeVersionData{Version: kymaVersion}, nil).Once()
 
-		step := NewOverridesFromSecretsAndConfigStep(memoryStorage.Operations(), runtimeOverridesMock, rcvMock)
+		step := NewOverridesFromSecretsAndConfigStep(memoryStorage.Operations(), runtimeOverridesMock, rcvMock, nil)
 
 		// When
 		operation, repeat, err := step.Run(operation, logrus.New())
@@ -78,7 +79,7 @@ func TestOverridesFromSecretsAndConfigStep_Run_WithVersionFromOperation(t *testi
 		rcvMock := &automock.RuntimeVersionConfiguratorForProvisioning{}
 		defer rcvMock.AssertExpectations(t)
 
-		step := NewOverridesFromSecretsAndConfigStep(memoryStorage.Operations(), runtimeOverridesMock, rcvMock)
+		step := NewOverridesFromSecretsAndConfigStep(memoryStorage.Operations(), runtimeOverridesMock, rcvMock, nil)
 
 		// When
 		operation, repeat, err := step.Run(operation, logrus.New())
@@ -88,3 +89,38 @@ func TestOverridesFromSecretsAndConfigStep_Run_WithVersionFromOperation(t *testi
 		assert.Equal(t, time.Duration(0), repeat)
 	})
 }
+
+func TestOverridesFromSecretsAndConfigStep_Run_WithHookOverrides(t *testing.T) {
+	// given
+	kymaVersion := "1.15.0"
+	memoryStorage := storage.NewMemoryStorage()
+
+	inputCreatorMock := &automock.ProvisionerInputCreator{}
+	defer inputCreatorMock.AssertExpectations(t)
+	secret := true
+	inputCreatorMock.On("AppendOverrides", "monitoring", []*gqlschema.ConfigEntryInput{{Key: "retention", Value: "7d", Secret: new(bool)}}).Return(inputCreatorMock).Once()
+	inputCreatorMock.On("AppendGlobalOverrides", []*gqlschema.ConfigEntryInput{{Key: "token", Value: "abc", Secret: &secret}}).Return(inputCreatorMock).Once()
+
+	runtimeOverridesMock := &automock.RuntimeOverridesAppender{}
+	defer runtimeOverridesMock.AssertExpectations(t)
+	runtimeOverridesMock.On("Append", inputCreatorMock, "gcp", kymaVersion).Return(nil).Once()
+
+	operation := internal.Operation{
+		ProvisioningParameters: internal.ProvisioningParameters{PlanID: "ca6e5357-707f-4565-bbbd-b3ab732597c6"},
+		InputCreator:           inputCreatorMock,
+		RuntimeVersion:         internal.RuntimeVersionData{Version: kymaVersion},
+		HookOverrides: []internal.HookOverride{
+			{Component: "monitoring", Key: "retention", Value: "7d"},
+			{Key: "token", Value: "abc", Secret: true},
+		},
+		Type: internal.OperationTypeProvision,
+	}
+	step := NewOverridesFromSecretsAndConfigStep(memoryStorage.Operations(), runtimeOverridesMock, &automock.RuntimeVersionConfiguratorForProvisioning{}, nil)
+
+	// when
+	_, repeat, err := step.Run(operation, logrus.New())
+
+	// then
+	assert.NoError(t, err)
+	assert.Zero(t, repeat)
+}
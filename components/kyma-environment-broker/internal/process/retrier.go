@@ -0,0 +1,21 @@
+package process
+
+// Retrier processes the operations of the staged manager again without waiting for the step retry
+type Retrier struct {
+	queue   *Queue
+	manager *StagedManager
+}
+
+func NewRetrier(queue *Queue, manager *StagedManager) *Retrier {
+	return &Retrier{
+		queue:   queue,
+		manager: manager,
+	}
+}
+
+// RetryNow adds the operation to the queue and interrupts the waiting for the step retry if the operation is processed by a worker,
+// the queue processes the operation again as soon as the worker returns it
+func (r *Retrier) RetryNow(operationID string) {
+	r.queue.Add(operationID)
+	r.manager.WakeUp(operationID)
+}
@@ -6,13 +6,18 @@ import (
 	"sync"
 	"time"
 
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
 	kebError "github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/error"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/event"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/retrypolicy"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/tracing"
 
 	"github.com/pivotal-cf/brokerapi/v8/domain"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type StagedManager struct {
@@ -22,8 +27,14 @@ type StagedManager struct {
 
 	stages           []*stage
 	operationTimeout time.Duration
+	// retryPolicies override the retry intervals and times hard-coded in the steps, nil keeps the hard-coded values
+	retryPolicies *retrypolicy.Registry
+	// maxStepProcessingTime limits the time a step is retried in the worker before the retry is returned to the caller
+	maxStepProcessingTime time.Duration
 
 	mu sync.RWMutex
+	// wakeUps interrupt the waiting for the step retry of the operations
+	wakeUps map[string]chan struct{}
 
 	speedFactor int64
 }
@@ -33,6 +44,12 @@ type Step interface {
 	Run(operation internal.Operation, logger logrus.FieldLogger) (internal.Operation, time.Duration, error)
 }
 
+// RetryPolicyUser is implemented by the steps which retry with an operation manager,
+// the staged manager passes its retry policies to the step when the step is added
+type RetryPolicyUser interface {
+	UseRetryPolicies(policies *retrypolicy.Registry)
+}
+
 type StepCondition func(operation internal.Operation) bool
 
 type StepWithCondition struct {
@@ -43,6 +60,8 @@ type StepWithCondition struct {
 type stage struct {
 	name  string
 	steps []StepWithCondition
+	// compensations undo the changes of the stage when the operation is canceled
+	compensations []StepWithCondition
 }
 
 func (s *stage) AddStep(step Step, cnd StepCondition) {
@@ -52,13 +71,18 @@ func (s *stage) AddStep(step Step, cnd StepCondition) {
 	})
 }
 
-func NewStagedManager(storage storage.Operations, pub event.Publisher, operationTimeout time.Duration, logger logrus.FieldLogger) *StagedManager {
+// NewStagedManager creates the manager which fails the operations running longer than operationTimeout, 0 disables the timeout.
+// The retry policies are passed to the steps implementing RetryPolicyUser, nil keeps the retry intervals and times of the steps.
+func NewStagedManager(storage storage.Operations, pub event.Publisher, operationTimeout time.Duration, retryPolicies *retrypolicy.Registry, logger logrus.FieldLogger) *StagedManager {
 	return &StagedManager{
-		log:              logger,
-		operationStorage: storage,
-		publisher:        pub,
-		operationTimeout: operationTimeout,
-		speedFactor:      1,
+		log:                   logger,
+		operationStorage:      storage,
+		publisher:             pub,
+		operationTimeout:      operationTimeout,
+		retryPolicies:         retryPolicies,
+		maxStepProcessingTime: 10 * time.Minute,
+		wakeUps:               make(map[string]chan struct{}),
+		speedFactor:           1,
 	}
 }
 
@@ -68,6 +92,23 @@ func (m *StagedManager) SpeedUp(speedFactor int64) {
 	m.speedFactor = speedFactor
 }
 
+// SetMaxStepProcessingTime changes the time a step is retried in the worker, 0 returns every retry to the caller
+// which is required when the caller schedules the retries itself, e.g. the orchestration strategies
+func (m *StagedManager) SetMaxStepProcessingTime(maxStepProcessingTime time.Duration) {
+	m.maxStepProcessingTime = maxStepProcessingTime
+}
+
+// WakeUp interrupts the waiting for the step retry of the operation processed by a worker,
+// the worker returns the operation to the queue instead of running the step again
+func (m *StagedManager) WakeUp(operationID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if wakeUp, found := m.wakeUps[operationID]; found {
+		close(wakeUp)
+		delete(m.wakeUps, operationID)
+	}
+}
+
 func (m *StagedManager) DefineStages(names []string) {
 	m.stages = make([]*stage, len(names))
 	for i, n := range names {
@@ -78,6 +119,7 @@ func (m *StagedManager) DefineStages(names []string) {
 func (m *StagedManager) AddStep(stageName string, step Step, cnd StepCondition) error {
 	for _, s := range m.stages {
 		if s.name == stageName {
+			m.useRetryPolicies(step)
 			s.AddStep(step, cnd)
 			return nil
 		}
@@ -85,6 +127,37 @@ func (m *StagedManager) AddStep(stageName string, step Step, cnd StepCondition)
 	return fmt.Errorf("stage %s not defined", stageName)
 }
 
+// InsertStep adds the step at the beginning of the stage, before the steps added so far
+func (m *StagedManager) InsertStep(stageName string, step Step, cnd StepCondition) error {
+	for _, s := range m.stages {
+		if s.name == stageName {
+			m.useRetryPolicies(step)
+			s.steps = append([]StepWithCondition{{Step: step, condition: cnd}}, s.steps...)
+			return nil
+		}
+	}
+	return fmt.Errorf("stage %s not defined", stageName)
+}
+
+// AddCompensation adds the step which undoes the changes of the stage when the operation is canceled,
+// the compensation steps run only for the started stages, the stages in the reverse order and the steps of a stage in the order they are added
+func (m *StagedManager) AddCompensation(stageName string, step Step, cnd StepCondition) error {
+	for _, s := range m.stages {
+		if s.name == stageName {
+			m.useRetryPolicies(step)
+			s.compensations = append(s.compensations, StepWithCondition{Step: step, condition: cnd})
+			return nil
+		}
+	}
+	return fmt.Errorf("stage %s not defined", stageName)
+}
+
+func (m *StagedManager) useRetryPolicies(step Step) {
+	if user, ok := step.(RetryPolicyUser); ok && m.retryPolicies != nil {
+		user.UseRetryPolicies(m.retryPolicies)
+	}
+}
+
 func (m *StagedManager) GetAllStages() []string {
 	var all []string
 	for _, s := range m.stages {
@@ -101,13 +174,20 @@ func (m *StagedManager) Execute(operationID string) (time.Duration, error) {
 	}
 
 	logOperation := m.log.WithFields(logrus.Fields{"operation": operationID, "instanceID": operation.InstanceID, "planID": operation.ProvisioningParameters.PlanID})
+	if operation.State == domain.Failed || operation.State == domain.Succeeded || operation.State == orchestration.Canceled {
+		logOperation.Infof("Operation is already finished with state %s", operation.State)
+		return 0, nil
+	}
+	if operation.CancellationRequested {
+		return m.cancel(*operation, logOperation)
+	}
 	logOperation.Infof("Start process operation steps for GlobalAccount=%s, ", operation.ProvisioningParameters.ErsContext.GlobalAccountID)
-	if time.Since(operation.CreatedAt) > m.operationTimeout {
+	if m.operationTimeout > 0 && time.Since(operation.ProcessingStartedAt()) > m.operationTimeout {
 		timeoutErr := kebError.TimeoutError("operation has reached the time limit")
 		operation.LastError = timeoutErr
 		defer m.callPubSubOutsideSteps(operation, timeoutErr)
 
-		logOperation.Infof("operation has reached the time limit: operation was created at: %s", operation.CreatedAt)
+		logOperation.Infof("operation has reached the time limit: operation processing started at: %s", operation.ProcessingStartedAt())
 		operation.State = domain.Failed
 		_, err = m.operationStorage.UpdateOperation(*operation)
 		if err != nil {
@@ -135,9 +215,16 @@ func (m *StagedManager) Execute(operationID string) (time.Duration, error) {
 				logStep.Debugf("Skipping")
 				continue
 			}
+			if processedOperation.IsStepSkipped(step.Name()) {
+				logStep.Infof("Skipping, the step was skipped manually")
+				continue
+			}
+			if canceled, found := m.cancellationRequested(operationID, logStep); found {
+				return m.cancel(canceled, logOperation)
+			}
 			operation.EventInfof("processing step: %v", step.Name())
 
-			processedOperation, when, err = m.runStep(step, processedOperation, logStep)
+			processedOperation, when, err = m.runStep(step, stage.name, processedOperation, logStep)
 			if err != nil {
 				logStep.Errorf("Process operation failed: %s", err)
 				operation.EventErrorf(err, "step %v processing returned error", step.Name())
@@ -178,6 +265,83 @@ func (m *StagedManager) Execute(operationID string) (time.Duration, error) {
 	return 0, nil
 }
 
+// cancellationRequested reads the operation from the storage, the cancellation is requested by the API while the operation is processed
+func (m *StagedManager) cancellationRequested(operationID string, log logrus.FieldLogger) (internal.Operation, bool) {
+	operation, err := m.operationStorage.GetOperationByID(operationID)
+	if err != nil {
+		log.Warnf("Unable to check the cancellation of the operation: %s", err)
+		return internal.Operation{}, false
+	}
+	if !operation.CancellationRequested {
+		return internal.Operation{}, false
+	}
+	return *operation, true
+}
+
+// cancel runs the compensation steps of the started stages and finishes the operation with the canceled state,
+// the stages before the first not finished stage and that stage are started.
+// A compensation which fails the operation stops the cancellation, the operation stays failed.
+func (m *StagedManager) cancel(operation internal.Operation, logOperation logrus.FieldLogger) (time.Duration, error) {
+	logOperation.Infof("Cancellation of the operation requested")
+	var started []*stage
+	for _, stage := range m.stages {
+		started = append(started, stage)
+		if !operation.IsStageFinished(stage.name) {
+			break
+		}
+	}
+
+	for i := len(started) - 1; i >= 0; i-- {
+		stage := started[i]
+		for _, step := range stage.compensations {
+			logStep := logOperation.WithField("step", step.Name()).
+				WithField("stage", stage.name)
+			if operation.IsStepCompensated(step.Name()) {
+				continue
+			}
+			if step.condition != nil && !step.condition(operation) {
+				logStep.Debugf("Skipping")
+				continue
+			}
+			operation.EventInfof("processing compensation step: %v", step.Name())
+
+			processedOperation, when, err := m.runStep(step, stage.name, operation, logStep)
+			if err != nil && processedOperation.State == domain.Failed {
+				// the compensation could not undo the stage, the operation ends failed with its description instead of canceled
+				logStep.Errorf("Compensation failed the operation: %s", err)
+				operation.EventErrorf(err, "compensation step %v failed the operation", step.Name())
+				return 0, nil
+			}
+			if err != nil {
+				// the cancellation must finish, the failed compensation is reported and the next one is run
+				logStep.Errorf("Compensation failed: %s", err)
+				operation.EventErrorf(err, "compensation step %v processing returned error", step.Name())
+				processedOperation.ExcutedButNotCompleted = append(processedOperation.ExcutedButNotCompleted, step.Name())
+			} else if when > 0 {
+				return when, nil
+			}
+
+			processedOperation.CompensateStep(step.Name())
+			updated, dbErr := m.operationStorage.UpdateOperation(processedOperation)
+			if dbErr != nil {
+				logStep.Infof("Unable to save operation with compensated step: %s", dbErr)
+				return time.Second, nil
+			}
+			operation = *updated
+		}
+	}
+
+	logOperation.Infof("Operation canceled")
+	operation.State = orchestration.Canceled
+	operation.Description = "Operation canceled"
+	if _, err := m.operationStorage.UpdateOperation(operation); err != nil {
+		logOperation.Infof("Unable to save operation with finished the cancellation: %s", err)
+		return time.Second, nil
+	}
+	operation.EventInfof("operation processing %v", operation.State)
+	return 0, nil
+}
+
 func (m *StagedManager) saveFinishedStage(operation internal.Operation, s *stage, log logrus.FieldLogger) (internal.Operation, error) {
 	operation.FinishStage(s.name)
 	op, err := m.operationStorage.UpdateOperation(operation)
@@ -189,30 +353,45 @@ func (m *StagedManager) saveFinishedStage(operation internal.Operation, s *stage
 	return *op, nil
 }
 
-func (m *StagedManager) runStep(step Step, operation internal.Operation, logger logrus.FieldLogger) (internal.Operation, time.Duration, error) {
+func (m *StagedManager) runStep(step Step, stageName string, operation internal.Operation, logger logrus.FieldLogger) (internal.Operation, time.Duration, error) {
 	begin := time.Now()
 	for {
 		start := time.Now()
 		logger.Infof("Start step")
+		_, span := tracing.Tracer().Start(tracing.OperationContext(context.Background(), &operation), step.Name(),
+			trace.WithAttributes(tracing.OperationAttributes(operation)...),
+			trace.WithAttributes(attribute.String("keb.stage.name", stageName)))
+		// the step continues the trace with tracing.StepContext when it calls other services
+		operation.StepSpanID = ""
+		if span.SpanContext().IsValid() {
+			operation.StepSpanID = span.SpanContext().SpanID().String()
+		}
 		processedOperation, when, err := step.Run(operation, logger)
+		span.SetAttributes(attribute.String("keb.step.retry_in", when.String()))
+		tracing.End(span, err)
 
 		if err != nil {
 			processedOperation.LastError = kebError.ReasonForError(err)
 			logOperation := m.log.WithFields(logrus.Fields{"operation": processedOperation.ID, "error_component": processedOperation.LastError.Component(), "error_reason": processedOperation.LastError.Reason()})
 			logOperation.Errorf("Last error from step %s: %s", step.Name(), processedOperation.LastError.Error())
-			// only save to storage, skip for alerting if error
-			_, err = m.operationStorage.UpdateOperation(processedOperation)
-			if err != nil {
+			// only save to storage, skip for alerting if error, the step error is returned even if the operation is saved,
+			// so Execute does not continue with the next step and the upgrade managers can report the failed attempt
+			updatedOperation, dbErr := m.operationStorage.UpdateOperation(processedOperation)
+			if dbErr != nil {
 				logOperation.Errorf("Unable to save operation with resolved last error from step: %s", step.Name())
+			} else {
+				processedOperation = *updatedOperation
 			}
 		}
 
 		m.publisher.Publish(context.TODO(), OperationStepProcessed{
 			StepProcessed: StepProcessed{
-				StepName: step.Name(),
-				Duration: time.Since(start),
-				When:     when,
-				Error:    err,
+				StepName:  step.Name(),
+				Stage:     stageName,
+				StartedAt: start,
+				Duration:  time.Since(start),
+				When:      when,
+				Error:     err,
 			},
 			Operation:    processedOperation,
 			OldOperation: operation,
@@ -222,11 +401,36 @@ func (m *StagedManager) runStep(step Step, operation internal.Operation, logger
 		// - the step does not need a retry
 		// - step returns an error
 		// - the loop takes too much time (to not block the worker too long)
-		if when == 0 || err != nil || time.Since(begin) > 10*time.Minute {
+		if when == 0 || err != nil || time.Since(begin) >= m.maxStepProcessingTime {
 			return processedOperation, when, err
 		}
 		operation.EventInfof("step %v sleeping for %v", step.Name(), when)
-		time.Sleep(when / time.Duration(m.speedFactor))
+		if woken := m.sleep(operation.ID, when/time.Duration(m.speedFactor)); woken {
+			logger.Infof("Waiting for the step retry interrupted")
+			return processedOperation, when, nil
+		}
+	}
+}
+
+// sleep waits for the given time unless the operation is woken up, it returns true if the waiting was interrupted
+func (m *StagedManager) sleep(operationID string, d time.Duration) bool {
+	m.mu.Lock()
+	wakeUp := make(chan struct{})
+	m.wakeUps[operationID] = wakeUp
+	m.mu.Unlock()
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		m.mu.Lock()
+		if m.wakeUps[operationID] == wakeUp {
+			delete(m.wakeUps, operationID)
+		}
+		m.mu.Unlock()
+		return false
+	case <-wakeUp:
+		return true
 	}
 }
 
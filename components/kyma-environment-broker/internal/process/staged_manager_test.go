@@ -7,7 +7,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/retrypolicy"
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/ptr"
 
@@ -21,6 +23,10 @@ import (
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
@@ -94,6 +100,25 @@ func TestWithRetry(t *testing.T) {
 	assert.True(t, op.IsStageFinished("stage-2"))
 }
 
+func TestStepErrorStopsProcessing(t *testing.T) {
+	// given
+	operation := FixOperation("op-0001234")
+	mgr, operationStorage, eventCollector := SetupStagedManager(operation)
+	mgr.AddStep("stage-1", &erroringStep{name: "first", eventPublisher: eventCollector}, nil)
+	mgr.AddStep("stage-1", &testingStep{name: "second", eventPublisher: eventCollector}, nil)
+
+	// when
+	retry, err := mgr.Execute(operation.ID)
+
+	// then
+	assert.EqualError(t, err, "temporary failure")
+	assert.Zero(t, retry)
+	eventCollector.AssertProcessedSteps(t, []string{"first"})
+	op, _ := operationStorage.GetOperationByID(operation.ID)
+	assert.Equal(t, "temporary failure", op.LastError.Error())
+	assert.False(t, op.IsStageFinished("stage-1"))
+}
+
 func TestSkipFinishedStage(t *testing.T) {
 	// given
 	operation := FixOperation("op-0001234")
@@ -116,6 +141,251 @@ func TestSkipFinishedStage(t *testing.T) {
 	assert.True(t, op.IsStageFinished("stage-2"))
 }
 
+func TestSkipManuallySkippedStep(t *testing.T) {
+	// given
+	operation := FixOperation("op-0001234")
+	operation.SkipStep("second")
+
+	mgr, operationStorage, eventCollector := SetupStagedManager(operation)
+	mgr.AddStep("stage-1", &testingStep{name: "first", eventPublisher: eventCollector}, nil)
+	mgr.AddStep("stage-1", &testingStep{name: "second", eventPublisher: eventCollector}, nil)
+	mgr.AddStep("stage-2", &testingStep{name: "first-2", eventPublisher: eventCollector}, nil)
+
+	// when
+	retry, _ := mgr.Execute(operation.ID)
+
+	// then
+	assert.Zero(t, retry)
+	eventCollector.AssertProcessedSteps(t, []string{"first", "first-2"})
+	op, _ := operationStorage.GetOperationByID(operation.ID)
+	assert.Equal(t, domain.Succeeded, op.State)
+}
+
+func TestSkipFinishedOperation(t *testing.T) {
+	// given
+	operation := FixOperation("op-0001234")
+	operation.State = domain.Failed
+
+	mgr, operationStorage, eventCollector := SetupStagedManager(operation)
+	mgr.AddStep("stage-1", &testingStep{name: "first", eventPublisher: eventCollector}, nil)
+
+	// when
+	retry, err := mgr.Execute(operation.ID)
+
+	// then
+	assert.NoError(t, err)
+	assert.Zero(t, retry)
+	assert.Empty(t, eventCollector.StepsProcessed)
+	op, _ := operationStorage.GetOperationByID(operation.ID)
+	assert.Equal(t, domain.Failed, op.State)
+	assert.Empty(t, op.FinishedStages)
+}
+
+func TestWakeUpInterruptsStepRetry(t *testing.T) {
+	// given
+	operation := FixOperation("op-0001234")
+
+	mgr, _, eventCollector := SetupStagedManager(operation)
+	mgr.SpeedUp(1)
+	mgr.AddStep("stage-1", &retryingStep{name: "first", when: time.Hour}, nil)
+
+	done := make(chan time.Duration)
+	go func() {
+		retry, _ := mgr.Execute(operation.ID)
+		done <- retry
+	}()
+
+	// when
+	var retry time.Duration
+	err := wait.PollImmediate(10*time.Millisecond, 5*time.Second, func() (bool, error) {
+		mgr.WakeUp(operation.ID)
+		select {
+		case retry = <-done:
+			return true, nil
+		default:
+			return false, nil
+		}
+	})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, time.Hour, retry)
+	eventCollector.WaitForEvents(t, 1)
+}
+
+func TestCancelBetweenSteps(t *testing.T) {
+	// given
+	operation := FixOperation("op-0001234")
+
+	mgr, operationStorage, eventCollector := SetupStagedManager(operation)
+	mgr.AddStep("stage-1", &cancelingStep{name: "first", operations: operationStorage, eventPublisher: eventCollector}, nil)
+	mgr.AddStep("stage-1", &testingStep{name: "second", eventPublisher: eventCollector}, nil)
+	mgr.AddStep("stage-2", &testingStep{name: "first-2", eventPublisher: eventCollector}, nil)
+	mgr.AddCompensation("stage-1", &testingStep{name: "undo-1", eventPublisher: eventCollector}, nil)
+	mgr.AddCompensation("stage-2", &testingStep{name: "undo-2", eventPublisher: eventCollector}, nil)
+
+	// when
+	retry, err := mgr.Execute(operation.ID)
+
+	// then
+	assert.NoError(t, err)
+	assert.Zero(t, retry)
+	eventCollector.AssertProcessedSteps(t, []string{"first", "undo-1"})
+	op, _ := operationStorage.GetOperationByID(operation.ID)
+	assert.Equal(t, domain.LastOperationState(orchestration.Canceled), op.State)
+	assert.Equal(t, []string{"undo-1"}, op.CompensatedSteps)
+	assert.False(t, op.IsStageFinished("stage-1"))
+}
+
+func TestCancelCompensatesStartedStagesInReverseOrder(t *testing.T) {
+	// given
+	operation := FixOperation("op-0001234")
+	operation.FinishStage("stage-1")
+	operation.CancellationRequested = true
+
+	mgr, operationStorage, eventCollector := SetupStagedManager(operation)
+	mgr.AddStep("stage-1", &testingStep{name: "first", eventPublisher: eventCollector}, nil)
+	mgr.AddStep("stage-2", &testingStep{name: "first-2", eventPublisher: eventCollector}, nil)
+	mgr.AddCompensation("stage-1", &testingStep{name: "undo-1", eventPublisher: eventCollector}, nil)
+	mgr.AddCompensation("stage-2", &testingStep{name: "undo-2", eventPublisher: eventCollector}, nil)
+	mgr.AddCompensation("stage-2", &testingStep{name: "undo-2-skipped", eventPublisher: eventCollector}, func(_ internal.Operation) bool {
+		return false
+	})
+
+	// when
+	retry, err := mgr.Execute(operation.ID)
+
+	// then
+	assert.NoError(t, err)
+	assert.Zero(t, retry)
+	eventCollector.AssertProcessedSteps(t, []string{"undo-2", "undo-1"})
+	op, _ := operationStorage.GetOperationByID(operation.ID)
+	assert.Equal(t, domain.LastOperationState(orchestration.Canceled), op.State)
+}
+
+func TestCancelRetriesCompensation(t *testing.T) {
+	// given
+	operation := FixOperation("op-0001234")
+	operation.CancellationRequested = true
+
+	mgr, operationStorage, eventCollector := SetupStagedManager(operation)
+	mgr.SetMaxStepProcessingTime(0)
+	mgr.AddStep("stage-1", &testingStep{name: "first", eventPublisher: eventCollector}, nil)
+	mgr.AddCompensation("stage-1", &testingStep{name: "undo-1", eventPublisher: eventCollector}, nil)
+	mgr.AddCompensation("stage-1", &onceRetryingStep{name: "undo-1-retried", eventPublisher: eventCollector}, nil)
+
+	// when
+	retry, err := mgr.Execute(operation.ID)
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, time.Millisecond, retry)
+	op, _ := operationStorage.GetOperationByID(operation.ID)
+	assert.Equal(t, domain.InProgress, op.State)
+
+	// when
+	retry, err = mgr.Execute(operation.ID)
+
+	// then
+	assert.NoError(t, err)
+	assert.Zero(t, retry)
+	eventCollector.AssertProcessedSteps(t, []string{"undo-1", "undo-1-retried", "undo-1-retried"})
+	op, _ = operationStorage.GetOperationByID(operation.ID)
+	assert.Equal(t, domain.LastOperationState(orchestration.Canceled), op.State)
+}
+
+func TestCancelStopsWhenCompensationFailsOperation(t *testing.T) {
+	// given
+	operation := FixOperation("op-0001234")
+	operation.CancellationRequested = true
+
+	mgr, operationStorage, eventCollector := SetupStagedManager(operation)
+	mgr.AddStep("stage-1", &testingStep{name: "first", eventPublisher: eventCollector}, nil)
+	mgr.AddCompensation("stage-1", &failingCompensationStep{name: "undo-1-failed", operations: operationStorage, eventPublisher: eventCollector}, nil)
+	mgr.AddCompensation("stage-1", &testingStep{name: "undo-1", eventPublisher: eventCollector}, nil)
+
+	// when
+	retry, err := mgr.Execute(operation.ID)
+
+	// then
+	assert.NoError(t, err)
+	assert.Zero(t, retry)
+	eventCollector.AssertProcessedSteps(t, []string{"undo-1-failed"})
+	op, _ := operationStorage.GetOperationByID(operation.ID)
+	assert.Equal(t, domain.Failed, op.State)
+	assert.Equal(t, "runtime still exists", op.Description)
+}
+
+func TestTimeoutCountedFromRetry(t *testing.T) {
+	// given
+	operation := FixOperation("op-0001234")
+	operation.CreatedAt = time.Now().Add(-time.Hour)
+	operation.RetriedAt = time.Now()
+	operation.FinishStage("stage-1")
+
+	mgr, operationStorage, eventCollector := SetupStagedManager(operation)
+	mgr.AddStep("stage-1", &testingStep{name: "first", eventPublisher: eventCollector}, nil)
+	mgr.AddStep("stage-2", &testingStep{name: "first-2", eventPublisher: eventCollector}, nil)
+
+	// when
+	retry, err := mgr.Execute(operation.ID)
+
+	// then
+	assert.NoError(t, err)
+	assert.Zero(t, retry)
+	eventCollector.AssertProcessedSteps(t, []string{"first-2"})
+	op, _ := operationStorage.GetOperationByID(operation.ID)
+	assert.Equal(t, domain.Succeeded, op.State)
+}
+
+func TestRetryPoliciesPassedToSteps(t *testing.T) {
+	// given
+	operation := FixOperation("op-0001234")
+	memoryStorage := storage.NewMemoryStorage()
+	memoryStorage.Operations().InsertOperation(operation)
+	retryPolicies := retrypolicy.NewRegistry(retrypolicy.Policies{}, map[string]string{})
+	mgr := process.NewStagedManager(memoryStorage.Operations(), &CollectingEventHandler{}, time.Hour, retryPolicies, logrus.New())
+	mgr.DefineStages([]string{"stage-1"})
+	step := &policyRecordingStep{name: "first"}
+	mgr.AddStep("stage-1", step, nil)
+
+	// when
+	_, err := mgr.Execute(operation.ID)
+
+	// then
+	assert.NoError(t, err)
+	assert.Same(t, retryPolicies, step.retryPolicies)
+}
+
+func TestStepSpansInOperationTrace(t *testing.T) {
+	// given
+	exporter := tracetest.NewInMemoryExporter()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	defer otel.SetTracerProvider(trace.NewNoopTracerProvider())
+
+	operation := FixOperation("op-0001234")
+	mgr, operationStorage, eventCollector := SetupStagedManager(operation)
+	mgr.AddStep("stage-1", &onceRetryingStep{name: "first", eventPublisher: eventCollector}, nil)
+	mgr.AddStep("stage-2", &testingStep{name: "second", eventPublisher: eventCollector}, nil)
+
+	// when
+	_, err := mgr.Execute(operation.ID)
+
+	// then
+	assert.NoError(t, err)
+	op, _ := operationStorage.GetOperationByID(operation.ID)
+	assert.NotEmpty(t, op.TraceID)
+
+	spans := exporter.GetSpans()
+	assert.Len(t, spans, 4)
+	var names []string
+	for _, span := range spans {
+		assert.Equal(t, op.TraceID, span.SpanContext.TraceID().String())
+		names = append(names, span.Name)
+	}
+	assert.Equal(t, []string{"provision operation", "first", "first", "second"}, names)
+}
+
 func SetupStagedManager(op internal.Operation) (*process.StagedManager, storage.Operations, *CollectingEventHandler) {
 	memoryStorage := storage.NewMemoryStorage()
 	memoryStorage.Operations().InsertOperation(op)
@@ -123,7 +393,7 @@ func SetupStagedManager(op internal.Operation) (*process.StagedManager, storage.
 	eventCollector := &CollectingEventHandler{}
 	l := logrus.New()
 	l.SetLevel(logrus.DebugLevel)
-	mgr := process.NewStagedManager(memoryStorage.Operations(), eventCollector, 3*time.Second, l)
+	mgr := process.NewStagedManager(memoryStorage.Operations(), eventCollector, 3*time.Second, nil, l)
 	mgr.SpeedUp(100000)
 	mgr.DefineStages([]string{"stage-1", "stage-2"})
 
@@ -144,6 +414,59 @@ func (s *testingStep) Run(operation internal.Operation, logger logrus.FieldLogge
 	return operation, 0, nil
 }
 
+// cancelingStep requests the cancellation of the operation as the API does while the operation is processed
+type cancelingStep struct {
+	name           string
+	operations     storage.Operations
+	eventPublisher event.Publisher
+}
+
+func (s *cancelingStep) Name() string {
+	return s.name
+}
+func (s *cancelingStep) Run(operation internal.Operation, logger logrus.FieldLogger) (internal.Operation, time.Duration, error) {
+	s.eventPublisher.Publish(context.Background(), s.name)
+	stored, err := s.operations.GetOperationByID(operation.ID)
+	if err != nil {
+		return operation, 0, err
+	}
+	stored.CancellationRequested = true
+	updated, err := s.operations.UpdateOperation(*stored)
+	if err != nil {
+		return operation, 0, err
+	}
+	return *updated, 0, nil
+}
+
+// failingCompensationStep fails the operation as a compensation which cannot undo the stage
+type failingCompensationStep struct {
+	name           string
+	operations     storage.Operations
+	eventPublisher event.Publisher
+}
+
+func (s *failingCompensationStep) Name() string {
+	return s.name
+}
+func (s *failingCompensationStep) Run(operation internal.Operation, logger logrus.FieldLogger) (internal.Operation, time.Duration, error) {
+	s.eventPublisher.Publish(context.Background(), s.name)
+	return process.NewOperationManager(s.operations).OperationFailed(operation, "runtime still exists", nil, logger)
+}
+
+// erroringStep returns an error without failing the operation
+type erroringStep struct {
+	name           string
+	eventPublisher event.Publisher
+}
+
+func (s *erroringStep) Name() string {
+	return s.name
+}
+func (s *erroringStep) Run(operation internal.Operation, logger logrus.FieldLogger) (internal.Operation, time.Duration, error) {
+	s.eventPublisher.Publish(context.Background(), s.name)
+	return operation, 0, fmt.Errorf("temporary failure")
+}
+
 type onceRetryingStep struct {
 	name           string
 	processed      bool
@@ -163,6 +486,33 @@ func (s *onceRetryingStep) Run(operation internal.Operation, logger logrus.Field
 	return operation, 0, nil
 }
 
+type policyRecordingStep struct {
+	name          string
+	retryPolicies *retrypolicy.Registry
+}
+
+func (s *policyRecordingStep) Name() string {
+	return s.name
+}
+func (s *policyRecordingStep) UseRetryPolicies(policies *retrypolicy.Registry) {
+	s.retryPolicies = policies
+}
+func (s *policyRecordingStep) Run(operation internal.Operation, logger logrus.FieldLogger) (internal.Operation, time.Duration, error) {
+	return operation, 0, nil
+}
+
+type retryingStep struct {
+	name string
+	when time.Duration
+}
+
+func (s *retryingStep) Name() string {
+	return s.name
+}
+func (s *retryingStep) Run(operation internal.Operation, logger logrus.FieldLogger) (internal.Operation, time.Duration, error) {
+	return operation, s.when, nil
+}
+
 func fixProvisioningParametersWithPlanID(planID, region string) internal.ProvisioningParameters {
 	return internal.ProvisioningParameters{
 		PlanID:    planID,
@@ -277,7 +627,7 @@ func SetupStagedManager2(op internal.Operation) (*process.StagedManager, storage
 	l := logrus.New()
 	l.SetLevel(logrus.DebugLevel)
 	pubSub := event.NewPubSub(nil)
-	mgr := process.NewStagedManager(memoryStorage.Operations(), pubSub, 3*time.Second, l)
+	mgr := process.NewStagedManager(memoryStorage.Operations(), pubSub, 3*time.Second, nil, l)
 	mgr.SpeedUp(100000)
 	mgr.DefineStages([]string{"stage-1", "stage-2"})
 
@@ -6,7 +6,9 @@ import (
 	"time"
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/retrypolicy"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
 	"github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
@@ -19,6 +21,7 @@ import (
 type syncKubeconfig struct {
 	k8sClient        client.Client
 	operationManager *process.OperationManager
+	planRegistry     *broker.PlanRegistry
 }
 
 // deleteKubeconfig step ensures kubeconfig secret for lifecycle manager is removed during deprovisioning
@@ -27,8 +30,8 @@ type deleteKubeconfig struct {
 	operationManager *process.OperationManager
 }
 
-func SyncKubeconfig(os storage.Operations, k8sClient client.Client) syncKubeconfig {
-	return syncKubeconfig{k8sClient: k8sClient, operationManager: process.NewOperationManager(os)}
+func SyncKubeconfig(os storage.Operations, k8sClient client.Client, planRegistry *broker.PlanRegistry) syncKubeconfig {
+	return syncKubeconfig{k8sClient: k8sClient, operationManager: process.NewOperationManager(os), planRegistry: planRegistry}
 }
 
 func DeleteKubeconfig(os storage.Operations, k8sClient client.Client) deleteKubeconfig {
@@ -43,8 +46,16 @@ func (_ deleteKubeconfig) Name() string {
 	return "Delete_Kubeconfig"
 }
 
+func (s syncKubeconfig) UseRetryPolicies(policies *retrypolicy.Registry) {
+	s.operationManager.UseRetryPolicies(policies, s.Name())
+}
+
+func (s deleteKubeconfig) UseRetryPolicies(policies *retrypolicy.Registry) {
+	s.operationManager.UseRetryPolicies(policies, s.Name())
+}
+
 func (s syncKubeconfig) Run(o internal.Operation, log logrus.FieldLogger) (internal.Operation, time.Duration, error) {
-	secret := initSecret(o)
+	secret := initSecret(o, s.planRegistry)
 	if err := s.k8sClient.Create(context.Background(), secret); errors.IsAlreadyExists(err) {
 		if err := s.k8sClient.Update(context.Background(), secret); err != nil {
 			msg := fmt.Sprintf("failed to update kubeconfig secret %v/%v for lifecycle manager: %v", secret.Namespace, secret.Name, err)
@@ -60,7 +71,8 @@ func (s syncKubeconfig) Run(o internal.Operation, log logrus.FieldLogger) (inter
 }
 
 func (s deleteKubeconfig) Run(o internal.Operation, log logrus.FieldLogger) (internal.Operation, time.Duration, error) {
-	secret := initSecret(o)
+	// the labels are not needed to delete the secret
+	secret := initSecret(o, nil)
 	if err := s.k8sClient.Delete(context.Background(), secret); err != nil && !errors.IsNotFound(err) {
 		msg := fmt.Sprintf("failed to delete kubeconfig secret %v/%v for lifecycle manager: %v", secret.Namespace, secret.Name, err)
 		log.Error(msg)
@@ -69,7 +81,7 @@ func (s deleteKubeconfig) Run(o internal.Operation, log logrus.FieldLogger) (int
 	return o, 0, nil
 }
 
-func initSecret(o internal.Operation) *corev1.Secret {
+func initSecret(o internal.Operation, planRegistry *broker.PlanRegistry) *corev1.Secret {
 	secret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: o.InstanceDetails.KymaResourceNamespace,
@@ -79,7 +91,7 @@ func initSecret(o internal.Operation) *corev1.Secret {
 			"config": o.Kubeconfig,
 		},
 	}
-	ApplyLabelsAndAnnotationsForLM(secret, o)
+	ApplyLabelsAndAnnotationsForLM(secret, o, planRegistry)
 	return secret
 }
 
@@ -88,8 +100,8 @@ type syncKubeconfigUpgradeKyma struct {
 	syncKubeconfig
 }
 
-func SyncKubeconfigUpgradeKyma(os storage.Operations, k8sClient client.Client) syncKubeconfigUpgradeKyma {
-	return syncKubeconfigUpgradeKyma{SyncKubeconfig(os, k8sClient)}
+func SyncKubeconfigUpgradeKyma(os storage.Operations, k8sClient client.Client, planRegistry *broker.PlanRegistry) syncKubeconfigUpgradeKyma {
+	return syncKubeconfigUpgradeKyma{SyncKubeconfig(os, k8sClient, planRegistry)}
 }
 
 func (s syncKubeconfigUpgradeKyma) Run(o internal.UpgradeKymaOperation, logger logrus.FieldLogger) (internal.UpgradeKymaOperation, time.Duration, error) {
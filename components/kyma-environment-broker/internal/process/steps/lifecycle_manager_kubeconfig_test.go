@@ -26,7 +26,7 @@ func TestCheckKymaKubeconfigCreated(t *testing.T) {
 	err := memoryStorage.Operations().InsertOperation(operation)
 	assert.NoError(t, err)
 
-	step := SyncKubeconfig(memoryStorage.Operations(), k8sClient)
+	step := SyncKubeconfig(memoryStorage.Operations(), k8sClient, nil)
 
 	// When
 	_, backoff, err := step.Run(operation, logger.NewLogSpy().Logger)
@@ -11,6 +11,7 @@ import (
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/reconciler"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/tracing"
 	"github.com/sirupsen/logrus"
 )
 
@@ -45,7 +46,7 @@ func (s *ApplyReconcilerConfigurationStep) Run(operation internal.Operation, log
 
 	log.Infof("Applying Cluster Configuration: cluster(runtimeID)=%s, kymaVersion=%s, kymaProfile=%s, components=[%s]",
 		cluster.RuntimeID, cluster.KymaConfig.Version, cluster.KymaConfig.Profile, s.componentList(*cluster))
-	state, err := s.reconcilerClient.ApplyClusterConfig(*cluster)
+	state, err := s.reconcilerClient.ApplyClusterConfig(tracing.StepContext(operation), *cluster)
 	switch {
 	case kebError.IsTemporaryError(err):
 		msg := fmt.Sprintf("Request to Reconciler failed: %s", err.Error())
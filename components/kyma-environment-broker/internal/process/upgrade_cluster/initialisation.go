@@ -13,6 +13,7 @@ import (
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process/input"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/provisioner"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/tracing"
 	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
 	"github.com/pivotal-cf/brokerapi/v8/domain"
 	"github.com/sirupsen/logrus"
@@ -157,13 +158,13 @@ func (s *InitialisationStep) performRuntimeTasks(step int, operation internal.Up
 	case UpgradeInitSteps:
 		if hasMonitors && !inMaintenance {
 			log.Infof("executing init upgrade steps")
-			err = s.evaluationManager.SetMaintenanceStatus(&operation.Avs, log)
+			err = s.evaluationManager.SetMaintenanceStatus(tracing.StepContext(operation.Operation), &operation.Avs, log)
 			operation, delay, _ = s.operationManager.UpdateOperation(operation, updateAvsStatus, log)
 		}
 	case UpgradeFinishSteps:
 		if hasMonitors && inMaintenance {
 			log.Infof("executing finish upgrade steps")
-			err = s.evaluationManager.RestoreStatus(&operation.Avs, log)
+			err = s.evaluationManager.RestoreStatus(tracing.StepContext(operation.Operation), &operation.Avs, log)
 			operation, delay, _ = s.operationManager.UpdateOperation(operation, updateAvsStatus, log)
 		}
 	}
@@ -195,7 +196,7 @@ func (s *InitialisationStep) checkRuntimeStatus(operation internal.UpgradeCluste
 		return s.operationManager.OperationFailed(operation, fmt.Sprintf("operation has reached the time limit: %s", CheckStatusTimeout), nil, log)
 	}
 
-	status, err := s.provisionerClient.RuntimeOperationStatus(operation.RuntimeOperation.GlobalAccountID, operation.ProvisionerOperationID)
+	status, err := s.provisionerClient.RuntimeOperationStatus(tracing.StepContext(operation.Operation), operation.RuntimeOperation.GlobalAccountID, operation.ProvisionerOperationID)
 	if err != nil {
 		return operation, s.timeSchedule.StatusCheck, nil
 	}
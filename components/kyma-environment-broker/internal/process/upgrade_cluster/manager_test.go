@@ -30,16 +30,21 @@ const (
 func TestManager_Execute(t *testing.T) {
 	for name, tc := range map[string]struct {
 		operationID            string
+		finishedStages         []string
 		expectedError          bool
 		expectedRepeat         time.Duration
 		expectedDesc           string
+		expectedState          domain.LastOperationState
+		expectedStages         []string
 		expectedNumberOfEvents int
 	}{
 		"operation successful": {
 			operationID:            operationIDSuccess,
 			expectedError:          false,
 			expectedRepeat:         time.Duration(0),
-			expectedDesc:           "init one two final",
+			expectedDesc:           "Processing finished",
+			expectedState:          domain.Succeeded,
+			expectedStages:         []string{"first", "second"},
 			expectedNumberOfEvents: 4,
 		},
 		"operation failed": {
@@ -52,6 +57,18 @@ func TestManager_Execute(t *testing.T) {
 			expectedError:          false,
 			expectedRepeat:         time.Duration(10),
 			expectedDesc:           "init",
+			expectedState:          domain.InProgress,
+			expectedStages:         []string{},
+			expectedNumberOfEvents: 1,
+		},
+		"operation resumed from the last finished stage": {
+			operationID:            operationIDRepeat,
+			finishedStages:         []string{"first"},
+			expectedError:          false,
+			expectedRepeat:         time.Duration(10),
+			expectedDesc:           "final",
+			expectedState:          domain.InProgress,
+			expectedStages:         []string{"first"},
 			expectedNumberOfEvents: 1,
 		},
 	} {
@@ -60,7 +77,9 @@ func TestManager_Execute(t *testing.T) {
 			log := logrus.New()
 			memoryStorage := storage.NewMemoryStorage()
 			operations := memoryStorage.Operations()
-			err := operations.InsertUpgradeClusterOperation(fixOperation(tc.operationID))
+			operation := fixOperation(tc.operationID)
+			operation.FinishedStages = append([]string{}, tc.finishedStages...)
+			err := operations.InsertUpgradeClusterOperation(operation)
 			assert.NoError(t, err)
 
 			sInit := testStep{t: t, name: "init", storage: operations}
@@ -71,15 +90,15 @@ func TestManager_Execute(t *testing.T) {
 
 			eventBroker := event.NewPubSub(logrus.New())
 			eventCollector := &collectingEventHandler{}
-			eventBroker.Subscribe(process.UpgradeClusterStepProcessed{}, eventCollector.OnEvent)
-
-			manager := NewManager(operations, eventBroker, log)
-			manager.InitStep(&sInit)
+			eventBroker.Subscribe(process.OperationStepProcessed{}, eventCollector.OnEvent)
 
-			manager.AddStep(2, &sFinal, nil)
-			manager.AddStep(1, &s1, nil)
-			manager.AddStep(1, &s2, func(operation internal.Operation) bool { return true })
-			manager.AddStep(1, &s3, func(operation internal.Operation) bool { return false })
+			manager := NewManager(operations, eventBroker, nil, log)
+			manager.DefineStages([]string{"first", "second"})
+			assert.NoError(t, manager.AddStep("first", &sInit, nil))
+			assert.NoError(t, manager.AddStep("first", &s1, nil))
+			assert.NoError(t, manager.AddStep("first", &s2, func(operation internal.Operation) bool { return true }))
+			assert.NoError(t, manager.AddStep("first", &s3, func(operation internal.Operation) bool { return false }))
+			assert.NoError(t, manager.AddStep("second", &sFinal, nil))
 
 			// when
 			repeat, err := manager.Execute(tc.operationID)
@@ -94,6 +113,8 @@ func TestManager_Execute(t *testing.T) {
 				operation, err := operations.GetOperationByID(tc.operationID)
 				assert.NoError(t, err)
 				assert.Equal(t, tc.expectedDesc, strings.Trim(operation.Description, " "))
+				assert.Equal(t, tc.expectedState, operation.State)
+				assert.Equal(t, tc.expectedStages, operation.FinishedStages)
 			}
 			assert.NoError(t, wait.PollImmediate(20*time.Millisecond, 2*time.Second, func() (bool, error) {
 				return len(eventCollector.Events) == tc.expectedNumberOfEvents, nil
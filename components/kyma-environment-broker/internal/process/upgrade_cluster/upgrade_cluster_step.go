@@ -8,6 +8,7 @@ import (
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/provisioner"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/tracing"
 	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
 	"github.com/sirupsen/logrus"
 )
@@ -77,7 +78,7 @@ func (s *UpgradeClusterStep) Run(operation internal.UpgradeClusterOperation, log
 	var provisionerResponse gqlschema.OperationStatus
 	if operation.ProvisionerOperationID == "" {
 		// trigger upgradeRuntime mutation
-		provisionerResponse, err = s.provisionerClient.UpgradeShoot(operation.ProvisioningParameters.ErsContext.GlobalAccountID, operation.RuntimeOperation.RuntimeID, input)
+		provisionerResponse, err = s.provisionerClient.UpgradeShoot(tracing.StepContext(operation.Operation), operation.ProvisioningParameters.ErsContext.GlobalAccountID, operation.RuntimeOperation.RuntimeID, input)
 		if err != nil {
 			log.Errorf("call to provisioner failed: %s", err)
 			return operation, s.timeSchedule.Retry, nil
@@ -95,7 +96,7 @@ func (s *UpgradeClusterStep) Run(operation internal.UpgradeClusterOperation, log
 	}
 
 	if provisionerResponse.RuntimeID == nil {
-		provisionerResponse, err = s.provisionerClient.RuntimeOperationStatus(operation.ProvisioningParameters.ErsContext.GlobalAccountID, operation.ProvisionerOperationID)
+		provisionerResponse, err = s.provisionerClient.RuntimeOperationStatus(tracing.StepContext(operation.Operation), operation.ProvisioningParameters.ErsContext.GlobalAccountID, operation.ProvisionerOperationID)
 		if err != nil {
 			log.Errorf("call to provisioner about operation status failed: %s", err)
 			return operation, s.timeSchedule.Retry, nil
@@ -116,8 +117,8 @@ func (s *UpgradeClusterStep) Run(operation internal.UpgradeClusterOperation, log
 
 	log.Infof("cluster upgrade process initiated successfully")
 
-	// return repeat mode to start the initialization step which will now check the runtime status
-	return operation, s.timeSchedule.Retry, nil
+	// the initialisation step checks the runtime status in the next stage
+	return operation, 0, nil
 
 }
 
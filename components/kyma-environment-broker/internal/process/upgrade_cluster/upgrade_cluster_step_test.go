@@ -2,7 +2,6 @@ package upgrade_cluster
 
 import (
 	"testing"
-	"time"
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/fixture"
@@ -59,7 +58,7 @@ func TestUpgradeClusterStep_Run(t *testing.T) {
 
 	provisionerClient := &provisionerAutomock.Client{}
 	disabled := false
-	provisionerClient.On("UpgradeShoot", fixGlobalAccountID, fixRuntimeID, gqlschema.UpgradeShootInput{
+	provisionerClient.On("UpgradeShoot", mock.Anything, fixGlobalAccountID, fixRuntimeID, gqlschema.UpgradeShootInput{
 		GardenerConfig: &gqlschema.GardenerUpgradeInput{
 			KubernetesVersion:                   ptr.String(fixKubernetesVersion),
 			MachineImage:                        ptr.String(fixMachineImage),
@@ -86,7 +85,7 @@ func TestUpgradeClusterStep_Run(t *testing.T) {
 		Message:   nil,
 		RuntimeID: StringPtr(fixRuntimeID),
 	}, nil)
-	provisionerClient.On("RuntimeOperationStatus", fixGlobalAccountID, fixProvisionerOperationID).Return(gqlschema.OperationStatus{
+	provisionerClient.On("RuntimeOperationStatus", mock.Anything, fixGlobalAccountID, fixProvisionerOperationID).Return(gqlschema.OperationStatus{
 		ID:        ptr.String(fixProvisionerOperationID),
 		Operation: "",
 		State:     "",
@@ -102,7 +101,7 @@ func TestUpgradeClusterStep_Run(t *testing.T) {
 
 	// then
 	assert.NoError(t, err)
-	assert.Equal(t, 5*time.Second, repeat)
+	assert.Zero(t, repeat)
 	assert.Equal(t, fixProvisionerOperationID, operation.ProvisionerOperationID)
 }
 
@@ -144,7 +143,7 @@ func fixInputCreator(t *testing.T) internal.ProvisionerInputCreator {
 			TrialNodesNumber:              1,
 			AutoUpdateKubernetesVersion:   fixAutoUpdateKubernetesVersion,
 			AutoUpdateMachineImageVersion: fixAutoUpdateMachineImageVersion,
-		}, fixKymaVersion, nil, nil, fixture.FixOIDCConfigDTO())
+		}, fixKymaVersion, nil, nil, fixture.FixOIDCConfigDTO(), nil, nil)
 	require.NoError(t, err, "Input factory creation error")
 
 	ver := internal.RuntimeVersionData{
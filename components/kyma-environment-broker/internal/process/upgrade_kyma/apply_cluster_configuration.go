@@ -11,6 +11,7 @@ import (
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/reconciler"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/tracing"
 	"github.com/sirupsen/logrus"
 )
 
@@ -68,7 +69,7 @@ func (s *ApplyClusterConfigurationStep) Run(operation internal.UpgradeKymaOperat
 		clusterConfiguration.KymaConfig.Profile,
 		s.componentList(clusterConfiguration),
 		clusterConfiguration.RuntimeInput.Name)
-	state, err := s.reconcilerClient.ApplyClusterConfig(clusterConfiguration)
+	state, err := s.reconcilerClient.ApplyClusterConfig(tracing.StepContext(operation.Operation), clusterConfiguration)
 	switch {
 	case kebError.IsTemporaryError(err):
 		msg := fmt.Sprintf("Request to Reconciler failed: %s", err.Error())
@@ -91,8 +92,8 @@ func (s *ApplyClusterConfigurationStep) Run(operation internal.UpgradeKymaOperat
 		return operation, 5 * time.Second, nil
 	}
 
-	// return some retry value to get back to initialisation step
-	return updatedOperation, 5 * time.Second, nil
+	// the cluster configuration is checked in the next stage
+	return updatedOperation, 0, nil
 
 }
 
@@ -7,6 +7,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process/provisioning"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
 )
@@ -16,8 +17,8 @@ type ApplyKymaStep struct {
 	*provisioning.ApplyKymaStep
 }
 
-func NewApplyKymaStep(os storage.Operations, cli client.Client) *ApplyKymaStep {
-	return &ApplyKymaStep{provisioning.NewApplyKymaStep(os, cli)}
+func NewApplyKymaStep(os storage.Operations, cli client.Client, planRegistry *broker.PlanRegistry) *ApplyKymaStep {
+	return &ApplyKymaStep{provisioning.NewApplyKymaStep(os, cli, planRegistry)}
 }
 
 func (s *ApplyKymaStep) Run(o internal.UpgradeKymaOperation, logger logrus.FieldLogger) (internal.UpgradeKymaOperation, time.Duration, error) {
@@ -107,6 +107,27 @@ func (_m *CreatorForPlan) GetPlanDefaults(planID string, platformProvider intern
 	return r0, r1
 }
 
+// GetPlanProvider provides a mock function with given fields: planID, platformProvider, parametersProvider
+func (_m *CreatorForPlan) GetPlanProvider(planID string, platformProvider internal.CloudProvider, parametersProvider *internal.CloudProvider) (internal.CloudProvider, error) {
+	ret := _m.Called(planID, platformProvider, parametersProvider)
+
+	var r0 internal.CloudProvider
+	if rf, ok := ret.Get(0).(func(string, internal.CloudProvider, *internal.CloudProvider) internal.CloudProvider); ok {
+		r0 = rf(planID, platformProvider, parametersProvider)
+	} else {
+		r0 = ret.Get(0).(internal.CloudProvider)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, internal.CloudProvider, *internal.CloudProvider) error); ok {
+		r1 = rf(planID, platformProvider, parametersProvider)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // IsPlanSupport provides a mock function with given fields: planID
 func (_m *CreatorForPlan) IsPlanSupport(planID string) bool {
 	ret := _m.Called(planID)
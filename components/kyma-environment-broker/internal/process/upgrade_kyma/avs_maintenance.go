@@ -7,6 +7,7 @@ import (
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/avs"
 	kebError "github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/error"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/tracing"
 	"github.com/sirupsen/logrus"
 )
 
@@ -18,7 +19,7 @@ func SetAvsStatusMaintenance(evaluationManager *avs.EvaluationManager, operation
 
 	if hasMonitors && !inMaintenance {
 		log.Infof("setting AVS evaluations statuses to maintenance")
-		err = evaluationManager.SetMaintenanceStatus(&operation.Avs, log)
+		err = evaluationManager.SetMaintenanceStatus(tracing.StepContext(operation.Operation), &operation.Avs, log)
 		operation, delay, _ = operationManager.UpdateOperation(operation, func(op *internal.UpgradeKymaOperation) {
 			op.Avs.AvsInternalEvaluationStatus = operation.Avs.AvsInternalEvaluationStatus
 			op.Avs.AvsExternalEvaluationStatus = operation.Avs.AvsExternalEvaluationStatus
@@ -39,7 +40,7 @@ func RestoreAvsStatus(evaluationManager *avs.EvaluationManager, operationManager
 
 	if hasMonitors && inMaintenance {
 		log.Infof("clearing AVS maintenantce statuses and restoring original AVS evaluation statuses")
-		err = evaluationManager.RestoreStatus(&operation.Avs, log)
+		err = evaluationManager.RestoreStatus(tracing.StepContext(operation.Operation), &operation.Avs, log)
 		operation, delay, _ = operationManager.UpdateOperation(operation, func(op *internal.UpgradeKymaOperation) {
 			op.Avs.AvsInternalEvaluationStatus = operation.Avs.AvsInternalEvaluationStatus
 			op.Avs.AvsExternalEvaluationStatus = operation.Avs.AvsExternalEvaluationStatus
@@ -9,6 +9,7 @@ import (
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/provisioner"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/tracing"
 )
 
 type GetKubeconfigStep struct {
@@ -41,7 +42,7 @@ func (s *GetKubeconfigStep) Run(operation internal.UpgradeKymaOperation, log log
 		return s.operationManager.OperationFailed(operation, "Runtime ID is empty", nil, log)
 	}
 
-	status, err := s.provisionerClient.RuntimeStatus(operation.ProvisioningParameters.ErsContext.GlobalAccountID, operation.Runtime.RuntimeID)
+	status, err := s.provisionerClient.RuntimeStatus(tracing.StepContext(operation.Operation), operation.ProvisioningParameters.ErsContext.GlobalAccountID, operation.Runtime.RuntimeID)
 	if err != nil {
 		log.Errorf("call to provisioner RuntimeStatus failed: %s", err.Error())
 		return operation, 1 * time.Minute, nil
@@ -44,26 +44,26 @@ func createMonitors(t *testing.T, client *avs.Client, internalStatus string, ext
 	)
 
 	// internal
-	inMonitor, err := client.CreateEvaluation(&avs.BasicEvaluationCreateRequest{
+	inMonitor, err := client.CreateEvaluation(context.Background(), &avs.BasicEvaluationCreateRequest{
 		Name: "internal monitor",
 	})
 	require.NoError(t, err)
 	operationInternalId = inMonitor.Id
 
 	if avs.ValidStatus(internalStatus) {
-		_, err = client.SetStatus(inMonitor.Id, internalStatus)
+		_, err = client.SetStatus(context.Background(), inMonitor.Id, internalStatus)
 		require.NoError(t, err)
 	}
 
 	// external
-	exMonitor, err := client.CreateEvaluation(&avs.BasicEvaluationCreateRequest{
+	exMonitor, err := client.CreateEvaluation(context.Background(), &avs.BasicEvaluationCreateRequest{
 		Name: "internal monitor",
 	})
 	require.NoError(t, err)
 	operationExternalId = exMonitor.Id
 
 	if avs.ValidStatus(externalStatus) {
-		_, err = client.SetStatus(exMonitor.Id, externalStatus)
+		_, err = client.SetStatus(context.Background(), exMonitor.Id, externalStatus)
 		require.NoError(t, err)
 	}
 
@@ -101,7 +101,7 @@ func createEvalManagerWithValidity(t *testing.T, storage storage.BrokerStorage,
 	require.NoError(t, err)
 
 	avsDel := avs.NewDelegator(client, avs.Config{}, storage.Operations())
-	upgradeEvalManager := avs.NewEvaluationManager(avsDel, avs.Config{})
+	upgradeEvalManager := avs.NewEvaluationManager(avsDel, avs.Config{}, nil)
 
 	return upgradeEvalManager, client
 }
@@ -133,7 +133,7 @@ func TestInitialisationStep_Run(t *testing.T) {
 		require.NoError(t, err)
 
 		provisionerClient := &provisionerAutomock.Client{}
-		provisionerClient.On("RuntimeOperationStatus", fixGlobalAccountID, fixProvisionerOperationID).Return(gqlschema.OperationStatus{
+		provisionerClient.On("RuntimeOperationStatus", mock.Anything, fixGlobalAccountID, fixProvisionerOperationID).Return(gqlschema.OperationStatus{
 			ID:        ptr.String(fixProvisionerOperationID),
 			Operation: "",
 			State:     gqlschema.OperationStateSucceeded,
@@ -320,7 +320,7 @@ func TestInitialisationStep_Run(t *testing.T) {
 		require.NoError(t, err)
 
 		provisionerClient := &provisionerAutomock.Client{}
-		provisionerClient.On("RuntimeOperationStatus", fixGlobalAccountID, fixProvisionerOperationID).Return(gqlschema.OperationStatus{
+		provisionerClient.On("RuntimeOperationStatus", mock.Anything, fixGlobalAccountID, fixProvisionerOperationID).Return(gqlschema.OperationStatus{
 			ID:        ptr.String(fixProvisionerOperationID),
 			Operation: "",
 			State:     gqlschema.OperationStateSucceeded,
@@ -389,7 +389,7 @@ func TestInitialisationStep_Run(t *testing.T) {
 		require.NoError(t, err)
 
 		provisionerClient := &provisionerAutomock.Client{}
-		provisionerClient.On("RuntimeOperationStatus", fixGlobalAccountID, fixProvisionerOperationID).Return(gqlschema.OperationStatus{
+		provisionerClient.On("RuntimeOperationStatus", mock.Anything, fixGlobalAccountID, fixProvisionerOperationID).Return(gqlschema.OperationStatus{
 			ID:        ptr.String(fixProvisionerOperationID),
 			Operation: "",
 			State:     gqlschema.OperationStateSucceeded,
@@ -458,7 +458,7 @@ func TestInitialisationStep_Run(t *testing.T) {
 		require.NoError(t, err)
 
 		provisionerClient := &provisionerAutomock.Client{}
-		provisionerClient.On("RuntimeOperationStatus", fixGlobalAccountID, fixProvisionerOperationID).Return(gqlschema.OperationStatus{
+		provisionerClient.On("RuntimeOperationStatus", mock.Anything, fixGlobalAccountID, fixProvisionerOperationID).Return(gqlschema.OperationStatus{
 			ID:        ptr.String(fixProvisionerOperationID),
 			Operation: "",
 			State:     gqlschema.OperationStateFailed,
@@ -528,7 +528,7 @@ func TestInitialisationStep_Run(t *testing.T) {
 		require.NoError(t, err)
 
 		provisionerClient := &provisionerAutomock.Client{}
-		provisionerClient.On("RuntimeOperationStatus", fixGlobalAccountID, fixProvisionerOperationID).Return(gqlschema.OperationStatus{
+		provisionerClient.On("RuntimeOperationStatus", mock.Anything, fixGlobalAccountID, fixProvisionerOperationID).Return(gqlschema.OperationStatus{
 			ID:        ptr.String(fixProvisionerOperationID),
 			Operation: "",
 			State:     gqlschema.OperationStateSucceeded,
@@ -598,7 +598,7 @@ func TestInitialisationStep_Run(t *testing.T) {
 		require.NoError(t, err)
 
 		provisionerClient := &provisionerAutomock.Client{}
-		provisionerClient.On("RuntimeOperationStatus", fixGlobalAccountID, fixProvisionerOperationID).Return(gqlschema.OperationStatus{
+		provisionerClient.On("RuntimeOperationStatus", mock.Anything, fixGlobalAccountID, fixProvisionerOperationID).Return(gqlschema.OperationStatus{
 			ID:        ptr.String(fixProvisionerOperationID),
 			Operation: "",
 			State:     gqlschema.OperationStateSucceeded,
@@ -669,7 +669,7 @@ func TestInitialisationStep_Run(t *testing.T) {
 		require.NoError(t, err)
 
 		provisionerClient := &provisionerAutomock.Client{}
-		provisionerClient.On("RuntimeOperationStatus", fixGlobalAccountID, fixProvisionerOperationID).Return(gqlschema.OperationStatus{
+		provisionerClient.On("RuntimeOperationStatus", mock.Anything, fixGlobalAccountID, fixProvisionerOperationID).Return(gqlschema.OperationStatus{
 			ID:        ptr.String(fixProvisionerOperationID),
 			Operation: "",
 			State:     gqlschema.OperationStateSucceeded,
@@ -739,7 +739,7 @@ func TestInitialisationStep_Run(t *testing.T) {
 		require.NoError(t, err)
 
 		provisionerClient := &provisionerAutomock.Client{}
-		provisionerClient.On("RuntimeOperationStatus", fixGlobalAccountID, fixProvisionerOperationID).Return(
+		provisionerClient.On("RuntimeOperationStatus", mock.Anything, fixGlobalAccountID, fixProvisionerOperationID).Return(
 			gqlschema.OperationStatus{
 				ID:        ptr.String(fixProvisionerOperationID),
 				Operation: "",
@@ -809,8 +809,8 @@ func TestInitialisationStep_Run(t *testing.T) {
 		provisionerClient := &provisionerAutomock.Client{}
 		// for the first 2 step.Run calls, RuntimeOperationStatus will return OperationStateInProgress
 		// otherwise, OperationStateSucceeded
-		provisionerClient.On("RuntimeOperationStatus", fixGlobalAccountID, fixProvisionerOperationID).Return(
-			func(accountID string, operationID string) gqlschema.OperationStatus {
+		provisionerClient.On("RuntimeOperationStatus", mock.Anything, fixGlobalAccountID, fixProvisionerOperationID).Return(
+			func(ctx context.Context, accountID string, operationID string) gqlschema.OperationStatus {
 				callCounter++
 				if callCounter < 2 {
 					return gqlschema.OperationStatus{
@@ -1,13 +1,12 @@
 package upgrade_kyma
 
 import (
-	"context"
-	"sort"
 	"time"
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/event"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/process"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/retrypolicy"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
 	"github.com/sirupsen/logrus"
 )
@@ -19,56 +18,42 @@ type Step interface {
 
 type StepCondition func(operation internal.UpgradeKymaOperation) bool
 
-type StepWithCondition struct {
-	Step
-	condition StepCondition
-}
-
+// Manager runs the upgrade Kyma operations in stages, a finished stage is not run again when the operation is resumed
 type Manager struct {
 	log              logrus.FieldLogger
-	steps            map[int][]StepWithCondition
+	stagedManager    *process.StagedManager
 	operationStorage storage.Operations
-
-	publisher event.Publisher
 }
 
-func NewManager(storage storage.Operations, pub event.Publisher, logger logrus.FieldLogger) *Manager {
+func NewManager(storage storage.Operations, pub event.Publisher, retryPolicies *retrypolicy.Registry, logger logrus.FieldLogger) *Manager {
+	// the upgrade steps check their own time limits, the operations may wait for the maintenance window long after their creation
+	stagedManager := process.NewStagedManager(storage, pub, 0, retryPolicies, logger)
+	// the orchestration strategy schedules the retries, the workers must not be blocked
+	stagedManager.SetMaxStepProcessingTime(0)
+
 	return &Manager{
 		log:              logger,
-		steps:            make(map[int][]StepWithCondition, 0),
+		stagedManager:    stagedManager,
 		operationStorage: storage,
-		publisher:        pub,
 	}
 }
 
-func (m *Manager) InitStep(step Step) {
-	m.AddStep(0, step, nil)
+func (m *Manager) DefineStages(names []string) {
+	m.stagedManager.DefineStages(names)
 }
 
-func (m *Manager) AddStep(weight int, step Step, cnd StepCondition) {
-	if weight <= 0 {
-		weight = 1
+func (m *Manager) AddStep(stageName string, step Step, cnd StepCondition) error {
+	var condition process.StepCondition
+	if cnd != nil {
+		condition = func(operation internal.Operation) bool {
+			return cnd(internal.UpgradeKymaOperation{Operation: operation})
+		}
 	}
-	m.steps[weight] = append(m.steps[weight], StepWithCondition{
-		Step:      step,
-		condition: cnd,
-	})
+	return m.stagedManager.AddStep(stageName, &stagedStep{step: step}, condition)
 }
 
-func (m *Manager) runStep(step Step, operation internal.UpgradeKymaOperation, logger logrus.FieldLogger) (internal.UpgradeKymaOperation, time.Duration, error) {
-	start := time.Now()
-	processedOperation, when, err := step.Run(operation, logger)
-	m.publisher.Publish(context.TODO(), process.UpgradeKymaStepProcessed{
-		OldOperation: operation,
-		Operation:    processedOperation,
-		StepProcessed: process.StepProcessed{
-			StepName: step.Name(),
-			Duration: time.Since(start),
-			When:     when,
-			Error:    err,
-		},
-	})
-	return processedOperation, when, err
+func (m *Manager) GetAllStages() []string {
+	return m.stagedManager.GetAllStages()
 }
 
 func (m *Manager) Execute(operationID string) (time.Duration, error) {
@@ -77,45 +62,11 @@ func (m *Manager) Execute(operationID string) (time.Duration, error) {
 		m.log.Errorf("Cannot fetch operation from storage: %s", err)
 		return 3 * time.Second, nil
 	}
-	operation := *op
-	if operation.IsFinished() {
+	if op.IsFinished() {
 		return 0, nil
 	}
 
-	var when time.Duration
-	logOperation := m.log.WithFields(logrus.Fields{"operation": operationID, "instanceID": operation.InstanceID})
-
-	logOperation.Info("Start process operation steps")
-	for _, weightStep := range m.sortWeight() {
-		steps := m.steps[weightStep]
-		for _, step := range steps {
-			if step.condition != nil && !step.condition(operation) {
-				continue
-			}
-			logStep := logOperation.WithField("step", step.Name())
-			logStep.Infof("Start step")
-
-			operation, when, err = m.runStep(step, operation, logStep)
-			if err != nil {
-				logStep.Errorf("Process operation failed: %s", err)
-				return 0, err
-			}
-			if operation.IsFinished() {
-				logStep.Infof("Operation %q got status %s. Process finished.", operation.Operation.ID, operation.State)
-				return 0, nil
-			}
-			if when == 0 {
-				logStep.Info("Process operation successful")
-				continue
-			}
-
-			logStep.Infof("Process operation will be repeated in %s ...", when)
-			return when, nil
-		}
-	}
-
-	logOperation.Infof("Operation %q got status %s. All steps finished.", operation.Operation.ID, operation.State)
-	return 0, nil
+	return m.stagedManager.Execute(operationID)
 }
 
 func (m Manager) Reschedule(operationID string, maintenanceWindowBegin, maintenanceWindowEnd time.Time) error {
@@ -134,12 +85,18 @@ func (m Manager) Reschedule(operationID string, maintenanceWindowBegin, maintena
 	return err
 }
 
-func (m *Manager) sortWeight() []int {
-	var weight []int
-	for w := range m.steps {
-		weight = append(weight, w)
-	}
-	sort.Ints(weight)
+// stagedStep runs the upgrade Kyma step in the staged manager
+type stagedStep struct {
+	step Step
+}
+
+func (s *stagedStep) Name() string {
+	return s.step.Name()
+}
 
-	return weight
+func (s *stagedStep) Run(operation internal.Operation, logger logrus.FieldLogger) (internal.Operation, time.Duration, error) {
+	// the runtime operation ID is not stored in the operation data
+	operation.RuntimeOperation.ID = operation.ID
+	processed, when, err := s.step.Run(internal.UpgradeKymaOperation{Operation: operation}, logger)
+	return processed.Operation, when, err
 }
@@ -28,9 +28,13 @@ const (
 func TestManager_Execute(t *testing.T) {
 	for name, tc := range map[string]struct {
 		operationID            string
+		finishedStages         []string
+		state                  domain.LastOperationState
 		expectedError          bool
 		expectedRepeat         time.Duration
 		expectedDesc           string
+		expectedState          domain.LastOperationState
+		expectedStages         []string
 		expectedNumberOfEvents int
 	}{
 		"operation successful": {
@@ -38,6 +42,8 @@ func TestManager_Execute(t *testing.T) {
 			expectedError:          false,
 			expectedRepeat:         time.Duration(0),
 			expectedDesc:           "init one two final",
+			expectedState:          domain.Succeeded,
+			expectedStages:         []string{"first"},
 			expectedNumberOfEvents: 4,
 		},
 		"operation failed": {
@@ -50,15 +56,42 @@ func TestManager_Execute(t *testing.T) {
 			expectedError:          false,
 			expectedRepeat:         time.Duration(10),
 			expectedDesc:           "init",
+			expectedState:          domain.InProgress,
+			expectedStages:         []string{},
 			expectedNumberOfEvents: 1,
 		},
+		"operation resumed from the last finished stage": {
+			operationID:            operationIDSuccess,
+			finishedStages:         []string{"first"},
+			expectedError:          false,
+			expectedRepeat:         time.Duration(0),
+			expectedDesc:           "two final",
+			expectedState:          domain.Succeeded,
+			expectedStages:         []string{"first"},
+			expectedNumberOfEvents: 2,
+		},
+		"operation already finished": {
+			operationID:            operationIDSuccess,
+			state:                  domain.Failed,
+			expectedError:          false,
+			expectedRepeat:         time.Duration(0),
+			expectedDesc:           "",
+			expectedState:          domain.Failed,
+			expectedStages:         []string{},
+			expectedNumberOfEvents: 0,
+		},
 	} {
 		t.Run(name, func(t *testing.T) {
 			// given
 			log := logrus.New()
 			memoryStorage := storage.NewMemoryStorage()
 			operations := memoryStorage.Operations()
-			err := operations.InsertUpgradeKymaOperation(fixOperation(tc.operationID))
+			operation := fixOperation(tc.operationID)
+			operation.FinishedStages = append([]string{}, tc.finishedStages...)
+			if tc.state != "" {
+				operation.State = tc.state
+			}
+			err := operations.InsertUpgradeKymaOperation(operation)
 			assert.NoError(t, err)
 
 			sInit := testStep{t: t, name: "init", storage: operations}
@@ -68,14 +101,14 @@ func TestManager_Execute(t *testing.T) {
 
 			eventBroker := event.NewPubSub(logrus.New())
 			eventCollector := &collectingEventHandler{}
-			eventBroker.Subscribe(process.UpgradeKymaStepProcessed{}, eventCollector.OnEvent)
-
-			manager := NewManager(operations, eventBroker, log)
-			manager.InitStep(&sInit)
+			eventBroker.Subscribe(process.OperationStepProcessed{}, eventCollector.OnEvent)
 
-			manager.AddStep(2, &sFinal, nil)
-			manager.AddStep(1, &s1, nil)
-			manager.AddStep(1, &s2, nil)
+			manager := NewManager(operations, eventBroker, nil, log)
+			manager.DefineStages([]string{"first", "second"})
+			assert.NoError(t, manager.AddStep("first", &sInit, nil))
+			assert.NoError(t, manager.AddStep("first", &s1, nil))
+			assert.NoError(t, manager.AddStep("second", &s2, nil))
+			assert.NoError(t, manager.AddStep("second", &sFinal, nil))
 
 			// when
 			repeat, err := manager.Execute(tc.operationID)
@@ -90,6 +123,8 @@ func TestManager_Execute(t *testing.T) {
 				operation, err := operations.GetOperationByID(tc.operationID)
 				assert.NoError(t, err)
 				assert.Equal(t, tc.expectedDesc, strings.Trim(operation.Description, " "))
+				assert.Equal(t, tc.expectedState, operation.State)
+				assert.Equal(t, tc.expectedStages, operation.FinishedStages)
 			}
 			assert.NoError(t, wait.PollImmediate(20*time.Millisecond, 2*time.Second, func() (bool, error) {
 				return len(eventCollector.Events) == tc.expectedNumberOfEvents, nil
@@ -98,6 +133,18 @@ func TestManager_Execute(t *testing.T) {
 	}
 }
 
+func TestManager_AddStepToUndefinedStage(t *testing.T) {
+	// given
+	manager := NewManager(storage.NewMemoryStorage().Operations(), event.NewPubSub(logrus.New()), nil, logrus.New())
+	manager.DefineStages([]string{"first"})
+
+	// when
+	err := manager.AddStep("second", &testStep{t: t, name: "one"}, nil)
+
+	// then
+	assert.EqualError(t, err, "stage second not defined")
+}
+
 func fixOperation(ID string) internal.UpgradeKymaOperation {
 	upgradeOperation := fixture.FixUpgradeKymaOperation(ID, "fea2c1a1-139d-43f6-910a-a618828a79d5")
 	upgradeOperation.State = domain.InProgress
@@ -120,6 +167,10 @@ func (ts *testStep) Run(operation internal.UpgradeKymaOperation, logger logrus.F
 	logger.Infof("inside %s step", ts.name)
 
 	operation.Description = fmt.Sprintf("%s %s", operation.Description, ts.name)
+	if ts.name == "final" {
+		// the last step of the upgrade finishes the operation
+		operation.State = domain.Succeeded
+	}
 	updated, err := ts.storage.UpdateUpgradeKymaOperation(operation)
 	if err != nil {
 		ts.t.Error(err)
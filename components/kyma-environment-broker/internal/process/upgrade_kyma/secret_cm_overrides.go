@@ -26,14 +26,16 @@ type OverridesFromSecretsAndConfigStep struct {
 	operationManager       *process.UpgradeKymaOperationManager
 	runtimeOverrides       RuntimeOverridesAppender
 	runtimeVerConfigurator RuntimeVersionConfiguratorForUpgrade
+	planRegistry           *broker.PlanRegistry
 }
 
 func NewOverridesFromSecretsAndConfigStep(os storage.Operations, runtimeOverrides RuntimeOverridesAppender,
-	rvc RuntimeVersionConfiguratorForUpgrade) *OverridesFromSecretsAndConfigStep {
+	rvc RuntimeVersionConfiguratorForUpgrade, planRegistry *broker.PlanRegistry) *OverridesFromSecretsAndConfigStep {
 	return &OverridesFromSecretsAndConfigStep{
 		operationManager:       process.NewUpgradeKymaOperationManager(os),
 		runtimeOverrides:       runtimeOverrides,
 		runtimeVerConfigurator: rvc,
+		planRegistry:           planRegistry,
 	}
 }
 
@@ -42,7 +44,7 @@ func (s *OverridesFromSecretsAndConfigStep) Name() string {
 }
 
 func (s *OverridesFromSecretsAndConfigStep) Run(operation internal.UpgradeKymaOperation, log logrus.FieldLogger) (internal.UpgradeKymaOperation, time.Duration, error) {
-	planName, exists := broker.PlanNamesMapping[operation.ProvisioningParameters.PlanID]
+	planName, exists := s.planRegistry.PlanNames()[operation.ProvisioningParameters.PlanID]
 	if !exists {
 		log.Errorf("cannot map planID '%s' to planName", operation.ProvisioningParameters.PlanID)
 		return s.operationManager.OperationFailed(operation, "invalid operation provisioning parameters", nil, log)
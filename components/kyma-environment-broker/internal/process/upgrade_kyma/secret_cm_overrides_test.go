@@ -39,7 +39,7 @@ func TestOverridesFromSecretsAndConfigStep_Run_WithVersionComputed(t *testing.T)
 		defer rvcMock.AssertExpectations(t)
 		rvcMock.On("ForUpgrade", operation).Return(&internal.RuntimeVersionData{Version: kymaVersion}, nil).Once()
 
-		step := NewOverridesFromSecretsAndConfigStep(memoryStorage.Operations(), runtimeOverridesMock, rvcMock)
+		step := NewOverridesFromSecretsAndConfigStep(memoryStorage.Operations(), runtimeOverridesMock, rvcMock, nil)
 
 		// When
 		operation, repeat, err := step.Run(operation, logrus.New())
@@ -77,7 +77,7 @@ func TestOverridesFromSecretsAndConfigStep_Run_WithVersionFromOperation(t *testi
 		rvcMock := &automock.RuntimeVersionConfiguratorForUpgrade{}
 		defer rvcMock.AssertExpectations(t)
 
-		step := NewOverridesFromSecretsAndConfigStep(memoryStorage.Operations(), runtimeOverridesMock, rvcMock)
+		step := NewOverridesFromSecretsAndConfigStep(memoryStorage.Operations(), runtimeOverridesMock, rvcMock, nil)
 
 		// When
 		operation, repeat, err := step.Run(operation, logrus.New())
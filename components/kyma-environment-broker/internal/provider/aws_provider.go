@@ -33,11 +33,15 @@ type (
 	AWSInput struct {
 		MultiZone                    bool
 		ControlPlaneFailureTolerance string
+		Zones                        ZonesProvider
 	}
 	AWSTrialInput struct {
 		PlatformRegionMapping map[string]string
+		Zones                 ZonesProvider
+	}
+	AWSFreemiumInput struct {
+		Zones ZonesProvider
 	}
-	AWSFreemiumInput struct{}
 )
 
 func (p *AWSInput) Defaults() *gqlschema.ClusterConfigInput {
@@ -64,7 +68,7 @@ func (p *AWSInput) Defaults() *gqlschema.ClusterConfigInput {
 			ProviderSpecificConfig: &gqlschema.ProviderSpecificInput{
 				AwsConfig: &gqlschema.AWSProviderConfigInput{
 					VpcCidr:  "10.250.0.0/16",
-					AwsZones: generateMultipleAWSZones(MultipleZonesForAWSRegion(DefaultAWSRegion, zonesCount)),
+					AwsZones: generateMultipleAWSZones(MultipleZonesForAWSRegion(p.Zones, DefaultAWSRegion, zonesCount)),
 				},
 			},
 			ControlPlaneFailureTolerance: controlPlaneFailureTolerance,
@@ -88,8 +92,34 @@ var awsZones = map[string]string{
 	"ap-southeast-2": "abc",
 }
 
-func ZoneForAWSRegion(region string) string {
+// ZonesProvider supplies the availability zones of the provider regions, e.g. derived from Gardener CloudProfiles.
+// It is used for AWS and GCP, the Azure zones are numbered 1-3 in every region and are not derived from it.
+// A nil ZonesProvider or a region it does not know falls back to the built-in zones.
+type ZonesProvider interface {
+	Zones(providerType, region string) (zones []string, found bool)
+}
+
+// awsZoneSuffixes returns the zone suffixes for the given AWS region, e.g. "abc" for eu-central-1a, eu-central-1b and eu-central-1c
+func awsZoneSuffixes(zonesProvider ZonesProvider, region string) (string, bool) {
+	if zonesProvider != nil {
+		if zones, found := zonesProvider.Zones("aws", region); found && len(zones) > 0 {
+			suffixes := ""
+			for _, zone := range zones {
+				if suffix := strings.TrimPrefix(zone, region); len(suffix) == 1 {
+					suffixes += suffix
+				}
+			}
+			if suffixes != "" {
+				return suffixes, true
+			}
+		}
+	}
 	zones, found := awsZones[region]
+	return zones, found
+}
+
+func ZoneForAWSRegion(zonesProvider ZonesProvider, region string) string {
+	zones, found := awsZoneSuffixes(zonesProvider, region)
 	if !found {
 		zones = "a"
 	}
@@ -98,8 +128,8 @@ func ZoneForAWSRegion(region string) string {
 	return fmt.Sprintf("%s%s", region, zone)
 }
 
-func MultipleZonesForAWSRegion(region string, zonesCount int) []string {
-	zones, found := awsZones[region]
+func MultipleZonesForAWSRegion(zonesProvider ZonesProvider, region string, zonesCount int) []string {
+	zones, found := awsZoneSuffixes(zonesProvider, region)
 	if !found {
 		zones = "a"
 		zonesCount = 1
@@ -165,9 +195,9 @@ func (p *AWSInput) ApplyParameters(input *gqlschema.ClusterConfigInput, pp inter
 		if p.MultiZone {
 			zonesCount = DefaultAWSMultiZoneCount
 		}
-		input.GardenerConfig.ProviderSpecificConfig.AwsConfig.AwsZones = generateMultipleAWSZones(MultipleZonesForAWSRegion(*pp.Parameters.Region, zonesCount))
+		input.GardenerConfig.ProviderSpecificConfig.AwsConfig.AwsZones = generateMultipleAWSZones(MultipleZonesForAWSRegion(p.Zones, *pp.Parameters.Region, zonesCount))
 	case internal.IsEuAccess(pp.PlatformRegion):
-		updateRegionWithZones(input, DefaultEuAccessAWSRegion)
+		updateRegionWithZones(input, DefaultEuAccessAWSRegion, p.Zones)
 	}
 }
 
@@ -180,10 +210,10 @@ func (p *AWSInput) Provider() internal.CloudProvider {
 }
 
 func (p *AWSTrialInput) Defaults() *gqlschema.ClusterConfigInput {
-	return awsLiteDefaults(DefaultAWSTrialRegion)
+	return awsLiteDefaults(DefaultAWSTrialRegion, p.Zones)
 }
 
-func awsLiteDefaults(region string) *gqlschema.ClusterConfigInput {
+func awsLiteDefaults(region string, zonesProvider ZonesProvider) *gqlschema.ClusterConfigInput {
 	return &gqlschema.ClusterConfigInput{
 		GardenerConfig: &gqlschema.GardenerConfigInput{
 			DiskType:       ptr.String("gp2"),
@@ -202,7 +232,7 @@ func awsLiteDefaults(region string) *gqlschema.ClusterConfigInput {
 					VpcCidr: "10.250.0.0/16",
 					AwsZones: []*gqlschema.AWSZoneInput{
 						{
-							Name:         ZoneForAWSRegion(region),
+							Name:         ZoneForAWSRegion(zonesProvider, region),
 							PublicCidr:   "10.250.32.0/20",
 							InternalCidr: "10.250.48.0/20",
 							WorkerCidr:   "10.250.0.0/19",
@@ -218,7 +248,7 @@ func (p *AWSTrialInput) ApplyParameters(input *gqlschema.ClusterConfigInput, pp
 	params := pp.Parameters
 
 	if internal.IsEuAccess(pp.PlatformRegion) {
-		updateRegionWithZones(input, DefaultEuAccessAWSRegion)
+		updateRegionWithZones(input, DefaultEuAccessAWSRegion, p.Zones)
 		return
 	}
 
@@ -227,19 +257,19 @@ func (p *AWSTrialInput) ApplyParameters(input *gqlschema.ClusterConfigInput, pp
 		abstractRegion, found := p.PlatformRegionMapping[pp.PlatformRegion]
 		if found {
 			r := toAWSSpecific[abstractRegion]
-			updateRegionWithZones(input, r)
+			updateRegionWithZones(input, r, p.Zones)
 		}
 	}
 
 	if params.Region != nil && *params.Region != "" {
 		r := toAWSSpecific[*params.Region]
-		updateRegionWithZones(input, r)
+		updateRegionWithZones(input, r, p.Zones)
 	}
 }
 
-func updateRegionWithZones(input *gqlschema.ClusterConfigInput, region string) {
+func updateRegionWithZones(input *gqlschema.ClusterConfigInput, region string, zonesProvider ZonesProvider) {
 	input.GardenerConfig.Region = region
-	input.GardenerConfig.ProviderSpecificConfig.AwsConfig.AwsZones[0].Name = ZoneForAWSRegion(region)
+	input.GardenerConfig.ProviderSpecificConfig.AwsConfig.AwsZones[0].Name = ZoneForAWSRegion(zonesProvider, region)
 }
 
 func (p *AWSTrialInput) Profile() gqlschema.KymaProfile {
@@ -252,14 +282,14 @@ func (p *AWSTrialInput) Provider() internal.CloudProvider {
 
 func (p *AWSFreemiumInput) Defaults() *gqlschema.ClusterConfigInput {
 	// Lite (freemium) must have the same defaults as Trial plan, but there was a requirement to change a region only for Trial.
-	defaults := awsLiteDefaults(DefaultAWSRegion)
+	defaults := awsLiteDefaults(DefaultAWSRegion, p.Zones)
 
 	return defaults
 }
 
 func (p *AWSFreemiumInput) ApplyParameters(input *gqlschema.ClusterConfigInput, pp internal.ProvisioningParameters) {
 	if pp.Parameters.Region != nil && *pp.Parameters.Region != "" && pp.Parameters.Zones == nil {
-		input.GardenerConfig.ProviderSpecificConfig.AwsConfig.AwsZones[0].Name = ZoneForAWSRegion(*pp.Parameters.Region)
+		input.GardenerConfig.ProviderSpecificConfig.AwsConfig.AwsZones[0].Name = ZoneForAWSRegion(p.Zones, *pp.Parameters.Region)
 	}
 }
 
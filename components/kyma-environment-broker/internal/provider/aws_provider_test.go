@@ -35,7 +35,7 @@ func TestMultipleZonesForAWSRegion(t *testing.T) {
 		region := "us-east-1"
 
 		// when
-		generatedZones := MultipleZonesForAWSRegion(region, 3)
+		generatedZones := MultipleZonesForAWSRegion(nil, region, 3)
 
 		// then
 		for _, zone := range generatedZones {
@@ -66,7 +66,7 @@ func TestMultipleZonesForAWSRegion(t *testing.T) {
 		// "us-east-1" region has maximum 6 zones, user request 20
 
 		// when
-		generatedZones := MultipleZonesForAWSRegion(region, zonesCountExceedingMaximum)
+		generatedZones := MultipleZonesForAWSRegion(nil, region, zonesCountExceedingMaximum)
 
 		// then
 		for _, zone := range generatedZones {
@@ -75,6 +75,26 @@ func TestMultipleZonesForAWSRegion(t *testing.T) {
 		}
 		assert.Equal(t, maximumZonesForRegion, len(generatedZones))
 	})
+
+	t.Run("for zones from zones provider", func(t *testing.T) {
+		// given
+		zonesProvider := fakeZonesProvider{"aws/eu-north-1": {"eu-north-1a", "eu-north-1b"}}
+
+		// when
+		generatedZones := MultipleZonesForAWSRegion(zonesProvider, "eu-north-1", 3)
+
+		// then
+		assert.ElementsMatch(t, []string{"eu-north-1a", "eu-north-1b"}, generatedZones)
+		assert.Contains(t, []string{"eu-central-1a", "eu-central-1b", "eu-central-1c"}, ZoneForAWSRegion(zonesProvider, "eu-central-1"))
+	})
+}
+
+// fakeZonesProvider maps "<provider type>/<region>" to the zones of the region
+type fakeZonesProvider map[string][]string
+
+func (p fakeZonesProvider) Zones(providerType, region string) ([]string, bool) {
+	zones, found := p[providerType+"/"+region]
+	return zones, found
 }
 
 func TestAWSInput_SingleZone_ApplyParameters(t *testing.T) {
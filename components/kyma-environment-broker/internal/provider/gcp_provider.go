@@ -32,9 +32,11 @@ type (
 	GcpInput struct {
 		MultiZone                    bool
 		ControlPlaneFailureTolerance string
+		Zones                        ZonesProvider
 	}
 	GcpTrialInput struct {
 		PlatformRegionMapping map[string]string
+		Zones                 ZonesProvider
 	}
 )
 
@@ -61,7 +63,7 @@ func (p *GcpInput) Defaults() *gqlschema.ClusterConfigInput {
 			MaxUnavailable: 0,
 			ProviderSpecificConfig: &gqlschema.ProviderSpecificInput{
 				GcpConfig: &gqlschema.GCPProviderConfigInput{
-					Zones: ZonesForGCPRegion(DefaultGCPRegion, zonesCount),
+					Zones: ZonesForGCPRegion(p.Zones, DefaultGCPRegion, zonesCount),
 				},
 			},
 			ControlPlaneFailureTolerance: controlPlaneFailureTolerance,
@@ -80,7 +82,7 @@ func (p *GcpInput) ApplyParameters(input *gqlschema.ClusterConfigInput, pp inter
 		if p.MultiZone {
 			zonesCount = DefaultGCPMultiZoneCount
 		}
-		updateSlice(&input.GardenerConfig.ProviderSpecificConfig.GcpConfig.Zones, ZonesForGCPRegion(*pp.Parameters.Region, zonesCount))
+		updateSlice(&input.GardenerConfig.ProviderSpecificConfig.GcpConfig.Zones, ZonesForGCPRegion(p.Zones, *pp.Parameters.Region, zonesCount))
 	}
 }
 
@@ -107,7 +109,7 @@ func (p *GcpTrialInput) Defaults() *gqlschema.ClusterConfigInput {
 			MaxUnavailable: 0,
 			ProviderSpecificConfig: &gqlschema.ProviderSpecificInput{
 				GcpConfig: &gqlschema.GCPProviderConfigInput{
-					Zones: ZonesForGCPRegion(DefaultGCPRegion, 1),
+					Zones: ZonesForGCPRegion(p.Zones, DefaultGCPRegion, 1),
 				},
 			},
 		},
@@ -134,7 +136,7 @@ func (p *GcpTrialInput) ApplyParameters(input *gqlschema.ClusterConfigInput, pp
 	// region is not empty - it means override the default one
 	if region != "" {
 		updateString(&input.GardenerConfig.Region, &region)
-		updateSlice(&input.GardenerConfig.ProviderSpecificConfig.GcpConfig.Zones, ZonesForGCPRegion(region, 1))
+		updateSlice(&input.GardenerConfig.ProviderSpecificConfig.GcpConfig.Zones, ZonesForGCPRegion(p.Zones, region, 1))
 	}
 }
 
@@ -146,14 +148,22 @@ func (p *GcpTrialInput) Provider() internal.CloudProvider {
 	return internal.GCP
 }
 
-func ZonesForGCPRegion(region string, zonesCount int) []string {
-	zoneCodes := []string{"a", "b", "c"}
-	var zones []string
-	rand.Shuffle(len(zoneCodes), func(i, j int) { zoneCodes[i], zoneCodes[j] = zoneCodes[j], zoneCodes[i] })
-
-	for i := 0; i < zonesCount && i < len(zoneCodes); i++ {
-		zones = append(zones, fmt.Sprintf("%s-%s", region, zoneCodes[i]))
+func ZonesForGCPRegion(zonesProvider ZonesProvider, region string, zonesCount int) []string {
+	var availableZones []string
+	if zonesProvider != nil {
+		if zones, found := zonesProvider.Zones("gcp", region); found {
+			availableZones = append(availableZones, zones...)
+		}
 	}
+	if len(availableZones) == 0 {
+		for _, code := range []string{"a", "b", "c"} {
+			availableZones = append(availableZones, fmt.Sprintf("%s-%s", region, code))
+		}
+	}
+	rand.Shuffle(len(availableZones), func(i, j int) { availableZones[i], availableZones[j] = availableZones[j], availableZones[i] })
 
-	return zones
+	if zonesCount > len(availableZones) {
+		zonesCount = len(availableZones)
+	}
+	return availableZones[:zonesCount]
 }
@@ -155,3 +155,23 @@ func TestGcpInput_MultiZone_ApplyParameters(t *testing.T) {
 		assert.Equal(t, "zone", *input.GardenerConfig.ControlPlaneFailureTolerance)
 	})
 }
+
+func TestGcpInput_ZonesFromZonesProvider(t *testing.T) {
+	// given
+	svc := GcpInput{
+		MultiZone: true,
+		Zones:     fakeZonesProvider{"gcp/europe-north1": {"europe-north1-a", "europe-north1-b", "europe-north1-c", "europe-north1-d"}},
+	}
+	input := svc.Defaults()
+
+	// when
+	svc.ApplyParameters(input, internal.ProvisioningParameters{
+		Parameters: internal.ProvisioningParametersDTO{
+			Region: ptr.String("europe-north1"),
+		},
+	})
+
+	// then
+	assert.Len(t, input.GardenerConfig.ProviderSpecificConfig.GcpConfig.Zones, 3)
+	assert.Subset(t, []string{"europe-north1-a", "europe-north1-b", "europe-north1-c", "europe-north1-d"}, input.GardenerConfig.ProviderSpecificConfig.GcpConfig.Zones)
+}
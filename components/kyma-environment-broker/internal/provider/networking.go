@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/networking"
+	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
+)
+
+// ApplyNetworking sets the node range of the runtime. The node range replaces the worker range
+// and the VPC or VNet range, the zone subnets are derived from it the same way as from the default range.
+func ApplyNetworking(input *gqlschema.ClusterConfigInput, params *internal.NetworkingDTO) error {
+	if params == nil || input.GardenerConfig == nil {
+		return nil
+	}
+	config := input.GardenerConfig
+	if params.Nodes == nil || *params.Nodes == "" {
+		return nil
+	}
+
+	nodes, err := networking.ParseNetwork(*params.Nodes)
+	if err != nil {
+		return fmt.Errorf("while parsing nodes range: %w", err)
+	}
+	config.WorkerCidr = nodes.String()
+	if config.ProviderSpecificConfig == nil {
+		return nil
+	}
+	if aws := config.ProviderSpecificConfig.AwsConfig; aws != nil {
+		aws.VpcCidr = nodes.String()
+		if err := updateAWSZonesSubnets(aws.AwsZones, nodes); err != nil {
+			return fmt.Errorf("while generating AWS zones subnets: %w", err)
+		}
+	}
+	if azure := config.ProviderSpecificConfig.AzureConfig; azure != nil {
+		azure.VnetCidr = nodes.String()
+		if err := updateAzureZonesSubnets(azure.AzureZones, nodes); err != nil {
+			return fmt.Errorf("while generating Azure zones subnets: %w", err)
+		}
+	}
+	return nil
+}
+
+// updateAWSZonesSubnets splits the VPC into 4 blocks, one for each zone, and each block into
+// the workers subnet (first half) and the public and internal subnets (last quarters), see generateMultipleAWSZones
+func updateAWSZonesSubnets(zones []*gqlschema.AWSZoneInput, vpc *net.IPNet) error {
+	ones, _ := vpc.Mask.Size()
+	for i, zone := range zones {
+		block, err := networking.Subnet(vpc, ones+2, i)
+		if err != nil {
+			return err
+		}
+		workers, _ := networking.Subnet(block, ones+3, 0)
+		public, _ := networking.Subnet(block, ones+4, 2)
+		internalSubnet, _ := networking.Subnet(block, ones+4, 3)
+		zone.WorkerCidr = workers.String()
+		zone.PublicCidr = public.String()
+		zone.InternalCidr = internalSubnet.String()
+	}
+	return nil
+}
+
+// updateAzureZonesSubnets splits the VNet into 8 subnets, one for each zone, see generateMultipleAzureZones
+func updateAzureZonesSubnets(zones []*gqlschema.AzureZoneInput, vnet *net.IPNet) error {
+	ones, _ := vnet.Mask.Size()
+	for i, zone := range zones {
+		subnet, err := networking.Subnet(vnet, ones+3, i)
+		if err != nil {
+			return err
+		}
+		zone.Cidr = subnet.String()
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/networking"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/ptr"
+	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyNetworking(t *testing.T) {
+	t.Run("should derive the AWS zones subnets from the nodes range", func(t *testing.T) {
+		// given
+		input := (&AWSInput{MultiZone: true}).Defaults()
+
+		// when
+		err := ApplyNetworking(input, &internal.NetworkingDTO{Nodes: ptr.String("10.180.0.0/18")})
+
+		// then
+		require.NoError(t, err)
+		config := input.GardenerConfig
+		assert.Equal(t, "10.180.0.0/18", config.WorkerCidr)
+		assert.Equal(t, "10.180.0.0/18", config.ProviderSpecificConfig.AwsConfig.VpcCidr)
+
+		zones := config.ProviderSpecificConfig.AwsConfig.AwsZones
+		require.Len(t, zones, 3)
+		assert.Equal(t, "10.180.32.0/21", zones[2].WorkerCidr)
+		assert.Equal(t, "10.180.40.0/22", zones[2].PublicCidr)
+		assert.Equal(t, "10.180.44.0/22", zones[2].InternalCidr)
+	})
+
+	t.Run("should derive the Azure zones subnets from the nodes range", func(t *testing.T) {
+		// given
+		input := (&AzureInput{MultiZone: true}).Defaults()
+
+		// when
+		err := ApplyNetworking(input, &internal.NetworkingDTO{Nodes: ptr.String("10.180.0.0/16")})
+
+		// then
+		require.NoError(t, err)
+		azure := input.GardenerConfig.ProviderSpecificConfig.AzureConfig
+		assert.Equal(t, "10.180.0.0/16", azure.VnetCidr)
+		require.Len(t, azure.AzureZones, 3)
+		assert.Equal(t, "10.180.64.0/19", azure.AzureZones[2].Cidr)
+	})
+
+	t.Run("should keep the default subnets layout", func(t *testing.T) {
+		// given
+		input := (&AWSInput{MultiZone: true}).Defaults()
+		expected := generateMultipleAWSZones([]string{"a", "b", "c"})
+
+		// when
+		err := ApplyNetworking(input, &internal.NetworkingDTO{Nodes: ptr.String(networking.DefaultNodesCIDR)})
+
+		// then
+		require.NoError(t, err)
+		for i, zone := range input.GardenerConfig.ProviderSpecificConfig.AwsConfig.AwsZones {
+			assert.Equal(t, expected[i].WorkerCidr, zone.WorkerCidr)
+			assert.Equal(t, expected[i].PublicCidr, zone.PublicCidr)
+			assert.Equal(t, expected[i].InternalCidr, zone.InternalCidr)
+		}
+	})
+
+	t.Run("should not change the input without networking parameters", func(t *testing.T) {
+		// given
+		input := &gqlschema.ClusterConfigInput{GardenerConfig: &gqlschema.GardenerConfigInput{WorkerCidr: "10.250.0.0/19"}}
+
+		// when
+		err := ApplyNetworking(input, nil)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, &gqlschema.ClusterConfigInput{GardenerConfig: &gqlschema.GardenerConfigInput{WorkerCidr: "10.250.0.0/19"}}, input)
+	})
+}
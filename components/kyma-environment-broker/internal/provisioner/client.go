@@ -7,6 +7,7 @@ import (
 
 	kebError "github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/error"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/httputil"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/tracing"
 
 	gcli "github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/third_party/machinebox/graphql"
 	schema "github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
@@ -21,13 +22,14 @@ const (
 //go:generate mockery --name=Client --output=automock --outpkg=automock --case=underscore
 
 type Client interface {
-	ProvisionRuntime(accountID, subAccountID string, config schema.ProvisionRuntimeInput) (schema.OperationStatus, error)
-	DeprovisionRuntime(accountID, runtimeID string) (string, error)
-	UpgradeRuntime(accountID, runtimeID string, config schema.UpgradeRuntimeInput) (schema.OperationStatus, error)
-	UpgradeShoot(accountID, runtimeID string, config schema.UpgradeShootInput) (schema.OperationStatus, error)
-	ReconnectRuntimeAgent(accountID, runtimeID string) (string, error)
-	RuntimeOperationStatus(accountID, operationID string) (schema.OperationStatus, error)
-	RuntimeStatus(accountID, runtimeID string) (schema.RuntimeStatus, error)
+	ProvisionRuntime(ctx context.Context, accountID, subAccountID string, config schema.ProvisionRuntimeInput) (schema.OperationStatus, error)
+	DeprovisionRuntime(ctx context.Context, accountID, runtimeID string) (string, error)
+	UpgradeRuntime(ctx context.Context, accountID, runtimeID string, config schema.UpgradeRuntimeInput) (schema.OperationStatus, error)
+	UpgradeShoot(ctx context.Context, accountID, runtimeID string, config schema.UpgradeShootInput) (schema.OperationStatus, error)
+	ReconnectRuntimeAgent(ctx context.Context, accountID, runtimeID string) (string, error)
+	HibernateRuntime(ctx context.Context, accountID, runtimeID string) (schema.OperationStatus, error)
+	RuntimeOperationStatus(ctx context.Context, accountID, operationID string) (schema.OperationStatus, error)
+	RuntimeStatus(ctx context.Context, accountID, runtimeID string) (schema.RuntimeStatus, error)
 }
 
 type client struct {
@@ -37,7 +39,9 @@ type client struct {
 }
 
 func NewProvisionerClient(endpoint string, queryDumping bool) Client {
-	graphQlClient := gcli.NewClient(endpoint, gcli.WithHTTPClient(httputil.NewClient(120, false)))
+	httpClient := httputil.NewClient(120, false)
+	httpClient.Transport = tracing.NewTransport(httpClient.Transport, "provisioner")
+	graphQlClient := gcli.NewClient(endpoint, gcli.WithHTTPClient(httpClient))
 	if queryDumping {
 		graphQlClient.Log = func(s string) {
 			fmt.Println(s)
@@ -51,7 +55,7 @@ func NewProvisionerClient(endpoint string, queryDumping bool) Client {
 	}
 }
 
-func (c *client) ProvisionRuntime(accountID, subAccountID string, config schema.ProvisionRuntimeInput) (schema.OperationStatus, error) {
+func (c *client) ProvisionRuntime(ctx context.Context, accountID, subAccountID string, config schema.ProvisionRuntimeInput) (schema.OperationStatus, error) {
 	provisionRuntimeIptGQL, err := c.graphqlizer.ProvisionRuntimeInputToGraphQL(config)
 	if err != nil {
 		return schema.OperationStatus{}, fmt.Errorf("failed to convert Provision Runtime Input to query: %w", err)
@@ -63,7 +67,7 @@ func (c *client) ProvisionRuntime(accountID, subAccountID string, config schema.
 	req.Header.Add(subAccountIDKey, subAccountID)
 
 	var response schema.OperationStatus
-	err = c.executeRequest(req, &response)
+	err = c.executeRequest(ctx, req, &response)
 	if err != nil {
 		return schema.OperationStatus{}, fmt.Errorf("failed to provision a Runtime: %w", err)
 	}
@@ -71,20 +75,20 @@ func (c *client) ProvisionRuntime(accountID, subAccountID string, config schema.
 	return response, nil
 }
 
-func (c *client) DeprovisionRuntime(accountID, runtimeID string) (string, error) {
+func (c *client) DeprovisionRuntime(ctx context.Context, accountID, runtimeID string) (string, error) {
 	query := c.queryProvider.deprovisionRuntime(runtimeID)
 	req := gcli.NewRequest(query)
 	req.Header.Add(accountIDKey, accountID)
 
 	var operationId string
-	err := c.executeRequest(req, &operationId)
+	err := c.executeRequest(ctx, req, &operationId)
 	if err != nil {
 		return "", fmt.Errorf("failed to deprovision Runtime: %w", err)
 	}
 	return operationId, nil
 }
 
-func (c *client) UpgradeRuntime(accountID, runtimeID string, config schema.UpgradeRuntimeInput) (schema.OperationStatus, error) {
+func (c *client) UpgradeRuntime(ctx context.Context, accountID, runtimeID string, config schema.UpgradeRuntimeInput) (schema.OperationStatus, error) {
 	upgradeRuntimeIptGQL, err := c.graphqlizer.UpgradeRuntimeInputToGraphQL(config)
 	if err != nil {
 		return schema.OperationStatus{}, fmt.Errorf("failed to convert Upgrade Runtime Input to query: %w", err)
@@ -95,14 +99,14 @@ func (c *client) UpgradeRuntime(accountID, runtimeID string, config schema.Upgra
 	req.Header.Add(accountIDKey, accountID)
 
 	var res schema.OperationStatus
-	err = c.executeRequest(req, &res)
+	err = c.executeRequest(ctx, req, &res)
 	if err != nil {
 		return schema.OperationStatus{}, fmt.Errorf("failed to upgrade Runtime: %w", err)
 	}
 	return res, nil
 }
 
-func (c *client) UpgradeShoot(accountID, runtimeID string, config schema.UpgradeShootInput) (schema.OperationStatus, error) {
+func (c *client) UpgradeShoot(ctx context.Context, accountID, runtimeID string, config schema.UpgradeShootInput) (schema.OperationStatus, error) {
 	upgradeShootIptGQL, err := c.graphqlizer.UpgradeShootInputToGraphQL(config)
 	if err != nil {
 		return schema.OperationStatus{}, fmt.Errorf("failed to convert Upgrade Shoot Input to query: %w", err)
@@ -113,53 +117,66 @@ func (c *client) UpgradeShoot(accountID, runtimeID string, config schema.Upgrade
 	req.Header.Add(accountIDKey, accountID)
 
 	var res schema.OperationStatus
-	err = c.executeRequest(req, &res)
+	err = c.executeRequest(ctx, req, &res)
 	if err != nil {
 		return schema.OperationStatus{}, fmt.Errorf("failed to upgrade Shoot: %w", err)
 	}
 	return res, nil
 }
 
-func (c *client) ReconnectRuntimeAgent(accountID, runtimeID string) (string, error) {
+func (c *client) ReconnectRuntimeAgent(ctx context.Context, accountID, runtimeID string) (string, error) {
 	query := c.queryProvider.reconnectRuntimeAgent(runtimeID)
 	req := gcli.NewRequest(query)
 	req.Header.Add(accountIDKey, accountID)
 
 	var operationId string
-	err := c.executeRequest(req, &operationId)
+	err := c.executeRequest(ctx, req, &operationId)
 	if err != nil {
 		return "", fmt.Errorf("failed to reconnect Runtime agent: %w", err)
 	}
 	return operationId, nil
 }
 
-func (c *client) RuntimeOperationStatus(accountID, operationID string) (schema.OperationStatus, error) {
+func (c *client) HibernateRuntime(ctx context.Context, accountID, runtimeID string) (schema.OperationStatus, error) {
+	query := c.queryProvider.hibernateRuntime(runtimeID)
+	req := gcli.NewRequest(query)
+	req.Header.Add(accountIDKey, accountID)
+
+	var res schema.OperationStatus
+	err := c.executeRequest(ctx, req, &res)
+	if err != nil {
+		return schema.OperationStatus{}, fmt.Errorf("failed to hibernate Runtime: %w", err)
+	}
+	return res, nil
+}
+
+func (c *client) RuntimeOperationStatus(ctx context.Context, accountID, operationID string) (schema.OperationStatus, error) {
 	query := c.queryProvider.runtimeOperationStatus(operationID)
 	req := gcli.NewRequest(query)
 	req.Header.Add(accountIDKey, accountID)
 
 	var response schema.OperationStatus
-	err := c.executeRequest(req, &response)
+	err := c.executeRequest(ctx, req, &response)
 	if err != nil {
 		return schema.OperationStatus{}, fmt.Errorf("failed to get Runtime operation status: %w", err)
 	}
 	return response, nil
 }
 
-func (c *client) RuntimeStatus(accountID, runtimeID string) (schema.RuntimeStatus, error) {
+func (c *client) RuntimeStatus(ctx context.Context, accountID, runtimeID string) (schema.RuntimeStatus, error) {
 	query := c.queryProvider.runtimeStatus(runtimeID)
 	req := gcli.NewRequest(query)
 	req.Header.Add(accountIDKey, accountID)
 
 	var response schema.RuntimeStatus
-	err := c.executeRequest(req, &response)
+	err := c.executeRequest(ctx, req, &response)
 	if err != nil {
 		return schema.RuntimeStatus{}, fmt.Errorf("failed to get Runtime status: %w", err)
 	}
 	return response, nil
 }
 
-func (c *client) executeRequest(req *gcli.Request, respDestination interface{}) error {
+func (c *client) executeRequest(ctx context.Context, req *gcli.Request, respDestination interface{}) error {
 	if reflect.ValueOf(respDestination).Kind() != reflect.Ptr {
 		return fmt.Errorf("destination is not of pointer type")
 	}
@@ -169,7 +186,7 @@ func (c *client) executeRequest(req *gcli.Request, respDestination interface{})
 	}
 
 	wrapper := &graphQLResponseWrapper{Result: respDestination}
-	err := c.graphQLClient.Run(context.TODO(), req, wrapper)
+	err := c.graphQLClient.Run(ctx, req, wrapper)
 	switch {
 	case isNotFoundError(err):
 		return kebError.NotFoundError{}
@@ -23,6 +23,7 @@ const (
 	provisionRuntimeOperationID   = "c89f7862-0ef9-4d4e-bc82-afbc5ac98b8d"
 	upgradeRuntimeOperationID     = "74f47e0a-9a76-4336-9974-70705500a981"
 	deprovisionRuntimeOperationID = "f9f7b734-7538-419c-8ac1-37060c60531a"
+	hibernateRuntimeOperationID   = "0bd5bbf8-1a9e-4c5f-9b38-1d4e4c0f8a6e"
 )
 
 var (
@@ -45,7 +46,7 @@ func TestClient_ProvisionRuntime(t *testing.T) {
 		client := NewProvisionerClient(testServer.URL, false)
 
 		// When
-		status, err := client.ProvisionRuntime(testAccountID, testSubAccountID, fixProvisionRuntimeInput())
+		status, err := client.ProvisionRuntime(context.Background(), testAccountID, testSubAccountID, fixProvisionRuntimeInput())
 
 		// Then
 		assert.NoError(t, err)
@@ -65,7 +66,7 @@ func TestClient_ProvisionRuntime(t *testing.T) {
 		client := NewProvisionerClient(testServer.URL, false)
 
 		// When
-		status, err := client.ProvisionRuntime(testAccountID, testSubAccountID, fixProvisionRuntimeInputWithoutDnsConfig())
+		status, err := client.ProvisionRuntime(context.Background(), testAccountID, testSubAccountID, fixProvisionRuntimeInputWithoutDnsConfig())
 
 		// Then
 		assert.NoError(t, err)
@@ -85,7 +86,7 @@ func TestClient_ProvisionRuntime(t *testing.T) {
 		client := NewProvisionerClient(testServer.URL, false)
 
 		// When
-		status, err := client.ProvisionRuntime(testAccountID, testSubAccountID, fixProvisionRuntimeInput())
+		status, err := client.ProvisionRuntime(context.Background(), testAccountID, testSubAccountID, fixProvisionRuntimeInput())
 
 		// Then
 		assert.Error(t, err)
@@ -103,11 +104,11 @@ func TestClient_DeprovisionRuntime(t *testing.T) {
 		defer testServer.Close()
 
 		client := NewProvisionerClient(testServer.URL, false)
-		operation, err := client.ProvisionRuntime(testAccountID, testSubAccountID, fixProvisionRuntimeInput())
+		operation, err := client.ProvisionRuntime(context.Background(), testAccountID, testSubAccountID, fixProvisionRuntimeInput())
 		assert.NoError(t, err)
 
 		// When
-		operationId, err := client.DeprovisionRuntime(testAccountID, *operation.RuntimeID)
+		operationId, err := client.DeprovisionRuntime(context.Background(), testAccountID, *operation.RuntimeID)
 
 		// Then
 		assert.NoError(t, err)
@@ -123,13 +124,13 @@ func TestClient_DeprovisionRuntime(t *testing.T) {
 		defer testServer.Close()
 
 		client := NewProvisionerClient(testServer.URL, false)
-		operation, err := client.ProvisionRuntime(testAccountID, testSubAccountID, fixProvisionRuntimeInput())
+		operation, err := client.ProvisionRuntime(context.Background(), testAccountID, testSubAccountID, fixProvisionRuntimeInput())
 		assert.NoError(t, err)
 
 		tr.failed = true
 
 		// When
-		operationId, err := client.DeprovisionRuntime(testAccountID, *operation.RuntimeID)
+		operationId, err := client.DeprovisionRuntime(context.Background(), testAccountID, *operation.RuntimeID)
 
 		// Then
 		assert.Error(t, err)
@@ -147,11 +148,11 @@ func TestClient_UpgradeRuntime(t *testing.T) {
 		defer testServer.Close()
 
 		client := NewProvisionerClient(testServer.URL, false)
-		operation, err := client.ProvisionRuntime(testAccountID, testSubAccountID, fixProvisionRuntimeInput())
+		operation, err := client.ProvisionRuntime(context.Background(), testAccountID, testSubAccountID, fixProvisionRuntimeInput())
 		assert.NoError(t, err)
 
 		// when
-		status, err := client.UpgradeRuntime(testAccountID, *operation.RuntimeID, fixUpgradeRuntimeInput("1.14.0"))
+		status, err := client.UpgradeRuntime(context.Background(), testAccountID, *operation.RuntimeID, fixUpgradeRuntimeInput("1.14.0"))
 
 		// then
 		assert.NoError(t, err)
@@ -168,13 +169,13 @@ func TestClient_UpgradeRuntime(t *testing.T) {
 		defer testServer.Close()
 
 		client := NewProvisionerClient(testServer.URL, false)
-		operation, err := client.ProvisionRuntime(testAccountID, testSubAccountID, fixProvisionRuntimeInput())
+		operation, err := client.ProvisionRuntime(context.Background(), testAccountID, testSubAccountID, fixProvisionRuntimeInput())
 		assert.NoError(t, err)
 
 		tr.failed = true
 
 		// when
-		status, err := client.UpgradeRuntime(testAccountID, *operation.RuntimeID, fixUpgradeRuntimeInput("1.14.0"))
+		status, err := client.UpgradeRuntime(context.Background(), testAccountID, *operation.RuntimeID, fixUpgradeRuntimeInput("1.14.0"))
 
 		// Then
 		assert.Error(t, err)
@@ -192,11 +193,11 @@ func TestClient_UpgradeShoot(t *testing.T) {
 		defer testServer.Close()
 
 		client := NewProvisionerClient(testServer.URL, false)
-		operation, err := client.ProvisionRuntime(testAccountID, testSubAccountID, fixProvisionRuntimeInput())
+		operation, err := client.ProvisionRuntime(context.Background(), testAccountID, testSubAccountID, fixProvisionRuntimeInput())
 		assert.NoError(t, err)
 
 		// when
-		status, err := client.UpgradeShoot(testAccountID, *operation.RuntimeID, fixUpgradeShootInput())
+		status, err := client.UpgradeShoot(context.Background(), testAccountID, *operation.RuntimeID, fixUpgradeShootInput())
 
 		// then
 		assert.NoError(t, err)
@@ -213,13 +214,13 @@ func TestClient_UpgradeShoot(t *testing.T) {
 		defer testServer.Close()
 
 		client := NewProvisionerClient(testServer.URL, false)
-		operation, err := client.ProvisionRuntime(testAccountID, testSubAccountID, fixProvisionRuntimeInput())
+		operation, err := client.ProvisionRuntime(context.Background(), testAccountID, testSubAccountID, fixProvisionRuntimeInput())
 		assert.NoError(t, err)
 
 		tr.failed = true
 
 		// when
-		status, err := client.UpgradeShoot(testAccountID, *operation.RuntimeID, fixUpgradeShootInput())
+		status, err := client.UpgradeShoot(context.Background(), testAccountID, *operation.RuntimeID, fixUpgradeShootInput())
 
 		// Then
 		assert.Error(t, err)
@@ -229,6 +230,42 @@ func TestClient_UpgradeShoot(t *testing.T) {
 	})
 }
 
+func TestClient_HibernateRuntime(t *testing.T) {
+	t.Run("should trigger runtime hibernation", func(t *testing.T) {
+		// given
+		tr := &testResolver{t: t, runtime: &testRuntime{}}
+		testServer := fixHTTPServer(tr)
+		defer testServer.Close()
+
+		client := NewProvisionerClient(testServer.URL, false)
+
+		// when
+		status, err := client.HibernateRuntime(context.Background(), testAccountID, provisionRuntimeID)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, ptr.String(hibernateRuntimeOperationID), status.ID)
+		assert.Equal(t, schema.OperationTypeHibernate, status.Operation)
+		assert.Equal(t, ptr.String(provisionRuntimeID), status.RuntimeID)
+	})
+
+	t.Run("provisioner should return error", func(t *testing.T) {
+		// given
+		tr := &testResolver{t: t, runtime: &testRuntime{}, failed: true}
+		testServer := fixHTTPServer(tr)
+		defer testServer.Close()
+
+		client := NewProvisionerClient(testServer.URL, false)
+
+		// when
+		status, err := client.HibernateRuntime(context.Background(), testAccountID, provisionRuntimeID)
+
+		// then
+		assert.Error(t, err)
+		assert.Empty(t, status)
+	})
+}
+
 func TestClient_ReconnectRuntimeAgent(t *testing.T) {
 	t.Run("should reconnect runtime agent", func(t *testing.T) {
 		// Given
@@ -237,11 +274,11 @@ func TestClient_ReconnectRuntimeAgent(t *testing.T) {
 		defer testServer.Close()
 
 		client := NewProvisionerClient(testServer.URL, false)
-		operation, err := client.ProvisionRuntime(testAccountID, testSubAccountID, fixProvisionRuntimeInput())
+		operation, err := client.ProvisionRuntime(context.Background(), testAccountID, testSubAccountID, fixProvisionRuntimeInput())
 		assert.NoError(t, err)
 
 		// When
-		operationId, err := client.ReconnectRuntimeAgent(testAccountID, *operation.RuntimeID)
+		operationId, err := client.ReconnectRuntimeAgent(context.Background(), testAccountID, *operation.RuntimeID)
 
 		// Then
 		assert.NoError(t, err)
@@ -255,13 +292,13 @@ func TestClient_ReconnectRuntimeAgent(t *testing.T) {
 		defer testServer.Close()
 
 		client := NewProvisionerClient(testServer.URL, false)
-		operation, err := client.ProvisionRuntime(testAccountID, testSubAccountID, fixProvisionRuntimeInput())
+		operation, err := client.ProvisionRuntime(context.Background(), testAccountID, testSubAccountID, fixProvisionRuntimeInput())
 		assert.NoError(t, err)
 
 		tr.failed = true
 
 		// When
-		operationId, err := client.ReconnectRuntimeAgent(testAccountID, *operation.RuntimeID)
+		operationId, err := client.ReconnectRuntimeAgent(context.Background(), testAccountID, *operation.RuntimeID)
 
 		// Then
 		assert.Error(t, err)
@@ -292,7 +329,7 @@ func TestClient_ReconnectRuntimeAgent(t *testing.T) {
 		client := NewProvisionerClient(server.URL, false)
 
 		// when
-		_, err := client.ProvisionRuntime(testAccountID, testSubAccountID, fixProvisionRuntimeInput())
+		_, err := client.ProvisionRuntime(context.Background(), testAccountID, testSubAccountID, fixProvisionRuntimeInput())
 		lastErr := kebError.ReasonForError(err)
 
 		// Then
@@ -326,7 +363,7 @@ func TestClient_ReconnectRuntimeAgent(t *testing.T) {
 		client := NewProvisionerClient(server.URL, false)
 
 		// when
-		_, err := client.ProvisionRuntime(testAccountID, testSubAccountID, fixProvisionRuntimeInput())
+		_, err := client.ProvisionRuntime(context.Background(), testAccountID, testSubAccountID, fixProvisionRuntimeInput())
 		lastErr := kebError.ReasonForError(err)
 
 		// Then
@@ -340,7 +377,7 @@ func TestClient_ReconnectRuntimeAgent(t *testing.T) {
 		client := NewProvisionerClient("http://not-existing", false)
 
 		// when
-		_, err := client.ProvisionRuntime(testAccountID, testSubAccountID, fixProvisionRuntimeInput())
+		_, err := client.ProvisionRuntime(context.Background(), testAccountID, testSubAccountID, fixProvisionRuntimeInput())
 
 		// Then
 		assert.Error(t, err)
@@ -356,11 +393,11 @@ func TestClient_RuntimeOperationStatus(t *testing.T) {
 		defer testServer.Close()
 
 		client := NewProvisionerClient(testServer.URL, false)
-		_, err := client.ProvisionRuntime(testAccountID, testSubAccountID, fixProvisionRuntimeInput())
+		_, err := client.ProvisionRuntime(context.Background(), testAccountID, testSubAccountID, fixProvisionRuntimeInput())
 		assert.NoError(t, err)
 
 		// When
-		status, err := client.RuntimeOperationStatus(testAccountID, provisionRuntimeID)
+		status, err := client.RuntimeOperationStatus(context.Background(), testAccountID, provisionRuntimeID)
 
 		// Then
 		assert.NoError(t, err)
@@ -377,13 +414,13 @@ func TestClient_RuntimeOperationStatus(t *testing.T) {
 		defer testServer.Close()
 
 		client := NewProvisionerClient(testServer.URL, false)
-		_, err := client.ProvisionRuntime(testAccountID, testSubAccountID, fixProvisionRuntimeInput())
+		_, err := client.ProvisionRuntime(context.Background(), testAccountID, testSubAccountID, fixProvisionRuntimeInput())
 		assert.NoError(t, err)
 
 		tr.failed = true
 
 		// When
-		status, err := client.RuntimeOperationStatus(testAccountID, provisionRuntimeID)
+		status, err := client.RuntimeOperationStatus(context.Background(), testAccountID, provisionRuntimeID)
 
 		// Then
 		assert.Error(t, err)
@@ -569,8 +606,19 @@ func (tmr testMutationResolver) DeprovisionRuntime(_ context.Context, id string)
 	return tmr.runtime.deprovisionOperationID, nil
 }
 
-func (tmr testMutationResolver) HibernateRuntime(ctx context.Context, id string) (*schema.OperationStatus, error) {
-	return nil, fmt.Errorf("not implemented")
+func (tmr testMutationResolver) HibernateRuntime(_ context.Context, id string) (*schema.OperationStatus, error) {
+	tmr.t.Log("HibernateRuntime testMutationResolver")
+
+	if tmr.failed {
+		return nil, fmt.Errorf("hibernate runtime failed for %s", id)
+	}
+
+	return &schema.OperationStatus{
+		ID:        ptr.String(hibernateRuntimeOperationID),
+		State:     schema.OperationStateInProgress,
+		Operation: schema.OperationTypeHibernate,
+		RuntimeID: ptr.String(id),
+	}, nil
 }
 
 func (tmr testMutationResolver) RollBackUpgradeOperation(_ context.Context, id string) (*schema.RuntimeStatus, error) {
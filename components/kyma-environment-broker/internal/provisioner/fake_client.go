@@ -103,7 +103,7 @@ func (c *FakeClient) SetOperation(id string, operation schema.OperationStatus) {
 
 // Provisioner Client methods
 
-func (c *FakeClient) ProvisionRuntime(accountID, subAccountID string, config schema.ProvisionRuntimeInput) (schema.OperationStatus, error) {
+func (c *FakeClient) ProvisionRuntime(ctx context.Context, accountID, subAccountID string, config schema.ProvisionRuntimeInput) (schema.OperationStatus, error) {
 	rid := uuid.New().String()
 	opId := uuid.New().String()
 
@@ -169,7 +169,7 @@ func (c *FakeClient) ProvisionRuntimeWithIDs(accountID, subAccountID, runtimeID,
 	}, nil
 }
 
-func (c *FakeClient) DeprovisionRuntime(accountID, runtimeID string) (string, error) {
+func (c *FakeClient) DeprovisionRuntime(ctx context.Context, accountID, runtimeID string) (string, error) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
@@ -185,11 +185,27 @@ func (c *FakeClient) DeprovisionRuntime(accountID, runtimeID string) (string, er
 	return opId, nil
 }
 
-func (c *FakeClient) ReconnectRuntimeAgent(accountID, runtimeID string) (string, error) {
+func (c *FakeClient) HibernateRuntime(ctx context.Context, accountID, runtimeID string) (schema.OperationStatus, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	opId := uuid.New().String()
+	operation := schema.OperationStatus{
+		ID:        &opId,
+		Operation: schema.OperationTypeHibernate,
+		State:     schema.OperationStateInProgress,
+		RuntimeID: &runtimeID,
+	}
+	c.operations[opId] = operation
+
+	return operation, nil
+}
+
+func (c *FakeClient) ReconnectRuntimeAgent(ctx context.Context, accountID, runtimeID string) (string, error) {
 	return "", fmt.Errorf("not implemented")
 }
 
-func (c *FakeClient) RuntimeOperationStatus(accountID, operationID string) (schema.OperationStatus, error) {
+func (c *FakeClient) RuntimeOperationStatus(ctx context.Context, accountID, operationID string) (schema.OperationStatus, error) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
@@ -200,7 +216,7 @@ func (c *FakeClient) RuntimeOperationStatus(accountID, operationID string) (sche
 	return o, nil
 }
 
-func (c *FakeClient) RuntimeStatus(accountID, runtimeID string) (schema.RuntimeStatus, error) {
+func (c *FakeClient) RuntimeStatus(ctx context.Context, accountID, runtimeID string) (schema.RuntimeStatus, error) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
@@ -222,7 +238,7 @@ func (c *FakeClient) RuntimeStatus(accountID, runtimeID string) (schema.RuntimeS
 	return schema.RuntimeStatus{}, errors.New("no status for given runtime id")
 }
 
-func (c *FakeClient) UpgradeRuntime(accountID, runtimeID string, config schema.UpgradeRuntimeInput) (schema.OperationStatus, error) {
+func (c *FakeClient) UpgradeRuntime(ctx context.Context, accountID, runtimeID string, config schema.UpgradeRuntimeInput) (schema.OperationStatus, error) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
@@ -245,7 +261,7 @@ func (c *FakeClient) UpgradeRuntime(accountID, runtimeID string, config schema.U
 	}, nil
 }
 
-func (c *FakeClient) UpgradeShoot(accountID, runtimeID string, config schema.UpgradeShootInput) (schema.OperationStatus, error) {
+func (c *FakeClient) UpgradeShoot(ctx context.Context, accountID, runtimeID string, config schema.UpgradeShootInput) (schema.OperationStatus, error) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
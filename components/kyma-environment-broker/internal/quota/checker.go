@@ -0,0 +1,196 @@
+package quota
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbmodel"
+	"github.com/pivotal-cf/brokerapi/v8/domain/apiresponses"
+	"github.com/sirupsen/logrus"
+)
+
+// AnyGlobalAccount is the global account ID of the default quotas which apply to the global accounts without own quota
+const AnyGlobalAccount = "*"
+
+// Usage holds the resources used or requested by the instances of a global account
+type Usage struct {
+	Instances     int
+	AutoScalerMax int
+	VolumeSizeGb  int
+}
+
+func (u Usage) add(other Usage) Usage {
+	return Usage{
+		Instances:     u.Instances + other.Instances,
+		AutoScalerMax: u.AutoScalerMax + other.AutoScalerMax,
+		VolumeSizeGb:  u.VolumeSizeGb + other.VolumeSizeGb,
+	}
+}
+
+// Checker enforces the quotas when the instances are provisioned or updated, it implements broker.QuotaChecker
+type Checker struct {
+	quotas       storage.Quotas
+	instances    storage.Instances
+	planDefaults broker.PlanDefaults
+	planRegistry *broker.PlanRegistry
+	log          logrus.FieldLogger
+}
+
+func NewChecker(quotas storage.Quotas, instances storage.Instances, planDefaults broker.PlanDefaults, planRegistry *broker.PlanRegistry, log logrus.FieldLogger) *Checker {
+	return &Checker{
+		quotas:       quotas,
+		instances:    instances,
+		planDefaults: planDefaults,
+		planRegistry: planRegistry,
+		log:          log.WithField("service", "QuotaChecker"),
+	}
+}
+
+// CheckProvisioning returns a 422 failure response if a new instance with the given resources exceeds a quota
+func (c *Checker) CheckProvisioning(globalAccountID, planID string, autoScalerMax, volumeSizeGb int) error {
+	return c.check(globalAccountID, planID, Usage{Instances: 1, AutoScalerMax: autoScalerMax, VolumeSizeGb: volumeSizeGb})
+}
+
+// CheckUpdate returns a 422 failure response if the updated parameters of the instance raise the autoscaler maximum
+// or the volume size over a quota, lowering them is always allowed
+func (c *Checker) CheckUpdate(instance internal.Instance, parameters internal.ProvisioningParametersDTO) error {
+	current := c.instanceUsage(instance)
+	instance.Parameters.Parameters = parameters
+	updated := c.instanceUsage(instance)
+
+	requested := Usage{
+		AutoScalerMax: increase(current.AutoScalerMax, updated.AutoScalerMax),
+		VolumeSizeGb:  increase(current.VolumeSizeGb, updated.VolumeSizeGb),
+	}
+	if requested == (Usage{}) {
+		return nil
+	}
+	return c.check(instance.GlobalAccountID, instance.ServicePlanID, requested)
+}
+
+// Lock serializes the quota checks and the instance changes of the global account in all KEB replicas until the returned
+// function is called, the instance must be stored before unlocking, otherwise a concurrent request is checked without it
+func (c *Checker) Lock(globalAccountID string) (unlock func(), err error) {
+	return c.quotas.Lock(globalAccountID)
+}
+
+func (c *Checker) check(globalAccountID, planID string, requested Usage) error {
+	planName := c.planRegistry.PlanNames()[planID]
+	for _, selector := range []string{planName, broker.AllPlansSelector} {
+		quota, found, err := c.EffectiveQuota(globalAccountID, selector)
+		if err != nil {
+			return fmt.Errorf("while getting quota: %w", err)
+		}
+		if !found {
+			continue
+		}
+		usage, err := c.Usage(globalAccountID, selector)
+		if err != nil {
+			return fmt.Errorf("while calculating quota usage: %w", err)
+		}
+		if violations := exceeded(quota, usage.add(requested), requested); len(violations) > 0 {
+			err := fmt.Errorf("quota exceeded for global account %s in %s: %s", globalAccountID, describePlan(selector), strings.Join(violations, ", "))
+			c.log.Info(err.Error())
+			return apiresponses.NewFailureResponse(err, http.StatusUnprocessableEntity, "quota")
+		}
+	}
+	return nil
+}
+
+// EffectiveQuota returns the quota of the global account for the plan name or the all_plans selector,
+// the default quota is returned if the global account has no own quota
+func (c *Checker) EffectiveQuota(globalAccountID, planName string) (internal.Quota, bool, error) {
+	for _, id := range []string{globalAccountID, AnyGlobalAccount} {
+		quota, err := c.quotas.Get(id, planName)
+		switch {
+		case err == nil:
+			return quota, true, nil
+		case !dberr.IsNotFound(err):
+			return internal.Quota{}, false, err
+		}
+	}
+	return internal.Quota{}, false, nil
+}
+
+// Usage sums the resources of the not expired instances of the global account in the plan or in all plans
+func (c *Checker) Usage(globalAccountID, planName string) (Usage, error) {
+	filter := dbmodel.InstanceFilter{GlobalAccountIDs: []string{globalAccountID}, Expired: &[]bool{false}[0]}
+	if planName != broker.AllPlansSelector {
+		filter.Plans = []string{planName}
+	}
+	instances, _, _, err := c.instances.List(filter)
+	if err != nil {
+		return Usage{}, err
+	}
+
+	usage := Usage{}
+	for _, instance := range instances {
+		if instance.IsExpired() {
+			continue
+		}
+		usage = usage.add(c.instanceUsage(instance))
+	}
+	return usage, nil
+}
+
+// instanceUsage returns the resources of the instance, the plan defaults are used for the parameters which are not set
+func (c *Checker) instanceUsage(instance internal.Instance) Usage {
+	usage := Usage{Instances: 1}
+	parameters := instance.Parameters.Parameters
+	if parameters.AutoScalerMax != nil {
+		usage.AutoScalerMax = *parameters.AutoScalerMax
+	}
+	if parameters.VolumeSizeGb != nil {
+		usage.VolumeSizeGb = *parameters.VolumeSizeGb
+	}
+	if parameters.AutoScalerMax != nil && parameters.VolumeSizeGb != nil {
+		return usage
+	}
+
+	defaults, err := c.planDefaults(instance.ServicePlanID, instance.Parameters.PlatformProvider, parameters.Provider)
+	if err != nil || defaults.GardenerConfig == nil {
+		c.log.Warnf("unable to obtain plan defaults of instance %s, only the instance parameters are counted", instance.InstanceID)
+		return usage
+	}
+	if parameters.AutoScalerMax == nil {
+		usage.AutoScalerMax = defaults.GardenerConfig.AutoScalerMax
+	}
+	if parameters.VolumeSizeGb == nil && defaults.GardenerConfig.VolumeSizeGb != nil {
+		usage.VolumeSizeGb = *defaults.GardenerConfig.VolumeSizeGb
+	}
+	return usage
+}
+
+// exceeded returns the violated limits of the quota, only the requested resources are checked
+func exceeded(quota internal.Quota, usage, requested Usage) []string {
+	var violations []string
+	if quota.MaxInstances != nil && requested.Instances > 0 && usage.Instances > *quota.MaxInstances {
+		violations = append(violations, fmt.Sprintf("%d instances requested, %d allowed", usage.Instances, *quota.MaxInstances))
+	}
+	if quota.MaxAutoScalerMax != nil && requested.AutoScalerMax > 0 && usage.AutoScalerMax > *quota.MaxAutoScalerMax {
+		violations = append(violations, fmt.Sprintf("%d autoscaler maximum nodes requested in total, %d allowed", usage.AutoScalerMax, *quota.MaxAutoScalerMax))
+	}
+	if quota.MaxVolumeSizeGb != nil && requested.VolumeSizeGb > 0 && usage.VolumeSizeGb > *quota.MaxVolumeSizeGb {
+		violations = append(violations, fmt.Sprintf("%d GB volume size requested in total, %d GB allowed", usage.VolumeSizeGb, *quota.MaxVolumeSizeGb))
+	}
+	return violations
+}
+
+func increase(current, updated int) int {
+	if updated > current {
+		return updated - current
+	}
+	return 0
+}
+
+func describePlan(planName string) string {
+	if planName == broker.AllPlansSelector {
+		return "all plans"
+	}
+	return fmt.Sprintf("plan %s", planName)
+}
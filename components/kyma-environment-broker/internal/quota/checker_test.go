@@ -0,0 +1,238 @@
+package quota_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/ptr"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/quota"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
+	"github.com/pivotal-cf/brokerapi/v8/domain/apiresponses"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	globalAccountID = "ga-1"
+	otherAccountID  = "ga-2"
+)
+
+func TestChecker_CheckProvisioning(t *testing.T) {
+	t.Run("should allow provisioning without quota", func(t *testing.T) {
+		// given
+		db := storage.NewMemoryStorage()
+		checker := quota.NewChecker(db.Quotas(), db.Instances(), fixPlanDefaults, nil, logrus.New())
+		insertInstance(t, db, "i-1", globalAccountID, broker.AWSPlanID, nil)
+
+		// when
+		err := checker.CheckProvisioning(globalAccountID, broker.AWSPlanID, 10, 50)
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("should reject provisioning over the instances quota", func(t *testing.T) {
+		// given
+		db := storage.NewMemoryStorage()
+		checker := quota.NewChecker(db.Quotas(), db.Instances(), fixPlanDefaults, nil, logrus.New())
+		require.NoError(t, db.Quotas().Upsert(internal.Quota{GlobalAccountID: globalAccountID, PlanName: broker.AWSPlanName, MaxInstances: ptr.Integer(1)}))
+		insertInstance(t, db, "i-1", globalAccountID, broker.AWSPlanID, nil)
+		insertInstance(t, db, "i-2", otherAccountID, broker.AWSPlanID, nil)
+
+		// when
+		err := checker.CheckProvisioning(globalAccountID, broker.AWSPlanID, 10, 50)
+		otherErr := checker.CheckProvisioning(otherAccountID, broker.AWSPlanID, 10, 50)
+		otherPlanErr := checker.CheckProvisioning(globalAccountID, broker.AzurePlanID, 10, 50)
+
+		// then
+		require.Error(t, err)
+		assertUnprocessableEntity(t, err)
+		assert.Contains(t, err.Error(), "plan aws: 2 instances requested, 1 allowed")
+		assert.NoError(t, otherErr)
+		assert.NoError(t, otherPlanErr)
+	})
+
+	t.Run("should apply the default quota to global accounts without own quota", func(t *testing.T) {
+		// given
+		db := storage.NewMemoryStorage()
+		checker := quota.NewChecker(db.Quotas(), db.Instances(), fixPlanDefaults, nil, logrus.New())
+		require.NoError(t, db.Quotas().Upsert(internal.Quota{GlobalAccountID: quota.AnyGlobalAccount, PlanName: broker.AllPlansSelector, MaxAutoScalerMax: ptr.Integer(20)}))
+		require.NoError(t, db.Quotas().Upsert(internal.Quota{GlobalAccountID: otherAccountID, PlanName: broker.AllPlansSelector, MaxAutoScalerMax: ptr.Integer(100)}))
+		insertInstance(t, db, "i-1", globalAccountID, broker.AWSPlanID, nil)
+		insertInstance(t, db, "i-2", otherAccountID, broker.AWSPlanID, nil)
+
+		// when
+		err := checker.CheckProvisioning(globalAccountID, broker.AzurePlanID, 11, 50)
+		allowedErr := checker.CheckProvisioning(globalAccountID, broker.AzurePlanID, 10, 50)
+		otherErr := checker.CheckProvisioning(otherAccountID, broker.AzurePlanID, 11, 50)
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "all plans: 21 autoscaler maximum nodes requested in total, 20 allowed")
+		assert.NoError(t, allowedErr)
+		assert.NoError(t, otherErr)
+	})
+
+	t.Run("should not count expired instances", func(t *testing.T) {
+		// given
+		db := storage.NewMemoryStorage()
+		checker := quota.NewChecker(db.Quotas(), db.Instances(), fixPlanDefaults, nil, logrus.New())
+		require.NoError(t, db.Quotas().Upsert(internal.Quota{GlobalAccountID: globalAccountID, PlanName: broker.TrialPlanName, MaxInstances: ptr.Integer(1)}))
+		insertInstance(t, db, "i-1", globalAccountID, broker.TrialPlanID, ptr.Time(time.Now()))
+
+		// when
+		err := checker.CheckProvisioning(globalAccountID, broker.TrialPlanID, 4, 50)
+
+		// then
+		assert.NoError(t, err)
+	})
+}
+
+func TestChecker_CheckUpdate(t *testing.T) {
+	t.Run("should check the autoscaler maximum", func(t *testing.T) {
+		// given
+		db := storage.NewMemoryStorage()
+		checker := quota.NewChecker(db.Quotas(), db.Instances(), fixPlanDefaults, nil, logrus.New())
+		require.NoError(t, db.Quotas().Upsert(internal.Quota{GlobalAccountID: globalAccountID, PlanName: broker.AWSPlanName, MaxAutoScalerMax: ptr.Integer(25)}))
+		instance := insertInstance(t, db, "i-1", globalAccountID, broker.AWSPlanID, nil)
+		insertInstance(t, db, "i-2", globalAccountID, broker.AWSPlanID, nil)
+
+		// when
+		raiseErr := checker.CheckUpdate(instance, fixUpdatedParameters(16, nil))
+		allowedErr := checker.CheckUpdate(instance, fixUpdatedParameters(15, nil))
+		lowerErr := checker.CheckUpdate(instance, fixUpdatedParameters(5, nil))
+
+		// then
+		require.Error(t, raiseErr)
+		assertUnprocessableEntity(t, raiseErr)
+		assert.NoError(t, allowedErr)
+		assert.NoError(t, lowerErr)
+	})
+
+	t.Run("should check the volume size", func(t *testing.T) {
+		// given
+		db := storage.NewMemoryStorage()
+		checker := quota.NewChecker(db.Quotas(), db.Instances(), fixPlanDefaults, nil, logrus.New())
+		require.NoError(t, db.Quotas().Upsert(internal.Quota{GlobalAccountID: globalAccountID, PlanName: broker.AllPlansSelector, MaxVolumeSizeGb: ptr.Integer(120)}))
+		instance := insertInstance(t, db, "i-1", globalAccountID, broker.AWSPlanID, nil)
+		insertInstance(t, db, "i-2", globalAccountID, broker.AzurePlanID, nil)
+
+		// when
+		raiseErr := checker.CheckUpdate(instance, fixUpdatedParameters(10, ptr.Integer(80)))
+		allowedErr := checker.CheckUpdate(instance, fixUpdatedParameters(10, ptr.Integer(70)))
+
+		// then
+		require.Error(t, raiseErr)
+		assertUnprocessableEntity(t, raiseErr)
+		assert.Contains(t, raiseErr.Error(), "all plans: 130 GB volume size requested in total, 120 GB allowed")
+		assert.NoError(t, allowedErr)
+	})
+
+	t.Run("should not reject an update which does not raise the exceeded resource", func(t *testing.T) {
+		// given
+		db := storage.NewMemoryStorage()
+		checker := quota.NewChecker(db.Quotas(), db.Instances(), fixPlanDefaults, nil, logrus.New())
+		require.NoError(t, db.Quotas().Upsert(internal.Quota{GlobalAccountID: globalAccountID, PlanName: broker.AWSPlanName, MaxVolumeSizeGb: ptr.Integer(10)}))
+		instance := insertInstance(t, db, "i-1", globalAccountID, broker.AWSPlanID, nil)
+
+		// when
+		err := checker.CheckUpdate(instance, fixUpdatedParameters(15, nil))
+
+		// then
+		assert.NoError(t, err)
+	})
+}
+
+func TestChecker_Lock(t *testing.T) {
+	// given
+	db := storage.NewMemoryStorage()
+	checker := quota.NewChecker(db.Quotas(), db.Instances(), fixPlanDefaults, nil, logrus.New())
+	unlock, err := checker.Lock(globalAccountID)
+	require.NoError(t, err)
+	locked := make(chan struct{})
+
+	// when
+	go func() {
+		unlock, err := checker.Lock(globalAccountID)
+		require.NoError(t, err)
+		defer unlock()
+		close(locked)
+	}()
+	unlockOther, err := checker.Lock(otherAccountID)
+	require.NoError(t, err)
+	unlockOther()
+
+	// then
+	select {
+	case <-locked:
+		t.Fatal("the lock of the global account was acquired twice")
+	case <-time.After(50 * time.Millisecond):
+	}
+	unlock()
+	select {
+	case <-locked:
+	case <-time.After(time.Second):
+		t.Fatal("the lock of the global account was not released")
+	}
+}
+
+func TestChecker_Usage(t *testing.T) {
+	// given
+	db := storage.NewMemoryStorage()
+	checker := quota.NewChecker(db.Quotas(), db.Instances(), fixPlanDefaults, nil, logrus.New())
+	instance := insertInstance(t, db, "i-1", globalAccountID, broker.AWSPlanID, nil)
+	instance.Parameters.Parameters.AutoScalerMax = ptr.Integer(30)
+	_, err := db.Instances().Update(instance)
+	require.NoError(t, err)
+	insertInstance(t, db, "i-2", globalAccountID, broker.AzurePlanID, nil)
+
+	// when
+	awsUsage, err := checker.Usage(globalAccountID, broker.AWSPlanName)
+	require.NoError(t, err)
+	allUsage, err := checker.Usage(globalAccountID, broker.AllPlansSelector)
+	require.NoError(t, err)
+
+	// then
+	assert.Equal(t, quota.Usage{Instances: 1, AutoScalerMax: 30, VolumeSizeGb: 50}, awsUsage)
+	assert.Equal(t, quota.Usage{Instances: 2, AutoScalerMax: 40, VolumeSizeGb: 100}, allUsage)
+}
+
+func insertInstance(t *testing.T, db storage.BrokerStorage, id, globalAccountID, planID string, expiredAt *time.Time) internal.Instance {
+	instance := internal.Instance{
+		InstanceID:      id,
+		GlobalAccountID: globalAccountID,
+		ServicePlanID:   planID,
+		ServicePlanName: broker.PlanNamesMapping[planID],
+		ExpiredAt:       expiredAt,
+		CreatedAt:       time.Now(),
+		Parameters:      internal.ProvisioningParameters{PlanID: planID},
+	}
+	require.NoError(t, db.Instances().Insert(instance))
+	return instance
+}
+
+func fixUpdatedParameters(autoScalerMax int, volumeSizeGb *int) internal.ProvisioningParametersDTO {
+	parameters := internal.ProvisioningParametersDTO{VolumeSizeGb: volumeSizeGb}
+	parameters.AutoScalerMax = ptr.Integer(autoScalerMax)
+	return parameters
+}
+
+func fixPlanDefaults(planID string, platformProvider internal.CloudProvider, provider *internal.CloudProvider) (*gqlschema.ClusterConfigInput, error) {
+	return &gqlschema.ClusterConfigInput{
+		GardenerConfig: &gqlschema.GardenerConfigInput{
+			AutoScalerMax: 10,
+			VolumeSizeGb:  ptr.Integer(50),
+		},
+	}, nil
+}
+
+func assertUnprocessableEntity(t *testing.T, err error) {
+	failure, ok := err.(*apiresponses.FailureResponse)
+	require.True(t, ok)
+	assert.Equal(t, http.StatusUnprocessableEntity, failure.ValidatedStatusCode(nil))
+}
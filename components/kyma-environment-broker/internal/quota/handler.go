@@ -0,0 +1,191 @@
+package quota
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/gorilla/mux"
+	pkg "github.com/kyma-project/control-plane/components/kyma-environment-broker/common/quota"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/httputil"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbmodel"
+	"github.com/sirupsen/logrus"
+)
+
+// Handler exposes the admin API which manages the quotas
+type Handler struct {
+	quotas  storage.Quotas
+	checker *Checker
+	log     logrus.FieldLogger
+}
+
+func NewHandler(quotas storage.Quotas, checker *Checker, log logrus.FieldLogger) *Handler {
+	return &Handler{
+		quotas:  quotas,
+		checker: checker,
+		log:     log.WithField("service", "QuotaHandler"),
+	}
+}
+
+func (h *Handler) AttachRoutes(router *mux.Router) {
+	router.HandleFunc("/quotas", h.listQuotas).Methods(http.MethodGet)
+	router.HandleFunc("/quotas/{global_account_id}/usage", h.getUsage).Methods(http.MethodGet)
+	router.HandleFunc("/quotas/{global_account_id}/{plan_name}", h.getQuota).Methods(http.MethodGet)
+	router.HandleFunc("/quotas/{global_account_id}/{plan_name}", h.setQuota).Methods(http.MethodPut)
+	router.HandleFunc("/quotas/{global_account_id}/{plan_name}", h.deleteQuota).Methods(http.MethodDelete)
+}
+
+func (h *Handler) listQuotas(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	quotas, err := h.quotas.List(dbmodel.QuotaFilter{
+		GlobalAccountIDs: query[pkg.GlobalAccountIDParam],
+		PlanNames:        query[pkg.PlanParam],
+	})
+	if err != nil {
+		h.log.Errorf("while listing quotas: %v", err)
+		httputil.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Errorf("while listing quotas: %w", err))
+		return
+	}
+
+	response := pkg.QuotaListDTO{Data: make([]pkg.QuotaDTO, 0, len(quotas)), Count: len(quotas)}
+	for _, quota := range quotas {
+		response.Data = append(response.Data, toDTO(quota))
+	}
+	httputil.WriteResponse(w, http.StatusOK, response)
+}
+
+func (h *Handler) getQuota(w http.ResponseWriter, r *http.Request) {
+	globalAccountID, planName := mux.Vars(r)["global_account_id"], mux.Vars(r)["plan_name"]
+
+	quota, err := h.quotas.Get(globalAccountID, planName)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if dberr.IsNotFound(err) {
+			status = http.StatusNotFound
+		}
+		httputil.WriteErrorResponse(w, status, fmt.Errorf("while getting quota: %w", err))
+		return
+	}
+	httputil.WriteResponse(w, http.StatusOK, toDTO(quota))
+}
+
+func (h *Handler) setQuota(w http.ResponseWriter, r *http.Request) {
+	globalAccountID, planName := mux.Vars(r)["global_account_id"], mux.Vars(r)["plan_name"]
+
+	var limits pkg.LimitsDTO
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&limits); err != nil {
+		httputil.WriteErrorResponse(w, http.StatusBadRequest, fmt.Errorf("while decoding quota limits: %w", err))
+		return
+	}
+	if err := validate(planName, limits, h.checker.planRegistry.PlanIDs()); err != nil {
+		httputil.WriteErrorResponse(w, http.StatusBadRequest, fmt.Errorf("while validating quota: %w", err))
+		return
+	}
+
+	quota := internal.Quota{
+		GlobalAccountID:  globalAccountID,
+		PlanName:         planName,
+		MaxInstances:     limits.MaxInstances,
+		MaxAutoScalerMax: limits.MaxAutoScalerMax,
+		MaxVolumeSizeGb:  limits.MaxVolumeSizeGb,
+	}
+	if err := h.quotas.Upsert(quota); err != nil {
+		h.log.Errorf("while saving quota for global account %s and plan %s: %v", globalAccountID, planName, err)
+		httputil.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Errorf("while saving quota: %w", err))
+		return
+	}
+	h.log.Infof("quota for global account %s and plan %s set to %+v", globalAccountID, planName, limits)
+
+	saved, err := h.quotas.Get(globalAccountID, planName)
+	if err != nil {
+		httputil.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Errorf("while getting quota: %w", err))
+		return
+	}
+	httputil.WriteResponse(w, http.StatusOK, toDTO(saved))
+}
+
+func (h *Handler) deleteQuota(w http.ResponseWriter, r *http.Request) {
+	globalAccountID, planName := mux.Vars(r)["global_account_id"], mux.Vars(r)["plan_name"]
+
+	if err := h.quotas.Delete(globalAccountID, planName); err != nil {
+		h.log.Errorf("while deleting quota for global account %s and plan %s: %v", globalAccountID, planName, err)
+		httputil.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Errorf("while deleting quota: %w", err))
+		return
+	}
+	h.log.Infof("quota for global account %s and plan %s deleted", globalAccountID, planName)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// getUsage returns the usage of every plan for which a quota is in effect for the global account
+func (h *Handler) getUsage(w http.ResponseWriter, r *http.Request) {
+	globalAccountID := mux.Vars(r)["global_account_id"]
+
+	quotas, err := h.quotas.List(dbmodel.QuotaFilter{GlobalAccountIDs: []string{globalAccountID, AnyGlobalAccount}})
+	if err != nil {
+		httputil.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Errorf("while listing quotas: %w", err))
+		return
+	}
+	planNames := map[string]struct{}{}
+	for _, quota := range quotas {
+		planNames[quota.PlanName] = struct{}{}
+	}
+
+	response := pkg.UsageListDTO{Data: make([]pkg.UsageDTO, 0, len(planNames))}
+	for planName := range planNames {
+		quota, _, err := h.checker.EffectiveQuota(globalAccountID, planName)
+		if err != nil {
+			httputil.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Errorf("while getting quota: %w", err))
+			return
+		}
+		usage, err := h.checker.Usage(globalAccountID, planName)
+		if err != nil {
+			h.log.Errorf("while calculating usage of global account %s: %v", globalAccountID, err)
+			httputil.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Errorf("while calculating usage: %w", err))
+			return
+		}
+		response.Data = append(response.Data, pkg.UsageDTO{
+			PlanName:      planName,
+			Quota:         toDTO(quota),
+			Instances:     usage.Instances,
+			AutoScalerMax: usage.AutoScalerMax,
+			VolumeSizeGb:  usage.VolumeSizeGb,
+		})
+	}
+	sort.Slice(response.Data, func(i, j int) bool { return response.Data[i].PlanName < response.Data[j].PlanName })
+	httputil.WriteResponse(w, http.StatusOK, response)
+}
+
+func validate(planName string, limits pkg.LimitsDTO, planIDs map[string]string) error {
+	if _, known := planIDs[planName]; !known && planName != broker.AllPlansSelector {
+		return fmt.Errorf("unknown plan %s", planName)
+	}
+	for name, limit := range map[string]*int{
+		"maxInstances":     limits.MaxInstances,
+		"maxAutoScalerMax": limits.MaxAutoScalerMax,
+		"maxVolumeSizeGb":  limits.MaxVolumeSizeGb,
+	} {
+		if limit != nil && *limit < 0 {
+			return fmt.Errorf("%s must not be negative", name)
+		}
+	}
+	return nil
+}
+
+func toDTO(quota internal.Quota) pkg.QuotaDTO {
+	return pkg.QuotaDTO{
+		GlobalAccountID:  quota.GlobalAccountID,
+		PlanName:         quota.PlanName,
+		MaxInstances:     quota.MaxInstances,
+		MaxAutoScalerMax: quota.MaxAutoScalerMax,
+		MaxVolumeSizeGb:  quota.MaxVolumeSizeGb,
+		CreatedAt:        quota.CreatedAt,
+		UpdatedAt:        quota.UpdatedAt,
+	}
+}
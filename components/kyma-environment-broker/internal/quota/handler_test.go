@@ -0,0 +1,97 @@
+package quota_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	pkg "github.com/kyma-project/control-plane/components/kyma-environment-broker/common/quota"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/quota"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler(t *testing.T) {
+	// given
+	db := storage.NewMemoryStorage()
+	checker := quota.NewChecker(db.Quotas(), db.Instances(), fixPlanDefaults, nil, logrus.New())
+	router := mux.NewRouter()
+	quota.NewHandler(db.Quotas(), checker, logrus.New()).AttachRoutes(router)
+	insertInstance(t, db, "i-1", globalAccountID, broker.AWSPlanID, nil)
+
+	t.Run("should set quota", func(t *testing.T) {
+		// when
+		rr := call(t, router, http.MethodPut, "/quotas/ga-1/aws", `{"maxInstances": 3, "maxAutoScalerMax": 30}`)
+
+		// then
+		require.Equal(t, http.StatusOK, rr.Code)
+		var dto pkg.QuotaDTO
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &dto))
+		assert.Equal(t, 3, *dto.MaxInstances)
+		assert.Nil(t, dto.MaxVolumeSizeGb)
+	})
+
+	t.Run("should reject invalid quota", func(t *testing.T) {
+		// when
+		unknownPlan := call(t, router, http.MethodPut, "/quotas/ga-1/unknown", `{"maxInstances": 3}`)
+		negative := call(t, router, http.MethodPut, "/quotas/ga-1/aws", `{"maxInstances": -1}`)
+
+		// then
+		assert.Equal(t, http.StatusBadRequest, unknownPlan.Code)
+		assert.Equal(t, http.StatusBadRequest, negative.Code)
+	})
+
+	t.Run("should list quotas", func(t *testing.T) {
+		// given
+		call(t, router, http.MethodPut, "/quotas/*/all_plans", `{"maxInstances": 10}`)
+
+		// when
+		rr := call(t, router, http.MethodGet, "/quotas?global_account_id=ga-1", "")
+
+		// then
+		require.Equal(t, http.StatusOK, rr.Code)
+		var list pkg.QuotaListDTO
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &list))
+		require.Equal(t, 1, list.Count)
+		assert.Equal(t, broker.AWSPlanName, list.Data[0].PlanName)
+	})
+
+	t.Run("should return usage", func(t *testing.T) {
+		// when
+		rr := call(t, router, http.MethodGet, "/quotas/ga-1/usage", "")
+
+		// then
+		require.Equal(t, http.StatusOK, rr.Code)
+		var usage pkg.UsageListDTO
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &usage))
+		require.Len(t, usage.Data, 2)
+		assert.Equal(t, broker.AllPlansSelector, usage.Data[0].PlanName)
+		assert.Equal(t, quota.AnyGlobalAccount, usage.Data[0].Quota.GlobalAccountID)
+		assert.Equal(t, broker.AWSPlanName, usage.Data[1].PlanName)
+		assert.Equal(t, 1, usage.Data[1].Instances)
+		assert.Equal(t, 10, usage.Data[1].AutoScalerMax)
+	})
+
+	t.Run("should delete quota", func(t *testing.T) {
+		// when
+		rr := call(t, router, http.MethodDelete, "/quotas/ga-1/aws", "")
+
+		// then
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+		assert.Equal(t, http.StatusNotFound, call(t, router, http.MethodGet, "/quotas/ga-1/aws", "").Code)
+	})
+}
+
+func call(t *testing.T, router *mux.Router, method, url, body string) *httptest.ResponseRecorder {
+	req, err := http.NewRequest(method, url, bytes.NewBufferString(body))
+	require.NoError(t, err)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	return rr
+}
@@ -2,6 +2,7 @@ package reconciler
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -17,11 +18,11 @@ import (
 //go:generate mockery --name=Client --output=automock --outpkg=automock --case=underscore
 
 type Client interface {
-	ApplyClusterConfig(cluster reconcilerApi.Cluster) (*reconcilerApi.HTTPClusterResponse, error)
-	DeleteCluster(clusterName string) error
-	GetCluster(clusterName string, configVersion int64) (*reconcilerApi.HTTPClusterResponse, error)
-	GetLatestCluster(clusterName string) (*reconcilerApi.HTTPClusterResponse, error)
-	GetStatusChange(clusterName, offset string) ([]*reconcilerApi.StatusChange, error)
+	ApplyClusterConfig(ctx context.Context, cluster reconcilerApi.Cluster) (*reconcilerApi.HTTPClusterResponse, error)
+	DeleteCluster(ctx context.Context, clusterName string) error
+	GetCluster(ctx context.Context, clusterName string, configVersion int64) (*reconcilerApi.HTTPClusterResponse, error)
+	GetLatestCluster(ctx context.Context, clusterName string) (*reconcilerApi.HTTPClusterResponse, error)
+	GetStatusChange(ctx context.Context, clusterName, offset string) ([]*reconcilerApi.StatusChange, error)
 }
 
 type Config struct {
@@ -44,7 +45,7 @@ func NewReconcilerClient(httpClient *http.Client, log logrus.FieldLogger, cfg *C
 }
 
 // POST /v1/clusters
-func (c *client) ApplyClusterConfig(cluster reconcilerApi.Cluster) (*reconcilerApi.HTTPClusterResponse, error) {
+func (c *client) ApplyClusterConfig(ctx context.Context, cluster reconcilerApi.Cluster) (*reconcilerApi.HTTPClusterResponse, error) {
 	reqBody, err := json.Marshal(cluster)
 	if err != nil {
 		c.log.Error(err)
@@ -53,7 +54,7 @@ func (c *client) ApplyClusterConfig(cluster reconcilerApi.Cluster) (*reconcilerA
 
 	reader := bytes.NewReader(reqBody)
 
-	request, err := http.NewRequest("POST", fmt.Sprintf("%s/v1/clusters", c.config.URL), reader)
+	request, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/v1/clusters", c.config.URL), reader)
 	if err != nil {
 		c.log.Error(err)
 		return &reconcilerApi.HTTPClusterResponse{}, err
@@ -92,8 +93,8 @@ func (c *client) ApplyClusterConfig(cluster reconcilerApi.Cluster) (*reconcilerA
 }
 
 // DELETE /v1/clusters/{clusterName}
-func (c *client) DeleteCluster(clusterName string) error {
-	request, err := http.NewRequest("DELETE", fmt.Sprintf("%s/v1/clusters/%s", c.config.URL, clusterName), nil)
+func (c *client) DeleteCluster(ctx context.Context, clusterName string) error {
+	request, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/v1/clusters/%s", c.config.URL, clusterName), nil)
 	if err != nil {
 		c.log.Error(err)
 		return err
@@ -118,8 +119,8 @@ func (c *client) DeleteCluster(clusterName string) error {
 }
 
 // GET /v1/clusters/{clusterName}/configs/{configVersion}/status
-func (c *client) GetCluster(clusterName string, configVersion int64) (*reconcilerApi.HTTPClusterResponse, error) {
-	request, err := http.NewRequest("GET", fmt.Sprintf("%s/v1/clusters/%s/configs/%d/status", c.config.URL, clusterName, configVersion), nil)
+func (c *client) GetCluster(ctx context.Context, clusterName string, configVersion int64) (*reconcilerApi.HTTPClusterResponse, error) {
+	request, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/v1/clusters/%s/configs/%d/status", c.config.URL, clusterName, configVersion), nil)
 	if err != nil {
 		c.log.Error(err)
 		return &reconcilerApi.HTTPClusterResponse{}, err
@@ -155,8 +156,8 @@ func (c *client) GetCluster(clusterName string, configVersion int64) (*reconcile
 }
 
 // GET v1/clusters/{clusterName}/status
-func (c *client) GetLatestCluster(clusterName string) (*reconcilerApi.HTTPClusterResponse, error) {
-	request, err := http.NewRequest("GET", fmt.Sprintf("%s/v1/clusters/%s/status", c.config.URL, clusterName), nil)
+func (c *client) GetLatestCluster(ctx context.Context, clusterName string) (*reconcilerApi.HTTPClusterResponse, error) {
+	request, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/v1/clusters/%s/status", c.config.URL, clusterName), nil)
 	if err != nil {
 		c.log.Error(err)
 		return &reconcilerApi.HTTPClusterResponse{}, err
@@ -185,8 +186,8 @@ func (c *client) GetLatestCluster(clusterName string) (*reconcilerApi.HTTPCluste
 
 // GET v1/clusters/{clusterName}/statusChanges/{offset}
 // offset is parsed to time.Duration
-func (c *client) GetStatusChange(clusterName, offset string) ([]*reconcilerApi.StatusChange, error) {
-	request, err := http.NewRequest("GET", fmt.Sprintf("%s/v1/clusters/%s/statusChanges/%s", c.config.URL, clusterName, offset), nil)
+func (c *client) GetStatusChange(ctx context.Context, clusterName, offset string) ([]*reconcilerApi.StatusChange, error) {
+	request, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/v1/clusters/%s/statusChanges/%s", c.config.URL, clusterName, offset), nil)
 	if err != nil {
 		c.log.Error(err)
 		return []*reconcilerApi.StatusChange{}, err
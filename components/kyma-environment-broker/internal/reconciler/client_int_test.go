@@ -4,6 +4,8 @@
 package reconciler
 
 import (
+	"context"
+
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
@@ -57,7 +59,7 @@ func TestClient_ReconcileCluster(t *testing.T) {
 	reqPayload := fixPayload(t)
 
 	// when
-	response, err := client.ApplyClusterConfig(*reqPayload)
+	response, err := client.ApplyClusterConfig(context.Background(), *reqPayload)
 
 	// then
 	if err != nil {
@@ -71,7 +73,7 @@ func TestClient_ReconcileCluster(t *testing.T) {
 		t.Error(err)
 	}
 	err = wait.PollImmediate(10*time.Second, 2*time.Minute, func() (done bool, err error) {
-		response, callErr := client.GetLatestCluster(reqPayload.Cluster)
+		response, callErr := client.GetLatestCluster(context.Background(), reqPayload.Cluster)
 		if callErr != nil {
 			return false, callErr
 		}
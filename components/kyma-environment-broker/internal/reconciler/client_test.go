@@ -1,6 +1,8 @@
 package reconciler
 
 import (
+	"context"
+
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -45,7 +47,7 @@ func Test_RegisterCluster(t *testing.T) {
 	client := NewReconcilerClient(http.DefaultClient, logrus.New().WithField("client", "reconciler"), &Config{URL: ts.URL})
 
 	// when
-	response, err := client.ApplyClusterConfig(*requestedCluster)
+	response, err := client.ApplyClusterConfig(context.Background(), *requestedCluster)
 
 	// then
 	require.NoError(t, err)
@@ -71,7 +73,7 @@ func Test_DeleteCluster(t *testing.T) {
 	client := NewReconcilerClient(http.DefaultClient, logrus.New().WithField("client", "reconciler"), &Config{URL: ts.URL})
 
 	// when
-	err := client.DeleteCluster(fixClusterID)
+	err := client.DeleteCluster(context.Background(), fixClusterID)
 
 	// then
 	require.NoError(t, err)
@@ -100,7 +102,7 @@ func Test_GetCluster(t *testing.T) {
 	client := NewReconcilerClient(http.DefaultClient, logrus.New().WithField("client", "reconciler"), &Config{URL: ts.URL})
 
 	// when
-	response, err := client.GetCluster(fixClusterID, fixConfigVersion)
+	response, err := client.GetCluster(context.Background(), fixClusterID, fixConfigVersion)
 
 	// then
 	require.NoError(t, err)
@@ -133,7 +135,7 @@ func Test_GetLatestCluster(t *testing.T) {
 	client := NewReconcilerClient(http.DefaultClient, logrus.New().WithField("client", "reconciler"), &Config{URL: ts.URL})
 
 	// when
-	response, err := client.GetLatestCluster(fixClusterID)
+	response, err := client.GetLatestCluster(context.Background(), fixClusterID)
 
 	// then
 	require.NoError(t, err)
@@ -172,7 +174,7 @@ func Test_GetStatusChange(t *testing.T) {
 	client := NewReconcilerClient(http.DefaultClient, logrus.New().WithField("client", "reconciler"), &Config{URL: ts.URL})
 
 	// when
-	response, err := client.GetStatusChange(fixClusterID, fixOffset)
+	response, err := client.GetStatusChange(context.Background(), fixClusterID, fixOffset)
 
 	// then
 	require.NoError(t, err)
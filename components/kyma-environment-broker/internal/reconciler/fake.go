@@ -1,6 +1,8 @@
 package reconciler
 
 import (
+	"context"
+
 	"fmt"
 	"sync"
 	"time"
@@ -38,12 +40,12 @@ func NewFakeClient() *FakeClient {
 }
 
 // POST /v1/clusters
-func (c *FakeClient) ApplyClusterConfig(cluster reconcilerApi.Cluster) (*reconcilerApi.HTTPClusterResponse, error) {
+func (c *FakeClient) ApplyClusterConfig(ctx context.Context, cluster reconcilerApi.Cluster) (*reconcilerApi.HTTPClusterResponse, error) {
 	return c.addToInventory(cluster)
 }
 
 // DELETE /v1/clusters/{clusterName}
-func (c *FakeClient) DeleteCluster(clusterName string) error {
+func (c *FakeClient) DeleteCluster(ctx context.Context, clusterName string) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	_, exists := c.inventoryClusters[clusterName]
@@ -55,7 +57,7 @@ func (c *FakeClient) DeleteCluster(clusterName string) error {
 }
 
 // GET /v1/clusters/{clusterName}/configs/{configVersion}/status
-func (c *FakeClient) GetCluster(clusterName string, configVersion int64) (*reconcilerApi.HTTPClusterResponse, error) {
+func (c *FakeClient) GetCluster(ctx context.Context, clusterName string, configVersion int64) (*reconcilerApi.HTTPClusterResponse, error) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
@@ -71,7 +73,7 @@ func (c *FakeClient) GetCluster(clusterName string, configVersion int64) (*recon
 }
 
 // GET v1/clusters/{clusterName}/status
-func (c *FakeClient) GetLatestCluster(clusterName string) (*reconcilerApi.HTTPClusterResponse, error) {
+func (c *FakeClient) GetLatestCluster(ctx context.Context, clusterName string) (*reconcilerApi.HTTPClusterResponse, error) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
@@ -86,7 +88,7 @@ func (c *FakeClient) GetLatestCluster(clusterName string) (*reconcilerApi.HTTPCl
 
 // GET v1/clusters/{clusterName}/statusChanges/{offset}
 // offset is parsed to time.Duration
-func (c *FakeClient) GetStatusChange(clusterName, offset string) ([]*reconcilerApi.StatusChange, error) {
+func (c *FakeClient) GetStatusChange(ctx context.Context, clusterName, offset string) ([]*reconcilerApi.StatusChange, error) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
@@ -0,0 +1,144 @@
+package recreation
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	pkg "github.com/kyma-project/control-plane/components/kyma-environment-broker/common/operation"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/httputil"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
+	"github.com/sirupsen/logrus"
+)
+
+// Queue is the deprovisioning queue which processes the deprovisioning operation of the recreation
+type Queue interface {
+	Add(processId string)
+}
+
+// Handler exposes the admin API which recreates the instances: the Runtime is deprovisioned without removing the instance,
+// then the Recreate_Instance deprovisioning step provisions the instance again with the same parameters
+type Handler struct {
+	instances           storage.Instances
+	operations          storage.Operations
+	deprovisioningQueue Queue
+	log                 logrus.FieldLogger
+}
+
+func NewHandler(instances storage.Instances, operations storage.Operations, deprovisioningQueue Queue, log logrus.FieldLogger) *Handler {
+	return &Handler{
+		instances:           instances,
+		operations:          operations,
+		deprovisioningQueue: deprovisioningQueue,
+		log:                 log.WithField("service", "RecreationHandler"),
+	}
+}
+
+func (h *Handler) AttachRoutes(router *mux.Router) {
+	router.HandleFunc("/recreations/{instance_id}", h.recreate).Methods(http.MethodPost)
+	router.HandleFunc("/recreations/{instance_id}", h.getRecreation).Methods(http.MethodGet)
+}
+
+// recreate starts the recreation, only the provisioned instances without an operation in progress can be recreated
+func (h *Handler) recreate(w http.ResponseWriter, r *http.Request) {
+	instanceID := mux.Vars(r)["instance_id"]
+
+	var action pkg.ActionDTO
+	if err := json.NewDecoder(r.Body).Decode(&action); err != nil && err != io.EOF {
+		httputil.WriteErrorResponse(w, http.StatusBadRequest, fmt.Errorf("while decoding action: %w", err))
+		return
+	}
+
+	instance, err := h.instances.GetByID(instanceID)
+	switch {
+	case dberr.IsNotFound(err):
+		httputil.WriteErrorResponse(w, http.StatusNotFound, fmt.Errorf("instance %s not found", instanceID))
+		return
+	case err != nil:
+		h.log.Errorf("while getting instance %s: %v", instanceID, err)
+		httputil.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Errorf("while getting instance: %w", err))
+		return
+	}
+	if instance.RuntimeID == "" || instance.IsExpired() {
+		httputil.WriteErrorResponse(w, http.StatusConflict, fmt.Errorf("instance %s has no Runtime, it is suspended or expired", instanceID))
+		return
+	}
+
+	lastOperation, err := h.operations.GetLastOperation(instanceID)
+	if err != nil && !dberr.IsNotFound(err) {
+		h.log.Errorf("while getting last operation of instance %s: %v", instanceID, err)
+		httputil.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Errorf("while getting last operation: %w", err))
+		return
+	}
+	if lastOperation != nil && !lastOperation.IsFinished() {
+		httputil.WriteErrorResponse(w, http.StatusConflict, fmt.Errorf("instance %s has the %s operation %s in progress", instanceID, lastOperation.Type, lastOperation.ID))
+		return
+	}
+
+	operation := internal.NewRecreationOperationWithID(uuid.New().String(), instance)
+	identity := httputil.RequestIdentity(r)
+	if action.Reason != "" {
+		operation.EventInfof("recreation requested manually by %s: %s", identity, action.Reason)
+	} else {
+		operation.EventInfof("recreation requested manually by %s", identity)
+	}
+	if err := h.operations.InsertDeprovisioningOperation(operation); err != nil {
+		h.log.Errorf("while inserting deprovisioning operation of instance %s: %v", instanceID, err)
+		httputil.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Errorf("while inserting deprovisioning operation: %w", err))
+		return
+	}
+	h.log.Infof("Recreation of instance %s requested by %s, deprovisioning operation %s", instanceID, identity, operation.ID)
+	h.deprovisioningQueue.Add(operation.ID)
+
+	httputil.WriteResponse(w, http.StatusOK, pkg.RecreationDTO{
+		InstanceID:                instanceID,
+		DeprovisioningOperationID: operation.ID,
+		DeprovisioningState:       string(operation.State),
+	})
+}
+
+// getRecreation returns the states of the operations of the last recreation of the instance
+func (h *Handler) getRecreation(w http.ResponseWriter, r *http.Request) {
+	instanceID := mux.Vars(r)["instance_id"]
+
+	deprovisionings, err := h.operations.ListDeprovisioningOperationsByInstanceID(instanceID)
+	if err != nil && !dberr.IsNotFound(err) {
+		h.log.Errorf("while listing deprovisioning operations of instance %s: %v", instanceID, err)
+		httputil.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Errorf("while listing deprovisioning operations: %w", err))
+		return
+	}
+	var last *internal.DeprovisioningOperation
+	for i, op := range deprovisionings {
+		if op.Recreate && (last == nil || op.CreatedAt.After(last.CreatedAt)) {
+			last = &deprovisionings[i]
+		}
+	}
+	if last == nil {
+		httputil.WriteErrorResponse(w, http.StatusNotFound, fmt.Errorf("instance %s was not recreated", instanceID))
+		return
+	}
+
+	recreation := pkg.RecreationDTO{
+		InstanceID:                instanceID,
+		DeprovisioningOperationID: last.ID,
+		DeprovisioningState:       string(last.State),
+		ProvisioningOperationID:   last.RecreationOperationID,
+	}
+	if last.RecreationOperationID != "" {
+		provisioning, err := h.operations.GetProvisioningOperationByID(last.RecreationOperationID)
+		switch {
+		case err == nil:
+			recreation.ProvisioningState = string(provisioning.State)
+		case !dberr.IsNotFound(err):
+			h.log.Errorf("while getting provisioning operation %s: %v", last.RecreationOperationID, err)
+			httputil.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Errorf("while getting provisioning operation: %w", err))
+			return
+		}
+	}
+	httputil.WriteResponse(w, http.StatusOK, recreation)
+}
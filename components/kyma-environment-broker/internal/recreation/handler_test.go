@@ -0,0 +1,117 @@
+package recreation_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	pkg "github.com/kyma-project/control-plane/components/kyma-environment-broker/common/operation"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/fixture"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/recreation"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/pivotal-cf/brokerapi/v8/domain"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler(t *testing.T) {
+	// given
+	db := storage.NewMemoryStorage()
+	queue := &queueStub{}
+	router := mux.NewRouter()
+	recreation.NewHandler(db.Instances(), db.Operations(), queue, logrus.New()).AttachRoutes(router)
+
+	t.Run("should start recreation", func(t *testing.T) {
+		// given
+		insertInstance(t, db, "inst-recreate", domain.Succeeded)
+
+		// when
+		rr := call(t, router, http.MethodPost, "/recreations/inst-recreate", `{"reason": "disaster recovery"}`)
+
+		// then
+		require.Equal(t, http.StatusOK, rr.Code)
+		var dto pkg.RecreationDTO
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &dto))
+		assert.Equal(t, []string{dto.DeprovisioningOperationID}, queue.added)
+		op, err := db.Operations().GetDeprovisioningOperationByID(dto.DeprovisioningOperationID)
+		require.NoError(t, err)
+		assert.True(t, op.Recreate)
+		assert.True(t, op.Temporary)
+	})
+
+	t.Run("should return recreation", func(t *testing.T) {
+		// given
+		insertInstance(t, db, "inst-get", domain.Succeeded)
+		deprovisioning := internal.NewRecreationOperationWithID("deprov-get", &internal.Instance{InstanceID: "inst-get"})
+		deprovisioning.State = domain.Succeeded
+		deprovisioning.RecreationOperationID = "prov-get"
+		require.NoError(t, db.Operations().InsertDeprovisioningOperation(deprovisioning))
+		provisioning := fixture.FixProvisioningOperation("prov-get", "inst-get")
+		provisioning.State = domain.InProgress
+		require.NoError(t, db.Operations().InsertOperation(provisioning))
+
+		// when
+		rr := call(t, router, http.MethodGet, "/recreations/inst-get", "")
+
+		// then
+		require.Equal(t, http.StatusOK, rr.Code)
+		var dto pkg.RecreationDTO
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &dto))
+		assert.Equal(t, pkg.RecreationDTO{
+			InstanceID:                "inst-get",
+			DeprovisioningOperationID: "deprov-get",
+			DeprovisioningState:       string(domain.Succeeded),
+			ProvisioningOperationID:   "prov-get",
+			ProvisioningState:         string(domain.InProgress),
+		}, dto)
+	})
+
+	t.Run("should reject instance with operation in progress", func(t *testing.T) {
+		// given
+		insertInstance(t, db, "inst-busy", domain.InProgress)
+
+		// when
+		rr := call(t, router, http.MethodPost, "/recreations/inst-busy", "")
+
+		// then
+		assert.Equal(t, http.StatusConflict, rr.Code)
+	})
+
+	t.Run("should return not found", func(t *testing.T) {
+		// when
+		rrPost := call(t, router, http.MethodPost, "/recreations/not-existing", "")
+		rrGet := call(t, router, http.MethodGet, "/recreations/not-existing", "")
+
+		// then
+		assert.Equal(t, http.StatusNotFound, rrPost.Code)
+		assert.Equal(t, http.StatusNotFound, rrGet.Code)
+	})
+}
+
+type queueStub struct {
+	added []string
+}
+
+func (q *queueStub) Add(processId string) {
+	q.added = append(q.added, processId)
+}
+
+func insertInstance(t *testing.T, db storage.BrokerStorage, id string, lastOperationState domain.LastOperationState) {
+	require.NoError(t, db.Instances().Insert(fixture.FixInstance(id)))
+	op := fixture.FixProvisioningOperation("prov-"+id, id)
+	op.State = lastOperationState
+	require.NoError(t, db.Operations().InsertOperation(op))
+}
+
+func call(t *testing.T, router *mux.Router, method, path, body string) *httptest.ResponseRecorder {
+	req, err := http.NewRequest(method, path, bytes.NewBufferString(body))
+	require.NoError(t, err)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	return rr
+}
@@ -0,0 +1,70 @@
+package retrypolicy
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// OverridesKey is the key of the ConfigMap data with the policies in the YAML format
+const OverridesKey = "policies.yaml"
+
+// ConfigMapLoader reads the overrides from the ConfigMap, so the retry policies can be changed without a redeploy
+type ConfigMapLoader struct {
+	k8sClient client.Client
+	registry  *Registry
+	name      string
+	namespace string
+	log       logrus.FieldLogger
+}
+
+func NewConfigMapLoader(k8sClient client.Client, registry *Registry, cfg Config, log logrus.FieldLogger) *ConfigMapLoader {
+	return &ConfigMapLoader{
+		k8sClient: k8sClient,
+		registry:  registry,
+		name:      cfg.OverridesConfigMap,
+		namespace: cfg.Namespace,
+		log:       log.WithField("service", "RetryPolicyLoader"),
+	}
+}
+
+// Run reloads the overrides every interval until the context is done, the previous overrides are kept when the ConfigMap is invalid
+func (l *ConfigMapLoader) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := l.Load(ctx); err != nil {
+				l.log.Errorf("while loading retry policy overrides: %s", err)
+			}
+		}
+	}
+}
+
+// Load reads the ConfigMap and replaces the overrides, a missing ConfigMap removes the overrides
+func (l *ConfigMapLoader) Load(ctx context.Context) error {
+	cm := &coreV1.ConfigMap{}
+	err := l.k8sClient.Get(ctx, client.ObjectKey{Namespace: l.namespace, Name: l.name}, cm)
+	switch {
+	case errors.IsNotFound(err):
+		l.registry.SetOverrides(Policies{})
+		return nil
+	case err != nil:
+		return fmt.Errorf("while getting ConfigMap %s/%s: %w", l.namespace, l.name, err)
+	}
+
+	overrides, err := Parse([]byte(cm.Data[OverridesKey]))
+	if err != nil {
+		return fmt.Errorf("while parsing ConfigMap %s/%s: %w", l.namespace, l.name, err)
+	}
+	l.registry.SetOverrides(overrides)
+	return nil
+}
@@ -0,0 +1,63 @@
+package retrypolicy_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/retrypolicy"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	coreV1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestConfigMapLoader_Load(t *testing.T) {
+	// given
+	cfg := retrypolicy.Config{OverridesConfigMap: "retry-policies", Namespace: "kcp-system"}
+	cm := &coreV1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "retry-policies", Namespace: "kcp-system"},
+		Data: map[string]string{
+			retrypolicy.OverridesKey: "Check_Runtime:\n  default:\n    maxDuration: 2h\n",
+		},
+	}
+	k8sClient := fake.NewClientBuilder().WithRuntimeObjects(cm).Build()
+	registry := retrypolicy.NewRegistry(retrypolicy.Policies{}, broker.PlanNamesMapping)
+	loader := retrypolicy.NewConfigMapLoader(k8sClient, registry, cfg, logrus.New())
+	defaults := retrypolicy.Policy{MaxDuration: time.Hour}
+
+	// when
+	err := loader.Load(context.Background())
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, 2*time.Hour, registry.Policy("Check_Runtime", broker.AWSPlanID, defaults).MaxDuration)
+
+	t.Run("should keep overrides when the ConfigMap is invalid", func(t *testing.T) {
+		// given
+		cm.Data[retrypolicy.OverridesKey] = "Check_Runtime:\n  default:\n    backoff: linear\n"
+		require.NoError(t, k8sClient.Update(context.Background(), cm))
+
+		// when
+		err := loader.Load(context.Background())
+
+		// then
+		assert.Error(t, err)
+		assert.Equal(t, 2*time.Hour, registry.Policy("Check_Runtime", broker.AWSPlanID, defaults).MaxDuration)
+	})
+
+	t.Run("should remove overrides when the ConfigMap is deleted", func(t *testing.T) {
+		// given
+		require.NoError(t, k8sClient.Delete(context.Background(), cm))
+
+		// when
+		err := loader.Load(context.Background())
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, time.Hour, registry.Policy("Check_Runtime", broker.AWSPlanID, defaults).MaxDuration)
+	})
+}
@@ -0,0 +1,135 @@
+package retrypolicy
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// AllPlans is the plan key of the policy which applies to all plans without own policy
+const AllPlans = "default"
+
+type Backoff string
+
+const (
+	BackoffConstant    Backoff = "constant"
+	BackoffExponential Backoff = "exponential"
+)
+
+// ExhaustionAction defines what happens with the operation when the step is retried longer than the max duration
+type ExhaustionAction string
+
+const (
+	ExhaustionFail     ExhaustionAction = "fail"
+	ExhaustionContinue ExhaustionAction = "continue"
+)
+
+type Config struct {
+	// FilePath points to the YAML file with the policies deployed with KEB
+	FilePath string `envconfig:"optional"`
+	// OverridesConfigMap is the name of the ConfigMap with the policies which take precedence over the file, it is read every RefreshInterval
+	OverridesConfigMap string        `envconfig:"default=kcp-keb-retry-policies"`
+	Namespace          string        `envconfig:"default=kcp-system"`
+	RefreshInterval    time.Duration `envconfig:"default=1m"`
+}
+
+// Policy defines how a step is retried, the values which are not set keep the values hard-coded in the step
+type Policy struct {
+	Backoff         Backoff          `yaml:"backoff"`
+	InitialInterval time.Duration    `yaml:"initialInterval"`
+	MaxInterval     time.Duration    `yaml:"maxInterval"`
+	MaxDuration     time.Duration    `yaml:"maxDuration"`
+	OnExhaustion    ExhaustionAction `yaml:"onExhaustion"`
+}
+
+// Policies maps a step name to the policies keyed by the plan name
+type Policies map[string]map[string]Policy
+
+// Interval returns the time to wait for the next retry when the step has been retried for the elapsed time.
+// The exponential backoff doubles the interval with every retry which makes the next interval equal to the elapsed time plus the initial interval.
+func (p Policy) Interval(elapsed time.Duration) time.Duration {
+	interval := p.InitialInterval
+	if p.Backoff == BackoffExponential {
+		interval += elapsed
+	}
+	if p.MaxInterval > 0 && interval > p.MaxInterval {
+		interval = p.MaxInterval
+	}
+	return interval
+}
+
+// Merge returns the policy with the values set in the override
+func (p Policy) Merge(override Policy) Policy {
+	if override.Backoff != "" {
+		p.Backoff = override.Backoff
+	}
+	if override.InitialInterval > 0 {
+		p.InitialInterval = override.InitialInterval
+	}
+	if override.MaxInterval > 0 {
+		p.MaxInterval = override.MaxInterval
+	}
+	if override.MaxDuration > 0 {
+		p.MaxDuration = override.MaxDuration
+	}
+	if override.OnExhaustion != "" {
+		p.OnExhaustion = override.OnExhaustion
+	}
+	return p
+}
+
+func (p Policy) Validate() error {
+	switch p.Backoff {
+	case "", BackoffConstant, BackoffExponential:
+	default:
+		return fmt.Errorf("unknown backoff %q, must be one of: %s, %s", p.Backoff, BackoffConstant, BackoffExponential)
+	}
+	switch p.OnExhaustion {
+	case "", ExhaustionFail, ExhaustionContinue:
+	default:
+		return fmt.Errorf("unknown exhaustion action %q, must be one of: %s, %s", p.OnExhaustion, ExhaustionFail, ExhaustionContinue)
+	}
+	if p.InitialInterval < 0 || p.MaxInterval < 0 || p.MaxDuration < 0 {
+		return fmt.Errorf("durations must not be negative")
+	}
+	if p.MaxInterval > 0 && p.InitialInterval > p.MaxInterval {
+		return fmt.Errorf("initial interval %s is longer than max interval %s", p.InitialInterval, p.MaxInterval)
+	}
+	return nil
+}
+
+// Parse reads the policies in the YAML format:
+//
+//	Check_Cluster_Deregistration:
+//	  default:
+//	    maxDuration: 90m
+//	  trial:
+//	    maxDuration: 30m
+//	    onExhaustion: fail
+func Parse(data []byte) (Policies, error) {
+	policies := Policies{}
+	if err := yaml.UnmarshalStrict(data, &policies); err != nil {
+		return nil, fmt.Errorf("while unmarshalling retry policies: %w", err)
+	}
+	for step, plans := range policies {
+		for plan, policy := range plans {
+			if err := policy.Validate(); err != nil {
+				return nil, fmt.Errorf("while validating retry policy of step %s and plan %s: %w", step, plan, err)
+			}
+		}
+	}
+	return policies, nil
+}
+
+func ReadFromFile(filename string) (Policies, error) {
+	if filename == "" {
+		return Policies{}, nil
+	}
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("while reading %s file with retry policies: %w", filename, err)
+	}
+	return Parse(data)
+}
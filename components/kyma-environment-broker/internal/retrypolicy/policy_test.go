@@ -0,0 +1,96 @@
+package retrypolicy_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/retrypolicy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	t.Run("should parse policies", func(t *testing.T) {
+		// when
+		policies, err := retrypolicy.Parse([]byte(`
+Check_Cluster_Deregistration:
+  default:
+    maxDuration: 90m
+  trial:
+    backoff: exponential
+    initialInterval: 30s
+    maxInterval: 5m
+    maxDuration: 30m
+    onExhaustion: fail
+`))
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 90*time.Minute, policies["Check_Cluster_Deregistration"]["default"].MaxDuration)
+		assert.Equal(t, retrypolicy.Policy{
+			Backoff:         retrypolicy.BackoffExponential,
+			InitialInterval: 30 * time.Second,
+			MaxInterval:     5 * time.Minute,
+			MaxDuration:     30 * time.Minute,
+			OnExhaustion:    retrypolicy.ExhaustionFail,
+		}, policies["Check_Cluster_Deregistration"]["trial"])
+	})
+
+	for name, data := range map[string]string{
+		"unknown field":      "Check_Runtime:\n  default:\n    timeout: 1m\n",
+		"unknown backoff":    "Check_Runtime:\n  default:\n    backoff: linear\n",
+		"unknown exhaustion": "Check_Runtime:\n  default:\n    onExhaustion: ignore\n",
+		"invalid intervals":  "Check_Runtime:\n  default:\n    initialInterval: 10m\n    maxInterval: 1m\n",
+	} {
+		t.Run("should reject "+name, func(t *testing.T) {
+			// when
+			_, err := retrypolicy.Parse([]byte(data))
+
+			// then
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestPolicy_Interval(t *testing.T) {
+	// given
+	constant := retrypolicy.Policy{Backoff: retrypolicy.BackoffConstant, InitialInterval: time.Minute}
+	exponential := retrypolicy.Policy{Backoff: retrypolicy.BackoffExponential, InitialInterval: time.Minute, MaxInterval: 10 * time.Minute}
+
+	// then
+	assert.Equal(t, time.Minute, constant.Interval(time.Hour))
+	assert.Equal(t, time.Minute, exponential.Interval(0))
+	assert.Equal(t, 4*time.Minute, exponential.Interval(3*time.Minute))
+	assert.Equal(t, 10*time.Minute, exponential.Interval(time.Hour))
+}
+
+func TestRegistry_Policy(t *testing.T) {
+	// given
+	registry := retrypolicy.NewRegistry(retrypolicy.Policies{
+		"Check_Runtime": {
+			retrypolicy.AllPlans: {MaxDuration: time.Hour, InitialInterval: 30 * time.Second},
+			"trial":              {MaxDuration: 20 * time.Minute},
+		},
+	}, broker.PlanNamesMapping)
+	defaults := retrypolicy.Policy{Backoff: retrypolicy.BackoffConstant, InitialInterval: time.Minute, MaxDuration: 3 * time.Hour}
+
+	// then
+	assert.Equal(t, defaults, registry.Policy("Other_Step", broker.AzurePlanID, defaults))
+	assert.Equal(t, time.Hour, registry.Policy("Check_Runtime", broker.AzurePlanID, defaults).MaxDuration)
+	assert.Equal(t, 20*time.Minute, registry.Policy("Check_Runtime", broker.TrialPlanID, defaults).MaxDuration)
+	assert.Equal(t, 30*time.Second, registry.Policy("Check_Runtime", broker.TrialPlanID, defaults).InitialInterval)
+
+	// when
+	registry.SetOverrides(retrypolicy.Policies{
+		"Check_Runtime": {
+			retrypolicy.AllPlans: {MaxDuration: 2 * time.Hour, OnExhaustion: retrypolicy.ExhaustionContinue},
+		},
+	})
+
+	// then - the override for all plans takes precedence over the policy for the plan
+	trial := registry.Policy("Check_Runtime", broker.TrialPlanID, defaults)
+	assert.Equal(t, 2*time.Hour, trial.MaxDuration)
+	assert.Equal(t, retrypolicy.ExhaustionContinue, trial.OnExhaustion)
+	assert.Equal(t, 30*time.Second, trial.InitialInterval)
+}
@@ -0,0 +1,48 @@
+package retrypolicy
+
+import "sync"
+
+// Registry holds the policies deployed with KEB and the overrides which can be replaced at runtime
+type Registry struct {
+	mu        sync.RWMutex
+	base      Policies
+	overrides Policies
+	// planNames maps the plan IDs to the plan names used as the keys of the policies
+	planNames map[string]string
+}
+
+func NewRegistry(base Policies, planNames map[string]string) *Registry {
+	return &Registry{
+		base:      base,
+		overrides: Policies{},
+		planNames: planNames,
+	}
+}
+
+// SetOverrides replaces the policies which take precedence over the policies deployed with KEB
+func (r *Registry) SetOverrides(overrides Policies) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.overrides = overrides
+}
+
+// Policy returns the policy of the step in the plan. The values are taken from the first source which sets them, in the following order:
+// the override for the plan, the override for all plans, the policy for the plan, the policy for all plans and the given defaults.
+func (r *Registry) Policy(stepName, planID string, defaults Policy) Policy {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	planName := r.planNames[planID]
+	policy := defaults
+	for _, policies := range []Policies{r.base, r.overrides} {
+		plans, found := policies[stepName]
+		if !found {
+			continue
+		}
+		policy = policy.Merge(plans[AllPlans])
+		if planName != "" {
+			policy = policy.Merge(plans[planName])
+		}
+	}
+	return policy
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbmodel"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/trial"
 )
 
 const numberOfUpgradeOperationsToReturn = 2
@@ -26,16 +27,20 @@ type Handler struct {
 	instancesDb     storage.Instances
 	operationsDb    storage.Operations
 	runtimeStatesDb storage.RuntimeStates
+	trialEventsDb   storage.TrialEvents
+	trialPolicy     *trial.Policy
 	converter       Converter
 
 	defaultMaxPage int
 }
 
-func NewHandler(instanceDb storage.Instances, operationDb storage.Operations, runtimeStatesDb storage.RuntimeStates, defaultMaxPage int, defaultRequestRegion string) *Handler {
+func NewHandler(instanceDb storage.Instances, operationDb storage.Operations, runtimeStatesDb storage.RuntimeStates, trialEventsDb storage.TrialEvents, trialPolicy *trial.Policy, defaultMaxPage int, defaultRequestRegion string) *Handler {
 	return &Handler{
 		instancesDb:     instanceDb,
 		operationsDb:    operationDb,
 		runtimeStatesDb: runtimeStatesDb,
+		trialEventsDb:   trialEventsDb,
+		trialPolicy:     trialPolicy,
 		converter:       NewConverter(defaultRequestRegion),
 		defaultMaxPage:  defaultMaxPage,
 	}
@@ -145,6 +150,11 @@ func (h *Handler) getRuntimes(w http.ResponseWriter, req *http.Request) {
 		httputil.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Errorf("while fetching instances: %w", err))
 		return
 	}
+	trialEvents, err := h.listTrialEvents(instances)
+	if err != nil {
+		httputil.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Errorf("while fetching trial events: %w", err))
+		return
+	}
 
 	for _, instance := range instances {
 		dto, err := h.converter.NewDTO(instance)
@@ -174,6 +184,10 @@ func (h *Handler) getRuntimes(w http.ResponseWriter, req *http.Request) {
 			httputil.WriteErrorResponse(w, http.StatusInternalServerError, err)
 			return
 		}
+		if h.trialPolicy.Applies(instance.ServicePlanID) {
+			trialDTO := h.trialPolicy.ToDTO(instance, trialEvents[instance.InstanceID])
+			dto.Trial = &trialDTO
+		}
 
 		toReturn = append(toReturn, dto)
 	}
@@ -186,6 +200,29 @@ func (h *Handler) getRuntimes(w http.ResponseWriter, req *http.Request) {
 	httputil.WriteResponse(w, http.StatusOK, runtimePage)
 }
 
+// listTrialEvents returns the trial events of the expirable instances grouped by the instance ID
+func (h *Handler) listTrialEvents(instances []internal.Instance) (map[string][]internal.TrialEvent, error) {
+	instanceIDs := make([]string, 0)
+	for _, instance := range instances {
+		if h.trialPolicy.Applies(instance.ServicePlanID) {
+			instanceIDs = append(instanceIDs, instance.InstanceID)
+		}
+	}
+	trialEvents := make(map[string][]internal.TrialEvent)
+	if len(instanceIDs) == 0 {
+		return trialEvents, nil
+	}
+
+	events, err := h.trialEventsDb.List(dbmodel.TrialEventFilter{InstanceIDs: instanceIDs})
+	if err != nil {
+		return nil, err
+	}
+	for _, event := range events {
+		trialEvents[event.InstanceID] = append(trialEvents[event.InstanceID], event)
+	}
+	return trialEvents, nil
+}
+
 func (h *Handler) takeLastNonDryRunOperations(oprs []internal.UpgradeKymaOperation) ([]internal.UpgradeKymaOperation, int) {
 	toReturn := make([]internal.UpgradeKymaOperation, 0)
 	totalCount := 0
@@ -11,10 +11,12 @@ import (
 	"github.com/gorilla/mux"
 	pkg "github.com/kyma-project/control-plane/components/kyma-environment-broker/common/runtime"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/fixture"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/ptr"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/runtime"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/driver/memory"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/trial"
 	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
 	"github.com/pivotal-cf/brokerapi/v8/domain"
 	"github.com/sirupsen/logrus"
@@ -49,7 +51,7 @@ func TestRuntimeHandler(t *testing.T) {
 		err = instances.Insert(testInstance2)
 		require.NoError(t, err)
 
-		runtimeHandler := runtime.NewHandler(instances, operations, states, 2, "")
+		runtimeHandler := runtime.NewHandler(instances, operations, states, memory.NewTrialEvents(), fixTrialPolicy(), 2, "")
 
 		req, err := http.NewRequest("GET", "/runtimes?page_size=1", nil)
 		require.NoError(t, err)
@@ -100,7 +102,7 @@ func TestRuntimeHandler(t *testing.T) {
 		instances := memory.NewInstance(operations)
 		states := memory.NewRuntimeStates()
 
-		runtimeHandler := runtime.NewHandler(instances, operations, states, 2, "region")
+		runtimeHandler := runtime.NewHandler(instances, operations, states, memory.NewTrialEvents(), fixTrialPolicy(), 2, "region")
 
 		req, err := http.NewRequest("GET", "/runtimes?page_size=a", nil)
 		require.NoError(t, err)
@@ -155,7 +157,7 @@ func TestRuntimeHandler(t *testing.T) {
 		err = operations.InsertOperation(testOp2)
 		require.NoError(t, err)
 
-		runtimeHandler := runtime.NewHandler(instances, operations, states, 2, "")
+		runtimeHandler := runtime.NewHandler(instances, operations, states, memory.NewTrialEvents(), fixTrialPolicy(), 2, "")
 
 		req, err := http.NewRequest("GET", fmt.Sprintf("/runtimes?account=%s&subaccount=%s&instance_id=%s&runtime_id=%s&region=%s&shoot=%s", testID1, testID1, testID1, testID1, testID1, fmt.Sprintf("Shoot-%s", testID1)), nil)
 		require.NoError(t, err)
@@ -228,7 +230,7 @@ func TestRuntimeHandler(t *testing.T) {
 		err = operations.InsertDeprovisioningOperation(deprovOp3)
 		require.NoError(t, err)
 
-		runtimeHandler := runtime.NewHandler(instances, operations, states, 2, "")
+		runtimeHandler := runtime.NewHandler(instances, operations, states, memory.NewTrialEvents(), fixTrialPolicy(), 2, "")
 
 		rr := httptest.NewRecorder()
 		router := mux.NewRouter()
@@ -334,7 +336,7 @@ func TestRuntimeHandler(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		runtimeHandler := runtime.NewHandler(instances, operations, states, 2, "")
+		runtimeHandler := runtime.NewHandler(instances, operations, states, memory.NewTrialEvents(), fixTrialPolicy(), 2, "")
 
 		req, err := http.NewRequest("GET", "/runtimes", nil)
 		require.NoError(t, err)
@@ -404,7 +406,7 @@ func TestRuntimeHandler(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		runtimeHandler := runtime.NewHandler(instances, operations, states, 2, "")
+		runtimeHandler := runtime.NewHandler(instances, operations, states, memory.NewTrialEvents(), fixTrialPolicy(), 2, "")
 
 		req, err := http.NewRequest("GET", "/runtimes", nil)
 		require.NoError(t, err)
@@ -473,7 +475,7 @@ func TestRuntimeHandler(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		runtimeHandler := runtime.NewHandler(instances, operations, states, 2, "")
+		runtimeHandler := runtime.NewHandler(instances, operations, states, memory.NewTrialEvents(), fixTrialPolicy(), 2, "")
 
 		req, err := http.NewRequest("GET", "/runtimes", nil)
 		require.NoError(t, err)
@@ -525,7 +527,7 @@ func TestRuntimeHandler(t *testing.T) {
 		err = operations.InsertUpgradeKymaOperation(upgOp)
 		require.NoError(t, err)
 
-		runtimeHandler := runtime.NewHandler(instances, operations, states, 2, "")
+		runtimeHandler := runtime.NewHandler(instances, operations, states, memory.NewTrialEvents(), fixTrialPolicy(), 2, "")
 
 		rr := httptest.NewRecorder()
 		router := mux.NewRouter()
@@ -656,7 +658,7 @@ func TestRuntimeHandler(t *testing.T) {
 		err = states.Insert(fixOpgClusterState)
 		require.NoError(t, err)
 
-		runtimeHandler := runtime.NewHandler(instances, operations, states, 2, "")
+		runtimeHandler := runtime.NewHandler(instances, operations, states, memory.NewTrialEvents(), fixTrialPolicy(), 2, "")
 
 		rr := httptest.NewRecorder()
 		router := mux.NewRouter()
@@ -683,6 +685,47 @@ func TestRuntimeHandler(t *testing.T) {
 		require.NotNil(t, out.Data[0].ClusterConfig)
 		assert.Equal(t, "1.19.19", out.Data[0].ClusterConfig.KubernetesVersion)
 	})
+
+	t.Run("should return the trial expiry and the extension history", func(t *testing.T) {
+		// given
+		operations := memory.NewOperation()
+		instances := memory.NewInstance(operations)
+		states := memory.NewRuntimeStates()
+		trialEvents := memory.NewTrialEvents()
+
+		createdAt := time.Now().UTC().Truncate(time.Second)
+		trialInstance := fixInstance("trial", createdAt)
+		trialInstance.ServicePlanID = broker.TrialPlanID
+		require.NoError(t, instances.Insert(trialInstance))
+		require.NoError(t, instances.Insert(fixInstance("aws", createdAt.Add(time.Second))))
+		require.NoError(t, trialEvents.Insert(internal.TrialEvent{ID: "e1", InstanceID: "trial", SubAccountID: "trial", Type: internal.TrialEventExtension, Period: 7 * 24 * time.Hour, Message: "support ticket", CreatedAt: createdAt}))
+
+		runtimeHandler := runtime.NewHandler(instances, operations, states, trialEvents, fixTrialPolicy(), 2, "")
+
+		rr := httptest.NewRecorder()
+		router := mux.NewRouter()
+		runtimeHandler.AttachRoutes(router)
+
+		// when
+		req, err := http.NewRequest("GET", "/runtimes", nil)
+		require.NoError(t, err)
+		router.ServeHTTP(rr, req)
+
+		// then
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var out pkg.RuntimesPage
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &out))
+		require.Len(t, out.Data, 2)
+		require.NotNil(t, out.Data[0].Trial)
+		assert.Equal(t, createdAt.Add(21*24*time.Hour), out.Data[0].Trial.ExpiresAt)
+		assert.Equal(t, []pkg.TrialEventDTO{{Type: "extension", Period: "168h0m0s", Message: "support ticket", CreatedAt: createdAt}}, out.Data[0].Trial.Events)
+		assert.Nil(t, out.Data[1].Trial)
+	})
+}
+
+func fixTrialPolicy() *trial.Policy {
+	return trial.NewPolicy(trial.Config{}, trial.ExpirationPeriods(14*24*time.Hour, nil), nil)
 }
 
 func fixInstance(id string, t time.Time) internal.Instance {
@@ -57,6 +57,13 @@ func AlreadyExists(format string, a ...interface{}) Error {
 	return errorf(CodeAlreadyExists, format, a...)
 }
 
+func IsAlreadyExists(err error) bool {
+	ae, ok := err.(interface {
+		Code() int
+	})
+	return ok && ae.Code() == CodeAlreadyExists
+}
+
 func Conflict(format string, a ...interface{}) Error {
 	return errorf(CodeConflict, format, a...)
 }
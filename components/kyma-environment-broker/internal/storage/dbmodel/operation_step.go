@@ -0,0 +1,47 @@
+package dbmodel
+
+import (
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+)
+
+type OperationStepDTO struct {
+	ID             string
+	OperationID    string
+	StepName       string
+	StageName      string
+	StartedAt      time.Time
+	FinishedAt     time.Time
+	Result         string
+	RetryInSeconds int64
+	ErrorMessage   string
+}
+
+func NewOperationStepDTO(s internal.OperationStep) OperationStepDTO {
+	return OperationStepDTO{
+		ID:             s.ID,
+		OperationID:    s.OperationID,
+		StepName:       s.StepName,
+		StageName:      s.StageName,
+		StartedAt:      s.StartedAt,
+		FinishedAt:     s.FinishedAt,
+		Result:         string(s.Result),
+		RetryInSeconds: int64(s.RetryIn / time.Second),
+		ErrorMessage:   s.Error,
+	}
+}
+
+func (s OperationStepDTO) ToOperationStep() internal.OperationStep {
+	return internal.OperationStep{
+		ID:          s.ID,
+		OperationID: s.OperationID,
+		StepName:    s.StepName,
+		StageName:   s.StageName,
+		StartedAt:   s.StartedAt,
+		FinishedAt:  s.FinishedAt,
+		Result:      internal.OperationStepResult(s.Result),
+		RetryIn:     time.Duration(s.RetryInSeconds) * time.Second,
+		Error:       s.ErrorMessage,
+	}
+}
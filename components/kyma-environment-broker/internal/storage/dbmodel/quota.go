@@ -0,0 +1,63 @@
+package dbmodel
+
+import (
+	"time"
+
+	"github.com/gocraft/dbr"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+)
+
+// QuotaFilter holds the filters when listing quotas
+type QuotaFilter struct {
+	GlobalAccountIDs []string
+	PlanNames        []string
+}
+
+type QuotaDTO struct {
+	GlobalAccountID  string
+	PlanName         string
+	MaxInstances     dbr.NullInt64
+	MaxAutoScalerMax dbr.NullInt64
+	MaxVolumeSizeGb  dbr.NullInt64
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+func NewQuotaDTO(q internal.Quota) QuotaDTO {
+	return QuotaDTO{
+		GlobalAccountID:  q.GlobalAccountID,
+		PlanName:         q.PlanName,
+		MaxInstances:     toNullInt64(q.MaxInstances),
+		MaxAutoScalerMax: toNullInt64(q.MaxAutoScalerMax),
+		MaxVolumeSizeGb:  toNullInt64(q.MaxVolumeSizeGb),
+		CreatedAt:        q.CreatedAt,
+		UpdatedAt:        q.UpdatedAt,
+	}
+}
+
+func (q QuotaDTO) ToQuota() internal.Quota {
+	return internal.Quota{
+		GlobalAccountID:  q.GlobalAccountID,
+		PlanName:         q.PlanName,
+		MaxInstances:     fromNullInt64(q.MaxInstances),
+		MaxAutoScalerMax: fromNullInt64(q.MaxAutoScalerMax),
+		MaxVolumeSizeGb:  fromNullInt64(q.MaxVolumeSizeGb),
+		CreatedAt:        q.CreatedAt,
+		UpdatedAt:        q.UpdatedAt,
+	}
+}
+
+func toNullInt64(v *int) dbr.NullInt64 {
+	if v == nil {
+		return dbr.NullInt64{}
+	}
+	return dbr.NewNullInt64(int64(*v))
+}
+
+func fromNullInt64(v dbr.NullInt64) *int {
+	if !v.Valid {
+		return nil
+	}
+	i := int(v.Int64)
+	return &i
+}
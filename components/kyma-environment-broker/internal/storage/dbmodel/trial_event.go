@@ -0,0 +1,48 @@
+package dbmodel
+
+import (
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+)
+
+// TrialEventFilter holds the filters when listing trial events
+type TrialEventFilter struct {
+	InstanceIDs   []string
+	SubAccountIDs []string
+	Types         []internal.TrialEventType
+}
+
+type TrialEventDTO struct {
+	ID            string
+	InstanceID    string
+	SubAccountID  string
+	Type          string
+	PeriodSeconds int64
+	Message       string
+	CreatedAt     time.Time
+}
+
+func NewTrialEventDTO(e internal.TrialEvent) TrialEventDTO {
+	return TrialEventDTO{
+		ID:            e.ID,
+		InstanceID:    e.InstanceID,
+		SubAccountID:  e.SubAccountID,
+		Type:          string(e.Type),
+		PeriodSeconds: int64(e.Period / time.Second),
+		Message:       e.Message,
+		CreatedAt:     e.CreatedAt,
+	}
+}
+
+func (e TrialEventDTO) ToTrialEvent() internal.TrialEvent {
+	return internal.TrialEvent{
+		ID:           e.ID,
+		InstanceID:   e.InstanceID,
+		SubAccountID: e.SubAccountID,
+		Type:         internal.TrialEventType(e.Type),
+		Period:       time.Duration(e.PeriodSeconds) * time.Second,
+		Message:      e.Message,
+		CreatedAt:    e.CreatedAt,
+	}
+}
@@ -135,15 +135,34 @@ func (s *operations) UpdateOperation(op internal.Operation) (*internal.Operation
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	oldOp, exists := s.operations[op.ID]
-	if !exists {
+	storedOp, exists := s.operations[op.ID]
+	upgradeClusterOp, isUpgradeCluster := s.upgradeClusterOperations[op.ID]
+	updateOp, isUpdate := s.updateOperations[op.ID]
+
+	var oldOp internal.Operation
+	switch {
+	case exists:
+		oldOp = storedOp
+	case isUpgradeCluster:
+		oldOp = upgradeClusterOp.Operation
+	case isUpdate:
+		oldOp = updateOp.Operation
+	default:
 		return nil, dberr.NotFound("instance operation with id %s not found", op.ID)
 	}
 	if oldOp.Version != op.Version {
 		return nil, dberr.Conflict("unable to update operation with id %s (for instance id %s) - conflict", op.ID, op.InstanceID)
 	}
 	op.Version = op.Version + 1
-	s.operations[op.ID] = op
+
+	switch {
+	case exists:
+		s.operations[op.ID] = op
+	case isUpgradeCluster:
+		s.upgradeClusterOperations[op.ID] = internal.UpgradeClusterOperation{Operation: op}
+	default:
+		s.updateOperations[op.ID] = internal.UpdatingOperation{Operation: op}
+	}
 
 	return &op, nil
 }
@@ -0,0 +1,68 @@
+package memory
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
+)
+
+type operationSteps struct {
+	mu sync.Mutex
+
+	steps map[string][]internal.OperationStep
+}
+
+func NewOperationSteps() *operationSteps {
+	return &operationSteps{
+		steps: make(map[string][]internal.OperationStep),
+	}
+}
+
+func (s *operationSteps) Insert(step internal.OperationStep) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, existing := range s.steps[step.OperationID] {
+		if existing.ID == step.ID {
+			return dberr.AlreadyExists("operation step with id %s already exist", step.ID)
+		}
+	}
+	s.steps[step.OperationID] = append(s.steps[step.OperationID], step)
+
+	return nil
+}
+
+func (s *operationSteps) ListByOperationID(operationID string) ([]internal.OperationStep, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := make([]internal.OperationStep, len(s.steps[operationID]))
+	copy(result, s.steps[operationID])
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].StartedAt.Before(result[j].StartedAt)
+	})
+	return result, nil
+}
+
+func (s *operationSteps) DeleteFinishedBefore(until time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for operationID, steps := range s.steps {
+		kept := []internal.OperationStep{}
+		for _, step := range steps {
+			if !step.FinishedAt.Before(until) {
+				kept = append(kept, step)
+			}
+		}
+		if len(kept) == 0 {
+			delete(s.steps, operationID)
+			continue
+		}
+		s.steps[operationID] = kept
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package memory
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbmodel"
+)
+
+type quotaKey struct {
+	globalAccountID string
+	planName        string
+}
+
+type quotas struct {
+	mu sync.Mutex
+
+	quotas map[quotaKey]internal.Quota
+	locks  map[string]*accountLock
+}
+
+// accountLock is the lock of one global account, it is removed when no caller waits for it
+type accountLock struct {
+	sync.Mutex
+	waiting int
+}
+
+func NewQuotas() *quotas {
+	return &quotas{
+		quotas: make(map[quotaKey]internal.Quota),
+		locks:  make(map[string]*accountLock),
+	}
+}
+
+func (s *quotas) Upsert(quota internal.Quota) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := quotaKey{quota.GlobalAccountID, quota.PlanName}
+	quota.CreatedAt = time.Now()
+	if existing, found := s.quotas[key]; found {
+		quota.CreatedAt = existing.CreatedAt
+	}
+	quota.UpdatedAt = time.Now()
+	s.quotas[key] = quota
+
+	return nil
+}
+
+func (s *quotas) Get(globalAccountID, planName string) (internal.Quota, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	quota, found := s.quotas[quotaKey{globalAccountID, planName}]
+	if !found {
+		return internal.Quota{}, dberr.NotFound("quota for global account %s and plan %s not exist", globalAccountID, planName)
+	}
+	return quota, nil
+}
+
+func (s *quotas) List(filter dbmodel.QuotaFilter) ([]internal.Quota, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := make([]internal.Quota, 0)
+	for _, quota := range s.quotas {
+		if len(filter.GlobalAccountIDs) > 0 && !contains(filter.GlobalAccountIDs, quota.GlobalAccountID) {
+			continue
+		}
+		if len(filter.PlanNames) > 0 && !contains(filter.PlanNames, quota.PlanName) {
+			continue
+		}
+		result = append(result, quota)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].GlobalAccountID != result[j].GlobalAccountID {
+			return result[i].GlobalAccountID < result[j].GlobalAccountID
+		}
+		return result[i].PlanName < result[j].PlanName
+	})
+	return result, nil
+}
+
+func (s *quotas) Delete(globalAccountID, planName string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.quotas, quotaKey{globalAccountID, planName})
+	return nil
+}
+
+func (s *quotas) Lock(globalAccountID string) (func(), error) {
+	s.mu.Lock()
+	lock, found := s.locks[globalAccountID]
+	if !found {
+		lock = &accountLock{}
+		s.locks[globalAccountID] = lock
+	}
+	lock.waiting++
+	s.mu.Unlock()
+
+	lock.Lock()
+	return func() {
+		lock.Unlock()
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		lock.waiting--
+		if lock.waiting == 0 {
+			delete(s.locks, globalAccountID)
+		}
+	}, nil
+}
+
+func contains(items []string, item string) bool {
+	for _, i := range items {
+		if i == item {
+			return true
+		}
+	}
+	return false
+}
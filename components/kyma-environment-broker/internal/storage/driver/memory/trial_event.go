@@ -0,0 +1,69 @@
+package memory
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbmodel"
+)
+
+type trialEvents struct {
+	mu sync.Mutex
+
+	events []internal.TrialEvent
+}
+
+func NewTrialEvents() *trialEvents {
+	return &trialEvents{}
+}
+
+func (s *trialEvents) Insert(event internal.TrialEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, existing := range s.events {
+		if existing.ID == event.ID {
+			return dberr.AlreadyExists("trial event with id %s already exist", event.ID)
+		}
+		if event.Type == internal.TrialEventExtension && existing.Type == internal.TrialEventExtension && existing.SubAccountID == event.SubAccountID {
+			return dberr.AlreadyExists("trial event %s of subaccount %s already exist", event.Type, event.SubAccountID)
+		}
+	}
+	s.events = append(s.events, event)
+
+	return nil
+}
+
+func (s *trialEvents) List(filter dbmodel.TrialEventFilter) ([]internal.TrialEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := make([]internal.TrialEvent, 0)
+	for _, event := range s.events {
+		if len(filter.InstanceIDs) > 0 && !contains(filter.InstanceIDs, event.InstanceID) {
+			continue
+		}
+		if len(filter.SubAccountIDs) > 0 && !contains(filter.SubAccountIDs, event.SubAccountID) {
+			continue
+		}
+		if len(filter.Types) > 0 && !containsTrialEventType(filter.Types, event.Type) {
+			continue
+		}
+		result = append(result, event)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].CreatedAt.Before(result[j].CreatedAt)
+	})
+	return result, nil
+}
+
+func containsTrialEventType(types []internal.TrialEventType, eventType internal.TrialEventType) bool {
+	for _, t := range types {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
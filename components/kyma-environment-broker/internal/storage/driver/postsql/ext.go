@@ -13,4 +13,8 @@ type Cipher interface {
 	// methods used to encrypt/decrypt kubeconfig
 	EncryptKubeconfig(pp *internal.ProvisioningParameters) error
 	DecryptKubeconfig(pp *internal.ProvisioningParameters) error
+
+	// methods used to encrypt/decrypt the credentials of the customer hyperscaler account
+	EncryptHyperscalerAccount(pp *internal.ProvisioningParameters) error
+	DecryptHyperscalerAccount(pp *internal.ProvisioningParameters) error
 }
@@ -350,6 +350,10 @@ func (s *Instance) toInstance(dto dbmodel.InstanceDTO) (internal.Instance, error
 	if err != nil {
 		log.Warn("decrypting skipped because kubeconfig is in a plain text")
 	}
+	err = s.cipher.DecryptHyperscalerAccount(&params)
+	if err != nil {
+		return internal.Instance{}, fmt.Errorf("while decrypting hyperscaler account: %w", err)
+	}
 
 	return internal.Instance{
 		InstanceID:                  dto.InstanceID,
@@ -441,6 +445,10 @@ func (s *Instance) toInstanceDTO(instance internal.Instance) (dbmodel.InstanceDT
 	if err != nil {
 		return dbmodel.InstanceDTO{}, fmt.Errorf("while encrypting kubeconfig: %w", err)
 	}
+	err = s.cipher.EncryptHyperscalerAccount(&instance.Parameters)
+	if err != nil {
+		return dbmodel.InstanceDTO{}, fmt.Errorf("while encrypting hyperscaler account: %w", err)
+	}
 	params, err := json.Marshal(instance.Parameters)
 	if err != nil {
 		return dbmodel.InstanceDTO{}, fmt.Errorf("while marshaling parameters: %w", err)
@@ -906,6 +906,10 @@ func (s *operations) operationToDB(op internal.Operation) (dbmodel.OperationDTO,
 	if err != nil {
 		return dbmodel.OperationDTO{}, fmt.Errorf("while encrypting kubeconfig: %w", err)
 	}
+	err = s.cipher.EncryptHyperscalerAccount(&op.ProvisioningParameters)
+	if err != nil {
+		return dbmodel.OperationDTO{}, fmt.Errorf("while encrypting hyperscaler account: %w", err)
+	}
 	pp, err := json.Marshal(op.ProvisioningParameters)
 	if err != nil {
 		return dbmodel.OperationDTO{}, fmt.Errorf("while marshal provisioning parameters: %w", err)
@@ -944,6 +948,10 @@ func (s *operations) toOperation(dto *dbmodel.OperationDTO, existingOp internal.
 	if err != nil {
 		log.Warn("decrypting skipped because kubeconfig is in a plain text")
 	}
+	err = s.cipher.DecryptHyperscalerAccount(&provisioningParameters)
+	if err != nil {
+		return internal.Operation{}, fmt.Errorf("while decrypting hyperscaler account: %w", err)
+	}
 
 	stages := make([]string, 0)
 	finishedSteps := storage.SQLNullStringToString(dto.FinishedStages)
@@ -0,0 +1,82 @@
+package postsql
+
+import (
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbmodel"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/postsql"
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+type operationSteps struct {
+	postsql.Factory
+}
+
+func NewOperationSteps(sess postsql.Factory) *operationSteps {
+	return &operationSteps{
+		Factory: sess,
+	}
+}
+
+func (s *operationSteps) Insert(step internal.OperationStep) error {
+	sess := s.NewWriteSession()
+	dto := dbmodel.NewOperationStepDTO(step)
+	var lastErr dberr.Error
+	err := wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
+		lastErr = sess.InsertOperationStep(dto)
+		if lastErr != nil {
+			if lastErr.Code() == dberr.CodeAlreadyExists {
+				return false, lastErr
+			}
+			log.Errorf("while inserting step %s of operation %s: %v", step.StepName, step.OperationID, lastErr)
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil && lastErr != nil {
+		return lastErr
+	}
+	return err
+}
+
+func (s *operationSteps) ListByOperationID(operationID string) ([]internal.OperationStep, error) {
+	sess := s.NewReadSession()
+	var dtos []dbmodel.OperationStepDTO
+	var lastErr dberr.Error
+	err := wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
+		dtos, lastErr = sess.ListOperationSteps(operationID)
+		if lastErr != nil {
+			log.Errorf("while listing steps of operation %s: %v", operationID, lastErr)
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, lastErr
+	}
+	result := make([]internal.OperationStep, 0, len(dtos))
+	for _, dto := range dtos {
+		result = append(result, dto.ToOperationStep())
+	}
+	return result, nil
+}
+
+func (s *operationSteps) DeleteFinishedBefore(until time.Time) error {
+	sess := s.NewWriteSession()
+	var lastErr dberr.Error
+	err := wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
+		lastErr = sess.DeleteOperationSteps(until)
+		if lastErr != nil {
+			log.Errorf("while deleting steps finished before %s: %v", until, lastErr)
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil && lastErr != nil {
+		return lastErr
+	}
+	return err
+}
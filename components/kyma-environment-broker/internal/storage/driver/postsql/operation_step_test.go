@@ -0,0 +1,71 @@
+package postsql_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/events"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperationSteps(t *testing.T) {
+
+	ctx := context.Background()
+
+	t.Run("Operation steps", func(t *testing.T) {
+		containerCleanupFunc, cfg, err := storage.InitTestDBContainer(t.Logf, ctx, "test_DB_1")
+		require.NoError(t, err)
+		defer containerCleanupFunc()
+
+		tablesCleanupFunc, err := storage.InitTestDBTables(t, cfg.ConnectionURL())
+		require.NoError(t, err)
+		defer tablesCleanupFunc()
+
+		cipher := storage.NewEncrypter(cfg.SecretKey)
+		brokerStorage, _, err := storage.NewFromConfig(cfg, events.Config{}, cipher, logrus.StandardLogger())
+		require.NoError(t, err)
+		require.NotNil(t, brokerStorage)
+
+		svc := brokerStorage.OperationSteps()
+		now := time.Now().UTC().Truncate(time.Second)
+
+		// when
+		err = svc.Insert(internal.OperationStep{ID: "s2", OperationID: "op1", StepName: "Create_Runtime", StageName: "create_runtime",
+			StartedAt: now.Add(time.Minute), FinishedAt: now.Add(2 * time.Minute), Result: internal.OperationStepSucceeded})
+		require.NoError(t, err)
+		err = svc.Insert(internal.OperationStep{ID: "s1", OperationID: "op1", StepName: "Create_Runtime", StageName: "create_runtime",
+			StartedAt: now, FinishedAt: now.Add(time.Second), Result: internal.OperationStepRetry, RetryIn: 30 * time.Second, Error: "not ready"})
+		require.NoError(t, err)
+		err = svc.Insert(internal.OperationStep{ID: "s3", OperationID: "op2", StepName: "Remove_Runtime", StartedAt: now, FinishedAt: now, Result: internal.OperationStepFailed})
+		require.NoError(t, err)
+
+		// then
+		got, err := svc.ListByOperationID("op1")
+		require.NoError(t, err)
+		require.Len(t, got, 2)
+		assert.Equal(t, "s1", got[0].ID)
+		assert.Equal(t, internal.OperationStepRetry, got[0].Result)
+		assert.Equal(t, 30*time.Second, got[0].RetryIn)
+		assert.Equal(t, "not ready", got[0].Error)
+		assert.Equal(t, "s2", got[1].ID)
+		assert.Equal(t, "create_runtime", got[1].StageName)
+
+		// when
+		err = svc.DeleteFinishedBefore(now.Add(time.Minute))
+
+		// then
+		require.NoError(t, err)
+		got, err = svc.ListByOperationID("op1")
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		assert.Equal(t, "s2", got[0].ID)
+		got, err = svc.ListByOperationID("op2")
+		require.NoError(t, err)
+		assert.Empty(t, got)
+	})
+}
@@ -0,0 +1,126 @@
+package postsql
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbmodel"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/postsql"
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// quotaLockClass separates the advisory locks of the global accounts from other advisory locks
+const quotaLockClass = 1
+
+type quotas struct {
+	postsql.Factory
+}
+
+func NewQuotas(sess postsql.Factory) *quotas {
+	return &quotas{
+		Factory: sess,
+	}
+}
+
+func (s *quotas) Upsert(quota internal.Quota) error {
+	existing, err := s.Get(quota.GlobalAccountID, quota.PlanName)
+	switch {
+	case err == nil:
+		quota.CreatedAt = existing.CreatedAt
+	case dberr.IsNotFound(err):
+		quota.CreatedAt = time.Now()
+	default:
+		return err
+	}
+	quota.UpdatedAt = time.Now()
+	dto := dbmodel.NewQuotaDTO(quota)
+
+	sess := s.NewWriteSession()
+	exists := err == nil
+	return wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
+		var err dberr.Error
+		if exists {
+			err = sess.UpdateQuota(dto)
+		} else {
+			err = sess.InsertQuota(dto)
+		}
+		if err != nil {
+			// the quota was created or deleted in the meantime
+			if dberr.IsNotFound(err) || err.Code() == dberr.CodeAlreadyExists {
+				exists = !exists
+			}
+			log.Errorf("while saving quota for global account %s and plan %s: %v", quota.GlobalAccountID, quota.PlanName, err)
+			return false, nil
+		}
+		return true, nil
+	})
+}
+
+func (s *quotas) Get(globalAccountID, planName string) (internal.Quota, error) {
+	sess := s.NewReadSession()
+	var dto dbmodel.QuotaDTO
+	var lastErr dberr.Error
+	err := wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
+		dto, lastErr = sess.GetQuota(globalAccountID, planName)
+		if lastErr != nil {
+			if dberr.IsNotFound(lastErr) {
+				return false, lastErr
+			}
+			log.Errorf("while getting quota for global account %s and plan %s: %v", globalAccountID, planName, lastErr)
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		if lastErr != nil {
+			return internal.Quota{}, lastErr
+		}
+		return internal.Quota{}, err
+	}
+	return dto.ToQuota(), nil
+}
+
+func (s *quotas) List(filter dbmodel.QuotaFilter) ([]internal.Quota, error) {
+	sess := s.NewReadSession()
+	var dtos []dbmodel.QuotaDTO
+	var lastErr dberr.Error
+	err := wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
+		dtos, lastErr = sess.ListQuotas(filter)
+		if lastErr != nil {
+			log.Errorf("while listing quotas: %v", lastErr)
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, lastErr
+	}
+	result := make([]internal.Quota, 0, len(dtos))
+	for _, dto := range dtos {
+		result = append(result, dto.ToQuota())
+	}
+	return result, nil
+}
+
+func (s *quotas) Delete(globalAccountID, planName string) error {
+	sess := s.NewWriteSession()
+	return wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
+		err := sess.DeleteQuota(globalAccountID, planName)
+		if err != nil {
+			log.Errorf("while deleting quota for global account %s and plan %s: %v", globalAccountID, planName, err)
+			return false, nil
+		}
+		return true, nil
+	})
+}
+
+func (s *quotas) Lock(globalAccountID string) (func(), error) {
+	unlock, err := s.AdvisoryLock(quotaLockClass, globalAccountID)
+	if err != nil {
+		return nil, fmt.Errorf("while locking global account %s: %w", globalAccountID, err)
+	}
+	return unlock, nil
+}
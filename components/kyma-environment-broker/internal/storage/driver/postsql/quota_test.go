@@ -0,0 +1,116 @@
+package postsql_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/events"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/ptr"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbmodel"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuotas(t *testing.T) {
+
+	ctx := context.Background()
+
+	t.Run("Quotas", func(t *testing.T) {
+		containerCleanupFunc, cfg, err := storage.InitTestDBContainer(t.Logf, ctx, "test_DB_1")
+		require.NoError(t, err)
+		defer containerCleanupFunc()
+
+		tablesCleanupFunc, err := storage.InitTestDBTables(t, cfg.ConnectionURL())
+		require.NoError(t, err)
+		defer tablesCleanupFunc()
+
+		cipher := storage.NewEncrypter(cfg.SecretKey)
+		brokerStorage, _, err := storage.NewFromConfig(cfg, events.Config{}, cipher, logrus.StandardLogger())
+		require.NoError(t, err)
+		require.NotNil(t, brokerStorage)
+
+		svc := brokerStorage.Quotas()
+
+		// when
+		err = svc.Upsert(internal.Quota{GlobalAccountID: "ga-1", PlanName: "aws", MaxInstances: ptr.Integer(2)})
+		require.NoError(t, err)
+		err = svc.Upsert(internal.Quota{GlobalAccountID: "*", PlanName: "all_plans", MaxAutoScalerMax: ptr.Integer(40)})
+		require.NoError(t, err)
+		created, err := svc.Get("ga-1", "aws")
+		require.NoError(t, err)
+
+		err = svc.Upsert(internal.Quota{GlobalAccountID: "ga-1", PlanName: "aws", MaxInstances: ptr.Integer(3), MaxVolumeSizeGb: ptr.Integer(100)})
+		require.NoError(t, err)
+
+		// then
+		got, err := svc.Get("ga-1", "aws")
+		require.NoError(t, err)
+		assert.Equal(t, ptr.Integer(3), got.MaxInstances)
+		assert.Equal(t, ptr.Integer(100), got.MaxVolumeSizeGb)
+		assert.Nil(t, got.MaxAutoScalerMax)
+		assert.True(t, created.CreatedAt.Equal(got.CreatedAt))
+
+		list, err := svc.List(dbmodel.QuotaFilter{})
+		require.NoError(t, err)
+		assert.Len(t, list, 2)
+
+		list, err = svc.List(dbmodel.QuotaFilter{GlobalAccountIDs: []string{"*"}})
+		require.NoError(t, err)
+		require.Len(t, list, 1)
+		assert.Equal(t, ptr.Integer(40), list[0].MaxAutoScalerMax)
+
+		err = svc.Delete("ga-1", "aws")
+		require.NoError(t, err)
+		_, err = svc.Get("ga-1", "aws")
+		assert.True(t, dberr.IsNotFound(err))
+	})
+
+	t.Run("Lock", func(t *testing.T) {
+		containerCleanupFunc, cfg, err := storage.InitTestDBContainer(t.Logf, ctx, "test_DB_1")
+		require.NoError(t, err)
+		defer containerCleanupFunc()
+
+		tablesCleanupFunc, err := storage.InitTestDBTables(t, cfg.ConnectionURL())
+		require.NoError(t, err)
+		defer tablesCleanupFunc()
+
+		cipher := storage.NewEncrypter(cfg.SecretKey)
+		replica1, _, err := storage.NewFromConfig(cfg, events.Config{}, cipher, logrus.StandardLogger())
+		require.NoError(t, err)
+		replica2, _, err := storage.NewFromConfig(cfg, events.Config{}, cipher, logrus.StandardLogger())
+		require.NoError(t, err)
+
+		unlock, err := replica1.Quotas().Lock("ga-1")
+		require.NoError(t, err)
+		locked := make(chan struct{})
+
+		// when
+		go func() {
+			unlock, err := replica2.Quotas().Lock("ga-1")
+			require.NoError(t, err)
+			defer unlock()
+			close(locked)
+		}()
+		unlockOther, err := replica2.Quotas().Lock("ga-2")
+		require.NoError(t, err)
+		unlockOther()
+
+		// then
+		select {
+		case <-locked:
+			t.Fatal("the lock of the global account was acquired by two replicas")
+		case <-time.After(100 * time.Millisecond):
+		}
+		unlock()
+		select {
+		case <-locked:
+		case <-time.After(5 * time.Second):
+			t.Fatal("the lock of the global account was not released")
+		}
+	})
+}
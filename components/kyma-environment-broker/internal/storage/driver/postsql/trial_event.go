@@ -0,0 +1,63 @@
+package postsql
+
+import (
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbmodel"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/postsql"
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+type trialEvents struct {
+	postsql.Factory
+}
+
+func NewTrialEvents(sess postsql.Factory) *trialEvents {
+	return &trialEvents{
+		Factory: sess,
+	}
+}
+
+func (s *trialEvents) Insert(event internal.TrialEvent) error {
+	sess := s.NewWriteSession()
+	dto := dbmodel.NewTrialEventDTO(event)
+	var lastErr dberr.Error
+	err := wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
+		lastErr = sess.InsertTrialEvent(dto)
+		if lastErr != nil {
+			if lastErr.Code() == dberr.CodeAlreadyExists {
+				return false, lastErr
+			}
+			log.Errorf("while inserting trial event %s of instance %s: %v", event.Type, event.InstanceID, lastErr)
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil && lastErr != nil {
+		return lastErr
+	}
+	return err
+}
+
+func (s *trialEvents) List(filter dbmodel.TrialEventFilter) ([]internal.TrialEvent, error) {
+	sess := s.NewReadSession()
+	var dtos []dbmodel.TrialEventDTO
+	var lastErr dberr.Error
+	err := wait.PollImmediate(defaultRetryInterval, defaultRetryTimeout, func() (bool, error) {
+		dtos, lastErr = sess.ListTrialEvents(filter)
+		if lastErr != nil {
+			log.Errorf("while listing trial events: %v", lastErr)
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, lastErr
+	}
+	result := make([]internal.TrialEvent, 0, len(dtos))
+	for _, dto := range dtos {
+		result = append(result, dto.ToTrialEvent())
+	}
+	return result, nil
+}
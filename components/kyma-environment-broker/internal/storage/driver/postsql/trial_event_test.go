@@ -0,0 +1,60 @@
+package postsql_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/events"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbmodel"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrialEvents(t *testing.T) {
+
+	ctx := context.Background()
+
+	t.Run("Trial events", func(t *testing.T) {
+		containerCleanupFunc, cfg, err := storage.InitTestDBContainer(t.Logf, ctx, "test_DB_1")
+		require.NoError(t, err)
+		defer containerCleanupFunc()
+
+		tablesCleanupFunc, err := storage.InitTestDBTables(t, cfg.ConnectionURL())
+		require.NoError(t, err)
+		defer tablesCleanupFunc()
+
+		cipher := storage.NewEncrypter(cfg.SecretKey)
+		brokerStorage, _, err := storage.NewFromConfig(cfg, events.Config{}, cipher, logrus.StandardLogger())
+		require.NoError(t, err)
+		require.NotNil(t, brokerStorage)
+
+		svc := brokerStorage.TrialEvents()
+		now := time.Now().UTC().Truncate(time.Second)
+
+		// when
+		err = svc.Insert(internal.TrialEvent{ID: "e1", InstanceID: "i1", SubAccountID: "sa1", Type: internal.TrialEventWarning, Period: 7 * 24 * time.Hour, CreatedAt: now})
+		require.NoError(t, err)
+		err = svc.Insert(internal.TrialEvent{ID: "e2", InstanceID: "i1", SubAccountID: "sa1", Type: internal.TrialEventExtension, Period: 7 * 24 * time.Hour, Message: "support ticket", CreatedAt: now.Add(time.Minute)})
+		require.NoError(t, err)
+		err = svc.Insert(internal.TrialEvent{ID: "e3", InstanceID: "i2", SubAccountID: "sa1", Type: internal.TrialEventExtension, Period: time.Hour, CreatedAt: now.Add(2 * time.Minute)})
+
+		// then
+		assert.True(t, dberr.IsAlreadyExists(err))
+
+		got, err := svc.List(dbmodel.TrialEventFilter{InstanceIDs: []string{"i1"}})
+		require.NoError(t, err)
+		require.Len(t, got, 2)
+		assert.Equal(t, internal.TrialEventWarning, got[0].Type)
+		assert.Equal(t, 7*24*time.Hour, got[1].Period)
+		assert.Equal(t, "support ticket", got[1].Message)
+
+		extensions, err := svc.List(dbmodel.TrialEventFilter{SubAccountIDs: []string{"sa1"}, Types: []internal.TrialEventType{internal.TrialEventExtension}})
+		require.NoError(t, err)
+		assert.Len(t, extensions, 1)
+	})
+}
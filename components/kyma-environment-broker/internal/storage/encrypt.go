@@ -146,3 +146,38 @@ func (e *Encrypter) DecryptKubeconfig(provisioningParameters *internal.Provision
 	provisioningParameters.Parameters.Kubeconfig = string(decryptedKubeconfig)
 	return nil
 }
+
+func (e *Encrypter) EncryptHyperscalerAccount(provisioningParameters *internal.ProvisioningParameters) error {
+	account := provisioningParameters.Parameters.HyperscalerAccount
+	if account == nil {
+		return nil
+	}
+	// the parameters are passed by value but the map is shared, a new map must be created to not encrypt the caller's credentials
+	encrypted := make(map[string]string, len(account.Credentials))
+	for key, value := range account.Credentials {
+		encryptedValue, err := e.Encrypt([]byte(value))
+		if err != nil {
+			return fmt.Errorf("while encrypting hyperscaler account %s: %w", key, err)
+		}
+		encrypted[key] = string(encryptedValue)
+	}
+	provisioningParameters.Parameters.HyperscalerAccount = &internal.HyperscalerAccountDTO{Credentials: encrypted}
+	return nil
+}
+
+func (e *Encrypter) DecryptHyperscalerAccount(provisioningParameters *internal.ProvisioningParameters) error {
+	account := provisioningParameters.Parameters.HyperscalerAccount
+	if account == nil {
+		return nil
+	}
+	decrypted := make(map[string]string, len(account.Credentials))
+	for key, value := range account.Credentials {
+		decryptedValue, err := e.Decrypt([]byte(value))
+		if err != nil {
+			return fmt.Errorf("while decrypting hyperscaler account %s: %w", key, err)
+		}
+		decrypted[key] = string(decryptedValue)
+	}
+	provisioningParameters.Parameters.HyperscalerAccount = &internal.HyperscalerAccountDTO{Credentials: decrypted}
+	return nil
+}
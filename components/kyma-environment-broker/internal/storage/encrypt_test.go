@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"testing"
 
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"k8s.io/apimachinery/pkg/util/rand"
@@ -70,3 +71,28 @@ func TestNewEncrypter(t *testing.T) {
 	})
 
 }
+
+func TestEncrypter_HyperscalerAccount(t *testing.T) {
+	// given
+	e := NewEncrypter(rand.String(32))
+	credentials := map[string]string{"accessKeyID": "key-id", "secretAccessKey": "secret"}
+	pp := internal.ProvisioningParameters{Parameters: internal.ProvisioningParametersDTO{
+		HyperscalerAccount: &internal.HyperscalerAccountDTO{Credentials: credentials},
+	}}
+	encrypted := pp
+
+	// when
+	err := e.EncryptHyperscalerAccount(&encrypted)
+	require.NoError(t, err)
+
+	// then
+	assert.NotEqual(t, "secret", encrypted.Parameters.HyperscalerAccount.Credentials["secretAccessKey"])
+	assert.Equal(t, "secret", pp.Parameters.HyperscalerAccount.Credentials["secretAccessKey"])
+
+	// when
+	err = e.DecryptHyperscalerAccount(&encrypted)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, credentials, encrypted.Parameters.HyperscalerAccount.Credentials)
+}
@@ -76,6 +76,31 @@ type Orchestrations interface {
 	List(filter dbmodel.OrchestrationFilter) ([]internal.Orchestration, int, int, error)
 }
 
+type Quotas interface {
+	// Upsert creates the quota or replaces the limits of the existing one
+	Upsert(quota internal.Quota) error
+	Get(globalAccountID, planName string) (internal.Quota, error)
+	List(filter dbmodel.QuotaFilter) ([]internal.Quota, error)
+	Delete(globalAccountID, planName string) error
+	// Lock blocks until the lock of the global account is acquired, the lock is shared by all KEB replicas
+	// and is held until the returned function is called
+	Lock(globalAccountID string) (unlock func(), err error)
+}
+
+type TrialEvents interface {
+	// Insert saves the event, the second extension of a subaccount is rejected with the AlreadyExists error
+	Insert(event internal.TrialEvent) error
+	List(filter dbmodel.TrialEventFilter) ([]internal.TrialEvent, error)
+}
+
+type OperationSteps interface {
+	Insert(step internal.OperationStep) error
+	// ListByOperationID returns the runs of the steps of the operation ordered by their start
+	ListByOperationID(operationID string) ([]internal.OperationStep, error)
+	// DeleteFinishedBefore removes the runs of the steps finished before the given time
+	DeleteFinishedBefore(until time.Time) error
+}
+
 type RuntimeStates interface {
 	Insert(runtimeState internal.RuntimeState) error
 	GetByOperationID(operationID string) (internal.RuntimeState, error)
@@ -1,6 +1,8 @@
 package postsql
 
 import (
+	"context"
+	"database/sql/driver"
 	"time"
 
 	dbr "github.com/gocraft/dbr"
@@ -9,13 +11,19 @@ import (
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbmodel"
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/predicate"
+	log "github.com/sirupsen/logrus"
 )
 
+const advisoryLockTimeout = 30 * time.Second
+
 //go:generate mockery --name=Factory
 type Factory interface {
 	NewReadSession() ReadSession
 	NewWriteSession() WriteSession
 	NewSessionWithinTransaction() (WriteSessionWithinTransaction, dberr.Error)
+	// AdvisoryLock blocks until the session-level advisory lock of the key in the class is acquired on a dedicated connection,
+	// the lock is shared by all KEB replicas and is released by the returned function or when the connection is lost
+	AdvisoryLock(class int, key string) (unlock func(), err dberr.Error)
 }
 
 //go:generate mockery --name=ReadSession
@@ -52,6 +60,10 @@ type ReadSession interface {
 	GetLatestRuntimeStateWithKymaVersionByRuntimeID(runtimeID string) (dbmodel.RuntimeStateDTO, dberr.Error)
 	GetLatestRuntimeStateWithOIDCConfigByRuntimeID(runtimeID string) (dbmodel.RuntimeStateDTO, dberr.Error)
 	ListEvents(filter events.EventFilter) ([]events.EventDTO, error)
+	GetQuota(globalAccountID, planName string) (dbmodel.QuotaDTO, dberr.Error)
+	ListQuotas(filter dbmodel.QuotaFilter) ([]dbmodel.QuotaDTO, dberr.Error)
+	ListTrialEvents(filter dbmodel.TrialEventFilter) ([]dbmodel.TrialEventDTO, dberr.Error)
+	ListOperationSteps(operationID string) ([]dbmodel.OperationStepDTO, dberr.Error)
 }
 
 //go:generate mockery --name=WriteSession
@@ -66,6 +78,12 @@ type WriteSession interface {
 	InsertRuntimeState(state dbmodel.RuntimeStateDTO) dberr.Error
 	InsertEvent(level events.EventLevel, message, instanceID, operationID string) dberr.Error
 	DeleteEvents(until time.Time) dberr.Error
+	InsertQuota(quota dbmodel.QuotaDTO) dberr.Error
+	UpdateQuota(quota dbmodel.QuotaDTO) dberr.Error
+	DeleteQuota(globalAccountID, planName string) dberr.Error
+	InsertTrialEvent(event dbmodel.TrialEventDTO) dberr.Error
+	InsertOperationStep(step dbmodel.OperationStepDTO) dberr.Error
+	DeleteOperationSteps(until time.Time) dberr.Error
 }
 
 type Transaction interface {
@@ -114,3 +132,27 @@ func (sf *factory) NewSessionWithinTransaction() (WriteSessionWithinTransaction,
 		transaction: dbTransaction,
 	}, nil
 }
+
+func (sf *factory) AdvisoryLock(class int, key string) (func(), dberr.Error) {
+	ctx, cancel := context.WithTimeout(context.Background(), advisoryLockTimeout)
+	defer cancel()
+
+	conn, err := sf.connection.DB.Conn(ctx)
+	if err != nil {
+		return nil, dberr.Internal("Failed to obtain connection: %s", err)
+	}
+	// the key is hashed, a collision only serializes the holders of both keys
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1, hashtext($2))", class, key); err != nil {
+		conn.Close()
+		return nil, dberr.Internal("Failed to acquire advisory lock %d/%s: %s", class, key, err)
+	}
+
+	return func() {
+		if _, err := conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1, hashtext($2))", class, key); err != nil {
+			log.Errorf("while releasing advisory lock %d/%s, closing the connection: %s", class, key, err)
+			// the lock is released together with the session, so the connection must not return to the pool
+			conn.Raw(func(interface{}) error { return driver.ErrBadConn })
+		}
+		conn.Close()
+	}, nil
+}
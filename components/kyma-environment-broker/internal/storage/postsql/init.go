@@ -17,6 +17,9 @@ const (
 	OperationTableName     = "operations"
 	OrchestrationTableName = "orchestrations"
 	RuntimeStateTableName  = "runtime_states"
+	QuotaTableName         = "quotas"
+	TrialEventTableName    = "trial_events"
+	OperationStepTableName = "operation_steps"
 	CreatedAtField         = "created_at"
 )
 
@@ -106,7 +106,10 @@ func (r readSession) FindAllInstancesForSubAccounts(subAccountslist []string) ([
 func (r readSession) GetLastOperation(instanceID string) (dbmodel.OperationDTO, dberr.Error) {
 	inst := dbr.Eq("instance_id", instanceID)
 	state := dbr.Neq("state", []string{orchestration.Pending, orchestration.Canceled})
-	condition := dbr.And(inst, state)
+	// the canceled upgrades did not change the instance, the OSB operations canceled through the operations API are the last operations
+	canceled := dbr.And(dbr.Eq("state", orchestration.Canceled),
+		dbr.Neq("type", []string{string(internal.OperationTypeUpgradeKyma), string(internal.OperationTypeUpgradeCluster)}))
+	condition := dbr.And(inst, dbr.Or(state, canceled))
 	operation, err := r.getLastOperation(condition)
 	if err != nil {
 		switch {
@@ -889,3 +892,77 @@ func (r readSession) getOrchestrationCount(filter dbmodel.OrchestrationFilter) (
 
 	return res.Total, err
 }
+
+func (r readSession) GetQuota(globalAccountID, planName string) (dbmodel.QuotaDTO, dberr.Error) {
+	var quota dbmodel.QuotaDTO
+	err := r.session.
+		Select("*").
+		From(QuotaTableName).
+		Where(dbr.Eq("global_account_id", globalAccountID)).
+		Where(dbr.Eq("plan_name", planName)).
+		LoadOne(&quota)
+
+	if err != nil {
+		if err == dbr.ErrNotFound {
+			return dbmodel.QuotaDTO{}, dberr.NotFound("cannot find quota for global account %s and plan %s", globalAccountID, planName)
+		}
+		return dbmodel.QuotaDTO{}, dberr.Internal("Failed to get quota: %s", err)
+	}
+	return quota, nil
+}
+
+func (r readSession) ListQuotas(filter dbmodel.QuotaFilter) ([]dbmodel.QuotaDTO, dberr.Error) {
+	var quotas []dbmodel.QuotaDTO
+	stmt := r.session.
+		Select("*").
+		From(QuotaTableName).
+		OrderBy("global_account_id").
+		OrderBy("plan_name")
+	if len(filter.GlobalAccountIDs) > 0 {
+		stmt.Where("global_account_id IN ?", filter.GlobalAccountIDs)
+	}
+	if len(filter.PlanNames) > 0 {
+		stmt.Where("plan_name IN ?", filter.PlanNames)
+	}
+
+	if _, err := stmt.Load(&quotas); err != nil {
+		return nil, dberr.Internal("Failed to get quotas: %s", err)
+	}
+	return quotas, nil
+}
+
+func (r readSession) ListTrialEvents(filter dbmodel.TrialEventFilter) ([]dbmodel.TrialEventDTO, dberr.Error) {
+	var trialEvents []dbmodel.TrialEventDTO
+	stmt := r.session.
+		Select("*").
+		From(TrialEventTableName).
+		OrderBy(CreatedAtField)
+	if len(filter.InstanceIDs) > 0 {
+		stmt.Where("instance_id IN ?", filter.InstanceIDs)
+	}
+	if len(filter.SubAccountIDs) > 0 {
+		stmt.Where("sub_account_id IN ?", filter.SubAccountIDs)
+	}
+	if len(filter.Types) > 0 {
+		stmt.Where("type IN ?", filter.Types)
+	}
+
+	if _, err := stmt.Load(&trialEvents); err != nil {
+		return nil, dberr.Internal("Failed to get trial events: %s", err)
+	}
+	return trialEvents, nil
+}
+
+func (r readSession) ListOperationSteps(operationID string) ([]dbmodel.OperationStepDTO, dberr.Error) {
+	var steps []dbmodel.OperationStepDTO
+	_, err := r.session.
+		Select("*").
+		From(OperationStepTableName).
+		Where(dbr.Eq("operation_id", operationID)).
+		OrderBy("started_at").
+		Load(&steps)
+	if err != nil {
+		return nil, dberr.Internal("Failed to get steps of operation %s: %s", operationID, err)
+	}
+	return steps, nil
+}
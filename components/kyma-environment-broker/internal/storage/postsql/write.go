@@ -278,6 +278,88 @@ func (ws writeSession) RollbackUnlessCommitted() {
 	ws.transaction.RollbackUnlessCommitted()
 }
 
+func (ws writeSession) InsertQuota(quota dbmodel.QuotaDTO) dberr.Error {
+	_, err := ws.insertInto(QuotaTableName).
+		Pair("global_account_id", quota.GlobalAccountID).
+		Pair("plan_name", quota.PlanName).
+		Pair("max_instances", quota.MaxInstances).
+		Pair("max_auto_scaler_max", quota.MaxAutoScalerMax).
+		Pair("max_volume_size_gb", quota.MaxVolumeSizeGb).
+		Pair("created_at", quota.CreatedAt).
+		Pair("updated_at", quota.UpdatedAt).
+		Exec()
+
+	if err != nil {
+		if err, ok := err.(*pq.Error); ok {
+			if err.Code == UniqueViolationErrorCode {
+				return dberr.AlreadyExists("quota for global account %s and plan %s already exist", quota.GlobalAccountID, quota.PlanName)
+			}
+		}
+		return dberr.Internal("Failed to insert record to quotas table: %s", err)
+	}
+
+	return nil
+}
+
+func (ws writeSession) UpdateQuota(quota dbmodel.QuotaDTO) dberr.Error {
+	res, err := ws.update(QuotaTableName).
+		Where(dbr.Eq("global_account_id", quota.GlobalAccountID)).
+		Where(dbr.Eq("plan_name", quota.PlanName)).
+		Set("max_instances", quota.MaxInstances).
+		Set("max_auto_scaler_max", quota.MaxAutoScalerMax).
+		Set("max_volume_size_gb", quota.MaxVolumeSizeGb).
+		Set("updated_at", quota.UpdatedAt).
+		Exec()
+
+	if err != nil {
+		return dberr.Internal("Failed to update record to quotas table: %s", err)
+	}
+	rAffected, e := res.RowsAffected()
+	if e != nil {
+		return dberr.Internal("the DB driver does not support RowsAffected operation")
+	}
+	if rAffected == int64(0) {
+		return dberr.NotFound("Cannot find quota for global account %s and plan %s", quota.GlobalAccountID, quota.PlanName)
+	}
+
+	return nil
+}
+
+func (ws writeSession) DeleteQuota(globalAccountID, planName string) dberr.Error {
+	_, err := ws.deleteFrom(QuotaTableName).
+		Where(dbr.Eq("global_account_id", globalAccountID)).
+		Where(dbr.Eq("plan_name", planName)).
+		Exec()
+
+	if err != nil {
+		return dberr.Internal("Failed to delete record from quotas table: %s", err)
+	}
+	return nil
+}
+
+func (ws writeSession) InsertTrialEvent(event dbmodel.TrialEventDTO) dberr.Error {
+	_, err := ws.insertInto(TrialEventTableName).
+		Pair("id", event.ID).
+		Pair("instance_id", event.InstanceID).
+		Pair("sub_account_id", event.SubAccountID).
+		Pair("type", event.Type).
+		Pair("period_seconds", event.PeriodSeconds).
+		Pair("message", event.Message).
+		Pair("created_at", event.CreatedAt).
+		Exec()
+
+	if err != nil {
+		if err, ok := err.(*pq.Error); ok {
+			if err.Code == UniqueViolationErrorCode {
+				return dberr.AlreadyExists("trial event %s of subaccount %s already exist", event.Type, event.SubAccountID)
+			}
+		}
+		return dberr.Internal("Failed to insert record to trial_events table: %s", err)
+	}
+
+	return nil
+}
+
 func (ws writeSession) insertInto(table string) *dbr.InsertStmt {
 	if ws.transaction != nil {
 		return ws.transaction.InsertInto(table)
@@ -301,3 +383,38 @@ func (ws writeSession) update(table string) *dbr.UpdateStmt {
 
 	return ws.session.Update(table)
 }
+
+func (ws writeSession) InsertOperationStep(step dbmodel.OperationStepDTO) dberr.Error {
+	_, err := ws.insertInto(OperationStepTableName).
+		Pair("id", step.ID).
+		Pair("operation_id", step.OperationID).
+		Pair("step_name", step.StepName).
+		Pair("stage_name", step.StageName).
+		Pair("started_at", step.StartedAt).
+		Pair("finished_at", step.FinishedAt).
+		Pair("result", step.Result).
+		Pair("retry_in_seconds", step.RetryInSeconds).
+		Pair("error_message", step.ErrorMessage).
+		Exec()
+
+	if err != nil {
+		if err, ok := err.(*pq.Error); ok {
+			if err.Code == UniqueViolationErrorCode {
+				return dberr.AlreadyExists("operation step with id %s already exist", step.ID)
+			}
+		}
+		return dberr.Internal("Failed to insert record to operation_steps table: %s", err)
+	}
+
+	return nil
+}
+
+func (ws writeSession) DeleteOperationSteps(until time.Time) dberr.Error {
+	_, err := ws.deleteFrom(OperationStepTableName).
+		Where(dbr.Lt("finished_at", until)).
+		Exec()
+	if err != nil {
+		return dberr.Internal("failed to delete operation steps finished before %v: %v", until.Format(time.RFC1123Z), err)
+	}
+	return nil
+}
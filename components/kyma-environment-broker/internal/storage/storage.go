@@ -23,6 +23,9 @@ type BrokerStorage interface {
 	Orchestrations() Orchestrations
 	RuntimeStates() RuntimeStates
 	Events() Events
+	Quotas() Quotas
+	TrialEvents() TrialEvents
+	OperationSteps() OperationSteps
 }
 
 const (
@@ -51,6 +54,9 @@ func NewFromConfig(cfg Config, evcfg events.Config, cipher postgres.Cipher, log
 		orchestrations: postgres.NewOrchestrations(fact),
 		runtimeStates:  postgres.NewRuntimeStates(fact, cipher),
 		events:         events.New(evcfg, eventstorage.New(fact, log)),
+		quotas:         postgres.NewQuotas(fact),
+		trialEvents:    postgres.NewTrialEvents(fact),
+		operationSteps: postgres.NewOperationSteps(fact),
 	}, connection, nil
 }
 
@@ -62,6 +68,9 @@ func NewMemoryStorage() BrokerStorage {
 		orchestrations: memory.NewOrchestrations(),
 		runtimeStates:  memory.NewRuntimeStates(),
 		events:         events.New(events.Config{}, NewInMemoryEvents()),
+		quotas:         memory.NewQuotas(),
+		trialEvents:    memory.NewTrialEvents(),
+		operationSteps: memory.NewOperationSteps(),
 	}
 }
 
@@ -119,6 +128,9 @@ type storage struct {
 	orchestrations Orchestrations
 	runtimeStates  RuntimeStates
 	events         Events
+	quotas         Quotas
+	trialEvents    TrialEvents
+	operationSteps OperationSteps
 }
 
 func (s storage) Instances() Instances {
@@ -148,3 +160,15 @@ func (s storage) RuntimeStates() RuntimeStates {
 func (s storage) Events() Events {
 	return s.events
 }
+
+func (s storage) Quotas() Quotas {
+	return s.quotas
+}
+
+func (s storage) TrialEvents() TrialEvents {
+	return s.trialEvents
+}
+
+func (s storage) OperationSteps() OperationSteps {
+	return s.operationSteps
+}
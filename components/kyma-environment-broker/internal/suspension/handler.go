@@ -19,6 +19,7 @@ type ContextUpdateHandler struct {
 	operations          storage.Operations
 	provisioningQueue   Adder
 	deprovisioningQueue Adder
+	planRegistry        *broker.PlanRegistry
 
 	log logrus.FieldLogger
 }
@@ -27,17 +28,18 @@ type Adder interface {
 	Add(processId string)
 }
 
-func NewContextUpdateHandler(operations storage.Operations, provisioningQueue Adder, deprovisioningQueue Adder, l logrus.FieldLogger) *ContextUpdateHandler {
+func NewContextUpdateHandler(operations storage.Operations, provisioningQueue Adder, deprovisioningQueue Adder, planRegistry *broker.PlanRegistry, l logrus.FieldLogger) *ContextUpdateHandler {
 	return &ContextUpdateHandler{
 		operations:          operations,
 		provisioningQueue:   provisioningQueue,
 		deprovisioningQueue: deprovisioningQueue,
+		planRegistry:        planRegistry,
 		log:                 l,
 	}
 }
 
 // Handle performs suspension/unsuspension for given instance.
-// Applies only when 'Active' parameter has changes and ServicePlanID is `Trial`
+// Applies only when 'Active' parameter has changes and the lifecycle of the plan enables the suspension, by default for the expirable plans
 func (h *ContextUpdateHandler) Handle(instance *internal.Instance, newCtx internal.ERSContext) (bool, error) {
 	l := h.log.WithFields(logrus.Fields{
 		"instanceID":      instance.InstanceID,
@@ -45,8 +47,8 @@ func (h *ContextUpdateHandler) Handle(instance *internal.Instance, newCtx intern
 		"globalAccountID": instance.GlobalAccountID,
 	})
 
-	if !broker.IsTrialPlan(instance.ServicePlanID) {
-		l.Info("Context update for non-trial instance, skipping")
+	if !h.planRegistry.LifecycleByID(instance.ServicePlanID).Suspension {
+		l.Info("Context update for an instance of a plan without suspension, skipping")
 		return false, nil
 	}
 
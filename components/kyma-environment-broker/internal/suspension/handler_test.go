@@ -25,7 +25,7 @@ func TestSuspension(t *testing.T) {
 	deprovisioning := NewDummyQueue()
 	st := storage.NewMemoryStorage()
 
-	svc := NewContextUpdateHandler(st.Operations(), provisioning, deprovisioning, logrus.New())
+	svc := NewContextUpdateHandler(st.Operations(), provisioning, deprovisioning, nil, logrus.New())
 	instance := fixInstance(fixActiveErsContext())
 	st.Instances().Insert(*instance)
 
@@ -43,6 +43,51 @@ func TestSuspension(t *testing.T) {
 	assert.Equal(t, instance.InstanceID, op.InstanceID)
 }
 
+func TestSuspension_PlanLifecycle(t *testing.T) {
+	t.Run("should skip suspension of the free plan by default", func(t *testing.T) {
+		// given
+		deprovisioning := NewDummyQueue()
+		st := storage.NewMemoryStorage()
+		svc := NewContextUpdateHandler(st.Operations(), NewDummyQueue(), deprovisioning, nil, logrus.New())
+		instance := fixInstance(fixActiveErsContext())
+		instance.ServicePlanID = broker.FreemiumPlanID
+		st.Instances().Insert(*instance)
+
+		// when
+		changed, err := svc.Handle(instance, fixInactiveErsContext())
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, changed)
+		assertQueue(t, deprovisioning)
+	})
+
+	t.Run("should suspend the free plan with suspension in its lifecycle", func(t *testing.T) {
+		// given
+		planRegistry, err := broker.NewPlanRegistry(broker.PlanDefinitions{
+			Lifecycles: map[string]broker.PlanLifecycle{broker.FreemiumPlanName: {Suspension: true}},
+		})
+		require.NoError(t, err)
+		deprovisioning := NewDummyQueue()
+		st := storage.NewMemoryStorage()
+		svc := NewContextUpdateHandler(st.Operations(), NewDummyQueue(), deprovisioning, planRegistry, logrus.New())
+		instance := fixInstance(fixActiveErsContext())
+		instance.ServicePlanID = broker.FreemiumPlanID
+		st.Instances().Insert(*instance)
+
+		// when
+		changed, err := svc.Handle(instance, fixInactiveErsContext())
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, changed)
+		op, err := st.Operations().GetDeprovisioningOperationByInstanceID("instance-id")
+		require.NoError(t, err)
+		assert.True(t, op.Temporary)
+		assertQueue(t, deprovisioning, op.ID)
+	})
+}
+
 func TestSuspension_Retrigger(t *testing.T) {
 	t.Run("should skip suspension when temporary deprovisioning operation already succeeded", func(t *testing.T) {
 		// given
@@ -50,7 +95,7 @@ func TestSuspension_Retrigger(t *testing.T) {
 		deprovisioning := NewDummyQueue()
 		st := storage.NewMemoryStorage()
 
-		svc := NewContextUpdateHandler(st.Operations(), provisioning, deprovisioning, logrus.New())
+		svc := NewContextUpdateHandler(st.Operations(), provisioning, deprovisioning, nil, logrus.New())
 		instance := fixInstance(fixInactiveErsContext())
 		st.Instances().Insert(*instance)
 		st.Operations().InsertDeprovisioningOperation(internal.DeprovisioningOperation{
@@ -86,7 +131,7 @@ func TestSuspension_Retrigger(t *testing.T) {
 		deprovisioning := NewDummyQueue()
 		st := storage.NewMemoryStorage()
 
-		svc := NewContextUpdateHandler(st.Operations(), provisioning, deprovisioning, logrus.New())
+		svc := NewContextUpdateHandler(st.Operations(), provisioning, deprovisioning, nil, logrus.New())
 		instance := fixInstance(fixInactiveErsContext())
 		st.Instances().Insert(*instance)
 		st.Operations().InsertDeprovisioningOperation(internal.DeprovisioningOperation{
@@ -133,7 +178,7 @@ func TestUnsuspension(t *testing.T) {
 	deprovisioning := NewDummyQueue()
 	st := storage.NewMemoryStorage()
 
-	svc := NewContextUpdateHandler(st.Operations(), provisioning, deprovisioning, logrus.New())
+	svc := NewContextUpdateHandler(st.Operations(), provisioning, deprovisioning, nil, logrus.New())
 	instance := fixInstance(fixInactiveErsContext())
 	instance.InstanceDetails.ShootName = "c-012345"
 	instance.InstanceDetails.ShootDomain = "c-012345.sap.com"
@@ -167,7 +212,7 @@ func TestUnsuspensionForDeprovisioningInstance(t *testing.T) {
 	deprovisioning := NewDummyQueue()
 	st := storage.NewMemoryStorage()
 
-	svc := NewContextUpdateHandler(st.Operations(), provisioning, deprovisioning, logrus.New())
+	svc := NewContextUpdateHandler(st.Operations(), provisioning, deprovisioning, nil, logrus.New())
 	instance := fixInstance(fixInactiveErsContext())
 	instance.InstanceDetails.ShootName = "c-012345"
 	instance.InstanceDetails.ShootDomain = "c-012345.sap.com"
@@ -195,7 +240,7 @@ func TestUnsuspensionForExpiredInstance(t *testing.T) {
 	deprovisioning := NewDummyQueue()
 	st := storage.NewMemoryStorage()
 
-	svc := NewContextUpdateHandler(st.Operations(), provisioning, deprovisioning, logrus.New())
+	svc := NewContextUpdateHandler(st.Operations(), provisioning, deprovisioning, nil, logrus.New())
 	instance := fixInstance(fixInactiveErsContext())
 	instance.InstanceDetails.ShootName = "c-012345"
 	instance.InstanceDetails.ShootDomain = "c-012345.sap.com"
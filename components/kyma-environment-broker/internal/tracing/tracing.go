@@ -0,0 +1,152 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/kyma-project/control-plane/components/kyma-environment-broker"
+
+type Config struct {
+	Enabled bool `envconfig:"default=false"`
+	// Endpoint is the OTLP/HTTP traces endpoint of the collector, e.g. http://otel-collector.kyma-system:4318/v1/traces
+	Endpoint    string `envconfig:"optional"`
+	ServiceName string `envconfig:"default=kyma-environment-broker"`
+}
+
+// Init configures the propagation of the trace context in the W3C headers and, if the tracing is enabled, the export of the spans to the OTLP endpoint.
+// The returned function flushes the spans which are not exported yet.
+func Init(cfg Config, log logrus.FieldLogger) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	if !cfg.Enabled {
+		return func(context.Context) error { return nil }, nil
+	}
+	if cfg.Endpoint == "" {
+		return nil, fmt.Errorf("tracing is enabled but the OTLP endpoint is not set")
+	}
+
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		log.Errorf("while tracing: %s", err)
+	}))
+	exporter, err := NewExporter(context.Background(), cfg.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	provider := NewTracerProvider(exporter, cfg.ServiceName)
+	otel.SetTracerProvider(provider)
+	log.Infof("Exporting traces to %s", cfg.Endpoint)
+
+	return provider.Shutdown, nil
+}
+
+// NewExporter returns the exporter which sends the spans to the OTLP/HTTP traces endpoint, the endpoint is called without TLS if its scheme is http
+func NewExporter(ctx context.Context, endpoint string) (*otlptrace.Exporter, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("while parsing the OTLP endpoint: %w", err)
+	}
+	options := []otlptracehttp.Option{otlptracehttp.WithEndpoint(u.Host)}
+	if u.Path != "" {
+		options = append(options, otlptracehttp.WithURLPath(u.Path))
+	}
+	if u.Scheme == "http" {
+		options = append(options, otlptracehttp.WithInsecure())
+	}
+	exporter, err := otlptracehttp.New(ctx, options...)
+	if err != nil {
+		return nil, fmt.Errorf("while creating the OTLP exporter: %w", err)
+	}
+	return exporter, nil
+}
+
+// NewTracerProvider returns the provider which samples all spans and exports them in batches with the given exporter
+func NewTracerProvider(exporter sdktrace.SpanExporter, serviceName string) *sdktrace.TracerProvider {
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", serviceName))),
+	)
+}
+
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// End records the error in the span and ends it
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// OperationContext returns the context with the root span of the operation trace. The root span is started
+// and its IDs are stored in the operation when the operation is processed for the first time.
+func OperationContext(ctx context.Context, operation *internal.Operation) context.Context {
+	if spanContext, ok := remoteSpanContext(operation.TraceID, operation.RootSpanID); ok {
+		return trace.ContextWithRemoteSpanContext(ctx, spanContext)
+	}
+
+	ctx, span := Tracer().Start(ctx, fmt.Sprintf("%s operation", operation.Type), trace.WithNewRoot(), trace.WithAttributes(OperationAttributes(*operation)...))
+	span.End()
+	if span.SpanContext().IsValid() {
+		operation.TraceID = span.SpanContext().TraceID().String()
+		operation.RootSpanID = span.SpanContext().SpanID().String()
+	}
+	return ctx
+}
+
+// StepContext returns the context with the span of the step which processes the operation, the steps pass it to the clients
+// so the client spans and the trace context sent to the called services belong to the operation trace.
+// Outside of a step the context has the root span of the operation trace.
+func StepContext(operation internal.Operation) context.Context {
+	spanID := operation.StepSpanID
+	if spanID == "" {
+		spanID = operation.RootSpanID
+	}
+	if spanContext, ok := remoteSpanContext(operation.TraceID, spanID); ok {
+		return trace.ContextWithRemoteSpanContext(context.Background(), spanContext)
+	}
+	return context.Background()
+}
+
+func remoteSpanContext(traceIDHex, spanIDHex string) (trace.SpanContext, bool) {
+	traceID, err := trace.TraceIDFromHex(traceIDHex)
+	if err != nil {
+		return trace.SpanContext{}, false
+	}
+	spanID, err := trace.SpanIDFromHex(spanIDHex)
+	if err != nil {
+		return trace.SpanContext{}, false
+	}
+	return trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	}), true
+}
+
+func OperationAttributes(operation internal.Operation) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String("keb.operation.id", operation.ID),
+		attribute.String("keb.operation.type", string(operation.Type)),
+		attribute.String("keb.instance.id", operation.InstanceID),
+		attribute.String("keb.runtime.id", operation.RuntimeID),
+		attribute.String("keb.plan.id", operation.ProvisioningParameters.PlanID),
+	}
+}
@@ -0,0 +1,149 @@
+package tracing
+
+import (
+	"context"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	collectortrace "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestOperationContext(t *testing.T) {
+	// given
+	exporter := setUpTracing(t)
+	operation := internal.Operation{ID: "op-id", Type: internal.OperationTypeProvision}
+
+	// when
+	_, first := Tracer().Start(OperationContext(context.Background(), &operation), "first")
+	first.End()
+	_, second := Tracer().Start(OperationContext(context.Background(), &operation), "second")
+	second.End()
+
+	// then
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 3)
+	root := spans[0]
+	assert.Equal(t, "provision operation", root.Name)
+	assert.Equal(t, root.SpanContext.TraceID().String(), operation.TraceID)
+	assert.Equal(t, root.SpanContext.SpanID().String(), operation.RootSpanID)
+	for _, span := range spans[1:] {
+		assert.Equal(t, root.SpanContext.TraceID(), span.SpanContext.TraceID())
+		assert.Equal(t, root.SpanContext.SpanID(), span.Parent.SpanID())
+	}
+}
+
+func TestStepContext(t *testing.T) {
+	// given
+	exporter := setUpTracing(t)
+	operation := internal.Operation{ID: "op-id", Type: internal.OperationTypeProvision}
+	ctx, step := Tracer().Start(OperationContext(context.Background(), &operation), "step")
+	operation.StepSpanID = step.SpanContext().SpanID().String()
+
+	// when
+	_, client := Tracer().Start(StepContext(operation), "client")
+	client.End()
+	step.End()
+
+	// then
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 3)
+	assert.Equal(t, "client", spans[1].Name)
+	assert.Equal(t, trace.SpanContextFromContext(ctx).TraceID(), spans[1].SpanContext.TraceID())
+	assert.Equal(t, step.SpanContext().SpanID(), spans[1].Parent.SpanID())
+	assert.False(t, trace.SpanContextFromContext(StepContext(internal.Operation{})).IsValid())
+}
+
+func TestTransport(t *testing.T) {
+	// given
+	exporter := setUpTracing(t)
+	var traceParent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceParent = r.Header.Get("traceparent")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	client := &http.Client{Transport: NewTransport(nil, "provisioner")}
+
+	ctx, parent := Tracer().Start(context.Background(), "step")
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, server.URL+"/graphql?token=secret", nil)
+	require.NoError(t, err)
+
+	// when
+	response, err := client.Do(request)
+	parent.End()
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, response.StatusCode)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+	span := spans[0]
+	assert.Equal(t, "provisioner POST", span.Name)
+	assert.Equal(t, trace.SpanKindClient, span.SpanKind)
+	assert.Equal(t, parent.SpanContext().SpanID(), span.Parent.SpanID())
+	assert.Contains(t, span.Attributes, attribute.String("http.url", server.URL+"/graphql"))
+	assert.Equal(t, "Error", span.Status.Code.String())
+	assert.Contains(t, traceParent, span.SpanContext.TraceID().String())
+	assert.Contains(t, traceParent, span.SpanContext.SpanID().String())
+}
+
+func TestExporter(t *testing.T) {
+	// given
+	var received collectortrace.ExportTraceServiceRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/traces", r.URL.Path)
+		body, err := ioutil.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.NoError(t, proto.Unmarshal(body, &received))
+	}))
+	defer server.Close()
+	exporter, err := NewExporter(context.Background(), server.URL+"/v1/traces")
+	require.NoError(t, err)
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	// when
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "parent")
+	_, child := provider.Tracer("test").Start(ctx, "child")
+	child.SetAttributes(attribute.String("keb.step.name", "Create_Runtime"))
+	child.End()
+
+	// then
+	require.Len(t, received.ResourceSpans, 1)
+	require.Len(t, received.ResourceSpans[0].ScopeSpans, 1)
+	spans := received.ResourceSpans[0].ScopeSpans[0].Spans
+	require.Len(t, spans, 1)
+	assert.Equal(t, "child", spans[0].Name)
+	assert.Equal(t, parent.SpanContext().TraceID().String(), hex.EncodeToString(spans[0].TraceId))
+	assert.Equal(t, parent.SpanContext().SpanID().String(), hex.EncodeToString(spans[0].ParentSpanId))
+	require.Len(t, spans[0].Attributes, 1)
+	assert.Equal(t, "keb.step.name", spans[0].Attributes[0].Key)
+	assert.Equal(t, "Create_Runtime", spans[0].Attributes[0].Value.GetStringValue())
+}
+
+func setUpTracing(t *testing.T) *tracetest.InMemoryExporter {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	previousProvider, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+	return exporter
+}
@@ -0,0 +1,55 @@
+package tracing
+
+import (
+	"fmt"
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type transport struct {
+	base    http.RoundTripper
+	service string
+}
+
+// NewTransport returns the transport which records the requests to the service as client spans
+// and propagates the trace context in the request headers
+func NewTransport(base http.RoundTripper, service string) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &transport{
+		base:    base,
+		service: service,
+	}
+}
+
+func (t *transport) RoundTrip(request *http.Request) (*http.Response, error) {
+	ctx, span := Tracer().Start(request.Context(), fmt.Sprintf("%s %s", t.service, request.Method),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("peer.service", t.service),
+			attribute.String("http.method", request.Method),
+			// the query is skipped because it can contain secrets
+			attribute.String("http.url", fmt.Sprintf("%s://%s%s", request.URL.Scheme, request.URL.Host, request.URL.Path)),
+		))
+
+	request = request.Clone(ctx)
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(request.Header))
+
+	response, err := t.base.RoundTrip(request)
+	if err != nil {
+		End(span, err)
+		return nil, err
+	}
+	span.SetAttributes(attribute.Int("http.status_code", response.StatusCode))
+	if response.StatusCode >= http.StatusInternalServerError {
+		span.SetStatus(codes.Error, response.Status)
+	}
+	span.End()
+	return response, nil
+}
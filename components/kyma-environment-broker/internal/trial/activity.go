@@ -0,0 +1,69 @@
+package trial
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbmodel"
+	"github.com/sirupsen/logrus"
+)
+
+// sources of the usage of an instance, stored in the message of the activity event
+const (
+	ActivitySourceKubeconfig  = "kubeconfig"
+	ActivitySourceConsumption = "consumption"
+)
+
+// activityInterval limits the number of the recorded activity events, the inactivity expiry is counted in days
+const activityInterval = time.Hour
+
+// ActivityRecorder records the usage of the instances of the plans with inactivity expiry
+type ActivityRecorder struct {
+	trialEvents storage.TrialEvents
+	policy      *Policy
+	log         logrus.FieldLogger
+}
+
+func NewActivityRecorder(trialEvents storage.TrialEvents, policy *Policy, log logrus.FieldLogger) *ActivityRecorder {
+	return &ActivityRecorder{
+		trialEvents: trialEvents,
+		policy:      policy,
+		log:         log.WithField("service", "ActivityRecorder"),
+	}
+}
+
+// Record saves the usage of the instance unless it has been recorded in the last hour or the plan has no inactivity expiry
+func (r *ActivityRecorder) Record(instance internal.Instance, source string) error {
+	if !r.policy.TracksActivity(instance.ServicePlanID) {
+		return nil
+	}
+	now := time.Now()
+
+	events, err := r.trialEvents.List(dbmodel.TrialEventFilter{
+		InstanceIDs: []string{instance.InstanceID},
+		Types:       []internal.TrialEventType{internal.TrialEventActivity},
+	})
+	if err != nil {
+		return fmt.Errorf("while listing activity events: %w", err)
+	}
+	if len(events) > 0 && now.Sub(events[len(events)-1].CreatedAt) < activityInterval {
+		return nil
+	}
+
+	err = r.trialEvents.Insert(internal.TrialEvent{
+		ID:           uuid.New().String(),
+		InstanceID:   instance.InstanceID,
+		SubAccountID: instance.SubAccountID,
+		Type:         internal.TrialEventActivity,
+		Message:      source,
+		CreatedAt:    now,
+	})
+	if err != nil {
+		return fmt.Errorf("while saving activity event: %w", err)
+	}
+	r.log.Debugf("%s activity of instance %s recorded", source, instance.InstanceID)
+	return nil
+}
@@ -0,0 +1,132 @@
+package trial
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	pkg "github.com/kyma-project/control-plane/components/kyma-environment-broker/common/runtime"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/httputil"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dberr"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbmodel"
+	"github.com/sirupsen/logrus"
+)
+
+// Handler exposes the admin API which extends the trial instances and records the consumption of the instances with inactivity expiry
+type Handler struct {
+	instances   storage.Instances
+	trialEvents storage.TrialEvents
+	policy      *Policy
+	activity    *ActivityRecorder
+	log         logrus.FieldLogger
+}
+
+func NewHandler(instances storage.Instances, trialEvents storage.TrialEvents, policy *Policy, log logrus.FieldLogger) *Handler {
+	return &Handler{
+		instances:   instances,
+		trialEvents: trialEvents,
+		policy:      policy,
+		activity:    NewActivityRecorder(trialEvents, policy, log),
+		log:         log.WithField("service", "TrialHandler"),
+	}
+}
+
+func (h *Handler) AttachRoutes(router *mux.Router) {
+	router.HandleFunc("/trials/{instance_id}/extension", h.extend).Methods(http.MethodPost)
+	router.HandleFunc("/trials/{instance_id}/activity", h.recordActivity).Methods(http.MethodPost)
+}
+
+// extend prolongs the trial by the extension period, once per subaccount and only before the trial expires
+func (h *Handler) extend(w http.ResponseWriter, r *http.Request) {
+	instanceID := mux.Vars(r)["instance_id"]
+
+	var extension pkg.TrialExtensionDTO
+	if err := json.NewDecoder(r.Body).Decode(&extension); err != nil && err != io.EOF {
+		httputil.WriteErrorResponse(w, http.StatusBadRequest, fmt.Errorf("while decoding trial extension: %w", err))
+		return
+	}
+
+	instance, ok := h.getInstance(w, instanceID)
+	if !ok {
+		return
+	}
+	if !h.policy.Extendable(instance.ServicePlanID) {
+		httputil.WriteErrorResponse(w, http.StatusBadRequest, fmt.Errorf("instance %s of plan %s does not expire after a fixed period", instanceID, instance.ServicePlanName))
+		return
+	}
+	if instance.IsExpired() {
+		httputil.WriteErrorResponse(w, http.StatusConflict, fmt.Errorf("instance %s has already expired", instanceID))
+		return
+	}
+
+	events, err := h.trialEvents.List(dbmodel.TrialEventFilter{InstanceIDs: []string{instanceID}})
+	if err != nil {
+		httputil.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Errorf("while listing trial events: %w", err))
+		return
+	}
+	if currentHibernation(events) != nil {
+		httputil.WriteErrorResponse(w, http.StatusConflict, fmt.Errorf("instance %s is hibernated in the grace period", instanceID))
+		return
+	}
+
+	event := internal.TrialEvent{
+		ID:           uuid.New().String(),
+		InstanceID:   instanceID,
+		SubAccountID: instance.SubAccountID,
+		Type:         internal.TrialEventExtension,
+		Period:       h.policy.ExtensionPeriod(),
+		Message:      extension.Reason,
+		CreatedAt:    time.Now(),
+	}
+	if err := h.trialEvents.Insert(event); err != nil {
+		if dberr.IsAlreadyExists(err) {
+			httputil.WriteErrorResponse(w, http.StatusConflict, fmt.Errorf("a trial of subaccount %s has already been extended", instance.SubAccountID))
+			return
+		}
+		h.log.Errorf("while saving the extension of instance %s: %v", instanceID, err)
+		httputil.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Errorf("while saving trial extension: %w", err))
+		return
+	}
+	h.log.Infof("trial instance %s of subaccount %s extended by %s", instanceID, instance.SubAccountID, event.Period)
+
+	httputil.WriteResponse(w, http.StatusOK, h.policy.ToDTO(*instance, append(events, event)))
+}
+
+// recordActivity prolongs the instance with inactivity expiry when the consumption is reported
+func (h *Handler) recordActivity(w http.ResponseWriter, r *http.Request) {
+	instanceID := mux.Vars(r)["instance_id"]
+
+	instance, ok := h.getInstance(w, instanceID)
+	if !ok {
+		return
+	}
+	if !h.policy.TracksActivity(instance.ServicePlanID) {
+		httputil.WriteErrorResponse(w, http.StatusBadRequest, fmt.Errorf("instance %s of plan %s does not expire after inactivity", instanceID, instance.ServicePlanName))
+		return
+	}
+	if err := h.activity.Record(*instance, ActivitySourceConsumption); err != nil {
+		h.log.Errorf("while recording the activity of instance %s: %v", instanceID, err)
+		httputil.WriteErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) getInstance(w http.ResponseWriter, instanceID string) (*internal.Instance, bool) {
+	instance, err := h.instances.GetByID(instanceID)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if dberr.IsNotFound(err) {
+			status = http.StatusNotFound
+		}
+		httputil.WriteErrorResponse(w, status, fmt.Errorf("while getting instance: %w", err))
+		return nil, false
+	}
+	return instance, true
+}
@@ -0,0 +1,142 @@
+package trial_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	pkg "github.com/kyma-project/control-plane/components/kyma-environment-broker/common/runtime"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/storage/dbmodel"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/trial"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_Extend(t *testing.T) {
+	// given
+	db := storage.NewMemoryStorage()
+	policy := trial.NewPolicy(trial.Config{ExtensionPeriod: extensionPeriod}, trial.ExpirationPeriods(trialPeriod, nil), map[string]time.Duration{broker.FreemiumPlanID: 30 * day})
+	router := mux.NewRouter()
+	trial.NewHandler(db.Instances(), db.TrialEvents(), policy, logrus.New()).AttachRoutes(router)
+
+	now := time.Now().UTC()
+	insertInstance(t, db, "i-1", "sa-1", broker.TrialPlanID, now, nil)
+	insertInstance(t, db, "i-2", "sa-1", broker.TrialPlanID, now, nil)
+	insertInstance(t, db, "i-3", "sa-3", broker.TrialPlanID, now, &now)
+	insertInstance(t, db, "i-4", "sa-4", broker.TrialPlanID, now, nil)
+	insertInstance(t, db, "i-5", "sa-5", broker.AWSPlanID, now, nil)
+	insertInstance(t, db, "i-6", "sa-6", broker.FreemiumPlanID, now, nil)
+	require.NoError(t, db.TrialEvents().Insert(internal.TrialEvent{ID: "e-1", InstanceID: "i-4", SubAccountID: "sa-4", Type: internal.TrialEventHibernation, CreatedAt: now}))
+
+	t.Run("should extend the trial", func(t *testing.T) {
+		// when
+		rr := call(t, router, "/trials/i-1/extension", `{"reason": "support ticket"}`)
+
+		// then
+		require.Equal(t, http.StatusOK, rr.Code)
+		var dto pkg.TrialDTO
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &dto))
+		assert.WithinDuration(t, now.Add(trialPeriod+extensionPeriod), dto.ExpiresAt, time.Second)
+		require.Len(t, dto.Events, 1)
+		assert.Equal(t, "extension", dto.Events[0].Type)
+		assert.Equal(t, "support ticket", dto.Events[0].Message)
+	})
+
+	t.Run("should extend only one trial of the subaccount", func(t *testing.T) {
+		// when
+		rr := call(t, router, "/trials/i-2/extension", "")
+
+		// then
+		assert.Equal(t, http.StatusConflict, rr.Code)
+	})
+
+	t.Run("should not extend an expired trial", func(t *testing.T) {
+		// when
+		rr := call(t, router, "/trials/i-3/extension", "")
+
+		// then
+		assert.Equal(t, http.StatusConflict, rr.Code)
+	})
+
+	t.Run("should not extend a hibernated trial", func(t *testing.T) {
+		// when
+		rr := call(t, router, "/trials/i-4/extension", "")
+
+		// then
+		assert.Equal(t, http.StatusConflict, rr.Code)
+	})
+
+	t.Run("should not extend an instance which does not expire", func(t *testing.T) {
+		// when
+		rr := call(t, router, "/trials/i-5/extension", "")
+
+		// then
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should not extend an instance with inactivity expiry", func(t *testing.T) {
+		// when
+		rr := call(t, router, "/trials/i-6/extension", "")
+
+		// then
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should record the consumption of an instance with inactivity expiry", func(t *testing.T) {
+		// when
+		rr := call(t, router, "/trials/i-6/activity", "")
+		again := call(t, router, "/trials/i-6/activity", "")
+
+		// then
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+		assert.Equal(t, http.StatusNoContent, again.Code)
+		events, err := db.TrialEvents().List(dbmodel.TrialEventFilter{InstanceIDs: []string{"i-6"}})
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, internal.TrialEventActivity, events[0].Type)
+		assert.Equal(t, trial.ActivitySourceConsumption, events[0].Message)
+	})
+
+	t.Run("should not record the consumption of an instance without inactivity expiry", func(t *testing.T) {
+		// when
+		rr := call(t, router, "/trials/i-5/activity", "")
+
+		// then
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should return not found", func(t *testing.T) {
+		// when
+		rr := call(t, router, "/trials/unknown/extension", "")
+
+		// then
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
+
+func insertInstance(t *testing.T, db storage.BrokerStorage, id, subAccountID, planID string, createdAt time.Time, expiredAt *time.Time) {
+	require.NoError(t, db.Instances().Insert(internal.Instance{
+		InstanceID:      id,
+		SubAccountID:    subAccountID,
+		ServicePlanID:   planID,
+		ServicePlanName: broker.PlanNamesMapping[planID],
+		CreatedAt:       createdAt,
+		ExpiredAt:       expiredAt,
+	}))
+}
+
+func call(t *testing.T, router *mux.Router, url, body string) *httptest.ResponseRecorder {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewBufferString(body))
+	require.NoError(t, err)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	return rr
+}
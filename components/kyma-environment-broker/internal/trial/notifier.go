@@ -0,0 +1,78 @@
+package trial
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/sirupsen/logrus"
+)
+
+// Notifier warns the owner of a trial instance about its upcoming expiration
+type Notifier interface {
+	NotifyExpiration(instance internal.Instance, expiresAt time.Time, timeLeft time.Duration) error
+}
+
+// ExpirationWarning is the payload sent to the webhook
+type ExpirationWarning struct {
+	InstanceID      string    `json:"instanceId"`
+	GlobalAccountID string    `json:"globalAccountId"`
+	SubAccountID    string    `json:"subAccountId"`
+	PlanName        string    `json:"planName"`
+	ExpiresAt       time.Time `json:"expiresAt"`
+	TimeLeft        string    `json:"timeLeft"`
+}
+
+// NewNotifier returns the notifier calling the configured webhook or, without the webhook, the notifier which only logs the warnings
+func NewNotifier(cfg Config, httpClient *http.Client, log logrus.FieldLogger) Notifier {
+	log = log.WithField("service", "TrialNotifier")
+	if cfg.WebhookURL == "" {
+		return &logNotifier{log: log}
+	}
+	return &webhookNotifier{url: cfg.WebhookURL, httpClient: httpClient, log: log}
+}
+
+type webhookNotifier struct {
+	url        string
+	httpClient *http.Client
+	log        logrus.FieldLogger
+}
+
+func (n *webhookNotifier) NotifyExpiration(instance internal.Instance, expiresAt time.Time, timeLeft time.Duration) error {
+	body, err := json.Marshal(ExpirationWarning{
+		InstanceID:      instance.InstanceID,
+		GlobalAccountID: instance.GlobalAccountID,
+		SubAccountID:    instance.SubAccountID,
+		PlanName:        instance.ServicePlanName,
+		ExpiresAt:       expiresAt,
+		TimeLeft:        timeLeft.String(),
+	})
+	if err != nil {
+		return fmt.Errorf("while marshalling expiration warning: %w", err)
+	}
+
+	resp, err := n.httpClient.Post(n.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("while calling trial webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		msg, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("trial webhook responded with status %d: %s", resp.StatusCode, string(msg))
+	}
+	n.log.Infof("expiration warning for instance %s sent, time left: %s", instance.InstanceID, timeLeft)
+	return nil
+}
+
+type logNotifier struct {
+	log logrus.FieldLogger
+}
+
+func (n *logNotifier) NotifyExpiration(instance internal.Instance, expiresAt time.Time, timeLeft time.Duration) error {
+	n.log.Infof("trial instance %s of subaccount %s expires at %s, time left: %s", instance.InstanceID, instance.SubAccountID, expiresAt, timeLeft)
+	return nil
+}
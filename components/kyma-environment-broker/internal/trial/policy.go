@@ -0,0 +1,240 @@
+package trial
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	pkg "github.com/kyma-project/control-plane/components/kyma-environment-broker/common/runtime"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
+)
+
+type Config struct {
+	// Warnings lists the periods before the expiration when the owner of the trial is notified, e.g. 168h;24h
+	Warnings Periods `envconfig:"default=168h;24h"`
+	// GracePeriod is the time between the expiration and the suspension of the trial in which the cluster is hibernated, 0 suspends the trial right away
+	GracePeriod time.Duration `envconfig:"default=0"`
+	// ExtensionPeriod prolongs the trial when it is extended, only one extension is allowed per subaccount
+	ExtensionPeriod time.Duration `envconfig:"default=168h"`
+	// WebhookURL receives the expiration warnings, the warnings are only logged if not set
+	WebhookURL string `envconfig:"optional"`
+}
+
+// Periods is a list of durations
+type Periods []time.Duration
+
+// Unmarshal parses the list of durations separated with a semicolon or a comma, e.g. 168h;24h
+func (p *Periods) Unmarshal(s string) error {
+	periods := Periods{}
+	for _, entry := range strings.FieldsFunc(s, func(r rune) bool { return r == ';' || r == ',' }) {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		period, err := time.ParseDuration(entry)
+		if err != nil || period <= 0 {
+			return fmt.Errorf("period %q must be a positive duration", entry)
+		}
+		periods = append(periods, period)
+	}
+	*p = periods
+	return nil
+}
+
+type Action string
+
+const (
+	ActionNone      Action = "none"
+	ActionWarn      Action = "warn"
+	ActionHibernate Action = "hibernate"
+	ActionWakeUp    Action = "wakeup"
+	ActionExpire    Action = "expire"
+)
+
+// Decision is the next step in the lifecycle of a trial instance
+type Decision struct {
+	Action    Action
+	ExpiresAt time.Time
+	// Warning is the period before the expiration the warning is sent for
+	Warning time.Duration
+}
+
+// Policy decides when the trial instances are warned, hibernated and expired
+type Policy struct {
+	cfg               Config
+	expirationPeriods map[string]time.Duration
+	inactivityPeriods map[string]time.Duration
+}
+
+// NewPolicy creates the policy for the plans with the given expiration periods, see ExpirationPeriods, and the plans
+// which expire when they are not used for the given inactivity periods, see broker.InactivityExpiryPeriods
+func NewPolicy(cfg Config, expirationPeriods, inactivityPeriods map[string]time.Duration) *Policy {
+	warnings := append(Periods{}, cfg.Warnings...)
+	sort.Slice(warnings, func(i, j int) bool { return warnings[i] < warnings[j] })
+	cfg.Warnings = warnings
+
+	return &Policy{
+		cfg:               cfg,
+		expirationPeriods: expirationPeriods,
+		inactivityPeriods: inactivityPeriods,
+	}
+}
+
+// ExpirationPeriods maps the IDs of the expirable plans to their expiration periods, the trial plan expires after trialPeriod
+func ExpirationPeriods(trialPeriod time.Duration, planDefinitions []broker.PlanDefinition) map[string]time.Duration {
+	expirationPeriods := map[string]time.Duration{broker.TrialPlanID: trialPeriod}
+	for _, definition := range planDefinitions {
+		if definition.Features.TrialExpiry > 0 {
+			expirationPeriods[definition.ID] = definition.Features.TrialExpiry
+		}
+	}
+	return expirationPeriods
+}
+
+// PlanIDs returns the IDs of the plans the policy applies to
+func (p *Policy) PlanIDs() []string {
+	planIDs := make([]string, 0, len(p.expirationPeriods)+len(p.inactivityPeriods))
+	for planID := range p.expirationPeriods {
+		planIDs = append(planIDs, planID)
+	}
+	for planID := range p.inactivityPeriods {
+		if _, found := p.expirationPeriods[planID]; !found {
+			planIDs = append(planIDs, planID)
+		}
+	}
+	sort.Strings(planIDs)
+	return planIDs
+}
+
+func (p *Policy) Applies(planID string) bool {
+	_, expires := p.expirationPeriods[planID]
+	_, inactivityExpires := p.inactivityPeriods[planID]
+	return expires || inactivityExpires
+}
+
+// Extendable returns true for the plans which expire after a fixed period, the plans with inactivity expiry are prolonged by the usage
+func (p *Policy) Extendable(planID string) bool {
+	_, found := p.expirationPeriods[planID]
+	return found
+}
+
+// TracksActivity returns true for the plans with inactivity expiry
+func (p *Policy) TracksActivity(planID string) bool {
+	_, found := p.inactivityPeriods[planID]
+	return found
+}
+
+func (p *Policy) ExtensionPeriod() time.Duration {
+	return p.cfg.ExtensionPeriod
+}
+
+// ExpiresAt returns the expiration time of the instance prolonged by its extensions, or the end of the inactivity period
+// after its last usage, whichever comes first
+func (p *Policy) ExpiresAt(instance internal.Instance, events []internal.TrialEvent) time.Time {
+	var expiresAt time.Time
+	if period, found := p.expirationPeriods[instance.ServicePlanID]; found {
+		expiresAt = instance.CreatedAt.Add(period)
+		for _, event := range events {
+			if event.Type == internal.TrialEventExtension {
+				expiresAt = expiresAt.Add(event.Period)
+			}
+		}
+	}
+	if period, found := p.inactivityPeriods[instance.ServicePlanID]; found {
+		lastActivity := instance.CreatedAt
+		for _, event := range events {
+			if event.Type == internal.TrialEventActivity && event.CreatedAt.After(lastActivity) {
+				lastActivity = event.CreatedAt
+			}
+		}
+		if inactiveAt := lastActivity.Add(period); expiresAt.IsZero() || inactiveAt.Before(expiresAt) {
+			expiresAt = inactiveAt
+		}
+	}
+	return expiresAt
+}
+
+// Evaluate returns the next step for the instance with the given trial events: the expiration once the grace period is over,
+// the hibernation in the grace period, the wake-up of the hibernated instance whose expiration was prolonged by its usage,
+// or the most urgent warning which has not been sent since the last extension
+func (p *Policy) Evaluate(instance internal.Instance, events []internal.TrialEvent, now time.Time) Decision {
+	expiresAt := p.ExpiresAt(instance, events)
+	decision := Decision{Action: ActionNone, ExpiresAt: expiresAt}
+	hibernation := currentHibernation(events)
+
+	switch {
+	case !now.Before(expiresAt.Add(p.cfg.GracePeriod)):
+		decision.Action = ActionExpire
+		return decision
+	case !now.Before(expiresAt):
+		if hibernation == nil {
+			decision.Action = ActionHibernate
+		}
+		return decision
+	case hibernation != nil:
+		decision.Action = ActionWakeUp
+		return decision
+	}
+
+	for _, warning := range p.cfg.Warnings {
+		if now.Before(expiresAt.Add(-warning)) {
+			continue
+		}
+		if !warningSent(events, warning) {
+			decision.Action = ActionWarn
+			decision.Warning = warning
+		}
+		return decision
+	}
+	return decision
+}
+
+// ToDTO returns the expiration and the lifecycle history of the instance
+func (p *Policy) ToDTO(instance internal.Instance, events []internal.TrialEvent) pkg.TrialDTO {
+	dto := pkg.TrialDTO{
+		ExpiresAt: p.ExpiresAt(instance, events),
+		Events:    make([]pkg.TrialEventDTO, 0, len(events)),
+	}
+	if hibernation := currentHibernation(events); hibernation != nil {
+		dto.HibernatedAt = &hibernation.CreatedAt
+	}
+	for _, event := range events {
+		dto.Events = append(dto.Events, pkg.TrialEventDTO{
+			Type:      string(event.Type),
+			Period:    event.Period.String(),
+			Message:   event.Message,
+			CreatedAt: event.CreatedAt,
+		})
+	}
+	return dto
+}
+
+// warningSent checks if the warning has been sent since the last extension or usage, the events are ordered by the creation time
+func warningSent(events []internal.TrialEvent, warning time.Duration) bool {
+	sent := false
+	for _, event := range events {
+		switch {
+		case event.Type == internal.TrialEventExtension || event.Type == internal.TrialEventActivity:
+			sent = false
+		case event.Type == internal.TrialEventWarning && event.Period == warning:
+			sent = true
+		}
+	}
+	return sent
+}
+
+// currentHibernation returns the hibernation of the cluster which has not been followed by a wake-up, the events are ordered by the creation time
+func currentHibernation(events []internal.TrialEvent) *internal.TrialEvent {
+	var hibernation *internal.TrialEvent
+	for i := range events {
+		switch events[i].Type {
+		case internal.TrialEventHibernation:
+			hibernation = &events[i]
+		case internal.TrialEventWakeUp:
+			hibernation = nil
+		}
+	}
+	return hibernation
+}
@@ -0,0 +1,263 @@
+package trial_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/broker"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/internal/trial"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	day             = 24 * time.Hour
+	trialPeriod     = 14 * day
+	extensionPeriod = 7 * day
+)
+
+var createdAt = time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+
+func TestPolicy_Evaluate(t *testing.T) {
+	instance := internal.Instance{InstanceID: "i-1", ServicePlanID: broker.TrialPlanID, CreatedAt: createdAt}
+	expiresAt := createdAt.Add(trialPeriod)
+	warning := func(period time.Duration, at time.Time) internal.TrialEvent {
+		return internal.TrialEvent{Type: internal.TrialEventWarning, Period: period, CreatedAt: at}
+	}
+	extension := internal.TrialEvent{Type: internal.TrialEventExtension, Period: extensionPeriod, CreatedAt: createdAt.Add(12 * day)}
+	hibernation := internal.TrialEvent{Type: internal.TrialEventHibernation, CreatedAt: expiresAt}
+
+	for name, tc := range map[string]struct {
+		gracePeriod     time.Duration
+		events          []internal.TrialEvent
+		now             time.Time
+		expectedAction  trial.Action
+		expectedWarning time.Duration
+		expectedExpiry  time.Time
+	}{
+		"no action before the first warning": {
+			now:            createdAt.Add(day),
+			expectedAction: trial.ActionNone,
+		},
+		"first warning": {
+			now:             expiresAt.Add(-6 * day),
+			expectedAction:  trial.ActionWarn,
+			expectedWarning: 7 * day,
+		},
+		"first warning already sent": {
+			events:         []internal.TrialEvent{warning(7*day, expiresAt.Add(-7*day))},
+			now:            expiresAt.Add(-6 * day),
+			expectedAction: trial.ActionNone,
+		},
+		"only the most urgent warning": {
+			now:             expiresAt.Add(-time.Hour),
+			expectedAction:  trial.ActionWarn,
+			expectedWarning: day,
+		},
+		"warnings again after the extension": {
+			events:          []internal.TrialEvent{warning(7*day, createdAt.Add(7*day)), extension},
+			now:             expiresAt.Add(time.Hour),
+			expectedAction:  trial.ActionWarn,
+			expectedWarning: 7 * day,
+			expectedExpiry:  expiresAt.Add(extensionPeriod),
+		},
+		"expiration without the grace period": {
+			now:            expiresAt,
+			expectedAction: trial.ActionExpire,
+		},
+		"hibernation in the grace period": {
+			gracePeriod:    3 * day,
+			now:            expiresAt.Add(time.Hour),
+			expectedAction: trial.ActionHibernate,
+		},
+		"already hibernated": {
+			gracePeriod:    3 * day,
+			events:         []internal.TrialEvent{hibernation},
+			now:            expiresAt.Add(day),
+			expectedAction: trial.ActionNone,
+		},
+		"expiration after the grace period": {
+			gracePeriod:    3 * day,
+			events:         []internal.TrialEvent{hibernation},
+			now:            expiresAt.Add(3 * day),
+			expectedAction: trial.ActionExpire,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// given
+			policy := trial.NewPolicy(trial.Config{
+				Warnings:        trial.Periods{day, 7 * day},
+				GracePeriod:     tc.gracePeriod,
+				ExtensionPeriod: extensionPeriod,
+			}, trial.ExpirationPeriods(trialPeriod, nil), nil)
+			if tc.expectedExpiry.IsZero() {
+				tc.expectedExpiry = expiresAt
+			}
+
+			// when
+			decision := policy.Evaluate(instance, tc.events, tc.now)
+
+			// then
+			assert.Equal(t, tc.expectedAction, decision.Action)
+			assert.Equal(t, tc.expectedWarning, decision.Warning)
+			assert.Equal(t, tc.expectedExpiry, decision.ExpiresAt)
+		})
+	}
+}
+
+func TestPolicy_InactivityExpiry(t *testing.T) {
+	// given
+	policy := trial.NewPolicy(trial.Config{Warnings: trial.Periods{7 * day}}, trial.ExpirationPeriods(trialPeriod, nil), map[string]time.Duration{broker.FreemiumPlanID: 30 * day})
+	instance := internal.Instance{InstanceID: "i-1", ServicePlanID: broker.FreemiumPlanID, CreatedAt: createdAt}
+	warning := internal.TrialEvent{Type: internal.TrialEventWarning, Period: 7 * day, CreatedAt: createdAt.Add(24 * day)}
+	activity := internal.TrialEvent{Type: internal.TrialEventActivity, Message: trial.ActivitySourceKubeconfig, CreatedAt: createdAt.Add(25 * day)}
+
+	t.Run("should expire the instance which has not been used", func(t *testing.T) {
+		// when
+		decision := policy.Evaluate(instance, []internal.TrialEvent{warning}, createdAt.Add(30*day))
+
+		// then
+		assert.Equal(t, trial.ActionExpire, decision.Action)
+		assert.Equal(t, createdAt.Add(30*day), decision.ExpiresAt)
+	})
+
+	t.Run("should prolong the instance by the usage", func(t *testing.T) {
+		// when
+		decision := policy.Evaluate(instance, []internal.TrialEvent{warning, activity}, createdAt.Add(50*day))
+
+		// then
+		assert.Equal(t, trial.ActionWarn, decision.Action)
+		assert.Equal(t, createdAt.Add(55*day), decision.ExpiresAt)
+	})
+
+	t.Run("should wake up the instance used in the grace period and hibernate it again", func(t *testing.T) {
+		// given
+		policy := trial.NewPolicy(trial.Config{GracePeriod: 3 * day}, nil, map[string]time.Duration{broker.FreemiumPlanID: 30 * day})
+		hibernation := internal.TrialEvent{Type: internal.TrialEventHibernation, CreatedAt: createdAt.Add(30 * day)}
+		usage := internal.TrialEvent{Type: internal.TrialEventActivity, CreatedAt: createdAt.Add(31 * day)}
+		wakeUp := internal.TrialEvent{Type: internal.TrialEventWakeUp, CreatedAt: createdAt.Add(31*day + time.Hour)}
+
+		// when
+		decision := policy.Evaluate(instance, nil, createdAt.Add(30*day))
+
+		// then
+		assert.Equal(t, trial.ActionHibernate, decision.Action)
+		assert.Nil(t, policy.ToDTO(instance, nil).HibernatedAt)
+
+		// when
+		decision = policy.Evaluate(instance, []internal.TrialEvent{hibernation, usage}, createdAt.Add(31*day+time.Hour))
+
+		// then
+		assert.Equal(t, trial.ActionWakeUp, decision.Action)
+		assert.Equal(t, createdAt.Add(61*day), decision.ExpiresAt)
+		assert.Equal(t, createdAt.Add(30*day), *policy.ToDTO(instance, []internal.TrialEvent{hibernation, usage}).HibernatedAt)
+
+		// when
+		decision = policy.Evaluate(instance, []internal.TrialEvent{hibernation, usage, wakeUp}, createdAt.Add(40*day))
+
+		// then
+		assert.Equal(t, trial.ActionNone, decision.Action)
+		assert.Nil(t, policy.ToDTO(instance, []internal.TrialEvent{hibernation, usage, wakeUp}).HibernatedAt)
+
+		// when
+		decision = policy.Evaluate(instance, []internal.TrialEvent{hibernation, usage, wakeUp}, createdAt.Add(61*day))
+
+		// then
+		assert.Equal(t, trial.ActionHibernate, decision.Action)
+	})
+
+	t.Run("should apply the inactivity expiry only to the plans with the inactivity period", func(t *testing.T) {
+		assert.True(t, policy.TracksActivity(broker.FreemiumPlanID))
+		assert.False(t, policy.Extendable(broker.FreemiumPlanID))
+		assert.False(t, policy.TracksActivity(broker.TrialPlanID))
+		assert.ElementsMatch(t, []string{broker.FreemiumPlanID, broker.TrialPlanID}, policy.PlanIDs())
+	})
+}
+
+func TestExpirationPeriods(t *testing.T) {
+	// given
+	definitions := []broker.PlanDefinition{
+		{ID: "plan-1", Features: broker.PlanFeatures{TrialExpiry: 30 * day}},
+		{ID: "plan-2"},
+	}
+
+	// when
+	policy := trial.NewPolicy(trial.Config{}, trial.ExpirationPeriods(trialPeriod, definitions), nil)
+
+	// then
+	assert.Equal(t, []string{broker.TrialPlanID, "plan-1"}, policy.PlanIDs())
+	assert.Equal(t, createdAt.Add(30*day), policy.ExpiresAt(internal.Instance{ServicePlanID: "plan-1", CreatedAt: createdAt}, nil))
+}
+
+func TestPeriods_Unmarshal(t *testing.T) {
+	// given
+	var periods trial.Periods
+
+	// when
+	err := periods.Unmarshal("168h;24h, 1h")
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, trial.Periods{7 * day, day, time.Hour}, periods)
+	assert.EqualError(t, periods.Unmarshal("7d"), `period "7d" must be a positive duration`)
+}
+
+func TestNotifier(t *testing.T) {
+	instance := internal.Instance{InstanceID: "i-1", GlobalAccountID: "ga-1", SubAccountID: "sa-1", ServicePlanName: broker.TrialPlanName}
+
+	t.Run("should call the webhook", func(t *testing.T) {
+		// given
+		var received trial.ExpirationWarning
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer server.Close()
+		notifier := trial.NewNotifier(trial.Config{WebhookURL: server.URL}, server.Client(), logrus.New())
+
+		// when
+		err := notifier.NotifyExpiration(instance, createdAt, day)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, trial.ExpirationWarning{
+			InstanceID:      "i-1",
+			GlobalAccountID: "ga-1",
+			SubAccountID:    "sa-1",
+			PlanName:        broker.TrialPlanName,
+			ExpiresAt:       createdAt,
+			TimeLeft:        "24h0m0s",
+		}, received)
+	})
+
+	t.Run("should fail when the webhook fails", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+		notifier := trial.NewNotifier(trial.Config{WebhookURL: server.URL}, server.Client(), logrus.New())
+
+		// when
+		err := notifier.NotifyExpiration(instance, createdAt, day)
+
+		// then
+		assert.EqualError(t, err, "trial webhook responded with status 503: unavailable\n")
+	})
+
+	t.Run("should only log without the webhook", func(t *testing.T) {
+		// given
+		notifier := trial.NewNotifier(trial.Config{}, http.DefaultClient, logrus.New())
+
+		// when
+		err := notifier.NotifyExpiration(instance, createdAt, day)
+
+		// then
+		assert.NoError(t, err)
+	})
+}
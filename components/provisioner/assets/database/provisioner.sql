@@ -46,6 +46,8 @@ CREATE TABLE gardener_config
     shoot_networking_filter_disabled boolean,
     control_plane_failure_tolerance varchar(256),
     eu_access boolean NOT NULL,
+    pods_cidr varchar(256),
+    services_cidr varchar(256),
     UNIQUE(cluster_id),
     foreign key (cluster_id) REFERENCES cluster (id) ON DELETE CASCADE
 );
@@ -83,7 +85,9 @@ CREATE TABLE operation
     last_transition timestamp without time zone,
     err_message text NOT NULL,
     reason text NOT NULL,
-    component text NOT NULL
+    component text NOT NULL,
+    trace_id varchar(32) NOT NULL DEFAULT '',
+    span_id varchar(16) NOT NULL DEFAULT ''
 );
 
 -- Kyma Release
@@ -37,6 +37,7 @@ import (
 	migrator "github.com/kyma-project/control-plane/components/provisioner/internal/provider-config-migrator"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/provisioning/persistence/dbsession"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/runtime"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/tracing"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/util/k8s"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/uuid"
 	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
@@ -95,6 +96,8 @@ type config struct {
 
 	LogLevel string `envconfig:"default=info"`
 
+	Tracing tracing.Config
+
 	// TODO: Remove after data migration
 	RunAwsConfigMigration bool `envconfig:"default=false"`
 }
@@ -155,6 +158,14 @@ func main() {
 	log.Infof("Starting Provisioner")
 	log.Infof("Config: %s", cfg.String())
 
+	shutdownTracing, err := tracing.Init(cfg.Tracing, log.WithField("Component", "Tracing"))
+	exitOnError(err, "Failed to initialize tracing")
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			log.Errorf("Failed to flush traces: %s", err.Error())
+		}
+	}()
+
 	connString := fmt.Sprintf(connStringFormat, cfg.Database.Host, cfg.Database.Port, cfg.Database.User,
 		cfg.Database.Password, cfg.Database.Name, cfg.Database.SSLMode, cfg.Database.SSLRootCert)
 
@@ -301,6 +312,7 @@ func main() {
 
 	log.Infof("Registering endpoint on %s...", cfg.APIEndpoint)
 	router := mux.NewRouter()
+	router.Use(middlewares.ExtractTraceContext)
 	router.Use(middlewares.ExtractTenant)
 
 	router.HandleFunc("/", playground.Handler("Dataloader", cfg.PlaygroundAPIEndpoint))
@@ -0,0 +1,29 @@
+package middlewares
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/kyma-project/control-plane/components/provisioner/internal/tracing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ExtractTraceContext continues the trace propagated in the request headers, e.g. by the Kyma Environment Broker, with a server span of the request
+func ExtractTraceContext(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		ctx, span := tracing.Tracer().Start(ctx, fmt.Sprintf("%s %s", r.Method, r.URL.Path),
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("http.method", r.Method),
+				attribute.String("http.target", r.URL.Path),
+			))
+		defer span.End()
+
+		handler.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
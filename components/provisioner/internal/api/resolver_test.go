@@ -16,6 +16,7 @@ import (
 	"github.com/kyma-project/control-plane/components/provisioner/internal/provisioning/mocks"
 	"github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -86,7 +87,7 @@ func TestResolver_ProvisionRuntime(t *testing.T) {
 			KymaConfig:    kymaConfig,
 		}
 
-		provisioningService.On("ProvisionRuntime", config, tenant, "").Return(operation, nil)
+		provisioningService.On("ProvisionRuntime", mock.Anything, config, tenant, "").Return(operation, nil)
 		validator.On("ValidateProvisioningInput", config).Return(nil)
 
 		//when
@@ -148,7 +149,7 @@ func TestResolver_ProvisionRuntime(t *testing.T) {
 		config := gqlschema.ProvisionRuntimeInput{RuntimeInput: runtimeInput, ClusterConfig: clusterConfig, KymaConfig: kymaConfig}
 
 		tenantUpdater.On("GetTenant", ctx).Return(tenant, nil)
-		provisioningService.On("ProvisionRuntime", config, tenant, "").Return(nil, apperrors.Internal("Provisioning failed"))
+		provisioningService.On("ProvisionRuntime", mock.Anything, config, tenant, "").Return(nil, apperrors.Internal("Provisioning failed"))
 		validator.On("ValidateProvisioningInput", config).Return(nil)
 
 		//when
@@ -209,7 +210,7 @@ func TestResolver_DeprovisionRuntime(t *testing.T) {
 
 		expectedID := "ec781980-0533-4098-aab7-96b535569732"
 
-		provisioningService.On("DeprovisionRuntime", runtimeID).Return(expectedID, nil)
+		provisioningService.On("DeprovisionRuntime", mock.Anything, runtimeID).Return(expectedID, nil)
 		tenantUpdater.On("GetAndUpdateTenant", runtimeID, ctx).Return(nil)
 
 		//when
@@ -226,7 +227,7 @@ func TestResolver_DeprovisionRuntime(t *testing.T) {
 		validator := &validatorMocks.Validator{}
 		tenantUpdater := &validatorMocks.TenantUpdater{}
 		provisioner := api.NewResolver(provisioningService, validator, tenantUpdater)
-		provisioningService.On("DeprovisionRuntime", runtimeID).Return("", apperrors.Internal("Deprovisioning fails because reasons"))
+		provisioningService.On("DeprovisionRuntime", mock.Anything, runtimeID).Return("", apperrors.Internal("Deprovisioning fails because reasons"))
 		tenantUpdater.On("GetAndUpdateTenant", runtimeID, ctx).Return(nil)
 
 		//when
@@ -248,7 +249,7 @@ func TestResolver_DeprovisionRuntime(t *testing.T) {
 
 		ctx := context.Background()
 
-		provisioningService.On("DeprovisionRuntime", runtimeID).Return(expectedID, nil, nil)
+		provisioningService.On("DeprovisionRuntime", mock.Anything, runtimeID).Return(expectedID, nil, nil)
 		tenantUpdater.On("GetAndUpdateTenant", runtimeID, ctx).Return(apperrors.BadRequest("tenant header not passed"))
 
 		//when
@@ -281,7 +282,7 @@ func TestResolver_UpgradeRuntime(t *testing.T) {
 			RuntimeID: util.StringPtr(runtimeID),
 		}
 
-		provisioningService.On("UpgradeRuntime", runtimeID, upgradeInput).Return(operation, nil)
+		provisioningService.On("UpgradeRuntime", mock.Anything, runtimeID, upgradeInput).Return(operation, nil)
 		validator.On("ValidateUpgradeInput", upgradeInput).Return(nil)
 		tenantUpdater.On("GetAndUpdateTenant", runtimeID, ctx).Return(nil)
 
@@ -304,7 +305,7 @@ func TestResolver_UpgradeRuntime(t *testing.T) {
 		validator := &validatorMocks.Validator{}
 		tenantUpdater := &validatorMocks.TenantUpdater{}
 
-		provisioningService.On("UpgradeRuntime", runtimeID, upgradeInput).Return(nil, apperrors.Internal("error"))
+		provisioningService.On("UpgradeRuntime", mock.Anything, runtimeID, upgradeInput).Return(nil, apperrors.Internal("error"))
 		validator.On("ValidateUpgradeInput", upgradeInput).Return(nil)
 		tenantUpdater.On("GetAndUpdateTenant", runtimeID, ctx).Return(nil)
 
@@ -518,7 +519,7 @@ func TestResolver_UpgradeShoot(t *testing.T) {
 
 		tenantUpdater.On("GetAndUpdateTenant", runtimeID, ctx).Return(nil)
 		validator.On("ValidateUpgradeShootInput", upgradeShootInput).Return(nil)
-		provisioningService.On("UpgradeGardenerShoot", runtimeID, upgradeShootInput).Return(operation, nil)
+		provisioningService.On("UpgradeGardenerShoot", mock.Anything, runtimeID, upgradeShootInput).Return(operation, nil)
 
 		resolver := api.NewResolver(provisioningService, validator, tenantUpdater)
 
@@ -576,7 +577,7 @@ func TestResolver_HibernateRuntime(t *testing.T) {
 			Message:   &message,
 		}
 
-		provisioningService.On("HibernateCluster", runtimeID).Return(operationStatus, nil)
+		provisioningService.On("HibernateCluster", mock.Anything, runtimeID).Return(operationStatus, nil)
 		tenantUpdater.On("GetAndUpdateTenant", runtimeID, ctx).Return(nil)
 
 		//when
@@ -595,7 +596,7 @@ func TestResolver_HibernateRuntime(t *testing.T) {
 
 		provisioner := api.NewResolver(provisioningService, validator, tenantUpdater)
 
-		provisioningService.On("HibernateCluster", runtimeID).Return(nil, apperrors.Internal("Some error"))
+		provisioningService.On("HibernateCluster", mock.Anything, runtimeID).Return(nil, apperrors.Internal("Some error"))
 		tenantUpdater.On("GetAndUpdateTenant", runtimeID, ctx).Return(nil)
 
 		//when
@@ -79,6 +79,8 @@ type GardenerConfig struct {
 	ShootNetworkingFilterDisabled       *bool
 	ControlPlaneFailureTolerance        *string
 	EuAccess                            bool
+	PodsCIDR                            *string
+	ServicesCIDR                        *string
 }
 
 type ExtensionProviderConfig struct {
@@ -190,8 +192,10 @@ func (c GardenerConfig) ToShootTemplate(namespace string, accountId string, subA
 				},
 			},
 			Networking: gardener_types.Networking{
-				Type:  "calico", // Default value - we may consider adding it to API (if Hydroform will support it)
-				Nodes: util.StringPtr(c.GardenerProviderConfig.NodeCIDR(c)),
+				Type:     "calico", // Default value - we may consider adding it to API (if Hydroform will support it)
+				Nodes:    util.StringPtr(c.GardenerProviderConfig.NodeCIDR(c)),
+				Pods:     c.PodsCIDR,
+				Services: c.ServicesCIDR,
 			},
 			Purpose:           purpose,
 			ExposureClassName: exposureClassName,
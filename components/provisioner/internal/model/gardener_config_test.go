@@ -612,6 +612,27 @@ func TestGardenerConfig_ToShootTemplate(t *testing.T) {
 
 }
 
+func TestGardenerConfig_ToShootTemplate_NetworkingCIDRs(t *testing.T) {
+	// given
+	gcpGardenerProvider, err := NewGCPGardenerConfig(fixGCPGardenerInput([]string{"fix-zone-1"}))
+	require.NoError(t, err)
+	gardenerConfig := fixGardenerConfig("gcp", gcpGardenerProvider)
+	gardenerConfig.PodsCIDR = util.StringPtr("10.96.0.0/13")
+	gardenerConfig.ServicesCIDR = util.StringPtr("10.104.0.0/13")
+
+	// when
+	template, err := gardenerConfig.ToShootTemplate("gardener-namespace", "account", "sub-account", oidcConfig(), dnsConfig())
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, gardener_types.Networking{
+		Type:     "calico",
+		Nodes:    util.StringPtr("10.10.10.10/255"),
+		Pods:     util.StringPtr("10.96.0.0/13"),
+		Services: util.StringPtr("10.104.0.0/13"),
+	}, template.Spec.Networking)
+}
+
 func TestEditShootConfig(t *testing.T) {
 	zones := []string{"fix-zone-1", "fix-zone-2"}
 
@@ -90,6 +90,9 @@ type Operation struct {
 	Stage          OperationStage
 	LastTransition *time.Time
 	LastError
+	// TraceID and SpanID identify the span of the request which started the operation, the stages are traced as its children
+	TraceID string
+	SpanID  string
 }
 
 type RuntimeAgentConnectionStatus int
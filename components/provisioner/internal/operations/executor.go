@@ -12,7 +12,10 @@ import (
 	"github.com/kyma-project/control-plane/components/provisioner/internal/director"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/model"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/provisioning/persistence/dbsession"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/tracing"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
@@ -118,7 +121,14 @@ func (e *Executor) process(operation model.Operation, cluster model.Cluster, log
 			return false, 0, NewNonRecoverableError(apperrors.Internal("error: timeout while processing operation").SetReason(apperrors.ErrProvisionerTimeout))
 		}
 
+		_, span := tracing.Tracer().Start(tracing.OperationContext(operation.TraceID, operation.SpanID), string(step.Name()), trace.WithAttributes(
+			attribute.String("provisioner.operation.id", operation.ID),
+			attribute.String("provisioner.operation.type", string(operation.Type)),
+			attribute.String("provisioner.runtime.id", cluster.ID),
+		))
 		result, err := step.Run(cluster, operation, log)
+		span.SetAttributes(attribute.String("provisioner.next_stage", string(result.Stage)), attribute.String("provisioner.delay", result.Delay.String()))
+		tracing.End(span, err)
 		if err != nil {
 			if errors.Is(err, ErrKubeconfigNil) {
 				log.Warnf("Warning, the %s", err)
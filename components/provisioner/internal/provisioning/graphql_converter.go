@@ -106,6 +106,8 @@ func (c graphQLConverter) gardenerConfigToGraphQLConfig(config model.GardenerCon
 		ShootNetworkingFilterDisabled:       config.ShootNetworkingFilterDisabled,
 		ControlPlaneFailureTolerance:        config.ControlPlaneFailureTolerance,
 		EuAccess:                            &config.EuAccess,
+		PodsCidr:                            config.PodsCIDR,
+		ServicesCidr:                        config.ServicesCIDR,
 	}
 }
 
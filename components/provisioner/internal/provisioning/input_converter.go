@@ -122,6 +122,8 @@ func (c converter) gardenerConfigFromInput(runtimeID string, input *gqlschema.Ga
 		ShootNetworkingFilterDisabled:       input.ShootNetworkingFilterDisabled,
 		ControlPlaneFailureTolerance:        input.ControlPlaneFailureTolerance,
 		EuAccess:                            util.UnwrapBoolOrDefault(input.EuAccess, c.defaultEuAccess),
+		PodsCIDR:                            input.PodsCidr,
+		ServicesCIDR:                        input.ServicesCidr,
 	}, nil
 }
 
@@ -184,6 +186,8 @@ func (c converter) UpgradeShootInputToGardenerConfig(input gqlschema.GardenerUpg
 		Region:       config.Region,
 		LicenceType:  config.LicenceType,
 		WorkerCidr:   config.WorkerCidr,
+		PodsCIDR:     config.PodsCIDR,
+		ServicesCIDR: config.ServicesCIDR,
 
 		Purpose:                             util.DefaultStrIfNil(input.Purpose, config.Purpose),
 		KubernetesVersion:                   util.UnwrapStrOrDefault(input.KubernetesVersion, config.KubernetesVersion),
@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	apperrors "github.com/kyma-project/control-plane/components/provisioner/internal/apperrors"
 	gqlschema "github.com/kyma-project/control-plane/components/provisioner/pkg/gqlschema"
 
@@ -14,20 +16,20 @@ type Service struct {
 	mock.Mock
 }
 
-// DeprovisionRuntime provides a mock function with given fields: id
-func (_m *Service) DeprovisionRuntime(id string) (string, apperrors.AppError) {
-	ret := _m.Called(id)
+// DeprovisionRuntime provides a mock function with given fields: ctx, id
+func (_m *Service) DeprovisionRuntime(ctx context.Context, id string) (string, apperrors.AppError) {
+	ret := _m.Called(ctx, id)
 
 	var r0 string
-	if rf, ok := ret.Get(0).(func(string) string); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, id)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
 	var r1 apperrors.AppError
-	if rf, ok := ret.Get(1).(func(string) apperrors.AppError); ok {
-		r1 = rf(id)
+	if rf, ok := ret.Get(1).(func(context.Context, string) apperrors.AppError); ok {
+		r1 = rf(ctx, id)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(apperrors.AppError)
@@ -37,13 +39,13 @@ func (_m *Service) DeprovisionRuntime(id string) (string, apperrors.AppError) {
 	return r0, r1
 }
 
-// HibernateCluster provides a mock function with given fields: clusterID
-func (_m *Service) HibernateCluster(clusterID string) (*gqlschema.OperationStatus, apperrors.AppError) {
-	ret := _m.Called(clusterID)
+// HibernateCluster provides a mock function with given fields: ctx, clusterID
+func (_m *Service) HibernateCluster(ctx context.Context, clusterID string) (*gqlschema.OperationStatus, apperrors.AppError) {
+	ret := _m.Called(ctx, clusterID)
 
 	var r0 *gqlschema.OperationStatus
-	if rf, ok := ret.Get(0).(func(string) *gqlschema.OperationStatus); ok {
-		r0 = rf(clusterID)
+	if rf, ok := ret.Get(0).(func(context.Context, string) *gqlschema.OperationStatus); ok {
+		r0 = rf(ctx, clusterID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*gqlschema.OperationStatus)
@@ -51,8 +53,8 @@ func (_m *Service) HibernateCluster(clusterID string) (*gqlschema.OperationStatu
 	}
 
 	var r1 apperrors.AppError
-	if rf, ok := ret.Get(1).(func(string) apperrors.AppError); ok {
-		r1 = rf(clusterID)
+	if rf, ok := ret.Get(1).(func(context.Context, string) apperrors.AppError); ok {
+		r1 = rf(ctx, clusterID)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(apperrors.AppError)
@@ -62,13 +64,13 @@ func (_m *Service) HibernateCluster(clusterID string) (*gqlschema.OperationStatu
 	return r0, r1
 }
 
-// ProvisionRuntime provides a mock function with given fields: config, tenant, subAccount
-func (_m *Service) ProvisionRuntime(config gqlschema.ProvisionRuntimeInput, tenant string, subAccount string) (*gqlschema.OperationStatus, apperrors.AppError) {
-	ret := _m.Called(config, tenant, subAccount)
+// ProvisionRuntime provides a mock function with given fields: ctx, config, tenant, subAccount
+func (_m *Service) ProvisionRuntime(ctx context.Context, config gqlschema.ProvisionRuntimeInput, tenant string, subAccount string) (*gqlschema.OperationStatus, apperrors.AppError) {
+	ret := _m.Called(ctx, config, tenant, subAccount)
 
 	var r0 *gqlschema.OperationStatus
-	if rf, ok := ret.Get(0).(func(gqlschema.ProvisionRuntimeInput, string, string) *gqlschema.OperationStatus); ok {
-		r0 = rf(config, tenant, subAccount)
+	if rf, ok := ret.Get(0).(func(context.Context, gqlschema.ProvisionRuntimeInput, string, string) *gqlschema.OperationStatus); ok {
+		r0 = rf(ctx, config, tenant, subAccount)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*gqlschema.OperationStatus)
@@ -76,8 +78,8 @@ func (_m *Service) ProvisionRuntime(config gqlschema.ProvisionRuntimeInput, tena
 	}
 
 	var r1 apperrors.AppError
-	if rf, ok := ret.Get(1).(func(gqlschema.ProvisionRuntimeInput, string, string) apperrors.AppError); ok {
-		r1 = rf(config, tenant, subAccount)
+	if rf, ok := ret.Get(1).(func(context.Context, gqlschema.ProvisionRuntimeInput, string, string) apperrors.AppError); ok {
+		r1 = rf(ctx, config, tenant, subAccount)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(apperrors.AppError)
@@ -185,13 +187,13 @@ func (_m *Service) RuntimeStatus(id string) (*gqlschema.RuntimeStatus, apperrors
 	return r0, r1
 }
 
-// UpgradeGardenerShoot provides a mock function with given fields: id, input
-func (_m *Service) UpgradeGardenerShoot(id string, input gqlschema.UpgradeShootInput) (*gqlschema.OperationStatus, apperrors.AppError) {
-	ret := _m.Called(id, input)
+// UpgradeGardenerShoot provides a mock function with given fields: ctx, id, input
+func (_m *Service) UpgradeGardenerShoot(ctx context.Context, id string, input gqlschema.UpgradeShootInput) (*gqlschema.OperationStatus, apperrors.AppError) {
+	ret := _m.Called(ctx, id, input)
 
 	var r0 *gqlschema.OperationStatus
-	if rf, ok := ret.Get(0).(func(string, gqlschema.UpgradeShootInput) *gqlschema.OperationStatus); ok {
-		r0 = rf(id, input)
+	if rf, ok := ret.Get(0).(func(context.Context, string, gqlschema.UpgradeShootInput) *gqlschema.OperationStatus); ok {
+		r0 = rf(ctx, id, input)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*gqlschema.OperationStatus)
@@ -199,8 +201,8 @@ func (_m *Service) UpgradeGardenerShoot(id string, input gqlschema.UpgradeShootI
 	}
 
 	var r1 apperrors.AppError
-	if rf, ok := ret.Get(1).(func(string, gqlschema.UpgradeShootInput) apperrors.AppError); ok {
-		r1 = rf(id, input)
+	if rf, ok := ret.Get(1).(func(context.Context, string, gqlschema.UpgradeShootInput) apperrors.AppError); ok {
+		r1 = rf(ctx, id, input)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(apperrors.AppError)
@@ -210,13 +212,13 @@ func (_m *Service) UpgradeGardenerShoot(id string, input gqlschema.UpgradeShootI
 	return r0, r1
 }
 
-// UpgradeRuntime provides a mock function with given fields: id, config
-func (_m *Service) UpgradeRuntime(id string, config gqlschema.UpgradeRuntimeInput) (*gqlschema.OperationStatus, apperrors.AppError) {
-	ret := _m.Called(id, config)
+// UpgradeRuntime provides a mock function with given fields: ctx, id, config
+func (_m *Service) UpgradeRuntime(ctx context.Context, id string, config gqlschema.UpgradeRuntimeInput) (*gqlschema.OperationStatus, apperrors.AppError) {
+	ret := _m.Called(ctx, id, config)
 
 	var r0 *gqlschema.OperationStatus
-	if rf, ok := ret.Get(0).(func(string, gqlschema.UpgradeRuntimeInput) *gqlschema.OperationStatus); ok {
-		r0 = rf(id, config)
+	if rf, ok := ret.Get(0).(func(context.Context, string, gqlschema.UpgradeRuntimeInput) *gqlschema.OperationStatus); ok {
+		r0 = rf(ctx, id, config)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*gqlschema.OperationStatus)
@@ -224,8 +226,8 @@ func (_m *Service) UpgradeRuntime(id string, config gqlschema.UpgradeRuntimeInpu
 	}
 
 	var r1 apperrors.AppError
-	if rf, ok := ret.Get(1).(func(string, gqlschema.UpgradeRuntimeInput) apperrors.AppError); ok {
-		r1 = rf(id, config)
+	if rf, ok := ret.Get(1).(func(context.Context, string, gqlschema.UpgradeRuntimeInput) apperrors.AppError); ok {
+		r1 = rf(ctx, id, config)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(apperrors.AppError)
@@ -136,6 +136,8 @@ func (ws writeSession) InsertGardenerConfig(config model.GardenerConfig) dberror
 		Pair("shoot_networking_filter_disabled", config.ShootNetworkingFilterDisabled).
 		Pair("control_plane_failure_tolerance", config.ControlPlaneFailureTolerance).
 		Pair("eu_access", config.EuAccess).
+		Pair("pods_cidr", config.PodsCIDR).
+		Pair("services_cidr", config.ServicesCIDR).
 		Exec()
 
 	if err != nil {
@@ -1,6 +1,7 @@
 package provisioning
 
 import (
+	"context"
 	"time"
 
 	gardener_Types "github.com/gardener/gardener/pkg/apis/core/v1beta1"
@@ -13,6 +14,7 @@ import (
 	"github.com/kyma-project/control-plane/components/provisioner/internal/operations/queue"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/persistence/dberrors"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/provisioning/persistence/dbsession"
+	"github.com/kyma-project/control-plane/components/provisioner/internal/tracing"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/util"
 	"github.com/kyma-project/control-plane/components/provisioner/internal/util/k8s"
 	uuid "github.com/kyma-project/control-plane/components/provisioner/internal/uuid"
@@ -22,15 +24,15 @@ import (
 
 //go:generate mockery --name=Service
 type Service interface {
-	ProvisionRuntime(config gqlschema.ProvisionRuntimeInput, tenant, subAccount string) (*gqlschema.OperationStatus, apperrors.AppError)
-	UpgradeRuntime(id string, config gqlschema.UpgradeRuntimeInput) (*gqlschema.OperationStatus, apperrors.AppError)
-	DeprovisionRuntime(id string) (string, apperrors.AppError)
-	UpgradeGardenerShoot(id string, input gqlschema.UpgradeShootInput) (*gqlschema.OperationStatus, apperrors.AppError)
+	ProvisionRuntime(ctx context.Context, config gqlschema.ProvisionRuntimeInput, tenant, subAccount string) (*gqlschema.OperationStatus, apperrors.AppError)
+	UpgradeRuntime(ctx context.Context, id string, config gqlschema.UpgradeRuntimeInput) (*gqlschema.OperationStatus, apperrors.AppError)
+	DeprovisionRuntime(ctx context.Context, id string) (string, apperrors.AppError)
+	UpgradeGardenerShoot(ctx context.Context, id string, input gqlschema.UpgradeShootInput) (*gqlschema.OperationStatus, apperrors.AppError)
 	ReconnectRuntimeAgent(id string) (string, apperrors.AppError)
 	RuntimeStatus(id string) (*gqlschema.RuntimeStatus, apperrors.AppError)
 	RuntimeOperationStatus(id string) (*gqlschema.OperationStatus, apperrors.AppError)
 	RollBackLastUpgrade(runtimeID string) (*gqlschema.RuntimeStatus, apperrors.AppError)
-	HibernateCluster(clusterID string) (*gqlschema.OperationStatus, apperrors.AppError)
+	HibernateCluster(ctx context.Context, clusterID string) (*gqlschema.OperationStatus, apperrors.AppError)
 }
 
 //go:generate mockery --name=Provisioner
@@ -104,7 +106,7 @@ func NewProvisioningService(
 	}
 }
 
-func (r *service) ProvisionRuntime(config gqlschema.ProvisionRuntimeInput, tenant, subAccount string) (*gqlschema.OperationStatus, apperrors.AppError) {
+func (r *service) ProvisionRuntime(ctx context.Context, config gqlschema.ProvisionRuntimeInput, tenant, subAccount string) (*gqlschema.OperationStatus, apperrors.AppError) {
 	runtimeInput := config.RuntimeInput
 
 	var runtimeID string
@@ -133,7 +135,7 @@ func (r *service) ProvisionRuntime(config gqlschema.ProvisionRuntimeInput, tenan
 	withKymaConfig := config.KymaConfig != nil
 
 	// Try to set provisioning started before triggering it (which is hard to interrupt) to verify all unique constraints
-	operation, dberr := r.setProvisioningStarted(dbSession, runtimeID, cluster, withKymaConfig)
+	operation, dberr := r.setProvisioningStarted(ctx, dbSession, runtimeID, cluster, withKymaConfig)
 	if dberr != nil {
 		r.unregisterFailedRuntime(runtimeID, tenant)
 		return nil, dberr
@@ -173,7 +175,7 @@ func (r *service) unregisterFailedRuntime(id, tenant string) {
 	}
 }
 
-func (r *service) DeprovisionRuntime(id string) (string, apperrors.AppError) {
+func (r *service) DeprovisionRuntime(ctx context.Context, id string) (string, apperrors.AppError) {
 	session := r.dbSessionFactory.NewReadWriteSession()
 
 	appErr := r.verifyLastOperationFinished(session, id)
@@ -192,6 +194,7 @@ func (r *service) DeprovisionRuntime(id string) (string, apperrors.AppError) {
 	if appErr != nil {
 		return "", apperrors.Internal("Failed to start deprovisioning: %s", appErr.Error()).SetComponent(appErr.Component()).SetReason(appErr.Reason())
 	}
+	operation.TraceID, operation.SpanID = tracing.SpanIDs(ctx)
 
 	dberr = session.InsertOperation(operation)
 	if dberr != nil {
@@ -209,7 +212,7 @@ func (r *service) DeprovisionRuntime(id string) (string, apperrors.AppError) {
 	return operation.ID, nil
 }
 
-func (r *service) UpgradeGardenerShoot(runtimeID string, input gqlschema.UpgradeShootInput) (*gqlschema.OperationStatus, apperrors.AppError) {
+func (r *service) UpgradeGardenerShoot(ctx context.Context, runtimeID string, input gqlschema.UpgradeShootInput) (*gqlschema.OperationStatus, apperrors.AppError) {
 	log.Infof("Starting Upgrade of Gardener Shoot for Runtime '%s'...", runtimeID)
 
 	if input.GardenerConfig == nil {
@@ -266,7 +269,7 @@ func (r *service) UpgradeGardenerShoot(runtimeID string, input gqlschema.Upgrade
 	}
 	defer txSession.RollbackUnlessCommitted()
 
-	operation, gardError := r.setGardenerShootUpgradeStarted(txSession, cluster, gardenerConfig, input.Administrators)
+	operation, gardError := r.setGardenerShootUpgradeStarted(ctx, txSession, cluster, gardenerConfig, input.Administrators)
 	if gardError != nil {
 		return &gqlschema.OperationStatus{}, apperrors.Internal("Failed to set shoot upgrade started: %s", gardError.Error())
 	}
@@ -286,7 +289,7 @@ func (r *service) UpgradeGardenerShoot(runtimeID string, input gqlschema.Upgrade
 	return r.graphQLConverter.OperationStatusToGQLOperationStatus(operation), nil
 }
 
-func (r *service) HibernateCluster(runtimeID string) (*gqlschema.OperationStatus, apperrors.AppError) {
+func (r *service) HibernateCluster(ctx context.Context, runtimeID string) (*gqlschema.OperationStatus, apperrors.AppError) {
 	log.Infof("Starting hibernation for Runtime '%s'...", runtimeID)
 
 	session := r.dbSessionFactory.NewReadSession()
@@ -307,7 +310,7 @@ func (r *service) HibernateCluster(runtimeID string) (*gqlschema.OperationStatus
 	}
 	defer txSession.RollbackUnlessCommitted()
 
-	operation, gardError := r.setHibernationStarted(txSession, cluster, cluster.ClusterConfig)
+	operation, gardError := r.setHibernationStarted(ctx, txSession, cluster, cluster.ClusterConfig)
 	if gardError != nil {
 		return nil, apperrors.Internal("Failed to set hibernation started: %s", gardError.Error())
 	}
@@ -340,7 +343,7 @@ func (r *service) verifyLastOperationFinished(session dbsession.ReadSession, run
 	return nil
 }
 
-func (r *service) UpgradeRuntime(runtimeId string, input gqlschema.UpgradeRuntimeInput) (*gqlschema.OperationStatus, apperrors.AppError) {
+func (r *service) UpgradeRuntime(ctx context.Context, runtimeId string, input gqlschema.UpgradeRuntimeInput) (*gqlschema.OperationStatus, apperrors.AppError) {
 	if input.KymaConfig == nil {
 		return &gqlschema.OperationStatus{}, apperrors.BadRequest("error: Kyma config is nil")
 	}
@@ -376,7 +379,7 @@ func (r *service) UpgradeRuntime(runtimeId string, input gqlschema.UpgradeRuntim
 	}
 	defer txSession.RollbackUnlessCommitted()
 
-	operation, dberr := r.setUpgradeStarted(txSession, cluster, kymaConfig)
+	operation, dberr := r.setUpgradeStarted(ctx, txSession, cluster, kymaConfig)
 	if dberr != nil {
 		return &gqlschema.OperationStatus{}, apperrors.Internal("failed to set upgrade started: %s", dberr.Error())
 	}
@@ -526,7 +529,7 @@ func (r *service) getRuntimeStatus(runtimeID string) (model.RuntimeStatus, apper
 	}, nil
 }
 
-func (r *service) setProvisioningStarted(dbSession dbsession.WriteSession, runtimeID string, cluster model.Cluster, withKymaConfig bool) (model.Operation, dberrors.Error) {
+func (r *service) setProvisioningStarted(ctx context.Context, dbSession dbsession.WriteSession, runtimeID string, cluster model.Cluster, withKymaConfig bool) (model.Operation, dberrors.Error) {
 	timestamp := time.Now()
 	cluster.CreationTimestamp = timestamp
 
@@ -546,7 +549,7 @@ func (r *service) setProvisioningStarted(dbSession dbsession.WriteSession, runti
 		}
 	}
 
-	operation, err := r.setOperationStarted(dbSession, runtimeID, provisioningMode, model.WaitingForClusterDomain, timestamp, "Provisioning started")
+	operation, err := r.setOperationStarted(ctx, dbSession, runtimeID, provisioningMode, model.WaitingForClusterDomain, timestamp, "Provisioning started")
 	if err != nil {
 		return model.Operation{}, err.Append("Failed to set provisioning started: %s")
 	}
@@ -554,7 +557,7 @@ func (r *service) setProvisioningStarted(dbSession dbsession.WriteSession, runti
 	return operation, nil
 }
 
-func (r *service) setGardenerShootUpgradeStarted(txSession dbsession.WriteSession, currentCluster model.Cluster, gardenerConfig model.GardenerConfig, administrators []string) (model.Operation, error) {
+func (r *service) setGardenerShootUpgradeStarted(ctx context.Context, txSession dbsession.WriteSession, currentCluster model.Cluster, gardenerConfig model.GardenerConfig, administrators []string) (model.Operation, error) {
 	log.Infof("Starting Upgrade of Gardener Shoot operation")
 
 	dberr := txSession.UpdateGardenerClusterConfig(gardenerConfig)
@@ -567,7 +570,7 @@ func (r *service) setGardenerShootUpgradeStarted(txSession dbsession.WriteSessio
 		return model.Operation{}, dberrors.Internal("Failed to set Shoot Upgrade started: %s", dberr.Error())
 	}
 
-	operation, dbError := r.setOperationStarted(txSession, currentCluster.ID, model.UpgradeShoot, model.WaitingForShootNewVersion, time.Now(), "Starting Gardener Shoot upgrade")
+	operation, dbError := r.setOperationStarted(ctx, txSession, currentCluster.ID, model.UpgradeShoot, model.WaitingForShootNewVersion, time.Now(), "Starting Gardener Shoot upgrade")
 
 	if dbError != nil {
 		return model.Operation{}, dbError.Append("Failed to start operation of Gardener Shoot upgrade %s", dbError.Error())
@@ -576,14 +579,14 @@ func (r *service) setGardenerShootUpgradeStarted(txSession dbsession.WriteSessio
 	return operation, nil
 }
 
-func (r *service) setUpgradeStarted(txSession dbsession.WriteSession, cluster model.Cluster, kymaConfig model.KymaConfig) (model.Operation, dberrors.Error) {
+func (r *service) setUpgradeStarted(ctx context.Context, txSession dbsession.WriteSession, cluster model.Cluster, kymaConfig model.KymaConfig) (model.Operation, dberrors.Error) {
 
 	err := txSession.InsertKymaConfig(kymaConfig)
 	if err != nil {
 		return model.Operation{}, err.Append("Failed to insert Kyma Config")
 	}
 
-	operation, err := r.setOperationStarted(txSession, cluster.ID, model.Upgrade, model.StartingUpgrade, time.Now(), "Starting Kyma upgrade")
+	operation, err := r.setOperationStarted(ctx, txSession, cluster.ID, model.Upgrade, model.StartingUpgrade, time.Now(), "Starting Kyma upgrade")
 	if err != nil {
 		return model.Operation{}, err.Append("Failed to set operation started")
 	}
@@ -609,10 +612,10 @@ func (r *service) setUpgradeStarted(txSession dbsession.WriteSession, cluster mo
 	return operation, nil
 }
 
-func (r *service) setHibernationStarted(txSession dbsession.WriteSession, currentCluster model.Cluster, gardenerConfig model.GardenerConfig) (model.Operation, error) {
+func (r *service) setHibernationStarted(ctx context.Context, txSession dbsession.WriteSession, currentCluster model.Cluster, gardenerConfig model.GardenerConfig) (model.Operation, error) {
 	log.Infof("Starting hibernation operation")
 
-	operation, dbError := r.setOperationStarted(txSession, currentCluster.ID, model.Hibernate, model.WaitForHibernation, time.Now(), "Starting ")
+	operation, dbError := r.setOperationStarted(ctx, txSession, currentCluster.ID, model.Hibernate, model.WaitForHibernation, time.Now(), "Starting ")
 
 	if dbError != nil {
 		return model.Operation{}, dbError.Append("Failed to start hibernation operation:  %s", dbError.Error())
@@ -622,6 +625,7 @@ func (r *service) setHibernationStarted(txSession dbsession.WriteSession, curren
 }
 
 func (r *service) setOperationStarted(
+	ctx context.Context,
 	dbSession dbsession.WriteSession,
 	runtimeID string,
 	operationType model.OperationType,
@@ -640,6 +644,7 @@ func (r *service) setOperationStarted(
 		Stage:          operationStage,
 		LastTransition: &timestamp,
 	}
+	operation.TraceID, operation.SpanID = tracing.SpanIDs(ctx)
 
 	err := dbSession.InsertOperation(operation)
 	if err != nil {
@@ -1,6 +1,8 @@
 package provisioning
 
 import (
+	"context"
+
 	"testing"
 	"time"
 
@@ -139,7 +141,7 @@ func TestService_ProvisionRuntime(t *testing.T) {
 		service := NewProvisioningService(inputConverter, graphQLConverter, directorServiceMock, sessionFactoryMock, provisioner, uuidGenerator, nil, nil, provisioningQueue, nil, nil, nil, nil, nil, nil)
 
 		// when
-		operationStatus, err := service.ProvisionRuntime(provisionRuntimeInput, tenant, subAccountId)
+		operationStatus, err := service.ProvisionRuntime(context.Background(), provisionRuntimeInput, tenant, subAccountId)
 		require.NoError(t, err)
 
 		// then
@@ -185,7 +187,7 @@ func TestService_ProvisionRuntime(t *testing.T) {
 		service := NewProvisioningService(inputConverter, graphQLConverter, directorServiceMock, sessionFactoryMock, provisioner, uuidGenerator, nil, nil, nil, provisioningNoInstallQueue, nil, nil, nil, nil, nil)
 
 		// when
-		operationStatus, err := service.ProvisionRuntime(provisionRuntimeInputNoKymaConfig, tenant, subAccountId)
+		operationStatus, err := service.ProvisionRuntime(context.Background(), provisionRuntimeInputNoKymaConfig, tenant, subAccountId)
 		require.NoError(t, err)
 
 		// then
@@ -221,7 +223,7 @@ func TestService_ProvisionRuntime(t *testing.T) {
 		service := NewProvisioningService(inputConverter, graphQLConverter, directorServiceMock, sessionFactoryMock, provisioner, uuidGenerator, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 		// when
-		_, err := service.ProvisionRuntime(provisionRuntimeInput, tenant, subAccountId)
+		_, err := service.ProvisionRuntime(context.Background(), provisionRuntimeInput, tenant, subAccountId)
 		require.Error(t, err)
 
 		//then
@@ -254,7 +256,7 @@ func TestService_ProvisionRuntime(t *testing.T) {
 		service := NewProvisioningService(inputConverter, graphQLConverter, directorServiceMock, sessionFactoryMock, provisioner, uuidGenerator, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 		// when
-		_, err := service.ProvisionRuntime(provisionRuntimeInput, tenant, subAccountId)
+		_, err := service.ProvisionRuntime(context.Background(), provisionRuntimeInput, tenant, subAccountId)
 		require.Error(t, err)
 		util.CheckErrorType(t, err, apperrors.CodeInternal)
 
@@ -276,7 +278,7 @@ func TestService_ProvisionRuntime(t *testing.T) {
 		service := NewProvisioningService(inputConverter, graphQLConverter, directorServiceMock, nil, nil, uuidGenerator, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 		// when
-		_, err := service.ProvisionRuntime(provisionRuntimeInput, tenant, subAccountId)
+		_, err := service.ProvisionRuntime(context.Background(), provisionRuntimeInput, tenant, subAccountId)
 		require.Error(t, err)
 		util.CheckErrorType(t, err, apperrors.CodeInternal)
 
@@ -311,7 +313,7 @@ func TestService_ProvisionRuntime(t *testing.T) {
 		service := NewProvisioningService(inputConverter, graphQLConverter, directorServiceMock, sessionFactoryMock, provisioner, uuidGenerator, nil, nil, provisioningQueue, nil, nil, nil, nil, nil, nil)
 
 		// when
-		operationStatus, err := service.ProvisionRuntime(provisionRuntimeInput, tenant, subAccountId)
+		operationStatus, err := service.ProvisionRuntime(context.Background(), provisionRuntimeInput, tenant, subAccountId)
 		require.NoError(t, err)
 
 		// then
@@ -385,7 +387,7 @@ func TestService_DeprovisionRuntime(t *testing.T) {
 		resolver := NewProvisioningService(inputConverter, graphQLConverter, nil, sessionFactoryMock, provisioner, uuid.NewUUIDGenerator(), nil, installationClient, nil, nil, deprovisioningQueue, nil, nil, nil, nil)
 
 		// when
-		opID, err := resolver.DeprovisionRuntime(runtimeID)
+		opID, err := resolver.DeprovisionRuntime(context.Background(), runtimeID)
 		require.NoError(t, err)
 
 		// then
@@ -418,7 +420,7 @@ func TestService_DeprovisionRuntime(t *testing.T) {
 		resolver := NewProvisioningService(inputConverter, graphQLConverter, nil, sessionFactoryMock, provisioner, uuid.NewUUIDGenerator(), nil, installationClient, nil, nil, deprovisioningQueue, nil, nil, nil, nil)
 
 		// when
-		opID, err := resolver.DeprovisionRuntime(runtimeID)
+		opID, err := resolver.DeprovisionRuntime(context.Background(), runtimeID)
 		require.NoError(t, err)
 
 		// then
@@ -456,7 +458,7 @@ func TestService_DeprovisionRuntime(t *testing.T) {
 		resolver := NewProvisioningService(inputConverter, graphQLConverter, nil, sessionFactoryMock, provisioner, uuid.NewUUIDGenerator(), nil, nil, nil, nil, deprovisioningQueue, nil, nil, nil, nil)
 
 		// when
-		opID, err := resolver.DeprovisionRuntime(runtimeID)
+		opID, err := resolver.DeprovisionRuntime(context.Background(), runtimeID)
 		require.NoError(t, err)
 
 		// then
@@ -499,7 +501,7 @@ func TestService_DeprovisionRuntime(t *testing.T) {
 		resolver := NewProvisioningService(inputConverter, graphQLConverter, nil, sessionFactoryMock, provisioner, uuid.NewUUIDGenerator(), nil, installationClient, nil, nil, nil, deprovisioningNoInstallQueue, nil, nil, nil)
 
 		// when
-		opID, err := resolver.DeprovisionRuntime(runtimeID)
+		opID, err := resolver.DeprovisionRuntime(context.Background(), runtimeID)
 		require.NoError(t, err)
 
 		// then
@@ -546,7 +548,7 @@ func TestService_DeprovisionRuntime(t *testing.T) {
 		resolver := NewProvisioningService(inputConverter, graphQLConverter, nil, sessionFactoryMock, provisioner, uuid.NewUUIDGenerator(), nil, nil, nil, nil, nil, deprovisioningNoInstallQueue, nil, nil, nil)
 
 		// when
-		opID, err := resolver.DeprovisionRuntime(runtimeID)
+		opID, err := resolver.DeprovisionRuntime(context.Background(), runtimeID)
 		require.NoError(t, err)
 
 		// then
@@ -573,7 +575,7 @@ func TestService_DeprovisionRuntime(t *testing.T) {
 		resolver := NewProvisioningService(inputConverter, graphQLConverter, nil, sessionFactoryMock, provisioner, uuid.NewUUIDGenerator(), nil, installationClient, nil, nil, nil, nil, nil, nil, nil)
 
 		// when
-		_, err := resolver.DeprovisionRuntime(runtimeID)
+		_, err := resolver.DeprovisionRuntime(context.Background(), runtimeID)
 		require.Error(t, err)
 		util.CheckErrorType(t, err, apperrors.CodeInternal)
 
@@ -598,7 +600,7 @@ func TestService_DeprovisionRuntime(t *testing.T) {
 		resolver := NewProvisioningService(inputConverter, graphQLConverter, nil, sessionFactoryMock, nil, uuid.NewUUIDGenerator(), nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 		// when
-		_, err := resolver.DeprovisionRuntime(runtimeID)
+		_, err := resolver.DeprovisionRuntime(context.Background(), runtimeID)
 		require.Error(t, err)
 
 		// then
@@ -622,7 +624,7 @@ func TestService_DeprovisionRuntime(t *testing.T) {
 		resolver := NewProvisioningService(inputConverter, graphQLConverter, nil, sessionFactoryMock, nil, uuid.NewUUIDGenerator(), nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 		// when
-		_, err := resolver.DeprovisionRuntime(runtimeID)
+		_, err := resolver.DeprovisionRuntime(context.Background(), runtimeID)
 		require.Error(t, err)
 
 		// then
@@ -645,7 +647,7 @@ func TestService_DeprovisionRuntime(t *testing.T) {
 		resolver := NewProvisioningService(inputConverter, graphQLConverter, nil, sessionFactoryMock, nil, uuid.NewUUIDGenerator(), nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 		// when
-		_, err := resolver.DeprovisionRuntime(runtimeID)
+		_, err := resolver.DeprovisionRuntime(context.Background(), runtimeID)
 		require.Error(t, err)
 
 		// then
@@ -942,7 +944,7 @@ func TestService_UpgradeRuntime(t *testing.T) {
 			service := NewProvisioningService(inputConverter, graphQLConverter, nil, sessionFactory, nil, uuidGenerator, shootProvider, nil, provisioningQueue, nil, deprovisioningQueue, nil, upgradeQueue, upgradeShootQueue, nil)
 
 			// when
-			operationStatus, err := service.UpgradeRuntime(runtimeID, upgradeInput)
+			operationStatus, err := service.UpgradeRuntime(context.Background(), runtimeID, upgradeInput)
 			require.NoError(t, err)
 
 			// then
@@ -1032,7 +1034,7 @@ func TestService_UpgradeRuntime(t *testing.T) {
 			service := NewProvisioningService(inputConverter, graphQLConverter, nil, sessionFactory, nil, uuidGenerator, shootProvider, nil, provisioningQueue, nil, deprovisioningQueue, nil, upgradeQueue, upgradeShootQueue, nil)
 
 			// when
-			_, err := service.UpgradeRuntime(runtimeID, upgradeInput)
+			_, err := service.UpgradeRuntime(context.Background(), runtimeID, upgradeInput)
 			require.Error(t, err)
 
 			// then
@@ -1146,7 +1148,7 @@ func TestService_UpgradeGardenerShoot(t *testing.T) {
 			service := NewProvisioningService(inputConverter, graphQLConverter, nil, sessionFactory, provisioner, uuidGenerator, shootProvider, nil, nil, nil, nil, nil, nil, upgradeShootQueue, nil)
 
 			// when
-			operationStatus, err := service.UpgradeGardenerShoot(runtimeID, upgradeShootInput)
+			operationStatus, err := service.UpgradeGardenerShoot(context.Background(), runtimeID, upgradeShootInput)
 			require.NoError(t, err)
 
 			// then
@@ -1266,7 +1268,7 @@ func TestService_UpgradeGardenerShoot(t *testing.T) {
 			service := NewProvisioningService(inputConverter, graphQLConverter, nil, sessionFactory, provisioner, uuidGenerator, shootProvider, nil, nil, nil, nil, nil, nil, upgradeShootQueue, nil)
 
 			// when
-			_, err := service.UpgradeGardenerShoot(runtimeID, upgradeShootInput)
+			_, err := service.UpgradeGardenerShoot(context.Background(), runtimeID, upgradeShootInput)
 			require.Error(t, err)
 
 			// then
@@ -1493,7 +1495,7 @@ func TestService_HibernateShoot(t *testing.T) {
 			service := NewProvisioningService(inputConverter, graphQLConverter, nil, sessionFactoryMock, provisioner, uuidGenerator, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 			// when
-			_, err := service.HibernateCluster(runtimeID)
+			_, err := service.HibernateCluster(context.Background(), runtimeID)
 			require.Error(t, err)
 
 			// then
@@ -1525,7 +1527,7 @@ func TestService_HibernateShoot(t *testing.T) {
 		service := NewProvisioningService(inputConverter, graphQLConverter, nil, sessionFactoryMock, provisionerMock, uuidGenerator, nil, nil, nil, nil, nil, nil, nil, nil, hibernationQueue)
 
 		// when
-		runtimeStatus, err := service.HibernateCluster(runtimeID)
+		runtimeStatus, err := service.HibernateCluster(context.Background(), runtimeID)
 		require.NoError(t, err)
 
 		// then
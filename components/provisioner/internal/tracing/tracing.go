@@ -0,0 +1,116 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/kyma-project/control-plane/components/provisioner"
+
+type Config struct {
+	Enabled bool `envconfig:"default=false"`
+	// Endpoint is the OTLP/HTTP traces endpoint of the collector, e.g. http://otel-collector.kyma-system:4318/v1/traces
+	Endpoint    string `envconfig:"optional"`
+	ServiceName string `envconfig:"default=provisioner"`
+}
+
+// Init configures the propagation of the trace context in the W3C headers and, if the tracing is enabled, the export of the spans to the OTLP endpoint.
+// The returned function flushes the spans which are not exported yet.
+func Init(cfg Config, log logrus.FieldLogger) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	if !cfg.Enabled {
+		return func(context.Context) error { return nil }, nil
+	}
+	if cfg.Endpoint == "" {
+		return nil, fmt.Errorf("tracing is enabled but the OTLP endpoint is not set")
+	}
+
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		log.Errorf("while tracing: %s", err)
+	}))
+	exporter, err := NewExporter(context.Background(), cfg.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", cfg.ServiceName))),
+	)
+	otel.SetTracerProvider(provider)
+	log.Infof("Exporting traces to %s", cfg.Endpoint)
+
+	return provider.Shutdown, nil
+}
+
+// NewExporter returns the exporter which sends the spans to the OTLP/HTTP traces endpoint, the endpoint is called without TLS if its scheme is http
+func NewExporter(ctx context.Context, endpoint string) (*otlptrace.Exporter, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("while parsing the OTLP endpoint: %w", err)
+	}
+	options := []otlptracehttp.Option{otlptracehttp.WithEndpoint(u.Host)}
+	if u.Path != "" {
+		options = append(options, otlptracehttp.WithURLPath(u.Path))
+	}
+	if u.Scheme == "http" {
+		options = append(options, otlptracehttp.WithInsecure())
+	}
+	exporter, err := otlptracehttp.New(ctx, options...)
+	if err != nil {
+		return nil, fmt.Errorf("while creating the OTLP exporter: %w", err)
+	}
+	return exporter, nil
+}
+
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// End records the error in the span and ends it
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// SpanIDs returns the IDs of the span in the context, the operation stores them to trace its stages as children of the span
+func SpanIDs(ctx context.Context) (traceID, spanID string) {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.IsValid() {
+		return "", ""
+	}
+	return spanContext.TraceID().String(), spanContext.SpanID().String()
+}
+
+// OperationContext returns the context with the span stored in the operation as the remote parent,
+// without the stored IDs the stages are traced in a new trace
+func OperationContext(traceIDHex, spanIDHex string) context.Context {
+	traceID, err := trace.TraceIDFromHex(traceIDHex)
+	if err != nil {
+		return context.Background()
+	}
+	spanID, err := trace.SpanIDFromHex(spanIDHex)
+	if err != nil {
+		return context.Background()
+	}
+	return trace.ContextWithRemoteSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	}))
+}
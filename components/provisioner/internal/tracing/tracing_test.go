@@ -0,0 +1,30 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestOperationContext(t *testing.T) {
+	// given
+	spanContext := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{0x01},
+		SpanID:  trace.SpanID{0x02},
+	})
+	traceID, spanID := SpanIDs(trace.ContextWithSpanContext(context.Background(), spanContext))
+
+	// when
+	ctx := OperationContext(traceID, spanID)
+
+	// then
+	assert.Equal(t, spanContext.TraceID(), trace.SpanContextFromContext(ctx).TraceID())
+	assert.Equal(t, spanContext.SpanID(), trace.SpanContextFromContext(ctx).SpanID())
+	assert.True(t, trace.SpanContextFromContext(ctx).IsRemote())
+	traceID, spanID = SpanIDs(context.Background())
+	assert.Empty(t, traceID)
+	assert.Empty(t, spanID)
+	assert.False(t, trace.SpanContextFromContext(OperationContext("", "")).IsValid())
+}
@@ -165,6 +165,8 @@ type GardenerConfig struct {
 	ShootNetworkingFilterDisabled       *bool                  `json:"shootNetworkingFilterDisabled"`
 	ControlPlaneFailureTolerance        *string                `json:"controlPlaneFailureTolerance"`
 	EuAccess                            *bool                  `json:"euAccess"`
+	PodsCidr                            *string                `json:"podsCidr"`
+	ServicesCidr                        *string                `json:"servicesCidr"`
 }
 
 type GardenerConfigInput struct {
@@ -196,6 +198,8 @@ type GardenerConfigInput struct {
 	ShootNetworkingFilterDisabled       *bool                  `json:"shootNetworkingFilterDisabled"`
 	ControlPlaneFailureTolerance        *string                `json:"controlPlaneFailureTolerance"`
 	EuAccess                            *bool                  `json:"euAccess"`
+	PodsCidr                            *string                `json:"podsCidr"`
+	ServicesCidr                        *string                `json:"servicesCidr"`
 }
 
 type GardenerUpgradeInput struct {
@@ -120,11 +120,13 @@ type ComplexityRoot struct {
 		MaxUnavailable                      func(childComplexity int) int
 		Name                                func(childComplexity int) int
 		OidcConfig                          func(childComplexity int) int
+		PodsCidr                            func(childComplexity int) int
 		Provider                            func(childComplexity int) int
 		ProviderSpecificConfig              func(childComplexity int) int
 		Purpose                             func(childComplexity int) int
 		Region                              func(childComplexity int) int
 		Seed                                func(childComplexity int) int
+		ServicesCidr                        func(childComplexity int) int
 		ShootNetworkingFilterDisabled       func(childComplexity int) int
 		TargetSecret                        func(childComplexity int) int
 		VolumeSizeGb                        func(childComplexity int) int
@@ -566,6 +568,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.GardenerConfig.OidcConfig(childComplexity), true
 
+	case "GardenerConfig.podsCidr":
+		if e.complexity.GardenerConfig.PodsCidr == nil {
+			break
+		}
+
+		return e.complexity.GardenerConfig.PodsCidr(childComplexity), true
+
 	case "GardenerConfig.provider":
 		if e.complexity.GardenerConfig.Provider == nil {
 			break
@@ -601,6 +610,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.GardenerConfig.Seed(childComplexity), true
 
+	case "GardenerConfig.servicesCidr":
+		if e.complexity.GardenerConfig.ServicesCidr == nil {
+			break
+		}
+
+		return e.complexity.GardenerConfig.ServicesCidr(childComplexity), true
+
 	case "GardenerConfig.shootNetworkingFilterDisabled":
 		if e.complexity.GardenerConfig.ShootNetworkingFilterDisabled == nil {
 			break
@@ -1076,6 +1092,8 @@ type GardenerConfig {
     shootNetworkingFilterDisabled: Boolean
     controlPlaneFailureTolerance: String
     euAccess: Boolean
+    podsCidr: String
+    servicesCidr: String
 }
 
 union ProviderSpecificConfig = GCPProviderConfig | AzureProviderConfig | AWSProviderConfig | OpenStackProviderConfig
@@ -1279,6 +1297,8 @@ input GardenerConfigInput {
     shootNetworkingFilterDisabled: Boolean          # Indicator for the Shoot Networking Filter extension being disabled. If 'nil' provided, 'true' will be used as a default value
     controlPlaneFailureTolerance: String            # Shoot control plane HA failure tolerance level to configure. Valid values: 'nil' (left empty, no HA), "node", "zone"
     euAccess: Boolean                               # EU Access indicated whether to annotate the Shoot with the 'support.gardener.cloud/eu-access-for-cluster-nodes' annotation
+    podsCidr: String                                # Classless Inter-Domain Routing range for the pods. If not provided the Gardener default is used
+    servicesCidr: String                            # Classless Inter-Domain Routing range for the services. If not provided the Gardener default is used
 }
 
 input OIDCConfigInput {
@@ -3397,6 +3417,68 @@ func (ec *executionContext) _GardenerConfig_euAccess(ctx context.Context, field
 	return ec.marshalOBoolean2ᚖbool(ctx, field.Selections, res)
 }
 
+func (ec *executionContext) _GardenerConfig_podsCidr(ctx context.Context, field graphql.CollectedField, obj *GardenerConfig) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:   "GardenerConfig",
+		Field:    field,
+		Args:     nil,
+		IsMethod: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.PodsCidr, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _GardenerConfig_servicesCidr(ctx context.Context, field graphql.CollectedField, obj *GardenerConfig) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:   "GardenerConfig",
+		Field:    field,
+		Args:     nil,
+		IsMethod: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.ServicesCidr, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+}
+
 func (ec *executionContext) _HibernationStatus_hibernated(ctx context.Context, field graphql.CollectedField, obj *HibernationStatus) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
@@ -6445,6 +6527,18 @@ func (ec *executionContext) unmarshalInputGardenerConfigInput(ctx context.Contex
 			if err != nil {
 				return it, err
 			}
+		case "podsCidr":
+			var err error
+			it.PodsCidr, err = ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "servicesCidr":
+			var err error
+			it.ServicesCidr, err = ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
 		}
 	}
 
@@ -7256,6 +7350,10 @@ func (ec *executionContext) _GardenerConfig(ctx context.Context, sel ast.Selecti
 			out.Values[i] = ec._GardenerConfig_controlPlaneFailureTolerance(ctx, field, obj)
 		case "euAccess":
 			out.Values[i] = ec._GardenerConfig_euAccess(ctx, field, obj)
+		case "podsCidr":
+			out.Values[i] = ec._GardenerConfig_podsCidr(ctx, field, obj)
+		case "servicesCidr":
+			out.Values[i] = ec._GardenerConfig_servicesCidr(ctx, field, obj)
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -0,0 +1,14 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS quotas (
+    global_account_id   varchar(255) NOT NULL,
+    plan_name           varchar(255) NOT NULL,
+    max_instances       integer,
+    max_auto_scaler_max integer,
+    max_volume_size_gb  integer,
+    created_at          timestamp with time zone NOT NULL,
+    updated_at          timestamp with time zone NOT NULL,
+    PRIMARY KEY (global_account_id, plan_name)
+);
+
+COMMIT;
@@ -0,0 +1,18 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS trial_events (
+    id             varchar(255) PRIMARY KEY,
+    instance_id    varchar(255) NOT NULL,
+    sub_account_id varchar(255) NOT NULL,
+    type           varchar(32) NOT NULL,
+    period_seconds bigint NOT NULL DEFAULT 0,
+    message        text,
+    created_at     timestamp with time zone NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS trial_events_instance_id_idx ON trial_events (instance_id);
+
+-- only one extension is allowed per subaccount
+CREATE UNIQUE INDEX IF NOT EXISTS trial_events_extension_sub_account_id_idx ON trial_events (sub_account_id) WHERE type = 'extension';
+
+COMMIT;
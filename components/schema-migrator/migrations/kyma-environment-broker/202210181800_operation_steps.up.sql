@@ -0,0 +1,17 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS operation_steps (
+    id               varchar(255) PRIMARY KEY,
+    operation_id     varchar(255) NOT NULL,
+    step_name        varchar(255) NOT NULL,
+    stage_name       varchar(255),
+    started_at       timestamp with time zone NOT NULL,
+    finished_at      timestamp with time zone NOT NULL,
+    result           varchar(32) NOT NULL,
+    retry_in_seconds bigint NOT NULL DEFAULT 0,
+    error_message    text
+);
+
+CREATE INDEX IF NOT EXISTS operation_steps_operation_id_idx ON operation_steps (operation_id);
+
+COMMIT;
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS operation_steps_finished_at_idx;
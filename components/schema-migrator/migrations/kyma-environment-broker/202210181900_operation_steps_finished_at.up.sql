@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS operation_steps_finished_at_idx ON operation_steps (finished_at);
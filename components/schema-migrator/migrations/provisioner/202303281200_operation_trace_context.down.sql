@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE operation DROP COLUMN trace_id;
+ALTER TABLE operation DROP COLUMN span_id;
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE operation ADD COLUMN trace_id varchar(32) NOT NULL DEFAULT '';
+ALTER TABLE operation ADD COLUMN span_id varchar(16) NOT NULL DEFAULT '';
+
+COMMIT;
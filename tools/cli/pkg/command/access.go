@@ -0,0 +1,299 @@
+package command
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kubeconfig-service/pkg/access"
+	"github.com/kyma-project/control-plane/components/kubeconfig-service/pkg/client"
+	"github.com/kyma-project/control-plane/tools/cli/pkg/logger"
+	"github.com/kyma-project/control-plane/tools/cli/pkg/printer"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var accessRequestColumns = []printer.Column{
+	{
+		Header:    "REQUEST ID",
+		FieldSpec: "{.id}",
+	},
+	{
+		Header:    "RUNTIME ID",
+		FieldSpec: "{.runtimeID}",
+	},
+	{
+		Header:    "REQUESTER",
+		FieldSpec: "{.requester}",
+	},
+	{
+		Header:    "TICKET",
+		FieldSpec: "{.ticketID}",
+	},
+	{
+		Header:    "STATUS",
+		FieldSpec: "{.status}",
+	},
+	{
+		Header:    "APPROVER",
+		FieldSpec: "{.approver}",
+	},
+	{
+		Header:    "EXPIRES AT",
+		FieldSpec: "{.expiresAt}",
+	},
+}
+
+var auditColumns = []printer.Column{
+	{
+		Header:    "TIME",
+		FieldSpec: "{.time}",
+	},
+	{
+		Header:    "EVENT",
+		FieldSpec: "{.event}",
+	},
+	{
+		Header:    "ACTOR",
+		FieldSpec: "{.actor}",
+	},
+	{
+		Header:    "RUNTIME ID",
+		FieldSpec: "{.runtimeID}",
+	},
+	{
+		Header:    "ROLE",
+		FieldSpec: "{.role}",
+	},
+	{
+		Header:    "REQUEST ID",
+		FieldSpec: "{.requestID}",
+	},
+	{
+		Header:    "DETAILS",
+		FieldSpec: "{.details}",
+	},
+}
+
+// AccessCommand represents an execution of the kcp access commands
+type AccessCommand struct {
+	cobraCmd        *cobra.Command
+	log             logger.Logger
+	output          string
+	globalAccountID string
+	subAccountID    string
+	runtimeID       string
+	shoot           string
+	justification   string
+	ticketID        string
+	status          string
+	actor           string
+	event           string
+	since           time.Duration
+}
+
+// NewAccessCmd constructs the kcp access command and its subcommands
+func NewAccessCmd() *cobra.Command {
+	cobraCmd := &cobra.Command{
+		Use:   "access",
+		Short: "Manages break-glass access requests for runtimeAdmin kubeconfigs.",
+		Long: `Manages break-glass access requests for runtimeAdmin kubeconfigs.
+Downloading a kubeconfig file with the runtimeAdmin role requires an access request approved by a member of the approver group.
+Each approved request allows a single download. Every request, approval, download, and expiry is written to the audit log.`,
+	}
+	cobraCmd.AddCommand(
+		newAccessRequestCmd(),
+		newAccessApproveCmd(),
+		newAccessListCmd(),
+		newAccessAuditCmd(),
+	)
+	return cobraCmd
+}
+
+func newAccessRequestCmd() *cobra.Command {
+	cmd := AccessCommand{}
+	cobraCmd := &cobra.Command{
+		Use:   "request",
+		Short: "Files an access request for a given Kyma Runtime.",
+		Long: `Files an access request for a given Kyma Runtime.
+The Runtime can be specified by one of the following:
+  - Global account / subaccount pair with the --account and --subaccount options
+  - Global account / Runtime ID pair with the --account and --runtime-id options
+  - Shoot cluster name with the --shoot option.`,
+		Example: `  kcp access request -c c-178e034 --justification "Broken istio-ingressgateway" --ticket INC-1234  Requests access to the Runtime of the given Shoot cluster.`,
+		PreRunE: func(_ *cobra.Command, _ []string) error { return cmd.validateRequest() },
+		RunE:    func(_ *cobra.Command, _ []string) error { return cmd.request() },
+	}
+	cmd.cobraCmd = cobraCmd
+
+	SetOutputOpt(cobraCmd, &cmd.output)
+	cobraCmd.Flags().StringVarP(&cmd.globalAccountID, "account", "g", "", "Global account ID of the specific Kyma Runtime.")
+	cobraCmd.Flags().StringVarP(&cmd.subAccountID, "subaccount", "s", "", "Subccount ID of the specific Kyma Runtime.")
+	cobraCmd.Flags().StringVarP(&cmd.runtimeID, "runtime-id", "r", "", "Runtime ID of the specific Kyma Runtime.")
+	cobraCmd.Flags().StringVarP(&cmd.shoot, "shoot", "c", "", "Shoot cluster name of the specific Kyma Runtime.")
+	cobraCmd.Flags().StringVar(&cmd.justification, "justification", "", "Reason why runtimeAdmin access is needed.")
+	cobraCmd.Flags().StringVar(&cmd.ticketID, "ticket", "", "ID of the incident or support ticket.")
+	return cobraCmd
+}
+
+func newAccessApproveCmd() *cobra.Command {
+	cmd := AccessCommand{}
+	cobraCmd := &cobra.Command{
+		Use:     "approve <request id>",
+		Short:   "Approves an access request filed by another operator.",
+		Example: `  kcp access approve 0c4357f5-83e0-4b72-9472-49b5cd417c00  Approves the given access request.`,
+		Args:    cobra.ExactArgs(1),
+		PreRunE: func(_ *cobra.Command, _ []string) error { return cmd.validateKubeconfigAPI() },
+		RunE:    func(_ *cobra.Command, args []string) error { return cmd.approve(args[0]) },
+	}
+	cmd.cobraCmd = cobraCmd
+
+	SetOutputOpt(cobraCmd, &cmd.output)
+	return cobraCmd
+}
+
+func newAccessListCmd() *cobra.Command {
+	cmd := AccessCommand{}
+	cobraCmd := &cobra.Command{
+		Use:   "requests",
+		Short: "Displays access requests.",
+		Example: `  kcp access requests --status pending   Displays all access requests waiting for approval.
+  kcp access requests -r RUNTIMEID         Displays all access requests for the given Runtime.`,
+		PreRunE: func(_ *cobra.Command, _ []string) error { return cmd.validateKubeconfigAPI() },
+		RunE:    func(_ *cobra.Command, _ []string) error { return cmd.list() },
+	}
+	cmd.cobraCmd = cobraCmd
+
+	SetOutputOpt(cobraCmd, &cmd.output)
+	cobraCmd.Flags().StringVarP(&cmd.runtimeID, "runtime-id", "r", "", "Filter by Runtime ID.")
+	cobraCmd.Flags().StringVar(&cmd.status, "status", "", "Filter by status. The possible values are: pending, approved, issued, expired.")
+	return cobraCmd
+}
+
+func newAccessAuditCmd() *cobra.Command {
+	cmd := AccessCommand{}
+	cobraCmd := &cobra.Command{
+		Use:   "audit",
+		Short: "Displays the kubeconfig audit log.",
+		Example: `  kcp access audit -r RUNTIMEID --since 24h  Displays all audit log entries for the given Runtime from the last 24 hours.
+  kcp access audit --actor john --event issued   Displays all kubeconfig files issued to the given user.`,
+		PreRunE: func(_ *cobra.Command, _ []string) error { return cmd.validateKubeconfigAPI() },
+		RunE:    func(_ *cobra.Command, _ []string) error { return cmd.audit() },
+	}
+	cmd.cobraCmd = cobraCmd
+
+	SetOutputOpt(cobraCmd, &cmd.output)
+	cobraCmd.Flags().StringVarP(&cmd.runtimeID, "runtime-id", "r", "", "Filter by Runtime ID.")
+	cobraCmd.Flags().StringVar(&cmd.actor, "actor", "", "Filter by the user who performed the action.")
+	cobraCmd.Flags().StringVar(&cmd.event, "event", "", "Filter by event. The possible values are: requested, approved, issued, expired.")
+	cobraCmd.Flags().DurationVar(&cmd.since, "since", 0, "Displays only entries newer than the given duration, e.g. 24h.")
+	return cobraCmd
+}
+
+func (cmd *AccessCommand) validateKubeconfigAPI() error {
+	if GlobalOpts.KubeconfigAPIURL() == "" {
+		return fmt.Errorf("missing required %s option", GlobalOpts.kubeconfigAPIURL)
+	}
+	return ValidateOutputOpt(cmd.output)
+}
+
+func (cmd *AccessCommand) validateRequest() error {
+	if err := cmd.validateKubeconfigAPI(); err != nil {
+		return err
+	}
+	if cmd.justification == "" || cmd.ticketID == "" {
+		return errors.New("both --justification and --ticket options have to be specified")
+	}
+	if cmd.globalAccountID != "" && (cmd.subAccountID != "" || cmd.runtimeID != "") || cmd.shoot != "" {
+		return nil
+	}
+	return errors.New("at least one of the following options have to be specified: account/subaccount, account/runtime-id, shoot")
+}
+
+func (cmd *AccessCommand) client() client.Client {
+	cmd.log = logger.New()
+	return client.NewClient(cmd.cobraCmd.Context(), GlobalOpts.KubeconfigAPIURL(), CLICredentialManager(cmd.log))
+}
+
+func (cmd *AccessCommand) request() error {
+	kcClient := cmd.client()
+	if cmd.globalAccountID == "" || cmd.runtimeID == "" {
+		rt, err := resolveRuntime(cmd.cobraCmd.Context(), CLICredentialManager(cmd.log), cmd.globalAccountID, cmd.subAccountID, cmd.shoot)
+		if err != nil {
+			return errors.Wrap(err, "while resolving runtime")
+		}
+		cmd.globalAccountID = rt.GlobalAccountID
+		cmd.runtimeID = rt.RuntimeID
+	}
+
+	r, err := kcClient.CreateAccessRequest(access.CreateAccessRequestDTO{
+		TenantID:      cmd.globalAccountID,
+		RuntimeID:     cmd.runtimeID,
+		Justification: cmd.justification,
+		TicketID:      cmd.ticketID,
+	})
+	if err != nil {
+		return errors.Wrap(err, "while filing access request")
+	}
+	return cmd.print([]access.AccessRequest{r}, accessRequestColumns)
+}
+
+func (cmd *AccessCommand) approve(requestID string) error {
+	r, err := cmd.client().ApproveAccessRequest(requestID)
+	if err != nil {
+		return errors.Wrap(err, "while approving access request")
+	}
+	return cmd.print([]access.AccessRequest{r}, accessRequestColumns)
+}
+
+func (cmd *AccessCommand) list() error {
+	requests, err := cmd.client().ListAccessRequests(access.RequestFilter{
+		RuntimeID: cmd.runtimeID,
+		Status:    access.RequestStatus(cmd.status),
+	})
+	if err != nil {
+		return errors.Wrap(err, "while listing access requests")
+	}
+	return cmd.print(requests, accessRequestColumns)
+}
+
+func (cmd *AccessCommand) audit() error {
+	filter := access.AuditFilter{
+		RuntimeID: cmd.runtimeID,
+		Actor:     cmd.actor,
+		Event:     access.Event(cmd.event),
+	}
+	if cmd.since > 0 {
+		filter.Since = time.Now().Add(-cmd.since)
+	}
+
+	entries, err := cmd.client().ListAuditLog(filter)
+	if err != nil {
+		return errors.Wrap(err, "while listing audit log")
+	}
+	return cmd.print(entries, auditColumns)
+}
+
+func (cmd *AccessCommand) print(obj interface{}, columns []printer.Column) error {
+	switch cmd.output {
+	case tableOutput:
+		tp, err := printer.NewTablePrinter(columns, false)
+		if err != nil {
+			return err
+		}
+		return tp.PrintObj(obj)
+	case jsonOutput:
+		jp := printer.NewJSONPrinter("  ")
+		return jp.PrintObj(obj)
+	default:
+		_, templateFile := printer.ParseOutputToTemplateTypeAndElement(cmd.output)
+		column, err := printer.ParseColumnToHeaderAndFieldSpec(templateFile)
+		if err != nil {
+			return err
+		}
+		ccp, err := printer.NewTablePrinter(column, false)
+		if err != nil {
+			return err
+		}
+		return ccp.PrintObj(obj)
+	}
+}
@@ -0,0 +1,240 @@
+package command
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
+	"github.com/kyma-project/control-plane/tools/cli/pkg/logger"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const dryRunPollInterval = 5 * time.Second
+
+// ApplyCommand represents an execution of the kcp apply command
+type ApplyCommand struct {
+	cobraCmd     *cobra.Command
+	log          logger.Logger
+	file         string
+	validateOnly bool
+	waitTimeout  time.Duration
+	manifest     *Manifest
+	client       orchestration.Client
+}
+
+// NewApplyCmd constructs a new instance of ApplyCommand and configures it in terms of a cobra.Command
+func NewApplyCmd() *cobra.Command {
+	cmd := ApplyCommand{}
+	cobraCmd := &cobra.Command{
+		Use:   "apply -f {MANIFEST FILE}",
+		Short: "Applies a declarative orchestration manifest.",
+		Long: `Creates an upgrade orchestration from a declarative manifest, instead of the options of the kcp upgrade commands.
+The manifest is validated locally first. Then, the targets of the manifest are resolved by Kyma Control Plane (KCP) within a dry-run orchestration,
+and the resolved Runtimes are compared with the Runtimes of the last orchestration applied from a manifest with the same name.
+After confirmation, the orchestration is created and the manifest is stored with it. Use the kcp orchestrations {ID} manifest command to display it later.
+
+The manifest has the following format:
+  apiVersion: kcp.kyma-project.io/v1
+  kind: UpgradeKyma                    # or UpgradeCluster
+  metadata:
+    name: kyma-rollout                 # manifests with the same name are compared with each other
+  spec:
+    targets:
+      include:                         # the same selectors as in the --target option of the kcp upgrade commands
+      - target: all
+      exclude:
+      - globalAccount: "CA.*"
+    strategy:
+      type: parallel                   # default: parallel
+      schedule: now                    # "immediate", "now" (default) or an RFC3339 date
+      maintenanceWindow: true
+      parallel:
+        workers: 4
+    dryRun: false
+    kyma:                              # UpgradeKyma only
+      version: 2.10.0
+    kubernetes:                        # UpgradeCluster only
+      kubernetesVersion: 1.25.4`,
+		Example: `  kcp apply -f upgrade.yaml             Validate, preview, and apply the orchestration manifest.
+  kcp apply -f upgrade.yaml --validate  Only validate the orchestration manifest locally.
+  cat upgrade.yaml | kcp apply -f -     Apply the orchestration manifest read from the standard input.`,
+		PreRunE: func(_ *cobra.Command, _ []string) error { return cmd.Validate() },
+		RunE:    func(_ *cobra.Command, _ []string) error { return cmd.Run() },
+	}
+	cmd.cobraCmd = cobraCmd
+
+	cobraCmd.Flags().StringVarP(&cmd.file, "filename", "f", "", "Path to the orchestration manifest, or - to read it from the standard input.")
+	cobraCmd.Flags().BoolVar(&cmd.validateOnly, "validate", false, "Only validate the manifest locally, without contacting Kyma Control Plane.")
+	cobraCmd.Flags().DurationVar(&cmd.waitTimeout, "wait-timeout", 5*time.Minute, "Maximum time to wait for the dry-run orchestration which resolves the targets.")
+	cobraCmd.MarkFlagRequired("filename")
+	return cobraCmd
+}
+
+// Validate checks the input parameters and the manifest of the apply command
+func (cmd *ApplyCommand) Validate() error {
+	m, err := LoadManifest(cmd.file)
+	if err != nil {
+		return err
+	}
+	cmd.manifest = m
+	return nil
+}
+
+// Run executes the apply command
+func (cmd *ApplyCommand) Run() error {
+	if cmd.validateOnly {
+		fmt.Printf("Manifest %s is valid.\n", cmd.manifest.Metadata.Name)
+		return nil
+	}
+
+	cmd.log = logger.New()
+	cred := CLICredentialManager(cmd.log)
+	cmd.client = orchestration.NewClient(cmd.cobraCmd.Context(), GlobalOpts.KEBAPIURL(), cred)
+
+	resolved, err := cmd.resolveTargets()
+	if err != nil {
+		return err
+	}
+	previous, previousID, err := cmd.previousTargets()
+	if err != nil {
+		return err
+	}
+
+	if previousID == "" {
+		fmt.Printf("No orchestration was applied from manifest %s before.\n", cmd.manifest.Metadata.Name)
+	} else {
+		fmt.Printf("Comparing with orchestration %s applied from manifest %s.\n", previousID, cmd.manifest.Metadata.Name)
+	}
+	added, removed, unchanged := diffTargets(previous, resolved)
+	for _, op := range added {
+		fmt.Printf("+ %s (runtime ID: %s, global account: %s, plan: %s)\n", op.ShootName, op.RuntimeID, op.GlobalAccountID, op.ServicePlanName)
+	}
+	for _, op := range removed {
+		fmt.Printf("- %s (runtime ID: %s, global account: %s, plan: %s)\n", op.ShootName, op.RuntimeID, op.GlobalAccountID, op.ServicePlanName)
+	}
+	fmt.Printf("%d Runtime(s) targeted: %d added, %d removed, %d unchanged.\n", len(resolved), len(added), len(removed), unchanged)
+
+	if !PromptUser(fmt.Sprintf("The %s orchestration will be created for %d Runtime(s). Are you sure you want to continue?", cmd.manifest.OrchestrationType(), len(resolved))) {
+		return errors.New("Apply aborted")
+	}
+
+	var ur orchestration.UpgradeResponse
+	switch cmd.manifest.OrchestrationType() {
+	case orchestration.UpgradeClusterOrchestration:
+		ur, err = cmd.client.UpgradeCluster(cmd.manifest.Parameters())
+	default:
+		ur, err = cmd.client.UpgradeKyma(cmd.manifest.Parameters())
+	}
+	if err != nil {
+		return errors.Wrap(err, "while creating orchestration")
+	}
+	fmt.Println("OrchestrationID:", ur.OrchestrationID)
+
+	if !cmd.manifest.Spec.DryRun && GlobalOpts.SlackAPIURL() != "" {
+		err = SendSlackNotification(fmt.Sprintf("apply %s", cmd.manifest.Metadata.Name), cmd.cobraCmd, "OrchestrationID:"+ur.OrchestrationID, cred)
+		if err != nil {
+			return errors.Wrap(err, "while sending notification to slack")
+		}
+	}
+	return nil
+}
+
+// resolveTargets creates a dry-run copy of the manifest orchestration, scheduled immediately, and returns its operations
+func (cmd *ApplyCommand) resolveTargets() ([]orchestration.OperationResponse, error) {
+	params := cmd.manifest.Parameters()
+	params.DryRun = true
+	params.Strategy.Schedule = string(orchestration.Immediate)
+	params.Strategy.MaintenanceWindow = false
+
+	var ur orchestration.UpgradeResponse
+	var err error
+	switch cmd.manifest.OrchestrationType() {
+	case orchestration.UpgradeClusterOrchestration:
+		ur, err = cmd.client.UpgradeCluster(params)
+	default:
+		ur, err = cmd.client.UpgradeKyma(params)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "while creating dry-run orchestration")
+	}
+	fmt.Printf("Resolving targets with dry-run orchestration %s\n", ur.OrchestrationID)
+
+	deadline := time.Now().Add(cmd.waitTimeout)
+	for {
+		sr, err := cmd.client.GetOrchestration(ur.OrchestrationID)
+		if err != nil {
+			return nil, errors.Wrap(err, "while getting dry-run orchestration")
+		}
+		switch sr.State {
+		case orchestration.Succeeded, orchestration.Failed:
+			orl, err := cmd.client.ListOperations(ur.OrchestrationID, orchestration.ListParameters{})
+			if err != nil {
+				return nil, errors.Wrap(err, "while listing operations of dry-run orchestration")
+			}
+			return orl.Data, nil
+		case orchestration.Canceling, orchestration.Canceled:
+			return nil, fmt.Errorf("dry-run orchestration %s was canceled", ur.OrchestrationID)
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("timed out waiting for dry-run orchestration %s, current state: %s", ur.OrchestrationID, sr.State)
+		}
+		time.Sleep(dryRunPollInterval)
+	}
+}
+
+// previousTargets returns the operations of the last non dry-run orchestration applied from a manifest with the same name
+func (cmd *ApplyCommand) previousTargets() ([]orchestration.OperationResponse, string, error) {
+	srl, err := cmd.client.ListOrchestrations(orchestration.ListParameters{})
+	if err != nil {
+		return nil, "", errors.Wrap(err, "while listing orchestrations")
+	}
+	var last *orchestration.StatusResponse
+	for i, sr := range srl.Data {
+		if sr.Parameters.DryRun || sr.Type != cmd.manifest.OrchestrationType() || manifestName(sr.Parameters) != cmd.manifest.Metadata.Name {
+			continue
+		}
+		if last == nil || sr.CreatedAt.After(last.CreatedAt) {
+			last = &srl.Data[i]
+		}
+	}
+	if last == nil {
+		return nil, "", nil
+	}
+
+	orl, err := cmd.client.ListOperations(last.OrchestrationID, orchestration.ListParameters{})
+	if err != nil {
+		return nil, "", errors.Wrapf(err, "while listing operations of orchestration %s", last.OrchestrationID)
+	}
+	return orl.Data, last.OrchestrationID, nil
+}
+
+// diffTargets compares the Runtimes of two orchestrations, the results are sorted by the shoot name
+func diffTargets(previous, current []orchestration.OperationResponse) (added, removed []orchestration.OperationResponse, unchanged int) {
+	previousRuntimes := map[string]bool{}
+	for _, op := range previous {
+		previousRuntimes[op.RuntimeID] = true
+	}
+	currentRuntimes := map[string]bool{}
+	for _, op := range current {
+		currentRuntimes[op.RuntimeID] = true
+		if previousRuntimes[op.RuntimeID] {
+			unchanged++
+		} else {
+			added = append(added, op)
+		}
+	}
+	for _, op := range previous {
+		if !currentRuntimes[op.RuntimeID] {
+			removed = append(removed, op)
+		}
+	}
+
+	byShoot := func(ops []orchestration.OperationResponse) {
+		sort.Slice(ops, func(i, j int) bool { return ops[i].ShootName < ops[j].ShootName })
+	}
+	byShoot(added)
+	byShoot(removed)
+	return added, removed, unchanged
+}
@@ -0,0 +1,196 @@
+package command
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/events"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/runtime"
+	"github.com/kyma-project/control-plane/tools/cli/pkg/dashboard"
+	"github.com/kyma-project/control-plane/tools/cli/pkg/logger"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"golang.org/x/oauth2"
+)
+
+// DashboardCommand represents an execution of the kcp dashboard command
+type DashboardCommand struct {
+	cobraCmd *cobra.Command
+	log      logger.Logger
+	states   []string
+	filter   dashboard.Filter
+	refresh  time.Duration
+}
+
+// NewDashboardCmd constructs a new instance of DashboardCommand and configures it in terms of a cobra.Command
+func NewDashboardCmd() *cobra.Command {
+	cmd := DashboardCommand{}
+	cobraCmd := &cobra.Command{
+		Use:     "dashboard",
+		Aliases: []string{"dash"},
+		Short:   "Displays an interactive dashboard of Kyma Runtimes and orchestrations.",
+		Long: `Displays an interactive terminal dashboard of Kyma Runtimes and orchestrations, which is refreshed automatically.
+The Runtimes can be filtered by state, plan, and region, either with the command options or by pressing "/" in the dashboard and typing a filter such as "state=failed,error plan=azure".
+Press Enter to display the operations and events of the selected Runtime, or the operations and progress of the selected orchestration. Press Tab to switch between Runtimes and orchestrations.
+
+The following actions are available for the selected Runtime or orchestration:
+  K : Download the kubeconfig file of the Runtime, in the same way as the kcp kubeconfig command
+  E : Enable the reconciliation of the Runtime, in the same way as the kcp reconciliations enable command
+  D : Disable the reconciliation of the Runtime, in the same way as the kcp reconciliations disable command
+  C : Cancel the orchestration, in the same way as the kcp orchestrations cancel command
+  R : Retry the failed operations of the orchestration, in the same way as the kcp orchestrations retry command`,
+		Example: `  kcp dashboard                                   Display all Runtimes.
+  kcp dashboard --state failed,error --plan azure  Display the failed Azure Runtimes, refreshed every 10 seconds.
+  kcp dashboard --region westeurope --refresh 1m   Display the Runtimes of the given region, refreshed every minute.`,
+		PreRunE: func(_ *cobra.Command, _ []string) error { return cmd.Validate() },
+		RunE:    func(_ *cobra.Command, _ []string) error { return cmd.Run() },
+	}
+	cmd.cobraCmd = cobraCmd
+
+	cobraCmd.Flags().StringSliceVarP(&cmd.states, "state", "S", nil, "Filter by Runtime state. The possible values (case insensitive) are: succeeded, failed, error, provisioning, deprovisioning, upgrading, suspended, all. You can provide multiple values, either separated by a comma (e.g. succeeded,failed), or by specifying the option multiple times.")
+	cobraCmd.Flags().StringSliceVarP(&cmd.filter.Plans, "plan", "p", nil, "Filter by service plan name. You can provide multiple values, either separated by a comma (e.g. azure,trial), or by specifying the option multiple times.")
+	cobraCmd.Flags().StringSliceVarP(&cmd.filter.Regions, "region", "R", nil, "Filter by provider region. You can provide multiple values, either separated by a comma (e.g. westeurope,northeurope), or by specifying the option multiple times.")
+	cobraCmd.Flags().DurationVar(&cmd.refresh, "refresh", 10*time.Second, "Interval of the automatic refresh.")
+	return cobraCmd
+}
+
+// Validate checks the input parameters of the dashboard command
+func (cmd *DashboardCommand) Validate() error {
+	if cmd.refresh < time.Second {
+		return fmt.Errorf("invalid value for refresh: %s, the minimum is 1s", cmd.refresh)
+	}
+	for _, s := range cmd.states {
+		val := runtime.State(strings.ToLower(s))
+		switch val {
+		case runtime.StateSucceeded, runtime.StateFailed, runtime.StateError, runtime.StateProvisioning, runtime.StateDeprovisioning, runtime.StateUpgrading, runtime.StateSuspended, runtime.AllState:
+			cmd.filter.States = append(cmd.filter.States, val)
+		default:
+			return fmt.Errorf("invalid value for state: %s", s)
+		}
+	}
+	return nil
+}
+
+// Run executes the dashboard command
+func (cmd *DashboardCommand) Run() error {
+	cmd.log = logger.New()
+	cred := CLICredentialManager(cmd.log)
+	httpClient := oauth2.NewClient(cmd.cobraCmd.Context(), cred)
+	source := newDashboardSource(httpClient, orchestration.NewClient(cmd.cobraCmd.Context(), GlobalOpts.KEBAPIURL(), cred))
+
+	return dashboard.Run(cmd.cobraCmd.Context(), dashboard.NewModel(source, cmd.filter), &dashboardActions{cmd: cmd}, cmd.refresh)
+}
+
+// dashboardSource fetches the dashboard data from the KEB APIs
+type dashboardSource struct {
+	runtimes       runtime.Client
+	events         events.Client
+	orchestrations orchestration.Client
+}
+
+func newDashboardSource(httpClient *http.Client, orchestrationClient orchestration.Client) *dashboardSource {
+	return &dashboardSource{
+		runtimes:       runtime.NewClient(GlobalOpts.KEBAPIURL(), httpClient),
+		events:         events.NewClient(GlobalOpts.KEBAPIURL(), httpClient),
+		orchestrations: orchestrationClient,
+	}
+}
+
+func (s *dashboardSource) ListRuntimes(filter dashboard.Filter) ([]runtime.RuntimeDTO, error) {
+	rp, err := s.runtimes.ListRuntimes(runtime.ListParameters{
+		States:          filter.States,
+		Plans:           filter.Plans,
+		Regions:         filter.Regions,
+		OperationDetail: runtime.LastOperation,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "while listing runtimes")
+	}
+	return rp.Data, nil
+}
+
+func (s *dashboardSource) GetRuntime(runtimeID string) (runtime.RuntimeDTO, error) {
+	rp, err := s.runtimes.ListRuntimes(runtime.ListParameters{
+		RuntimeIDs:      []string{runtimeID},
+		States:          []runtime.State{runtime.AllState},
+		OperationDetail: runtime.AllOperation,
+	})
+	if err != nil {
+		return runtime.RuntimeDTO{}, errors.Wrap(err, "while getting runtime")
+	}
+	if len(rp.Data) != 1 {
+		return runtime.RuntimeDTO{}, fmt.Errorf("unexpected number of runtimes with ID %s: %d", runtimeID, len(rp.Data))
+	}
+	return rp.Data[0], nil
+}
+
+func (s *dashboardSource) ListEvents(instanceID string) ([]events.EventDTO, error) {
+	evs, err := s.events.ListEvents([]string{instanceID})
+	return evs, errors.Wrap(err, "while listing events")
+}
+
+func (s *dashboardSource) ListOrchestrations() ([]orchestration.StatusResponse, error) {
+	srl, err := s.orchestrations.ListOrchestrations(orchestration.ListParameters{})
+	return srl.Data, errors.Wrap(err, "while listing orchestrations")
+}
+
+func (s *dashboardSource) GetOrchestration(orchestrationID string) (orchestration.StatusResponse, error) {
+	sr, err := s.orchestrations.GetOrchestration(orchestrationID)
+	return sr, errors.Wrap(err, "while getting orchestration")
+}
+
+func (s *dashboardSource) ListOperations(orchestrationID string) ([]orchestration.OperationResponse, error) {
+	orl, err := s.orchestrations.ListOperations(orchestrationID, orchestration.ListParameters{})
+	return orl.Data, errors.Wrap(err, "while listing operations")
+}
+
+// dashboardActions executes the dashboard actions with the implementations of the corresponding kcp commands
+type dashboardActions struct {
+	cmd *DashboardCommand
+}
+
+func (a *dashboardActions) DownloadKubeconfig(rt runtime.RuntimeDTO) error {
+	if GlobalOpts.KubeconfigAPIURL() == "" {
+		return fmt.Errorf("missing required %s option", GlobalOpts.kubeconfigAPIURL)
+	}
+	// resolving the runtime by the shoot name keeps the EU access confirmation of the kubeconfig command
+	kc := &KubeconfigCommand{cobraCmd: a.cmd.cobraCmd, shoot: rt.ShootName}
+	return kc.Run()
+}
+
+func (a *dashboardActions) EnableReconciliation(rt runtime.RuntimeDTO) error {
+	enable := &reconciliationEnableCmd{opts: reconciliationEnableOpts{runtimeID: rt.RuntimeID}}
+	if err := enable.Validate(); err != nil {
+		return err
+	}
+	enable.ctx = a.cmd.cobraCmd.Context()
+	return enable.Run()
+}
+
+func (a *dashboardActions) DisableReconciliation(rt runtime.RuntimeDTO) error {
+	disable := &reconciliationDisableCmd{opts: reconciliationDisableOpts{runtimeID: rt.RuntimeID}}
+	if err := disable.Validate(); err != nil {
+		return err
+	}
+	disable.ctx = a.cmd.cobraCmd.Context()
+	return disable.Run()
+}
+
+func (a *dashboardActions) CancelOrchestration(orchestrationID string) error {
+	return a.orchestrationCommand().cancelOrchestration(orchestrationID)
+}
+
+func (a *dashboardActions) RetryOrchestration(orchestrationID string) error {
+	return a.orchestrationCommand().retryOrchestration(orchestrationID)
+}
+
+func (a *dashboardActions) orchestrationCommand() *OrchestrationCommand {
+	return &OrchestrationCommand{
+		cobraCmd: a.cmd.cobraCmd,
+		log:      a.cmd.log,
+		client:   orchestration.NewClient(a.cmd.cobraCmd.Context(), GlobalOpts.KEBAPIURL(), CLICredentialManager(a.cmd.log)),
+	}
+}
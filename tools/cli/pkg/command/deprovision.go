@@ -3,26 +3,35 @@ package command
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/runtime"
 	"github.com/kyma-project/control-plane/tools/cli/pkg/credential"
 	"github.com/kyma-project/control-plane/tools/cli/pkg/logger"
+	"github.com/kyma-project/control-plane/tools/cli/pkg/printer"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"golang.org/x/oauth2"
 
 	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/deprovision"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
 )
 
 type DeprovisionCommand struct {
-	cobraCmd        *cobra.Command
-	log             logger.Logger
-	shootName       string
-	globalAccountID string
-	subAccountID    string
-	runtimeID       string
-	outputPath      string
-	instanceID      string
+	cobraCmd            *cobra.Command
+	log                 logger.Logger
+	shootName           string
+	globalAccountID     string
+	subAccountID        string
+	runtimeID           string
+	outputPath          string
+	instanceID          string
+	targetInputs        []string
+	targetExcludeInputs []string
+	targets             orchestration.TargetSpec
+	parallelism         int
+	dryRun              bool
+	force               bool
 }
 
 func NewDeprovisionCmd() *cobra.Command {
@@ -30,12 +39,16 @@ func NewDeprovisionCmd() *cobra.Command {
 	cobraCmd := &cobra.Command{
 		Use:     "deprovision",
 		Aliases: []string{"d"},
-		Short:   "Deprovisions a Kyma Runtime",
-		Long: `Deprovisions a Kyma Runtime.
+		Short:   "Deprovisions one or more Kyma Runtimes",
+		Long: `Deprovisions one or more Kyma Runtimes.
 The Runtime can be specified by one of the following:
   - Global account / Runtime ID pair with the --account and --runtime-id options
-  - Shoot cluster name with the --shoot option.`,
-		Example: `  kcp deprovision -c c-178e034                            Deprovisions the SKR using a Shoot cluster name.`,
+  - Shoot cluster name with the --shoot option.
+Multiple Runtimes can be specified with the --target and --target-exclude options. The targets are resolved the same way as for the orchestrations,
+the resolved Runtimes are listed and deprovisioned after your confirmation, with up to --parallelism requests at the same time.`,
+		Example: `  kcp deprovision -c c-178e034                            Deprovisions the SKR using a Shoot cluster name.
+  kcp deprovision --target account=CA.* --dry-run         Lists the Runtimes of the matching global accounts which would be deprovisioned.
+  kcp deprovision --target plan=trial,region=eu-west-1 -p 10   Deprovisions the trial Runtimes in the region, 10 at the same time.`,
 
 		PreRunE: func(_ *cobra.Command, _ []string) error { return cmd.Validate() },
 		RunE:    func(_ *cobra.Command, _ []string) error { return cmd.Run() },
@@ -46,6 +59,10 @@ The Runtime can be specified by one of the following:
 	cobraCmd.Flags().StringVarP(&cmd.subAccountID, "subaccount", "s", "", "Subccount ID of the specific Kyma Runtime.")
 	cobraCmd.Flags().StringVarP(&cmd.runtimeID, "runtime-id", "r", "", "Runtime ID of the specific Kyma Runtime.")
 	cobraCmd.Flags().StringVarP(&cmd.shootName, "shootName", "c", "", "Shoot cluster name of the specific Kyma Runtime.")
+	SetRuntimeTargetOpts(cobraCmd, &cmd.targetInputs, &cmd.targetExcludeInputs)
+	cobraCmd.Flags().IntVarP(&cmd.parallelism, "parallelism", "p", 4, "Number of deprovisioning requests sent at the same time when the Runtimes are selected with --target.")
+	cobraCmd.Flags().BoolVar(&cmd.dryRun, "dry-run", false, "List the Runtimes selected with --target without deprovisioning them.")
+	cobraCmd.Flags().BoolVarP(&cmd.force, "force", "f", false, "Deprovision the Runtimes selected with --target without the confirmation.")
 
 	return cobraCmd
 }
@@ -65,33 +82,135 @@ func (cmd *DeprovisionCommand) Run() error {
 
 	client := deprovision.NewDeprovisionClient(param)
 
+	if len(cmd.targetInputs) > 0 {
+		return cmd.deprovisionTargets(cred, client)
+	}
 	if cmd.runtimeID != "" {
 		err := client.DeprovisionRuntime(cmd.runtimeID)
 		if err != nil {
-			errors.Wrap(err, "while calling deprovision endpoint")
+			return errors.Wrap(err, "while calling deprovision endpoint")
 		}
 	} else {
 		err := cmd.resolveInstanceID(cmd.cobraCmd.Context(), cred)
 		if err != nil {
-			errors.Wrap(err, "while resolving runtime from shootName")
+			return errors.Wrap(err, "while resolving runtime from shootName")
 		}
 		err = client.DeprovisionRuntime(cmd.instanceID)
 		if err != nil {
-			errors.Wrap(err, "while calling deprovision endpoint with resolved instanceID")
+			return errors.Wrap(err, "while calling deprovision endpoint with resolved instanceID")
 		}
 	}
 	return nil
 }
 
 func (cmd *DeprovisionCommand) Validate() error {
+	if len(cmd.targetInputs) > 0 {
+		if cmd.globalAccountID != "" || cmd.subAccountID != "" || cmd.runtimeID != "" || cmd.shootName != "" {
+			return errors.New("the --target option cannot be used together with the account, subaccount, runtime-id and shoot options")
+		}
+		if cmd.parallelism < 1 {
+			return errors.New("--parallelism must be at least 1")
+		}
+		// the Runtimes are confirmed after the targets are resolved
+		return ValidateTransformRuntimeTargetOpts(cmd.targetInputs, cmd.targetExcludeInputs, &cmd.targets)
+	}
 	if cmd.globalAccountID != "" && (cmd.subAccountID != "" || cmd.runtimeID != "") || cmd.shootName != "" {
 		if !promptUser(fmt.Sprintf("Runtime: '%s' will be deprovisioned. Are you sure you want to continue? ", cmd.shootName)) {
 			return errors.New("deprovision command aborted")
 		}
 		return nil
 	} else {
-		return errors.New("at least one of the following options have to be specified: account/subaccount, account/runtime-id, shoot")
+		return errors.New("at least one of the following options have to be specified: account/subaccount, account/runtime-id, shoot, target")
+	}
+}
+
+// deprovisionTargets deprovisions the Runtimes resolved from the targets in parallel, the failed requests do not stop the remaining ones
+func (cmd *DeprovisionCommand) deprovisionTargets(cred credential.Manager, client deprovision.Client) error {
+	runtimes, err := resolveRuntimeTargets(cmd.cobraCmd.Context(), cred, cmd.targets, cmd.log, false)
+	if err != nil {
+		return err
+	}
+	if len(runtimes) == 0 {
+		fmt.Println("No Runtimes matched the targets")
+		return nil
+	}
+	tp, err := printer.NewTablePrinter(deprovisionTargetColumns, false)
+	if err != nil {
+		return err
+	}
+	if err := tp.PrintObj(runtimes); err != nil {
+		return err
 	}
+	if cmd.dryRun {
+		fmt.Printf("Dry run: %d Runtime(s) would be deprovisioned\n", len(runtimes))
+		return nil
+	}
+	if !cmd.force && !promptUser(fmt.Sprintf("%d Runtime(s) will be deprovisioned. Are you sure you want to continue? ", len(runtimes))) {
+		return errors.New("deprovision command aborted")
+	}
+
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		failed int
+	)
+	workers := make(chan struct{}, cmd.parallelism)
+	for _, rt := range runtimes {
+		wg.Add(1)
+		workers <- struct{}{}
+		go func(rt orchestration.Runtime) {
+			defer func() {
+				<-workers
+				wg.Done()
+			}()
+			if err := client.DeprovisionRuntime(rt.InstanceID); err != nil {
+				cmd.log.Errorf("Deprovisioning of Runtime %s (shoot %s) failed: %s", rt.RuntimeID, rt.ShootName, err)
+				mu.Lock()
+				failed++
+				mu.Unlock()
+				return
+			}
+			cmd.log.Infof("Deprovisioning of Runtime %s (shoot %s) requested", rt.RuntimeID, rt.ShootName)
+		}(rt)
+	}
+	wg.Wait()
+
+	if failed > 0 {
+		return fmt.Errorf("%d/%d deprovisioning request(s) failed", failed, len(runtimes))
+	}
+	fmt.Printf("Deprovisioning of %d Runtime(s) requested\n", len(runtimes))
+	return nil
+}
+
+var deprovisionTargetColumns = []printer.Column{
+	{
+		Header:    "GLOBAL ACCOUNT",
+		FieldSpec: "{.GlobalAccountID}",
+	},
+	{
+		Header:    "SUBACCOUNT",
+		FieldSpec: "{.SubAccountID}",
+	},
+	{
+		Header:    "SHOOT",
+		FieldSpec: "{.ShootName}",
+	},
+	{
+		Header:    "RUNTIME ID",
+		FieldSpec: "{.RuntimeID}",
+	},
+	{
+		Header:    "INSTANCE ID",
+		FieldSpec: "{.InstanceID}",
+	},
+	{
+		Header:    "PLAN",
+		FieldSpec: "{.Plan}",
+	},
+	{
+		Header:    "REGION",
+		FieldSpec: "{.Region}",
+	},
 }
 
 func (cmd *DeprovisionCommand) resolveInstanceID(ctx context.Context, cred credential.Manager) error {
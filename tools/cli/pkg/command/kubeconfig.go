@@ -102,36 +102,45 @@ func (cmd *KubeconfigCommand) Validate() error {
 }
 
 func (cmd *KubeconfigCommand) resolveRuntimeAttributes(ctx context.Context, cred credential.Manager) error {
+	rt, err := resolveRuntime(ctx, cred, cmd.globalAccountID, cmd.subAccountID, cmd.shoot)
+	if err != nil {
+		return err
+	}
+
+	cmd.runtimeID = rt.RuntimeID
+	cmd.globalAccountID = rt.GlobalAccountID
+
+	if isEuAccess(rt.SubAccountRegion) {
+		if !promptUser("This Kyma runtime is operated and supported in European Union (EU) Access mode.\nPlease confirm that you are either physically located in EU or that you have positively verified that EU access restrictions do not apply in your situation.\nYour confirmation will be logged. ") {
+			return fmt.Errorf("aborted downloading kubeconfig for shoot: %s", rt.ShootName)
+		}
+	}
+	return nil
+}
+
+// resolveRuntime finds the single runtime matching either the global account / subaccount pair or the shoot name
+func resolveRuntime(ctx context.Context, cred credential.Manager, globalAccountID, subAccountID, shoot string) (runtime.RuntimeDTO, error) {
 	httpClient := oauth2.NewClient(ctx, cred)
 	rtClient := runtime.NewClient(GlobalOpts.KEBAPIURL(), httpClient)
 	params := runtime.ListParameters{}
-	if cmd.shoot != "" {
-		params.Shoots = []string{cmd.shoot}
+	if shoot != "" {
+		params.Shoots = []string{shoot}
 	} else {
-		params.GlobalAccountIDs = []string{cmd.globalAccountID}
-		params.SubAccountIDs = []string{cmd.subAccountID}
+		params.GlobalAccountIDs = []string{globalAccountID}
+		params.SubAccountIDs = []string{subAccountID}
 	}
 
 	rp, err := rtClient.ListRuntimes(params)
 	if err != nil {
-		return err
+		return runtime.RuntimeDTO{}, err
 	}
 	if rp.Count < 1 {
-		return fmt.Errorf("no runtimes matched the input options")
+		return runtime.RuntimeDTO{}, fmt.Errorf("no runtimes matched the input options")
 	}
 	if rp.Count > 1 {
-		return fmt.Errorf("multiple runtimes (%d) matched the input options", rp.Count)
+		return runtime.RuntimeDTO{}, fmt.Errorf("multiple runtimes (%d) matched the input options", rp.Count)
 	}
-
-	cmd.runtimeID = rp.Data[0].RuntimeID
-	cmd.globalAccountID = rp.Data[0].GlobalAccountID
-
-	if isEuAccess(rp.Data[0].SubAccountRegion) {
-		if !promptUser("This Kyma runtime is operated and supported in European Union (EU) Access mode.\nPlease confirm that you are either physically located in EU or that you have positively verified that EU access restrictions do not apply in your situation.\nYour confirmation will be logged. ") {
-			return fmt.Errorf("aborted downloading kubeconfig for shoot: %s", rp.Data[0].ShootName)
-		}
-	}
-	return nil
+	return rp.Data[0], nil
 }
 
 func (cmd *KubeconfigCommand) saveKubeconfig(kubeconfig string) error {
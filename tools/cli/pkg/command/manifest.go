@@ -0,0 +1,223 @@
+package command
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	manifestAPIVersion = "kcp.kyma-project.io/v1"
+
+	upgradeKymaKind    = "UpgradeKyma"
+	upgradeClusterKind = "UpgradeCluster"
+)
+
+// Manifest is the declarative specification of an upgrade orchestration applied with the kcp apply command
+type Manifest struct {
+	APIVersion string           `json:"apiVersion"`
+	Kind       string           `json:"kind"`
+	Metadata   ManifestMetadata `json:"metadata"`
+	Spec       ManifestSpec     `json:"spec"`
+
+	// raw is the manifest as read from the file, which is stored with the orchestration
+	raw string
+}
+
+// ManifestMetadata identifies the manifest, orchestrations applied from manifests with the same name are compared with each other
+type ManifestMetadata struct {
+	Name string `json:"name"`
+}
+
+// ManifestSpec holds the orchestration parameters of the manifest
+type ManifestSpec struct {
+	Targets    orchestration.TargetSpec            `json:"targets"`
+	Strategy   ManifestStrategy                    `json:"strategy,omitempty"`
+	DryRun     bool                                `json:"dryRun,omitempty"`
+	Kyma       *orchestration.KymaParameters       `json:"kyma,omitempty"`
+	Kubernetes *orchestration.KubernetesParameters `json:"kubernetes,omitempty"`
+}
+
+// ManifestStrategy holds the orchestration strategy of the manifest
+type ManifestStrategy struct {
+	Type              orchestration.StrategyType         `json:"type,omitempty"`
+	Schedule          string                             `json:"schedule,omitempty"`
+	MaintenanceWindow bool                               `json:"maintenanceWindow,omitempty"`
+	Parallel          orchestration.ParallelStrategySpec `json:"parallel,omitempty"`
+}
+
+// LoadManifest reads and validates the manifest from the given file, or from the standard input if the path is "-"
+func LoadManifest(path string) (*Manifest, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "while reading manifest %s", path)
+	}
+	return ParseManifest(data)
+}
+
+// ParseManifest decodes and validates the manifest, unknown fields are rejected
+func ParseManifest(data []byte) (*Manifest, error) {
+	m := &Manifest{}
+	if err := yaml.UnmarshalStrict(data, m); err != nil {
+		return nil, errors.Wrap(err, "while decoding manifest")
+	}
+	m.raw = string(data)
+
+	if m.Spec.Strategy.Type == "" {
+		m.Spec.Strategy.Type = orchestration.ParallelStrategy
+	}
+	if m.Spec.Strategy.Schedule == "" {
+		m.Spec.Strategy.Schedule = string(orchestration.Now)
+	}
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Validate checks the manifest against the constraints enforced by Kyma Environment Broker, without contacting it
+func (m *Manifest) Validate() error {
+	if m.APIVersion != manifestAPIVersion {
+		return fmt.Errorf("unsupported apiVersion: %q, expected %q", m.APIVersion, manifestAPIVersion)
+	}
+	if m.Metadata.Name == "" {
+		return errors.New("metadata.name must not be empty")
+	}
+
+	if len(m.Spec.Targets.Include) == 0 {
+		return errors.New("spec.targets.include must not be empty")
+	}
+	for i, t := range m.Spec.Targets.Include {
+		if err := validateManifestTarget(t, true); err != nil {
+			return errors.Wrapf(err, "spec.targets.include[%d]", i)
+		}
+	}
+	for i, t := range m.Spec.Targets.Exclude {
+		if err := validateManifestTarget(t, false); err != nil {
+			return errors.Wrapf(err, "spec.targets.exclude[%d]", i)
+		}
+	}
+
+	if err := validateManifestStrategy(m.Spec.Strategy); err != nil {
+		return errors.Wrap(err, "spec.strategy")
+	}
+
+	switch m.Kind {
+	case upgradeKymaKind:
+		if m.Spec.Kubernetes != nil {
+			return fmt.Errorf("spec.kubernetes is not supported by kind %s", m.Kind)
+		}
+		if m.Spec.Kyma != nil {
+			if err := ValidateUpgradeKymaVersionFmt(m.Spec.Kyma.Version); err != nil {
+				return errors.Wrap(err, "spec.kyma.version")
+			}
+		}
+	case upgradeClusterKind:
+		if m.Spec.Kyma != nil {
+			return fmt.Errorf("spec.kyma is not supported by kind %s", m.Kind)
+		}
+	default:
+		return fmt.Errorf("unsupported kind: %q, expected %s or %s", m.Kind, upgradeKymaKind, upgradeClusterKind)
+	}
+
+	return nil
+}
+
+// Parameters converts the manifest to the orchestration parameters, the manifest itself is attached to them
+func (m *Manifest) Parameters() orchestration.Parameters {
+	params := orchestration.Parameters{
+		Targets: m.Spec.Targets,
+		Strategy: orchestration.StrategySpec{
+			Type:              m.Spec.Strategy.Type,
+			Schedule:          m.Spec.Strategy.Schedule,
+			MaintenanceWindow: m.Spec.Strategy.MaintenanceWindow,
+			Parallel:          m.Spec.Strategy.Parallel,
+		},
+		DryRun:     m.Spec.DryRun,
+		Kubernetes: m.Spec.Kubernetes,
+		Kyma:       m.Spec.Kyma,
+		Manifest:   m.raw,
+	}
+	// KEB expects the kyma parameters to be present for Kyma upgrades
+	if m.Kind == upgradeKymaKind && params.Kyma == nil {
+		params.Kyma = &orchestration.KymaParameters{}
+	}
+	if m.Kind == upgradeClusterKind && params.Kubernetes == nil {
+		params.Kubernetes = &orchestration.KubernetesParameters{}
+	}
+	return params
+}
+
+// OrchestrationType returns the type of the orchestration created from the manifest
+func (m *Manifest) OrchestrationType() orchestration.Type {
+	if m.Kind == upgradeClusterKind {
+		return orchestration.UpgradeClusterOrchestration
+	}
+	return orchestration.UpgradeKymaOrchestration
+}
+
+// manifestName returns the name of the manifest stored with an orchestration, or an empty string if it has none
+func manifestName(params orchestration.Parameters) string {
+	if params.Manifest == "" {
+		return ""
+	}
+	m := Manifest{}
+	if err := yaml.Unmarshal([]byte(params.Manifest), &m); err != nil {
+		return ""
+	}
+	return m.Metadata.Name
+}
+
+func validateManifestTarget(t orchestration.RuntimeTarget, include bool) error {
+	if t == (orchestration.RuntimeTarget{}) {
+		return errors.New("at least one selector must be set")
+	}
+	if t.Target != "" {
+		if t.Target != orchestration.TargetAll {
+			return fmt.Errorf("invalid value for target: %s", t.Target)
+		}
+		if !include {
+			return fmt.Errorf("\"%s\" cannot be used in exclude targets", orchestration.TargetAll)
+		}
+	}
+	for name, pattern := range map[string]string{"globalAccount": t.GlobalAccount, "subAccount": t.SubAccount, "region": t.Region} {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return errors.Wrapf(err, "invalid regular expression for %s", name)
+		}
+	}
+	if t.PlanName != "" && !isValidPlanName(t.PlanName) {
+		return fmt.Errorf("invalid value for planName: %s", t.PlanName)
+	}
+	return nil
+}
+
+func validateManifestStrategy(s ManifestStrategy) error {
+	if s.Type != orchestration.ParallelStrategy {
+		return fmt.Errorf("invalid value for type: %s", s.Type)
+	}
+	if s.Parallel.Workers < 0 {
+		return fmt.Errorf("invalid value for parallel.workers: %d", s.Parallel.Workers)
+	}
+	switch orchestration.ScheduleType(s.Schedule) {
+	case orchestration.Immediate, orchestration.Now:
+	case orchestration.MaintenanceWindow:
+		return fmt.Errorf("the schedule type '%s' is deprecated, please use maintenanceWindow: true instead", s.Schedule)
+	default:
+		if _, err := time.Parse(time.RFC3339, s.Schedule); err != nil {
+			return fmt.Errorf("invalid value for schedule: %s, the possible values are \"immediate\", \"now\" or an RFC3339 date", s.Schedule)
+		}
+	}
+	return nil
+}
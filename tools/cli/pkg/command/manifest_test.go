@@ -0,0 +1,114 @@
+package command
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const fixKymaManifest = `apiVersion: kcp.kyma-project.io/v1
+kind: UpgradeKyma
+metadata:
+  name: kyma-rollout
+spec:
+  targets:
+    include:
+    - target: all
+    exclude:
+    - globalAccount: "CA.*"
+      planName: trial
+  strategy:
+    maintenanceWindow: true
+    parallel:
+      workers: 4
+  kyma:
+    version: 2.10.0
+`
+
+func TestParseManifest(t *testing.T) {
+	t.Run("should convert manifest to orchestration parameters", func(t *testing.T) {
+		// when
+		m, err := ParseManifest([]byte(fixKymaManifest))
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, orchestration.UpgradeKymaOrchestration, m.OrchestrationType())
+		assert.Equal(t, orchestration.Parameters{
+			Targets: orchestration.TargetSpec{
+				Include: []orchestration.RuntimeTarget{{Target: orchestration.TargetAll}},
+				Exclude: []orchestration.RuntimeTarget{{GlobalAccount: "CA.*", PlanName: trialPlan}},
+			},
+			Strategy: orchestration.StrategySpec{
+				Type:              orchestration.ParallelStrategy,
+				Schedule:          string(orchestration.Now),
+				MaintenanceWindow: true,
+				Parallel:          orchestration.ParallelStrategySpec{Workers: 4},
+			},
+			Kyma:     &orchestration.KymaParameters{Version: "2.10.0"},
+			Manifest: fixKymaManifest,
+		}, m.Parameters())
+		assert.Equal(t, "kyma-rollout", manifestName(m.Parameters()))
+	})
+
+	t.Run("should default kubernetes parameters of cluster upgrades", func(t *testing.T) {
+		// when
+		m, err := ParseManifest([]byte("apiVersion: kcp.kyma-project.io/v1\nkind: UpgradeCluster\nmetadata:\n  name: cluster\nspec:\n  targets:\n    include:\n    - region: europe\n  strategy:\n    schedule: immediate\n"))
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, orchestration.UpgradeClusterOrchestration, m.OrchestrationType())
+		assert.Equal(t, &orchestration.KubernetesParameters{}, m.Parameters().Kubernetes)
+		assert.Nil(t, m.Parameters().Kyma)
+	})
+
+	for name, tc := range map[string]struct {
+		old, new string
+		err      string
+	}{
+		"unknown field":         {old: "    maintenanceWindow: true", new: "    window: true", err: "unknown field"},
+		"unsupported kind":      {old: "kind: UpgradeKyma", new: "kind: Provision", err: "unsupported kind"},
+		"missing name":          {old: "  name: kyma-rollout", new: "  name: \"\"", err: "metadata.name"},
+		"all in exclude":        {old: "    - globalAccount: \"CA.*\"", new: "    - target: all", err: "cannot be used in exclude targets"},
+		"invalid regex":         {old: "\"CA.*\"", new: "\"CA[\"", err: "invalid regular expression for globalAccount"},
+		"invalid plan":          {old: "planName: trial", new: "planName: unknown", err: "invalid value for planName"},
+		"deprecated schedule":   {old: "    maintenanceWindow: true", new: "    schedule: maintenanceWindow", err: "deprecated"},
+		"invalid schedule":      {old: "    maintenanceWindow: true", new: "    schedule: tomorrow", err: "invalid value for schedule"},
+		"invalid kyma version":  {old: "version: 2.10.0", new: "version: latest", err: "unsupported version format"},
+		"kubernetes parameters": {old: "  kyma:\n    version: 2.10.0", new: "  kubernetes:\n    kubernetesVersion: 1.25.4", err: "spec.kubernetes is not supported"},
+	} {
+		t.Run("should reject "+name, func(t *testing.T) {
+			// given
+			manifest := replaceOnce(t, fixKymaManifest, tc.old, tc.new)
+
+			// when
+			_, err := ParseManifest([]byte(manifest))
+
+			// then
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
+
+func TestDiffTargets(t *testing.T) {
+	// given
+	previous := []orchestration.OperationResponse{{RuntimeID: "r1", ShootName: "c-1"}, {RuntimeID: "r2", ShootName: "c-2"}}
+	current := []orchestration.OperationResponse{{RuntimeID: "r4", ShootName: "c-4"}, {RuntimeID: "r2", ShootName: "c-2"}, {RuntimeID: "r3", ShootName: "c-3"}}
+
+	// when
+	added, removed, unchanged := diffTargets(previous, current)
+
+	// then
+	assert.Equal(t, []orchestration.OperationResponse{{RuntimeID: "r3", ShootName: "c-3"}, {RuntimeID: "r4", ShootName: "c-4"}}, added)
+	assert.Equal(t, []orchestration.OperationResponse{{RuntimeID: "r1", ShootName: "c-1"}}, removed)
+	assert.Equal(t, 1, unchanged)
+}
+
+func replaceOnce(t *testing.T, s, old, new string) string {
+	i := strings.Index(s, old)
+	require.GreaterOrEqual(t, i, 0, "fixture does not contain %q", old)
+	return s[:i] + new + s[i+len(old):]
+}
@@ -20,7 +20,10 @@ func NewOperationCmd() *cobra.Command {
 	cobraCmd.AddCommand(
 		NewOperationStopCmd(),
 		NewOperationDebugLogsCmd(),
+		NewOperationTimelineCmd(),
+		NewOperationRetryCmd(),
 	)
+	cobraCmd.AddCommand(NewOperationActionCmds()...)
 
 	if cobraCmd.Parent() != nil && cobraCmd.Parent().Context() != nil {
 		cmd.ctx = cobraCmd.Parent().Context()
@@ -0,0 +1,112 @@
+package command
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/operation"
+	"github.com/kyma-project/control-plane/tools/cli/pkg/logger"
+	"github.com/kyma-project/control-plane/tools/cli/pkg/printer"
+)
+
+// OperationActionsCommand represents an execution of the kcp operation commands which resolve the KEB operations stuck in a step
+type OperationActionsCommand struct {
+	log    logger.Logger
+	client operation.Client
+	output string
+	reason string
+}
+
+// NewOperationActionCmds constructs the kcp operation subcommands which call the KEB /operations admin API
+func NewOperationActionCmds() []*cobra.Command {
+	cmd := OperationActionsCommand{}
+	return []*cobra.Command{
+		cmd.newActionCmd(&cobra.Command{
+			Use:   "skip-step <operation ID> <step name>",
+			Short: "Skips a step of a KEB operation.",
+			Long: `Skips a step of a KEB operation which hangs, e.g. Check_Cluster_Deregistration. The step is not run anymore and the operation is processed again immediately.
+The action is recorded in the events of the operation with your identity.`,
+			Example: `  kcp operation skip-step OPID Check_Cluster_Deregistration --reason "cluster deregistered manually"   Skip the step of the operation.`,
+			Args:    cobra.ExactArgs(2),
+		}, func(args []string, action operation.ActionDTO) (operation.OperationDTO, error) {
+			return cmd.client.SkipStep(args[0], args[1], action)
+		}),
+		cmd.newActionCmd(&cobra.Command{
+			Use:   "retry-now <operation ID>",
+			Short: "Retries a KEB operation immediately.",
+			Long: `Processes a KEB operation again without waiting for the retry requested by the current step.
+Not supported for the upgrade operations which are retried by their orchestration. The action is recorded in the events of the operation with your identity.`,
+			Example: `  kcp operation retry-now OPID   Process the operation again.`,
+			Args:    cobra.ExactArgs(1),
+		}, func(args []string, action operation.ActionDTO) (operation.OperationDTO, error) {
+			return cmd.client.Retry(args[0], action)
+		}),
+		cmd.newActionCmd(&cobra.Command{
+			Use:   "fail <operation ID>",
+			Short: "Fails a KEB operation.",
+			Long: `Finishes a KEB operation with the failed state, the remaining steps are not run.
+The action is recorded in the events of the operation with your identity.`,
+			Example: `  kcp operation fail OPID --reason "cluster removed manually"   Fail the operation.`,
+			Args:    cobra.ExactArgs(1),
+		}, func(args []string, action operation.ActionDTO) (operation.OperationDTO, error) {
+			return cmd.client.Fail(args[0], action)
+		}),
+		cmd.newActionCmd(&cobra.Command{
+			Use:   "succeed <operation ID>",
+			Short: "Succeeds a KEB operation.",
+			Long: `Finishes a KEB operation with the succeeded state, the remaining steps are not run.
+The action is recorded in the events of the operation with your identity.`,
+			Example: `  kcp operation succeed OPID --reason "Kyma resource deleted manually"   Succeed the operation.`,
+			Args:    cobra.ExactArgs(1),
+		}, func(args []string, action operation.ActionDTO) (operation.OperationDTO, error) {
+			return cmd.client.Succeed(args[0], action)
+		}),
+		cmd.newActionCmd(&cobra.Command{
+			Use:   "cancel <operation ID>",
+			Short: "Cancels a KEB operation.",
+			Long: `Cancels a KEB operation in progress. The operation stops before its next step, the started stages are compensated, e.g. the runtime created by a canceled provisioning is deprovisioned,
+and the operation finishes with the canceled state. The OSB last operation of the canceled provisioning, update, or deprovisioning is reported as failed.
+The action is recorded in the events of the operation with your identity.`,
+			Example: `  kcp operation cancel OPID --reason "wrong region requested"   Cancel the operation.`,
+			Args:    cobra.ExactArgs(1),
+		}, func(args []string, action operation.ActionDTO) (operation.OperationDTO, error) {
+			return cmd.client.Cancel(args[0], action)
+		}),
+	}
+}
+
+func (cmd *OperationActionsCommand) newActionCmd(cobraCmd *cobra.Command, call func(args []string, action operation.ActionDTO) (operation.OperationDTO, error)) *cobra.Command {
+	cobraCmd.PreRunE = func(_ *cobra.Command, _ []string) error { return ValidateOutputOpt(cmd.output) }
+	cobraCmd.RunE = func(cobraCmd *cobra.Command, args []string) error {
+		cmd.log = logger.New()
+		cmd.client = operation.NewClient(cobraCmd.Context(), GlobalOpts.KEBAPIURL(), CLICredentialManager(cmd.log))
+		op, err := call(args, operation.ActionDTO{Reason: cmd.reason})
+		if err != nil {
+			return errors.Wrapf(err, "while calling %s", cobraCmd.Name())
+		}
+		return cmd.print(op)
+	}
+	SetOutputOpt(cobraCmd, &cmd.output)
+	cobraCmd.Flags().StringVarP(&cmd.reason, "reason", "r", "", "Reason of the action, recorded in the events of the operation.")
+	return cobraCmd
+}
+
+func (cmd *OperationActionsCommand) print(op operation.OperationDTO) error {
+	if cmd.output == jsonOutput {
+		printer.NewJSONPrinter("  ").PrintObj(op)
+		return nil
+	}
+	fmt.Printf("Operation %s of type %s is %s: %s\n", op.OperationID, op.Type, op.State, op.Description)
+	if len(op.SkippedSteps) > 0 {
+		fmt.Printf("Skipped steps: %v\n", op.SkippedSteps)
+	}
+	if op.CancellationRequested {
+		fmt.Printf("Cancellation requested, compensated steps: %v\n", op.CompensatedSteps)
+	}
+	if op.TraceID != "" {
+		fmt.Printf("Trace ID: %s\n", op.TraceID)
+	}
+	return nil
+}
@@ -0,0 +1,251 @@
+package command
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"golang.org/x/oauth2"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/operation"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/runtime"
+	"github.com/kyma-project/control-plane/tools/cli/pkg/credential"
+	"github.com/kyma-project/control-plane/tools/cli/pkg/logger"
+	"github.com/kyma-project/control-plane/tools/cli/pkg/printer"
+)
+
+// OperationRetryCommand represents an execution of the kcp operation retry command
+type OperationRetryCommand struct {
+	cobraCmd            *cobra.Command
+	log                 logger.Logger
+	client              operation.Client
+	output              string
+	reason              string
+	targetInputs        []string
+	targetExcludeInputs []string
+	targets             orchestration.TargetSpec
+	parallelism         int
+	dryRun              bool
+	force               bool
+}
+
+// failedOperation is the failed provisioning or update which is the last operation of a Runtime selected with --target
+type failedOperation struct {
+	OperationID string
+	Type        runtime.OperationType
+	InstanceID  string
+	RuntimeID   string
+	ShootName   string
+	Description string
+}
+
+// NewOperationRetryCmd constructs a new instance of OperationRetryCommand and configures it in terms of a cobra.Command
+func NewOperationRetryCmd() *cobra.Command {
+	cmd := OperationRetryCommand{}
+	cobraCmd := &cobra.Command{
+		Use:   "retry [<operation ID>...]",
+		Short: "Retries failed KEB provisioning and update operations.",
+		Long: `Retries failed KEB provisioning and update operations. The operation is processed again from its first not finished stage, the finished stages are not repeated.
+The operation must be the last operation of its instance. An operation in progress is processed immediately, like with the retry-now command.
+Multiple Runtimes can be specified with the --target and --target-exclude options, the failed provisioning or update of each matching Runtime is retried after your confirmation.
+The targets are resolved from the Gardener shoots like for the orchestrations, so a failed provisioning which did not create the shoot can be retried only by the operation ID.
+The action is recorded in the events of the operation with your identity.`,
+		Example: `  kcp operation retry OPID --reason "EDP is available again"   Retry the failed operation.
+  kcp operation retry --target account=CA.* --dry-run           List the failed operations of the Runtimes of the matching global accounts.
+  kcp operation retry --target plan=azure,region=westeurope -f  Retry the failed operations of the Azure Runtimes in the region without the confirmation.`,
+		PreRunE: func(_ *cobra.Command, args []string) error { return cmd.Validate(args) },
+		RunE:    func(_ *cobra.Command, args []string) error { return cmd.Run(args) },
+	}
+	cmd.cobraCmd = cobraCmd
+
+	SetOutputOpt(cobraCmd, &cmd.output)
+	cobraCmd.Flags().StringVarP(&cmd.reason, "reason", "r", "", "Reason of the retry, recorded in the events of the operations.")
+	SetRuntimeTargetOpts(cobraCmd, &cmd.targetInputs, &cmd.targetExcludeInputs)
+	cobraCmd.Flags().IntVarP(&cmd.parallelism, "parallelism", "p", 4, "Number of retry requests sent at the same time when the Runtimes are selected with --target.")
+	cobraCmd.Flags().BoolVar(&cmd.dryRun, "dry-run", false, "List the failed operations of the Runtimes selected with --target without retrying them.")
+	cobraCmd.Flags().BoolVarP(&cmd.force, "force", "f", false, "Retry the failed operations of the Runtimes selected with --target without the confirmation.")
+	return cobraCmd
+}
+
+// Validate checks the operation IDs or the targets
+func (cmd *OperationRetryCommand) Validate(args []string) error {
+	if err := ValidateOutputOpt(cmd.output); err != nil {
+		return err
+	}
+	if len(cmd.targetInputs) == 0 {
+		if len(args) == 0 {
+			return errors.New("at least one operation ID or --target must be specified")
+		}
+		return nil
+	}
+	if len(args) > 0 {
+		return errors.New("the operation IDs cannot be used together with the --target option")
+	}
+	if cmd.parallelism < 1 {
+		return errors.New("--parallelism must be at least 1")
+	}
+	return ValidateTransformRuntimeTargetOpts(cmd.targetInputs, cmd.targetExcludeInputs, &cmd.targets)
+}
+
+// Run executes the operation retry command
+func (cmd *OperationRetryCommand) Run(args []string) error {
+	cmd.log = logger.New()
+	cred := CLICredentialManager(cmd.log)
+	cmd.client = operation.NewClient(cmd.cobraCmd.Context(), GlobalOpts.KEBAPIURL(), cred)
+
+	if len(cmd.targetInputs) > 0 {
+		rtClient := runtime.NewClient(GlobalOpts.KEBAPIURL(), oauth2.NewClient(cmd.cobraCmd.Context(), cred))
+		return cmd.retryTargets(cred, rtClient)
+	}
+
+	var retried []operation.OperationDTO
+	for _, operationID := range args {
+		op, err := cmd.client.Retry(operationID, operation.ActionDTO{Reason: cmd.reason})
+		if err != nil {
+			return errors.Wrapf(err, "while retrying operation %s", operationID)
+		}
+		retried = append(retried, op)
+	}
+	if cmd.output == jsonOutput {
+		printer.NewJSONPrinter("  ").PrintObj(retried)
+		return nil
+	}
+	for _, op := range retried {
+		fmt.Printf("Operation %s of type %s is %s: %s\n", op.OperationID, op.Type, op.State, op.Description)
+	}
+	return nil
+}
+
+// retryTargets retries the failed operations of the Runtimes matching the targets in parallel, the failed requests do not stop the remaining ones.
+// The targets are resolved like for the other commands, a failed provisioning which did not create the shoot can be retried only by the operation ID
+func (cmd *OperationRetryCommand) retryTargets(cred credential.Manager, rtClient runtime.Client) error {
+	resolved, err := resolveRuntimeTargets(cmd.cobraCmd.Context(), cred, cmd.targets, cmd.log, true)
+	if err != nil {
+		return err
+	}
+	rp, err := rtClient.ListRuntimes(runtime.ListParameters{
+		OperationDetail: runtime.LastOperation,
+		States:          []runtime.State{runtime.StateFailed, runtime.StateError},
+	})
+	if err != nil {
+		return errors.Wrap(err, "while listing runtimes")
+	}
+	operations := failedOperations(selectRuntimes(rp.Data, resolved))
+	if len(operations) == 0 {
+		fmt.Println("No failed provisioning or update operations of the Runtimes matching the targets")
+		return nil
+	}
+	tp, err := printer.NewTablePrinter(failedOperationColumns, false)
+	if err != nil {
+		return err
+	}
+	if err := tp.PrintObj(operations); err != nil {
+		return err
+	}
+	if cmd.dryRun {
+		fmt.Printf("Dry run: %d operation(s) would be retried\n", len(operations))
+		return nil
+	}
+	if !cmd.force && !promptUser(fmt.Sprintf("%d operation(s) will be retried. Are you sure you want to continue? ", len(operations))) {
+		return errors.New("retry command aborted")
+	}
+
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		failed int
+	)
+	workers := make(chan struct{}, cmd.parallelism)
+	for _, op := range operations {
+		wg.Add(1)
+		workers <- struct{}{}
+		go func(op failedOperation) {
+			defer func() {
+				<-workers
+				wg.Done()
+			}()
+			if _, err := cmd.client.Retry(op.OperationID, operation.ActionDTO{Reason: cmd.reason}); err != nil {
+				cmd.log.Errorf("Retry of %s operation %s of Runtime %s failed: %s", op.Type, op.OperationID, op.RuntimeID, err)
+				mu.Lock()
+				failed++
+				mu.Unlock()
+				return
+			}
+			cmd.log.Infof("Retry of %s operation %s of Runtime %s requested", op.Type, op.OperationID, op.RuntimeID)
+		}(op)
+	}
+	wg.Wait()
+
+	if failed > 0 {
+		return fmt.Errorf("%d/%d retry request(s) failed", failed, len(operations))
+	}
+	fmt.Printf("Retry of %d operation(s) requested\n", len(operations))
+	return nil
+}
+
+// selectRuntimes returns the Runtimes listed by KEB which are among the Runtimes resolved from the targets
+func selectRuntimes(runtimes []runtime.RuntimeDTO, resolved []orchestration.Runtime) []runtime.RuntimeDTO {
+	ids := make(map[string]bool, len(resolved))
+	for _, rt := range resolved {
+		ids[rt.RuntimeID] = true
+	}
+	var selected []runtime.RuntimeDTO
+	for _, rt := range runtimes {
+		if ids[rt.RuntimeID] {
+			selected = append(selected, rt)
+		}
+	}
+	return selected
+}
+
+// failedOperations returns the failed provisioning and update operations which are the last operations of the Runtimes
+func failedOperations(runtimes []runtime.RuntimeDTO) []failedOperation {
+	var operations []failedOperation
+	for _, rt := range runtimes {
+		lastOp := rt.LastOperation()
+		if lastOp.State != string(orchestration.Failed) {
+			continue
+		}
+		switch lastOp.Type {
+		case runtime.Provision, runtime.Unsuspension, runtime.Update:
+			operations = append(operations, failedOperation{
+				OperationID: lastOp.OperationID,
+				Type:        lastOp.Type,
+				InstanceID:  rt.InstanceID,
+				RuntimeID:   rt.RuntimeID,
+				ShootName:   rt.ShootName,
+				Description: lastOp.Description,
+			})
+		}
+	}
+	return operations
+}
+
+var failedOperationColumns = []printer.Column{
+	{
+		Header:    "INSTANCE ID",
+		FieldSpec: "{.InstanceID}",
+	},
+	{
+		Header:    "RUNTIME ID",
+		FieldSpec: "{.RuntimeID}",
+	},
+	{
+		Header:    "SHOOT",
+		FieldSpec: "{.ShootName}",
+	},
+	{
+		Header:    "TYPE",
+		FieldSpec: "{.Type}",
+	},
+	{
+		Header:    "OPERATION ID",
+		FieldSpec: "{.OperationID}",
+	},
+	{
+		Header:    "DESCRIPTION",
+		FieldSpec: "{.Description}",
+	},
+}
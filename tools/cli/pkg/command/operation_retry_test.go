@@ -0,0 +1,75 @@
+package command
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/runtime"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectFailedOperations(t *testing.T) {
+	// given
+	runtimes := []runtime.RuntimeDTO{
+		fixFailedRuntime("rt-1", "ga-1", "azure", "westeurope", runtime.Provision),
+		fixFailedRuntime("rt-2", "ga-1", "aws", "eu-central-1", runtime.Update),
+		fixFailedRuntime("rt-3", "ga-2", "azure", "westeurope", runtime.Provision),
+		fixFailedRuntime("rt-4", "ga-1", "azure", "westeurope", runtime.Deprovision),
+	}
+
+	for name, tc := range map[string]struct {
+		resolved []orchestration.Runtime
+		expected []string
+	}{
+		"all": {
+			resolved: []orchestration.Runtime{{RuntimeID: "rt-1"}, {RuntimeID: "rt-2"}, {RuntimeID: "rt-3"}, {RuntimeID: "rt-4"}},
+			expected: []string{"op-rt-1", "op-rt-2", "op-rt-3"},
+		},
+		"some": {
+			resolved: []orchestration.Runtime{{RuntimeID: "rt-2"}, {RuntimeID: "rt-3"}},
+			expected: []string{"op-rt-2", "op-rt-3"},
+		},
+		"not failed": {
+			resolved: []orchestration.Runtime{{RuntimeID: "rt-5"}},
+			expected: nil,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// when
+			operations := failedOperations(selectRuntimes(runtimes, tc.resolved))
+
+			// then
+			var ids []string
+			for _, op := range operations {
+				ids = append(ids, op.OperationID)
+			}
+			assert.Equal(t, tc.expected, ids)
+		})
+	}
+}
+
+func fixFailedRuntime(runtimeID, globalAccountID, plan, region string, opType runtime.OperationType) runtime.RuntimeDTO {
+	op := &runtime.Operation{
+		State:       string(orchestration.Failed),
+		Type:        opType,
+		OperationID: "op-" + runtimeID,
+		CreatedAt:   time.Now(),
+	}
+	rt := runtime.RuntimeDTO{
+		InstanceID:      "inst-" + runtimeID,
+		RuntimeID:       runtimeID,
+		GlobalAccountID: globalAccountID,
+		ServicePlanName: plan,
+		ProviderRegion:  region,
+	}
+	switch opType {
+	case runtime.Provision:
+		rt.Status.Provisioning = op
+	case runtime.Deprovision:
+		rt.Status.Deprovisioning = op
+	case runtime.Update:
+		rt.Status.Update = &runtime.OperationsData{Count: 1, TotalCount: 1, Data: []runtime.Operation{*op}}
+	}
+	return rt
+}
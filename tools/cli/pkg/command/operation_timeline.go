@@ -0,0 +1,172 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/operation"
+	"github.com/kyma-project/control-plane/tools/cli/pkg/logger"
+	"github.com/kyma-project/control-plane/tools/cli/pkg/printer"
+)
+
+const timelineBarWidth = 40
+
+// OperationTimelineCommand represents an execution of the kcp operation timeline command
+type OperationTimelineCommand struct {
+	log    logger.Logger
+	client operation.Client
+	output string
+}
+
+// timelineRow aggregates the runs of a step
+type timelineRow struct {
+	StepName   string
+	Stage      string
+	Attempts   int
+	StartedAt  time.Time
+	FinishedAt time.Time
+	Result     string
+	Error      string
+	Bar        string
+}
+
+var timelineColumns = []printer.Column{
+	{
+		Header:    "STEP",
+		FieldSpec: "{.StepName}",
+	},
+	{
+		Header:    "STAGE",
+		FieldSpec: "{.Stage}",
+	},
+	{
+		Header:    "ATTEMPTS",
+		FieldSpec: "{.Attempts}",
+	},
+	{
+		Header: "DURATION",
+		FieldFormatter: func(obj interface{}) string {
+			row := obj.(timelineRow)
+			return row.FinishedAt.Sub(row.StartedAt).Round(time.Second).String()
+		},
+	},
+	{
+		Header:    "RESULT",
+		FieldSpec: "{.Result}",
+	},
+	{
+		Header:    "TIMELINE",
+		FieldSpec: "{.Bar}",
+	},
+}
+
+// NewOperationTimelineCmd constructs a new instance of OperationTimelineCommand and configures it in terms of a cobra.Command
+func NewOperationTimelineCmd() *cobra.Command {
+	cmd := OperationTimelineCommand{}
+	cobraCmd := &cobra.Command{
+		Use:   "timeline <operation ID>",
+		Short: "Displays the timeline of a KEB operation.",
+		Long: `Displays the steps of a KEB operation in the order of their first run with the number of attempts, the time spent in the step and its last result.
+The chart shows when each step was running between the start of the first step and the end of the last one.`,
+		Example: `  kcp operation timeline OPID           Display the timeline of the operation.
+  kcp operation timeline OPID -o json   Display all runs of the steps in the JSON format.`,
+		Args:    cobra.ExactArgs(1),
+		PreRunE: func(_ *cobra.Command, _ []string) error { return ValidateOutputOpt(cmd.output) },
+		RunE:    func(cobraCmd *cobra.Command, args []string) error { return cmd.Run(cobraCmd, args[0]) },
+	}
+	SetOutputOpt(cobraCmd, &cmd.output)
+	return cobraCmd
+}
+
+// Run executes the timeline command
+func (cmd *OperationTimelineCommand) Run(cobraCmd *cobra.Command, operationID string) error {
+	cmd.log = logger.New()
+	cmd.client = operation.NewClient(cobraCmd.Context(), GlobalOpts.KEBAPIURL(), CLICredentialManager(cmd.log))
+	timeline, err := cmd.client.Timeline(operationID)
+	if err != nil {
+		return errors.Wrap(err, "while getting the operation timeline")
+	}
+
+	if cmd.output == jsonOutput {
+		printer.NewJSONPrinter("  ").PrintObj(timeline)
+		return nil
+	}
+	fmt.Printf("Operation %s of type %s is %s\n", timeline.OperationID, timeline.Type, timeline.State)
+	rows := timelineRows(timeline.Steps)
+	if len(rows) == 0 {
+		fmt.Println("No steps recorded")
+		return nil
+	}
+	tp, err := printer.NewTablePrinter(timelineColumns, false)
+	if err != nil {
+		return err
+	}
+	if err := tp.PrintObj(rows); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if row.Error != "" {
+			fmt.Printf("%s: %s\n", row.StepName, row.Error)
+		}
+	}
+	return nil
+}
+
+// timelineRows aggregates the runs of every step and draws the bars scaled to the time between the first and the last run
+func timelineRows(steps []operation.StepDTO) []timelineRow {
+	rows := make([]timelineRow, 0)
+	index := map[string]int{}
+	var begin, end time.Time
+	for _, step := range steps {
+		if begin.IsZero() || step.StartedAt.Before(begin) {
+			begin = step.StartedAt
+		}
+		if step.FinishedAt.After(end) {
+			end = step.FinishedAt
+		}
+
+		key := step.Stage + "/" + step.StepName
+		i, found := index[key]
+		if !found {
+			index[key] = len(rows)
+			rows = append(rows, timelineRow{StepName: step.StepName, Stage: step.Stage, StartedAt: step.StartedAt})
+			i = len(rows) - 1
+		}
+		row := &rows[i]
+		row.Attempts++
+		if step.StartedAt.Before(row.StartedAt) {
+			row.StartedAt = step.StartedAt
+		}
+		if step.FinishedAt.After(row.FinishedAt) {
+			row.FinishedAt = step.FinishedAt
+			row.Result = step.Result
+			row.Error = step.Error
+		}
+	}
+
+	for i := range rows {
+		rows[i].Bar = timelineBar(begin, end, rows[i].StartedAt, rows[i].FinishedAt)
+	}
+	return rows
+}
+
+func timelineBar(begin, end, from, to time.Time) string {
+	total := end.Sub(begin)
+	if total <= 0 {
+		return "|" + strings.Repeat("#", timelineBarWidth) + "|"
+	}
+	start := int(float64(from.Sub(begin)) / float64(total) * timelineBarWidth)
+	stop := int(float64(to.Sub(begin)) / float64(total) * timelineBarWidth)
+	if stop <= start {
+		// every step is visible, even the ones which took no time compared with the whole operation
+		stop = start + 1
+	}
+	if stop > timelineBarWidth {
+		start, stop = timelineBarWidth-(stop-start), timelineBarWidth
+	}
+	return "|" + strings.Repeat(".", start) + strings.Repeat("#", stop-start) + strings.Repeat(".", timelineBarWidth-stop) + "|"
+}
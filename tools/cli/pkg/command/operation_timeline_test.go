@@ -0,0 +1,34 @@
+package command
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/operation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimelineRows(t *testing.T) {
+	// given
+	begin := time.Date(2022, 10, 18, 12, 0, 0, 0, time.UTC)
+	steps := []operation.StepDTO{
+		{StepName: "Create_Runtime", Stage: "create_runtime", StartedAt: begin, FinishedAt: begin.Add(10 * time.Second), Result: "retry", Error: "not ready"},
+		{StepName: "Check_Runtime", Stage: "check_runtime", StartedAt: begin.Add(10 * time.Second), FinishedAt: begin.Add(20 * time.Second), Result: "retry"},
+		{StepName: "Create_Runtime", Stage: "create_runtime", StartedAt: begin.Add(20 * time.Second), FinishedAt: begin.Add(40 * time.Second), Result: "succeeded"},
+	}
+
+	// when
+	rows := timelineRows(steps)
+
+	// then
+	require.Len(t, rows, 2)
+	assert.Equal(t, "Create_Runtime", rows[0].StepName)
+	assert.Equal(t, 2, rows[0].Attempts)
+	assert.Equal(t, "succeeded", rows[0].Result)
+	assert.Empty(t, rows[0].Error)
+	assert.Equal(t, "|"+strings.Repeat("#", 40)+"|", rows[0].Bar)
+	assert.Equal(t, 1, rows[1].Attempts)
+	assert.Equal(t, "|"+strings.Repeat(".", 10)+strings.Repeat("#", 10)+strings.Repeat(".", 20)+"|", rows[1].Bar)
+}
@@ -294,12 +294,10 @@ func parseRuntimeTarget(targetInput string, targets *[]orchestration.RuntimeTarg
 		case instanceIDTarget:
 			target.InstanceID = selectorValue
 		case planTarget:
-			switch selectorValue {
-			case azurePlan, azureLitePlan, azureHAPlan, trialPlan, gcpPlan, openstackPlan, awsPlan, awsHAPlan, freePlan:
-				target.PlanName = selectorValue
-			default:
+			if !isValidPlanName(selectorValue) {
 				return fmt.Errorf("invalid value for selector: %s %s=%s", flagName, selectorKey, selectorValue)
 			}
+			target.PlanName = selectorValue
 		case shootTarget:
 			target.Shoot = selectorValue
 		default:
@@ -311,6 +309,14 @@ func parseRuntimeTarget(targetInput string, targets *[]orchestration.RuntimeTarg
 	return nil
 }
 
+func isValidPlanName(plan string) bool {
+	switch plan {
+	case azurePlan, azureLitePlan, azureHAPlan, trialPlan, gcpPlan, openstackPlan, awsPlan, awsHAPlan, freePlan:
+		return true
+	}
+	return false
+}
+
 func checkMissingRuntimeTargetSelector(selectorKey, selectorValue string, flagName string) error {
 
 	if selectorKey != orchestration.TargetAll && selectorValue == "" {
@@ -20,6 +20,7 @@ const (
 	retryCommand      = "retry"
 	operationsCommand = "operations"
 	opsCommand        = "ops"
+	manifestCommand   = "manifest"
 )
 
 // OrchestrationCommand represents an execution of the kcp orchestrations command
@@ -203,7 +204,7 @@ Message:            {{ .Msg }}
 func NewOrchestrationCmd() *cobra.Command {
 	cmd := OrchestrationCommand{}
 	cobraCmd := &cobra.Command{
-		Use:     "orchestrations [id] [ops|operations] [cancel] [retry]",
+		Use:     "orchestrations [id] [ops|operations] [cancel] [retry] [manifest]",
 		Aliases: []string{"orchestration", "o"},
 		Short:   "Displays Kyma Control Plane (KCP) orchestrations.",
 		Long: `Displays KCP orchestrations and their primary attributes, such as identifiers, type, state, parameters, or Runtime operations.
@@ -214,7 +215,8 @@ The command has the following modes:
   - When specifying an orchestration ID and ` + "`operations` or `ops`" + ` as arguments. In this mode, the command displays the Runtime operations for the given orchestration.
   - When specifying an orchestration ID and ` + "`cancel`" + ` as arguments. In this mode, the command cancels the orchestration and all pending Runtime operations.
   - When specifying an orchestration ID and ` + "`retry`" + ` as arguments. In this mode, the command retries all failed Runtime operations of the given orchestration. The ` + "`retry` " + `command only applies to the failed or in progress orchestration.
-      If the optional --operation flag is provided, it retries the specified Runtime operation of the given orchestration.`,
+      If the optional --operation flag is provided, it retries the specified Runtime operation of the given orchestration.
+  - When specifying an orchestration ID and ` + "`manifest`" + ` as arguments. In this mode, the command displays the manifest the orchestration was applied from with the kcp apply command.`,
 		Example: `  kcp orchestrations --state inprogress                                              Display all orchestrations which are in progress.
   kcp orchestration -o custom="Orchestration ID:{.OrchestrationID},STATE:{.State},CREATED AT:{.createdAt}"
                                                                                      Display all orchestations with specific custom fields.
@@ -224,7 +226,8 @@ The command has the following modes:
   kcp orchestration 0c4357f5-83e0-4b72-9472-49b5cd417c00 cancel                      Cancel the given orchestration.
   kcp orchestration 0c4357f5-83e0-4b72-9472-49b5cd417c00 retry                       Retry all failed operations of the given orchestration.
   kcp orchestration 0c4357f5-83e0-4b72-9472-49b5cd417c00 retry --operation OID1,OID2 Retry the given operations of the given orchestration
-  kcp orchestration 0c4357f5-83e0-4b72-9472-49b5cd417c00 retry --now --operation OID1 Retry the given operations of the given orchestration schedule immediately`,
+  kcp orchestration 0c4357f5-83e0-4b72-9472-49b5cd417c00 retry --now --operation OID1 Retry the given operations of the given orchestration schedule immediately
+  kcp orchestration 0c4357f5-83e0-4b72-9472-49b5cd417c00 manifest                    Display the manifest of the given orchestration.`,
 		Args:    cobra.MaximumNArgs(2),
 		PreRunE: func(_ *cobra.Command, args []string) error { return cmd.Validate(args) },
 		RunE:    func(_ *cobra.Command, args []string) error { return cmd.Run(args) },
@@ -294,6 +297,8 @@ func (cmd *OrchestrationCommand) Run(args []string) error {
 			return cmd.retryOrchestration(args[0])
 		case operationsCommand, opsCommand:
 			return cmd.showOperations(args[0])
+		case manifestCommand:
+			return cmd.showManifest(args[0])
 		}
 	}
 
@@ -322,7 +327,7 @@ func (cmd *OrchestrationCommand) Validate(args []string) error {
 	if len(args) == 2 {
 		cmd.subCommand = args[1]
 		switch cmd.subCommand {
-		case cancelCommand, retryCommand, operationsCommand, opsCommand:
+		case cancelCommand, retryCommand, operationsCommand, opsCommand, manifestCommand:
 		default:
 			return fmt.Errorf("invalid subcommand: %s", cmd.subCommand)
 		}
@@ -446,6 +451,18 @@ func (cmd *OrchestrationCommand) showOperationsDetails(orchestrationID string) e
 	return nil
 }
 
+func (cmd *OrchestrationCommand) showManifest(orchestrationID string) error {
+	sr, err := cmd.client.GetOrchestration(orchestrationID)
+	if err != nil {
+		return errors.Wrap(err, "while getting orchestration")
+	}
+	if sr.Parameters.Manifest == "" {
+		return fmt.Errorf("orchestration %s was not applied from a manifest", orchestrationID)
+	}
+	fmt.Print(sr.Parameters.Manifest)
+	return nil
+}
+
 func (cmd *OrchestrationCommand) cancelOrchestration(orchestrationID string) error {
 	sr, err := cmd.client.GetOrchestration(orchestrationID)
 	if err != nil {
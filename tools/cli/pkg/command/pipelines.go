@@ -0,0 +1,152 @@
+package command
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/pipeline"
+	"github.com/kyma-project/control-plane/tools/cli/pkg/logger"
+	"github.com/kyma-project/control-plane/tools/cli/pkg/printer"
+)
+
+// PipelinesCommand represents an execution of the kcp pipelines command
+type PipelinesCommand struct {
+	log        logger.Logger
+	client     pipeline.Client
+	output     string
+	listParams pipeline.ListParameters
+}
+
+// pipelineRow is a step of a pipeline displayed in one row of the table
+type pipelineRow struct {
+	OperationType string
+	Stage         string
+	Step          string
+	Plans         string
+	Condition     string
+	Input         string
+}
+
+var pipelineColumns = []printer.Column{
+	{
+		Header:    "OPERATION TYPE",
+		FieldSpec: "{.OperationType}",
+	},
+	{
+		Header:    "STAGE",
+		FieldSpec: "{.Stage}",
+	},
+	{
+		Header:    "STEP",
+		FieldSpec: "{.Step}",
+	},
+	{
+		Header:    "PLANS",
+		FieldSpec: "{.Plans}",
+	},
+	{
+		Header:    "CONDITION",
+		FieldSpec: "{.Condition}",
+	},
+	{
+		Header:    "INPUT",
+		FieldSpec: "{.Input}",
+	},
+}
+
+// NewPipelinesCmd constructs a new instance of PipelinesCommand and configures it in terms of a cobra.Command
+func NewPipelinesCmd() *cobra.Command {
+	cmd := PipelinesCommand{}
+	cobraCmd := &cobra.Command{
+		Use:     "pipelines [<operation type>]",
+		Aliases: []string{"pipeline"},
+		Short:   "Displays the stages and steps which process the KEB operations.",
+		Long: `Displays the stages and steps which process the operations of each type in the running KEB, as configured in its deployment.
+The steps of the integrations disabled in the configuration are not displayed. The plans and the condition under which a step runs are displayed next to it.
+With the --plan option only the steps run for the plan are displayed. The compensation steps which undo a stage of a canceled operation are marked as compensations.`,
+		Example: `  kcp pipelines                           Display the pipelines of all operation types.
+  kcp pipelines provision --plan azure    Display the steps which provision an Azure Runtime.`,
+		Args:    cobra.MaximumNArgs(1),
+		PreRunE: func(_ *cobra.Command, _ []string) error { return ValidateOutputOpt(cmd.output) },
+		RunE:    func(cobraCmd *cobra.Command, args []string) error { return cmd.Run(cobraCmd, args) },
+	}
+	SetOutputOpt(cobraCmd, &cmd.output)
+	cobraCmd.Flags().StringVar(&cmd.listParams.Plan, "plan", "", "Display only the steps run for the plan with the given name, e.g. azure.")
+	return cobraCmd
+}
+
+// Run executes the pipelines command
+func (cmd *PipelinesCommand) Run(cobraCmd *cobra.Command, args []string) error {
+	cmd.log = logger.New()
+	cmd.client = pipeline.NewClient(cobraCmd.Context(), GlobalOpts.KEBAPIURL(), CLICredentialManager(cmd.log))
+	if len(args) > 0 {
+		cmd.listParams.OperationType = args[0]
+	}
+
+	list, err := cmd.client.ListPipelines(cmd.listParams)
+	if err != nil {
+		return errors.Wrap(err, "while listing pipelines")
+	}
+
+	switch {
+	case cmd.output == tableOutput:
+		tp, err := printer.NewTablePrinter(pipelineColumns, false)
+		if err != nil {
+			return err
+		}
+		return tp.PrintObj(pipelineRows(list.Data))
+	case cmd.output == jsonOutput:
+		jp := printer.NewJSONPrinter("  ")
+		jp.PrintObj(list)
+	case strings.HasPrefix(cmd.output, customOutput):
+		_, templateFile := printer.ParseOutputToTemplateTypeAndElement(cmd.output)
+		column, err := printer.ParseColumnToHeaderAndFieldSpec(templateFile)
+		if err != nil {
+			return err
+		}
+		ccp, err := printer.NewTablePrinter(column, false)
+		if err != nil {
+			return err
+		}
+		return ccp.PrintObj(pipelineRows(list.Data))
+	}
+	return nil
+}
+
+// pipelineRows flattens the pipelines to one row per step, the compensations follow the steps of their stage
+func pipelineRows(pipelines []pipeline.PipelineDTO) []pipelineRow {
+	var rows []pipelineRow
+	for _, p := range pipelines {
+		for _, stage := range p.Stages {
+			for _, step := range stage.Steps {
+				rows = append(rows, stepRow(p.OperationType, stage.Name, step, ""))
+			}
+			for _, step := range stage.Compensations {
+				rows = append(rows, stepRow(p.OperationType, stage.Name, step, " (compensation)"))
+			}
+		}
+	}
+	return rows
+}
+
+func stepRow(operationType, stage string, step pipeline.StepDTO, suffix string) pipelineRow {
+	row := pipelineRow{
+		OperationType: operationType,
+		Stage:         stage,
+		Step:          step.Name + suffix,
+		Condition:     step.Condition,
+		Input:         step.Input,
+	}
+	if step.Rerun {
+		row.Step += " (rerun)"
+	}
+	switch {
+	case len(step.OnlyPlans) > 0:
+		row.Plans = strings.Join(step.OnlyPlans, ",")
+	case len(step.ExceptPlans) > 0:
+		row.Plans = "all except " + strings.Join(step.ExceptPlans, ",")
+	}
+	return row
+}
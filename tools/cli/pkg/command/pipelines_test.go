@@ -0,0 +1,44 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/pipeline"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPipelineRows(t *testing.T) {
+	// given
+	pipelines := []pipeline.PipelineDTO{
+		{
+			OperationType: "provision",
+			Stages: []pipeline.StageDTO{
+				{
+					Name: "create_runtime",
+					Steps: []pipeline.StepDTO{
+						{Name: "Provision_Initialization", Input: "creates"},
+						{Name: "Create_Runtime_Without_Kyma", Input: "needs", ExceptPlans: []string{"own_cluster"}},
+						{Name: "Create_Runtime_For_Own_Cluster", OnlyPlans: []string{"own_cluster"}},
+					},
+					Compensations: []pipeline.StepDTO{{Name: "Remove_Runtime", Condition: "the runtime was created"}},
+				},
+			},
+		},
+		{
+			OperationType: "upgradeKyma",
+			Stages:        []pipeline.StageDTO{{Name: "check_kyma", Steps: []pipeline.StepDTO{{Name: "Upgrade_Kyma_Initialisation", Rerun: true}}}},
+		},
+	}
+
+	// when
+	rows := pipelineRows(pipelines)
+
+	// then
+	assert.Equal(t, []pipelineRow{
+		{OperationType: "provision", Stage: "create_runtime", Step: "Provision_Initialization", Input: "creates"},
+		{OperationType: "provision", Stage: "create_runtime", Step: "Create_Runtime_Without_Kyma", Plans: "all except own_cluster", Input: "needs"},
+		{OperationType: "provision", Stage: "create_runtime", Step: "Create_Runtime_For_Own_Cluster", Plans: "own_cluster"},
+		{OperationType: "provision", Stage: "create_runtime", Step: "Remove_Runtime (compensation)", Condition: "the runtime was created"},
+		{OperationType: "upgradeKyma", Stage: "check_kyma", Step: "Upgrade_Kyma_Initialisation (rerun)"},
+	}, rows)
+}
@@ -0,0 +1,136 @@
+package command
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/pools"
+	"github.com/kyma-project/control-plane/tools/cli/pkg/logger"
+	"github.com/kyma-project/control-plane/tools/cli/pkg/printer"
+)
+
+// PoolsCommand represents an execution of the kcp pools command
+type PoolsCommand struct {
+	log        logger.Logger
+	client     pools.Client
+	output     string
+	listParams pools.ListParameters
+}
+
+var poolColumns = []printer.Column{
+	{
+		Header:    "TYPE",
+		FieldSpec: "{.HyperscalerType}",
+	},
+	{
+		Header:    "EU ACCESS",
+		FieldSpec: "{.EuAccess}",
+	},
+	{
+		Header:    "FREE",
+		FieldSpec: "{.Free}",
+	},
+	{
+		Header:    "USED",
+		FieldSpec: "{.Used}",
+	},
+	{
+		Header:    "DIRTY",
+		FieldSpec: "{.Dirty}",
+	},
+	{
+		Header:    "INTERNAL",
+		FieldSpec: "{.Internal}",
+	},
+	{
+		Header:    "SHARED",
+		FieldSpec: "{.Shared}",
+	},
+	{
+		Header: "SHARED SHOOTS",
+		FieldFormatter: func(obj interface{}) string {
+			pool := obj.(pools.PoolDTO)
+			if pool.MaxShootsPerSharedAccount == 0 {
+				return strconv.Itoa(pool.SharedShoots)
+			}
+			return fmt.Sprintf("%d/%d", pool.SharedShoots, pool.Shared*pool.MaxShootsPerSharedAccount)
+		},
+	},
+	{
+		Header:    "CUSTOMER",
+		FieldSpec: "{.CustomerAccounts}",
+	},
+	{
+		Header: "LOW WATERMARK",
+		FieldFormatter: func(obj interface{}) string {
+			pool := obj.(pools.PoolDTO)
+			switch {
+			case pool.LowWatermark == 0:
+				return "-"
+			case pool.BelowLowWatermark:
+				return fmt.Sprintf("%d (below)", pool.LowWatermark)
+			default:
+				return strconv.Itoa(pool.LowWatermark)
+			}
+		},
+	},
+}
+
+// NewPoolsCmd constructs a new instance of PoolsCommand and configures it in terms of a cobra.Command
+func NewPoolsCmd() *cobra.Command {
+	cmd := PoolsCommand{}
+	cobraCmd := &cobra.Command{
+		Use:     "pools",
+		Aliases: []string{"pool"},
+		Short:   "Displays the capacity of the hyperscaler account pools.",
+		Long: `Displays the number of free, used, dirty and internal secret bindings of the hyperscaler account pool per hyperscaler type and EU access.
+The shared secret bindings, the shoots using them and the secret bindings with customer credentials are displayed separately.
+Pools with less free secret bindings than the configured low watermark are marked as below.`,
+		Example: `  kcp pools                  Display all hyperscaler account pools.
+  kcp pools -t azure,aws     Display the Azure and AWS hyperscaler account pools.`,
+		PreRunE: func(_ *cobra.Command, _ []string) error { return ValidateOutputOpt(cmd.output) },
+		RunE:    func(cobraCmd *cobra.Command, _ []string) error { return cmd.Run(cobraCmd) },
+	}
+	SetOutputOpt(cobraCmd, &cmd.output)
+	cobraCmd.Flags().StringSliceVarP(&cmd.listParams.HyperscalerTypes, "type", "t", nil, "Filter by hyperscaler type. You can provide multiple values, either separated by a comma (e.g. azure,aws), or by specifying the option multiple times.")
+	return cobraCmd
+}
+
+// Run executes the pools command
+func (cmd *PoolsCommand) Run(cobraCmd *cobra.Command) error {
+	cmd.log = logger.New()
+	cmd.client = pools.NewClient(cobraCmd.Context(), GlobalOpts.KEBAPIURL(), CLICredentialManager(cmd.log))
+
+	list, err := cmd.client.ListPools(cmd.listParams)
+	if err != nil {
+		return errors.Wrap(err, "while listing hyperscaler account pools")
+	}
+
+	switch {
+	case cmd.output == tableOutput:
+		tp, err := printer.NewTablePrinter(poolColumns, false)
+		if err != nil {
+			return err
+		}
+		return tp.PrintObj(list.Data)
+	case cmd.output == jsonOutput:
+		jp := printer.NewJSONPrinter("  ")
+		jp.PrintObj(list)
+	case strings.HasPrefix(cmd.output, customOutput):
+		_, templateFile := printer.ParseOutputToTemplateTypeAndElement(cmd.output)
+		column, err := printer.ParseColumnToHeaderAndFieldSpec(templateFile)
+		if err != nil {
+			return err
+		}
+		ccp, err := printer.NewTablePrinter(column, false)
+		if err != nil {
+			return err
+		}
+		return ccp.PrintObj(list.Data)
+	}
+	return nil
+}
@@ -0,0 +1,256 @@
+package command
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/quota"
+	"github.com/kyma-project/control-plane/tools/cli/pkg/logger"
+	"github.com/kyma-project/control-plane/tools/cli/pkg/printer"
+)
+
+const (
+	maxInstancesFlag     = "max-instances"
+	maxAutoScalerMaxFlag = "max-autoscaler-max"
+	maxVolumeSizeFlag    = "max-volume-size"
+)
+
+// QuotasCommand represents an execution of the kcp quotas command and its subcommands
+type QuotasCommand struct {
+	log        logger.Logger
+	client     quota.Client
+	output     string
+	listParams quota.ListParameters
+
+	plan             string
+	maxInstances     int
+	maxAutoScalerMax int
+	maxVolumeSizeGb  int
+}
+
+var quotaColumns = []printer.Column{
+	{
+		Header:    "GLOBALACCOUNT",
+		FieldSpec: "{.GlobalAccountID}",
+	},
+	{
+		Header:    "PLAN",
+		FieldSpec: "{.PlanName}",
+	},
+	{
+		Header:         "MAX INSTANCES",
+		FieldFormatter: func(obj interface{}) string { return formatLimit(obj.(quota.QuotaDTO).MaxInstances) },
+	},
+	{
+		Header:         "MAX AUTOSCALER MAX",
+		FieldFormatter: func(obj interface{}) string { return formatLimit(obj.(quota.QuotaDTO).MaxAutoScalerMax) },
+	},
+	{
+		Header:         "MAX VOLUME SIZE GB",
+		FieldFormatter: func(obj interface{}) string { return formatLimit(obj.(quota.QuotaDTO).MaxVolumeSizeGb) },
+	},
+}
+
+var usageColumns = []printer.Column{
+	{
+		Header:    "PLAN",
+		FieldSpec: "{.PlanName}",
+	},
+	{
+		Header:    "QUOTA OF",
+		FieldSpec: "{.Quota.GlobalAccountID}",
+	},
+	{
+		Header: "INSTANCES",
+		FieldFormatter: func(obj interface{}) string {
+			usage := obj.(quota.UsageDTO)
+			return formatUsage(usage.Instances, usage.Quota.MaxInstances)
+		},
+	},
+	{
+		Header: "AUTOSCALER MAX",
+		FieldFormatter: func(obj interface{}) string {
+			usage := obj.(quota.UsageDTO)
+			return formatUsage(usage.AutoScalerMax, usage.Quota.MaxAutoScalerMax)
+		},
+	},
+	{
+		Header: "VOLUME SIZE GB",
+		FieldFormatter: func(obj interface{}) string {
+			usage := obj.(quota.UsageDTO)
+			return formatUsage(usage.VolumeSizeGb, usage.Quota.MaxVolumeSizeGb)
+		},
+	},
+}
+
+// NewQuotasCmd constructs a new instance of QuotasCommand and configures it in terms of a cobra.Command
+func NewQuotasCmd() *cobra.Command {
+	cmd := QuotasCommand{}
+	cobraCmd := &cobra.Command{
+		Use:     "quotas",
+		Aliases: []string{"quota", "q"},
+		Short:   "Displays and manages the quotas of global accounts.",
+		Long: `Displays the quotas which limit the number of Runtimes, the total autoscaler maximum and the total volume size of a global account in a plan.
+The global account ` + "`*`" + ` defines the default quota for all global accounts without their own quota, and the plan ` + "`all_plans`" + ` limits the sum over all plans.
+Provisioning and update requests exceeding a quota are rejected by KEB.`,
+		Example: `  kcp quotas                                      Display all quotas.
+  kcp quotas -g GAID1 -p aws                      Display the quota of the global account in the aws plan.`,
+		PreRunE: func(_ *cobra.Command, _ []string) error { return ValidateOutputOpt(cmd.output) },
+		RunE:    func(cobraCmd *cobra.Command, _ []string) error { return cmd.showQuotas(cobraCmd) },
+	}
+	SetOutputOpt(cobraCmd, &cmd.output)
+	cobraCmd.Flags().StringSliceVarP(&cmd.listParams.GlobalAccountIDs, "account", "g", nil, "Filter by global account ID. You can provide multiple values, either separated by a comma (e.g. GAID1,GAID2), or by specifying the option multiple times.")
+	cobraCmd.Flags().StringSliceVarP(&cmd.listParams.Plans, "plan", "p", nil, "Filter by service plan name. You can provide multiple values, either separated by a comma (e.g. azure,aws), or by specifying the option multiple times.")
+
+	cobraCmd.AddCommand(cmd.newSetCmd(), cmd.newDeleteCmd(), cmd.newUsageCmd())
+	return cobraCmd
+}
+
+func (cmd *QuotasCommand) newSetCmd() *cobra.Command {
+	cobraCmd := &cobra.Command{
+		Use:   "set <global account ID>",
+		Short: "Sets the quota of a global account in a plan.",
+		Long: `Creates or replaces the quota of a global account in a plan. Only the limits given as options are enforced, the other limits of an existing quota are removed.
+Use ` + "`*`" + ` as the global account ID to set the default quota.`,
+		Example: `  kcp quotas set GAID1 --plan aws --max-instances 5 --max-autoscaler-max 40   Allow up to 5 Runtimes with 40 nodes in total in the aws plan.
+  kcp quotas set '*' --plan all_plans --max-instances 10                      Allow up to 10 Runtimes for every global account without own quota.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cobraCmd *cobra.Command, args []string) error { return cmd.setQuota(cobraCmd, args[0]) },
+	}
+	cobraCmd.Flags().StringVarP(&cmd.plan, "plan", "p", "", "Service plan name, or all_plans to limit the sum over all plans.")
+	cobraCmd.Flags().IntVar(&cmd.maxInstances, maxInstancesFlag, 0, "Maximum number of Runtimes.")
+	cobraCmd.Flags().IntVar(&cmd.maxAutoScalerMax, maxAutoScalerMaxFlag, 0, "Maximum sum of the autoscaler maximum of the Runtimes.")
+	cobraCmd.Flags().IntVar(&cmd.maxVolumeSizeGb, maxVolumeSizeFlag, 0, "Maximum sum of the volume size of the Runtimes in GB.")
+	_ = cobraCmd.MarkFlagRequired("plan")
+	return cobraCmd
+}
+
+func (cmd *QuotasCommand) newDeleteCmd() *cobra.Command {
+	cobraCmd := &cobra.Command{
+		Use:     "delete <global account ID>",
+		Short:   "Deletes the quota of a global account in a plan.",
+		Long:    `Deletes the quota of a global account in a plan. The default quota applies to the global account afterwards, if it exists.`,
+		Example: `  kcp quotas delete GAID1 --plan aws   Delete the quota of the global account in the aws plan.`,
+		Args:    cobra.ExactArgs(1),
+		RunE:    func(cobraCmd *cobra.Command, args []string) error { return cmd.deleteQuota(cobraCmd, args[0]) },
+	}
+	cobraCmd.Flags().StringVarP(&cmd.plan, "plan", "p", "", "Service plan name, or all_plans.")
+	_ = cobraCmd.MarkFlagRequired("plan")
+	return cobraCmd
+}
+
+func (cmd *QuotasCommand) newUsageCmd() *cobra.Command {
+	cobraCmd := &cobra.Command{
+		Use:     "usage <global account ID>",
+		Short:   "Displays the usage of the quotas of a global account.",
+		Long:    `Displays the resources used by the Runtimes of a global account compared with the quotas in effect.`,
+		Example: `  kcp quotas usage GAID1   Display the quota usage of the global account.`,
+		Args:    cobra.ExactArgs(1),
+		PreRunE: func(_ *cobra.Command, _ []string) error { return ValidateOutputOpt(cmd.output) },
+		RunE:    func(cobraCmd *cobra.Command, args []string) error { return cmd.showUsage(cobraCmd, args[0]) },
+	}
+	SetOutputOpt(cobraCmd, &cmd.output)
+	return cobraCmd
+}
+
+// initClient creates the client in the context of the executed command, which is either the quotas command or one of its subcommands
+func (cmd *QuotasCommand) initClient(cobraCmd *cobra.Command) {
+	cmd.log = logger.New()
+	cmd.client = quota.NewClient(cobraCmd.Context(), GlobalOpts.KEBAPIURL(), CLICredentialManager(cmd.log))
+}
+
+func (cmd *QuotasCommand) showQuotas(cobraCmd *cobra.Command) error {
+	cmd.initClient(cobraCmd)
+	quotas, err := cmd.client.ListQuotas(cmd.listParams)
+	if err != nil {
+		return errors.Wrap(err, "while listing quotas")
+	}
+	return cmd.print(quotaColumns, quotas, quotas.Data)
+}
+
+func (cmd *QuotasCommand) setQuota(cobraCmd *cobra.Command, globalAccountID string) error {
+	limits := quota.LimitsDTO{}
+	for flag, limit := range map[string]**int{
+		maxInstancesFlag:     &limits.MaxInstances,
+		maxAutoScalerMaxFlag: &limits.MaxAutoScalerMax,
+		maxVolumeSizeFlag:    &limits.MaxVolumeSizeGb,
+	} {
+		if cobraCmd.Flags().Changed(flag) {
+			value, err := cobraCmd.Flags().GetInt(flag)
+			if err != nil {
+				return err
+			}
+			*limit = &value
+		}
+	}
+	if limits.MaxInstances == nil && limits.MaxAutoScalerMax == nil && limits.MaxVolumeSizeGb == nil {
+		return fmt.Errorf("at least one of --%s, --%s or --%s must be provided", maxInstancesFlag, maxAutoScalerMaxFlag, maxVolumeSizeFlag)
+	}
+
+	cmd.initClient(cobraCmd)
+	saved, err := cmd.client.SetQuota(globalAccountID, cmd.plan, limits)
+	if err != nil {
+		return errors.Wrap(err, "while setting quota")
+	}
+	fmt.Printf("Quota of global account %s in plan %s set.\n", saved.GlobalAccountID, saved.PlanName)
+	return nil
+}
+
+func (cmd *QuotasCommand) deleteQuota(cobraCmd *cobra.Command, globalAccountID string) error {
+	cmd.initClient(cobraCmd)
+	if err := cmd.client.DeleteQuota(globalAccountID, cmd.plan); err != nil {
+		return errors.Wrap(err, "while deleting quota")
+	}
+	fmt.Printf("Quota of global account %s in plan %s deleted.\n", globalAccountID, cmd.plan)
+	return nil
+}
+
+func (cmd *QuotasCommand) showUsage(cobraCmd *cobra.Command, globalAccountID string) error {
+	cmd.initClient(cobraCmd)
+	usage, err := cmd.client.GetUsage(globalAccountID)
+	if err != nil {
+		return errors.Wrap(err, "while getting quota usage")
+	}
+	return cmd.print(usageColumns, usage, usage.Data)
+}
+
+func (cmd *QuotasCommand) print(columns []printer.Column, obj interface{}, rows interface{}) error {
+	switch {
+	case cmd.output == tableOutput:
+		tp, err := printer.NewTablePrinter(columns, false)
+		if err != nil {
+			return err
+		}
+		return tp.PrintObj(rows)
+	case cmd.output == jsonOutput:
+		jp := printer.NewJSONPrinter("  ")
+		jp.PrintObj(obj)
+	case strings.HasPrefix(cmd.output, customOutput):
+		_, templateFile := printer.ParseOutputToTemplateTypeAndElement(cmd.output)
+		column, err := printer.ParseColumnToHeaderAndFieldSpec(templateFile)
+		if err != nil {
+			return err
+		}
+		ccp, err := printer.NewTablePrinter(column, false)
+		if err != nil {
+			return err
+		}
+		return ccp.PrintObj(rows)
+	}
+	return nil
+}
+
+func formatLimit(limit *int) string {
+	if limit == nil {
+		return "-"
+	}
+	return strconv.Itoa(*limit)
+}
+
+func formatUsage(used int, limit *int) string {
+	return fmt.Sprintf("%d/%s", used, formatLimit(limit))
+}
@@ -0,0 +1,149 @@
+package command
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"golang.org/x/oauth2"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/operation"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/runtime"
+	"github.com/kyma-project/control-plane/tools/cli/pkg/credential"
+	"github.com/kyma-project/control-plane/tools/cli/pkg/logger"
+	"github.com/kyma-project/control-plane/tools/cli/pkg/printer"
+)
+
+const recreationPollInterval = 30 * time.Second
+
+// RecreateCommand represents an execution of the kcp recreate command
+type RecreateCommand struct {
+	cobraCmd    *cobra.Command
+	log         logger.Logger
+	client      operation.Client
+	output      string
+	reason      string
+	force       bool
+	wait        bool
+	waitTimeout time.Duration
+}
+
+// NewRecreateCmd constructs a new instance of RecreateCommand and configures it in terms of a cobra.Command
+func NewRecreateCmd() *cobra.Command {
+	cmd := RecreateCommand{}
+	cobraCmd := &cobra.Command{
+		Use:   "recreate <runtime>",
+		Short: "Recreates a Kyma Runtime.",
+		Long: `Deprovisions a Kyma Runtime and provisions it again with the same provisioning parameters, instance ID, and subaccount.
+The Runtime can be specified by its Runtime ID, Shoot cluster name, or instance ID. KEB drives both operations: the provisioning starts when the deprovisioning is finished.
+The request is recorded in the events of the deprovisioning operation with your identity.`,
+		Example: `  kcp recreate c-178e034 --reason "disaster recovery"   Recreate the Runtime with the given Shoot cluster name.
+  kcp recreate c-178e034 --wait                          Recreate the Runtime and wait until it is provisioned again.`,
+		Args:    cobra.ExactArgs(1),
+		PreRunE: func(_ *cobra.Command, _ []string) error { return ValidateOutputOpt(cmd.output) },
+		RunE:    func(_ *cobra.Command, args []string) error { return cmd.Run(args[0]) },
+	}
+	cmd.cobraCmd = cobraCmd
+
+	SetOutputOpt(cobraCmd, &cmd.output)
+	cobraCmd.Flags().StringVarP(&cmd.reason, "reason", "r", "", "Reason of the recreation, recorded in the events of the deprovisioning operation.")
+	cobraCmd.Flags().BoolVarP(&cmd.force, "force", "f", false, "Recreate the Runtime without the confirmation.")
+	cobraCmd.Flags().BoolVarP(&cmd.wait, "wait", "w", false, "Wait until the provisioning of the recreated Runtime is finished.")
+	cobraCmd.Flags().DurationVar(&cmd.waitTimeout, "wait-timeout", 3*time.Hour, "Maximum time to wait for the recreation with the --wait option.")
+	return cobraCmd
+}
+
+// Run executes the recreate command
+func (cmd *RecreateCommand) Run(runtimeSelector string) error {
+	cmd.log = logger.New()
+	cred := CLICredentialManager(cmd.log)
+	cmd.client = operation.NewClient(cmd.cobraCmd.Context(), GlobalOpts.KEBAPIURL(), cred)
+
+	rt, err := cmd.resolveRuntime(runtimeSelector, cred)
+	if err != nil {
+		return err
+	}
+	if !cmd.force && !promptUser(fmt.Sprintf("Runtime %s (shoot %s, subaccount %s) will be deprovisioned and provisioned again. Are you sure you want to continue? ", rt.RuntimeID, rt.ShootName, rt.SubAccountID)) {
+		return errors.New("recreate command aborted")
+	}
+
+	recreation, err := cmd.client.Recreate(rt.InstanceID, operation.ActionDTO{Reason: cmd.reason})
+	if err != nil {
+		return errors.Wrap(err, "while requesting recreation")
+	}
+	if cmd.wait {
+		if recreation, err = cmd.waitForRecreation(recreation); err != nil {
+			return err
+		}
+	}
+	return cmd.print(recreation)
+}
+
+// resolveRuntime finds the Runtime by the Runtime ID, the Shoot cluster name, or the instance ID
+func (cmd *RecreateCommand) resolveRuntime(selector string, cred credential.Manager) (runtime.RuntimeDTO, error) {
+	rtClient := runtime.NewClient(GlobalOpts.KEBAPIURL(), oauth2.NewClient(cmd.cobraCmd.Context(), cred))
+	for _, params := range []runtime.ListParameters{
+		{RuntimeIDs: []string{selector}},
+		{Shoots: []string{selector}},
+		{InstanceIDs: []string{selector}},
+	} {
+		rp, err := rtClient.ListRuntimes(params)
+		if err != nil {
+			return runtime.RuntimeDTO{}, errors.Wrap(err, "while listing runtimes")
+		}
+		if rp.Count > 1 {
+			return runtime.RuntimeDTO{}, fmt.Errorf("multiple runtimes (%d) matched %s", rp.Count, selector)
+		}
+		if rp.Count == 1 {
+			return rp.Data[0], nil
+		}
+	}
+	return runtime.RuntimeDTO{}, fmt.Errorf("no runtime matched %s", selector)
+}
+
+// waitForRecreation polls the recreation until the provisioning or the deprovisioning is finished
+func (cmd *RecreateCommand) waitForRecreation(recreation operation.RecreationDTO) (operation.RecreationDTO, error) {
+	deadline := time.Now().Add(cmd.waitTimeout)
+	last := ""
+	for {
+		state := fmt.Sprintf("deprovisioning %s: %s", recreation.DeprovisioningOperationID, recreation.DeprovisioningState)
+		if recreation.ProvisioningOperationID != "" {
+			state = fmt.Sprintf("provisioning %s: %s", recreation.ProvisioningOperationID, recreation.ProvisioningState)
+		}
+		if state != last {
+			cmd.log.Infof("Recreation of instance %s, %s", recreation.InstanceID, state)
+			last = state
+		}
+		switch {
+		case recreation.DeprovisioningState == "failed":
+			return recreation, fmt.Errorf("deprovisioning operation %s failed", recreation.DeprovisioningOperationID)
+		case recreation.ProvisioningState == "failed":
+			return recreation, fmt.Errorf("provisioning operation %s failed", recreation.ProvisioningOperationID)
+		case recreation.ProvisioningState == "succeeded":
+			return recreation, nil
+		}
+		if time.Now().After(deadline) {
+			return recreation, fmt.Errorf("timed out waiting for the recreation of instance %s", recreation.InstanceID)
+		}
+		time.Sleep(recreationPollInterval)
+
+		var err error
+		recreation, err = cmd.client.GetRecreation(recreation.InstanceID)
+		if err != nil {
+			return recreation, errors.Wrap(err, "while getting recreation")
+		}
+	}
+}
+
+func (cmd *RecreateCommand) print(recreation operation.RecreationDTO) error {
+	if cmd.output == jsonOutput {
+		printer.NewJSONPrinter("  ").PrintObj(recreation)
+		return nil
+	}
+	fmt.Printf("Deprovisioning operation %s of instance %s is %s\n", recreation.DeprovisioningOperationID, recreation.InstanceID, recreation.DeprovisioningState)
+	if recreation.ProvisioningOperationID != "" {
+		fmt.Printf("Provisioning operation %s is %s\n", recreation.ProvisioningOperationID, recreation.ProvisioningState)
+	}
+	return nil
+}
@@ -54,10 +54,17 @@ See the **Global Options** section of each command for the description of these
 		NewOrchestrationCmd(),
 		NewKubeconfigCmd(),
 		NewUpgradeCmd(),
+		NewApplyCmd(),
 		NewTaskRunCmd(),
 		NewCompletionCommand(),
 		NewReconciliationCmd(),
 		NewDeprovisionCmd(),
+		NewRecreateCmd(),
+		NewAccessCmd(),
+		NewDashboardCmd(),
+		NewQuotasCmd(),
+		NewPoolsCmd(),
+		NewPipelinesCmd(),
 	)
 	return cmd
 }
@@ -2,6 +2,7 @@ package command
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -24,6 +25,7 @@ import (
 	"github.com/kyma-project/control-plane/tools/cli/pkg/credential"
 	"github.com/kyma-project/control-plane/tools/cli/pkg/logger"
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -42,8 +44,14 @@ type TaskRunCommand struct {
 	keepKubeconfigs     bool
 	noKubeconfig        bool
 	noPrefixOutput      bool
-	taskCommand         *exec.Cmd
+	taskArgs            []string
 	shell               string
+	journalPath         string
+	resume              string
+	reportFile          string
+	reportFormat        string
+	timeout             time.Duration
+	journal             *TaskRunJournal
 }
 
 // RuntimeLister implements the interface to obtains runtimes info from KEB for resolver
@@ -92,7 +100,10 @@ For each subprocess, the following Runtime-specific data are passed as environme
   - RUNTIME_ID       : Runtime ID of the Runtime
   - INSTANCE_ID      : Instance ID of the Runtime
 
-  If all subprocesses finish successfully with the zero status code, the exit status is zero (0). If one or more subprocesses exit with a non-zero status, the command will also exit with a non-zero status.`,
+  If all subprocesses finish successfully with the zero status code, the exit status is zero (0). If one or more subprocesses exit with a non-zero status, the command will also exit with a non-zero status.
+
+The exit code, duration, and captured output of the task on each Runtime are recorded in a run journal, by default in $HOME/.kcp/taskrun.
+Pass the journal to the --resume option to rerun the task only on the Runtimes where it failed, timed out, or has not finished.`,
 		Example: `  kcp taskrun --target all -- kubectl patch deployment valid-deployment -p '{"metadata":{"labels":{"my-label": "my-value"}}}'
     Execute a kubectl patch operation for all Runtimes.
   kcp taskrun --target account=CA4836781TID000000000123456789 /usr/local/bin/awesome-script.sh
@@ -100,8 +111,12 @@ For each subprocess, the following Runtime-specific data are passed as environme
   kcp taskrun --target all -- helm upgrade -i -n kyma-system my-kyma-addon --values overrides.yaml
     Deploy a Helm chart on all Runtimes.
   kcp taskrun -t all -s "/bin/bash -i -c" -- kc get ns
-    Run an alias command (kc for kubectl) defined in user's .bashrc invocation script`,
-		Args:    cobra.MinimumNArgs(1),
+    Run an alias command (kc for kubectl) defined in user's .bashrc invocation script
+  kcp taskrun --target all --timeout 10m --report report.xml --report-format junit -- ./check.sh
+    Run a check script with a 10 minutes limit per Runtime and write the results as a JUnit report.
+  kcp taskrun --resume ~/.kcp/taskrun/taskrun-20230301-101500.jsonl
+    Rerun the task of an interrupted execution on the Runtimes where it failed or has not finished.`,
+		Args:    cobra.ArbitraryArgs,
 		PreRunE: func(_ *cobra.Command, args []string) error { return cmd.Validate(args) },
 		RunE:    func(_ *cobra.Command, args []string) error { return cmd.Run(args) },
 	}
@@ -113,6 +128,11 @@ For each subprocess, the following Runtime-specific data are passed as environme
 	cobraCmd.Flags().BoolVar(&cmd.keepKubeconfigs, "keep-kubeconfig", false, "Option that allows you to keep downloaded kubeconfig files after execution for caching purposes.")
 	cobraCmd.Flags().BoolVar(&cmd.noKubeconfig, "no-kubeconfig", false, "Option that turns off the downloading and exposure of the kubeconfig file for each Runtime.")
 	cobraCmd.Flags().BoolVar(&cmd.noPrefixOutput, "no-prefix-output", false, "Option that omits the prefixing of each output line with the Runtime name. By default, all output lines are prepended for better traceability.")
+	cobraCmd.Flags().StringVar(&cmd.journalPath, "journal", "", "Path of the run journal file. By default, a new journal is created in the $HOME/.kcp/taskrun directory.")
+	cobraCmd.Flags().StringVar(&cmd.resume, "resume", "", "Path of the run journal of a previous execution. Reruns the recorded task command on the Runtimes where it failed, timed out, or has not finished.")
+	cobraCmd.Flags().StringVar(&cmd.reportFile, "report", "", "Path of the report file with the results of all Runtimes in the journal, written when the execution finishes.")
+	cobraCmd.Flags().StringVar(&cmd.reportFormat, "report-format", jsonReport, "Format of the report file. The possible values are: json, junit.")
+	cobraCmd.Flags().DurationVar(&cmd.timeout, "timeout", 0, "Maximum duration of the task on a single Runtime, e.g. 10m. When exceeded, the whole process group of the task is killed. By default, there is no limit.")
 	cobraCmd.Flags().StringP("shell", "s", "", "Invoke the task command using the given shell and it's options. Useful when the task command uses alias(es) defined in the shell's invocation scripts. Can also be set in the KCP configuration file or with the KCP_SHELL environment variable.")
 	viper.BindPFlag("shell", cobraCmd.Flags().Lookup("shell"))
 	return cobraCmd
@@ -124,24 +144,61 @@ func (cmd *TaskRunCommand) Run(args []string) error {
 	cmd.cred = CLICredentialManager(cmd.log)
 	defer cmd.cleanupTempKubeConfigDir()
 
-	operations, err := cmd.resolveOperations()
-	if err != nil {
-		return err
+	var runtimes []orchestration.Runtime
+	if cmd.journal != nil {
+		if err := cmd.journal.Resume(); err != nil {
+			return err
+		}
+		runtimes = cmd.journal.Unfinished()
+		cmd.log.Infof("Number of runtimes to resume: %d\n", len(runtimes))
+	} else {
+		var err error
+		runtimes, err = cmd.resolveRuntimes()
+		if err != nil {
+			return err
+		}
+		if cmd.journalPath == "" {
+			if cmd.journalPath, err = DefaultJournalPath(); err != nil {
+				return errors.Wrap(err, "while getting default journal path")
+			}
+		}
+		if cmd.journal, err = NewTaskRunJournal(cmd.journalPath, cmd.taskArgs, runtimes); err != nil {
+			return err
+		}
+	}
+	defer cmd.journal.Close()
+	cmd.log.Infof("Run journal: %s\n", cmd.journal.Path())
+
+	operations := make([]orchestration.RuntimeOperation, 0, len(runtimes))
+	for _, rt := range runtimes {
+		operations = append(operations, orchestration.RuntimeOperation{
+			Runtime: rt,
+			ID:      randomString(16),
+		})
 	}
 
 	mgr := NewRuntimeTaskMakager(cmd, operations)
-	strategy := strategies.NewParallelOrchestrationStrategy(mgr, cmd.log, 0)
-	execID, err := strategy.Execute(operations, orchestration.StrategySpec{
-		Type:     orchestration.ParallelStrategy,
-		Schedule: string(orchestration.Immediate),
-		Parallel: orchestration.ParallelStrategySpec{Workers: cmd.parallelism},
-	})
-	if err != nil {
-		return errors.Wrap(err, "while executing task")
+	if len(operations) > 0 {
+		strategy := strategies.NewParallelOrchestrationStrategy(mgr, cmd.log, 0)
+		execID, err := strategy.Execute(operations, orchestration.StrategySpec{
+			Type:     orchestration.ParallelStrategy,
+			Schedule: string(orchestration.Immediate),
+			Parallel: orchestration.ParallelStrategySpec{Workers: cmd.parallelism},
+		})
+		if err != nil {
+			return errors.Wrap(err, "while executing task")
+		}
+		strategy.Wait(execID)
 	}
-	strategy.Wait(execID)
 
-	return mgr.exitStatus()
+	if err := cmd.writeReport(); err != nil {
+		return err
+	}
+	if err := mgr.exitStatus(); err != nil {
+		cmd.log.Infof("To rerun the task on the failed runtimes, use: kcp taskrun --resume %s\n", cmd.journal.Path())
+		return err
+	}
+	return nil
 }
 
 // Validate checks the input parameters of the taskrun command
@@ -151,15 +208,39 @@ func (cmd *TaskRunCommand) Validate(args []string) error {
 		return fmt.Errorf("missing required %s option", GlobalOpts.kubeconfigAPIURL)
 	}
 
-	// Validate gardener-kubeconfig global option
-	if GlobalOpts.GardenerKubeconfig() == "" || GlobalOpts.GardenerNamespace() == "" {
-		return fmt.Errorf("missing required %s/%s options", GlobalOpts.gardenerKubeconfig, GlobalOpts.gardenerNamespace)
+	// Validate report options
+	if cmd.reportFormat != jsonReport && cmd.reportFormat != junitReport {
+		return fmt.Errorf("invalid value for report-format: %s", cmd.reportFormat)
+	}
+	if cmd.timeout < 0 {
+		return fmt.Errorf("invalid value for timeout: %s", cmd.timeout)
 	}
 
-	// Validate target options
-	err := ValidateTransformRuntimeTargetOpts(cmd.targetInputs, cmd.targetExcludeInputs, &cmd.targets)
-	if err != nil {
-		return err
+	if cmd.resume != "" {
+		// Targets and the task command of the resumed execution are taken from the journal
+		if len(cmd.targetInputs) != 0 || len(cmd.targetExcludeInputs) != 0 || len(args) != 0 || cmd.journalPath != "" {
+			return errors.New("target options, journal option, and task command cannot be specified together with the resume option")
+		}
+		journal, err := LoadTaskRunJournal(cmd.resume)
+		if err != nil {
+			return err
+		}
+		cmd.journal = journal
+	} else {
+		if len(args) == 0 {
+			return errors.New("missing task command")
+		}
+
+		// Validate gardener-kubeconfig global option
+		if GlobalOpts.GardenerKubeconfig() == "" || GlobalOpts.GardenerNamespace() == "" {
+			return fmt.Errorf("missing required %s/%s options", GlobalOpts.gardenerKubeconfig, GlobalOpts.gardenerNamespace)
+		}
+
+		// Validate target options
+		err := ValidateTransformRuntimeTargetOpts(cmd.targetInputs, cmd.targetExcludeInputs, &cmd.targets)
+		if err != nil {
+			return err
+		}
 	}
 
 	// Validate kubeconfig directory
@@ -172,6 +253,7 @@ func (cmd *TaskRunCommand) Validate(args []string) error {
 			return fmt.Errorf("%s: not a directory", cmd.kubeconfigDir)
 		}
 	} else if !cmd.noKubeconfig {
+		var err error
 		cmd.kubeconfigDir, err = ioutil.TempDir("", "kubeconfig-")
 		if err != nil {
 			return errors.Wrap(err, "while creating temporary kubeconfig directory")
@@ -180,25 +262,32 @@ func (cmd *TaskRunCommand) Validate(args []string) error {
 	}
 
 	// Validate task command and shell wrapper
-	// Construct task command object
-	cmd.shell = viper.GetString("shell")
-	if cmd.shell != "" {
-		splitSh := strings.Split(cmd.shell, " ")
-		if _, err := exec.LookPath(splitSh[0]); err != nil {
-			return err
-		}
-		allArgs := append(splitSh[1:], strings.Join(args, " "))
-		cmd.taskCommand = exec.CommandContext(cmd.cobraCmd.Context(), splitSh[0], allArgs...)
+	// Construct task command arguments
+	if cmd.journal != nil {
+		// The journal records the task command already wrapped with the shell
+		cmd.taskArgs = cmd.journal.Command()
 	} else {
-		if _, err := exec.LookPath(args[0]); err != nil {
-			return err
+		cmd.shell = viper.GetString("shell")
+		if cmd.shell != "" {
+			splitSh := strings.Split(cmd.shell, " ")
+			cmd.taskArgs = append(splitSh, strings.Join(args, " "))
+		} else {
+			cmd.taskArgs = args
 		}
-		cmd.taskCommand = exec.CommandContext(cmd.cobraCmd.Context(), args[0], args[1:]...)
+	}
+	if _, err := exec.LookPath(cmd.taskArgs[0]); err != nil {
+		return err
 	}
 	return nil
 }
 
-func (cmd *TaskRunCommand) resolveOperations() ([]orchestration.RuntimeOperation, error) {
+func (cmd *TaskRunCommand) resolveRuntimes() ([]orchestration.Runtime, error) {
+	return resolveRuntimeTargets(cmd.cobraCmd.Context(), cmd.cred, cmd.targets, cmd.log, false)
+}
+
+// resolveRuntimeTargets resolves the target specification to the Runtimes with the orchestration resolver, using the Gardener shoots and the Runtimes listed by KEB.
+// The Runtimes which provisioning failed are resolved only if includeFailedProvisioning is set
+func resolveRuntimeTargets(ctx context.Context, cred credential.Manager, targets orchestration.TargetSpec, log logger.Logger, includeFailedProvisioning bool) ([]orchestration.Runtime, error) {
 	gardenCfg, err := gardener.NewGardenerClusterConfig(GlobalOpts.GardenerKubeconfig())
 	if err != nil {
 		return nil, errors.Wrap(err, "while getting Gardener kubeconfig")
@@ -208,24 +297,35 @@ func (cmd *TaskRunCommand) resolveOperations() ([]orchestration.RuntimeOperation
 		return nil, errors.Wrap(err, "while getting Gardener client")
 	}
 
-	httpClient := oauth2.NewClient(cmd.cobraCmd.Context(), cmd.cred)
+	httpClient := oauth2.NewClient(ctx, cred)
 	lister := NewRuntimeLister(runtime.NewClient(GlobalOpts.KEBAPIURL(), httpClient))
-	resolver := orchestration.NewGardenerRuntimeResolver(dynamicGardener, GlobalOpts.GardenerNamespace(), lister, cmd.log)
-	runtimes, err := resolver.Resolve(cmd.targets)
+	resolver := orchestration.NewGardenerRuntimeResolver(dynamicGardener, GlobalOpts.GardenerNamespace(), lister, log)
+	if includeFailedProvisioning {
+		resolver.IncludeFailedProvisioning()
+	}
+	runtimes, err := resolver.Resolve(targets)
 	if err != nil {
 		return nil, errors.Wrap(err, "while resolving targets")
 	}
 
-	cmd.log.Infof("Number of resolved runtimes: %d\n", len(runtimes))
-	operations := make([]orchestration.RuntimeOperation, 0, len(runtimes))
-	for _, rt := range runtimes {
-		operations = append(operations, orchestration.RuntimeOperation{
-			Runtime: rt,
-			ID:      randomString(16),
-		})
+	log.Infof("Number of resolved runtimes: %d\n", len(runtimes))
+	return runtimes, nil
+}
+
+func (cmd *TaskRunCommand) writeReport() error {
+	if cmd.reportFile == "" {
+		return nil
 	}
+	f, err := os.Create(cmd.reportFile)
+	if err != nil {
+		return errors.Wrap(err, "while creating report file")
+	}
+	defer f.Close()
 
-	return operations, nil
+	if err := cmd.journal.Report().WriteReport(f, cmd.reportFormat); err != nil {
+		return errors.Wrap(err, "while writing report")
+	}
+	return nil
 }
 
 func (cmd *TaskRunCommand) cleanupTempKubeConfigDir() error {
@@ -268,19 +368,65 @@ func NewRuntimeTaskMakager(cmd *TaskRunCommand, operations []orchestration.Runti
 	return mgr
 }
 
-// Execute runs the task on the runtime identified by the operationID
+// Execute runs the task on the runtime identified by the operationID and records the result in the journal
 func (mgr *RuntimeTaskMakager) Execute(operationID string) (time.Duration, error) {
 	task := mgr.tasks[operationID]
 	log := mgr.cmd.log.WithField("shoot", task.operation.ShootName)
 
+	startedAt := time.Now()
+	record := TaskRecord{
+		Runtime:   task.operation.Runtime,
+		Status:    TaskRunning,
+		ExitCode:  -1,
+		StartedAt: &startedAt,
+	}
+	mgr.record(log, record)
+
+	output := &tailBuffer{max: maxCapturedOutput}
+	timedOut, err := mgr.run(task, log, output)
+	task.result = err
+
+	finishedAt := time.Now()
+	record.FinishedAt = &finishedAt
+	record.Duration = finishedAt.Sub(startedAt)
+	record.Output = output.String()
+	switch {
+	case err == nil:
+		record.Status = TaskSucceeded
+		record.ExitCode = 0
+	case timedOut:
+		record.Status = TaskTimedOut
+	default:
+		record.Status = TaskFailed
+	}
+	if err != nil {
+		record.Error = err.Error()
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			record.ExitCode = exitErr.ExitCode()
+		}
+	}
+	mgr.record(log, record)
+
+	return 0, err
+}
+
+func (mgr *RuntimeTaskMakager) record(log logrus.FieldLogger, record TaskRecord) {
+	if err := mgr.cmd.journal.Record(record); err != nil {
+		log.Errorf("Error: while recording task in journal: %s\n", err)
+	}
+}
+
+// run executes the task command and returns true if it was killed after exceeding the timeout
+func (mgr *RuntimeTaskMakager) run(task *RuntimeTask, log logrus.FieldLogger, output io.Writer) (bool, error) {
 	kubeconfigPath, err := mgr.getKubeconfig(task)
 	if err != nil {
 		log.Errorf("Error: while getting kubeconfig: %s\n", err.Error())
-		task.result = err
-		return 0, err
+		return false, errors.Wrap(err, "while getting kubeconfig")
 	}
 
-	command := *mgr.cmd.taskCommand
+	command := exec.Command(mgr.cmd.taskArgs[0], mgr.cmd.taskArgs[1:]...)
+	setProcessGroup(command)
 
 	// Prepare environment variables
 	command.Env = os.Environ()
@@ -299,17 +445,15 @@ func (mgr *RuntimeTaskMakager) Execute(operationID string) (time.Duration, error
 	stdout, err := command.StdoutPipe()
 	if err != nil {
 		log.Errorf("Error: while creating stdout: %s\n", err.Error())
-		task.result = err
-		return 0, err
+		return false, err
 	}
 	stderr, err := command.StderrPipe()
 	if err != nil {
 		log.Errorf("Error: while creating stderr: %s\n", err.Error())
-		task.result = err
-		return 0, err
+		return false, err
 	}
 
-	// Prepare echoer stdout / stderr writers
+	// Prepare echoer stdout / stderr writers, the output is also captured for the journal
 	echoerWg := sync.WaitGroup{}
 	echoer := func(src io.Reader, dst io.Writer) {
 		scanner := bufio.NewScanner(src)
@@ -318,6 +462,7 @@ func (mgr *RuntimeTaskMakager) Execute(operationID string) (time.Duration, error
 				fmt.Fprintf(dst, "%s ", task.operation.ShootName)
 			}
 			fmt.Fprintln(dst, scanner.Text())
+			fmt.Fprintln(output, scanner.Text())
 		}
 		if err := scanner.Err(); err != nil {
 			log.Errorf("Error: while reading from child process: %s\n", err)
@@ -332,16 +477,46 @@ func (mgr *RuntimeTaskMakager) Execute(operationID string) (time.Duration, error
 	err = command.Start()
 	if err != nil {
 		log.Errorf("Error: command started with error: %s\n", err.Error())
+		echoerWg.Wait()
+		return false, err
 	}
+
 	// Wait for the command subprocess to finish
-	echoerWg.Wait()
-	err = command.Wait()
+	done := make(chan error, 1)
+	go func() {
+		echoerWg.Wait()
+		done <- command.Wait()
+	}()
+
+	var timeout <-chan time.Time
+	if mgr.cmd.timeout > 0 {
+		timer := time.NewTimer(mgr.cmd.timeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	timedOut := false
+	select {
+	case err = <-done:
+	case <-timeout:
+		log.Errorf("Error: command exceeded the %s timeout, killing its process group\n", mgr.cmd.timeout)
+		timedOut = true
+		if err := killProcessGroup(command); err != nil {
+			log.Errorf("Error: while killing process group: %s\n", err)
+		}
+		<-done
+		err = fmt.Errorf("command timed out after %s", mgr.cmd.timeout)
+	case <-mgr.cmd.cobraCmd.Context().Done():
+		if err := killProcessGroup(command); err != nil {
+			log.Errorf("Error: while killing process group: %s\n", err)
+		}
+		err = <-done
+	}
 	if err != nil {
 		log.Errorf("Error: command exited with error: %s\n", err.Error())
 	}
-	task.result = err
 
-	return 0, err
+	return timedOut, err
 }
 
 func (mgr *RuntimeTaskMakager) Reschedule(operationID string, maintenanceWindowBegin, maintenanceWindowEnd time.Time) error {
@@ -0,0 +1,383 @@
+package command
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
+	"github.com/pkg/errors"
+)
+
+// TaskStatus is the state of the task execution on a single runtime
+type TaskStatus string
+
+const (
+	TaskPending   TaskStatus = "pending"
+	TaskRunning   TaskStatus = "running"
+	TaskSucceeded TaskStatus = "succeeded"
+	TaskFailed    TaskStatus = "failed"
+	TaskTimedOut  TaskStatus = "timedOut"
+)
+
+const (
+	jsonReport  = "json"
+	junitReport = "junit"
+)
+
+// maxCapturedOutput limits the command output kept in the journal for a single runtime, only the tail is kept
+const maxCapturedOutput = 64 * 1024
+
+// TaskRunInfo describes the taskrun execution recorded in the journal
+type TaskRunInfo struct {
+	Command   []string  `json:"command"`
+	StartedAt time.Time `json:"startedAt"`
+	Resumed   bool      `json:"resumed,omitempty"`
+}
+
+// TaskRecord is the result of the task execution on a single runtime
+type TaskRecord struct {
+	Runtime    orchestration.Runtime `json:"runtime"`
+	Status     TaskStatus            `json:"status"`
+	ExitCode   int                   `json:"exitCode"`
+	StartedAt  *time.Time            `json:"startedAt,omitempty"`
+	FinishedAt *time.Time            `json:"finishedAt,omitempty"`
+	Duration   time.Duration         `json:"duration"`
+	Output     string                `json:"output,omitempty"`
+	Error      string                `json:"error,omitempty"`
+}
+
+// Finished returns true if the task does not need to be executed again on resume
+func (r TaskRecord) Finished() bool {
+	return r.Status == TaskSucceeded
+}
+
+type journalLine struct {
+	Run  *TaskRunInfo `json:"run,omitempty"`
+	Task *TaskRecord  `json:"task,omitempty"`
+}
+
+// TaskRunJournal records the progress of kcp taskrun on disk. Every state change is appended as a JSON line,
+// so the journal survives an interrupted run and the last record of each runtime reflects its latest state.
+type TaskRunJournal struct {
+	mu    sync.Mutex
+	path  string
+	file  *os.File
+	info  TaskRunInfo
+	order []string
+	tasks map[string]TaskRecord
+}
+
+// DefaultJournalPath returns the journal path used when the --journal option is not specified
+func DefaultJournalPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, configDir, "taskrun", fmt.Sprintf("taskrun-%s.jsonl", time.Now().Format("20060102-150405"))), nil
+}
+
+// NewTaskRunJournal creates a new journal at the given path and records all runtimes as pending
+func NewTaskRunJournal(path string, command []string, runtimes []orchestration.Runtime) (*TaskRunJournal, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, errors.Wrap(err, "while creating journal directory")
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, errors.Wrap(err, "while creating journal")
+	}
+
+	j := &TaskRunJournal{
+		path:  path,
+		file:  file,
+		info:  TaskRunInfo{Command: command, StartedAt: time.Now()},
+		tasks: map[string]TaskRecord{},
+	}
+	if err := j.append(journalLine{Run: &j.info}); err != nil {
+		j.Close()
+		return nil, err
+	}
+	for _, rt := range runtimes {
+		if err := j.Record(TaskRecord{Runtime: rt, Status: TaskPending}); err != nil {
+			j.Close()
+			return nil, err
+		}
+	}
+	return j, nil
+}
+
+// LoadTaskRunJournal reads an existing journal, the state of each runtime is taken from its last record
+func LoadTaskRunJournal(path string) (*TaskRunJournal, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "while opening journal")
+	}
+	defer f.Close()
+
+	j, err := readTaskRunJournal(f)
+	if err != nil {
+		return nil, errors.Wrapf(err, "while reading journal %s", path)
+	}
+	j.path = path
+	return j, nil
+}
+
+// Resume opens the loaded journal for appending the results of the resumed run
+func (j *TaskRunJournal) Resume() error {
+	file, err := os.OpenFile(j.path, os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return errors.Wrap(err, "while opening journal")
+	}
+	j.file = file
+	// terminate a line cut off by the interrupted run, so that the next record starts on its own line
+	if _, err := j.file.Write([]byte("\n")); err != nil {
+		return errors.Wrap(err, "while writing journal")
+	}
+	j.info.StartedAt = time.Now()
+	j.info.Resumed = true
+	return j.append(journalLine{Run: &j.info})
+}
+
+func readTaskRunJournal(r io.Reader) (*TaskRunJournal, error) {
+	j := &TaskRunJournal{tasks: map[string]TaskRecord{}}
+	reader := bufio.NewReader(r)
+	for {
+		data, err := reader.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+		eof := err == io.EOF
+		if data = bytes.TrimSpace(data); len(data) > 0 {
+			var line journalLine
+			// a line cut off by a run interrupted in the middle of a write is skipped, the runtime keeps its previous state
+			if err := json.Unmarshal(data, &line); err == nil {
+				if line.Run != nil {
+					j.info = *line.Run
+				}
+				if line.Task != nil {
+					j.set(*line.Task)
+				}
+			}
+		}
+		if eof {
+			break
+		}
+	}
+	if len(j.info.Command) == 0 {
+		return nil, errors.New("journal does not contain the task command")
+	}
+	return j, nil
+}
+
+// Path returns the location of the journal file
+func (j *TaskRunJournal) Path() string {
+	return j.path
+}
+
+// Command returns the task command recorded in the journal
+func (j *TaskRunJournal) Command() []string {
+	return j.info.Command
+}
+
+// Record appends the new state of the task on a runtime to the journal
+func (j *TaskRunJournal) Record(record TaskRecord) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.set(record)
+	return j.append(journalLine{Task: &record})
+}
+
+// Unfinished returns the runtimes on which the task failed, timed out or has not completed
+func (j *TaskRunJournal) Unfinished() []orchestration.Runtime {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	runtimes := []orchestration.Runtime{}
+	for _, id := range j.order {
+		if r := j.tasks[id]; !r.Finished() {
+			runtimes = append(runtimes, r.Runtime)
+		}
+	}
+	return runtimes
+}
+
+// Records returns the latest state of every runtime in the journal in the order they were added
+func (j *TaskRunJournal) Records() []TaskRecord {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	records := make([]TaskRecord, 0, len(j.order))
+	for _, id := range j.order {
+		records = append(records, j.tasks[id])
+	}
+	return records
+}
+
+// Close closes the journal file
+func (j *TaskRunJournal) Close() error {
+	if j.file == nil {
+		return nil
+	}
+	return j.file.Close()
+}
+
+func (j *TaskRunJournal) set(record TaskRecord) {
+	id := record.Runtime.RuntimeID
+	if _, exists := j.tasks[id]; !exists {
+		j.order = append(j.order, id)
+	}
+	j.tasks[id] = record
+}
+
+func (j *TaskRunJournal) append(line journalLine) error {
+	data, err := json.Marshal(line)
+	if err != nil {
+		return errors.Wrap(err, "while encoding journal entry")
+	}
+	if _, err := j.file.Write(append(data, '\n')); err != nil {
+		return errors.Wrap(err, "while writing journal")
+	}
+	return j.file.Sync()
+}
+
+// TaskRunReport summarizes the task results of all runtimes in the journal
+type TaskRunReport struct {
+	Command   []string     `json:"command"`
+	Total     int          `json:"total"`
+	Succeeded int          `json:"succeeded"`
+	Failed    int          `json:"failed"`
+	TimedOut  int          `json:"timedOut"`
+	Pending   int          `json:"pending"`
+	Tasks     []TaskRecord `json:"tasks"`
+}
+
+// Report builds the report of the task results recorded in the journal
+func (j *TaskRunJournal) Report() TaskRunReport {
+	report := TaskRunReport{Command: j.info.Command, Tasks: j.Records()}
+	for _, r := range report.Tasks {
+		report.Total++
+		switch r.Status {
+		case TaskSucceeded:
+			report.Succeeded++
+		case TaskFailed:
+			report.Failed++
+		case TaskTimedOut:
+			report.TimedOut++
+		default:
+			report.Pending++
+		}
+	}
+	return report
+}
+
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Skipped  int             `xml:"skipped,attr"`
+	Time     string          `xml:"time,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Content string `xml:",chardata"`
+}
+
+// WriteReport writes the report in the given format, json or junit
+func (r TaskRunReport) WriteReport(w io.Writer, format string) error {
+	switch format {
+	case jsonReport:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(r)
+	case junitReport:
+		return r.writeJUnit(w)
+	default:
+		return fmt.Errorf("unsupported report format %s", format)
+	}
+}
+
+func (r TaskRunReport) writeJUnit(w io.Writer) error {
+	suite := junitTestSuite{Name: "kcp taskrun", Tests: r.Total, Failures: r.Failed + r.TimedOut, Skipped: r.Pending}
+	var total time.Duration
+	for _, t := range r.Tasks {
+		total += t.Duration
+		tc := junitTestCase{
+			Name:      t.Runtime.ShootName,
+			Classname: t.Runtime.GlobalAccountID,
+			Time:      seconds(t.Duration),
+		}
+		switch t.Status {
+		case TaskSucceeded:
+			tc.SystemOut = t.Output
+		case TaskFailed, TaskTimedOut:
+			msg := fmt.Sprintf("%s with exit code %d", t.Status, t.ExitCode)
+			if t.Error != "" {
+				msg = fmt.Sprintf("%s: %s", msg, t.Error)
+			}
+			tc.Failure = &junitMessage{Message: msg, Content: t.Output}
+		default:
+			tc.Skipped = &junitMessage{Message: string(t.Status)}
+		}
+		suite.Cases = append(suite.Cases, tc)
+	}
+	suite.Time = seconds(total)
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(junitTestSuites{Suites: []junitTestSuite{suite}}); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func seconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
+
+// tailBuffer keeps the last max bytes written to it, it is safe for concurrent use
+type tailBuffer struct {
+	mu  sync.Mutex
+	max int
+	buf []byte
+}
+
+func (b *tailBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.buf = append(b.buf, p...)
+	if len(b.buf) > b.max {
+		b.buf = b.buf[len(b.buf)-b.max:]
+	}
+	return len(p), nil
+}
+
+func (b *tailBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return string(b.buf)
+}
@@ -0,0 +1,151 @@
+package command
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fixJournalRuntimes() []orchestration.Runtime {
+	return []orchestration.Runtime{
+		{RuntimeID: "runtime-1", ShootName: "c-1", GlobalAccountID: "ga-1"},
+		{RuntimeID: "runtime-2", ShootName: "c-2", GlobalAccountID: "ga-1"},
+		{RuntimeID: "runtime-3", ShootName: "c-3", GlobalAccountID: "ga-2"},
+	}
+}
+
+func TestTaskRunJournal(t *testing.T) {
+	t.Run("should resume only failed and unfinished runtimes", func(t *testing.T) {
+		// given
+		path := filepath.Join(t.TempDir(), "taskrun", "journal.jsonl")
+		runtimes := fixJournalRuntimes()
+		j, err := NewTaskRunJournal(path, []string{"kubectl", "get", "ns"}, runtimes)
+		require.NoError(t, err)
+
+		require.NoError(t, j.Record(TaskRecord{Runtime: runtimes[0], Status: TaskSucceeded, Output: "ok\n"}))
+		require.NoError(t, j.Record(TaskRecord{Runtime: runtimes[1], Status: TaskFailed, ExitCode: 1}))
+		require.NoError(t, j.Record(TaskRecord{Runtime: runtimes[2], Status: TaskRunning, ExitCode: -1}))
+		require.NoError(t, j.Close())
+
+		// when
+		loaded, err := LoadTaskRunJournal(path)
+		require.NoError(t, err)
+
+		// then
+		assert.Equal(t, []string{"kubectl", "get", "ns"}, loaded.Command())
+		unfinished := loaded.Unfinished()
+		require.Len(t, unfinished, 2)
+		assert.Equal(t, "runtime-2", unfinished[0].RuntimeID)
+		assert.Equal(t, "runtime-3", unfinished[1].RuntimeID)
+
+		// when
+		require.NoError(t, loaded.Resume())
+		require.NoError(t, loaded.Record(TaskRecord{Runtime: runtimes[1], Status: TaskSucceeded}))
+		require.NoError(t, loaded.Close())
+		loaded, err = LoadTaskRunJournal(path)
+		require.NoError(t, err)
+
+		// then
+		unfinished = loaded.Unfinished()
+		require.Len(t, unfinished, 1)
+		assert.Equal(t, "runtime-3", unfinished[0].RuntimeID)
+	})
+
+	t.Run("should skip line cut off by interrupted run", func(t *testing.T) {
+		// given
+		path := filepath.Join(t.TempDir(), "journal.jsonl")
+		runtimes := fixJournalRuntimes()
+		j, err := NewTaskRunJournal(path, []string{"true"}, runtimes[:1])
+		require.NoError(t, err)
+		require.NoError(t, j.Close())
+
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0600)
+		require.NoError(t, err)
+		_, err = f.WriteString(`{"task":{"runtime":{"runtimeId":"runtime-1"},"status":"succ`)
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+
+		// when
+		loaded, err := LoadTaskRunJournal(path)
+		require.NoError(t, err)
+		require.NoError(t, loaded.Resume())
+		require.NoError(t, loaded.Record(TaskRecord{Runtime: runtimes[0], Status: TaskFailed}))
+		require.NoError(t, loaded.Close())
+		loaded, err = LoadTaskRunJournal(path)
+
+		// then
+		require.NoError(t, err)
+		records := loaded.Records()
+		require.Len(t, records, 1)
+		assert.Equal(t, TaskFailed, records[0].Status)
+	})
+
+	t.Run("should not overwrite existing journal", func(t *testing.T) {
+		// given
+		path := filepath.Join(t.TempDir(), "journal.jsonl")
+		j, err := NewTaskRunJournal(path, []string{"true"}, nil)
+		require.NoError(t, err)
+		require.NoError(t, j.Close())
+
+		// when
+		_, err = NewTaskRunJournal(path, []string{"true"}, nil)
+
+		// then
+		assert.Error(t, err)
+	})
+}
+
+func TestTaskRunReport(t *testing.T) {
+	// given
+	path := filepath.Join(t.TempDir(), "journal.jsonl")
+	runtimes := fixJournalRuntimes()
+	j, err := NewTaskRunJournal(path, []string{"./check.sh"}, runtimes)
+	require.NoError(t, err)
+	defer j.Close()
+	require.NoError(t, j.Record(TaskRecord{Runtime: runtimes[0], Status: TaskSucceeded, Duration: 1500 * time.Millisecond, Output: "ok\n"}))
+	require.NoError(t, j.Record(TaskRecord{Runtime: runtimes[1], Status: TaskTimedOut, ExitCode: -1, Duration: time.Minute, Error: "command timed out after 1m0s"}))
+
+	// when
+	report := j.Report()
+
+	// then
+	assert.Equal(t, 3, report.Total)
+	assert.Equal(t, 1, report.Succeeded)
+	assert.Equal(t, 1, report.TimedOut)
+	assert.Equal(t, 1, report.Pending)
+
+	t.Run("json", func(t *testing.T) {
+		// when
+		buf := &bytes.Buffer{}
+		require.NoError(t, report.WriteReport(buf, jsonReport))
+
+		// then
+		var decoded TaskRunReport
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+		assert.Equal(t, report, decoded)
+	})
+
+	t.Run("junit", func(t *testing.T) {
+		// when
+		buf := &bytes.Buffer{}
+		require.NoError(t, report.WriteReport(buf, junitReport))
+
+		// then
+		out := buf.String()
+		assert.Contains(t, out, `<testsuite name="kcp taskrun" tests="3" failures="1" skipped="1" time="61.500">`)
+		assert.Contains(t, out, `<testcase name="c-1" classname="ga-1" time="1.500">`)
+		assert.Contains(t, out, `<failure message="timedOut with exit code -1: command timed out after 1m0s"></failure>`)
+		assert.Contains(t, out, `<skipped message="pending"></skipped>`)
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		assert.Error(t, report.WriteReport(&bytes.Buffer{}, "xml"))
+	})
+}
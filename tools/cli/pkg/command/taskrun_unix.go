@@ -0,0 +1,21 @@
+//go:build !windows
+
+package command
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// setProcessGroup starts the task command in its own process group, so that its children can be killed with it
+func setProcessGroup(c *exec.Cmd) {
+	c.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+}
+
+// killProcessGroup kills the whole process group of the started task command
+func killProcessGroup(c *exec.Cmd) error {
+	if c.Process == nil {
+		return nil
+	}
+	return syscall.Kill(-c.Process.Pid, syscall.SIGKILL)
+}
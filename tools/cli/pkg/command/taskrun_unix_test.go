@@ -0,0 +1,73 @@
+//go:build !windows
+
+package command
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
+	"github.com/kyma-project/control-plane/tools/cli/pkg/logger"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestTaskRunCommand(t *testing.T, timeout time.Duration, args ...string) *TaskRunCommand {
+	cobraCmd := &cobra.Command{}
+	cobraCmd.SetContext(context.Background())
+	journal, err := NewTaskRunJournal(filepath.Join(t.TempDir(), "journal.jsonl"), args, fixJournalRuntimes()[:1])
+	require.NoError(t, err)
+	t.Cleanup(func() { journal.Close() })
+
+	return &TaskRunCommand{
+		cobraCmd:       cobraCmd,
+		log:            logger.New(),
+		noKubeconfig:   true,
+		noPrefixOutput: true,
+		taskArgs:       args,
+		timeout:        timeout,
+		journal:        journal,
+	}
+}
+
+func TestRuntimeTaskMakager_Execute(t *testing.T) {
+	t.Run("should record exit code and output", func(t *testing.T) {
+		// given
+		cmd := newTestTaskRunCommand(t, 0, "sh", "-c", `echo "$RUNTIME_ID"; exit 3`)
+		mgr := NewRuntimeTaskMakager(cmd, []orchestration.RuntimeOperation{{Runtime: fixJournalRuntimes()[0], ID: "op-1"}})
+
+		// when
+		_, err := mgr.Execute("op-1")
+
+		// then
+		assert.Error(t, err)
+		records := cmd.journal.Records()
+		require.Len(t, records, 1)
+		assert.Equal(t, TaskFailed, records[0].Status)
+		assert.Equal(t, 3, records[0].ExitCode)
+		assert.Equal(t, "runtime-1\n", records[0].Output)
+		assert.NotNil(t, records[0].FinishedAt)
+		assert.Equal(t, &TaskRunError{failed: 1, total: 1}, mgr.exitStatus())
+	})
+
+	t.Run("should kill process group after timeout", func(t *testing.T) {
+		// given
+		cmd := newTestTaskRunCommand(t, 200*time.Millisecond, "sh", "-c", "sleep 30 & sleep 30")
+		mgr := NewRuntimeTaskMakager(cmd, []orchestration.RuntimeOperation{{Runtime: fixJournalRuntimes()[0], ID: "op-1"}})
+		start := time.Now()
+
+		// when
+		_, err := mgr.Execute("op-1")
+
+		// then
+		assert.Error(t, err)
+		assert.Less(t, time.Since(start), 10*time.Second)
+		records := cmd.journal.Records()
+		require.Len(t, records, 1)
+		assert.Equal(t, TaskTimedOut, records[0].Status)
+		assert.Equal(t, -1, records[0].ExitCode)
+	})
+}
@@ -0,0 +1,18 @@
+//go:build windows
+
+package command
+
+import (
+	"os/exec"
+)
+
+// setProcessGroup is a no-op on Windows, where process groups are not supported by os/exec
+func setProcessGroup(c *exec.Cmd) {}
+
+// killProcessGroup kills the started task command, its children are not tracked on Windows
+func killProcessGroup(c *exec.Cmd) error {
+	if c.Process == nil {
+		return nil
+	}
+	return c.Process.Kill()
+}
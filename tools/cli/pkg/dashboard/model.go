@@ -0,0 +1,429 @@
+package dashboard
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/events"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/runtime"
+)
+
+// Source provides the data displayed by the dashboard
+type Source interface {
+	ListRuntimes(filter Filter) ([]runtime.RuntimeDTO, error)
+	// GetRuntime returns the runtime with all its operations
+	GetRuntime(runtimeID string) (runtime.RuntimeDTO, error)
+	ListEvents(instanceID string) ([]events.EventDTO, error)
+	ListOrchestrations() ([]orchestration.StatusResponse, error)
+	GetOrchestration(orchestrationID string) (orchestration.StatusResponse, error)
+	ListOperations(orchestrationID string) ([]orchestration.OperationResponse, error)
+}
+
+// Actions are the commands triggered by the dashboard keybindings. They are executed with the terminal
+// restored to its normal mode, so they can print output and prompt the user for a confirmation.
+type Actions interface {
+	DownloadKubeconfig(rt runtime.RuntimeDTO) error
+	EnableReconciliation(rt runtime.RuntimeDTO) error
+	DisableReconciliation(rt runtime.RuntimeDTO) error
+	CancelOrchestration(orchestrationID string) error
+	RetryOrchestration(orchestrationID string) error
+}
+
+// Filter narrows down the displayed runtimes
+type Filter struct {
+	States  []runtime.State
+	Plans   []string
+	Regions []string
+}
+
+// ParseFilter parses the filter typed in the dashboard, e.g. "state=failed,error plan=azure region=westeurope"
+func ParseFilter(s string) (Filter, error) {
+	f := Filter{}
+	for _, field := range strings.Fields(s) {
+		kv := strings.SplitN(field, "=", 2)
+		if len(kv) != 2 || kv[1] == "" {
+			return Filter{}, fmt.Errorf("invalid filter %q, expected key=value", field)
+		}
+		values := strings.Split(kv[1], ",")
+		switch kv[0] {
+		case "state":
+			for _, v := range values {
+				f.States = append(f.States, runtime.State(strings.ToLower(v)))
+			}
+		case "plan":
+			f.Plans = append(f.Plans, values...)
+		case "region":
+			f.Regions = append(f.Regions, values...)
+		default:
+			return Filter{}, fmt.Errorf("unknown filter key %q, expected one of: state, plan, region", kv[0])
+		}
+	}
+	return f, nil
+}
+
+func (f Filter) String() string {
+	parts := []string{}
+	if len(f.States) > 0 {
+		states := make([]string, 0, len(f.States))
+		for _, s := range f.States {
+			states = append(states, string(s))
+		}
+		parts = append(parts, "state="+strings.Join(states, ","))
+	}
+	if len(f.Plans) > 0 {
+		parts = append(parts, "plan="+strings.Join(f.Plans, ","))
+	}
+	if len(f.Regions) > 0 {
+		parts = append(parts, "region="+strings.Join(f.Regions, ","))
+	}
+	return strings.Join(parts, " ")
+}
+
+// Key is a single key press, either a printable character or one of the named keys
+type Key string
+
+const (
+	KeyUp        Key = "up"
+	KeyDown      Key = "down"
+	KeyPageUp    Key = "pgup"
+	KeyPageDown  Key = "pgdown"
+	KeyEnter     Key = "enter"
+	KeyEsc       Key = "esc"
+	KeyTab       Key = "tab"
+	KeyBackspace Key = "backspace"
+	KeyCtrlC     Key = "ctrl+c"
+)
+
+type view int
+
+const (
+	runtimesView view = iota
+	runtimeDetailsView
+	orchestrationsView
+	orchestrationDetailsView
+)
+
+// Command is the outcome of a key press which the dashboard loop has to handle
+type Command struct {
+	Quit    bool
+	Refresh bool
+	// Action is run with the terminal restored, a nil Action means no action was triggered
+	Action func(Actions) error
+	// Description of the action shown after it finishes
+	Description string
+}
+
+// Model holds the dashboard state, it is independent of the terminal so that it can be tested
+type Model struct {
+	source Source
+	filter Filter
+	view   view
+	cursor map[view]int
+
+	runtimes       []runtime.RuntimeDTO
+	orchestrations []orchestration.StatusResponse
+
+	runtime    runtime.RuntimeDTO
+	events     []events.EventDTO
+	orch       orchestration.StatusResponse
+	operations []orchestration.OperationResponse
+
+	editing     bool
+	input       string
+	status      string
+	refreshedAt time.Time
+	now         func() time.Time
+}
+
+// NewModel returns the dashboard model displaying the runtimes matching the filter
+func NewModel(source Source, filter Filter) *Model {
+	return &Model{
+		source: source,
+		filter: filter,
+		cursor: map[view]int{},
+		now:    time.Now,
+	}
+}
+
+// Refresh reloads the data of the current view
+func (m *Model) Refresh() {
+	var err error
+	switch m.view {
+	case runtimesView:
+		m.runtimes, err = m.source.ListRuntimes(m.filter)
+	case runtimeDetailsView:
+		err = m.loadRuntime(m.runtime.RuntimeID)
+	case orchestrationsView:
+		m.orchestrations, err = m.source.ListOrchestrations()
+		sort.SliceStable(m.orchestrations, func(i, j int) bool {
+			return m.orchestrations[i].CreatedAt.After(m.orchestrations[j].CreatedAt)
+		})
+	case orchestrationDetailsView:
+		err = m.loadOrchestration(m.orch.OrchestrationID)
+	}
+	if err != nil {
+		m.status = fmt.Sprintf("Error: %s", err)
+		return
+	}
+	m.refreshedAt = m.now()
+	m.clampCursor()
+}
+
+// SetStatus sets the message displayed in the status line
+func (m *Model) SetStatus(status string) {
+	m.status = status
+}
+
+// HandleKey updates the model according to the key and returns the command for the dashboard loop
+func (m *Model) HandleKey(k Key) Command {
+	if k == KeyCtrlC {
+		return Command{Quit: true}
+	}
+	if m.editing {
+		return m.handleFilterKey(k)
+	}
+
+	switch k {
+	case "q":
+		return Command{Quit: true}
+	case "r":
+		return Command{Refresh: true}
+	case KeyUp, "k":
+		m.cursor[m.view]--
+	case KeyDown, "j":
+		m.cursor[m.view]++
+	case KeyPageUp:
+		m.cursor[m.view] -= pageSize
+	case KeyPageDown:
+		m.cursor[m.view] += pageSize
+	case KeyTab:
+		if m.view == runtimesView || m.view == runtimeDetailsView {
+			m.view = orchestrationsView
+		} else {
+			m.view = runtimesView
+		}
+		return Command{Refresh: true}
+	case KeyEsc, KeyBackspace:
+		switch m.view {
+		case runtimeDetailsView:
+			m.view = runtimesView
+		case orchestrationDetailsView:
+			m.view = orchestrationsView
+		}
+		return Command{Refresh: true}
+	case KeyEnter:
+		return m.enter()
+	case "/":
+		if m.view == runtimesView {
+			m.editing = true
+			m.input = m.filter.String()
+		}
+	default:
+		return m.action(k)
+	}
+	m.clampCursor()
+	return Command{}
+}
+
+func (m *Model) handleFilterKey(k Key) Command {
+	switch k {
+	case KeyEsc:
+		m.editing = false
+	case KeyEnter:
+		f, err := ParseFilter(m.input)
+		if err != nil {
+			m.status = fmt.Sprintf("Error: %s", err)
+			return Command{}
+		}
+		m.editing = false
+		m.filter = f
+		m.cursor[runtimesView] = 0
+		return Command{Refresh: true}
+	case KeyBackspace:
+		if len(m.input) > 0 {
+			m.input = m.input[:len(m.input)-1]
+		}
+	default:
+		if len(k) == 1 {
+			m.input += string(k)
+		}
+	}
+	return Command{}
+}
+
+func (m *Model) enter() Command {
+	switch m.view {
+	case runtimesView:
+		if rt, ok := m.selectedRuntime(); ok {
+			m.view = runtimeDetailsView
+			m.runtime = rt
+			m.cursor[runtimeDetailsView] = 0
+			return Command{Refresh: true}
+		}
+	case orchestrationsView:
+		if o, ok := m.selectedOrchestration(); ok {
+			m.view = orchestrationDetailsView
+			m.orch = o
+			m.cursor[orchestrationDetailsView] = 0
+			return Command{Refresh: true}
+		}
+	}
+	return Command{}
+}
+
+func (m *Model) action(k Key) Command {
+	switch m.view {
+	case runtimesView, runtimeDetailsView:
+		rt, ok := m.selectedRuntime()
+		if !ok {
+			return Command{}
+		}
+		switch k {
+		case "K":
+			return Command{
+				Action:      func(a Actions) error { return a.DownloadKubeconfig(rt) },
+				Description: fmt.Sprintf("Kubeconfig download for %s", rt.ShootName),
+			}
+		case "E":
+			return Command{
+				Action:      func(a Actions) error { return a.EnableReconciliation(rt) },
+				Description: fmt.Sprintf("Enabling reconciliation for %s", rt.ShootName),
+				Refresh:     true,
+			}
+		case "D":
+			return Command{
+				Action:      func(a Actions) error { return a.DisableReconciliation(rt) },
+				Description: fmt.Sprintf("Disabling reconciliation for %s", rt.ShootName),
+				Refresh:     true,
+			}
+		}
+	case orchestrationsView, orchestrationDetailsView:
+		o, ok := m.selectedOrchestration()
+		if !ok {
+			return Command{}
+		}
+		switch k {
+		case "C":
+			return Command{
+				Action:      func(a Actions) error { return a.CancelOrchestration(o.OrchestrationID) },
+				Description: fmt.Sprintf("Canceling orchestration %s", o.OrchestrationID),
+				Refresh:     true,
+			}
+		case "R":
+			return Command{
+				Action:      func(a Actions) error { return a.RetryOrchestration(o.OrchestrationID) },
+				Description: fmt.Sprintf("Retrying orchestration %s", o.OrchestrationID),
+				Refresh:     true,
+			}
+		}
+	}
+	return Command{}
+}
+
+func (m *Model) selectedRuntime() (runtime.RuntimeDTO, bool) {
+	if m.view == runtimeDetailsView {
+		return m.runtime, true
+	}
+	i := m.cursor[runtimesView]
+	if i < 0 || i >= len(m.runtimes) {
+		return runtime.RuntimeDTO{}, false
+	}
+	return m.runtimes[i], true
+}
+
+func (m *Model) selectedOrchestration() (orchestration.StatusResponse, bool) {
+	if m.view == orchestrationDetailsView {
+		return m.orch, true
+	}
+	i := m.cursor[orchestrationsView]
+	if i < 0 || i >= len(m.orchestrations) {
+		return orchestration.StatusResponse{}, false
+	}
+	return m.orchestrations[i], true
+}
+
+func (m *Model) loadRuntime(runtimeID string) error {
+	rt, err := m.source.GetRuntime(runtimeID)
+	if err != nil {
+		return err
+	}
+	evs, err := m.source.ListEvents(rt.InstanceID)
+	if err != nil {
+		return err
+	}
+	m.runtime = rt
+	m.events = evs
+	return nil
+}
+
+func (m *Model) loadOrchestration(orchestrationID string) error {
+	o, err := m.source.GetOrchestration(orchestrationID)
+	if err != nil {
+		return err
+	}
+	ops, err := m.source.ListOperations(orchestrationID)
+	if err != nil {
+		return err
+	}
+	m.orch = o
+	m.operations = ops
+	return nil
+}
+
+func (m *Model) rows() int {
+	switch m.view {
+	case runtimesView:
+		return len(m.runtimes)
+	case runtimeDetailsView:
+		return len(m.runtimeDetails())
+	case orchestrationsView:
+		return len(m.orchestrations)
+	case orchestrationDetailsView:
+		return len(m.orchestrationDetails())
+	}
+	return 0
+}
+
+func (m *Model) clampCursor() {
+	c := m.cursor[m.view]
+	if c >= m.rows() {
+		c = m.rows() - 1
+	}
+	if c < 0 {
+		c = 0
+	}
+	m.cursor[m.view] = c
+}
+
+// runtimeOperations returns all operations of the runtime, the most recent first
+func runtimeOperations(rt runtime.RuntimeDTO) []runtime.Operation {
+	ops := []runtime.Operation{}
+	single := func(op *runtime.Operation, t runtime.OperationType) {
+		if op != nil {
+			o := *op
+			o.Type = t
+			ops = append(ops, o)
+		}
+	}
+	multi := func(data *runtime.OperationsData, t runtime.OperationType) {
+		if data == nil {
+			return
+		}
+		for _, o := range data.Data {
+			o.Type = t
+			ops = append(ops, o)
+		}
+	}
+	single(rt.Status.Provisioning, runtime.Provision)
+	single(rt.Status.Deprovisioning, runtime.Deprovision)
+	multi(rt.Status.UpgradingKyma, runtime.UpgradeKyma)
+	multi(rt.Status.UpgradingCluster, runtime.UpgradeCluster)
+	multi(rt.Status.Update, runtime.Update)
+	multi(rt.Status.Suspension, runtime.Suspension)
+	multi(rt.Status.Unsuspension, runtime.Unsuspension)
+	sort.SliceStable(ops, func(i, j int) bool { return ops[i].CreatedAt.After(ops[j].CreatedAt) })
+	return ops
+}
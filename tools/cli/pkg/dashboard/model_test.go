@@ -0,0 +1,212 @@
+package dashboard
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/events"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/runtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSource struct {
+	runtimes       []runtime.RuntimeDTO
+	orchestrations []orchestration.StatusResponse
+	lastFilter     Filter
+}
+
+func (s *fakeSource) ListRuntimes(filter Filter) ([]runtime.RuntimeDTO, error) {
+	s.lastFilter = filter
+	return s.runtimes, nil
+}
+
+func (s *fakeSource) GetRuntime(runtimeID string) (runtime.RuntimeDTO, error) {
+	for _, rt := range s.runtimes {
+		if rt.RuntimeID == runtimeID {
+			return rt, nil
+		}
+	}
+	return runtime.RuntimeDTO{}, nil
+}
+
+func (s *fakeSource) ListEvents(instanceID string) ([]events.EventDTO, error) {
+	return []events.EventDTO{{Level: events.ErrorEventLevel, InstanceID: &instanceID, Message: "step failed"}}, nil
+}
+
+func (s *fakeSource) ListOrchestrations() ([]orchestration.StatusResponse, error) {
+	return s.orchestrations, nil
+}
+
+func (s *fakeSource) GetOrchestration(orchestrationID string) (orchestration.StatusResponse, error) {
+	return s.orchestrations[0], nil
+}
+
+func (s *fakeSource) ListOperations(orchestrationID string) ([]orchestration.OperationResponse, error) {
+	return []orchestration.OperationResponse{{ShootName: "c-1", State: orchestration.Failed}}, nil
+}
+
+type fakeActions struct {
+	calls []string
+}
+
+func (a *fakeActions) DownloadKubeconfig(rt runtime.RuntimeDTO) error {
+	a.calls = append(a.calls, "kubeconfig "+rt.ShootName)
+	return nil
+}
+
+func (a *fakeActions) EnableReconciliation(rt runtime.RuntimeDTO) error {
+	a.calls = append(a.calls, "enable "+rt.RuntimeID)
+	return nil
+}
+
+func (a *fakeActions) DisableReconciliation(rt runtime.RuntimeDTO) error {
+	a.calls = append(a.calls, "disable "+rt.RuntimeID)
+	return nil
+}
+
+func (a *fakeActions) CancelOrchestration(orchestrationID string) error {
+	a.calls = append(a.calls, "cancel "+orchestrationID)
+	return nil
+}
+
+func (a *fakeActions) RetryOrchestration(orchestrationID string) error {
+	a.calls = append(a.calls, "retry "+orchestrationID)
+	return nil
+}
+
+func fixSource() *fakeSource {
+	created := time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC)
+	return &fakeSource{
+		runtimes: []runtime.RuntimeDTO{
+			{
+				RuntimeID:       "runtime-1",
+				InstanceID:      "instance-1",
+				ShootName:       "c-1",
+				ServicePlanName: "azure",
+				Status: runtime.RuntimeStatus{
+					State:        runtime.StateSucceeded,
+					Provisioning: &runtime.Operation{OperationID: "op-1", State: "succeeded", CreatedAt: created},
+				},
+			},
+			{
+				RuntimeID:       "runtime-2",
+				InstanceID:      "instance-2",
+				ShootName:       "c-2",
+				ServicePlanName: "aws",
+				Status: runtime.RuntimeStatus{
+					State:        runtime.StateFailed,
+					Provisioning: &runtime.Operation{OperationID: "op-2", State: "failed", CreatedAt: created},
+				},
+			},
+		},
+		orchestrations: []orchestration.StatusResponse{
+			{
+				OrchestrationID: "orch-1",
+				State:           orchestration.InProgress,
+				OperationStats:  map[string]int{orchestration.Succeeded: 3, orchestration.Failed: 1, orchestration.Pending: 4},
+			},
+		},
+	}
+}
+
+func TestModel(t *testing.T) {
+	t.Run("should drill down into runtime operations and events", func(t *testing.T) {
+		// given
+		m := NewModel(fixSource(), Filter{})
+		m.Refresh()
+
+		// when
+		m.HandleKey(KeyDown)
+		cmd := m.HandleKey(KeyEnter)
+		require.True(t, cmd.Refresh)
+		m.Refresh()
+
+		// then
+		screen := m.Render(120, 30)
+		assert.Contains(t, screen, "Runtime c-2")
+		assert.Contains(t, screen, "op-2")
+		assert.Contains(t, screen, "step failed")
+
+		// when
+		m.HandleKey(KeyEsc)
+		m.Refresh()
+
+		// then
+		assert.Contains(t, m.Render(120, 30), "Runtimes (2)")
+	})
+
+	t.Run("should apply filter typed in the dashboard", func(t *testing.T) {
+		// given
+		source := fixSource()
+		m := NewModel(source, Filter{})
+
+		// when
+		m.HandleKey("/")
+		for _, k := range parseKeys([]byte("state=failed plan=aws")) {
+			m.HandleKey(k)
+		}
+		cmd := m.HandleKey(KeyEnter)
+		m.Refresh()
+
+		// then
+		assert.True(t, cmd.Refresh)
+		assert.Equal(t, Filter{States: []runtime.State{runtime.StateFailed}, Plans: []string{"aws"}}, source.lastFilter)
+	})
+
+	t.Run("should trigger actions for selected runtime and orchestration", func(t *testing.T) {
+		// given
+		m := NewModel(fixSource(), Filter{})
+		m.Refresh()
+		actions := &fakeActions{}
+
+		// when
+		for _, k := range []Key{"K", KeyDown, "E", "D", KeyTab} {
+			cmd := m.HandleKey(k)
+			if cmd.Action != nil {
+				require.NoError(t, cmd.Action(actions))
+			}
+			if cmd.Refresh {
+				m.Refresh()
+			}
+		}
+		for _, k := range []Key{"C", "R"} {
+			require.NoError(t, m.HandleKey(k).Action(actions))
+		}
+
+		// then
+		assert.Equal(t, []string{"kubeconfig c-1", "enable runtime-2", "disable runtime-2", "cancel orch-1", "retry orch-1"}, actions.calls)
+	})
+
+	t.Run("should quit", func(t *testing.T) {
+		m := NewModel(fixSource(), Filter{})
+		assert.True(t, m.HandleKey("q").Quit)
+		assert.True(t, m.HandleKey(KeyCtrlC).Quit)
+	})
+}
+
+func TestParseFilter(t *testing.T) {
+	// when
+	f, err := ParseFilter("state=failed,Error plan=azure region=westeurope,northeurope")
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, []runtime.State{runtime.StateFailed, runtime.StateError}, f.States)
+	assert.Equal(t, "state=failed,error plan=azure region=westeurope,northeurope", f.String())
+
+	_, err = ParseFilter("shoot=c-1")
+	assert.Error(t, err)
+	_, err = ParseFilter("state")
+	assert.Error(t, err)
+}
+
+func TestProgressBar(t *testing.T) {
+	assert.Equal(t, "[#####-----]  50% 4/8", ProgressBar(map[string]int{orchestration.Succeeded: 3, orchestration.Failed: 1, orchestration.Pending: 4}, 10))
+	assert.Equal(t, "[----------]   0% 0/0", ProgressBar(nil, 10))
+}
+
+func TestParseKeys(t *testing.T) {
+	assert.Equal(t, []Key{KeyUp, KeyDown, KeyPageDown, KeyEnter, KeyEsc}, parseKeys([]byte("\x1b[A\x1b[B\x1b[6~\r\x1b")))
+	assert.Equal(t, []Key{"q", KeyTab, KeyBackspace, KeyCtrlC}, parseKeys([]byte{'q', '\t', 0x7f, 0x03}))
+}
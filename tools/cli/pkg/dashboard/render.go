@@ -0,0 +1,261 @@
+package dashboard
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/orchestration"
+	"github.com/kyma-project/control-plane/components/kyma-environment-broker/common/runtime"
+)
+
+const (
+	pageSize      = 10
+	progressWidth = 20
+	timeFormat    = "2006/01/02 15:04:05"
+
+	reverseVideo = "\x1b[7m"
+	resetStyle   = "\x1b[0m"
+)
+
+var helpLines = map[view]string{
+	runtimesView:             "↑/↓ move  enter details  / filter  tab orchestrations  r refresh  K kubeconfig  E/D enable/disable reconciliation  q quit",
+	runtimeDetailsView:       "↑/↓ scroll  esc back  tab orchestrations  r refresh  K kubeconfig  E/D enable/disable reconciliation  q quit",
+	orchestrationsView:       "↑/↓ move  enter details  tab runtimes  r refresh  C cancel  R retry  q quit",
+	orchestrationDetailsView: "↑/↓ scroll  esc back  tab runtimes  r refresh  C cancel  R retry  q quit",
+}
+
+// Render returns the dashboard screen of the given size
+func (m *Model) Render(width, height int) string {
+	body, selected := m.body()
+
+	// title, status and help lines take 3 rows
+	visible := height - 3
+	if visible < 1 {
+		visible = 1
+	}
+	// the first body line of the list views is the table header, which is always displayed
+	header := []string{}
+	if m.isList() && len(body) > 0 {
+		header, body = body[:1], body[1:]
+		visible--
+	}
+	offset := 0
+	if selected >= 0 {
+		if selected >= visible {
+			offset = selected - visible + 1
+		}
+	} else {
+		offset = m.cursor[m.view]
+		if max := len(body) - visible; offset > max {
+			offset = max
+		}
+		if offset < 0 {
+			offset = 0
+		}
+	}
+
+	sb := strings.Builder{}
+	sb.WriteString(reverseVideo + pad(m.title(), width) + resetStyle + "\r\n")
+	for _, line := range header {
+		sb.WriteString(truncate(line, width) + "\r\n")
+	}
+	for i := 0; i < visible; i++ {
+		line := ""
+		if offset+i < len(body) {
+			line = body[offset+i]
+		}
+		if offset+i == selected {
+			sb.WriteString(reverseVideo + pad(line, width) + resetStyle + "\r\n")
+		} else {
+			sb.WriteString(truncate(line, width) + "\r\n")
+		}
+	}
+	if m.editing {
+		sb.WriteString(truncate(fmt.Sprintf("Filter: %s█", m.input), width) + "\r\n")
+	} else {
+		sb.WriteString(truncate(m.status, width) + "\r\n")
+	}
+	sb.WriteString(truncate(helpLines[m.view], width))
+	return sb.String()
+}
+
+func (m *Model) isList() bool {
+	return m.view == runtimesView || m.view == orchestrationsView
+}
+
+func (m *Model) title() string {
+	refreshed := "never"
+	if !m.refreshedAt.IsZero() {
+		refreshed = m.refreshedAt.Format("15:04:05")
+	}
+	switch m.view {
+	case runtimesView:
+		filter := m.filter.String()
+		if filter == "" {
+			filter = "none"
+		}
+		return fmt.Sprintf(" KCP dashboard | Runtimes (%d) | filter: %s | refreshed: %s", len(m.runtimes), filter, refreshed)
+	case runtimeDetailsView:
+		return fmt.Sprintf(" KCP dashboard | Runtime %s | refreshed: %s", m.runtime.ShootName, refreshed)
+	case orchestrationsView:
+		return fmt.Sprintf(" KCP dashboard | Orchestrations (%d) | refreshed: %s", len(m.orchestrations), refreshed)
+	default:
+		return fmt.Sprintf(" KCP dashboard | Orchestration %s | refreshed: %s", m.orch.OrchestrationID, refreshed)
+	}
+}
+
+// body returns the lines of the current view and the index of the selected line, or -1 for scrollable views
+func (m *Model) body() ([]string, int) {
+	switch m.view {
+	case runtimesView:
+		rows := make([][]string, 0, len(m.runtimes))
+		for _, rt := range m.runtimes {
+			op := rt.LastOperation()
+			rows = append(rows, []string{rt.ShootName, rt.GlobalAccountID, rt.SubAccountID, rt.ProviderRegion, rt.ServicePlanName, runtimeState(rt), string(op.Type), op.UpdatedAt.Format(timeFormat)})
+		}
+		return table([]string{"SHOOT", "GLOBALACCOUNT ID", "SUBACCOUNT ID", "REGION", "PLAN", "STATE", "LAST OPERATION", "UPDATED AT"}, rows), m.cursor[runtimesView] + 1
+	case runtimeDetailsView:
+		return m.runtimeDetails(), -1
+	case orchestrationsView:
+		rows := make([][]string, 0, len(m.orchestrations))
+		for _, o := range m.orchestrations {
+			rows = append(rows, []string{o.OrchestrationID, string(o.Type), o.State, ProgressBar(o.OperationStats, progressWidth), fmt.Sprint(o.OperationStats[orchestration.Failed]), o.CreatedAt.Format(timeFormat)})
+		}
+		return table([]string{"ORCHESTRATION ID", "TYPE", "STATE", "PROGRESS", "FAILED", "CREATED AT"}, rows), m.cursor[orchestrationsView] + 1
+	default:
+		return m.orchestrationDetails(), -1
+	}
+}
+
+func (m *Model) runtimeDetails() []string {
+	rt := m.runtime
+	lines := []string{
+		fmt.Sprintf("Shoot:            %s", rt.ShootName),
+		fmt.Sprintf("Runtime ID:       %s", rt.RuntimeID),
+		fmt.Sprintf("Instance ID:      %s", rt.InstanceID),
+		fmt.Sprintf("Global account:   %s", rt.GlobalAccountID),
+		fmt.Sprintf("Subaccount:       %s", rt.SubAccountID),
+		fmt.Sprintf("Plan / region:    %s / %s", rt.ServicePlanName, rt.ProviderRegion),
+		fmt.Sprintf("State:            %s", runtimeState(rt)),
+		"",
+		"OPERATIONS",
+	}
+	ops := runtimeOperations(rt)
+	rows := make([][]string, 0, len(ops))
+	for _, op := range ops {
+		rows = append(rows, []string{op.OperationID, string(op.Type), op.State, op.CreatedAt.Format(timeFormat), op.Description})
+	}
+	lines = append(lines, table([]string{"OPERATION ID", "TYPE", "STATE", "CREATED AT", "DESCRIPTION"}, rows)...)
+
+	lines = append(lines, "", "EVENTS")
+	rows = make([][]string, 0, len(m.events))
+	for _, ev := range m.events {
+		opID := ""
+		if ev.OperationID != nil {
+			opID = *ev.OperationID
+		}
+		rows = append(rows, []string{ev.CreatedAt.Format(timeFormat), string(ev.Level), opID, ev.Message})
+	}
+	return append(lines, table([]string{"TIME", "LEVEL", "OPERATION ID", "MESSAGE"}, rows)...)
+}
+
+func (m *Model) orchestrationDetails() []string {
+	o := m.orch
+	lines := []string{
+		fmt.Sprintf("Orchestration ID: %s", o.OrchestrationID),
+		fmt.Sprintf("Type:             %s", o.Type),
+		fmt.Sprintf("State:            %s", o.State),
+		fmt.Sprintf("Description:      %s", o.Description),
+		fmt.Sprintf("Progress:         %s", ProgressBar(o.OperationStats, 2*progressWidth)),
+		fmt.Sprintf("Operations:       %s", operationStats(o.OperationStats)),
+		"",
+		"OPERATIONS",
+	}
+	rows := make([][]string, 0, len(m.operations))
+	for _, op := range m.operations {
+		rows = append(rows, []string{op.ShootName, op.OperationID, op.State, op.Description})
+	}
+	return append(lines, table([]string{"SHOOT", "OPERATION ID", "STATE", "DESCRIPTION"}, rows)...)
+}
+
+// ProgressBar renders the share of finished orchestration operations, e.g. [#####---------------] 25% 5/20
+func ProgressBar(stats map[string]int, width int) string {
+	total, done := 0, 0
+	for state, n := range stats {
+		total += n
+		switch state {
+		case orchestration.Succeeded, orchestration.Failed, orchestration.Canceled:
+			done += n
+		}
+	}
+	filled, percent := 0, 0
+	if total > 0 {
+		filled = done * width / total
+		percent = done * 100 / total
+	}
+	return fmt.Sprintf("[%s%s] %3d%% %d/%d", strings.Repeat("#", filled), strings.Repeat("-", width-filled), percent, done, total)
+}
+
+func operationStats(stats map[string]int) string {
+	parts := []string{}
+	for _, state := range []string{orchestration.Pending, orchestration.InProgress, orchestration.Retrying, orchestration.Succeeded, orchestration.Failed, orchestration.Canceling, orchestration.Canceled} {
+		if n := stats[state]; n > 0 {
+			parts = append(parts, fmt.Sprintf("%s: %d", state, n))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+func runtimeState(rt runtime.RuntimeDTO) string {
+	state := rt.Status.State
+	switch state {
+	case runtime.StateError, runtime.StateFailed:
+		return fmt.Sprintf("%s (%s)", state, rt.LastOperation().Type)
+	}
+	return string(state)
+}
+
+// table formats the rows into aligned columns, the first line is the header
+func table(headers []string, rows [][]string) []string {
+	widths := make([]int, len(headers))
+	for i, h := range headers {
+		widths[i] = len([]rune(h))
+	}
+	for _, row := range rows {
+		for i, cell := range row {
+			if l := len([]rune(cell)); l > widths[i] {
+				widths[i] = l
+			}
+		}
+	}
+
+	format := func(cells []string) string {
+		sb := strings.Builder{}
+		for i, cell := range cells {
+			if i == len(cells)-1 {
+				sb.WriteString(cell)
+				break
+			}
+			sb.WriteString(pad(cell, widths[i]+2))
+		}
+		return sb.String()
+	}
+	lines := []string{format(headers)}
+	for _, row := range rows {
+		lines = append(lines, format(row))
+	}
+	return lines
+}
+
+func truncate(s string, width int) string {
+	r := []rune(s)
+	if len(r) > width {
+		return string(r[:width])
+	}
+	return s
+}
+
+func pad(s string, width int) string {
+	s = truncate(s, width)
+	return s + strings.Repeat(" ", width-len([]rune(s)))
+}
@@ -0,0 +1,188 @@
+package dashboard
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	"golang.org/x/term"
+)
+
+const (
+	enterAltScreen = "\x1b[?1049h\x1b[?25l"
+	leaveAltScreen = "\x1b[?25h\x1b[?1049l"
+	clearScreen    = "\x1b[H\x1b[2J"
+
+	defaultWidth  = 120
+	defaultHeight = 40
+)
+
+type terminal struct {
+	in    *os.File
+	out   *os.File
+	state *term.State
+}
+
+// Run displays the dashboard in the terminal until the user quits, the current view is refreshed every interval
+func Run(ctx context.Context, m *Model, actions Actions, interval time.Duration) error {
+	t := &terminal{in: os.Stdin, out: os.Stdout}
+	if !term.IsTerminal(int(t.in.Fd())) || !term.IsTerminal(int(t.out.Fd())) {
+		return errors.New("the dashboard requires an interactive terminal")
+	}
+	if err := t.enter(); err != nil {
+		return err
+	}
+	defer t.leave()
+
+	// keys are read only on request, so that actions can read the standard input while the dashboard waits for them
+	want := make(chan struct{}, 1)
+	keys := make(chan []Key)
+	go readKeys(t.in, want, keys)
+	want <- struct{}{}
+
+	m.Refresh()
+	t.draw(m)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			m.Refresh()
+			t.draw(m)
+		case ks, ok := <-keys:
+			if !ok {
+				return nil
+			}
+			for _, k := range ks {
+				cmd := m.HandleKey(k)
+				if cmd.Quit {
+					return nil
+				}
+				if cmd.Action != nil {
+					if err := t.runAction(m, cmd, actions); err != nil {
+						return err
+					}
+				}
+				if cmd.Refresh {
+					m.Refresh()
+				}
+			}
+			t.draw(m)
+			want <- struct{}{}
+		}
+	}
+}
+
+func (t *terminal) enter() error {
+	state, err := term.MakeRaw(int(t.in.Fd()))
+	if err != nil {
+		return errors.Wrap(err, "while switching terminal to raw mode")
+	}
+	t.state = state
+	_, err = io.WriteString(t.out, enterAltScreen)
+	return err
+}
+
+func (t *terminal) leave() {
+	io.WriteString(t.out, leaveAltScreen)
+	if t.state != nil {
+		term.Restore(int(t.in.Fd()), t.state)
+		t.state = nil
+	}
+}
+
+func (t *terminal) draw(m *Model) {
+	width, height, err := term.GetSize(int(t.out.Fd()))
+	if err != nil {
+		width, height = defaultWidth, defaultHeight
+	}
+	io.WriteString(t.out, clearScreen+m.Render(width, height))
+}
+
+// runAction executes the action with the terminal restored, so that the action output and prompts are visible
+func (t *terminal) runAction(m *Model, cmd Command, actions Actions) error {
+	t.leave()
+	fmt.Fprintf(t.out, "%s\n", cmd.Description)
+	if err := cmd.Action(actions); err != nil {
+		fmt.Fprintf(t.out, "Error: %s\n", err)
+		m.SetStatus(fmt.Sprintf("%s failed: %s", cmd.Description, err))
+	} else {
+		m.SetStatus(fmt.Sprintf("%s finished", cmd.Description))
+	}
+	fmt.Fprint(t.out, "\nPress Enter to return to the dashboard")
+	readLine(t.in)
+	return t.enter()
+}
+
+func readKeys(in io.Reader, want <-chan struct{}, keys chan<- []Key) {
+	buf := make([]byte, 64)
+	for range want {
+		n, err := in.Read(buf)
+		if err != nil {
+			close(keys)
+			return
+		}
+		keys <- parseKeys(buf[:n])
+	}
+}
+
+func readLine(in io.Reader) {
+	b := make([]byte, 1)
+	for {
+		if n, err := in.Read(b); err != nil || n == 1 && b[0] == '\n' {
+			return
+		}
+	}
+}
+
+// parseKeys translates the bytes read from the terminal in raw mode to key presses
+func parseKeys(b []byte) []Key {
+	keys := []Key{}
+	for i := 0; i < len(b); {
+		switch b[i] {
+		case 0x1b:
+			if i+2 < len(b) && b[i+1] == '[' {
+				switch {
+				case b[i+2] == 'A':
+					keys = append(keys, KeyUp)
+				case b[i+2] == 'B':
+					keys = append(keys, KeyDown)
+				case i+3 < len(b) && b[i+3] == '~' && b[i+2] == '5':
+					keys = append(keys, KeyPageUp)
+					i++
+				case i+3 < len(b) && b[i+3] == '~' && b[i+2] == '6':
+					keys = append(keys, KeyPageDown)
+					i++
+				}
+				i += 3
+				continue
+			}
+			keys = append(keys, KeyEsc)
+		case 0x03:
+			keys = append(keys, KeyCtrlC)
+		case '\t':
+			keys = append(keys, KeyTab)
+		case '\r', '\n':
+			keys = append(keys, KeyEnter)
+		case 0x7f, 0x08:
+			keys = append(keys, KeyBackspace)
+		default:
+			r, size := utf8.DecodeRune(b[i:])
+			if unicode.IsPrint(r) {
+				keys = append(keys, Key(string(r)))
+			}
+			i += size
+			continue
+		}
+		i++
+	}
+	return keys
+}
@@ -1,6 +1,7 @@
 package metadata
 
 import (
+	"os"
 	"testing"
 
 	"github.com/kyma-project/control-plane/tools/cli/pkg/ers"
@@ -16,9 +17,14 @@ func TestSaveGet(t *testing.T) {
 		KymaSkipped:  true,
 	}
 	svc := Storage{}
+	// the metadata is stored relative to the working directory
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(t.TempDir()))
+	defer os.Chdir(wd)
 
 	// when
-	err := svc.Save(m)
+	err = svc.Save(m)
 	require.NoError(t, err)
 
 	// then